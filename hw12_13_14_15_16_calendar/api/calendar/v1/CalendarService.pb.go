@@ -77,13 +77,25 @@ func (x *Event) GetData() *EventData {
 }
 
 type EventData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Datetime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=datetime,proto3" json:"datetime,omitempty"`
-	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	RemindIn      *durationpb.Duration   `protobuf:"bytes,6,opt,name=remind_in,json=remindIn,proto3" json:"remind_in,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Datetime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=datetime,proto3" json:"datetime,omitempty"`
+	Duration    *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	UserId      string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RemindIn    *durationpb.Duration   `protobuf:"bytes,6,opt,name=remind_in,json=remindIn,proto3" json:"remind_in,omitempty"`
+	// One of: busy, free, tentative, out-of-office. Empty value is treated as busy.
+	BusyStatus string `protobuf:"bytes,7,opt,name=busy_status,json=busyStatus,proto3" json:"busy_status,omitempty"`
+	// All-day events have date-only semantics: the time part of datetime is ignored
+	// and the duration is rounded up to whole days. Empty duration means a single day.
+	AllDay bool     `protobuf:"varint,8,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	Tags   []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Color in #RRGGBB format.
+	Color    string `protobuf:"bytes,10,opt,name=color,proto3" json:"color,omitempty"`
+	Location string `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
+	Url      string `protobuf:"bytes,12,opt,name=url,proto3" json:"url,omitempty"`
+	// IDs of the booked resources. Bookings of the same resource must not overlap.
+	Resources     []string `protobuf:"bytes,13,rep,name=resources,proto3" json:"resources,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -160,11 +172,65 @@ func (x *EventData) GetRemindIn() *durationpb.Duration {
 	return nil
 }
 
+func (x *EventData) GetBusyStatus() string {
+	if x != nil {
+		return x.BusyStatus
+	}
+	return ""
+}
+
+func (x *EventData) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *EventData) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *EventData) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *EventData) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *EventData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *EventData) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
 type CreateEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          *EventData             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  *EventData             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// One of: reject, allow, allow-if-free. Overrides the default calendar policy.
+	OverlapPolicy *string `protobuf:"bytes,2,opt,name=overlap_policy,json=overlapPolicy,proto3,oneof" json:"overlap_policy,omitempty"`
+	// Repeated requests with the same key return the originally created event.
+	// Might be passed as Idempotency-Key header or idempotency-key metadata instead.
+	IdempotencyKey *string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateEventRequest) Reset() {
@@ -204,6 +270,20 @@ func (x *CreateEventRequest) GetData() *EventData {
 	return nil
 }
 
+func (x *CreateEventRequest) GetOverlapPolicy() string {
+	if x != nil && x.OverlapPolicy != nil {
+		return *x.OverlapPolicy
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
@@ -249,9 +329,11 @@ func (x *CreateEventResponse) GetEvent() *Event {
 }
 
 type UpdateEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Data          *EventData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data  *EventData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// One of: reject, allow, allow-if-free. Overrides the default calendar policy.
+	OverlapPolicy *string `protobuf:"bytes,3,opt,name=overlap_policy,json=overlapPolicy,proto3,oneof" json:"overlap_policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -300,6 +382,13 @@ func (x *UpdateEventRequest) GetData() *EventData {
 	return nil
 }
 
+func (x *UpdateEventRequest) GetOverlapPolicy() string {
+	if x != nil && x.OverlapPolicy != nil {
+		return *x.OverlapPolicy
+	}
+	return ""
+}
+
 type UpdateEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
@@ -601,9 +690,13 @@ func (x *GetAllUserEventsResponse) GetEvents() []*Event {
 }
 
 type GetEventsForDayRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
-	UserId        *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Date   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	UserId *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Events must have all of the given tags.
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Case-insensitive exact match of the event location.
+	Location      *string `protobuf:"bytes,4,opt,name=location,proto3,oneof" json:"location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -652,6 +745,20 @@ func (x *GetEventsForDayRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetEventsForDayRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetEventsForDayRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
 type GetEventsForDayResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
@@ -697,9 +804,13 @@ func (x *GetEventsForDayResponse) GetEvents() []*Event {
 }
 
 type GetEventsForWeekRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
-	UserId        *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Date   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	UserId *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Events must have all of the given tags.
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Case-insensitive exact match of the event location.
+	Location      *string `protobuf:"bytes,4,opt,name=location,proto3,oneof" json:"location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -748,6 +859,20 @@ func (x *GetEventsForWeekRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetEventsForWeekRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetEventsForWeekRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
 type GetEventsForWeekResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
@@ -793,9 +918,13 @@ func (x *GetEventsForWeekResponse) GetEvents() []*Event {
 }
 
 type GetEventsForMonthRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
-	UserId        *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Date   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	UserId *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Events must have all of the given tags.
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Case-insensitive exact match of the event location.
+	Location      *string `protobuf:"bytes,4,opt,name=location,proto3,oneof" json:"location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -844,6 +973,20 @@ func (x *GetEventsForMonthRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetEventsForMonthRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetEventsForMonthRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
 type GetEventsForMonthResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
@@ -889,10 +1032,14 @@ func (x *GetEventsForMonthResponse) GetEvents() []*Event {
 }
 
 type GetEventsForPeriodRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	UserId        *string                `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	UserId    *string                `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Events must have all of the given tags.
+	Tags []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Case-insensitive exact match of the event location.
+	Location      *string `protobuf:"bytes,5,opt,name=location,proto3,oneof" json:"location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -948,6 +1095,20 @@ func (x *GetEventsForPeriodRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetEventsForPeriodRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetEventsForPeriodRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
 type GetEventsForPeriodResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
@@ -992,71 +1153,2506 @@ func (x *GetEventsForPeriodResponse) GetEvents() []*Event {
 	return nil
 }
 
-var File_api_calendar_v1_CalendarService_proto protoreflect.FileDescriptor
+type CleanupEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events starting before the given time are deleted. Empty value means a year ago, as for the scheduler.
+	Before        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_calendar_v1_CalendarService_proto_rawDesc = "" +
-	"\n" +
-	"%api/calendar/v1/CalendarService.proto\x12\vcalendar.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/api/annotations.proto\"C\n" +
-	"\x05Event\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
-	"\x04data\x18\x02 \x01(\v2\x16.calendar.v1.EventDataR\x04data\"\x83\x02\n" +
-	"\tEventData\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x126\n" +
-	"\bdatetime\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdatetime\x125\n" +
-	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x17\n" +
-	"\auser_id\x18\x05 \x01(\tR\x06userId\x126\n" +
-	"\tremind_in\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bremindIn\"@\n" +
-	"\x12CreateEventRequest\x12*\n" +
-	"\x04data\x18\x01 \x01(\v2\x16.calendar.v1.EventDataR\x04data\"?\n" +
-	"\x13CreateEventResponse\x12(\n" +
-	"\x05event\x18\x01 \x01(\v2\x12.calendar.v1.EventR\x05event\"P\n" +
-	"\x12UpdateEventRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
-	"\x04data\x18\x02 \x01(\v2\x16.calendar.v1.EventDataR\x04data\"?\n" +
-	"\x13UpdateEventResponse\x12(\n" +
-	"\x05event\x18\x01 \x01(\v2\x12.calendar.v1.EventR\x05event\"$\n" +
-	"\x12DeleteEventRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
-	"\x13DeleteEventResponse\"!\n" +
-	"\x0fGetEventRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
-	"\x10GetEventResponse\x12(\n" +
-	"\x05event\x18\x01 \x01(\v2\x12.calendar.v1.EventR\x05event\"2\n" +
-	"\x17GetAllUserEventsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"F\n" +
-	"\x18GetAllUserEventsResponse\x12*\n" +
-	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"r\n" +
-	"\x16GetEventsForDayRequest\x12.\n" +
-	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
-	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01B\n" +
-	"\n" +
-	"\b_user_id\"E\n" +
-	"\x17GetEventsForDayResponse\x12*\n" +
-	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"s\n" +
-	"\x17GetEventsForWeekRequest\x12.\n" +
-	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
-	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01B\n" +
-	"\n" +
-	"\b_user_id\"F\n" +
-	"\x18GetEventsForWeekResponse\x12*\n" +
-	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"t\n" +
-	"\x18GetEventsForMonthRequest\x12.\n" +
-	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
-	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01B\n" +
-	"\n" +
-	"\b_user_id\"G\n" +
-	"\x19GetEventsForMonthResponse\x12*\n" +
-	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"\xb7\x01\n" +
-	"\x19GetEventsForPeriodRequest\x129\n" +
-	"\n" +
-	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1c\n" +
-	"\auser_id\x18\x03 \x01(\tH\x00R\x06userId\x88\x01\x01B\n" +
-	"\n" +
-	"\b_user_id\"H\n" +
-	"\x1aGetEventsForPeriodResponse\x12*\n" +
-	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events2\xf0\b\n" +
+func (x *CleanupEventsRequest) Reset() {
+	*x = CleanupEventsRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupEventsRequest) ProtoMessage() {}
+
+func (x *CleanupEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupEventsRequest.ProtoReflect.Descriptor instead.
+func (*CleanupEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CleanupEventsRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type CleanupEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupEventsResponse) Reset() {
+	*x = CleanupEventsResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupEventsResponse) ProtoMessage() {}
+
+func (x *CleanupEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupEventsResponse.ProtoReflect.Descriptor instead.
+func (*CleanupEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CleanupEventsResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type GetSchedulerStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchedulerStatusRequest) Reset() {
+	*x = GetSchedulerStatusRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchedulerStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchedulerStatusRequest) ProtoMessage() {}
+
+func (x *GetSchedulerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchedulerStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSchedulerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{22}
+}
+
+type GetSchedulerStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of the events, which reminders are due, but not sent yet.
+	DueNotifications int64 `protobuf:"varint,1,opt,name=due_notifications,json=dueNotifications,proto3" json:"due_notifications,omitempty"`
+	// Earliest reminder time among the events waiting for notification. Empty if there are no such events.
+	NextReminder  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=next_reminder,json=nextReminder,proto3" json:"next_reminder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchedulerStatusResponse) Reset() {
+	*x = GetSchedulerStatusResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchedulerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchedulerStatusResponse) ProtoMessage() {}
+
+func (x *GetSchedulerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchedulerStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSchedulerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetSchedulerStatusResponse) GetDueNotifications() int64 {
+	if x != nil {
+		return x.DueNotifications
+	}
+	return 0
+}
+
+func (x *GetSchedulerStatusResponse) GetNextReminder() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextReminder
+	}
+	return nil
+}
+
+type GetMissedRemindersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Outage window. Reminders due within [outage_start, outage_end) are reported.
+	OutageStart *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=outage_start,json=outageStart,proto3" json:"outage_start,omitempty"`
+	OutageEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=outage_end,json=outageEnd,proto3" json:"outage_end,omitempty"`
+	// Catch-up policy of the scheduler: always, skip or grace. Empty value means always.
+	CatchUpPolicy string `protobuf:"bytes,3,opt,name=catch_up_policy,json=catchUpPolicy,proto3" json:"catch_up_policy,omitempty"`
+	// Grace window of the grace policy.
+	CatchUpGrace  *durationpb.Duration `protobuf:"bytes,4,opt,name=catch_up_grace,json=catchUpGrace,proto3" json:"catch_up_grace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMissedRemindersRequest) Reset() {
+	*x = GetMissedRemindersRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMissedRemindersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMissedRemindersRequest) ProtoMessage() {}
+
+func (x *GetMissedRemindersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMissedRemindersRequest.ProtoReflect.Descriptor instead.
+func (*GetMissedRemindersRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetMissedRemindersRequest) GetOutageStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OutageStart
+	}
+	return nil
+}
+
+func (x *GetMissedRemindersRequest) GetOutageEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OutageEnd
+	}
+	return nil
+}
+
+func (x *GetMissedRemindersRequest) GetCatchUpPolicy() string {
+	if x != nil {
+		return x.CatchUpPolicy
+	}
+	return ""
+}
+
+func (x *GetMissedRemindersRequest) GetCatchUpGrace() *durationpb.Duration {
+	if x != nil {
+		return x.CatchUpGrace
+	}
+	return nil
+}
+
+// Reminder, which was due during the outage, as if it was handled at the end of the outage.
+type MissedReminder struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EventId  string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	UserId   string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Start    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	RemindAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	// Time between the reminder and the end of the outage.
+	Delay *durationpb.Duration `protobuf:"bytes,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	// The reminder is already handled by the scheduler.
+	Notified bool `protobuf:"varint,7,opt,name=notified,proto3" json:"notified,omitempty"`
+	// One of: delayed (sent before the event start), late (sent with the late marker), skipped.
+	Outcome       string `protobuf:"bytes,8,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissedReminder) Reset() {
+	*x = MissedReminder{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissedReminder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissedReminder) ProtoMessage() {}
+
+func (x *MissedReminder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissedReminder.ProtoReflect.Descriptor instead.
+func (*MissedReminder) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MissedReminder) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *MissedReminder) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MissedReminder) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MissedReminder) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *MissedReminder) GetRemindAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindAt
+	}
+	return nil
+}
+
+func (x *MissedReminder) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+func (x *MissedReminder) GetNotified() bool {
+	if x != nil {
+		return x.Notified
+	}
+	return false
+}
+
+func (x *MissedReminder) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+type GetMissedRemindersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminders     []*MissedReminder      `protobuf:"bytes,1,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMissedRemindersResponse) Reset() {
+	*x = GetMissedRemindersResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMissedRemindersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMissedRemindersResponse) ProtoMessage() {}
+
+func (x *GetMissedRemindersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMissedRemindersResponse.ProtoReflect.Descriptor instead.
+func (*GetMissedRemindersResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetMissedRemindersResponse) GetReminders() []*MissedReminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+type GetCacheStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsRequest) Reset() {
+	*x = GetCacheStatsRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsRequest) ProtoMessage() {}
+
+func (x *GetCacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{27}
+}
+
+type GetCacheStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The storage cache is enabled. Other fields are empty otherwise.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Number of the requests served from the cache.
+	Hits int64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Number of the requests passed to the storage.
+	Misses int64 `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	// Number of the events cached by ID.
+	Events int64 `protobuf:"varint,4,opt,name=events,proto3" json:"events,omitempty"`
+	// Number of the cached results of the period queries.
+	Periods int64 `protobuf:"varint,5,opt,name=periods,proto3" json:"periods,omitempty"`
+	// Maximum number of the cached events and of the cached period query results.
+	Size int64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	// Time to keep the cached results.
+	Ttl           *durationpb.Duration `protobuf:"bytes,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsResponse) Reset() {
+	*x = GetCacheStatsResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsResponse) ProtoMessage() {}
+
+func (x *GetCacheStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetCacheStatsResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetCacheStatsResponse) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *GetCacheStatsResponse) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *GetCacheStatsResponse) GetEvents() int64 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
+func (x *GetCacheStatsResponse) GetPeriods() int64 {
+	if x != nil {
+		return x.Periods
+	}
+	return 0
+}
+
+func (x *GetCacheStatsResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetCacheStatsResponse) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type PreviewNotificationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the event the reminder is rendered for.
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Channel the template is defined for, e.g. "log" or "email".
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Locale overriding the settings of the event owner, e.g. "ru" or "en-US". Empty value means no override.
+	Locale string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	// IANA time zone overriding the settings of the event owner, e.g. "Europe/Moscow". Empty value means no override.
+	TimeZone      string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewNotificationRequest) Reset() {
+	*x = PreviewNotificationRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewNotificationRequest) ProtoMessage() {}
+
+func (x *PreviewNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewNotificationRequest.ProtoReflect.Descriptor instead.
+func (*PreviewNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PreviewNotificationRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PreviewNotificationRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PreviewNotificationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PreviewNotificationRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type PreviewNotificationResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Channel string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Locale of the template used.
+	Locale string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Time zone the event time is formatted in.
+	TimeZone string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Template format: "text" or "html".
+	Format string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	// Rendered message.
+	Body          string `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewNotificationResponse) Reset() {
+	*x = PreviewNotificationResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewNotificationResponse) ProtoMessage() {}
+
+func (x *PreviewNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewNotificationResponse.ProtoReflect.Descriptor instead.
+func (*PreviewNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PreviewNotificationResponse) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PreviewNotificationResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PreviewNotificationResponse) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *PreviewNotificationResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *PreviewNotificationResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// Daily agenda digest settings of the user.
+type DigestSettings struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Local time the digest is sent at, in HH:MM format.
+	Time string `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// IANA time zone of the digest time, e.g. "Europe/Moscow". Empty value means UTC.
+	TimeZone string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Local date of the last sent digest in YYYY-MM-DD format. Ignored on update.
+	SentDate      string `protobuf:"bytes,4,opt,name=sent_date,json=sentDate,proto3" json:"sent_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DigestSettings) Reset() {
+	*x = DigestSettings{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DigestSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DigestSettings) ProtoMessage() {}
+
+func (x *DigestSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DigestSettings.ProtoReflect.Descriptor instead.
+func (*DigestSettings) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DigestSettings) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *DigestSettings) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *DigestSettings) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *DigestSettings) GetSentDate() string {
+	if x != nil {
+		return x.SentDate
+	}
+	return ""
+}
+
+type GetDigestSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigestSettingsRequest) Reset() {
+	*x = GetDigestSettingsRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigestSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigestSettingsRequest) ProtoMessage() {}
+
+func (x *GetDigestSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigestSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetDigestSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetDigestSettingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetDigestSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *DigestSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigestSettingsResponse) Reset() {
+	*x = GetDigestSettingsResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigestSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigestSettingsResponse) ProtoMessage() {}
+
+func (x *GetDigestSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigestSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetDigestSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDigestSettingsResponse) GetSettings() *DigestSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetDigestSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Settings      *DigestSettings        `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDigestSettingsRequest) Reset() {
+	*x = SetDigestSettingsRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDigestSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDigestSettingsRequest) ProtoMessage() {}
+
+func (x *SetDigestSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDigestSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetDigestSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetDigestSettingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetDigestSettingsRequest) GetSettings() *DigestSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetDigestSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *DigestSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDigestSettingsResponse) Reset() {
+	*x = SetDigestSettingsResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDigestSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDigestSettingsResponse) ProtoMessage() {}
+
+func (x *SetDigestSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDigestSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetDigestSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetDigestSettingsResponse) GetSettings() *DigestSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Webhook subscription of a third-party integration.
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Owner of the events delivered. Empty value means the events of all users.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// URL the signed POST requests are sent to.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// Secret the deliveries are signed with. Returned on creation only.
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Delivered changes: created, updated, deleted, reminder.
+	EventTypes []string `protobuf:"bytes,5,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Disabled subscriptions receive no deliveries.
+	Enabled bool `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Number of the consecutive failed deliveries.
+	Failures      int64                  `protobuf:"varint,7,opt,name=failures,proto3" json:"failures,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Webhook) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Single delivery attempt of the webhook subscription.
+type WebhookDelivery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the delivery, shared by all its attempts.
+	DeliveryId string `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	// Number of the attempt, starting with 1.
+	Attempt   int64  `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	WebhookId string `protobuf:"bytes,3,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Type of the delivered change.
+	EventType string `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventId   string `protobuf:"bytes,5,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// HTTP status code of the response, 0 if no response was received.
+	StatusCode int64 `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// Reason of the failure. Empty on success.
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Success       bool                   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WebhookDelivery) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempt() int64 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatusCode() int64 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Owner of the events to deliver. Empty value means the events of all users.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// HTTP or HTTPS URL to deliver the changes to.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Secret to sign the deliveries with. Empty value means a generated one.
+	Secret string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Changes to deliver: created, updated, deleted, reminder. Empty value means all of them.
+	EventTypes    []string `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limits the result to the subscriptions of the user. Empty value means all subscriptions.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListWebhooksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{43}
+}
+
+type EnableWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableWebhookRequest) Reset() {
+	*x = EnableWebhookRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableWebhookRequest) ProtoMessage() {}
+
+func (x *EnableWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableWebhookRequest.ProtoReflect.Descriptor instead.
+func (*EnableWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *EnableWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EnableWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableWebhookResponse) Reset() {
+	*x = EnableWebhookResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableWebhookResponse) ProtoMessage() {}
+
+func (x *EnableWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableWebhookResponse.ProtoReflect.Descriptor instead.
+func (*EnableWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *EnableWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Maximum number of the latest attempts returned. Zero value means no limit.
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Delivery attempts, newest first.
+	Deliveries    []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+// Bookable resource: a meeting room or a piece of shared equipment.
+type Resource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// One of: room, equipment.
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Number of seats. Zero value means it is not applicable.
+	Capacity int64 `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Features of the resource, e.g. projector or whiteboard.
+	Attributes    []string               `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resource) Reset() {
+	*x = Resource{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resource) ProtoMessage() {}
+
+func (x *Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resource.ProtoReflect.Descriptor instead.
+func (*Resource) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Resource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Resource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Resource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Resource) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Resource) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Resource) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Time interval of the resource, booked by the event.
+type ResourceBooking struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceBooking) Reset() {
+	*x = ResourceBooking{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceBooking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceBooking) ProtoMessage() {}
+
+func (x *ResourceBooking) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceBooking.ProtoReflect.Descriptor instead.
+func (*ResourceBooking) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ResourceBooking) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ResourceBooking) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ResourceBooking) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// Bookings of the resource within the requested period.
+type ResourceAvailability struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Resource *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// The resource is available if it has no bookings within the period.
+	Available bool `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	// Bookings sorted by the start time.
+	Bookings      []*ResourceBooking `protobuf:"bytes,3,rep,name=bookings,proto3" json:"bookings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceAvailability) Reset() {
+	*x = ResourceAvailability{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceAvailability) ProtoMessage() {}
+
+func (x *ResourceAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceAvailability.ProtoReflect.Descriptor instead.
+func (*ResourceAvailability) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ResourceAvailability) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *ResourceAvailability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *ResourceAvailability) GetBookings() []*ResourceBooking {
+	if x != nil {
+		return x.Bookings
+	}
+	return nil
+}
+
+type CreateResourceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// One of: room, equipment. Empty value is treated as room.
+	Kind          string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Capacity      int64    `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Attributes    []string `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResourceRequest) Reset() {
+	*x = CreateResourceRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResourceRequest) ProtoMessage() {}
+
+func (x *CreateResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResourceRequest.ProtoReflect.Descriptor instead.
+func (*CreateResourceRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateResourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateResourceRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CreateResourceRequest) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateResourceRequest) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type CreateResourceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResourceResponse) Reset() {
+	*x = CreateResourceResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResourceResponse) ProtoMessage() {}
+
+func (x *CreateResourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResourceResponse.ProtoReflect.Descriptor instead.
+func (*CreateResourceResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateResourceResponse) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+type ListResourcesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResourcesRequest) Reset() {
+	*x = ListResourcesRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResourcesRequest) ProtoMessage() {}
+
+func (x *ListResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{53}
+}
+
+type ListResourcesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resources sorted by the name.
+	Resources     []*Resource `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResourcesResponse) Reset() {
+	*x = ListResourcesResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResourcesResponse) ProtoMessage() {}
+
+func (x *ListResourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListResourcesResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListResourcesResponse) GetResources() []*Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type DeleteResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResourceRequest) Reset() {
+	*x = DeleteResourceRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResourceRequest) ProtoMessage() {}
+
+func (x *DeleteResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResourceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteResourceRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteResourceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteResourceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResourceResponse) Reset() {
+	*x = DeleteResourceResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResourceResponse) ProtoMessage() {}
+
+func (x *DeleteResourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResourceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResourceResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{56}
+}
+
+type GetResourceAvailabilityRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DateStart *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date_start,json=dateStart,proto3" json:"date_start,omitempty"`
+	DateEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_end,json=dateEnd,proto3" json:"date_end,omitempty"`
+	// Limits the result to the given resources. Empty value means all resources.
+	ResourceIds []string `protobuf:"bytes,3,rep,name=resource_ids,json=resourceIds,proto3" json:"resource_ids,omitempty"`
+	// Limits the result to the resources of the kind: room, equipment. Empty value means any kind.
+	Kind string `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Limits the result to the resources with at least the given capacity.
+	MinCapacity int64 `protobuf:"varint,5,opt,name=min_capacity,json=minCapacity,proto3" json:"min_capacity,omitempty"`
+	// Limits the result to the resources with all of the given attributes.
+	Attributes    []string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResourceAvailabilityRequest) Reset() {
+	*x = GetResourceAvailabilityRequest{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResourceAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResourceAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetResourceAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResourceAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetResourceAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetResourceAvailabilityRequest) GetDateStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateStart
+	}
+	return nil
+}
+
+func (x *GetResourceAvailabilityRequest) GetDateEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateEnd
+	}
+	return nil
+}
+
+func (x *GetResourceAvailabilityRequest) GetResourceIds() []string {
+	if x != nil {
+		return x.ResourceIds
+	}
+	return nil
+}
+
+func (x *GetResourceAvailabilityRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetResourceAvailabilityRequest) GetMinCapacity() int64 {
+	if x != nil {
+		return x.MinCapacity
+	}
+	return 0
+}
+
+func (x *GetResourceAvailabilityRequest) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type GetResourceAvailabilityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Availability of the matching resources, sorted by the name.
+	Availability  []*ResourceAvailability `protobuf:"bytes,1,rep,name=availability,proto3" json:"availability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResourceAvailabilityResponse) Reset() {
+	*x = GetResourceAvailabilityResponse{}
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResourceAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResourceAvailabilityResponse) ProtoMessage() {}
+
+func (x *GetResourceAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_calendar_v1_CalendarService_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResourceAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetResourceAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_api_calendar_v1_CalendarService_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetResourceAvailabilityResponse) GetAvailability() []*ResourceAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+var File_api_calendar_v1_CalendarService_proto protoreflect.FileDescriptor
+
+const file_api_calendar_v1_CalendarService_proto_rawDesc = "" +
+	"\n" +
+	"%api/calendar/v1/CalendarService.proto\x12\vcalendar.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/api/annotations.proto\"C\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.calendar.v1.EventDataR\x04data\"\xb3\x03\n" +
+	"\tEventData\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x126\n" +
+	"\bdatetime\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdatetime\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x126\n" +
+	"\tremind_in\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bremindIn\x12\x1f\n" +
+	"\vbusy_status\x18\a \x01(\tR\n" +
+	"busyStatus\x12\x17\n" +
+	"\aall_day\x18\b \x01(\bR\x06allDay\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x14\n" +
+	"\x05color\x18\n" +
+	" \x01(\tR\x05color\x12\x1a\n" +
+	"\blocation\x18\v \x01(\tR\blocation\x12\x10\n" +
+	"\x03url\x18\f \x01(\tR\x03url\x12\x1c\n" +
+	"\tresources\x18\r \x03(\tR\tresources\"\xc1\x01\n" +
+	"\x12CreateEventRequest\x12*\n" +
+	"\x04data\x18\x01 \x01(\v2\x16.calendar.v1.EventDataR\x04data\x12*\n" +
+	"\x0eoverlap_policy\x18\x02 \x01(\tH\x00R\roverlapPolicy\x88\x01\x01\x12,\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01B\x11\n" +
+	"\x0f_overlap_policyB\x12\n" +
+	"\x10_idempotency_key\"?\n" +
+	"\x13CreateEventResponse\x12(\n" +
+	"\x05event\x18\x01 \x01(\v2\x12.calendar.v1.EventR\x05event\"\x8f\x01\n" +
+	"\x12UpdateEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.calendar.v1.EventDataR\x04data\x12*\n" +
+	"\x0eoverlap_policy\x18\x03 \x01(\tH\x00R\roverlapPolicy\x88\x01\x01B\x11\n" +
+	"\x0f_overlap_policy\"?\n" +
+	"\x13UpdateEventResponse\x12(\n" +
+	"\x05event\x18\x01 \x01(\v2\x12.calendar.v1.EventR\x05event\"$\n" +
+	"\x12DeleteEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
+	"\x13DeleteEventResponse\"!\n" +
+	"\x0fGetEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x10GetEventResponse\x12(\n" +
+	"\x05event\x18\x01 \x01(\v2\x12.calendar.v1.EventR\x05event\"2\n" +
+	"\x17GetAllUserEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"F\n" +
+	"\x18GetAllUserEventsResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"\xb4\x01\n" +
+	"\x16GetEventsForDayRequest\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1f\n" +
+	"\blocation\x18\x04 \x01(\tH\x01R\blocation\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\v\n" +
+	"\t_location\"E\n" +
+	"\x17GetEventsForDayResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"\xb5\x01\n" +
+	"\x17GetEventsForWeekRequest\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1f\n" +
+	"\blocation\x18\x04 \x01(\tH\x01R\blocation\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\v\n" +
+	"\t_location\"F\n" +
+	"\x18GetEventsForWeekResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"\xb6\x01\n" +
+	"\x18GetEventsForMonthRequest\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1f\n" +
+	"\blocation\x18\x04 \x01(\tH\x01R\blocation\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\v\n" +
+	"\t_location\"G\n" +
+	"\x19GetEventsForMonthResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"\xf9\x01\n" +
+	"\x19GetEventsForPeriodRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1c\n" +
+	"\auser_id\x18\x03 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1f\n" +
+	"\blocation\x18\x05 \x01(\tH\x01R\blocation\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\v\n" +
+	"\t_location\"H\n" +
+	"\x1aGetEventsForPeriodResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\"J\n" +
+	"\x14CleanupEventsRequest\x122\n" +
+	"\x06before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\"1\n" +
+	"\x15CleanupEventsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"\x1b\n" +
+	"\x19GetSchedulerStatusRequest\"\x8a\x01\n" +
+	"\x1aGetSchedulerStatusResponse\x12+\n" +
+	"\x11due_notifications\x18\x01 \x01(\x03R\x10dueNotifications\x12?\n" +
+	"\rnext_reminder\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fnextReminder\"\xfe\x01\n" +
+	"\x19GetMissedRemindersRequest\x12=\n" +
+	"\foutage_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\voutageStart\x129\n" +
+	"\n" +
+	"outage_end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\toutageEnd\x12&\n" +
+	"\x0fcatch_up_policy\x18\x03 \x01(\tR\rcatchUpPolicy\x12?\n" +
+	"\x0ecatch_up_grace\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fcatchUpGrace\"\xac\x02\n" +
+	"\x0eMissedReminder\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x120\n" +
+	"\x05start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x127\n" +
+	"\tremind_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bremindAt\x12/\n" +
+	"\x05delay\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x1a\n" +
+	"\bnotified\x18\a \x01(\bR\bnotified\x12\x18\n" +
+	"\aoutcome\x18\b \x01(\tR\aoutcome\"W\n" +
+	"\x1aGetMissedRemindersResponse\x129\n" +
+	"\treminders\x18\x01 \x03(\v2\x1b.calendar.v1.MissedReminderR\treminders\"\x16\n" +
+	"\x14GetCacheStatsRequest\"\xd0\x01\n" +
+	"\x15GetCacheStatsResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x03R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x03R\x06misses\x12\x16\n" +
+	"\x06events\x18\x04 \x01(\x03R\x06events\x12\x18\n" +
+	"\aperiods\x18\x05 \x01(\x03R\aperiods\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\x12+\n" +
+	"\x03ttl\x18\a \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"\x86\x01\n" +
+	"\x1aPreviewNotificationRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\"\x98\x01\n" +
+	"\x1bPreviewNotificationResponse\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\"x\n" +
+	"\x0eDigestSettings\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x1b\n" +
+	"\tsent_date\x18\x04 \x01(\tR\bsentDate\"3\n" +
+	"\x18GetDigestSettingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"T\n" +
+	"\x19GetDigestSettingsResponse\x127\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1b.calendar.v1.DigestSettingsR\bsettings\"l\n" +
+	"\x18SetDigestSettingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
+	"\bsettings\x18\x02 \x01(\v2\x1b.calendar.v1.DigestSettingsR\bsettings\"T\n" +
+	"\x19SetDigestSettingsResponse\x127\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1b.calendar.v1.DigestSettingsR\bsettings\"\xee\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x05 \x03(\tR\n" +
+	"eventTypes\x12\x18\n" +
+	"\aenabled\x18\x06 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bfailures\x18\a \x01(\x03R\bfailures\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb1\x02\n" +
+	"\x0fWebhookDelivery\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12\x18\n" +
+	"\aattempt\x18\x02 \x01(\x03R\aattempt\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x03 \x01(\tR\twebhookId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x19\n" +
+	"\bevent_id\x18\x05 \x01(\tR\aeventId\x12\x1f\n" +
+	"\vstatus_code\x18\x06 \x01(\x03R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\b \x01(\bR\asuccess\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"z\n" +
+	"\x14CreateWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\"G\n" +
+	"\x15CreateWebhookResponse\x12.\n" +
+	"\awebhook\x18\x01 \x01(\v2\x14.calendar.v1.WebhookR\awebhook\".\n" +
+	"\x13ListWebhooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"H\n" +
+	"\x14ListWebhooksResponse\x120\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x14.calendar.v1.WebhookR\bwebhooks\"&\n" +
+	"\x14DeleteWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteWebhookResponse\"&\n" +
+	"\x14EnableWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x15EnableWebhookResponse\x12.\n" +
+	"\awebhook\x18\x01 \x01(\v2\x14.calendar.v1.WebhookR\awebhook\"D\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"]\n" +
+	"\x1dListWebhookDeliveriesResponse\x12<\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1c.calendar.v1.WebhookDeliveryR\n" +
+	"deliveries\"\xb9\x01\n" +
+	"\bResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x03R\bcapacity\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\tR\n" +
+	"attributes\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8c\x01\n" +
+	"\x0fResourceBooking\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\xa1\x01\n" +
+	"\x14ResourceAvailability\x121\n" +
+	"\bresource\x18\x01 \x01(\v2\x15.calendar.v1.ResourceR\bresource\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\bR\tavailable\x128\n" +
+	"\bbookings\x18\x03 \x03(\v2\x1c.calendar.v1.ResourceBookingR\bbookings\"{\n" +
+	"\x15CreateResourceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x03R\bcapacity\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\tR\n" +
+	"attributes\"K\n" +
+	"\x16CreateResourceResponse\x121\n" +
+	"\bresource\x18\x01 \x01(\v2\x15.calendar.v1.ResourceR\bresource\"\x16\n" +
+	"\x14ListResourcesRequest\"L\n" +
+	"\x15ListResourcesResponse\x123\n" +
+	"\tresources\x18\x01 \x03(\v2\x15.calendar.v1.ResourceR\tresources\"'\n" +
+	"\x15DeleteResourceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteResourceResponse\"\x8c\x02\n" +
+	"\x1eGetResourceAvailabilityRequest\x129\n" +
+	"\n" +
+	"date_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tdateStart\x125\n" +
+	"\bdate_end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\adateEnd\x12!\n" +
+	"\fresource_ids\x18\x03 \x03(\tR\vresourceIds\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12!\n" +
+	"\fmin_capacity\x18\x05 \x01(\x03R\vminCapacity\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x06 \x03(\tR\n" +
+	"attributes\"h\n" +
+	"\x1fGetResourceAvailabilityResponse\x12E\n" +
+	"\favailability\x18\x01 \x03(\v2!.calendar.v1.ResourceAvailabilityR\favailability2\xdf\x19\n" +
 	"\x0fCalendarService\x12q\n" +
 	"\vCreateEvent\x12\x1f.calendar.v1.CreateEventRequest\x1a .calendar.v1.CreateEventResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x04datab\x05event\"\n" +
 	"/v1/events\x12v\n" +
@@ -1067,7 +3663,24 @@ const file_api_calendar_v1_CalendarService_proto_rawDesc = "" +
 	"\x0fGetEventsForDay\x12#.calendar.v1.GetEventsForDayRequest\x1a$.calendar.v1.GetEventsForDayResponse\"\x1e\x82\xd3\xe4\x93\x02\x18b\x06events\x12\x0e/v1/events/day\x12\x80\x01\n" +
 	"\x10GetEventsForWeek\x12$.calendar.v1.GetEventsForWeekRequest\x1a%.calendar.v1.GetEventsForWeekResponse\"\x1f\x82\xd3\xe4\x93\x02\x19b\x06events\x12\x0f/v1/events/week\x12\x84\x01\n" +
 	"\x11GetEventsForMonth\x12%.calendar.v1.GetEventsForMonthRequest\x1a&.calendar.v1.GetEventsForMonthResponse\" \x82\xd3\xe4\x93\x02\x1ab\x06events\x12\x10/v1/events/month\x12\x88\x01\n" +
-	"\x12GetEventsForPeriod\x12&.calendar.v1.GetEventsForPeriodRequest\x1a'.calendar.v1.GetEventsForPeriodResponse\"!\x82\xd3\xe4\x93\x02\x1bb\x06events\x12\x11/v1/events/periodBHZFgithub.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1b\x06proto3"
+	"\x12GetEventsForPeriod\x12&.calendar.v1.GetEventsForPeriodRequest\x1a'.calendar.v1.GetEventsForPeriodResponse\"!\x82\xd3\xe4\x93\x02\x1bb\x06events\x12\x11/v1/events/period\x12t\n" +
+	"\rCleanupEvents\x12!.calendar.v1.CleanupEventsRequest\x1a\".calendar.v1.CleanupEventsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/admin/cleanup\x12\x82\x01\n" +
+	"\x12GetSchedulerStatus\x12&.calendar.v1.GetSchedulerStatusRequest\x1a'.calendar.v1.GetSchedulerStatusResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/admin/scheduler\x12\x94\x01\n" +
+	"\x12GetMissedReminders\x12&.calendar.v1.GetMissedRemindersRequest\x1a'.calendar.v1.GetMissedRemindersResponse\"-\x82\xd3\xe4\x93\x02'b\treminders\x12\x1a/v1/admin/reminders/missed\x12o\n" +
+	"\rGetCacheStats\x12!.calendar.v1.GetCacheStatsRequest\x1a\".calendar.v1.GetCacheStatsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/admin/cache\x12\x94\x01\n" +
+	"\x13PreviewNotification\x12'.calendar.v1.PreviewNotificationRequest\x1a(.calendar.v1.PreviewNotificationResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/admin/notifications/preview\x12\x90\x01\n" +
+	"\x11GetDigestSettings\x12%.calendar.v1.GetDigestSettingsRequest\x1a&.calendar.v1.GetDigestSettingsResponse\",\x82\xd3\xe4\x93\x02&b\bsettings\x12\x1a/v1/users/{user_id}/digest\x12\x9a\x01\n" +
+	"\x11SetDigestSettings\x12%.calendar.v1.SetDigestSettingsRequest\x1a&.calendar.v1.SetDigestSettingsResponse\"6\x82\xd3\xe4\x93\x020:\bsettingsb\bsettings\x1a\x1a/v1/users/{user_id}/digest\x12x\n" +
+	"\rCreateWebhook\x12!.calendar.v1.CreateWebhookRequest\x1a\".calendar.v1.CreateWebhookResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*b\awebhook\"\f/v1/webhooks\x12s\n" +
+	"\fListWebhooks\x12 .calendar.v1.ListWebhooksRequest\x1a!.calendar.v1.ListWebhooksResponse\"\x1e\x82\xd3\xe4\x93\x02\x18b\bwebhooks\x12\f/v1/webhooks\x12q\n" +
+	"\rDeleteWebhook\x12!.calendar.v1.DeleteWebhookRequest\x1a\".calendar.v1.DeleteWebhookResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/webhooks/{id}\x12\x84\x01\n" +
+	"\rEnableWebhook\x12!.calendar.v1.EnableWebhookRequest\x1a\".calendar.v1.EnableWebhookResponse\",\x82\xd3\xe4\x93\x02&:\x01*b\awebhook\"\x18/v1/webhooks/{id}/enable\x12\xa0\x01\n" +
+	"\x15ListWebhookDeliveries\x12).calendar.v1.ListWebhookDeliveriesRequest\x1a*.calendar.v1.ListWebhookDeliveriesResponse\"0\x82\xd3\xe4\x93\x02*b\n" +
+	"deliveries\x12\x1c/v1/webhooks/{id}/deliveries\x12}\n" +
+	"\x0eCreateResource\x12\".calendar.v1.CreateResourceRequest\x1a#.calendar.v1.CreateResourceResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*b\bresource\"\r/v1/resources\x12x\n" +
+	"\rListResources\x12!.calendar.v1.ListResourcesRequest\x1a\".calendar.v1.ListResourcesResponse\" \x82\xd3\xe4\x93\x02\x1ab\tresources\x12\r/v1/resources\x12u\n" +
+	"\x0eDeleteResource\x12\".calendar.v1.DeleteResourceRequest\x1a#.calendar.v1.DeleteResourceResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/resources/{id}\x12\xa6\x01\n" +
+	"\x17GetResourceAvailability\x12+.calendar.v1.GetResourceAvailabilityRequest\x1a,.calendar.v1.GetResourceAvailabilityResponse\"0\x82\xd3\xe4\x93\x02*b\favailability\x12\x1a/v1/resources/availabilityBHZFgithub.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1b\x06proto3"
 
 var (
 	file_api_calendar_v1_CalendarService_proto_rawDescOnce sync.Once
@@ -1081,74 +3694,174 @@ func file_api_calendar_v1_CalendarService_proto_rawDescGZIP() []byte {
 	return file_api_calendar_v1_CalendarService_proto_rawDescData
 }
 
-var file_api_calendar_v1_CalendarService_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_calendar_v1_CalendarService_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
 var file_api_calendar_v1_CalendarService_proto_goTypes = []any{
-	(*Event)(nil),                      // 0: calendar.v1.Event
-	(*EventData)(nil),                  // 1: calendar.v1.EventData
-	(*CreateEventRequest)(nil),         // 2: calendar.v1.CreateEventRequest
-	(*CreateEventResponse)(nil),        // 3: calendar.v1.CreateEventResponse
-	(*UpdateEventRequest)(nil),         // 4: calendar.v1.UpdateEventRequest
-	(*UpdateEventResponse)(nil),        // 5: calendar.v1.UpdateEventResponse
-	(*DeleteEventRequest)(nil),         // 6: calendar.v1.DeleteEventRequest
-	(*DeleteEventResponse)(nil),        // 7: calendar.v1.DeleteEventResponse
-	(*GetEventRequest)(nil),            // 8: calendar.v1.GetEventRequest
-	(*GetEventResponse)(nil),           // 9: calendar.v1.GetEventResponse
-	(*GetAllUserEventsRequest)(nil),    // 10: calendar.v1.GetAllUserEventsRequest
-	(*GetAllUserEventsResponse)(nil),   // 11: calendar.v1.GetAllUserEventsResponse
-	(*GetEventsForDayRequest)(nil),     // 12: calendar.v1.GetEventsForDayRequest
-	(*GetEventsForDayResponse)(nil),    // 13: calendar.v1.GetEventsForDayResponse
-	(*GetEventsForWeekRequest)(nil),    // 14: calendar.v1.GetEventsForWeekRequest
-	(*GetEventsForWeekResponse)(nil),   // 15: calendar.v1.GetEventsForWeekResponse
-	(*GetEventsForMonthRequest)(nil),   // 16: calendar.v1.GetEventsForMonthRequest
-	(*GetEventsForMonthResponse)(nil),  // 17: calendar.v1.GetEventsForMonthResponse
-	(*GetEventsForPeriodRequest)(nil),  // 18: calendar.v1.GetEventsForPeriodRequest
-	(*GetEventsForPeriodResponse)(nil), // 19: calendar.v1.GetEventsForPeriodResponse
-	(*timestamppb.Timestamp)(nil),      // 20: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),        // 21: google.protobuf.Duration
+	(*Event)(nil),                           // 0: calendar.v1.Event
+	(*EventData)(nil),                       // 1: calendar.v1.EventData
+	(*CreateEventRequest)(nil),              // 2: calendar.v1.CreateEventRequest
+	(*CreateEventResponse)(nil),             // 3: calendar.v1.CreateEventResponse
+	(*UpdateEventRequest)(nil),              // 4: calendar.v1.UpdateEventRequest
+	(*UpdateEventResponse)(nil),             // 5: calendar.v1.UpdateEventResponse
+	(*DeleteEventRequest)(nil),              // 6: calendar.v1.DeleteEventRequest
+	(*DeleteEventResponse)(nil),             // 7: calendar.v1.DeleteEventResponse
+	(*GetEventRequest)(nil),                 // 8: calendar.v1.GetEventRequest
+	(*GetEventResponse)(nil),                // 9: calendar.v1.GetEventResponse
+	(*GetAllUserEventsRequest)(nil),         // 10: calendar.v1.GetAllUserEventsRequest
+	(*GetAllUserEventsResponse)(nil),        // 11: calendar.v1.GetAllUserEventsResponse
+	(*GetEventsForDayRequest)(nil),          // 12: calendar.v1.GetEventsForDayRequest
+	(*GetEventsForDayResponse)(nil),         // 13: calendar.v1.GetEventsForDayResponse
+	(*GetEventsForWeekRequest)(nil),         // 14: calendar.v1.GetEventsForWeekRequest
+	(*GetEventsForWeekResponse)(nil),        // 15: calendar.v1.GetEventsForWeekResponse
+	(*GetEventsForMonthRequest)(nil),        // 16: calendar.v1.GetEventsForMonthRequest
+	(*GetEventsForMonthResponse)(nil),       // 17: calendar.v1.GetEventsForMonthResponse
+	(*GetEventsForPeriodRequest)(nil),       // 18: calendar.v1.GetEventsForPeriodRequest
+	(*GetEventsForPeriodResponse)(nil),      // 19: calendar.v1.GetEventsForPeriodResponse
+	(*CleanupEventsRequest)(nil),            // 20: calendar.v1.CleanupEventsRequest
+	(*CleanupEventsResponse)(nil),           // 21: calendar.v1.CleanupEventsResponse
+	(*GetSchedulerStatusRequest)(nil),       // 22: calendar.v1.GetSchedulerStatusRequest
+	(*GetSchedulerStatusResponse)(nil),      // 23: calendar.v1.GetSchedulerStatusResponse
+	(*GetMissedRemindersRequest)(nil),       // 24: calendar.v1.GetMissedRemindersRequest
+	(*MissedReminder)(nil),                  // 25: calendar.v1.MissedReminder
+	(*GetMissedRemindersResponse)(nil),      // 26: calendar.v1.GetMissedRemindersResponse
+	(*GetCacheStatsRequest)(nil),            // 27: calendar.v1.GetCacheStatsRequest
+	(*GetCacheStatsResponse)(nil),           // 28: calendar.v1.GetCacheStatsResponse
+	(*PreviewNotificationRequest)(nil),      // 29: calendar.v1.PreviewNotificationRequest
+	(*PreviewNotificationResponse)(nil),     // 30: calendar.v1.PreviewNotificationResponse
+	(*DigestSettings)(nil),                  // 31: calendar.v1.DigestSettings
+	(*GetDigestSettingsRequest)(nil),        // 32: calendar.v1.GetDigestSettingsRequest
+	(*GetDigestSettingsResponse)(nil),       // 33: calendar.v1.GetDigestSettingsResponse
+	(*SetDigestSettingsRequest)(nil),        // 34: calendar.v1.SetDigestSettingsRequest
+	(*SetDigestSettingsResponse)(nil),       // 35: calendar.v1.SetDigestSettingsResponse
+	(*Webhook)(nil),                         // 36: calendar.v1.Webhook
+	(*WebhookDelivery)(nil),                 // 37: calendar.v1.WebhookDelivery
+	(*CreateWebhookRequest)(nil),            // 38: calendar.v1.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),           // 39: calendar.v1.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),             // 40: calendar.v1.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),            // 41: calendar.v1.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),            // 42: calendar.v1.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),           // 43: calendar.v1.DeleteWebhookResponse
+	(*EnableWebhookRequest)(nil),            // 44: calendar.v1.EnableWebhookRequest
+	(*EnableWebhookResponse)(nil),           // 45: calendar.v1.EnableWebhookResponse
+	(*ListWebhookDeliveriesRequest)(nil),    // 46: calendar.v1.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),   // 47: calendar.v1.ListWebhookDeliveriesResponse
+	(*Resource)(nil),                        // 48: calendar.v1.Resource
+	(*ResourceBooking)(nil),                 // 49: calendar.v1.ResourceBooking
+	(*ResourceAvailability)(nil),            // 50: calendar.v1.ResourceAvailability
+	(*CreateResourceRequest)(nil),           // 51: calendar.v1.CreateResourceRequest
+	(*CreateResourceResponse)(nil),          // 52: calendar.v1.CreateResourceResponse
+	(*ListResourcesRequest)(nil),            // 53: calendar.v1.ListResourcesRequest
+	(*ListResourcesResponse)(nil),           // 54: calendar.v1.ListResourcesResponse
+	(*DeleteResourceRequest)(nil),           // 55: calendar.v1.DeleteResourceRequest
+	(*DeleteResourceResponse)(nil),          // 56: calendar.v1.DeleteResourceResponse
+	(*GetResourceAvailabilityRequest)(nil),  // 57: calendar.v1.GetResourceAvailabilityRequest
+	(*GetResourceAvailabilityResponse)(nil), // 58: calendar.v1.GetResourceAvailabilityResponse
+	(*timestamppb.Timestamp)(nil),           // 59: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),             // 60: google.protobuf.Duration
 }
 var file_api_calendar_v1_CalendarService_proto_depIdxs = []int32{
 	1,  // 0: calendar.v1.Event.data:type_name -> calendar.v1.EventData
-	20, // 1: calendar.v1.EventData.datetime:type_name -> google.protobuf.Timestamp
-	21, // 2: calendar.v1.EventData.duration:type_name -> google.protobuf.Duration
-	21, // 3: calendar.v1.EventData.remind_in:type_name -> google.protobuf.Duration
+	59, // 1: calendar.v1.EventData.datetime:type_name -> google.protobuf.Timestamp
+	60, // 2: calendar.v1.EventData.duration:type_name -> google.protobuf.Duration
+	60, // 3: calendar.v1.EventData.remind_in:type_name -> google.protobuf.Duration
 	1,  // 4: calendar.v1.CreateEventRequest.data:type_name -> calendar.v1.EventData
 	0,  // 5: calendar.v1.CreateEventResponse.event:type_name -> calendar.v1.Event
 	1,  // 6: calendar.v1.UpdateEventRequest.data:type_name -> calendar.v1.EventData
 	0,  // 7: calendar.v1.UpdateEventResponse.event:type_name -> calendar.v1.Event
 	0,  // 8: calendar.v1.GetEventResponse.event:type_name -> calendar.v1.Event
 	0,  // 9: calendar.v1.GetAllUserEventsResponse.events:type_name -> calendar.v1.Event
-	20, // 10: calendar.v1.GetEventsForDayRequest.date:type_name -> google.protobuf.Timestamp
+	59, // 10: calendar.v1.GetEventsForDayRequest.date:type_name -> google.protobuf.Timestamp
 	0,  // 11: calendar.v1.GetEventsForDayResponse.events:type_name -> calendar.v1.Event
-	20, // 12: calendar.v1.GetEventsForWeekRequest.date:type_name -> google.protobuf.Timestamp
+	59, // 12: calendar.v1.GetEventsForWeekRequest.date:type_name -> google.protobuf.Timestamp
 	0,  // 13: calendar.v1.GetEventsForWeekResponse.events:type_name -> calendar.v1.Event
-	20, // 14: calendar.v1.GetEventsForMonthRequest.date:type_name -> google.protobuf.Timestamp
+	59, // 14: calendar.v1.GetEventsForMonthRequest.date:type_name -> google.protobuf.Timestamp
 	0,  // 15: calendar.v1.GetEventsForMonthResponse.events:type_name -> calendar.v1.Event
-	20, // 16: calendar.v1.GetEventsForPeriodRequest.start_date:type_name -> google.protobuf.Timestamp
-	20, // 17: calendar.v1.GetEventsForPeriodRequest.end_date:type_name -> google.protobuf.Timestamp
+	59, // 16: calendar.v1.GetEventsForPeriodRequest.start_date:type_name -> google.protobuf.Timestamp
+	59, // 17: calendar.v1.GetEventsForPeriodRequest.end_date:type_name -> google.protobuf.Timestamp
 	0,  // 18: calendar.v1.GetEventsForPeriodResponse.events:type_name -> calendar.v1.Event
-	2,  // 19: calendar.v1.CalendarService.CreateEvent:input_type -> calendar.v1.CreateEventRequest
-	4,  // 20: calendar.v1.CalendarService.UpdateEvent:input_type -> calendar.v1.UpdateEventRequest
-	6,  // 21: calendar.v1.CalendarService.DeleteEvent:input_type -> calendar.v1.DeleteEventRequest
-	8,  // 22: calendar.v1.CalendarService.GetEvent:input_type -> calendar.v1.GetEventRequest
-	10, // 23: calendar.v1.CalendarService.GetAllUserEvents:input_type -> calendar.v1.GetAllUserEventsRequest
-	12, // 24: calendar.v1.CalendarService.GetEventsForDay:input_type -> calendar.v1.GetEventsForDayRequest
-	14, // 25: calendar.v1.CalendarService.GetEventsForWeek:input_type -> calendar.v1.GetEventsForWeekRequest
-	16, // 26: calendar.v1.CalendarService.GetEventsForMonth:input_type -> calendar.v1.GetEventsForMonthRequest
-	18, // 27: calendar.v1.CalendarService.GetEventsForPeriod:input_type -> calendar.v1.GetEventsForPeriodRequest
-	3,  // 28: calendar.v1.CalendarService.CreateEvent:output_type -> calendar.v1.CreateEventResponse
-	5,  // 29: calendar.v1.CalendarService.UpdateEvent:output_type -> calendar.v1.UpdateEventResponse
-	7,  // 30: calendar.v1.CalendarService.DeleteEvent:output_type -> calendar.v1.DeleteEventResponse
-	9,  // 31: calendar.v1.CalendarService.GetEvent:output_type -> calendar.v1.GetEventResponse
-	11, // 32: calendar.v1.CalendarService.GetAllUserEvents:output_type -> calendar.v1.GetAllUserEventsResponse
-	13, // 33: calendar.v1.CalendarService.GetEventsForDay:output_type -> calendar.v1.GetEventsForDayResponse
-	15, // 34: calendar.v1.CalendarService.GetEventsForWeek:output_type -> calendar.v1.GetEventsForWeekResponse
-	17, // 35: calendar.v1.CalendarService.GetEventsForMonth:output_type -> calendar.v1.GetEventsForMonthResponse
-	19, // 36: calendar.v1.CalendarService.GetEventsForPeriod:output_type -> calendar.v1.GetEventsForPeriodResponse
-	28, // [28:37] is the sub-list for method output_type
-	19, // [19:28] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	59, // 19: calendar.v1.CleanupEventsRequest.before:type_name -> google.protobuf.Timestamp
+	59, // 20: calendar.v1.GetSchedulerStatusResponse.next_reminder:type_name -> google.protobuf.Timestamp
+	59, // 21: calendar.v1.GetMissedRemindersRequest.outage_start:type_name -> google.protobuf.Timestamp
+	59, // 22: calendar.v1.GetMissedRemindersRequest.outage_end:type_name -> google.protobuf.Timestamp
+	60, // 23: calendar.v1.GetMissedRemindersRequest.catch_up_grace:type_name -> google.protobuf.Duration
+	59, // 24: calendar.v1.MissedReminder.start:type_name -> google.protobuf.Timestamp
+	59, // 25: calendar.v1.MissedReminder.remind_at:type_name -> google.protobuf.Timestamp
+	60, // 26: calendar.v1.MissedReminder.delay:type_name -> google.protobuf.Duration
+	25, // 27: calendar.v1.GetMissedRemindersResponse.reminders:type_name -> calendar.v1.MissedReminder
+	60, // 28: calendar.v1.GetCacheStatsResponse.ttl:type_name -> google.protobuf.Duration
+	31, // 29: calendar.v1.GetDigestSettingsResponse.settings:type_name -> calendar.v1.DigestSettings
+	31, // 30: calendar.v1.SetDigestSettingsRequest.settings:type_name -> calendar.v1.DigestSettings
+	31, // 31: calendar.v1.SetDigestSettingsResponse.settings:type_name -> calendar.v1.DigestSettings
+	59, // 32: calendar.v1.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	59, // 33: calendar.v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	36, // 34: calendar.v1.CreateWebhookResponse.webhook:type_name -> calendar.v1.Webhook
+	36, // 35: calendar.v1.ListWebhooksResponse.webhooks:type_name -> calendar.v1.Webhook
+	36, // 36: calendar.v1.EnableWebhookResponse.webhook:type_name -> calendar.v1.Webhook
+	37, // 37: calendar.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> calendar.v1.WebhookDelivery
+	59, // 38: calendar.v1.Resource.created_at:type_name -> google.protobuf.Timestamp
+	59, // 39: calendar.v1.ResourceBooking.start:type_name -> google.protobuf.Timestamp
+	59, // 40: calendar.v1.ResourceBooking.end:type_name -> google.protobuf.Timestamp
+	48, // 41: calendar.v1.ResourceAvailability.resource:type_name -> calendar.v1.Resource
+	49, // 42: calendar.v1.ResourceAvailability.bookings:type_name -> calendar.v1.ResourceBooking
+	48, // 43: calendar.v1.CreateResourceResponse.resource:type_name -> calendar.v1.Resource
+	48, // 44: calendar.v1.ListResourcesResponse.resources:type_name -> calendar.v1.Resource
+	59, // 45: calendar.v1.GetResourceAvailabilityRequest.date_start:type_name -> google.protobuf.Timestamp
+	59, // 46: calendar.v1.GetResourceAvailabilityRequest.date_end:type_name -> google.protobuf.Timestamp
+	50, // 47: calendar.v1.GetResourceAvailabilityResponse.availability:type_name -> calendar.v1.ResourceAvailability
+	2,  // 48: calendar.v1.CalendarService.CreateEvent:input_type -> calendar.v1.CreateEventRequest
+	4,  // 49: calendar.v1.CalendarService.UpdateEvent:input_type -> calendar.v1.UpdateEventRequest
+	6,  // 50: calendar.v1.CalendarService.DeleteEvent:input_type -> calendar.v1.DeleteEventRequest
+	8,  // 51: calendar.v1.CalendarService.GetEvent:input_type -> calendar.v1.GetEventRequest
+	10, // 52: calendar.v1.CalendarService.GetAllUserEvents:input_type -> calendar.v1.GetAllUserEventsRequest
+	12, // 53: calendar.v1.CalendarService.GetEventsForDay:input_type -> calendar.v1.GetEventsForDayRequest
+	14, // 54: calendar.v1.CalendarService.GetEventsForWeek:input_type -> calendar.v1.GetEventsForWeekRequest
+	16, // 55: calendar.v1.CalendarService.GetEventsForMonth:input_type -> calendar.v1.GetEventsForMonthRequest
+	18, // 56: calendar.v1.CalendarService.GetEventsForPeriod:input_type -> calendar.v1.GetEventsForPeriodRequest
+	20, // 57: calendar.v1.CalendarService.CleanupEvents:input_type -> calendar.v1.CleanupEventsRequest
+	22, // 58: calendar.v1.CalendarService.GetSchedulerStatus:input_type -> calendar.v1.GetSchedulerStatusRequest
+	24, // 59: calendar.v1.CalendarService.GetMissedReminders:input_type -> calendar.v1.GetMissedRemindersRequest
+	27, // 60: calendar.v1.CalendarService.GetCacheStats:input_type -> calendar.v1.GetCacheStatsRequest
+	29, // 61: calendar.v1.CalendarService.PreviewNotification:input_type -> calendar.v1.PreviewNotificationRequest
+	32, // 62: calendar.v1.CalendarService.GetDigestSettings:input_type -> calendar.v1.GetDigestSettingsRequest
+	34, // 63: calendar.v1.CalendarService.SetDigestSettings:input_type -> calendar.v1.SetDigestSettingsRequest
+	38, // 64: calendar.v1.CalendarService.CreateWebhook:input_type -> calendar.v1.CreateWebhookRequest
+	40, // 65: calendar.v1.CalendarService.ListWebhooks:input_type -> calendar.v1.ListWebhooksRequest
+	42, // 66: calendar.v1.CalendarService.DeleteWebhook:input_type -> calendar.v1.DeleteWebhookRequest
+	44, // 67: calendar.v1.CalendarService.EnableWebhook:input_type -> calendar.v1.EnableWebhookRequest
+	46, // 68: calendar.v1.CalendarService.ListWebhookDeliveries:input_type -> calendar.v1.ListWebhookDeliveriesRequest
+	51, // 69: calendar.v1.CalendarService.CreateResource:input_type -> calendar.v1.CreateResourceRequest
+	53, // 70: calendar.v1.CalendarService.ListResources:input_type -> calendar.v1.ListResourcesRequest
+	55, // 71: calendar.v1.CalendarService.DeleteResource:input_type -> calendar.v1.DeleteResourceRequest
+	57, // 72: calendar.v1.CalendarService.GetResourceAvailability:input_type -> calendar.v1.GetResourceAvailabilityRequest
+	3,  // 73: calendar.v1.CalendarService.CreateEvent:output_type -> calendar.v1.CreateEventResponse
+	5,  // 74: calendar.v1.CalendarService.UpdateEvent:output_type -> calendar.v1.UpdateEventResponse
+	7,  // 75: calendar.v1.CalendarService.DeleteEvent:output_type -> calendar.v1.DeleteEventResponse
+	9,  // 76: calendar.v1.CalendarService.GetEvent:output_type -> calendar.v1.GetEventResponse
+	11, // 77: calendar.v1.CalendarService.GetAllUserEvents:output_type -> calendar.v1.GetAllUserEventsResponse
+	13, // 78: calendar.v1.CalendarService.GetEventsForDay:output_type -> calendar.v1.GetEventsForDayResponse
+	15, // 79: calendar.v1.CalendarService.GetEventsForWeek:output_type -> calendar.v1.GetEventsForWeekResponse
+	17, // 80: calendar.v1.CalendarService.GetEventsForMonth:output_type -> calendar.v1.GetEventsForMonthResponse
+	19, // 81: calendar.v1.CalendarService.GetEventsForPeriod:output_type -> calendar.v1.GetEventsForPeriodResponse
+	21, // 82: calendar.v1.CalendarService.CleanupEvents:output_type -> calendar.v1.CleanupEventsResponse
+	23, // 83: calendar.v1.CalendarService.GetSchedulerStatus:output_type -> calendar.v1.GetSchedulerStatusResponse
+	26, // 84: calendar.v1.CalendarService.GetMissedReminders:output_type -> calendar.v1.GetMissedRemindersResponse
+	28, // 85: calendar.v1.CalendarService.GetCacheStats:output_type -> calendar.v1.GetCacheStatsResponse
+	30, // 86: calendar.v1.CalendarService.PreviewNotification:output_type -> calendar.v1.PreviewNotificationResponse
+	33, // 87: calendar.v1.CalendarService.GetDigestSettings:output_type -> calendar.v1.GetDigestSettingsResponse
+	35, // 88: calendar.v1.CalendarService.SetDigestSettings:output_type -> calendar.v1.SetDigestSettingsResponse
+	39, // 89: calendar.v1.CalendarService.CreateWebhook:output_type -> calendar.v1.CreateWebhookResponse
+	41, // 90: calendar.v1.CalendarService.ListWebhooks:output_type -> calendar.v1.ListWebhooksResponse
+	43, // 91: calendar.v1.CalendarService.DeleteWebhook:output_type -> calendar.v1.DeleteWebhookResponse
+	45, // 92: calendar.v1.CalendarService.EnableWebhook:output_type -> calendar.v1.EnableWebhookResponse
+	47, // 93: calendar.v1.CalendarService.ListWebhookDeliveries:output_type -> calendar.v1.ListWebhookDeliveriesResponse
+	52, // 94: calendar.v1.CalendarService.CreateResource:output_type -> calendar.v1.CreateResourceResponse
+	54, // 95: calendar.v1.CalendarService.ListResources:output_type -> calendar.v1.ListResourcesResponse
+	56, // 96: calendar.v1.CalendarService.DeleteResource:output_type -> calendar.v1.DeleteResourceResponse
+	58, // 97: calendar.v1.CalendarService.GetResourceAvailability:output_type -> calendar.v1.GetResourceAvailabilityResponse
+	73, // [73:98] is the sub-list for method output_type
+	48, // [48:73] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_api_calendar_v1_CalendarService_proto_init() }
@@ -1156,6 +3869,8 @@ func file_api_calendar_v1_CalendarService_proto_init() {
 	if File_api_calendar_v1_CalendarService_proto != nil {
 		return
 	}
+	file_api_calendar_v1_CalendarService_proto_msgTypes[2].OneofWrappers = []any{}
+	file_api_calendar_v1_CalendarService_proto_msgTypes[4].OneofWrappers = []any{}
 	file_api_calendar_v1_CalendarService_proto_msgTypes[12].OneofWrappers = []any{}
 	file_api_calendar_v1_CalendarService_proto_msgTypes[14].OneofWrappers = []any{}
 	file_api_calendar_v1_CalendarService_proto_msgTypes[16].OneofWrappers = []any{}
@@ -1166,7 +3881,7 @@ func file_api_calendar_v1_CalendarService_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_calendar_v1_CalendarService_proto_rawDesc), len(file_api_calendar_v1_CalendarService_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   59,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
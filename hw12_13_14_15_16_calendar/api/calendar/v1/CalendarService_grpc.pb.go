@@ -19,15 +19,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CalendarService_CreateEvent_FullMethodName        = "/calendar.v1.CalendarService/CreateEvent"
-	CalendarService_UpdateEvent_FullMethodName        = "/calendar.v1.CalendarService/UpdateEvent"
-	CalendarService_DeleteEvent_FullMethodName        = "/calendar.v1.CalendarService/DeleteEvent"
-	CalendarService_GetEvent_FullMethodName           = "/calendar.v1.CalendarService/GetEvent"
-	CalendarService_GetAllUserEvents_FullMethodName   = "/calendar.v1.CalendarService/GetAllUserEvents"
-	CalendarService_GetEventsForDay_FullMethodName    = "/calendar.v1.CalendarService/GetEventsForDay"
-	CalendarService_GetEventsForWeek_FullMethodName   = "/calendar.v1.CalendarService/GetEventsForWeek"
-	CalendarService_GetEventsForMonth_FullMethodName  = "/calendar.v1.CalendarService/GetEventsForMonth"
-	CalendarService_GetEventsForPeriod_FullMethodName = "/calendar.v1.CalendarService/GetEventsForPeriod"
+	CalendarService_CreateEvent_FullMethodName             = "/calendar.v1.CalendarService/CreateEvent"
+	CalendarService_UpdateEvent_FullMethodName             = "/calendar.v1.CalendarService/UpdateEvent"
+	CalendarService_DeleteEvent_FullMethodName             = "/calendar.v1.CalendarService/DeleteEvent"
+	CalendarService_GetEvent_FullMethodName                = "/calendar.v1.CalendarService/GetEvent"
+	CalendarService_GetAllUserEvents_FullMethodName        = "/calendar.v1.CalendarService/GetAllUserEvents"
+	CalendarService_GetEventsForDay_FullMethodName         = "/calendar.v1.CalendarService/GetEventsForDay"
+	CalendarService_GetEventsForWeek_FullMethodName        = "/calendar.v1.CalendarService/GetEventsForWeek"
+	CalendarService_GetEventsForMonth_FullMethodName       = "/calendar.v1.CalendarService/GetEventsForMonth"
+	CalendarService_GetEventsForPeriod_FullMethodName      = "/calendar.v1.CalendarService/GetEventsForPeriod"
+	CalendarService_CleanupEvents_FullMethodName           = "/calendar.v1.CalendarService/CleanupEvents"
+	CalendarService_GetSchedulerStatus_FullMethodName      = "/calendar.v1.CalendarService/GetSchedulerStatus"
+	CalendarService_GetMissedReminders_FullMethodName      = "/calendar.v1.CalendarService/GetMissedReminders"
+	CalendarService_GetCacheStats_FullMethodName           = "/calendar.v1.CalendarService/GetCacheStats"
+	CalendarService_PreviewNotification_FullMethodName     = "/calendar.v1.CalendarService/PreviewNotification"
+	CalendarService_GetDigestSettings_FullMethodName       = "/calendar.v1.CalendarService/GetDigestSettings"
+	CalendarService_SetDigestSettings_FullMethodName       = "/calendar.v1.CalendarService/SetDigestSettings"
+	CalendarService_CreateWebhook_FullMethodName           = "/calendar.v1.CalendarService/CreateWebhook"
+	CalendarService_ListWebhooks_FullMethodName            = "/calendar.v1.CalendarService/ListWebhooks"
+	CalendarService_DeleteWebhook_FullMethodName           = "/calendar.v1.CalendarService/DeleteWebhook"
+	CalendarService_EnableWebhook_FullMethodName           = "/calendar.v1.CalendarService/EnableWebhook"
+	CalendarService_ListWebhookDeliveries_FullMethodName   = "/calendar.v1.CalendarService/ListWebhookDeliveries"
+	CalendarService_CreateResource_FullMethodName          = "/calendar.v1.CalendarService/CreateResource"
+	CalendarService_ListResources_FullMethodName           = "/calendar.v1.CalendarService/ListResources"
+	CalendarService_DeleteResource_FullMethodName          = "/calendar.v1.CalendarService/DeleteResource"
+	CalendarService_GetResourceAvailability_FullMethodName = "/calendar.v1.CalendarService/GetResourceAvailability"
 )
 
 // CalendarServiceClient is the client API for CalendarService service.
@@ -52,6 +68,38 @@ type CalendarServiceClient interface {
 	GetEventsForMonth(ctx context.Context, in *GetEventsForMonthRequest, opts ...grpc.CallOption) (*GetEventsForMonthResponse, error)
 	// GET /v1/events/period
 	GetEventsForPeriod(ctx context.Context, in *GetEventsForPeriodRequest, opts ...grpc.CallOption) (*GetEventsForPeriodResponse, error)
+	// POST /v1/admin/cleanup
+	CleanupEvents(ctx context.Context, in *CleanupEventsRequest, opts ...grpc.CallOption) (*CleanupEventsResponse, error)
+	// GET /v1/admin/scheduler
+	GetSchedulerStatus(ctx context.Context, in *GetSchedulerStatusRequest, opts ...grpc.CallOption) (*GetSchedulerStatusResponse, error)
+	// GET /v1/admin/reminders/missed
+	GetMissedReminders(ctx context.Context, in *GetMissedRemindersRequest, opts ...grpc.CallOption) (*GetMissedRemindersResponse, error)
+	// GET /v1/admin/cache
+	GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*GetCacheStatsResponse, error)
+	// POST /v1/admin/notifications/preview
+	PreviewNotification(ctx context.Context, in *PreviewNotificationRequest, opts ...grpc.CallOption) (*PreviewNotificationResponse, error)
+	// GET /v1/users/{user_id}/digest
+	GetDigestSettings(ctx context.Context, in *GetDigestSettingsRequest, opts ...grpc.CallOption) (*GetDigestSettingsResponse, error)
+	// PUT /v1/users/{user_id}/digest
+	SetDigestSettings(ctx context.Context, in *SetDigestSettingsRequest, opts ...grpc.CallOption) (*SetDigestSettingsResponse, error)
+	// POST /v1/webhooks
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// GET /v1/webhooks
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// DELETE /v1/webhooks/{id}
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// POST /v1/webhooks/{id}/enable
+	EnableWebhook(ctx context.Context, in *EnableWebhookRequest, opts ...grpc.CallOption) (*EnableWebhookResponse, error)
+	// GET /v1/webhooks/{id}/deliveries
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// POST /v1/resources
+	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error)
+	// GET /v1/resources
+	ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	// DELETE /v1/resources/{id}
+	DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*DeleteResourceResponse, error)
+	// GET /v1/resources/availability
+	GetResourceAvailability(ctx context.Context, in *GetResourceAvailabilityRequest, opts ...grpc.CallOption) (*GetResourceAvailabilityResponse, error)
 }
 
 type calendarServiceClient struct {
@@ -152,6 +200,166 @@ func (c *calendarServiceClient) GetEventsForPeriod(ctx context.Context, in *GetE
 	return out, nil
 }
 
+func (c *calendarServiceClient) CleanupEvents(ctx context.Context, in *CleanupEventsRequest, opts ...grpc.CallOption) (*CleanupEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupEventsResponse)
+	err := c.cc.Invoke(ctx, CalendarService_CleanupEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetSchedulerStatus(ctx context.Context, in *GetSchedulerStatusRequest, opts ...grpc.CallOption) (*GetSchedulerStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSchedulerStatusResponse)
+	err := c.cc.Invoke(ctx, CalendarService_GetSchedulerStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetMissedReminders(ctx context.Context, in *GetMissedRemindersRequest, opts ...grpc.CallOption) (*GetMissedRemindersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMissedRemindersResponse)
+	err := c.cc.Invoke(ctx, CalendarService_GetMissedReminders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*GetCacheStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCacheStatsResponse)
+	err := c.cc.Invoke(ctx, CalendarService_GetCacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) PreviewNotification(ctx context.Context, in *PreviewNotificationRequest, opts ...grpc.CallOption) (*PreviewNotificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewNotificationResponse)
+	err := c.cc.Invoke(ctx, CalendarService_PreviewNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetDigestSettings(ctx context.Context, in *GetDigestSettingsRequest, opts ...grpc.CallOption) (*GetDigestSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDigestSettingsResponse)
+	err := c.cc.Invoke(ctx, CalendarService_GetDigestSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) SetDigestSettings(ctx context.Context, in *SetDigestSettingsRequest, opts ...grpc.CallOption) (*SetDigestSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDigestSettingsResponse)
+	err := c.cc.Invoke(ctx, CalendarService_SetDigestSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, CalendarService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, CalendarService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) EnableWebhook(ctx context.Context, in *EnableWebhookRequest, opts ...grpc.CallOption) (*EnableWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableWebhookResponse)
+	err := c.cc.Invoke(ctx, CalendarService_EnableWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, CalendarService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateResourceResponse)
+	err := c.cc.Invoke(ctx, CalendarService_CreateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResourcesResponse)
+	err := c.cc.Invoke(ctx, CalendarService_ListResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*DeleteResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResourceResponse)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetResourceAvailability(ctx context.Context, in *GetResourceAvailabilityRequest, opts ...grpc.CallOption) (*GetResourceAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResourceAvailabilityResponse)
+	err := c.cc.Invoke(ctx, CalendarService_GetResourceAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CalendarServiceServer is the server API for CalendarService service.
 // All implementations must embed UnimplementedCalendarServiceServer
 // for forward compatibility.
@@ -174,6 +382,38 @@ type CalendarServiceServer interface {
 	GetEventsForMonth(context.Context, *GetEventsForMonthRequest) (*GetEventsForMonthResponse, error)
 	// GET /v1/events/period
 	GetEventsForPeriod(context.Context, *GetEventsForPeriodRequest) (*GetEventsForPeriodResponse, error)
+	// POST /v1/admin/cleanup
+	CleanupEvents(context.Context, *CleanupEventsRequest) (*CleanupEventsResponse, error)
+	// GET /v1/admin/scheduler
+	GetSchedulerStatus(context.Context, *GetSchedulerStatusRequest) (*GetSchedulerStatusResponse, error)
+	// GET /v1/admin/reminders/missed
+	GetMissedReminders(context.Context, *GetMissedRemindersRequest) (*GetMissedRemindersResponse, error)
+	// GET /v1/admin/cache
+	GetCacheStats(context.Context, *GetCacheStatsRequest) (*GetCacheStatsResponse, error)
+	// POST /v1/admin/notifications/preview
+	PreviewNotification(context.Context, *PreviewNotificationRequest) (*PreviewNotificationResponse, error)
+	// GET /v1/users/{user_id}/digest
+	GetDigestSettings(context.Context, *GetDigestSettingsRequest) (*GetDigestSettingsResponse, error)
+	// PUT /v1/users/{user_id}/digest
+	SetDigestSettings(context.Context, *SetDigestSettingsRequest) (*SetDigestSettingsResponse, error)
+	// POST /v1/webhooks
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// GET /v1/webhooks
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// DELETE /v1/webhooks/{id}
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// POST /v1/webhooks/{id}/enable
+	EnableWebhook(context.Context, *EnableWebhookRequest) (*EnableWebhookResponse, error)
+	// GET /v1/webhooks/{id}/deliveries
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// POST /v1/resources
+	CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error)
+	// GET /v1/resources
+	ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error)
+	// DELETE /v1/resources/{id}
+	DeleteResource(context.Context, *DeleteResourceRequest) (*DeleteResourceResponse, error)
+	// GET /v1/resources/availability
+	GetResourceAvailability(context.Context, *GetResourceAvailabilityRequest) (*GetResourceAvailabilityResponse, error)
 	mustEmbedUnimplementedCalendarServiceServer()
 }
 
@@ -211,6 +451,54 @@ func (UnimplementedCalendarServiceServer) GetEventsForMonth(context.Context, *Ge
 func (UnimplementedCalendarServiceServer) GetEventsForPeriod(context.Context, *GetEventsForPeriodRequest) (*GetEventsForPeriodResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEventsForPeriod not implemented")
 }
+func (UnimplementedCalendarServiceServer) CleanupEvents(context.Context, *CleanupEventsRequest) (*CleanupEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupEvents not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetSchedulerStatus(context.Context, *GetSchedulerStatusRequest) (*GetSchedulerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulerStatus not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetMissedReminders(context.Context, *GetMissedRemindersRequest) (*GetMissedRemindersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMissedReminders not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetCacheStats(context.Context, *GetCacheStatsRequest) (*GetCacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheStats not implemented")
+}
+func (UnimplementedCalendarServiceServer) PreviewNotification(context.Context, *PreviewNotificationRequest) (*PreviewNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewNotification not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetDigestSettings(context.Context, *GetDigestSettingsRequest) (*GetDigestSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDigestSettings not implemented")
+}
+func (UnimplementedCalendarServiceServer) SetDigestSettings(context.Context, *SetDigestSettingsRequest) (*SetDigestSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDigestSettings not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedCalendarServiceServer) EnableWebhook(context.Context, *EnableWebhookRequest) (*EnableWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableWebhook not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResources not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteResource(context.Context, *DeleteResourceRequest) (*DeleteResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteResource not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetResourceAvailability(context.Context, *GetResourceAvailabilityRequest) (*GetResourceAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceAvailability not implemented")
+}
 func (UnimplementedCalendarServiceServer) mustEmbedUnimplementedCalendarServiceServer() {}
 func (UnimplementedCalendarServiceServer) testEmbeddedByValue()                         {}
 
@@ -394,6 +682,294 @@ func _CalendarService_GetEventsForPeriod_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalendarService_CleanupEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CleanupEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CleanupEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CleanupEvents(ctx, req.(*CleanupEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetSchedulerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchedulerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetSchedulerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetSchedulerStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetSchedulerStatus(ctx, req.(*GetSchedulerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetMissedReminders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMissedRemindersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetMissedReminders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetMissedReminders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetMissedReminders(ctx, req.(*GetMissedRemindersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetCacheStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetCacheStats(ctx, req.(*GetCacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_PreviewNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).PreviewNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_PreviewNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).PreviewNotification(ctx, req.(*PreviewNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetDigestSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDigestSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetDigestSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetDigestSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetDigestSettings(ctx, req.(*GetDigestSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_SetDigestSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDigestSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).SetDigestSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_SetDigestSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).SetDigestSettings(ctx, req.(*SetDigestSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_EnableWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).EnableWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_EnableWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).EnableWebhook(ctx, req.(*EnableWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateResource(ctx, req.(*CreateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListResources(ctx, req.(*ListResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteResource(ctx, req.(*DeleteResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetResourceAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetResourceAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetResourceAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetResourceAvailability(ctx, req.(*GetResourceAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CalendarService_ServiceDesc is the grpc.ServiceDesc for CalendarService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -437,6 +1013,70 @@ var CalendarService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEventsForPeriod",
 			Handler:    _CalendarService_GetEventsForPeriod_Handler,
 		},
+		{
+			MethodName: "CleanupEvents",
+			Handler:    _CalendarService_CleanupEvents_Handler,
+		},
+		{
+			MethodName: "GetSchedulerStatus",
+			Handler:    _CalendarService_GetSchedulerStatus_Handler,
+		},
+		{
+			MethodName: "GetMissedReminders",
+			Handler:    _CalendarService_GetMissedReminders_Handler,
+		},
+		{
+			MethodName: "GetCacheStats",
+			Handler:    _CalendarService_GetCacheStats_Handler,
+		},
+		{
+			MethodName: "PreviewNotification",
+			Handler:    _CalendarService_PreviewNotification_Handler,
+		},
+		{
+			MethodName: "GetDigestSettings",
+			Handler:    _CalendarService_GetDigestSettings_Handler,
+		},
+		{
+			MethodName: "SetDigestSettings",
+			Handler:    _CalendarService_SetDigestSettings_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _CalendarService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _CalendarService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _CalendarService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "EnableWebhook",
+			Handler:    _CalendarService_EnableWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _CalendarService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _CalendarService_CreateResource_Handler,
+		},
+		{
+			MethodName: "ListResources",
+			Handler:    _CalendarService_ListResources_Handler,
+		},
+		{
+			MethodName: "DeleteResource",
+			Handler:    _CalendarService_DeleteResource_Handler,
+		},
+		{
+			MethodName: "GetResourceAvailability",
+			Handler:    _CalendarService_GetResourceAvailability_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/calendar/v1/CalendarService.proto",
@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/notification/v1/Notification.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReminderKind int32
+
+const (
+	ReminderKind_REMINDER_KIND_UNSPECIFIED ReminderKind = 0
+	// Reminder sent remind_in before the event start.
+	ReminderKind_REMINDER_KIND_BEFORE_START ReminderKind = 1
+	// Digest of the user's agenda for the day, sent at the user-configured local time.
+	// Datetime and duration cover the day in the digest time zone.
+	ReminderKind_REMINDER_KIND_DAILY_DIGEST ReminderKind = 2
+)
+
+// Enum value maps for ReminderKind.
+var (
+	ReminderKind_name = map[int32]string{
+		0: "REMINDER_KIND_UNSPECIFIED",
+		1: "REMINDER_KIND_BEFORE_START",
+		2: "REMINDER_KIND_DAILY_DIGEST",
+	}
+	ReminderKind_value = map[string]int32{
+		"REMINDER_KIND_UNSPECIFIED":  0,
+		"REMINDER_KIND_BEFORE_START": 1,
+		"REMINDER_KIND_DAILY_DIGEST": 2,
+	}
+)
+
+func (x ReminderKind) Enum() *ReminderKind {
+	p := new(ReminderKind)
+	*p = x
+	return p
+}
+
+func (x ReminderKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReminderKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_notification_v1_Notification_proto_enumTypes[0].Descriptor()
+}
+
+func (ReminderKind) Type() protoreflect.EnumType {
+	return &file_api_notification_v1_Notification_proto_enumTypes[0]
+}
+
+func (x ReminderKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReminderKind.Descriptor instead.
+func (ReminderKind) EnumDescriptor() ([]byte, []int) {
+	return file_api_notification_v1_Notification_proto_rawDescGZIP(), []int{0}
+}
+
+// Notification is a reminder about the event or a daily agenda digest,
+// sent by the scheduler to the sender via the message broker.
+type Notification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Schema version. The sender rejects notifications of the versions it does not support.
+	SchemaVersion uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Datetime      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=datetime,proto3" json:"datetime,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	ReminderKind  ReminderKind           `protobuf:"varint,7,opt,name=reminder_kind,json=reminderKind,proto3,enum=notification.v1.ReminderKind" json:"reminder_kind,omitempty"`
+	// Time zone of the digest day, IANA name. Empty for the event reminders.
+	TimeZone string `protobuf:"bytes,8,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Events of the digest day. Only id, title, datetime and duration are set.
+	Agenda []*Notification `protobuf:"bytes,9,rep,name=agenda,proto3" json:"agenda,omitempty"`
+	// The reminder is sent after the event start, e.g. after the scheduler downtime.
+	Late          bool `protobuf:"varint,10,opt,name=late,proto3" json:"late,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_api_notification_v1_Notification_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_api_notification_v1_Notification_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_api_notification_v1_Notification_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Notification) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Notification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Notification) GetDatetime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Datetime
+	}
+	return nil
+}
+
+func (x *Notification) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Notification) GetReminderKind() ReminderKind {
+	if x != nil {
+		return x.ReminderKind
+	}
+	return ReminderKind_REMINDER_KIND_UNSPECIFIED
+}
+
+func (x *Notification) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Notification) GetAgenda() []*Notification {
+	if x != nil {
+		return x.Agenda
+	}
+	return nil
+}
+
+func (x *Notification) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
+var File_api_notification_v1_Notification_proto protoreflect.FileDescriptor
+
+const file_api_notification_v1_Notification_proto_rawDesc = "" +
+	"\n" +
+	"&api/notification/v1/Notification.proto\x12\x0fnotification.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\x8f\x03\n" +
+	"\fNotification\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x126\n" +
+	"\bdatetime\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdatetime\x125\n" +
+	"\bduration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12B\n" +
+	"\rreminder_kind\x18\a \x01(\x0e2\x1d.notification.v1.ReminderKindR\freminderKind\x12\x1b\n" +
+	"\ttime_zone\x18\b \x01(\tR\btimeZone\x125\n" +
+	"\x06agenda\x18\t \x03(\v2\x1d.notification.v1.NotificationR\x06agenda\x12\x12\n" +
+	"\x04late\x18\n" +
+	" \x01(\bR\x04late*m\n" +
+	"\fReminderKind\x12\x1d\n" +
+	"\x19REMINDER_KIND_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aREMINDER_KIND_BEFORE_START\x10\x01\x12\x1e\n" +
+	"\x1aREMINDER_KIND_DAILY_DIGEST\x10\x02BLZJgithub.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/notification/v1b\x06proto3"
+
+var (
+	file_api_notification_v1_Notification_proto_rawDescOnce sync.Once
+	file_api_notification_v1_Notification_proto_rawDescData []byte
+)
+
+func file_api_notification_v1_Notification_proto_rawDescGZIP() []byte {
+	file_api_notification_v1_Notification_proto_rawDescOnce.Do(func() {
+		file_api_notification_v1_Notification_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_notification_v1_Notification_proto_rawDesc), len(file_api_notification_v1_Notification_proto_rawDesc)))
+	})
+	return file_api_notification_v1_Notification_proto_rawDescData
+}
+
+var file_api_notification_v1_Notification_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_notification_v1_Notification_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_notification_v1_Notification_proto_goTypes = []any{
+	(ReminderKind)(0),             // 0: notification.v1.ReminderKind
+	(*Notification)(nil),          // 1: notification.v1.Notification
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 3: google.protobuf.Duration
+}
+var file_api_notification_v1_Notification_proto_depIdxs = []int32{
+	2, // 0: notification.v1.Notification.datetime:type_name -> google.protobuf.Timestamp
+	3, // 1: notification.v1.Notification.duration:type_name -> google.protobuf.Duration
+	0, // 2: notification.v1.Notification.reminder_kind:type_name -> notification.v1.ReminderKind
+	1, // 3: notification.v1.Notification.agenda:type_name -> notification.v1.Notification
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_notification_v1_Notification_proto_init() }
+func file_api_notification_v1_Notification_proto_init() {
+	if File_api_notification_v1_Notification_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_notification_v1_Notification_proto_rawDesc), len(file_api_notification_v1_Notification_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_notification_v1_Notification_proto_goTypes,
+		DependencyIndexes: file_api_notification_v1_Notification_proto_depIdxs,
+		EnumInfos:         file_api_notification_v1_Notification_proto_enumTypes,
+		MessageInfos:      file_api_notification_v1_Notification_proto_msgTypes,
+	}.Build()
+	File_api_notification_v1_Notification_proto = out.File
+	file_api_notification_v1_Notification_proto_goTypes = nil
+	file_api_notification_v1_Notification_proto_depIdxs = nil
+}
@@ -16,6 +16,8 @@ import (
 	internalgrpc "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc"       //nolint:depguard
 	internalhttp "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/http"       //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"                        //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/templates"                      //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/webhook"                        //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                              //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/logger"                              //nolint:depguard
 )
@@ -45,10 +47,14 @@ func run() error {
 	logg = logg.With(slog.String("service", "calendar"))
 
 	// Loading configuration from file and env.
-	cfg, err := loadConfig(ctx, logg)
+	loader, cfg, err := loadConfig(ctx, logg)
 	if err != nil {
 		return err
 	}
+	// Help, version or a subcommand was requested - nothing to run.
+	if cfg == nil {
+		return nil
+	}
 
 	// Initializing service logger.
 	logg, err = initializeLogger(ctx, logg, cfg)
@@ -64,7 +70,7 @@ func run() error {
 	defer storage.Close(ctx)
 
 	// Initializing signal handler.
-	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
 	// Initializing storage connection.
@@ -74,29 +80,65 @@ func run() error {
 	}
 	logg.Info(ctx, "storage connected established")
 
+	// Initializing notification templates for the previews.
+	renderer, err := initializeTemplates(ctx, logg, cfg, storage)
+	if err != nil {
+		return err
+	}
+
+	// Initializing webhook deliveries.
+	dispatcher, err := initializeWebhooks(ctx, logg, cfg, storage)
+	if err != nil {
+		return err
+	}
+
 	// Initializing the app.
-	calendar, err := initializeApp(ctx, logg, cfg, storage)
+	calendar, err := initializeApp(ctx, logg, cfg, storage, renderer, dispatcher)
 	if err != nil {
 		return err
 	}
 
+	// Applying the settings, which are safe to change at runtime, on config change or SIGHUP.
+	loader.Watch(ctx, cfg, logg,
+		config.Reloader{Section: "logger", Apply: logg.Reload},
+		config.Reloader{Section: "app", Keys: []string{"retries", "retry_timeout"}, Apply: calendar.Reload},
+		config.Reloader{Section: "templates", Keys: renderer.ReloadKeys(), Apply: renderer.Reload},
+	)
+
+	// Starting webhook deliveries. Queued changes are dropped on shutdown.
+	if dispatcher != nil {
+		dispatcher.Start(ctx)
+		defer func() {
+			cancel()
+			dispatcher.Wait()
+		}()
+	}
+
 	// Starting servers.
 	return startServers(ctx, cancel, logg, cfg, calendar)
 }
 
-func loadConfig(ctx context.Context, logg *logger.Logger) (config.ServiceConfig, error) {
+func loadConfig(ctx context.Context, logg *logger.Logger) (*config.Loader, config.ServiceConfig, error) {
 	loader := config.NewLoader("calendar", "Calendar service", "Calendar service for managing events and reminders",
 		defaultConfigFile, "CALENDAR")
+	loader.AddCommand(newMigrateCommand(), runMigrate)
+	loader.AddCheck("logger", logger.ValidateConfig)
+	loader.AddCheck("storage", storage.ValidateConfig)
+	loader.AddCheck("app", app.ValidateConfig)
+	loader.AddCheck("grpc", internalgrpc.ValidateConfig)
+	loader.AddCheck("http", internalhttp.ValidateConfig)
+	loader.AddCheck("templates", templates.ValidateConfig)
+	loader.AddCheck("webhooks", webhook.ValidateConfig)
 	cfg, err := loader.Load(&calendarConfig.Config{}, printVersion, os.Stdout)
 	if err != nil {
 		if errors.Is(err, config.ErrShouldStop) {
-			return nil, nil
+			return nil, nil, nil
 		}
 		logg.Error(ctx, "load config", slog.Any("err", err))
-		return nil, err
+		return nil, nil, err
 	}
 	logg.Info(ctx, "config loaded successfully")
-	return cfg, nil
+	return loader, cfg, nil
 }
 
 func initializeLogger(ctx context.Context, logg *logger.Logger, cfg config.ServiceConfig) (*logger.Logger, error) {
@@ -135,13 +177,19 @@ func initializeApp(
 	logg *logger.Logger,
 	cfg config.ServiceConfig,
 	storage storage.Storage,
+	renderer *templates.Renderer,
+	dispatcher *webhook.Dispatcher,
 ) (*app.App, error) {
 	appCfg, err := cfg.GetSubConfig("app")
 	if err != nil {
 		logg.Error(ctx, "get app config", slog.Any("err", err))
 		return nil, err
 	}
-	calendar, err := app.NewApp(logg.With(slog.String("layer", "APP")), storage, appCfg)
+	opts := []app.Option{app.WithRenderer(renderer)}
+	if dispatcher != nil {
+		opts = append(opts, app.WithNotifier(dispatcher))
+	}
+	calendar, err := app.NewApp(logg.With(slog.String("layer", "APP")), storage, appCfg, opts...)
 	if err != nil {
 		logg.Error(ctx, "create app", slog.Any("err", err))
 		return nil, err
@@ -150,6 +198,60 @@ func initializeApp(
 	return calendar, nil
 }
 
+// initializeWebhooks creates the webhook dispatcher. Returns nil if the deliveries are disabled.
+func initializeWebhooks(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+) (*webhook.Dispatcher, error) {
+	webhooksCfg, err := cfg.GetSubConfig("webhooks")
+	if err != nil {
+		logg.Error(ctx, "get webhooks config", slog.Any("err", err))
+		return nil, err
+	}
+	dispatcher, err := webhook.NewDispatcher(logg.With(slog.String("layer", "WEBHOOKS")), storage, webhooksCfg)
+	if err != nil {
+		logg.Error(ctx, "create webhook dispatcher", slog.Any("err", err))
+		return nil, err
+	}
+	if !dispatcher.Enabled() {
+		logg.Info(ctx, "webhook deliveries are disabled")
+		return nil, nil
+	}
+	logg.Info(ctx, "webhook dispatcher created successfully")
+	return dispatcher, nil
+}
+
+func initializeTemplates(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+) (*templates.Renderer, error) {
+	tmplCfg, err := cfg.GetSubConfig("templates")
+	if err != nil {
+		logg.Error(ctx, "get templates config", slog.Any("err", err))
+		return nil, err
+	}
+	store, err := templates.NewStore(tmplCfg, storage)
+	if err != nil {
+		logg.Error(ctx, "create templates store", slog.Any("err", err))
+		return nil, err
+	}
+	renderer, err := templates.NewRenderer(store, tmplCfg)
+	if err != nil {
+		logg.Error(ctx, "create templates renderer", slog.Any("err", err))
+		return nil, err
+	}
+	if err := renderer.Load(ctx); err != nil {
+		logg.Error(ctx, "load notification templates", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "notification templates loaded", slog.Any("channels", renderer.Channels()))
+	return renderer, nil
+}
+
 func startServers(
 	ctx context.Context,
 	cancel context.CancelFunc,
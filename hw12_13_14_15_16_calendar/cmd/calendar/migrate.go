@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"              //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                    //nolint:depguard
+	"github.com/spf13/cobra"                                                               //nolint:depguard
+)
+
+// newMigrateCommand returns the command managing the storage migrations.
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "migrate up|down|status|redo",
+		Short:     "Manage storage migrations",
+		Long:      "Apply, roll back, redo or show the status of the embedded storage migrations",
+		ValidArgs: []string{"up", "down", "status", "redo"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	}
+	cmd.Flags().Bool("seed", false, "Run the command on the test data migrations instead of the schema ones")
+	return cmd
+}
+
+// runMigrate executes the migration command on the configured storage and prints its report.
+func runMigrate(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	seed, err := cmd.Flags().GetBool("seed")
+	if err != nil {
+		return fmt.Errorf("get seed flag: %w", err)
+	}
+
+	storageCfg, err := cfg.GetSubConfig("storage")
+	if err != nil {
+		return fmt.Errorf("get storage config: %w", err)
+	}
+	s, err := storage.NewStorage(storageCfg)
+	if err != nil {
+		return fmt.Errorf("create storage: %w", err)
+	}
+	defer s.Close(ctx)
+
+	migrator, ok := s.(storage.Migrator)
+	if !ok {
+		return fmt.Errorf("%w: %v", projectErrors.ErrMigrationUnsupported, storageCfg["type"])
+	}
+
+	report, err := migrator.Migrate(ctx, args[0], seed)
+	fmt.Fprint(cmd.OutOrStdout(), report)
+	return err
+}
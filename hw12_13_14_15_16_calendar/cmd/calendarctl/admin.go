@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard
+)
+
+// newCleanupCommand returns the command deleting the old events.
+func newCleanupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Delete old events",
+		Long:  "Delete the events starting before the given time, a year ago by default, as the scheduler does",
+		Args:  cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	cmd.Flags().String("before", "", "Events starting before the given time are deleted")
+	return cmd
+}
+
+// runCleanup triggers the cleanup and prints the number of deleted events.
+func runCleanup(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	req := &pb.CleanupEventsRequest{}
+	if value, _ := cmd.Flags().GetString("before"); value != "" {
+		before, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		req.Before = timestamppb.New(before)
+	}
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.CleanupEvents(reqCtx, req)
+	if err != nil {
+		return fmt.Errorf("cleanup events: %w", err)
+	}
+	return printRecord(cmd.OutOrStdout(), c.output, []string{"deleted"}, map[string]any{"deleted": resp.Deleted})
+}
+
+// newStatusCommand returns the command showing the scheduler status.
+func newStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show scheduler status",
+		Long:  "Show the number of the due reminders, not sent yet, and the time of the next reminder",
+		Args:  cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	return cmd
+}
+
+// runStatus prints the state of the notification queue.
+func runStatus(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.GetSchedulerStatus(reqCtx, &pb.GetSchedulerStatusRequest{})
+	if err != nil {
+		return fmt.Errorf("get scheduler status: %w", err)
+	}
+
+	var nextReminder any
+	if resp.NextReminder != nil {
+		nextReminder = resp.NextReminder.AsTime().Local().Format(time.RFC3339)
+	}
+	keys := []string{"due_notifications", "next_reminder"}
+	return printRecord(cmd.OutOrStdout(), c.output, keys, map[string]any{
+		"due_notifications": resp.DueNotifications,
+		"next_reminder":     nextReminder,
+	})
+}
+
+// newCacheCommand returns the command showing the state of the storage cache of the calendar.
+func newCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Show storage cache stats",
+		Long:  "Show whether the storage cache of the calendar is enabled, its hit/miss counters, size and TTL",
+		Args:  cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	return cmd
+}
+
+// runCache prints the state of the storage cache.
+func runCache(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.GetCacheStats(reqCtx, &pb.GetCacheStatsRequest{})
+	if err != nil {
+		return fmt.Errorf("get cache stats: %w", err)
+	}
+
+	keys := []string{"enabled", "hits", "misses", "events", "periods", "size", "ttl"}
+	return printRecord(cmd.OutOrStdout(), c.output, keys, map[string]any{
+		"enabled": resp.Enabled,
+		"hits":    resp.Hits,
+		"misses":  resp.Misses,
+		"events":  resp.Events,
+		"periods": resp.Periods,
+		"size":    resp.Size,
+		"ttl":     resp.Ttl.AsDuration().String(),
+	})
+}
+
+// newPreviewCommand returns the command rendering the notification of the event.
+func newPreviewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preview ID",
+		Short: "Preview event notification",
+		Long: "Render the notification of the event with the channel template without sending it. " +
+			"The locale and the time zone of the event owner are used unless overridden",
+		Args: cobra.ExactArgs(1),
+	}
+	addClientFlags(cmd)
+	cmd.Flags().String("channel", "log", "Notification channel")
+	cmd.Flags().String("locale", "", "Locale overriding the user settings, e.g. ru")
+	cmd.Flags().String("time-zone", "", "IANA time zone overriding the user settings, e.g. Europe/Moscow")
+	return cmd
+}
+
+// runPreview renders the notification and prints it.
+func runPreview(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	req := &pb.PreviewNotificationRequest{EventId: args[0]}
+	req.Channel, _ = cmd.Flags().GetString("channel")
+	req.Locale, _ = cmd.Flags().GetString("locale")
+	req.TimeZone, _ = cmd.Flags().GetString("time-zone")
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.PreviewNotification(reqCtx, req)
+	if err != nil {
+		return fmt.Errorf("preview notification: %w", err)
+	}
+	keys := []string{"channel", "locale", "time_zone", "format", "body"}
+	return printRecord(cmd.OutOrStdout(), c.output, keys, map[string]any{
+		"channel":   resp.Channel,
+		"locale":    resp.Locale,
+		"time_zone": resp.TimeZone,
+		"format":    resp.Format,
+		"body":      resp.Body,
+	})
+}
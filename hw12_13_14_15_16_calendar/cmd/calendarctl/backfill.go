@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+	"google.golang.org/protobuf/types/known/durationpb"                         //nolint:depguard
+	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard
+)
+
+// missedReminderView is a representation of a missed reminder for the output.
+//
+//nolint:tagliatelle
+type missedReminderView struct {
+	EventID  string    `json:"event_id"  yaml:"event_id"`
+	Title    string    `json:"title"     yaml:"title"`
+	UserID   string    `json:"user_id"   yaml:"user_id"`
+	Start    time.Time `json:"start"     yaml:"start"`
+	RemindAt time.Time `json:"remind_at" yaml:"remind_at"`
+	Delay    string    `json:"delay"     yaml:"delay"`
+	Notified bool      `json:"notified"  yaml:"notified"`
+	Outcome  string    `json:"outcome"   yaml:"outcome"`
+}
+
+// newBackfillCommand returns the command reporting the reminders missed during the scheduler outage.
+func newBackfillCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Report reminders missed during an outage",
+		Long: "Report the reminders, which were due during the scheduler outage, and their outcome " +
+			"under the catch-up policy once the scheduler is back: delayed, late or skipped",
+		Args: cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	cmd.Flags().String("from", "", "Outage start")
+	cmd.Flags().String("to", "", "Outage end, now by default")
+	cmd.Flags().String("policy", "", "Catch-up policy of the scheduler: always, skip or grace. always by default")
+	cmd.Flags().Duration("grace", 0, "Grace window of the grace policy")
+	return cmd
+}
+
+// runBackfill requests the report and prints it.
+func runBackfill(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	flags := cmd.Flags()
+	value, _ := flags.GetString("from")
+	if value == "" {
+		return errors.New("outage start is not set, use --from")
+	}
+	from, err := parseTime(value)
+	if err != nil {
+		return err
+	}
+	to := time.Now()
+	if value, _ := flags.GetString("to"); value != "" {
+		if to, err = parseTime(value); err != nil {
+			return err
+		}
+	}
+	req := &pb.GetMissedRemindersRequest{
+		OutageStart: timestamppb.New(from),
+		OutageEnd:   timestamppb.New(to),
+	}
+	req.CatchUpPolicy, _ = flags.GetString("policy")
+	if grace, _ := flags.GetDuration("grace"); grace > 0 {
+		req.CatchUpGrace = durationpb.New(grace)
+	}
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.GetMissedReminders(reqCtx, req)
+	if err != nil {
+		return fmt.Errorf("get missed reminders: %w", err)
+	}
+	return printMissedReminders(cmd.OutOrStdout(), c.output, resp.Reminders)
+}
+
+// printMissedReminders prints the missed reminders in the given format.
+func printMissedReminders(w io.Writer, format outputFormat, reminders []*pb.MissedReminder) error {
+	views := make([]missedReminderView, len(reminders))
+	for i, reminder := range reminders {
+		views[i] = missedReminderView{
+			EventID:  reminder.EventId,
+			Title:    reminder.Title,
+			UserID:   reminder.UserId,
+			Start:    reminder.Start.AsTime().Local(),
+			RemindAt: reminder.RemindAt.AsTime().Local(),
+			Delay:    reminder.Delay.AsDuration().String(),
+			Notified: reminder.Notified,
+			Outcome:  reminder.Outcome,
+		}
+	}
+
+	if format != outputTable {
+		return printValue(w, format, views)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "EVENT ID\tTITLE\tUSER\tSTART\tREMIND AT\tDELAY\tNOTIFIED\tOUTCOME")
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n", v.EventID, v.Title, v.UserID,
+			v.Start.Format(time.RFC3339), v.RemindAt.Format(time.RFC3339), v.Delay, v.Notified, v.Outcome)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+	"google.golang.org/grpc"                                                    //nolint:depguard
+	"google.golang.org/grpc/credentials/insecure"                               //nolint:depguard
+	"google.golang.org/grpc/metadata"                                           //nolint:depguard
+)
+
+// Metadata keys the calendar service reads the user ID and the admin token from.
+const (
+	userIDMetadataKey     = "x-user-id"
+	adminTokenMetadataKey = "x-admin-token"
+)
+
+// client is a gRPC client of the calendar service with the settings of the current command.
+type client struct {
+	conn       *grpc.ClientConn
+	api        pb.CalendarServiceClient
+	timeout    time.Duration
+	userID     string
+	adminToken string
+	output     outputFormat
+}
+
+// addClientFlags declares the flags overriding the client config section.
+func addClientFlags(cmd *cobra.Command) {
+	cmd.Flags().String("address", "", "Calendar gRPC endpoint in host:port format")
+	cmd.Flags().StringP("user", "u", "", "User ID sent as x-user-id metadata")
+	cmd.Flags().StringP("output", "o", "", "Output format: table, json or yaml")
+	cmd.Flags().String("admin-token", "", "Admin token sent as x-admin-token metadata, required by the admin commands")
+}
+
+// newClient creates a client from the client config section and the command flags.
+// Flags take precedence over the config.
+func newClient(cmd *cobra.Command, cfg config.ServiceConfig) (*client, error) {
+	clientCfg, err := cfg.GetSubConfig("client")
+	if err != nil {
+		return nil, fmt.Errorf("get client config: %w", err)
+	}
+
+	address, _ := clientCfg["address"].(string)
+	timeout, _ := clientCfg["timeout"].(time.Duration)
+	userID, _ := clientCfg["user_id"].(string)
+	output, _ := clientCfg["output"].(string)
+	adminToken, _ := clientCfg["admin_token"].(string)
+
+	flags := map[string]*string{"address": &address, "user": &userID, "output": &output, "admin-token": &adminToken}
+	for flag, value := range flags {
+		if cmd.Flags().Changed(flag) {
+			if *value, err = cmd.Flags().GetString(flag); err != nil {
+				return nil, fmt.Errorf("get %s flag: %w", flag, err)
+			}
+		}
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("calendar address is not set")
+	}
+	format, err := parseOutputFormat(output)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("create gRPC client: %w", err)
+	}
+
+	return &client{
+		conn:       conn,
+		api:        pb.NewCalendarServiceClient(conn),
+		timeout:    max(0, timeout),
+		userID:     userID,
+		adminToken: adminToken,
+		output:     format,
+	}, nil
+}
+
+// Close closes the client connection.
+func (c *client) Close() {
+	_ = c.conn.Close()
+}
+
+// requestContext returns the context for a single request with the configured timeout, user ID and admin token.
+func (c *client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.userID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, userIDMetadataKey, c.userID)
+	}
+	if c.adminToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, adminTokenMetadataKey, c.adminToken)
+	}
+	if c.timeout > 0 {
+		return context.WithTimeout(ctx, c.timeout)
+	}
+	return context.WithCancel(ctx)
+}
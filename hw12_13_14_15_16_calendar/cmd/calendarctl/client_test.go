@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1"                    //nolint:depguard
+	ctlConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/calendarctl" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                          //nolint:depguard
+	calendarGRPC "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc"     //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc/mocks"            //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                            //nolint:depguard
+	"github.com/spf13/cobra"                                                                       //nolint:depguard
+	"github.com/stretchr/testify/mock"                                                             //nolint:depguard
+	"github.com/stretchr/testify/require"                                                          //nolint:depguard
+	"google.golang.org/grpc"                                                                       //nolint:depguard
+	"google.golang.org/grpc/metadata"                                                              //nolint:depguard
+)
+
+// testServer is a calendar gRPC server, backed by the application mock.
+type testServer struct {
+	app     *mocks.Application
+	address string
+
+	mu       sync.Mutex
+	metadata []metadata.MD
+}
+
+// startTestServer starts the calendar gRPC server on a random local port.
+// The server records the incoming metadata of every request.
+func startTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	logger := &mocks.Logger{}
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	ts := &testServer{app: &mocks.Application{}}
+	server, err := calendarGRPC.NewServer(logger, ts.app, map[string]any{
+		"host":             "127.0.0.1",
+		"port":             "0",
+		"shutdown_timeout": time.Second,
+	})
+	require.NoError(t, err, "error on server creation")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "error on listener creation")
+	ts.address = listener.Addr().String()
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(ts.recordMetadata))
+	pb.RegisterCalendarServiceServer(grpcServer, server)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			t.Logf("server stopped: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		grpcServer.Stop()
+		ts.app.AssertExpectations(t)
+	})
+	return ts
+}
+
+func (ts *testServer) recordMetadata(
+	ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ts.mu.Lock()
+	ts.metadata = append(ts.metadata, md)
+	ts.mu.Unlock()
+	return handler(ctx, req)
+}
+
+// lastMetadata returns the metadata of the last request.
+func (ts *testServer) lastMetadata(t *testing.T) metadata.MD {
+	t.Helper()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	require.NotEmpty(t, ts.metadata, "no requests received")
+	return ts.metadata[len(ts.metadata)-1]
+}
+
+// commandResult is the output of the executed command.
+type commandResult struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+// executeCommand executes the command with the given config, stdin and arguments.
+// The config loading of the loader is bypassed, so the command gets the config as is.
+func executeCommand(
+	cmd *cobra.Command,
+	run func(*cobra.Command, []string, config.ServiceConfig) error,
+	cfg *ctlConfig.Config,
+	stdin io.Reader,
+	args ...string,
+) commandResult {
+	var stdout, stderr bytes.Buffer
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return run(cmd, args, cfg)
+	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+	cmd.SetIn(stdin)
+	cmd.SetArgs(append([]string{}, args...))
+
+	err := cmd.Execute()
+	return commandResult{stdout: stdout.String(), stderr: stderr.String(), err: err}
+}
+
+func TestNewClient(t *testing.T) {
+	cfg := ctlConfig.ClientConf{
+		Address:    "calendar:9090",
+		Timeout:    5 * time.Second,
+		UserID:     "config-user",
+		Output:     "json",
+		AdminToken: "config-token",
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      ctlConfig.ClientConf
+		args     []string
+		expected client
+		address  string
+		isError  bool
+	}{
+		{
+			name:     "config only",
+			cfg:      cfg,
+			address:  "calendar:9090",
+			expected: client{timeout: 5 * time.Second, userID: "config-user", adminToken: "config-token", output: outputJSON},
+		},
+		{
+			name: "flags override config",
+			cfg:  cfg,
+			args: []string{
+				"--address", "localhost:9091", "--user", "flag-user", "-o", "YAML", "--admin-token", "flag-token",
+			},
+			address:  "localhost:9091",
+			expected: client{timeout: 5 * time.Second, userID: "flag-user", adminToken: "flag-token", output: outputYAML},
+		},
+		{
+			name:     "empty flags override config",
+			cfg:      cfg,
+			args:     []string{"--user", "", "--admin-token", "", "--output", ""},
+			address:  "calendar:9090",
+			expected: client{timeout: 5 * time.Second, output: outputTable},
+		},
+		{
+			name:     "flags only",
+			args:     []string{"--address", "localhost:9091", "-u", "flag-user"},
+			address:  "localhost:9091",
+			expected: client{userID: "flag-user", output: outputTable},
+		},
+		{
+			name:     "negative timeout",
+			cfg:      ctlConfig.ClientConf{Address: "calendar:9090", Timeout: -time.Second},
+			address:  "calendar:9090",
+			expected: client{output: outputTable},
+		},
+		{name: "missing address", cfg: ctlConfig.ClientConf{UserID: "config-user"}, isError: true},
+		{name: "empty address flag", cfg: cfg, args: []string{"--address", ""}, isError: true},
+		{name: "invalid output", cfg: cfg, args: []string{"-o", "xml"}, isError: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addClientFlags(cmd)
+			require.NoError(t, cmd.Flags().Parse(tC.args), "expected nil, got error")
+
+			c, err := newClient(cmd, &ctlConfig.Config{Client: tC.cfg})
+			if tC.isError {
+				require.Error(t, err, "expected error, got nil")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+			defer c.Close()
+
+			require.Equal(t, tC.address, c.conn.Target(), "unexpected address")
+			require.Equal(t, tC.expected.timeout, c.timeout, "unexpected timeout")
+			require.Equal(t, tC.expected.userID, c.userID, "unexpected user ID")
+			require.Equal(t, tC.expected.adminToken, c.adminToken, "unexpected admin token")
+			require.Equal(t, tC.expected.output, c.output, "unexpected output format")
+		})
+	}
+}
+
+func TestRequestMetadata(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cfg        ctlConfig.ClientConf
+		args       []string
+		userID     []string
+		adminToken []string
+	}{
+		{name: "no metadata"},
+		{
+			name:       "config",
+			cfg:        ctlConfig.ClientConf{UserID: "config-user", AdminToken: "config-token"},
+			userID:     []string{"config-user"},
+			adminToken: []string{"config-token"},
+		},
+		{
+			name:       "flags",
+			cfg:        ctlConfig.ClientConf{UserID: "config-user", AdminToken: "config-token"},
+			args:       []string{"-u", "flag-user", "--admin-token", "flag-token"},
+			userID:     []string{"flag-user"},
+			adminToken: []string{"flag-token"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			ts := startTestServer(t)
+			ts.app.On("GetSchedulerStatus", mock.Anything).Return(&dto.SchedulerStatus{}, nil).Once()
+
+			tC.cfg.Address = ts.address
+			res := executeCommand(newStatusCommand(), runStatus, &ctlConfig.Config{Client: tC.cfg}, nil, tC.args...)
+			require.NoError(t, res.err, "expected nil, got error")
+
+			md := ts.lastMetadata(t)
+			require.Equal(t, tC.userID, md.Get(userIDMetadataKey), "unexpected user ID metadata")
+			require.Equal(t, tC.adminToken, md.Get(adminTokenMetadataKey), "unexpected admin token metadata")
+		})
+	}
+}
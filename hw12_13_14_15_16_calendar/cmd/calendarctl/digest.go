@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+	"google.golang.org/grpc/codes"                                              //nolint:depguard
+	"google.golang.org/grpc/status"                                             //nolint:depguard
+)
+
+// newDigestCommand returns the command showing and updating the daily digest settings of the user.
+func newDigestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Show or update daily digest settings",
+		Long: "Show the daily agenda digest settings of the user. If any of the settings flags is set, " +
+			"the settings are updated, keeping the values of the flags which are not set",
+		Args: cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	cmd.Flags().Bool("enabled", false, "Send the daily digest")
+	cmd.Flags().String("time", "", "Local time the digest is sent at in HH:MM format")
+	cmd.Flags().String("time-zone", "", "IANA time zone of the digest time, e.g. Europe/Moscow. UTC by default")
+	return cmd
+}
+
+// runDigest prints the digest settings, updating them first if requested.
+func runDigest(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if c.userID == "" {
+		return errors.New("user ID is not set")
+	}
+
+	getCtx, getCancel := c.requestContext(ctx)
+	defer getCancel()
+	resp, err := c.api.GetDigestSettings(getCtx, &pb.GetDigestSettingsRequest{UserId: c.userID})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("get digest settings: %w", err)
+	}
+	settings := resp.GetSettings()
+
+	flags := cmd.Flags()
+	if flags.Changed("enabled") || flags.Changed("time") || flags.Changed("time-zone") {
+		if settings == nil {
+			settings = &pb.DigestSettings{}
+		}
+		if flags.Changed("enabled") {
+			settings.Enabled, _ = flags.GetBool("enabled")
+		}
+		if flags.Changed("time") {
+			settings.Time, _ = flags.GetString("time")
+		}
+		if flags.Changed("time-zone") {
+			settings.TimeZone, _ = flags.GetString("time-zone")
+		}
+
+		setCtx, setCancel := c.requestContext(ctx)
+		defer setCancel()
+		setResp, err := c.api.SetDigestSettings(setCtx, &pb.SetDigestSettingsRequest{
+			UserId:   c.userID,
+			Settings: settings,
+		})
+		if err != nil {
+			return fmt.Errorf("set digest settings: %w", err)
+		}
+		settings = setResp.Settings
+	}
+	if settings == nil {
+		return fmt.Errorf("daily digest is not configured for user %q", c.userID)
+	}
+
+	keys := []string{"user_id", "enabled", "time", "time_zone", "sent_date"}
+	return printRecord(cmd.OutOrStdout(), c.output, keys, map[string]any{
+		"user_id":   c.userID,
+		"enabled":   settings.Enabled,
+		"time":      settings.Time,
+		"time_zone": settings.TimeZone,
+		"sent_date": settings.SentDate,
+	})
+}
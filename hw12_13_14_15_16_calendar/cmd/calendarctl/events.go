@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+	"google.golang.org/grpc/codes"                                              //nolint:depguard
+	"google.golang.org/grpc/status"                                             //nolint:depguard
+	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard
+)
+
+// Accepted layouts of the time flags. Values without a time zone are treated as local time.
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", time.DateOnly}
+
+// parseTime parses the time flag value in any of the accepted layouts.
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: expected RFC3339 or YYYY-MM-DD[THH:MM] format", value)
+}
+
+// signalContext returns the context canceled on interruption.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+}
+
+// addEventFlags declares the flags setting the event fields.
+func addEventFlags(cmd *cobra.Command) {
+	cmd.Flags().String("title", "", "Event title")
+	cmd.Flags().String("start", "", "Event start time")
+	cmd.Flags().String("duration", "", "Event duration, e.g. 1h30m. Might be omitted for all-day events")
+	cmd.Flags().String("description", "", "Event description")
+	cmd.Flags().String("remind-in", "", "Time before the event start to send the reminder, e.g. 15m. 0s disables it")
+	cmd.Flags().String("busy-status", "", "One of: busy, free, tentative, out-of-office")
+	cmd.Flags().Bool("all-day", false, "Mark the event as an all-day one")
+	cmd.Flags().StringSlice("tags", nil, "Comma-separated event tags")
+	cmd.Flags().StringSlice("resources", nil, "Comma-separated IDs of the booked resources")
+	cmd.Flags().String("color", "", "Event color in #RRGGBB format")
+	cmd.Flags().String("location", "", "Event location")
+	cmd.Flags().String("url", "", "Event URL")
+	cmd.Flags().String("overlap-policy", "", "One of: reject, allow, allow-if-free. Overrides the calendar policy")
+}
+
+// applyEventFlags sets the event fields from the changed flags only, so the other fields are kept as-is.
+func applyEventFlags(cmd *cobra.Command, view *eventView) error {
+	flags := cmd.Flags()
+	for flag, field := range map[string]*string{
+		"title":       &view.Title,
+		"duration":    &view.Duration,
+		"description": &view.Description,
+		"remind-in":   &view.RemindIn,
+		"busy-status": &view.BusyStatus,
+		"color":       &view.Color,
+		"location":    &view.Location,
+		"url":         &view.URL,
+	} {
+		if !flags.Changed(flag) {
+			continue
+		}
+		value, err := flags.GetString(flag)
+		if err != nil {
+			return fmt.Errorf("get %s flag: %w", flag, err)
+		}
+		*field = value
+	}
+
+	if flags.Changed("start") {
+		value, _ := flags.GetString("start")
+		start, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		view.Datetime = start
+	}
+	if flags.Changed("all-day") {
+		view.AllDay, _ = flags.GetBool("all-day")
+	}
+	if flags.Changed("tags") {
+		view.Tags, _ = flags.GetStringSlice("tags")
+	}
+	if flags.Changed("resources") {
+		view.Resources, _ = flags.GetStringSlice("resources")
+	}
+	return nil
+}
+
+// overlapPolicy returns the overlap policy flag value or nil if it is not set.
+func overlapPolicy(cmd *cobra.Command) *string {
+	if !cmd.Flags().Changed("overlap-policy") {
+		return nil
+	}
+	value, _ := cmd.Flags().GetString("overlap-policy")
+	return &value
+}
+
+// newCreateCommand returns the command creating an event.
+func newCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an event",
+		Long:  "Create an event owned by the user set with --user or in the config",
+		Args:  cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	addEventFlags(cmd)
+	cmd.Flags().String("idempotency-key", "", "Key deduplicating the repeated create requests")
+	return cmd
+}
+
+// runCreate creates an event from the flags and prints it.
+func runCreate(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	view := eventView{UserID: c.userID}
+	if err := applyEventFlags(cmd, &view); err != nil {
+		return err
+	}
+	data, err := view.eventData()
+	if err != nil {
+		return err
+	}
+	req := &pb.CreateEventRequest{Data: data, OverlapPolicy: overlapPolicy(cmd)}
+	if key, _ := cmd.Flags().GetString("idempotency-key"); key != "" {
+		req.IdempotencyKey = &key
+	}
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.CreateEvent(reqCtx, req)
+	if err != nil {
+		return fmt.Errorf("create event: %w", err)
+	}
+	return printEvents(cmd.OutOrStdout(), c.output, []*pb.Event{resp.Event})
+}
+
+// newGetCommand returns the command printing events by their IDs.
+func newGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get ID...",
+		Short: "Show events",
+		Long:  "Show the events with the given IDs",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	addClientFlags(cmd)
+	return cmd
+}
+
+// runGet gets the events by their IDs and prints them.
+func runGet(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	events := make([]*pb.Event, 0, len(args))
+	for _, id := range args {
+		event, err := c.getEvent(ctx, id)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+	return printEvents(cmd.OutOrStdout(), c.output, events)
+}
+
+// getEvent gets the event by its ID.
+func (c *client) getEvent(ctx context.Context, id string) (*pb.Event, error) {
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+	resp, err := c.api.GetEvent(reqCtx, &pb.GetEventRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("get event %s: %w", id, err)
+	}
+	return resp.Event, nil
+}
+
+// addListFlags declares the flags selecting the events.
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().String("period", "all", "One of: all, day, week, month. all means every event of the user")
+	cmd.Flags().String("date", "", "Date within the period. Current date by default")
+	cmd.Flags().String("from", "", "Start of the custom period. Overrides --period")
+	cmd.Flags().String("to", "", "End of the custom period. Overrides --period")
+	cmd.Flags().StringSlice("tags", nil, "Events must have all of the given tags")
+	cmd.Flags().String("location", "", "Case-insensitive exact match of the event location")
+}
+
+// newListCommand returns the command listing events.
+func newListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List events",
+		Long: "List every event of the user or the events of the given day, week, month or custom period. " +
+			"Events of a period are filtered by the user only if it is set",
+		Args: cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	addListFlags(cmd)
+	return cmd
+}
+
+// runList lists the events selected by the flags and prints them.
+func runList(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	events, err := c.listEvents(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	return printEvents(cmd.OutOrStdout(), c.output, events)
+}
+
+// listEvents gets the events selected by the list flags.
+func (c *client) listEvents(ctx context.Context, cmd *cobra.Command) ([]*pb.Event, error) {
+	flags := cmd.Flags()
+	period, _ := flags.GetString("period")
+	tags, _ := flags.GetStringSlice("tags")
+	var location, userID *string
+	if flags.Changed("location") {
+		value, _ := flags.GetString("location")
+		location = &value
+	}
+	if c.userID != "" {
+		userID = &c.userID
+	}
+
+	date := time.Now()
+	if value, _ := flags.GetString("date"); value != "" {
+		var err error
+		if date, err = parseTime(value); err != nil {
+			return nil, err
+		}
+	}
+
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if flags.Changed("from") || flags.Changed("to") {
+		from, _ := flags.GetString("from")
+		to, _ := flags.GetString("to")
+		start, err := parseTime(from)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTime(to)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.api.GetEventsForPeriod(reqCtx, &pb.GetEventsForPeriodRequest{
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(end),
+			UserId:    userID,
+			Tags:      tags,
+			Location:  location,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, fmt.Errorf("list events: %w", err)
+		}
+		return resp.GetEvents(), nil
+	}
+
+	var events []*pb.Event
+	var err error
+	switch strings.ToLower(period) {
+	case "all":
+		if c.userID == "" {
+			return nil, fmt.Errorf("user is not set: use --user or a period")
+		}
+		var resp *pb.GetAllUserEventsResponse
+		resp, err = c.api.GetAllUserEvents(reqCtx, &pb.GetAllUserEventsRequest{UserId: c.userID})
+		events = resp.GetEvents()
+	case "day":
+		var resp *pb.GetEventsForDayResponse
+		resp, err = c.api.GetEventsForDay(reqCtx, &pb.GetEventsForDayRequest{
+			Date: timestamppb.New(date), UserId: userID, Tags: tags, Location: location,
+		})
+		events = resp.GetEvents()
+	case "week":
+		var resp *pb.GetEventsForWeekResponse
+		resp, err = c.api.GetEventsForWeek(reqCtx, &pb.GetEventsForWeekRequest{
+			Date: timestamppb.New(date), UserId: userID, Tags: tags, Location: location,
+		})
+		events = resp.GetEvents()
+	case "month":
+		var resp *pb.GetEventsForMonthResponse
+		resp, err = c.api.GetEventsForMonth(reqCtx, &pb.GetEventsForMonthRequest{
+			Date: timestamppb.New(date), UserId: userID, Tags: tags, Location: location,
+		})
+		events = resp.GetEvents()
+	default:
+		return nil, fmt.Errorf("unsupported period %q: expected all, day, week or month", period)
+	}
+	// The API reports the empty selection as NotFound, which is a valid result for the listing.
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	return events, nil
+}
+
+// newUpdateCommand returns the command updating an event.
+func newUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update an event",
+		Long:  "Update the given fields of the event, keeping the other ones as-is",
+		Args:  cobra.ExactArgs(1),
+	}
+	addClientFlags(cmd)
+	addEventFlags(cmd)
+	return cmd
+}
+
+// runUpdate applies the flags to the current event state and saves the result.
+func runUpdate(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// The API replaces the whole event, so the missing fields are taken from the current state.
+	event, err := c.getEvent(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	view := newEventView(event)
+	if err := applyEventFlags(cmd, &view); err != nil {
+		return err
+	}
+	data, err := view.eventData()
+	if err != nil {
+		return err
+	}
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	resp, err := c.api.UpdateEvent(reqCtx, &pb.UpdateEventRequest{
+		Id:            args[0],
+		Data:          data,
+		OverlapPolicy: overlapPolicy(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	return printEvents(cmd.OutOrStdout(), c.output, []*pb.Event{resp.Event})
+}
+
+// newDeleteCommand returns the command deleting events.
+func newDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete ID...",
+		Short: "Delete events",
+		Long:  "Delete the events with the given IDs",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	addClientFlags(cmd)
+	return cmd
+}
+
+// runDelete deletes the events by their IDs, stopping on the first failure.
+func runDelete(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	for _, id := range args {
+		reqCtx, reqCancel := c.requestContext(ctx)
+		_, err := c.api.DeleteEvent(reqCtx, &pb.DeleteEventRequest{Id: id})
+		reqCancel()
+		if err != nil {
+			return fmt.Errorf("delete event %s: %w", id, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "deleted %s\n", id)
+	}
+	return nil
+}
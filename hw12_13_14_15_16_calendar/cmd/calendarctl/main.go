@@ -0,0 +1,62 @@
+// Package main contains entrypoint for the calendar admin CLI.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	ctlConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/calendarctl" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                            //nolint:depguard
+)
+
+const (
+	exitCodeSuccess = 0
+	exitCodeError   = 1
+)
+
+var defaultConfigFile = "../../configs/calendarctl/config.toml"
+
+func main() {
+	if err := run(); err != nil {
+		os.Exit(exitCodeError)
+	}
+	os.Exit(exitCodeSuccess)
+}
+
+func run() error {
+	loader := config.NewLoader(
+		"calendarctl",
+		"Calendar admin CLI",
+		"Command line client managing the calendar events and the service state via the gRPC API",
+		defaultConfigFile,
+		"CALENDAR",
+	)
+	loader.AddCommand(newCreateCommand(), runCreate)
+	loader.AddCommand(newGetCommand(), runGet)
+	loader.AddCommand(newListCommand(), runList)
+	loader.AddCommand(newUpdateCommand(), runUpdate)
+	loader.AddCommand(newDeleteCommand(), runDelete)
+	loader.AddCommand(newImportCommand(), runImport)
+	loader.AddCommand(newExportCommand(), runExport)
+	loader.AddCommand(newCleanupCommand(), runCleanup)
+	loader.AddCommand(newStatusCommand(), runStatus)
+	loader.AddCommand(newCacheCommand(), runCache)
+	loader.AddCommand(newBackfillCommand(), runBackfill)
+	loader.AddCommand(newPreviewCommand(), runPreview)
+	loader.AddCommand(newDigestCommand(), runDigest)
+	loader.AddCommand(newWebhooksCommand(), runWebhooks)
+	loader.AddCommand(newResourcesCommand(), runResources)
+
+	// Command errors are already printed by cobra.
+	if _, err := loader.Load(&ctlConfig.Config{}, printVersion, os.Stdout); err != nil {
+		if errors.Is(err, config.ErrShouldStop) {
+			return nil
+		}
+		return err
+	}
+
+	// The root command itself does nothing.
+	fmt.Fprintln(os.Stderr, "Error: no command specified, see calendarctl --help")
+	return errors.New("no command specified")
+}
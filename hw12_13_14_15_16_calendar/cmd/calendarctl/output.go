@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"google.golang.org/protobuf/types/known/durationpb"                         //nolint:depguard
+	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard
+	"gopkg.in/yaml.v3"                                                          //nolint:depguard
+)
+
+// outputFormat is a format of the command results.
+type outputFormat string
+
+// Supported output formats.
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+	outputYAML  outputFormat = "yaml"
+)
+
+// parseOutputFormat parses the output format. Empty value means table.
+func parseOutputFormat(value string) (outputFormat, error) {
+	switch format := outputFormat(strings.ToLower(value)); format {
+	case "":
+		return outputTable, nil
+	case outputTable, outputJSON, outputYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q: expected table, json or yaml", value)
+	}
+}
+
+// eventView is a representation of an event for the output and the import/export files.
+// Durations are kept in Go format, e.g. "1h30m", to be readable and editable.
+//
+//nolint:tagliatelle
+type eventView struct {
+	ID          string    `json:"id,omitempty"          yaml:"id,omitempty"`
+	Title       string    `json:"title"                 yaml:"title"`
+	Datetime    time.Time `json:"datetime"              yaml:"datetime"`
+	Duration    string    `json:"duration"              yaml:"duration"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	UserID      string    `json:"user_id"               yaml:"user_id"`
+	RemindIn    string    `json:"remind_in,omitempty"   yaml:"remind_in,omitempty"`
+	BusyStatus  string    `json:"busy_status,omitempty" yaml:"busy_status,omitempty"`
+	AllDay      bool      `json:"all_day,omitempty"     yaml:"all_day,omitempty"`
+	Tags        []string  `json:"tags,omitempty"        yaml:"tags,omitempty"`
+	Resources   []string  `json:"resources,omitempty"   yaml:"resources,omitempty"`
+	Color       string    `json:"color,omitempty"       yaml:"color,omitempty"`
+	Location    string    `json:"location,omitempty"    yaml:"location,omitempty"`
+	URL         string    `json:"url,omitempty"         yaml:"url,omitempty"`
+}
+
+// newEventView converts the protobuf event to its view.
+func newEventView(event *pb.Event) eventView {
+	data := event.GetData()
+	view := eventView{
+		ID:          event.GetId(),
+		Title:       data.GetTitle(),
+		Datetime:    data.GetDatetime().AsTime().Local(),
+		Duration:    data.GetDuration().AsDuration().String(),
+		Description: data.GetDescription(),
+		UserID:      data.GetUserId(),
+		BusyStatus:  data.GetBusyStatus(),
+		AllDay:      data.GetAllDay(),
+		Tags:        data.GetTags(),
+		Resources:   data.GetResources(),
+		Color:       data.GetColor(),
+		Location:    data.GetLocation(),
+		URL:         data.GetUrl(),
+	}
+	if data.GetRemindIn() != nil {
+		view.RemindIn = data.GetRemindIn().AsDuration().String()
+	}
+	return view
+}
+
+// eventData converts the view to the protobuf event data.
+func (v *eventView) eventData() (*pb.EventData, error) {
+	data := &pb.EventData{
+		Title:       v.Title,
+		Datetime:    timestamppb.New(v.Datetime),
+		Description: v.Description,
+		UserId:      v.UserID,
+		BusyStatus:  v.BusyStatus,
+		AllDay:      v.AllDay,
+		Tags:        v.Tags,
+		Resources:   v.Resources,
+		Color:       v.Color,
+		Location:    v.Location,
+		Url:         v.URL,
+	}
+	if v.Duration != "" {
+		duration, err := time.ParseDuration(v.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("parse duration: %w", err)
+		}
+		data.Duration = durationpb.New(duration)
+	}
+	if v.RemindIn != "" {
+		remindIn, err := time.ParseDuration(v.RemindIn)
+		if err != nil {
+			return nil, fmt.Errorf("parse remind_in: %w", err)
+		}
+		data.RemindIn = durationpb.New(remindIn)
+	}
+	return data, nil
+}
+
+// printEvents prints the events in the given format. A table contains the most significant fields only.
+func printEvents(w io.Writer, format outputFormat, events []*pb.Event) error {
+	views := make([]eventView, len(events))
+	for i, event := range events {
+		views[i] = newEventView(event)
+	}
+
+	if format != outputTable {
+		return printValue(w, format, views)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tSTART\tDURATION\tUSER\tREMIND IN\tSTATUS\tTAGS\tLOCATION")
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", v.ID, v.Title, v.Datetime.Format(time.RFC3339),
+			v.Duration, v.UserID, v.RemindIn, v.BusyStatus, strings.Join(v.Tags, ","), v.Location)
+	}
+	return tw.Flush()
+}
+
+// printValue prints an arbitrary value as JSON for the json format and as YAML otherwise.
+func printValue(w io.Writer, format outputFormat, value any) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(value); err != nil {
+			return fmt.Errorf("encode JSON output: %w", err)
+		}
+	case outputTable, outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(value); err != nil {
+			return fmt.Errorf("encode YAML output: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("encode YAML output: %w", err)
+		}
+	}
+	return nil
+}
+
+// printRecord prints the key-value record in the given format. Keys are printed in the given order.
+func printRecord(w io.Writer, format outputFormat, keys []string, record map[string]any) error {
+	if format != outputTable {
+		return printValue(w, format, record)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s\t%v\n", strings.ToUpper(key), record[key])
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	ctlConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/calendarctl" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                          //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                        //nolint:depguard
+	"github.com/google/uuid"                                                                       //nolint:depguard
+	"github.com/stretchr/testify/mock"                                                             //nolint:depguard
+	"github.com/stretchr/testify/require"                                                          //nolint:depguard
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected outputFormat
+		isError  bool
+	}{
+		{value: "", expected: outputTable},
+		{value: "table", expected: outputTable},
+		{value: "JSON", expected: outputJSON},
+		{value: "yaml", expected: outputYAML},
+		{value: "yml", isError: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.value, func(t *testing.T) {
+			format, err := parseOutputFormat(tC.value)
+			if tC.isError {
+				require.Error(t, err, "expected error, got nil")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+			require.Equal(t, tC.expected, format, "unexpected output format")
+		})
+	}
+}
+
+func TestGetOutput(t *testing.T) {
+	event := &types.Event{
+		ID: uuid.MustParse("0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e"),
+		EventData: types.EventData{
+			Title:      "Meeting",
+			Datetime:   time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+			Duration:   90 * time.Minute,
+			UserID:     "user1",
+			RemindIn:   15 * time.Minute,
+			BusyStatus: types.BusyStatusBusy,
+			Tags:       types.Tags{"work", "team"},
+			Location:   "Office",
+		},
+	}
+	// Times are printed in the local time zone of the client.
+	start := event.Datetime.Local().Format(time.RFC3339)
+
+	testCases := []struct {
+		output   string
+		expected string
+	}{
+		{
+			output: "table",
+			expected: fmt.Sprintf(
+				"ID                                    TITLE    START%s  DURATION  USER   REMIND IN  STATUS  TAGS       LOCATION\n"+
+					"0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e  Meeting  %s  1h30m0s   user1  15m0s      busy    work,team  Office\n",
+				strings.Repeat(" ", len(start)-len("START")), start,
+			),
+		},
+		{
+			output: "json",
+			expected: fmt.Sprintf(`[
+  {
+    "id": "0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e",
+    "title": "Meeting",
+    "datetime": %q,
+    "duration": "1h30m0s",
+    "user_id": "user1",
+    "remind_in": "15m0s",
+    "busy_status": "busy",
+    "tags": [
+      "work",
+      "team"
+    ],
+    "location": "Office"
+  }
+]
+`, start),
+		},
+		{
+			output: "yaml",
+			expected: fmt.Sprintf(`- id: 0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e
+  title: Meeting
+  datetime: %s
+  duration: 1h30m0s
+  user_id: user1
+  remind_in: 15m0s
+  busy_status: busy
+  tags:
+    - work
+    - team
+  location: Office
+`, start),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.output, func(t *testing.T) {
+			ts := startTestServer(t)
+			ts.app.On("GetEvent", mock.Anything, event.ID.String()).Return(event, nil).Once()
+
+			cfg := &ctlConfig.Config{Client: ctlConfig.ClientConf{Address: ts.address, Output: tC.output}}
+			res := executeCommand(newGetCommand(), runGet, cfg, nil, event.ID.String())
+			require.NoError(t, res.err, "expected nil, got error")
+			require.Equal(t, tC.expected, res.stdout, "unexpected output")
+		})
+	}
+}
+
+func TestStatusOutput(t *testing.T) {
+	nextReminder := time.Date(2025, 1, 2, 9, 45, 0, 0, time.UTC)
+	next := nextReminder.Local().Format(time.RFC3339)
+
+	testCases := []struct {
+		name     string
+		status   *dto.SchedulerStatus
+		args     []string
+		expected string
+	}{
+		{
+			name:     "table",
+			status:   &dto.SchedulerStatus{DueNotifications: 3, NextReminder: &nextReminder},
+			expected: fmt.Sprintf("DUE_NOTIFICATIONS  3\nNEXT_REMINDER      %s\n", next),
+		},
+		{
+			name:     "json",
+			status:   &dto.SchedulerStatus{DueNotifications: 3, NextReminder: &nextReminder},
+			args:     []string{"-o", "json"},
+			expected: fmt.Sprintf("{\n  \"due_notifications\": 3,\n  \"next_reminder\": %q\n}\n", next),
+		},
+		{
+			name:     "json without reminders",
+			status:   &dto.SchedulerStatus{},
+			args:     []string{"--output", "json"},
+			expected: "{\n  \"due_notifications\": 0,\n  \"next_reminder\": null\n}\n",
+		},
+		{
+			name:     "yaml",
+			status:   &dto.SchedulerStatus{DueNotifications: 3, NextReminder: &nextReminder},
+			args:     []string{"-o", "yaml"},
+			expected: fmt.Sprintf("due_notifications: 3\nnext_reminder: %q\n", next),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			ts := startTestServer(t)
+			ts.app.On("GetSchedulerStatus", mock.Anything).Return(tC.status, nil).Once()
+
+			cfg := &ctlConfig.Config{Client: ctlConfig.ClientConf{Address: ts.address}}
+			res := executeCommand(newStatusCommand(), runStatus, cfg, nil, tC.args...)
+			require.NoError(t, res.err, "expected nil, got error")
+			require.Equal(t, tC.expected, res.stdout, "unexpected output")
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard
+)
+
+// resourceView is a representation of a bookable resource for the output.
+//
+//nolint:tagliatelle
+type resourceView struct {
+	ID         string    `json:"id"         yaml:"id"`
+	Name       string    `json:"name"       yaml:"name"`
+	Kind       string    `json:"kind"       yaml:"kind"`
+	Capacity   int64     `json:"capacity"   yaml:"capacity"`
+	Attributes []string  `json:"attributes" yaml:"attributes"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// bookingView is a representation of a resource booking for the output.
+//
+//nolint:tagliatelle
+type bookingView struct {
+	EventID string    `json:"event_id" yaml:"event_id"`
+	Start   time.Time `json:"start"    yaml:"start"`
+	End     time.Time `json:"end"      yaml:"end"`
+}
+
+// availabilityView is a representation of a resource availability for the output.
+type availabilityView struct {
+	Resource  resourceView  `json:"resource"  yaml:"resource"`
+	Available bool          `json:"available" yaml:"available"`
+	Bookings  []bookingView `json:"bookings"  yaml:"bookings"`
+}
+
+// newResourcesCommand returns the command managing the bookable resources.
+func newResourcesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resources [list | add NAME | delete ID | availability]",
+		Short: "Manage bookable resources",
+		Long: "List the meeting rooms and the shared equipment, add or delete a resource, " +
+			"or show the bookings of the resources within the period",
+		Args: cobra.RangeArgs(0, 2),
+	}
+	addClientFlags(cmd)
+	cmd.Flags().String("kind", "", "Resource kind: room or equipment. room on creation, any for availability")
+	cmd.Flags().Int64("capacity", 0, "Number of seats of the resource. Minimal capacity for availability")
+	cmd.Flags().StringSlice("attributes", nil, "Resource attributes, all of them are required for availability")
+	cmd.Flags().StringSlice("ids", nil, "IDs of the resources to show the availability of. All by default")
+	cmd.Flags().String("from", "", "Availability period start, now by default")
+	cmd.Flags().String("to", "", "Availability period end, a day after the start by default")
+	return cmd
+}
+
+// runResources executes the requested resources action and prints its result.
+//
+//nolint:funlen
+func runResources(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	action := args[0]
+	// Number of the arguments of each action.
+	actionArgs := map[string]int{"list": 0, "add": 1, "delete": 1, "availability": 0}
+	n, ok := actionArgs[action]
+	if !ok {
+		return fmt.Errorf("unknown action %q: expected list, add, delete or availability", action)
+	}
+	if len(args)-1 != n {
+		return fmt.Errorf("action %q expects %d argument(s), see calendarctl resources --help", action, n)
+	}
+
+	flags := cmd.Flags()
+	kind, _ := flags.GetString("kind")
+	capacity, _ := flags.GetInt64("capacity")
+	attributes, _ := flags.GetStringSlice("attributes")
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	w := cmd.OutOrStdout()
+
+	switch action {
+	case "list":
+		resp, err := c.api.ListResources(reqCtx, &pb.ListResourcesRequest{})
+		if err != nil {
+			return fmt.Errorf("list resources: %w", err)
+		}
+		return printResources(w, c.output, resp.Resources)
+	case "add":
+		resp, err := c.api.CreateResource(reqCtx, &pb.CreateResourceRequest{
+			Name:       args[1],
+			Kind:       kind,
+			Capacity:   capacity,
+			Attributes: attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("create resource: %w", err)
+		}
+		return printResources(w, c.output, []*pb.Resource{resp.Resource})
+	case "delete":
+		if _, err := c.api.DeleteResource(reqCtx, &pb.DeleteResourceRequest{Id: args[1]}); err != nil {
+			return fmt.Errorf("delete resource: %w", err)
+		}
+		return printRecord(w, c.output, []string{"deleted"}, map[string]any{"deleted": args[1]})
+	default: // The action is validated above, so it is "availability".
+		from, to, err := availabilityPeriod(cmd)
+		if err != nil {
+			return err
+		}
+		ids, _ := flags.GetStringSlice("ids")
+		resp, err := c.api.GetResourceAvailability(reqCtx, &pb.GetResourceAvailabilityRequest{
+			DateStart:   timestamppb.New(from),
+			DateEnd:     timestamppb.New(to),
+			ResourceIds: ids,
+			Kind:        kind,
+			MinCapacity: capacity,
+			Attributes:  attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("get resource availability: %w", err)
+		}
+		return printAvailability(w, c.output, resp.Availability)
+	}
+}
+
+// availabilityPeriod returns the availability period from the flags.
+// The period starts now and lasts a day by default.
+func availabilityPeriod(cmd *cobra.Command) (time.Time, time.Time, error) {
+	from := time.Now()
+	if value, _ := cmd.Flags().GetString("from"); value != "" {
+		var err error
+		if from, err = parseTime(value); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	to := from.Add(24 * time.Hour)
+	if value, _ := cmd.Flags().GetString("to"); value != "" {
+		var err error
+		if to, err = parseTime(value); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return from, to, nil
+}
+
+// newResourceView converts the protobuf resource to its view.
+func newResourceView(resource *pb.Resource) resourceView {
+	return resourceView{
+		ID:         resource.GetId(),
+		Name:       resource.GetName(),
+		Kind:       resource.GetKind(),
+		Capacity:   resource.GetCapacity(),
+		Attributes: resource.GetAttributes(),
+		CreatedAt:  resource.GetCreatedAt().AsTime().Local(),
+	}
+}
+
+// printResources prints the bookable resources in the given format.
+func printResources(w io.Writer, format outputFormat, resources []*pb.Resource) error {
+	views := make([]resourceView, len(resources))
+	for i, resource := range resources {
+		views[i] = newResourceView(resource)
+	}
+
+	if format != outputTable {
+		return printValue(w, format, views)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tKIND\tCAPACITY\tATTRIBUTES\tCREATED")
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", v.ID, v.Name, v.Kind, v.Capacity,
+			strings.Join(v.Attributes, ","), v.CreatedAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// printAvailability prints the availability of the resources in the given format.
+// A table contains a row per booking, so the available resources take a single row each.
+func printAvailability(w io.Writer, format outputFormat, availability []*pb.ResourceAvailability) error {
+	views := make([]availabilityView, len(availability))
+	for i, item := range availability {
+		views[i] = availabilityView{
+			Resource:  newResourceView(item.GetResource()),
+			Available: item.GetAvailable(),
+			Bookings:  make([]bookingView, len(item.GetBookings())),
+		}
+		for j, booking := range item.GetBookings() {
+			views[i].Bookings[j] = bookingView{
+				EventID: booking.GetEventId(),
+				Start:   booking.GetStart().AsTime().Local(),
+				End:     booking.GetEnd().AsTime().Local(),
+			}
+		}
+	}
+
+	if format != outputTable {
+		return printValue(w, format, views)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tKIND\tCAPACITY\tAVAILABLE\tBOOKED FROM\tBOOKED TO\tEVENT ID")
+	for _, v := range views {
+		r := v.Resource
+		if len(v.Bookings) == 0 {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%t\t\t\t\n", r.ID, r.Name, r.Kind, r.Capacity, v.Available)
+			continue
+		}
+		for _, b := range v.Bookings {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%t\t%s\t%s\t%s\n", r.ID, r.Name, r.Kind, r.Capacity, v.Available,
+				b.Start.Format(time.RFC3339), b.End.Format(time.RFC3339), b.EventID)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+)
+
+// importKeyPrefix prefixes the IDs of the imported events to build their idempotency keys.
+const importKeyPrefix = "import:"
+
+// newExportCommand returns the command exporting events to a JSON file.
+func newExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export events to JSON",
+		Long:  "Export the events, selected the same way as for the list command, as a JSON array",
+		Args:  cobra.NoArgs,
+	}
+	addClientFlags(cmd)
+	addListFlags(cmd)
+	cmd.Flags().StringP("file", "f", "", "Output file. Stdout by default")
+	return cmd
+}
+
+// runExport writes the selected events to the file or stdout.
+func runExport(cmd *cobra.Command, _ []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	events, err := c.listEvents(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	views := make([]eventView, len(events))
+	for i, event := range events {
+		views[i] = newEventView(event)
+	}
+
+	path, _ := cmd.Flags().GetString("file")
+	if path == "" {
+		return printValue(cmd.OutOrStdout(), outputJSON, views)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	if err := printValue(f, outputJSON, views); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close export file: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "exported %d events to %s\n", len(views), path)
+	return nil
+}
+
+// newImportCommand returns the command importing events from a JSON file.
+func newImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import events from JSON",
+		Long: "Create the events from a JSON array in the export format. Use - to read from stdin. " +
+			"Events with IDs are created with idempotency keys, so the repeated import within the idempotency TTL " +
+			"does not duplicate them. Events without a user are owned by the user set with --user or in the config",
+		Args: cobra.ExactArgs(1),
+	}
+	addClientFlags(cmd)
+	cmd.Flags().String("overlap-policy", "", "One of: reject, allow, allow-if-free. Overrides the calendar policy")
+	return cmd
+}
+
+// runImport creates the events from the file, stopping on the first failure, and prints the created ones.
+func runImport(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	views, err := readEventViews(cmd.InOrStdin(), args[0])
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	created := make([]*pb.Event, 0, len(views))
+	for i := range views {
+		if views[i].UserID == "" {
+			views[i].UserID = c.userID
+		}
+		data, err := views[i].eventData()
+		if err != nil {
+			return fmt.Errorf("event #%d: %w", i+1, err)
+		}
+		req := &pb.CreateEventRequest{Data: data, OverlapPolicy: overlapPolicy(cmd)}
+		if views[i].ID != "" {
+			key := importKeyPrefix + views[i].ID
+			req.IdempotencyKey = &key
+		}
+
+		reqCtx, reqCancel := c.requestContext(ctx)
+		resp, err := c.api.CreateEvent(reqCtx, req)
+		reqCancel()
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "imported %d of %d events\n", len(created), len(views))
+			return fmt.Errorf("import event #%d: %w", i+1, err)
+		}
+		created = append(created, resp.Event)
+	}
+
+	return printEvents(cmd.OutOrStdout(), c.output, created)
+}
+
+// readEventViews reads the JSON array of events from the file or stdin if the path is "-".
+func readEventViews(stdin io.Reader, path string) ([]eventView, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open import file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var views []eventView
+	if err := json.NewDecoder(r).Decode(&views); err != nil {
+		return nil, fmt.Errorf("decode import file: %w", err)
+	}
+	return views, nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ctlConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/calendarctl" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                          //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                        //nolint:depguard
+	"github.com/google/uuid"                                                                       //nolint:depguard
+	"github.com/stretchr/testify/mock"                                                             //nolint:depguard
+	"github.com/stretchr/testify/require"                                                          //nolint:depguard
+)
+
+const importData = `[
+  {
+    "id": "0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e",
+    "title": "First",
+    "datetime": "2025-01-02T10:00:00Z",
+    "duration": "1h"
+  },
+  {
+    "title": "Second",
+    "datetime": "2025-01-03T10:00:00Z",
+    "duration": "30m",
+    "user_id": "owner",
+    "remind_in": "15m"
+  }
+]`
+
+func TestReadEventViews(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "events.json")
+	require.NoError(t, os.WriteFile(validPath, []byte(importData), 0o600), "expected nil, got error")
+	invalidPath := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidPath, []byte(`{"title": "First"}`), 0o600), "expected nil, got error")
+
+	testCases := []struct {
+		name    string
+		path    string
+		stdin   string
+		titles  []string
+		isError bool
+	}{
+		{name: "file", path: validPath, stdin: "[]", titles: []string{"First", "Second"}},
+		{name: "stdin", path: "-", stdin: importData, titles: []string{"First", "Second"}},
+		{name: "empty array", path: "-", stdin: "[]", titles: []string{}},
+		{name: "not an array", path: invalidPath, isError: true},
+		{name: "invalid stdin", path: "-", stdin: "[{", isError: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), isError: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			views, err := readEventViews(strings.NewReader(tC.stdin), tC.path)
+			if tC.isError {
+				require.Error(t, err, "expected error, got nil")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+
+			titles := make([]string, len(views))
+			for i, view := range views {
+				titles[i] = view.Title
+			}
+			require.Equal(t, tC.titles, titles, "unexpected events")
+		})
+	}
+
+	views, err := readEventViews(nil, validPath)
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, eventView{
+		ID:       "0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e",
+		Title:    "First",
+		Datetime: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+		Duration: "1h",
+	}, views[0], "unexpected event view")
+}
+
+// importRecorder records the create requests of the import and replies with the created events.
+type importRecorder struct {
+	mu     sync.Mutex
+	inputs []dto.CreateEventInput
+}
+
+func (r *importRecorder) record(args mock.Arguments) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inputs = append(r.inputs, *args.Get(1).(*dto.CreateEventInput))
+}
+
+func TestRunImport(t *testing.T) {
+	ts := startTestServer(t)
+	recorder := &importRecorder{}
+	for _, title := range []string{"First", "Second"} {
+		ts.app.On("CreateEvent", mock.Anything, mock.MatchedBy(func(input *dto.CreateEventInput) bool {
+			return input.Title == title
+		})).Run(recorder.record).Return(&types.Event{
+			ID:        uuid.New(),
+			EventData: types.EventData{Title: title, Duration: time.Hour, UserID: "importer"},
+		}, nil).Twice()
+	}
+
+	cfg := &ctlConfig.Config{Client: ctlConfig.ClientConf{Address: ts.address, UserID: "importer", Output: "json"}}
+	// The repeated import sends the same idempotency keys.
+	for range 2 {
+		res := executeCommand(newImportCommand(), runImport, cfg, strings.NewReader(importData), "-")
+		require.NoError(t, res.err, "expected nil, got error")
+		require.Contains(t, res.stdout, `"title": "First"`, "created event is not printed")
+		require.Contains(t, res.stdout, `"title": "Second"`, "created event is not printed")
+	}
+
+	require.Len(t, recorder.inputs, 4, "unexpected number of the created events")
+	for i, input := range recorder.inputs {
+		if i%2 == 0 {
+			require.Equal(t, "First", input.Title, "events are imported out of order")
+			require.Equal(t, "import:0b6f5b8e-3c5e-4b8a-9d4e-1f2a3b4c5d6e", input.IdempotencyKey,
+				"unexpected idempotency key")
+			require.Equal(t, "importer", input.UserID, "event without a user is not owned by the client user")
+			continue
+		}
+		require.Equal(t, "Second", input.Title, "events are imported out of order")
+		require.Empty(t, input.IdempotencyKey, "event without an ID has an idempotency key")
+		require.Equal(t, "owner", input.UserID, "event user is overridden")
+		require.NotNil(t, input.RemindIn, "reminder is not imported")
+		require.Equal(t, 15*time.Minute, *input.RemindIn, "unexpected reminder")
+	}
+}
+
+func TestRunImportFailure(t *testing.T) {
+	ts := startTestServer(t)
+	ts.app.On("CreateEvent", mock.Anything, mock.MatchedBy(func(input *dto.CreateEventInput) bool {
+		return input.Title == "First"
+	})).Return(&types.Event{ID: uuid.New(), EventData: types.EventData{Title: "First"}}, nil).Once()
+	ts.app.On("CreateEvent", mock.Anything, mock.MatchedBy(func(input *dto.CreateEventInput) bool {
+		return input.Title == "Second"
+	})).Return(nil, errors.New("storage is unavailable")).Once()
+
+	cfg := &ctlConfig.Config{Client: ctlConfig.ClientConf{Address: ts.address}}
+	res := executeCommand(newImportCommand(), runImport, cfg, strings.NewReader(importData), "-")
+	require.ErrorContains(t, res.err, "import event #2", "expected error does not match")
+	require.Contains(t, res.stderr, "imported 1 of 2 events", "import progress is not reported")
+	require.Empty(t, res.stdout, "events are printed after the failure")
+}
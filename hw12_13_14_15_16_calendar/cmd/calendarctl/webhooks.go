@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"         //nolint:depguard
+	"github.com/spf13/cobra"                                                    //nolint:depguard
+)
+
+// webhookView is a representation of a webhook subscription for the output.
+//
+//nolint:tagliatelle
+type webhookView struct {
+	ID         string    `json:"id"                yaml:"id"`
+	UserID     string    `json:"user_id,omitempty" yaml:"user_id,omitempty"`
+	URL        string    `json:"url"               yaml:"url"`
+	Secret     string    `json:"secret,omitempty"  yaml:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"       yaml:"event_types"`
+	Enabled    bool      `json:"enabled"           yaml:"enabled"`
+	Failures   int64     `json:"failures"          yaml:"failures"`
+	CreatedAt  time.Time `json:"created_at"        yaml:"created_at"`
+}
+
+// webhookDeliveryView is a representation of a webhook delivery attempt for the output.
+//
+//nolint:tagliatelle
+type webhookDeliveryView struct {
+	DeliveryID string    `json:"delivery_id"     yaml:"delivery_id"`
+	Attempt    int64     `json:"attempt"         yaml:"attempt"`
+	EventType  string    `json:"event_type"      yaml:"event_type"`
+	EventID    string    `json:"event_id"        yaml:"event_id"`
+	StatusCode int64     `json:"status_code"     yaml:"status_code"`
+	Success    bool      `json:"success"         yaml:"success"`
+	Error      string    `json:"error,omitempty" yaml:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"      yaml:"created_at"`
+}
+
+// newWebhooksCommand returns the command managing the webhook subscriptions.
+func newWebhooksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks [list | add URL | delete ID | enable ID | deliveries ID]",
+		Short: "Manage webhook subscriptions",
+		Long: "List the webhook subscriptions of the user, or of all users if the user is not set, " +
+			"add a subscription of the user, delete or re-enable a subscription, or show its delivery log. " +
+			"Requires the admin token",
+		Args: cobra.RangeArgs(0, 2),
+	}
+	addClientFlags(cmd)
+	cmd.Flags().StringSlice("events", nil, "Changes to deliver: created, updated, deleted, reminder. All by default")
+	cmd.Flags().String("secret", "", "Secret to sign the deliveries with. Generated by default")
+	cmd.Flags().Int64("limit", 20, "Maximum number of the latest delivery attempts shown, 0 means no limit")
+	return cmd
+}
+
+// runWebhooks executes the requested webhooks action and prints its result.
+//
+//nolint:funlen
+func runWebhooks(cmd *cobra.Command, args []string, cfg config.ServiceConfig) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	action := args[0]
+	// Number of the arguments of each action.
+	actionArgs := map[string]int{"list": 0, "add": 1, "delete": 1, "enable": 1, "deliveries": 1}
+	n, ok := actionArgs[action]
+	if !ok {
+		return fmt.Errorf("unknown action %q: expected list, add, delete, enable or deliveries", action)
+	}
+	if len(args)-1 != n {
+		return fmt.Errorf("action %q expects %d argument(s), see calendarctl webhooks --help", action, n)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	c, err := newClient(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	reqCtx, reqCancel := c.requestContext(ctx)
+	defer reqCancel()
+	w := cmd.OutOrStdout()
+
+	switch action {
+	case "list":
+		resp, err := c.api.ListWebhooks(reqCtx, &pb.ListWebhooksRequest{UserId: c.userID})
+		if err != nil {
+			return fmt.Errorf("list webhooks: %w", err)
+		}
+		return printWebhooks(w, c.output, resp.Webhooks)
+	case "add":
+		events, _ := cmd.Flags().GetStringSlice("events")
+		secret, _ := cmd.Flags().GetString("secret")
+		resp, err := c.api.CreateWebhook(reqCtx, &pb.CreateWebhookRequest{
+			UserId:     c.userID,
+			Url:        args[1],
+			Secret:     secret,
+			EventTypes: events,
+		})
+		if err != nil {
+			return fmt.Errorf("create webhook: %w", err)
+		}
+		return printWebhooks(w, c.output, []*pb.Webhook{resp.Webhook})
+	case "delete":
+		if _, err := c.api.DeleteWebhook(reqCtx, &pb.DeleteWebhookRequest{Id: args[1]}); err != nil {
+			return fmt.Errorf("delete webhook: %w", err)
+		}
+		return printRecord(w, c.output, []string{"deleted"}, map[string]any{"deleted": args[1]})
+	case "enable":
+		resp, err := c.api.EnableWebhook(reqCtx, &pb.EnableWebhookRequest{Id: args[1]})
+		if err != nil {
+			return fmt.Errorf("enable webhook: %w", err)
+		}
+		return printWebhooks(w, c.output, []*pb.Webhook{resp.Webhook})
+	default: // The action is validated above, so it is "deliveries".
+		limit, _ := cmd.Flags().GetInt64("limit")
+		resp, err := c.api.ListWebhookDeliveries(reqCtx, &pb.ListWebhookDeliveriesRequest{Id: args[1], Limit: limit})
+		if err != nil {
+			return fmt.Errorf("list webhook deliveries: %w", err)
+		}
+		return printWebhookDeliveries(w, c.output, resp.Deliveries)
+	}
+}
+
+// printWebhooks prints the webhook subscriptions in the given format.
+// The secret is printed in the table only if it is returned, i.e. on creation.
+func printWebhooks(w io.Writer, format outputFormat, webhooks []*pb.Webhook) error {
+	views := make([]webhookView, len(webhooks))
+	withSecret := false
+	for i, webhook := range webhooks {
+		views[i] = webhookView{
+			ID:         webhook.Id,
+			UserID:     webhook.UserId,
+			URL:        webhook.Url,
+			Secret:     webhook.Secret,
+			EventTypes: webhook.EventTypes,
+			Enabled:    webhook.Enabled,
+			Failures:   webhook.Failures,
+			CreatedAt:  webhook.CreatedAt.AsTime().Local(),
+		}
+		withSecret = withSecret || webhook.Secret != ""
+	}
+
+	if format != outputTable {
+		return printValue(w, format, views)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "ID\tUSER\tURL\tEVENTS\tENABLED\tFAILURES\tCREATED"
+	if withSecret {
+		header += "\tSECRET"
+	}
+	fmt.Fprintln(tw, header)
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%d\t%s", v.ID, v.UserID, v.URL, strings.Join(v.EventTypes, ","),
+			v.Enabled, v.Failures, v.CreatedAt.Format(time.RFC3339))
+		if withSecret {
+			fmt.Fprintf(tw, "\t%s", v.Secret)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// printWebhookDeliveries prints the webhook delivery attempts in the given format.
+func printWebhookDeliveries(w io.Writer, format outputFormat, deliveries []*pb.WebhookDelivery) error {
+	views := make([]webhookDeliveryView, len(deliveries))
+	for i, delivery := range deliveries {
+		views[i] = webhookDeliveryView{
+			DeliveryID: delivery.DeliveryId,
+			Attempt:    delivery.Attempt,
+			EventType:  delivery.EventType,
+			EventID:    delivery.EventId,
+			StatusCode: delivery.StatusCode,
+			Success:    delivery.Success,
+			Error:      delivery.Error,
+			CreatedAt:  delivery.CreatedAt.AsTime().Local(),
+		}
+	}
+
+	if format != outputTable {
+		return printValue(w, format, views)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DELIVERY ID\tATTEMPT\tTYPE\tEVENT ID\tSTATUS\tSUCCESS\tERROR\tTIME")
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%d\t%t\t%s\t%s\n", v.DeliveryID, v.Attempt, v.EventType, v.EventID,
+			v.StatusCode, v.Success, v.Error, v.CreatedAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
@@ -10,12 +10,13 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/broker"                           //nolint:depguard
 	schedulerConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/scheduler" //nolint:depguard
 	schedulerPkg "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/scheduler"           //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"                          //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/webhook"                          //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                                //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/logger"                                //nolint:depguard
-	mq "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/rabbitmq"                           //nolint:depguard
 )
 
 const (
@@ -43,10 +44,14 @@ func run() error {
 	logg = logg.With(slog.String("service", "scheduler"))
 
 	// Loading configuration from file and env.
-	cfg, err := loadConfig(ctx, logg)
+	loader, cfg, err := loadConfig(ctx, logg)
 	if err != nil {
 		return err
 	}
+	// Help or version was requested - nothing to run.
+	if cfg == nil {
+		return nil
+	}
 
 	// Initializing service logger.
 	logg, err = initializeLogger(ctx, logg, cfg)
@@ -62,7 +67,7 @@ func run() error {
 	defer storage.Close(ctx)
 
 	// Initializing signal handler.
-	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
 	// Initializing storage connection.
@@ -85,12 +90,40 @@ func run() error {
 	}
 	logg.Info(ctx, "message queue connection established")
 
+	// Initializing webhook deliveries of the sent reminders.
+	dispatcher, err := initializeWebhooks(ctx, logg, cfg, storage)
+	if err != nil {
+		return err
+	}
+
 	// Initializing the app.
-	scheduler, err := initializeScheduler(ctx, logg, cfg, storage, brocker)
+	scheduler, err := initializeScheduler(ctx, logg, cfg, storage, brocker, dispatcher)
 	if err != nil {
 		return err
 	}
 
+	// Applying the settings, which are safe to change at runtime, on config change or SIGHUP.
+	reloaders := []config.Reloader{
+		{Section: "logger", Apply: logg.Reload},
+		{
+			Section: "app",
+			Keys: []string{
+				"retries", "retry_timeout", "queue_interval", "cleanup_interval", "digest_interval",
+				"catch_up_policy", "catch_up_grace",
+			},
+			Apply: scheduler.Reload,
+		},
+	}
+	if keys := brocker.ReloadKeys(); len(keys) > 0 {
+		reloaders = append(reloaders, config.Reloader{Section: "broker", Keys: keys, Apply: brocker.Reload})
+	}
+	loader.Watch(ctx, cfg, logg, reloaders...)
+
+	// Starting webhook deliveries. Queued reminders are dropped on shutdown.
+	if dispatcher != nil {
+		dispatcher.Start(ctx)
+	}
+
 	// Starting sending notifications.
 	scheduler.StartProducer(ctx)
 	logg.Info(ctx, "scheduler started successfully")
@@ -99,13 +132,19 @@ func run() error {
 	scheduler.StartCleanup(ctx)
 	logg.Info(ctx, "scheduler started successfully")
 
+	// Starting daily digests.
+	scheduler.StartDigest(ctx)
+
 	<-ctx.Done()
 	scheduler.Wait(ctx)
+	if dispatcher != nil {
+		dispatcher.Wait()
+	}
 
 	return nil
 }
 
-func loadConfig(ctx context.Context, logg *logger.Logger) (config.ServiceConfig, error) {
+func loadConfig(ctx context.Context, logg *logger.Logger) (*config.Loader, config.ServiceConfig, error) {
 	loader := config.NewLoader(
 		"scheduler",
 		"Calendar scheduler service",
@@ -113,16 +152,23 @@ func loadConfig(ctx context.Context, logg *logger.Logger) (config.ServiceConfig,
 		defaultConfigFile,
 		"CALENDAR",
 	)
+	loader.AddCheck("logger", logger.ValidateConfig)
+	loader.AddCheck("storage", storage.ValidateConfig)
+	loader.AddCheck("app", schedulerPkg.ValidateConfig)
+	loader.AddCheck("broker", func(cfg map[string]any) error {
+		return broker.ValidateConfig(cfg, broker.ProducerOnly)
+	})
+	loader.AddCheck("webhooks", webhook.ValidateConfig)
 	cfg, err := loader.Load(&schedulerConfig.Config{}, printVersion, os.Stdout)
 	if err != nil {
 		if errors.Is(err, config.ErrShouldStop) {
-			return nil, nil
+			return nil, nil, nil
 		}
 		logg.Error(ctx, "load config", slog.Any("err", err))
-		return nil, err
+		return nil, nil, err
 	}
 	logg.Info(ctx, "config loaded successfully")
-	return cfg, nil
+	return loader, cfg, nil
 }
 
 func initializeLogger(ctx context.Context, logg *logger.Logger, cfg config.ServiceConfig) (*logger.Logger, error) {
@@ -161,14 +207,21 @@ func initializeScheduler(
 	logg *logger.Logger,
 	cfg config.ServiceConfig,
 	storage storage.Storage,
-	brocker *mq.RabbitMQ,
+	brocker broker.Broker,
+	dispatcher *webhook.Dispatcher,
 ) (*schedulerPkg.Scheduler, error) {
 	schCfg, err := cfg.GetSubConfig("app")
 	if err != nil {
 		logg.Error(ctx, "get scheduler app config", slog.Any("err", err))
 		return nil, err
 	}
-	sch, err := schedulerPkg.NewScheduler(logg.With(slog.String("layer", "SCHEDULER")), storage, brocker, schCfg)
+	var opts []schedulerPkg.Option
+	if dispatcher != nil {
+		opts = append(opts, schedulerPkg.WithNotifier(dispatcher))
+	}
+	sch, err := schedulerPkg.NewScheduler(
+		logg.With(slog.String("layer", "SCHEDULER")), storage, brocker, schCfg, opts...,
+	)
 	if err != nil {
 		logg.Error(ctx, "create scheduler", slog.Any("err", err))
 		return nil, err
@@ -177,17 +230,42 @@ func initializeScheduler(
 	return sch, nil
 }
 
+// initializeWebhooks creates the webhook dispatcher. Returns nil if the deliveries are disabled.
+func initializeWebhooks(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+) (*webhook.Dispatcher, error) {
+	webhooksCfg, err := cfg.GetSubConfig("webhooks")
+	if err != nil {
+		logg.Error(ctx, "get webhooks config", slog.Any("err", err))
+		return nil, err
+	}
+	dispatcher, err := webhook.NewDispatcher(logg.With(slog.String("layer", "WEBHOOKS")), storage, webhooksCfg)
+	if err != nil {
+		logg.Error(ctx, "create webhook dispatcher", slog.Any("err", err))
+		return nil, err
+	}
+	if !dispatcher.Enabled() {
+		logg.Info(ctx, "webhook deliveries are disabled")
+		return nil, nil
+	}
+	logg.Info(ctx, "webhook dispatcher created successfully")
+	return dispatcher, nil
+}
+
 func initializeMesasgeQueue(
 	ctx context.Context,
 	logg *logger.Logger,
 	cfg config.ServiceConfig,
-) (*mq.RabbitMQ, error) {
-	mqCfg, err := cfg.GetSubConfig("rmq")
+) (broker.Broker, error) {
+	mqCfg, err := cfg.GetSubConfig("broker")
 	if err != nil {
 		logg.Error(ctx, "get message queue config", slog.Any("err", err))
 		return nil, err
 	}
-	brocker, err := mq.NewRabbitMQ(logg.With("layer", "RabbitMQ"), mqCfg, mq.ProducerOnly)
+	brocker, err := broker.NewBroker(logg.With(slog.String("layer", "BROKER")), mqCfg, broker.ProducerOnly)
 	if err != nil {
 		logg.Error(ctx, "create message queue", slog.Any("err", err))
 		return nil, err
@@ -10,11 +10,13 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/broker"                     //nolint:depguard
 	senderConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/sender" //nolint:depguard
 	senderPkg "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/sender"           //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"                    //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/templates"                  //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                          //nolint:depguard
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/logger"                          //nolint:depguard
-	mq "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/rabbitmq"                     //nolint:depguard
 )
 
 const (
@@ -42,10 +44,14 @@ func run() error {
 	logg = logg.With(slog.String("service", "sender"))
 
 	// Loading configuration from file and env.
-	cfg, err := loadConfig(ctx, logg)
+	loader, cfg, err := loadConfig(ctx, logg)
 	if err != nil {
 		return err
 	}
+	// Help or version was requested - nothing to run.
+	if cfg == nil {
+		return nil
+	}
 
 	// Initializing service logger.
 	logg, err = initializeLogger(ctx, logg, cfg)
@@ -54,9 +60,18 @@ func run() error {
 	}
 
 	// Initializing signal handler.
-	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	// Initializing notification templates. Storage is connected only if the templates are kept there.
+	renderer, store, err := initializeTemplates(ctx, logg, cfg)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		defer store.Close(ctx)
+	}
+
 	// Initializing message queue.
 	brocker, err := initializeMesasgeQueue(ctx, logg, cfg)
 	if err != nil {
@@ -71,11 +86,21 @@ func run() error {
 	logg.Info(ctx, "message queue connection established")
 
 	// Initializing the app.
-	sender, err := initializeSender(ctx, logg, brocker)
+	sender, err := initializeSender(ctx, logg, brocker, renderer)
 	if err != nil {
 		return err
 	}
 
+	// Applying the settings, which are safe to change at runtime, on config change or SIGHUP.
+	reloaders := []config.Reloader{
+		{Section: "logger", Apply: logg.Reload},
+		{Section: "templates", Keys: renderer.ReloadKeys(), Apply: renderer.Reload},
+	}
+	if keys := brocker.ReloadKeys(); len(keys) > 0 {
+		reloaders = append(reloaders, config.Reloader{Section: "broker", Keys: keys, Apply: brocker.Reload})
+	}
+	loader.Watch(ctx, cfg, logg, reloaders...)
+
 	err = sender.Start(ctx)
 	if err != nil {
 		return err
@@ -88,7 +113,7 @@ func run() error {
 	return nil
 }
 
-func loadConfig(ctx context.Context, logg *logger.Logger) (config.ServiceConfig, error) {
+func loadConfig(ctx context.Context, logg *logger.Logger) (*config.Loader, config.ServiceConfig, error) {
 	loader := config.NewLoader(
 		"sender",
 		"Calendar sender service",
@@ -96,16 +121,22 @@ func loadConfig(ctx context.Context, logg *logger.Logger) (config.ServiceConfig,
 		defaultConfigFile,
 		"CALENDAR",
 	)
+	loader.AddCheck("logger", logger.ValidateConfig)
+	loader.AddCheck("broker", func(cfg map[string]any) error {
+		return broker.ValidateConfig(cfg, broker.ConsumerOnly)
+	})
+	loader.AddCheck("templates", templates.ValidateConfig)
+	loader.AddCheck("storage", storage.ValidateConfig)
 	cfg, err := loader.Load(&senderConfig.Config{}, printVersion, os.Stdout)
 	if err != nil {
 		if errors.Is(err, config.ErrShouldStop) {
-			return nil, nil
+			return nil, nil, nil
 		}
 		logg.Error(ctx, "load config", slog.Any("err", err))
-		return nil, err
+		return nil, nil, err
 	}
 	logg.Info(ctx, "config loaded successfully")
-	return cfg, nil
+	return loader, cfg, nil
 }
 
 func initializeLogger(ctx context.Context, logg *logger.Logger, cfg config.ServiceConfig) (*logger.Logger, error) {
@@ -127,9 +158,10 @@ func initializeLogger(ctx context.Context, logg *logger.Logger, cfg config.Servi
 func initializeSender(
 	ctx context.Context,
 	logg *logger.Logger,
-	brocker *mq.RabbitMQ,
+	brocker broker.Broker,
+	renderer *templates.Renderer,
 ) (*senderPkg.Sender, error) {
-	sch, err := senderPkg.NewSender(logg.With(slog.String("layer", "SENDER")), brocker)
+	sch, err := senderPkg.NewSender(logg.With(slog.String("layer", "SENDER")), brocker, renderer)
 	if err != nil {
 		logg.Error(ctx, "create sender", slog.Any("err", err))
 		return nil, err
@@ -142,13 +174,13 @@ func initializeMesasgeQueue(
 	ctx context.Context,
 	logg *logger.Logger,
 	cfg config.ServiceConfig,
-) (*mq.RabbitMQ, error) {
-	mqCfg, err := cfg.GetSubConfig("rmq")
+) (broker.Broker, error) {
+	mqCfg, err := cfg.GetSubConfig("broker")
 	if err != nil {
 		logg.Error(ctx, "get message queue config", slog.Any("err", err))
 		return nil, err
 	}
-	brocker, err := mq.NewRabbitMQ(logg.With("layer", "RabbitMQ"), mqCfg, mq.ConsumerOnly)
+	brocker, err := broker.NewBroker(logg.With(slog.String("layer", "BROKER")), mqCfg, broker.ConsumerOnly)
 	if err != nil {
 		logg.Error(ctx, "create message queue", slog.Any("err", err))
 		return nil, err
@@ -156,3 +188,74 @@ func initializeMesasgeQueue(
 	logg.Info(ctx, "message queue created successfully")
 	return brocker, nil
 }
+
+func initializeTemplates(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+) (*templates.Renderer, storage.Storage, error) {
+	tmplCfg, err := cfg.GetSubConfig("templates")
+	if err != nil {
+		logg.Error(ctx, "get templates config", slog.Any("err", err))
+		return nil, nil, err
+	}
+
+	var s storage.Storage
+	if tmplCfg["source"] == templates.SourceStorage {
+		if s, err = initializeStorage(ctx, logg, cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	renderer, err := initializeRenderer(ctx, logg, tmplCfg, s)
+	if err != nil {
+		if s != nil {
+			s.Close(ctx)
+		}
+		return nil, nil, err
+	}
+	return renderer, s, nil
+}
+
+func initializeStorage(ctx context.Context, logg *logger.Logger, cfg config.ServiceConfig) (storage.Storage, error) {
+	storageCfg, err := cfg.GetSubConfig("storage")
+	if err != nil {
+		logg.Error(ctx, "get storage config", slog.Any("err", err))
+		return nil, err
+	}
+	s, err := storage.NewStorage(storageCfg)
+	if err != nil {
+		logg.Error(ctx, "create storage", slog.Any("err", err))
+		return nil, err
+	}
+	if err := s.Connect(ctx); err != nil {
+		logg.Error(ctx, "connect storage", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "storage connection established")
+	return s, nil
+}
+
+func initializeRenderer(
+	ctx context.Context,
+	logg *logger.Logger,
+	tmplCfg map[string]any,
+	s storage.Storage,
+) (*templates.Renderer, error) {
+	store, err := templates.NewStore(tmplCfg, s)
+	if err != nil {
+		logg.Error(ctx, "create templates store", slog.Any("err", err))
+		return nil, err
+	}
+	renderer, err := templates.NewRenderer(store, tmplCfg)
+	if err != nil {
+		logg.Error(ctx, "create templates renderer", slog.Any("err", err))
+		return nil, err
+	}
+	if err := renderer.Load(ctx); err != nil {
+		logg.Error(ctx, "load notification templates", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "notification templates loaded", slog.Any("channels", renderer.Channels()))
+	return renderer, nil
+}
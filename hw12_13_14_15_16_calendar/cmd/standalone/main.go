@@ -0,0 +1,487 @@
+// Package main contains entrypoint for the single-binary mode, running calendar, scheduler and sender
+// in a single process with the in-memory message broker.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	app "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app"                            //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/broker"                             //nolint:depguard
+	standaloneConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/config/standalone" //nolint:depguard
+	schedulerPkg "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/scheduler"             //nolint:depguard
+	senderPkg "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/sender"                   //nolint:depguard
+	internalgrpc "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc"           //nolint:depguard
+	internalhttp "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/http"           //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"                            //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/templates"                          //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/webhook"                            //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                                  //nolint:depguard
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/logger"                                  //nolint:depguard
+)
+
+const (
+	exitCodeSuccess = 0
+	exitCodeError   = 1
+)
+
+var defaultConfigFile = "../../configs/standalone/config.toml"
+
+func main() {
+	if err := run(); err != nil {
+		os.Exit(exitCodeError)
+	}
+	os.Exit(exitCodeSuccess)
+}
+
+func run() error {
+	ctx := context.Background()
+	// Creating temporary logger so errors will not be lost.
+	logg, err := logger.NewLogger()
+	if err != nil {
+		fmt.Printf("create temporary logger: %s\n", err.Error())
+		return err
+	}
+	logg = logg.With(slog.String("service", "standalone"))
+
+	// Loading configuration from file and env.
+	loader, cfg, err := loadConfig(ctx, logg)
+	if err != nil {
+		return err
+	}
+	// Help or version was requested - nothing to run.
+	if cfg == nil {
+		return nil
+	}
+
+	// Initializing service logger.
+	logg, err = initializeLogger(ctx, logg, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initializing the storage.
+	storage, err := initializeStorage(ctx, logg, cfg)
+	if err != nil {
+		return err
+	}
+	defer storage.Close(ctx)
+
+	// Initializing signal handler.
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// Initializing storage connection.
+	if err := storage.Connect(ctx); err != nil {
+		logg.Error(ctx, "connect storage", slog.Any("err", err))
+		return err
+	}
+	logg.Info(ctx, "storage connection established")
+
+	// Initializing message broker.
+	brocker, err := initializeMessageBroker(ctx, logg, cfg)
+	if err != nil {
+		return err
+	}
+	if err := brocker.Connect(ctx); err != nil {
+		logg.Error(ctx, "connect message broker", slog.Any("err", err))
+		return err
+	}
+	defer brocker.Close(ctx)
+	logg.Info(ctx, "message broker connection established")
+
+	// Initializing the components. Templates are shared by the sender and the calendar previews,
+	// webhook deliveries are shared by the calendar and the scheduler.
+	renderer, err := initializeTemplates(ctx, logg, cfg, storage)
+	if err != nil {
+		return err
+	}
+	dispatcher, err := initializeWebhooks(ctx, logg, cfg, storage)
+	if err != nil {
+		return err
+	}
+	calendar, err := initializeApp(ctx, logg, cfg, storage, renderer, dispatcher)
+	if err != nil {
+		return err
+	}
+	scheduler, err := initializeScheduler(ctx, logg, cfg, storage, brocker, dispatcher)
+	if err != nil {
+		return err
+	}
+	sender, err := initializeSender(ctx, logg, brocker, renderer)
+	if err != nil {
+		return err
+	}
+
+	// Applying the settings, which are safe to change at runtime, on config change or SIGHUP.
+	reloaders := []config.Reloader{
+		{Section: "logger", Apply: logg.Reload},
+		{Section: "app", Keys: []string{"retries", "retry_timeout"}, Apply: calendar.Reload},
+		{
+			Section: "scheduler",
+			Keys: []string{
+				"retries", "retry_timeout", "queue_interval", "cleanup_interval", "digest_interval",
+				"catch_up_policy", "catch_up_grace",
+			},
+			Apply: scheduler.Reload,
+		},
+		{Section: "templates", Keys: renderer.ReloadKeys(), Apply: renderer.Reload},
+	}
+	if keys := brocker.ReloadKeys(); len(keys) > 0 {
+		reloaders = append(reloaders, config.Reloader{Section: "broker", Keys: keys, Apply: brocker.Reload})
+	}
+	loader.Watch(ctx, cfg, logg, reloaders...)
+
+	// Starting webhook deliveries. Queued changes are dropped on shutdown.
+	if dispatcher != nil {
+		dispatcher.Start(ctx)
+	}
+
+	// Starting the notification pipeline.
+	if err := sender.Start(ctx); err != nil {
+		return err
+	}
+	logg.Info(ctx, "sender started successfully")
+	scheduler.StartProducer(ctx)
+	scheduler.StartCleanup(ctx)
+	scheduler.StartDigest(ctx)
+	logg.Info(ctx, "scheduler started successfully")
+
+	// Starting servers.
+	err = startServers(ctx, cancel, logg, cfg, calendar)
+
+	scheduler.Wait(ctx)
+	sender.Wait(ctx)
+	if dispatcher != nil {
+		dispatcher.Wait()
+	}
+
+	return err
+}
+
+func loadConfig(ctx context.Context, logg *logger.Logger) (*config.Loader, config.ServiceConfig, error) {
+	loader := config.NewLoader(
+		"standalone",
+		"Calendar standalone",
+		"Calendar, scheduler and sender running in a single process with the in-memory message broker",
+		defaultConfigFile,
+		"CALENDAR",
+	)
+	loader.AddCheck("logger", logger.ValidateConfig)
+	loader.AddCheck("storage", storage.ValidateConfig)
+	loader.AddCheck("app", app.ValidateConfig)
+	loader.AddCheck("scheduler", schedulerPkg.ValidateConfig)
+	loader.AddCheck("broker", func(cfg map[string]any) error {
+		return broker.ValidateConfig(cfg, broker.FullClient)
+	})
+	loader.AddCheck("grpc", internalgrpc.ValidateConfig)
+	loader.AddCheck("http", internalhttp.ValidateConfig)
+	loader.AddCheck("templates", templates.ValidateConfig)
+	loader.AddCheck("webhooks", webhook.ValidateConfig)
+	cfg, err := loader.Load(&standaloneConfig.Config{}, printVersion, os.Stdout)
+	if err != nil {
+		if errors.Is(err, config.ErrShouldStop) {
+			return nil, nil, nil
+		}
+		logg.Error(ctx, "load config", slog.Any("err", err))
+		return nil, nil, err
+	}
+	logg.Info(ctx, "config loaded successfully")
+	return loader, cfg, nil
+}
+
+func initializeLogger(ctx context.Context, logg *logger.Logger, cfg config.ServiceConfig) (*logger.Logger, error) {
+	logCfg, err := cfg.GetSubConfig("logger")
+	if err != nil {
+		logg.Error(ctx, "get logger config", slog.Any("err", err))
+		return nil, err
+	}
+	newLogg, err := logger.NewLogger(logger.WithConfig(logCfg))
+	if err != nil {
+		logg.Error(ctx, "create logger", slog.Any("err", err))
+		return nil, err
+	}
+	newLogg = newLogg.With(slog.String("service", "standalone"))
+	newLogg.Info(ctx, "logger created successfully")
+	return newLogg, nil
+}
+
+func initializeStorage(ctx context.Context, logg *logger.Logger, cfg config.ServiceConfig) (storage.Storage, error) {
+	storageCfg, err := cfg.GetSubConfig("storage")
+	if err != nil {
+		logg.Error(ctx, "get storage config", slog.Any("err", err))
+		return nil, err
+	}
+	storage, err := storage.NewStorage(storageCfg)
+	if err != nil {
+		logg.Error(ctx, "create storage", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "storage created successfully")
+	return storage, nil
+}
+
+func initializeMessageBroker(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+) (broker.Broker, error) {
+	brokerCfg, err := cfg.GetSubConfig("broker")
+	if err != nil {
+		logg.Error(ctx, "get message broker config", slog.Any("err", err))
+		return nil, err
+	}
+	brocker, err := broker.NewBroker(logg.With(slog.String("layer", "BROKER")), brokerCfg, broker.FullClient)
+	if err != nil {
+		logg.Error(ctx, "create message broker", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "message broker created successfully")
+	return brocker, nil
+}
+
+func initializeApp(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+	renderer *templates.Renderer,
+	dispatcher *webhook.Dispatcher,
+) (*app.App, error) {
+	appCfg, err := cfg.GetSubConfig("app")
+	if err != nil {
+		logg.Error(ctx, "get app config", slog.Any("err", err))
+		return nil, err
+	}
+	opts := []app.Option{app.WithRenderer(renderer)}
+	if dispatcher != nil {
+		opts = append(opts, app.WithNotifier(dispatcher))
+	}
+	calendar, err := app.NewApp(logg.With(slog.String("layer", "APP")), storage, appCfg, opts...)
+	if err != nil {
+		logg.Error(ctx, "create app", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "app created successfully")
+	return calendar, nil
+}
+
+func initializeTemplates(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+) (*templates.Renderer, error) {
+	tmplCfg, err := cfg.GetSubConfig("templates")
+	if err != nil {
+		logg.Error(ctx, "get templates config", slog.Any("err", err))
+		return nil, err
+	}
+	store, err := templates.NewStore(tmplCfg, storage)
+	if err != nil {
+		logg.Error(ctx, "create templates store", slog.Any("err", err))
+		return nil, err
+	}
+	renderer, err := templates.NewRenderer(store, tmplCfg)
+	if err != nil {
+		logg.Error(ctx, "create templates renderer", slog.Any("err", err))
+		return nil, err
+	}
+	if err := renderer.Load(ctx); err != nil {
+		logg.Error(ctx, "load notification templates", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "notification templates loaded", slog.Any("channels", renderer.Channels()))
+	return renderer, nil
+}
+
+// initializeWebhooks creates the webhook dispatcher. Returns nil if the deliveries are disabled.
+func initializeWebhooks(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+) (*webhook.Dispatcher, error) {
+	webhooksCfg, err := cfg.GetSubConfig("webhooks")
+	if err != nil {
+		logg.Error(ctx, "get webhooks config", slog.Any("err", err))
+		return nil, err
+	}
+	dispatcher, err := webhook.NewDispatcher(logg.With(slog.String("layer", "WEBHOOKS")), storage, webhooksCfg)
+	if err != nil {
+		logg.Error(ctx, "create webhook dispatcher", slog.Any("err", err))
+		return nil, err
+	}
+	if !dispatcher.Enabled() {
+		logg.Info(ctx, "webhook deliveries are disabled")
+		return nil, nil
+	}
+	logg.Info(ctx, "webhook dispatcher created successfully")
+	return dispatcher, nil
+}
+
+func initializeScheduler(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	storage storage.Storage,
+	brocker broker.Broker,
+	dispatcher *webhook.Dispatcher,
+) (*schedulerPkg.Scheduler, error) {
+	schCfg, err := cfg.GetSubConfig("scheduler")
+	if err != nil {
+		logg.Error(ctx, "get scheduler config", slog.Any("err", err))
+		return nil, err
+	}
+	var opts []schedulerPkg.Option
+	if dispatcher != nil {
+		opts = append(opts, schedulerPkg.WithNotifier(dispatcher))
+	}
+	sch, err := schedulerPkg.NewScheduler(
+		logg.With(slog.String("layer", "SCHEDULER")), storage, brocker, schCfg, opts...,
+	)
+	if err != nil {
+		logg.Error(ctx, "create scheduler", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "scheduler created successfully")
+	return sch, nil
+}
+
+func initializeSender(
+	ctx context.Context,
+	logg *logger.Logger,
+	brocker broker.Broker,
+	renderer *templates.Renderer,
+) (*senderPkg.Sender, error) {
+	sender, err := senderPkg.NewSender(logg.With(slog.String("layer", "SENDER")), brocker, renderer)
+	if err != nil {
+		logg.Error(ctx, "create sender", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "sender created successfully")
+	return sender, nil
+}
+
+func startServers(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	calendar *app.App,
+) error {
+	var wg sync.WaitGroup
+	errChan := make(chan error, 2) // Buffer for Start errors of both servers.
+
+	grpcServer, err := initializeGRPCServer(ctx, logg.With(slog.String("layer", "gRPC server")), cfg, calendar)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	httpServer, err := initializeHTTPServer(ctx, logg.With(slog.String("layer", "HTTP server")), cfg, calendar)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	// Starting gRPC server.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := grpcServer.Start(ctx); err != nil {
+			logg.Error(ctx, "start gRPC server", slog.Any("err", err))
+			errChan <- err
+		}
+	}()
+
+	// Starting http server.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := httpServer.Start(ctx); err != nil {
+			logg.Error(ctx, "start HTTP server", slog.Any("err", err))
+			errChan <- err
+		}
+	}()
+
+	logg.Info(ctx, "standalone is running...")
+
+	// Wait for the first error or context cancellation.
+	var serverErr error
+	select {
+	// We assume that the only possible result of server start is error or blocking.
+	case serverErr = <-errChan:
+		cancel()
+		logg.Error(ctx, "server error occurred, shutting down standalone...")
+	case <-ctx.Done():
+		logg.Info(ctx, "interruption received, shutting down standalone...")
+	}
+
+	// Stop both servers explicitly.
+	if err := grpcServer.Stop(ctx); err != nil {
+		logg.Error(ctx, "stop gRPC server", slog.Any("err", err))
+	} else {
+		logg.Info(ctx, "gRPC server stopped successfully")
+	}
+	if err := httpServer.Stop(ctx); err != nil {
+		logg.Error(ctx, "stop HTTP server", slog.Any("err", err))
+	} else {
+		logg.Info(ctx, "HTTP server stopped successfully")
+	}
+
+	wg.Wait()
+	close(errChan)
+	return serverErr
+}
+
+func initializeGRPCServer(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	calendar *app.App,
+) (*internalgrpc.Server, error) {
+	grpcCfg, err := cfg.GetSubConfig("grpc")
+	if err != nil {
+		logg.Error(ctx, "get gRPC server config", slog.Any("err", err))
+		return nil, err
+	}
+	grpcServer, err := internalgrpc.NewServer(logg, calendar, grpcCfg)
+	if err != nil {
+		logg.Error(ctx, "create gRPC server", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "gRPC server created successfully")
+	return grpcServer, nil
+}
+
+func initializeHTTPServer(
+	ctx context.Context,
+	logg *logger.Logger,
+	cfg config.ServiceConfig,
+	calendar *app.App,
+) (*internalhttp.Server, error) {
+	httpCfg, err := cfg.GetSubConfig("http")
+	if err != nil {
+		logg.Error(ctx, "get HTTP server config", slog.Any("err", err))
+		return nil, err
+	}
+	grpcCfg, err := cfg.GetSubConfig("grpc")
+	if err != nil {
+		logg.Error(ctx, "get gRPC server config for HTTP server", slog.Any("err", err))
+		return nil, err
+	}
+	httpServer, err := internalhttp.NewServer(logg, calendar, httpCfg, grpcCfg)
+	if err != nil {
+		logg.Error(ctx, "create HTTP server", slog.Any("err", err))
+		return nil, err
+	}
+	logg.Info(ctx, "HTTP server created successfully")
+	return httpServer, nil
+}
@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// defaultCleanupRetention is the default minimal age of the events deleted by the manual cleanup.
+const defaultCleanupRetention = 30 * 24 * time.Hour
+
+// CleanupEvents is trying to delete the events starting before the given time from the storage.
+// Zero time means a year ago, the same as for the periodic scheduler cleanup, or the retention border
+// if it is earlier. The time later than now minus the cleanup retention is rejected with ErrInvalidFieldData.
+//
+// Returns the number of deleted events, nil on success and 0, error otherwise.
+func (a *App) CleanupEvents(ctx context.Context, before time.Time) (int64, error) {
+	method := "CleanupEvents"
+	msg := method + ": %w"
+
+	border := time.Now().Add(-a.cleanupRetention)
+	if before.IsZero() {
+		before = time.Now().AddDate(-1, 0, 0)
+		if before.After(border) {
+			before = border
+		}
+	}
+	if before.After(border) {
+		return 0, fmt.Errorf(msg, fmt.Errorf("%w: before=%v is later than the retention border %v",
+			projectErrors.ErrInvalidFieldData, before.Format(time.RFC3339), border.Format(time.RFC3339)))
+	}
+
+	var deleted int64
+	err := a.withRetries(ctx, method, func() error {
+		count, err := a.s.DeleteOldEvents(ctx, before)
+		if err != nil {
+			return err
+		}
+		deleted = count
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(msg, err)
+	}
+
+	return deleted, nil
+}
+
+// GetSchedulerStatus is trying to get the state of the notification queue from the storage.
+// Returns *SchedulerStatus, nil on success and nil, error otherwise.
+func (a *App) GetSchedulerStatus(ctx context.Context) (*dto.SchedulerStatus, error) {
+	method := "GetSchedulerStatus"
+	msg := method + ": %w"
+
+	status := &dto.SchedulerStatus{}
+
+	err := a.withRetries(ctx, method, func() error {
+		count, err := a.s.CountEventsForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		status.DueNotifications = count
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	// No events waiting for notification is a valid state, not an error.
+	err = a.withRetries(ctx, method, func() error {
+		next, err := a.s.GetNextReminderTime(ctx)
+		if err != nil {
+			if errors.Is(err, projectErrors.ErrEventNotFound) {
+				return nil
+			}
+			return err
+		}
+		status.NextReminder = &next
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return status, nil
+}
+
+// GetMissedReminders is trying to report the reminders, which were due during the outage of the scheduler,
+// and their outcome under the given catch-up policy, as if they were handled at the end of the outage.
+//
+// Returns the reminders sorted by the reminder time, which might be empty, nil on success and nil, error otherwise.
+func (a *App) GetMissedReminders(ctx context.Context, input *dto.MissedRemindersInput) ([]*dto.MissedReminder, error) {
+	method := "GetMissedReminders"
+	msg := method + ": %w"
+
+	if input == nil {
+		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
+	}
+	if input.OutageStart.IsZero() || input.OutageEnd.IsZero() {
+		return nil, fmt.Errorf(msg, fmt.Errorf("%w: outage_start, outage_end", projectErrors.ErrEmptyField))
+	}
+	if !input.OutageStart.Before(input.OutageEnd) {
+		return nil, fmt.Errorf(msg, fmt.Errorf("%w: outage_start must be before outage_end",
+			projectErrors.ErrInvalidFieldData))
+	}
+	policy, err := types.ParseCatchUpPolicy(input.CatchUpPolicy)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+	if input.CatchUpGrace < 0 {
+		return nil, fmt.Errorf(msg, fmt.Errorf("%w: catch_up_grace", projectErrors.ErrInvalidFieldData))
+	}
+
+	var events []*types.Event
+	err = a.withRetries(ctx, method, func() error {
+		res, err := a.s.GetEventsForReminderPeriod(ctx, input.OutageStart, input.OutageEnd)
+		if err != nil && !errors.Is(err, projectErrors.ErrEventNotFound) {
+			return err
+		}
+		events = res
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	res := make([]*dto.MissedReminder, 0, len(events))
+	for _, event := range events {
+		remindAt := event.Datetime.Add(-event.RemindIn)
+		outcome := dto.MissedReminderDelayed
+		switch send, late := policy.Decide(event.Datetime, input.OutageEnd, input.CatchUpGrace); {
+		case !send:
+			outcome = dto.MissedReminderSkipped
+		case late:
+			outcome = dto.MissedReminderLate
+		}
+		res = append(res, &dto.MissedReminder{
+			EventID:  event.ID.String(),
+			Title:    event.Title,
+			UserID:   event.UserID,
+			Start:    event.Datetime,
+			RemindAt: remindAt,
+			Delay:    input.OutageEnd.Sub(remindAt),
+			Notified: event.IsNotified,
+			Outcome:  outcome,
+		})
+	}
+	return res, nil
+}
+
+// GetCacheStats returns the state and the hit/miss counters of the storage cache.
+// Returns the stats with Enabled unset if the cache is disabled.
+func (a *App) GetCacheStats(_ context.Context) (*dto.CacheStats, error) {
+	if a.cache == nil {
+		return &dto.CacheStats{}, nil
+	}
+	return a.cache.stats(), nil
+}
+
+// PreviewNotification is trying to render the reminder of the event with the given ID for the given channel
+// without sending it. Locale and time zone of the input override the settings of the event owner.
+//
+// Returns the rendered message, nil on success and nil, error otherwise.
+func (a *App) PreviewNotification(ctx context.Context, input *dto.PreviewNotificationInput) (*types.Message, error) {
+	method := "PreviewNotification"
+	msg := method + ": %w"
+
+	if input == nil {
+		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
+	}
+	if input.Channel == "" {
+		return nil, fmt.Errorf(msg, fmt.Errorf("%w: channel", projectErrors.ErrEmptyField))
+	}
+	if a.r == nil {
+		return nil, fmt.Errorf(msg, fmt.Errorf("%w: notification templates are not configured",
+			projectErrors.ErrTemplateNotFound))
+	}
+
+	event, err := a.GetEvent(ctx, input.EventID)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	res, err := a.r.Render(ctx, input.Channel, event.ToNotification(), &types.UserSettings{
+		UserID:   event.UserID,
+		Locale:   input.Locale,
+		TimeZone: input.TimeZone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+	return res, nil
+}
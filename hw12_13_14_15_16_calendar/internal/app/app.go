@@ -7,21 +7,54 @@ import (
 	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	pkgConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"          //nolint:depguard,nolintlint
 )
 
 // App represents a calendar application.
 type App struct {
-	mu           sync.RWMutex
-	l            Logger
-	s            Storage
-	retryTimeout time.Duration
-	retries      int
+	mu            sync.RWMutex
+	l             Logger
+	s             Storage
+	retryTimeout  time.Duration
+	retries       int
+	overlapPolicy types.OverlapPolicy
+
+	maxEventsPerUser int       // 0 means no limit.
+	quotaLocks       userLocks // Serialize the quota check with the event creation of the same user.
+
+	idempotency *idempotencyStore // Results of the create requests by their idempotency keys.
+
+	r Renderer // Notification renderer for the previews. Nil means previews are unavailable.
+
+	cache *cachedStorage // Storage cache, also set as the storage. Nil means the cache is disabled.
+
+	n Notifier // Webhook notifier of the event changes. Nil means webhooks are not delivered.
+
+	cleanupRetention time.Duration // Events younger than the retention are not deleted by the manual cleanup.
+}
+
+// Option defines a function that allows to configure optional App dependencies on construction.
+type Option func(a *App)
+
+// WithRenderer sets the renderer used for the notification previews.
+func WithRenderer(r Renderer) Option {
+	return func(a *App) {
+		a.r = r
+	}
+}
+
+// WithNotifier sets the notifier of the event changes for the webhook subscriptions.
+func WithNotifier(n Notifier) Option {
+	return func(a *App) {
+		a.n = n
+	}
 }
 
 // NewApp creates a new calendar application after arguments validation.
 //
 // It uses the provided logger and storage to log and store events.
-func NewApp(logger Logger, storage Storage, config map[string]any) (*App, error) {
+func NewApp(logger Logger, storage Storage, config map[string]any, opts ...Option) (*App, error) {
 	// Args validation.
 	missing := make([]string, 0)
 	if logger == nil {
@@ -38,25 +71,121 @@ func NewApp(logger Logger, storage Storage, config map[string]any) (*App, error)
 			projectErrors.ErrAppInitFailed, missing)
 	}
 
+	st, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &App{
+		l:             logger,
+		s:             storage,
+		retries:       st.retries,
+		retryTimeout:  st.retryTimeout,
+		overlapPolicy: st.overlapPolicy,
+
+		maxEventsPerUser: st.maxEventsPerUser,
+		idempotency:      newIdempotencyStore(st.idempotencyTTL),
+		cleanupRetention: st.cleanupRetention,
+	}
+	if st.cacheSize > 0 {
+		a.cache = newCachedStorage(storage, st.cacheSize, st.cacheTTL)
+		a.s = a.cache
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a, nil
+}
+
+// Reload applies the settings which might be changed at runtime: retries and retry_timeout.
+// Returns an error if the config is invalid, keeping the current settings.
+func (a *App) Reload(config map[string]any) error {
+	ve := &pkgConfig.ValidationError{}
+	retries, retryTimeout := parseRetries(config, ve)
+	if ve.HasErrors() {
+		return fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.retries = retries
+	a.retryTimeout = retryTimeout
+	return nil
+}
+
+// ValidateConfig validates the app config without creating an app.
+// Problems are returned as *config.ValidationError wrapped with ErrCorruptedConfig.
+func ValidateConfig(config map[string]any) error {
+	_, err := parseConfig(config)
+	return err
+}
+
+// settings are the app settings parsed from the config.
+type settings struct {
+	retries          int
+	retryTimeout     time.Duration
+	overlapPolicy    types.OverlapPolicy
+	maxEventsPerUser int // 0 means no limit.
+	idempotencyTTL   time.Duration
+	cacheSize        int // 0 disables the cache.
+	cacheTTL         time.Duration
+	cleanupRetention time.Duration
+}
+
+// parseConfig validates and extracts the app settings from the config, accumulating all problems.
+func parseConfig(config map[string]any) (*settings, error) {
+	ve := &pkgConfig.ValidationError{}
+	st := &settings{}
+	st.retries, st.retryTimeout = parseRetries(config, ve)
+
+	// Optional fields are zero valued if missing.
+	ve.InvalidType = append(ve.InvalidType, validateOptionalFields(config, optionalFields)...)
+	policyStr, _ := config["overlap_policy"].(string)
+	overlapPolicy, err := types.ParseOverlapPolicy(policyStr)
+	if err != nil {
+		ve.InvalidValue = append(ve.InvalidValue, "overlap_policy")
+	}
+	maxEventsPerUser, _ := config["max_events_per_user"].(int)
+	idempotencyTTL, _ := config["idempotency_ttl"].(time.Duration)
+	if idempotencyTTL <= 0 {
+		idempotencyTTL = defaultIdempotencyTTL
+	}
+	cacheSize, _ := config["cache_size"].(int)
+	cacheTTL, _ := config["cache_ttl"].(time.Duration)
+	if cacheTTL <= 0 {
+		cacheTTL = defaultCacheTTL
+	}
+	cleanupRetention, _ := config["cleanup_retention"].(time.Duration)
+	if cleanupRetention <= 0 {
+		cleanupRetention = defaultCleanupRetention
+	}
+
+	if ve.HasErrors() {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
+	}
+
+	st.overlapPolicy = overlapPolicy
+	st.maxEventsPerUser = max(0, maxEventsPerUser)
+	st.idempotencyTTL = idempotencyTTL
+	st.cacheSize = max(0, cacheSize)
+	st.cacheTTL = cacheTTL
+	st.cleanupRetention = cleanupRetention
+	return st, nil
+}
+
+// parseRetries validates and extracts the retry settings from the config. Problems are added to ve.
+func parseRetries(config map[string]any, ve *pkgConfig.ValidationError) (int, time.Duration) {
 	// Field types validation.
 	missing, wrongType := validateFields(config, expectedFields)
-	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("%w: missing=%v invalid_type=%v",
-			projectErrors.ErrCorruptedConfig, missing, wrongType)
-	}
+	ve.Missing = append(ve.Missing, missing...)
+	ve.InvalidType = append(ve.InvalidType, wrongType...)
 
 	// Extract from config an normalize the value.
 	retries, _ := config["retries"].(int)
 	retries = max(0, retries)
-	retryTimeout, _ := config["retry_timeout"].(time.Duration)
-	if retryTimeout <= 0 {
-		return nil, fmt.Errorf("%w: retry timeout must be positive, got %v", projectErrors.ErrCorruptedConfig, retryTimeout)
+	retryTimeout, ok := config["retry_timeout"].(time.Duration)
+	if ok && retryTimeout <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "retry_timeout")
 	}
-
-	return &App{
-		l:            logger,
-		s:            storage,
-		retries:      retries,
-		retryTimeout: retryTimeout,
-	}, nil
+	return retries, retryTimeout
 }
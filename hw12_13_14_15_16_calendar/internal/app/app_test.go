@@ -4,12 +4,18 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app/mocks"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory"       //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	pkgConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"          //nolint:depguard,nolintlint
 	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
@@ -79,6 +85,61 @@ func TestNewApp(t *testing.T) {
 			},
 			expectedErr: projectErrors.ErrCorruptedConfig,
 		},
+		{
+			name:    "unknown overlap policy in config",
+			logger:  &mocks.Logger{},
+			storage: &mocks.Storage{},
+			config: map[string]any{
+				"retries":        3,
+				"retry_timeout":  time.Millisecond * 100,
+				"overlap_policy": "sometimes",
+			},
+			expectedErr: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name:    "valid config with overlap policy",
+			logger:  &mocks.Logger{},
+			storage: &mocks.Storage{},
+			config: map[string]any{
+				"retries":        3,
+				"retry_timeout":  time.Millisecond * 100,
+				"overlap_policy": "allow-if-free",
+			},
+			expectedErr: nil,
+		},
+		{
+			name:    "wrong max events per user type in config",
+			logger:  &mocks.Logger{},
+			storage: &mocks.Storage{},
+			config: map[string]any{
+				"retries":             3,
+				"retry_timeout":       time.Millisecond * 100,
+				"max_events_per_user": "10",
+			},
+			expectedErr: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name:    "wrong idempotency ttl type in config",
+			logger:  &mocks.Logger{},
+			storage: &mocks.Storage{},
+			config: map[string]any{
+				"retries":         3,
+				"retry_timeout":   time.Millisecond * 100,
+				"idempotency_ttl": "1h",
+			},
+			expectedErr: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name:    "valid config with max events per user",
+			logger:  &mocks.Logger{},
+			storage: &mocks.Storage{},
+			config: map[string]any{
+				"retries":             3,
+				"retry_timeout":       time.Millisecond * 100,
+				"max_events_per_user": 10,
+			},
+			expectedErr: nil,
+		},
 		{
 			name:    "valid config",
 			logger:  &mocks.Logger{},
@@ -107,6 +168,150 @@ func TestNewApp(t *testing.T) {
 	}
 }
 
+func TestCreateEvent_Quota(t *testing.T) {
+	input := &dto.CreateEventInput{
+		Title:    "Event",
+		Datetime: time.Now().Add(time.Hour),
+		Duration: time.Hour,
+		UserID:   "user1",
+	}
+
+	testCases := []struct {
+		name        string
+		quota       int
+		stored      int64
+		storedErr   error
+		expectedErr error
+	}{
+		{"no quota", 0, 0, nil, nil},
+		{"below quota", 2, 1, nil, nil},
+		{"no user events", 1, 0, nil, nil},
+		{"quota reached", 2, 2, nil, projectErrors.ErrQuotaExceeded},
+		{"storage error", 2, 0, projectErrors.ErrPermissionDenied, projectErrors.ErrPermissionDenied},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			storage := new(mocks.Storage)
+			if tC.quota > 0 {
+				storage.On("CountUserEvents", mock.Anything, input.UserID).Return(tC.stored, tC.storedErr).Once()
+			}
+			if tC.expectedErr == nil {
+				storage.On("CreateEvent", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, event *types.Event) (*types.Event, error) {
+						return event, nil
+					}).Once()
+			}
+
+			app := &App{
+				s:                storage,
+				l:                new(mocks.Logger),
+				retryTimeout:     time.Millisecond,
+				maxEventsPerUser: tC.quota,
+			}
+
+			event, err := app.CreateEvent(context.Background(), input)
+			if tC.expectedErr != nil {
+				require.ErrorIs(t, err, tC.expectedErr, "unexpected error")
+				require.Nil(t, event, "event should be nil on error")
+			} else {
+				require.NoError(t, err, "expected nil, got error")
+				require.NotNil(t, event, "event should not be nil")
+			}
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateEvent_ConcurrentQuota(t *testing.T) {
+	storage, err := memory.NewStorage(0)
+	require.NoError(t, err, "expected nil, got error")
+	require.NoError(t, storage.Connect(context.Background()), "expected nil, got error")
+	app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond, maxEventsPerUser: 3}
+
+	start := time.Now().Add(time.Hour)
+	var created atomic.Int32
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := app.CreateEvent(context.Background(), &dto.CreateEventInput{
+				Title:    "Event",
+				Datetime: start.Add(time.Duration(i) * time.Hour),
+				Duration: time.Hour,
+				UserID:   fmt.Sprintf("user%d", i%2),
+			})
+			if err == nil {
+				created.Add(1)
+				return
+			}
+			require.ErrorIs(t, err, projectErrors.ErrQuotaExceeded, "unexpected error")
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(6), created.Load(), "quota of each user is exceeded or not reached")
+}
+
+func TestUserLocks(t *testing.T) {
+	var locks userLocks
+	unlock := locks.lock("user1")
+
+	// Other users are not blocked.
+	done := make(chan struct{})
+	go func() {
+		locks.lock("user2")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "lock of another user is blocked")
+	}
+
+	// The same user is blocked until the lock is released.
+	acquired := make(chan struct{})
+	go func() {
+		unlockAgain := locks.lock("user1")
+		close(acquired)
+		unlockAgain()
+	}()
+	select {
+	case <-acquired:
+		require.Fail(t, "lock of the same user is not blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	<-acquired
+
+	require.Eventually(t, func() bool {
+		locks.mu.Lock()
+		defer locks.mu.Unlock()
+		return len(locks.locks) == 0
+	}, time.Second, 10*time.Millisecond, "released locks are not removed")
+}
+
+func TestCreateEvent_ClientID(t *testing.T) {
+	id := uuid.New()
+	storage := new(mocks.Storage)
+	storage.On("CreateEvent", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, event *types.Event) (*types.Event, error) {
+			return event, nil
+		}).Once()
+	app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+	event, err := app.CreateEvent(context.Background(), &dto.CreateEventInput{
+		ID:       &id,
+		Title:    "Event",
+		Datetime: time.Now().Add(time.Hour),
+		Duration: time.Hour,
+		UserID:   "user1",
+	})
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, id, event.ID, "client-chosen ID is not applied")
+	storage.AssertExpectations(t)
+}
+
 func TestGetEvents_RetryLogic(t *testing.T) {
 	t.Run("success/retryable error", retryableSuccess)
 	t.Run("success/retryable storage error", retryableStorageErrorSuccess)
@@ -274,3 +479,334 @@ func nonRetryable(t *testing.T) {
 
 	storage.AssertNumberOfCalls(t, "GetEvent", 1)
 }
+
+func TestCleanupEvents(t *testing.T) {
+	storage := new(mocks.Storage)
+	before := time.Date(2020, 1, 16, 0, 0, 0, 0, time.UTC)
+	storage.On("DeleteOldEvents", mock.Anything, before).Return(int64(3), nil).Once()
+	// Zero border is replaced with the default one.
+	storage.On("DeleteOldEvents", mock.Anything, mock.MatchedBy(func(date time.Time) bool {
+		return date.Before(time.Now().AddDate(0, -11, 0)) && date.After(time.Now().AddDate(-1, 0, -1))
+	})).Return(int64(0), nil).Once()
+	app := &App{
+		s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond, cleanupRetention: defaultCleanupRetention,
+	}
+
+	deleted, err := app.CleanupEvents(context.Background(), before)
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, int64(3), deleted, "unexpected number of deleted events")
+
+	deleted, err = app.CleanupEvents(context.Background(), time.Time{})
+	require.NoError(t, err, "expected nil, got error")
+	require.Zero(t, deleted, "unexpected number of deleted events")
+
+	// Events within the retention are not deleted.
+	_, err = app.CleanupEvents(context.Background(), time.Now().AddDate(0, 0, -7))
+	require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+	_, err = app.CleanupEvents(context.Background(), time.Now().AddDate(1, 0, 0))
+	require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+
+	// Default border does not violate the long retention.
+	storage.On("DeleteOldEvents", mock.Anything, mock.MatchedBy(func(date time.Time) bool {
+		return date.Before(time.Now().AddDate(-2, 0, 0))
+	})).Return(int64(0), nil).Once()
+	app.cleanupRetention = 3 * 365 * 24 * time.Hour
+	_, err = app.CleanupEvents(context.Background(), time.Time{})
+	require.NoError(t, err, "expected nil, got error")
+
+	storage.AssertExpectations(t)
+}
+
+func TestGetEventChanges(t *testing.T) {
+	storage := new(mocks.Storage)
+	expected := &types.EventChanges{Seq: 5, Deleted: []uuid.UUID{uuid.New()}}
+	storage.On("GetEventChanges", mock.Anything, "user1", int64(3)).Return(expected, nil).Once()
+	// Expired changes are not retried.
+	storage.On("GetEventChanges", mock.Anything, "user1", int64(1)).
+		Return(nil, projectErrors.ErrChangesExpired).Once()
+	app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+	changes, err := app.GetEventChanges(context.Background(), "user1", 3)
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, expected, changes, "unexpected changes")
+
+	changes, err = app.GetEventChanges(context.Background(), "user1", 1)
+	require.ErrorIs(t, err, projectErrors.ErrChangesExpired, "expected error does not match")
+	require.Nil(t, changes, "changes should be nil on error")
+
+	storage.AssertExpectations(t)
+}
+
+func TestGetSchedulerStatus(t *testing.T) {
+	next := time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+
+	t.Run("waiting events", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CountEventsForNotification", mock.Anything).Return(int64(2), nil).Once()
+		storage.On("GetNextReminderTime", mock.Anything).Return(next, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		status, err := app.GetSchedulerStatus(context.Background())
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, &dto.SchedulerStatus{DueNotifications: 2, NextReminder: &next}, status)
+	})
+
+	t.Run("no waiting events", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CountEventsForNotification", mock.Anything).Return(int64(0), nil).Once()
+		storage.On("GetNextReminderTime", mock.Anything).
+			Return(time.Time{}, projectErrors.ErrEventNotFound).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		status, err := app.GetSchedulerStatus(context.Background())
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, &dto.SchedulerStatus{}, status)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CountEventsForNotification", mock.Anything).
+			Return(int64(0), projectErrors.ErrPermissionDenied).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		_, err := app.GetSchedulerStatus(context.Background())
+		require.ErrorIs(t, err, projectErrors.ErrPermissionDenied, "unexpected error")
+	})
+}
+
+func TestGetMissedReminders(t *testing.T) {
+	outageStart := time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+	outageEnd := outageStart.Add(time.Hour)
+	newEvent := func(title string, start time.Time, notified bool) *types.Event {
+		return &types.Event{ID: uuid.New(), EventData: types.EventData{
+			Title: title, Datetime: start, UserID: "user", RemindIn: 30 * time.Minute, IsNotified: notified,
+		}}
+	}
+	// Reminders at 10:00, 10:20 and 10:50, events start at 10:30, 10:50 and 11:20.
+	events := []*types.Event{
+		newEvent("Started long ago", outageStart.Add(30*time.Minute), true),
+		newEvent("Started recently", outageStart.Add(50*time.Minute), true),
+		newEvent("Not started", outageStart.Add(80*time.Minute), false),
+	}
+
+	testCases := []struct {
+		name     string
+		policy   string
+		grace    time.Duration
+		outcomes []string
+	}{
+		{"always", "", 0, []string{dto.MissedReminderLate, dto.MissedReminderLate, dto.MissedReminderDelayed}},
+		{"skip", "skip", 0, []string{dto.MissedReminderSkipped, dto.MissedReminderSkipped, dto.MissedReminderDelayed}},
+		{
+			"grace", "grace", 15 * time.Minute,
+			[]string{dto.MissedReminderSkipped, dto.MissedReminderLate, dto.MissedReminderDelayed},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			storage := new(mocks.Storage)
+			storage.On("GetEventsForReminderPeriod", mock.Anything, outageStart, outageEnd).Return(events, nil).Once()
+			app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+			res, err := app.GetMissedReminders(context.Background(), &dto.MissedRemindersInput{
+				OutageStart:   outageStart,
+				OutageEnd:     outageEnd,
+				CatchUpPolicy: tC.policy,
+				CatchUpGrace:  tC.grace,
+			})
+			require.NoError(t, err, "expected nil, got error")
+			require.Len(t, res, len(events))
+			for i, reminder := range res {
+				require.Equal(t, tC.outcomes[i], reminder.Outcome, "unexpected outcome of %q", reminder.Title)
+			}
+			require.Equal(t, time.Hour, res[0].Delay, "unexpected delay")
+			require.Equal(t, outageStart, res[0].RemindAt, "unexpected reminder time")
+			require.True(t, res[0].Notified, "unexpected notification flag")
+			storage.AssertExpectations(t)
+		})
+	}
+
+	t.Run("no reminders", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEventsForReminderPeriod", mock.Anything, outageStart, outageEnd).
+			Return(nil, projectErrors.ErrEventNotFound).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.GetMissedReminders(context.Background(), &dto.MissedRemindersInput{
+			OutageStart: outageStart,
+			OutageEnd:   outageEnd,
+		})
+		require.NoError(t, err, "expected nil, got error")
+		require.Empty(t, res)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		app := &App{s: new(mocks.Storage), l: new(mocks.Logger), retryTimeout: time.Millisecond}
+		ctx := context.Background()
+
+		_, err := app.GetMissedReminders(ctx, nil)
+		require.ErrorIs(t, err, projectErrors.ErrNoData)
+		_, err = app.GetMissedReminders(ctx, &dto.MissedRemindersInput{OutageStart: outageStart})
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+		_, err = app.GetMissedReminders(ctx, &dto.MissedRemindersInput{OutageStart: outageEnd, OutageEnd: outageStart})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.GetMissedReminders(ctx, &dto.MissedRemindersInput{
+			OutageStart: outageStart, OutageEnd: outageEnd, CatchUpPolicy: "never",
+		})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+	})
+}
+
+func TestDigestSettings(t *testing.T) {
+	stored := &types.DigestSettings{
+		UserID: "user", Enabled: true, Time: "08:30", TimeZone: "Europe/Moscow", SentDate: "2030-01-16",
+	}
+
+	t.Run("set", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("SetDigestSettings", mock.Anything, &types.DigestSettings{
+			UserID: "user", Enabled: true, Time: "08:30", TimeZone: "Europe/Moscow",
+		}).Return(stored, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.SetDigestSettings(context.Background(), &dto.DigestSettingsInput{
+			UserID: "user", Enabled: true, Time: "8:30", TimeZone: "Europe/Moscow",
+		})
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, stored, res)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetDigestSettings", mock.Anything, "user").Return(stored, nil).Once()
+		storage.On("GetDigestSettings", mock.Anything, "unknown").
+			Return(nil, projectErrors.ErrDigestSettingsNotFound).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.GetDigestSettings(context.Background(), "user")
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, stored, res)
+
+		_, err = app.GetDigestSettings(context.Background(), "unknown")
+		require.ErrorIs(t, err, projectErrors.ErrDigestSettingsNotFound)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		app := &App{s: new(mocks.Storage), l: new(mocks.Logger), retryTimeout: time.Millisecond}
+		ctx := context.Background()
+
+		_, err := app.SetDigestSettings(ctx, nil)
+		require.ErrorIs(t, err, projectErrors.ErrNoData)
+		_, err = app.SetDigestSettings(ctx, &dto.DigestSettingsInput{UserID: "user"})
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+		_, err = app.SetDigestSettings(ctx, &dto.DigestSettingsInput{UserID: "user", Time: "25:00"})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.SetDigestSettings(ctx, &dto.DigestSettingsInput{UserID: "user", Time: "08:00", TimeZone: "Mars"})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.GetDigestSettings(ctx, "")
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+	})
+}
+
+// fakeRenderer renders the notification title, recording the received settings.
+type fakeRenderer struct {
+	settings *types.UserSettings
+}
+
+func (r *fakeRenderer) Render(_ context.Context, channel string, n *types.Notification,
+	settings *types.UserSettings,
+) (*types.Message, error) {
+	if channel != "log" {
+		return nil, projectErrors.ErrTemplateNotFound
+	}
+	r.settings = settings
+	return &types.Message{Channel: channel, Locale: settings.Locale, Body: n.Title}, nil
+}
+
+func TestPreviewNotification(t *testing.T) {
+	id := uuid.New()
+	event := &types.Event{ID: id, EventData: types.EventData{Title: "Meeting", UserID: "user"}}
+
+	t.Run("success", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEvent", mock.Anything, id).Return(event, nil).Once()
+		renderer := &fakeRenderer{}
+		app, err := NewApp(new(mocks.Logger), storage, map[string]any{
+			"retries":       0,
+			"retry_timeout": time.Millisecond,
+		}, WithRenderer(renderer))
+		require.NoError(t, err, "expected nil, got error")
+
+		msg, err := app.PreviewNotification(context.Background(), &dto.PreviewNotificationInput{
+			EventID:  id.String(),
+			Channel:  "log",
+			Locale:   "ru",
+			TimeZone: "Europe/Moscow",
+		})
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, &types.Message{Channel: "log", Locale: "ru", Body: "Meeting"}, msg)
+		require.Equal(t, &types.UserSettings{UserID: "user", Locale: "ru", TimeZone: "Europe/Moscow"},
+			renderer.settings, "overrides are passed to the renderer")
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEvent", mock.Anything, id).Return(event, nil)
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond, r: &fakeRenderer{}}
+		ctx := context.Background()
+
+		_, err := app.PreviewNotification(ctx, nil)
+		require.ErrorIs(t, err, projectErrors.ErrNoData)
+		_, err = app.PreviewNotification(ctx, &dto.PreviewNotificationInput{EventID: id.String()})
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+		_, err = app.PreviewNotification(ctx, &dto.PreviewNotificationInput{EventID: "invalid", Channel: "log"})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.PreviewNotification(ctx, &dto.PreviewNotificationInput{EventID: id.String(), Channel: "sms"})
+		require.ErrorIs(t, err, projectErrors.ErrTemplateNotFound)
+
+		app.r = nil
+		_, err = app.PreviewNotification(ctx, &dto.PreviewNotificationInput{EventID: id.String(), Channel: "log"})
+		require.ErrorIs(t, err, projectErrors.ErrTemplateNotFound, "no renderer configured")
+	})
+}
+
+func TestReload(t *testing.T) {
+	app, err := NewApp(&mocks.Logger{}, &mocks.Storage{}, map[string]any{
+		"retries":       3,
+		"retry_timeout": time.Millisecond * 100,
+	})
+	require.NoError(t, err, "expected nil, got error")
+
+	err = app.Reload(map[string]any{"retries": 5, "retry_timeout": time.Second})
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, 5, app.retries, "retries are not reloaded")
+	require.Equal(t, time.Second, app.retryTimeout, "retry timeout is not reloaded")
+
+	err = app.Reload(map[string]any{"retries": 1, "retry_timeout": time.Duration(0)})
+	require.ErrorIs(t, err, projectErrors.ErrCorruptedConfig, "unexpected error")
+	require.Equal(t, 5, app.retries, "invalid config is partially applied")
+}
+
+func TestValidateConfig(t *testing.T) {
+	err := ValidateConfig(map[string]any{
+		"retries":       3,
+		"retry_timeout": time.Millisecond * 100,
+	})
+	require.NoError(t, err, "expected nil, got error")
+
+	err = ValidateConfig(map[string]any{
+		"retry_timeout":       time.Duration(0),
+		"overlap_policy":      "unknown",
+		"max_events_per_user": "10",
+	})
+	require.ErrorIs(t, err, projectErrors.ErrCorruptedConfig, "unexpected error")
+	var ve *pkgConfig.ValidationError
+	require.ErrorAs(t, err, &ve, "expected validation error")
+	require.Equal(t, []string{"retries"}, ve.Missing, "unexpected missing fields")
+	require.Equal(t, []string{"max_events_per_user"}, ve.InvalidType, "unexpected invalid type fields")
+	require.ElementsMatch(t, []string{"retry_timeout", "overlap_policy"}, ve.InvalidValue,
+		"unexpected invalid value fields")
+}
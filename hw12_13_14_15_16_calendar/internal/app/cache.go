@@ -0,0 +1,299 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"   //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/lru"   //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                //nolint:depguard,nolintlint
+)
+
+const (
+	// defaultCacheTTL is the default time to keep the cached query results.
+	defaultCacheTTL = time.Minute
+	// maxCacheUsers is the maximal number of the user generations kept by the cache.
+	// Exceeding it resets the generations and invalidates all period queries.
+	maxCacheUsers = 10000
+)
+
+// cachedStorage is a read-through caching decorator of Storage.
+//
+// Events are cached by ID and the results of the period queries - by the query, user, range and filter.
+// Errors, including ErrEventNotFound, are not cached. Any modification made through the decorator removes
+// the event from the cache and invalidates the period queries of its user and of all users. Modifications made
+// by other processes, e.g. notification marks of the scheduler, become visible after the TTL at most.
+//
+// Methods, which are not overridden, are passed to the underlying storage as is.
+type cachedStorage struct {
+	Storage
+	events  lru.Cache[uuid.UUID, *types.Event]
+	periods lru.Cache[string, []*types.Event]
+	size    int
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	version uint64            // Incremented on each modification. Results of the older reads are not cached.
+	epoch   uint64            // Incremented on the modifications of unknown users, invalidates all period queries.
+	users   map[string]uint64 // Generations of the period queries by user. "" stands for the queries of all users.
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// newCachedStorage wraps the storage with the caches of the given size each and the TTL of the results.
+// TTL <= 0 means the default TTL.
+func newCachedStorage(s Storage, size int, ttl time.Duration) *cachedStorage {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &cachedStorage{
+		Storage: s,
+		events:  lru.NewCache[uuid.UUID, *types.Event](size, ttl),
+		periods: lru.NewCache[string, []*types.Event](size, ttl),
+		size:    size,
+		ttl:     ttl,
+		users:   make(map[string]uint64),
+	}
+}
+
+// stats returns the cache statistics.
+func (c *cachedStorage) stats() *dto.CacheStats {
+	return &dto.CacheStats{
+		Enabled: true,
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Events:  c.events.Len(),
+		Periods: c.periods.Len(),
+		Size:    c.size,
+		TTL:     c.ttl,
+	}
+}
+
+// CreateEvent creates the event and invalidates the period queries of its user.
+func (c *cachedStorage) CreateEvent(ctx context.Context, event *types.Event) (*types.Event, error) {
+	res, err := c.Storage.CreateEvent(ctx, event)
+	if event != nil {
+		c.invalidate(nil, event.UserID)
+	}
+	return res, err
+}
+
+// UpdateEvent updates the event and invalidates it along with the period queries of its previous and new users.
+func (c *cachedStorage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.EventData) (*types.Event, error) {
+	users := []string{c.ownerOf(id)}
+	if data != nil {
+		users = append(users, data.UserID)
+	}
+	res, err := c.Storage.UpdateEvent(ctx, id, data)
+	c.invalidate(&id, users...)
+	return res, err
+}
+
+// DeleteEvent deletes the event and invalidates it along with the period queries of its user.
+func (c *cachedStorage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+	user := c.ownerOf(id)
+	err := c.Storage.DeleteEvent(ctx, id)
+	c.invalidate(&id, user)
+	return err
+}
+
+// DeleteOldEvents deletes the old events and clears the caches along with the user generations.
+func (c *cachedStorage) DeleteOldEvents(ctx context.Context, date time.Time) (int64, error) {
+	res, err := c.Storage.DeleteOldEvents(ctx, date)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version++
+	c.resetUsers()
+	c.events.Clear()
+	c.periods.Clear()
+	return res, err
+}
+
+// GetEvent returns the cached event or retrieves it from the storage, caching the result.
+func (c *cachedStorage) GetEvent(ctx context.Context, id uuid.UUID) (*types.Event, error) {
+	if event, ok := c.events.Get(id); ok {
+		c.hits.Add(1)
+		return types.DeepCopyEvent(event), nil
+	}
+	c.misses.Add(1)
+
+	version := c.currentVersion()
+	event, err := c.Storage.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.store(version, func() {
+		c.events.Set(id, types.DeepCopyEvent(event))
+	})
+	return event, nil
+}
+
+// GetAllUserEvents returns the cached events of the user or retrieves them from the storage, caching the result.
+func (c *cachedStorage) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
+	return c.query("all", time.Time{}, time.Time{}, &userID, nil, func() ([]*types.Event, error) {
+		return c.Storage.GetAllUserEvents(ctx, userID)
+	})
+}
+
+// GetEventsForDay returns the cached events of the day or retrieves them from the storage, caching the result.
+func (c *cachedStorage) GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	return c.query("day", date, time.Time{}, userID, filter, func() ([]*types.Event, error) {
+		return c.Storage.GetEventsForDay(ctx, date, userID, filter)
+	})
+}
+
+// GetEventsForWeek returns the cached events of the week or retrieves them from the storage, caching the result.
+func (c *cachedStorage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	return c.query("week", date, time.Time{}, userID, filter, func() ([]*types.Event, error) {
+		return c.Storage.GetEventsForWeek(ctx, date, userID, filter)
+	})
+}
+
+// GetEventsForMonth returns the cached events of the month or retrieves them from the storage, caching the result.
+func (c *cachedStorage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	return c.query("month", date, time.Time{}, userID, filter, func() ([]*types.Event, error) {
+		return c.Storage.GetEventsForMonth(ctx, date, userID, filter)
+	})
+}
+
+// GetEventsForPeriod returns the cached events of the period or retrieves them from the storage,
+// caching the result.
+func (c *cachedStorage) GetEventsForPeriod(ctx context.Context, dateStart, dateEnd time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	return c.query("period", dateStart, dateEnd, userID, filter, func() ([]*types.Event, error) {
+		return c.Storage.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
+	})
+}
+
+// query returns the cached result of the period query or executes it, caching the result.
+func (c *cachedStorage) query(kind string, dateStart, dateEnd time.Time, userID *string,
+	filter *types.EventFilter, fn func() ([]*types.Event, error),
+) ([]*types.Event, error) {
+	key, version := c.queryKey(kind, dateStart, dateEnd, userID, filter)
+	if events, ok := c.periods.Get(key); ok {
+		c.hits.Add(1)
+		return cloneEvents(events), nil
+	}
+	c.misses.Add(1)
+
+	events, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	c.store(version, func() {
+		c.periods.Set(key, cloneEvents(events))
+	})
+	return events, nil
+}
+
+// queryKey returns the cache key of the period query, which includes the current generation of the user,
+// and the current version of the cache.
+func (c *cachedStorage) queryKey(kind string, dateStart, dateEnd time.Time, userID *string,
+	filter *types.EventFilter,
+) (string, uint64) {
+	// The user is quoted to separate the queries of all users from the ones of the user with empty ID.
+	user := "*"
+	if userID != nil {
+		user = fmt.Sprintf("%q", *userID)
+	}
+	filterKey := ""
+	if filter != nil {
+		filterKey = strings.Join(filter.Tags, ",")
+		if filter.Location != nil {
+			filterKey += fmt.Sprintf("|%q", *filter.Location)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	generation := c.users[""]
+	if userID != nil {
+		generation = c.users[*userID]
+	}
+	key := fmt.Sprintf("%s|%d|%d|%s|%d.%d|%s", kind, dateStart.UnixNano(), dateEnd.UnixNano(), user,
+		c.epoch, generation, filterKey)
+	return key, c.version
+}
+
+// currentVersion returns the current version of the cache.
+func (c *cachedStorage) currentVersion() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
+// store executes fn if the cache was not modified since the given version, so the results of the reads,
+// concurrent with the modifications, are not cached.
+func (c *cachedStorage) store(version uint64, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version == version {
+		fn()
+	}
+}
+
+// ownerOf returns the user of the cached event. Returns empty string if the event is not cached.
+func (c *cachedStorage) ownerOf(id uuid.UUID) string {
+	if event, ok := c.events.Get(id); ok {
+		return event.UserID
+	}
+	return ""
+}
+
+// invalidate removes the event with the given ID from the cache and invalidates the period queries
+// of the given users and of all users. Empty user is treated as unknown and invalidates all period queries.
+// The replaced period queries are not removed and are evicted as the least recently used ones.
+func (c *cachedStorage) invalidate(id *uuid.UUID, users ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	if id != nil {
+		c.events.Remove(*id)
+	}
+	c.users[""]++
+	for _, user := range users {
+		if user == "" {
+			c.epoch++
+			continue
+		}
+		c.users[user]++
+	}
+	if len(c.users) > maxCacheUsers {
+		c.resetUsers()
+	}
+}
+
+// resetUsers drops the user generations and increments the epoch, invalidating all period queries.
+// The keys of the dropped generations are not reused, as they include the previous epoch.
+// Must be called with the mutex held.
+func (c *cachedStorage) resetUsers() {
+	c.epoch++
+	clear(c.users)
+}
+
+// cloneEvents returns a copy of the events.
+func cloneEvents(events []*types.Event) []*types.Event {
+	if events == nil {
+		return nil
+	}
+	res := make([]*types.Event, len(events))
+	for i, event := range events {
+		res[i] = types.DeepCopyEvent(event)
+	}
+	return res
+}
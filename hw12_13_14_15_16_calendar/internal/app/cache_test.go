@@ -0,0 +1,244 @@
+//nolint:depguard,nolintlint
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app/mocks"            //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+func newCachedEvent(userID string) *types.Event {
+	return &types.Event{
+		ID: uuid.New(),
+		EventData: types.EventData{
+			Title:    "Event",
+			Datetime: time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC),
+			Duration: time.Hour,
+			UserID:   userID,
+			Tags:     []string{"work"},
+		},
+	}
+}
+
+func TestCachedStorage_GetEvent(t *testing.T) {
+	ctx := context.Background()
+	event := newCachedEvent("user1")
+
+	t.Run("read-through", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEvent", mock.Anything, event.ID).Return(event, nil).Once()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		res, err := c.GetEvent(ctx, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, event, res)
+
+		// The cached event is not affected by the changes of the returned one.
+		res.Tags[0] = "changed"
+		res, err = c.GetEvent(ctx, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"work"}, res.Tags)
+
+		stats := c.stats()
+		require.Equal(t, int64(1), stats.Hits)
+		require.Equal(t, int64(1), stats.Misses)
+		require.Equal(t, 1, stats.Events)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEvent", mock.Anything, event.ID).Return(nil, projectErrors.ErrEventNotFound).Once()
+		storage.On("GetEvent", mock.Anything, event.ID).Return(event, nil).Once()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		_, err := c.GetEvent(ctx, event.ID)
+		require.ErrorIs(t, err, projectErrors.ErrEventNotFound)
+		res, err := c.GetEvent(ctx, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, event, res)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		updated := newCachedEvent("user1")
+		updated.ID = event.ID
+		updated.Title = "Updated"
+
+		storage := new(mocks.Storage)
+		storage.On("GetEvent", mock.Anything, event.ID).Return(event, nil).Once()
+		storage.On("UpdateEvent", mock.Anything, event.ID, &updated.EventData).Return(updated, nil).Once()
+		storage.On("GetEvent", mock.Anything, event.ID).Return(updated, nil).Once()
+		storage.On("DeleteEvent", mock.Anything, event.ID).Return(nil).Once()
+		storage.On("GetEvent", mock.Anything, event.ID).Return(nil, projectErrors.ErrEventNotFound).Once()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		_, err := c.GetEvent(ctx, event.ID)
+		require.NoError(t, err)
+		_, err = c.UpdateEvent(ctx, event.ID, &updated.EventData)
+		require.NoError(t, err)
+		res, err := c.GetEvent(ctx, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Updated", res.Title)
+
+		require.NoError(t, c.DeleteEvent(ctx, event.ID))
+		_, err = c.GetEvent(ctx, event.ID)
+		require.ErrorIs(t, err, projectErrors.ErrEventNotFound)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestCachedStorage_PeriodQueries(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2030, 1, 16, 0, 0, 0, 0, time.UTC)
+	user1, user2 := "user1", "user2"
+	event1, event2 := newCachedEvent(user1), newCachedEvent(user2)
+
+	t.Run("queries are cached by user, range and filter", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEventsForDay", mock.Anything, date, &user1, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event1}, nil).Once()
+		storage.On("GetEventsForDay", mock.Anything, date, &user2, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event2}, nil).Once()
+		filter := types.NewEventFilter([]string{"work"}, nil)
+		storage.On("GetEventsForDay", mock.Anything, date, &user1, filter).Return([]*types.Event{event1}, nil).Once()
+		storage.On("GetEventsForWeek", mock.Anything, date, &user1, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event1}, nil).Once()
+		storage.On("GetEventsForPeriod", mock.Anything, date, date.AddDate(0, 0, 1), (*string)(nil),
+			(*types.EventFilter)(nil)).Return([]*types.Event{event1, event2}, nil).Once()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		for range 2 {
+			res, err := c.GetEventsForDay(ctx, date, &user1, nil)
+			require.NoError(t, err)
+			require.Equal(t, []*types.Event{event1}, res)
+			res, err = c.GetEventsForDay(ctx, date, &user2, nil)
+			require.NoError(t, err)
+			require.Equal(t, []*types.Event{event2}, res)
+			_, err = c.GetEventsForDay(ctx, date, &user1, filter)
+			require.NoError(t, err)
+			_, err = c.GetEventsForWeek(ctx, date, &user1, nil)
+			require.NoError(t, err)
+			res, err = c.GetEventsForPeriod(ctx, date, date.AddDate(0, 0, 1), nil, nil)
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+		}
+
+		stats := c.stats()
+		require.Equal(t, int64(5), stats.Hits)
+		require.Equal(t, int64(5), stats.Misses)
+		require.Equal(t, 5, stats.Periods)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("modifications invalidate the queries of the user and of all users", func(t *testing.T) {
+		created := newCachedEvent(user1)
+		storage := new(mocks.Storage)
+		storage.On("GetEventsForDay", mock.Anything, date, &user1, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event1}, nil).Once()
+		storage.On("GetEventsForDay", mock.Anything, date, &user2, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event2}, nil).Once()
+		storage.On("GetEventsForDay", mock.Anything, date, (*string)(nil), (*types.EventFilter)(nil)).
+			Return([]*types.Event{event1, event2}, nil).Once()
+		storage.On("CreateEvent", mock.Anything, created).Return(created, nil).Once()
+		storage.On("GetEventsForDay", mock.Anything, date, &user1, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event1, created}, nil).Once()
+		storage.On("GetEventsForDay", mock.Anything, date, (*string)(nil), (*types.EventFilter)(nil)).
+			Return([]*types.Event{event1, event2, created}, nil).Once()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		_, err := c.GetEventsForDay(ctx, date, &user1, nil)
+		require.NoError(t, err)
+		_, err = c.GetEventsForDay(ctx, date, &user2, nil)
+		require.NoError(t, err)
+		_, err = c.GetEventsForDay(ctx, date, nil, nil)
+		require.NoError(t, err)
+
+		_, err = c.CreateEvent(ctx, created)
+		require.NoError(t, err)
+
+		res, err := c.GetEventsForDay(ctx, date, &user1, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		res, err = c.GetEventsForDay(ctx, date, &user2, nil)
+		require.NoError(t, err, "queries of the other users are kept")
+		require.Len(t, res, 1)
+		res, err = c.GetEventsForDay(ctx, date, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("unknown user invalidates all queries", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEventsForDay", mock.Anything, date, &user2, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event2}, nil).Twice()
+		storage.On("DeleteEvent", mock.Anything, event1.ID).Return(nil).Once()
+		storage.On("DeleteOldEvents", mock.Anything, date).Return(int64(1), nil).Once()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		_, err := c.GetEventsForDay(ctx, date, &user2, nil)
+		require.NoError(t, err)
+		require.NoError(t, c.DeleteEvent(ctx, event1.ID))
+		_, err = c.GetEventsForDay(ctx, date, &user2, nil)
+		require.NoError(t, err)
+
+		_, err = c.DeleteOldEvents(ctx, date)
+		require.NoError(t, err)
+		require.Equal(t, 0, c.stats().Periods)
+		require.Empty(t, c.users, "user generations are kept after the cleanup")
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("user generations are bounded", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetEventsForDay", mock.Anything, date, &user2, (*types.EventFilter)(nil)).
+			Return([]*types.Event{event2}, nil).Twice()
+		c := newCachedStorage(storage, 10, time.Minute)
+
+		_, err := c.GetEventsForDay(ctx, date, &user2, nil)
+		require.NoError(t, err)
+		for i := range maxCacheUsers {
+			c.invalidate(nil, fmt.Sprintf("user-%d", i))
+		}
+		require.LessOrEqual(t, len(c.users), maxCacheUsers)
+
+		// Generation of user2 is reset, but the query of the previous epoch is not reused.
+		_, err = c.GetEventsForDay(ctx, date, &user2, nil)
+		require.NoError(t, err)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestNewApp_Cache(t *testing.T) {
+	config := map[string]any{
+		"retries":       1,
+		"retry_timeout": time.Millisecond,
+	}
+	a, err := NewApp(&mocks.Logger{}, &mocks.Storage{}, config)
+	require.NoError(t, err)
+	stats, err := a.GetCacheStats(context.Background())
+	require.NoError(t, err)
+	require.False(t, stats.Enabled)
+
+	config["cache_size"] = 100
+	a, err = NewApp(&mocks.Logger{}, &mocks.Storage{}, config)
+	require.NoError(t, err)
+	stats, err = a.GetCacheStats(context.Background())
+	require.NoError(t, err)
+	require.True(t, stats.Enabled)
+	require.Equal(t, 100, stats.Size)
+	require.Equal(t, defaultCacheTTL, stats.TTL)
+
+	config["cache_ttl"] = "1m"
+	_, err = NewApp(&mocks.Logger{}, &mocks.Storage{}, config)
+	require.ErrorIs(t, err, projectErrors.ErrCorruptedConfig)
+}
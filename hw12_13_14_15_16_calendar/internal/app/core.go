@@ -19,11 +19,32 @@ func (a *App) CreateEvent(ctx context.Context, input *dto.CreateEventInput) (*ty
 		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
 	}
 
+	var resEvent *types.Event
+
+	// Repeated requests with the same idempotency key return the originally created event.
+	if input.IdempotencyKey != "" && a.idempotency != nil {
+		key := idempotencyKey(input)
+		stored, err := a.idempotency.begin(ctx, key, fingerprint(input))
+		if err != nil {
+			return nil, fmt.Errorf(msg, err)
+		}
+		if stored != nil {
+			return stored, nil
+		}
+		defer func() { a.idempotency.finish(key, resEvent) }()
+	}
+
+	// All-day events are allowed to omit the duration, which defaults to a single day.
+	duration := input.Duration
+	if input.AllDay && duration == 0 {
+		duration = types.Day
+	}
+
 	// Constructing the Event object and validating it.
 	event, err := types.NewEvent(
 		input.Title,
 		input.Datetime,
-		input.Duration,
+		duration,
 		safeDereference(input.Description),
 		input.UserID,
 		safeDereference(input.RemindIn),
@@ -31,8 +52,30 @@ func (a *App) CreateEvent(ctx context.Context, input *dto.CreateEventInput) (*ty
 	if err != nil {
 		return nil, fmt.Errorf(msg, err)
 	}
+	if input.ID != nil {
+		event.ID = *input.ID
+	}
+	if err = a.setOverlapOptions(&event.EventData, input.BusyStatus, input.OverlapPolicy); err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+	event.SetAllDay(input.AllDay)
+	err = event.SetDetails(input.Tags, safeDereference(input.Color), safeDereference(input.Location),
+		safeDereference(input.URL))
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+	if err = event.SetResources(input.Resources); err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
 
-	var resEvent *types.Event
+	// Quota check and the creation are serialized per user, so concurrent requests cannot exceed the quota.
+	if a.maxEventsPerUser > 0 {
+		unlock := a.quotaLocks.lock(event.UserID)
+		defer unlock()
+		if err = a.checkQuota(ctx, method, event.UserID); err != nil {
+			return nil, fmt.Errorf(msg, err)
+		}
+	}
 
 	// Trying to save the object in the storage.
 	err = a.withRetries(ctx, method, func() error {
@@ -46,6 +89,7 @@ func (a *App) CreateEvent(ctx context.Context, input *dto.CreateEventInput) (*ty
 	if err != nil {
 		return nil, fmt.Errorf(msg, err)
 	}
+	a.notify(ctx, types.WebhookEventCreated, resEvent)
 
 	return resEvent, nil
 }
@@ -59,11 +103,18 @@ func (a *App) UpdateEvent(ctx context.Context, input *dto.UpdateEventInput) (*ty
 		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
 	}
 
+	// All-day events are allowed to omit the duration, which defaults to a single day.
+	allDay := safeDereference(input.AllDay)
+	duration := safeDereference(input.Duration)
+	if allDay && duration == 0 {
+		duration = types.Day
+	}
+
 	// Constructing the Event object and validating it.
 	eventData, err := types.NewEventData(
 		safeDereference(input.Title),
 		safeDereference(input.Datetime),
-		safeDereference(input.Duration),
+		duration,
 		safeDereference(input.Description),
 		safeDereference(input.UserID),
 		safeDereference(input.RemindIn),
@@ -71,6 +122,18 @@ func (a *App) UpdateEvent(ctx context.Context, input *dto.UpdateEventInput) (*ty
 	if err != nil {
 		return nil, fmt.Errorf(msg, err)
 	}
+	if err = a.setOverlapOptions(eventData, input.BusyStatus, input.OverlapPolicy); err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+	eventData.SetAllDay(allDay)
+	err = eventData.SetDetails(input.Tags, safeDereference(input.Color), safeDereference(input.Location),
+		safeDereference(input.URL))
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+	if err = eventData.SetResources(input.Resources); err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
 
 	var resEvent *types.Event
 
@@ -86,6 +149,7 @@ func (a *App) UpdateEvent(ctx context.Context, input *dto.UpdateEventInput) (*ty
 	if err != nil {
 		return nil, fmt.Errorf(msg, err)
 	}
+	a.notify(ctx, types.WebhookEventUpdated, resEvent)
 
 	return resEvent, nil
 }
@@ -101,6 +165,22 @@ func (a *App) DeleteEvent(ctx context.Context, id string) error {
 		return fmt.Errorf(msg, err)
 	}
 
+	// The deleted event is delivered to the webhooks, so it is retrieved beforehand.
+	var deleted *types.Event
+	if a.n != nil {
+		err = a.withRetries(ctx, method, func() error {
+			event, err := a.s.GetEvent(ctx, *uuidID)
+			if err != nil {
+				return err
+			}
+			deleted = event
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf(msg, err)
+		}
+	}
+
 	// Trying to update the object in the storage.
 	err = a.withRetries(ctx, method, func() error {
 		err := a.s.DeleteEvent(ctx, *uuidID)
@@ -112,6 +192,7 @@ func (a *App) DeleteEvent(ctx context.Context, id string) error {
 	if err != nil {
 		return fmt.Errorf(msg, err)
 	}
+	a.notify(ctx, types.WebhookEventDeleted, deleted)
 
 	return nil
 }
@@ -168,6 +249,31 @@ func (a *App) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Eve
 	return events, nil
 }
 
+// GetEventChanges is trying to get the events of a given user ID, changed after the since sequence number,
+// and the IDs of the deleted ones from the storage. since = 0 means all events of the user.
+//
+// Returns ErrChangesExpired if the changes since the given sequence number are no longer available.
+func (a *App) GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error) {
+	method := "GetEventChanges"
+	msg := method + ": %w"
+
+	var changes *types.EventChanges
+
+	err := a.withRetries(ctx, method, func() error {
+		res, err := a.s.GetEventChanges(ctx, userID, since)
+		if err != nil {
+			return err
+		}
+		changes = res
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return changes, nil
+}
+
 // ListEvents is trying to get all events for a given user ID from the storage.
 //
 // period is the period of time to get events for, stratring from the given date.
@@ -198,6 +304,7 @@ func (a *App) ListEvents(ctx context.Context, input *dto.DateFilterInput) ([]*ty
 	}
 
 	var events []*types.Event
+	filter := types.NewEventFilter(input.Tags, input.Location)
 
 	// Trying to save the object in the storage.
 	err := a.withRetries(ctx, method, func() error {
@@ -205,11 +312,11 @@ func (a *App) ListEvents(ctx context.Context, input *dto.DateFilterInput) ([]*ty
 		var err error
 		switch input.Period {
 		case dto.Day:
-			res, err = a.s.GetEventsForDay(ctx, input.Date, input.UserID)
+			res, err = a.s.GetEventsForDay(ctx, input.Date, input.UserID, filter)
 		case dto.Week:
-			res, err = a.s.GetEventsForWeek(ctx, input.Date, input.UserID)
+			res, err = a.s.GetEventsForWeek(ctx, input.Date, input.UserID, filter)
 		case dto.Month:
-			res, err = a.s.GetEventsForMonth(ctx, input.Date, input.UserID)
+			res, err = a.s.GetEventsForMonth(ctx, input.Date, input.UserID, filter)
 		}
 
 		if err != nil {
@@ -238,10 +345,11 @@ func (a *App) GetEventsForPeriod(ctx context.Context, input *dto.DateRangeInput)
 	}
 
 	var events []*types.Event
+	filter := types.NewEventFilter(input.Tags, input.Location)
 
 	// Trying to save the object in the storage.
 	err := a.withRetries(ctx, method, func() error {
-		res, err := a.s.GetEventsForPeriod(ctx, input.DateStart, input.DateEnd, input.UserID)
+		res, err := a.s.GetEventsForPeriod(ctx, input.DateStart, input.DateEnd, input.UserID, filter)
 		if err != nil {
 			return err
 		}
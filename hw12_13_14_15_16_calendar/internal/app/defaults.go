@@ -7,3 +7,13 @@ var expectedFields = map[string]any{
 	"retries":       int(0),
 	"retry_timeout": time.Duration(0),
 }
+
+// optionalFields is a map of optional configuration fields and their default values.
+var optionalFields = map[string]any{
+	"overlap_policy":      "",
+	"max_events_per_user": int(0),
+	"idempotency_ttl":     time.Duration(0),
+	"cache_size":          int(0),
+	"cache_ttl":           time.Duration(0),
+	"cleanup_retention":   time.Duration(0),
+}
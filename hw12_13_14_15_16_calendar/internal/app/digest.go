@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// SetDigestSettings is trying to validate and store the daily digest settings of the user.
+// The date of the last sent digest is kept, so the digest is not sent twice a day on the settings change.
+//
+// Returns the stored settings, nil on success and nil, error otherwise.
+func (a *App) SetDigestSettings(ctx context.Context, input *dto.DigestSettingsInput) (*types.DigestSettings, error) {
+	method := "SetDigestSettings"
+	msg := method + ": %w"
+
+	if input == nil {
+		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
+	}
+
+	settings, err := types.NewDigestSettings(input.UserID, input.Enabled, input.Time, input.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	var res *types.DigestSettings
+	err = a.withRetries(ctx, method, func() error {
+		stored, err := a.s.SetDigestSettings(ctx, settings)
+		if err != nil {
+			return err
+		}
+		res = stored
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
+
+// GetDigestSettings is trying to get the daily digest settings of the user from the storage.
+// Returns the settings, nil on success and nil, error otherwise.
+func (a *App) GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error) {
+	method := "GetDigestSettings"
+	msg := method + ": %w"
+
+	if userID == "" {
+		return nil, fmt.Errorf(msg, fmt.Errorf("%w: user_id", projectErrors.ErrEmptyField))
+	}
+
+	var res *types.DigestSettings
+	err := a.withRetries(ctx, method, func() error {
+		settings, err := a.s.GetDigestSettings(ctx, userID)
+		if err != nil {
+			return err
+		}
+		res = settings
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
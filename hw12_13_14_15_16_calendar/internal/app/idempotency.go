@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+const (
+	defaultIdempotencyTTL      = 24 * time.Hour // Default time to keep the results of the requests.
+	idempotencyCleanupInterval = time.Minute    // Minimal interval between the removals of the expired results.
+)
+
+// idempotencyStore keeps the created events by the idempotency keys of their requests.
+//
+// The data is kept in the app memory, so it is not shared between the app instances and lost on restart.
+type idempotencyStore struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	entries     map[string]*idempotencyEntry
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte // Hash of the request payload.
+	event       *types.Event      // Nil while the request is in progress.
+	done        chan struct{}     // Closed once the request is finished.
+	expiresAt   time.Time
+}
+
+// newIdempotencyStore creates a new store, keeping the results for the given TTL.
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// idempotencyKey returns the store key of the request. Keys are scoped by the user.
+func idempotencyKey(input *dto.CreateEventInput) string {
+	return input.UserID + "\x00" + input.IdempotencyKey
+}
+
+// fingerprint returns the hash of the request payload. The idempotency key itself is not a part of the payload.
+func fingerprint(input *dto.CreateEventInput) [sha256.Size]byte {
+	// Marshaling of the plain DTO never fails.
+	data, _ := json.Marshal(input)
+	return sha256.Sum256(data)
+}
+
+// begin reserves the key for the request with the given payload fingerprint.
+//
+// Returns the stored event if the request was already completed. If the request with the same key is in progress,
+// begin waits for it to finish. Returns (nil, nil) if the caller must perform the request and call finish.
+//
+// Returns ErrIdempotencyConflict if the key is already used with another payload.
+func (st *idempotencyStore) begin(ctx context.Context, key string, fp [sha256.Size]byte) (*types.Event, error) {
+	for {
+		st.mu.Lock()
+		now := st.now()
+		st.cleanup(now)
+
+		entry, ok := st.entries[key]
+		if ok && entry.event != nil && !now.Before(entry.expiresAt) {
+			delete(st.entries, key)
+			ok = false
+		}
+		if !ok {
+			st.entries[key] = &idempotencyEntry{fingerprint: fp, done: make(chan struct{})}
+			st.mu.Unlock()
+			return nil, nil
+		}
+		if entry.fingerprint != fp {
+			st.mu.Unlock()
+			return nil, projectErrors.ErrIdempotencyConflict
+		}
+		if entry.event != nil {
+			event := types.DeepCopyEvent(entry.event)
+			st.mu.Unlock()
+			return event, nil
+		}
+		done := entry.done
+		st.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", projectErrors.ErrTimeoutExceeded, ctx.Err())
+		case <-done:
+			// The request might have failed and released the key, so the lookup is repeated.
+		}
+	}
+}
+
+// finish stores the created event for the reserved key.
+// Nil event means the request failed, so the key is released and the request might be retried.
+func (st *idempotencyStore) finish(key string, event *types.Event) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	entry, ok := st.entries[key]
+	if !ok {
+		return
+	}
+	if event == nil {
+		delete(st.entries, key)
+	} else {
+		entry.event = types.DeepCopyEvent(event)
+		entry.expiresAt = st.now().Add(st.ttl)
+	}
+	close(entry.done)
+}
+
+// cleanup removes the expired results. It is performed not more often than idempotencyCleanupInterval.
+func (st *idempotencyStore) cleanup(now time.Time) {
+	if now.Sub(st.lastCleanup) < idempotencyCleanupInterval {
+		return
+	}
+	st.lastCleanup = now
+
+	for key, entry := range st.entries {
+		if entry.event != nil && !now.Before(entry.expiresAt) {
+			delete(st.entries, key)
+		}
+	}
+}
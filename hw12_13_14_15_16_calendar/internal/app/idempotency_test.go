@@ -0,0 +1,168 @@
+//nolint:depguard,nolintlint
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app/mocks"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// newIdempotentApp creates an app with the idempotency store and the storage, returning the created events as-is.
+func newIdempotentApp(storage *mocks.Storage) *App {
+	return &App{
+		s:            storage,
+		l:            new(mocks.Logger),
+		retryTimeout: time.Millisecond,
+		idempotency:  newIdempotencyStore(time.Hour),
+	}
+}
+
+func newIdempotentInput(key string) *dto.CreateEventInput {
+	return &dto.CreateEventInput{
+		Title:          "Event",
+		Datetime:       time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC),
+		Duration:       time.Hour,
+		UserID:         "user1",
+		IdempotencyKey: key,
+	}
+}
+
+func returnCreated(_ context.Context, event *types.Event) (*types.Event, error) {
+	return event, nil
+}
+
+func TestCreateEvent_Idempotency(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repeated key returns original event", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateEvent", mock.Anything, mock.Anything).Return(returnCreated).Once()
+		app := newIdempotentApp(storage)
+
+		first, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+		require.NoError(t, err, "expected nil, got error")
+		second, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, first.ID, second.ID, "repeated request created another event")
+
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("different payload conflicts", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateEvent", mock.Anything, mock.Anything).Return(returnCreated).Once()
+		app := newIdempotentApp(storage)
+
+		_, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+		require.NoError(t, err, "expected nil, got error")
+
+		input := newIdempotentInput("key")
+		input.Title = "Another event"
+		_, err = app.CreateEvent(ctx, input)
+		require.ErrorIs(t, err, projectErrors.ErrIdempotencyConflict, "unexpected error")
+
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("keys are scoped by user", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateEvent", mock.Anything, mock.Anything).Return(returnCreated).Twice()
+		app := newIdempotentApp(storage)
+
+		_, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+		require.NoError(t, err, "expected nil, got error")
+		input := newIdempotentInput("key")
+		input.UserID = "user2"
+		_, err = app.CreateEvent(ctx, input)
+		require.NoError(t, err, "expected nil, got error")
+
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("failed request releases key", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateEvent", mock.Anything, mock.Anything).Return(nil, projectErrors.ErrDateBusy).Once()
+		storage.On("CreateEvent", mock.Anything, mock.Anything).Return(returnCreated).Once()
+		app := newIdempotentApp(storage)
+
+		_, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+		require.ErrorIs(t, err, projectErrors.ErrDateBusy, "unexpected error")
+		event, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+		require.NoError(t, err, "expected nil, got error")
+		require.NotNil(t, event, "expected event on retry")
+
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("concurrent requests create single event", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateEvent", mock.Anything, mock.Anything).Return(returnCreated).Once()
+		app := newIdempotentApp(storage)
+
+		const workers = 10
+		events := make([]*types.Event, workers)
+		var wg sync.WaitGroup
+		for i := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				event, err := app.CreateEvent(ctx, newIdempotentInput("key"))
+				if err == nil {
+					events[i] = event
+				}
+			}()
+		}
+		wg.Wait()
+
+		for _, event := range events {
+			require.NotNil(t, event, "request failed")
+			require.Equal(t, events[0].ID, event.ID, "concurrent requests created different events")
+		}
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestIdempotencyStoreExpiration(t *testing.T) {
+	now := time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+	st := newIdempotencyStore(time.Hour)
+	st.now = func() time.Time { return now }
+	input := newIdempotentInput("key")
+	key, fp := idempotencyKey(input), fingerprint(input)
+
+	stored, err := st.begin(context.Background(), key, fp)
+	require.NoError(t, err, "expected nil, got error")
+	require.Nil(t, stored, "unexpected stored event")
+	st.finish(key, &types.Event{EventData: types.EventData{Title: input.Title}})
+
+	now = now.Add(59 * time.Minute)
+	stored, err = st.begin(context.Background(), key, fp)
+	require.NoError(t, err, "expected nil, got error")
+	require.NotNil(t, stored, "event is expired too early")
+
+	now = now.Add(time.Minute)
+	stored, err = st.begin(context.Background(), key, fp)
+	require.NoError(t, err, "expected nil, got error")
+	require.Nil(t, stored, "event is not expired")
+}
+
+func TestIdempotencyStoreWaitCanceled(t *testing.T) {
+	st := newIdempotencyStore(time.Hour)
+	input := newIdempotentInput("key")
+	key, fp := idempotencyKey(input), fingerprint(input)
+
+	_, err := st.begin(context.Background(), key, fp)
+	require.NoError(t, err, "expected nil, got error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = st.begin(ctx, key, fp)
+	require.ErrorIs(t, err, projectErrors.ErrTimeoutExceeded, "unexpected error")
+}
@@ -36,21 +36,123 @@ type Storage interface {
 	// Returns a slice of events or an error if not found or the operation fails.
 	GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error)
 
-	// GetEventsForDay retrieves events for a specific day, optionally filtered by user ID.
+	// CountUserEvents counts the events of a given user ID.
+	// Returns the number of events, which is 0 if the user has no events, or an error if the operation fails.
+	CountUserEvents(ctx context.Context, userID string) (int64, error)
+
+	// GetEventChanges retrieves the events of a given user ID, changed after the since sequence number,
+	// and the IDs of the deleted ones. since = 0 means all events of the user.
+	// Returns the changes or an error if the changes since the point are no longer kept or the operation fails.
+	GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error)
+
+	// GetEventsForDay retrieves events for a specific day, optionally filtered by user ID and filter.
+	// Returns a slice of events or an error if not found or the operation fails.
+	GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
+
+	// GetEventsForWeek retrieves events for a specific week, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForDay(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForWeek(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
-	// GetEventsForWeek retrieves events for a specific week, optionally filtered by user ID.
+	// GetEventsForMonth retrieves events for a specific month, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForWeek(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForMonth(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
-	// GetEventsForMonth retrieves events for a specific month, optionally filtered by user ID.
+	// GetEventsForPeriod retrieves events for a given period, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForMonth(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForPeriod(ctx context.Context, dateStart, dateEnd time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
-	// GetEventsForPeriod retrieves events for a given period, optionally filtered by user ID.
+	// GetEventsForNotification retrieves events for notification, optionally filtered by user ID.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForPeriod(ctx context.Context, dateStart, dateEnd time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForNotification(ctx context.Context) ([]*types.Event, error)
+
+	// CountEventsForNotification counts the events, which require notification, without reading them.
+	// Returns the number of events, which is 0 if there are none, or an error if the operation fails.
+	CountEventsForNotification(ctx context.Context) (int64, error)
+
+	// GetNextReminderTime retrieves the earliest reminder time among the events waiting for notification.
+	// Returns the reminder time or an error if not found or the operation fails.
+	GetNextReminderTime(ctx context.Context) (time.Time, error)
+
+	// GetEventsForReminderPeriod retrieves events whose reminder time is within [dateStart, dateEnd),
+	// notified or not, sorted by the reminder time.
+	// Returns a slice of events or an error if not found or the operation fails.
+	GetEventsForReminderPeriod(ctx context.Context, dateStart, dateEnd time.Time) ([]*types.Event, error)
+
+	// DeleteOldEvents deletes old events from the storage.
+	// Returns the number of deleted events or an error if the operation fails.
+	DeleteOldEvents(ctx context.Context, date time.Time) (int64, error)
+
+	// SetDigestSettings creates or replaces the daily digest settings of the user, keeping the last sent date.
+	// Returns the stored settings or an error if the operation fails.
+	SetDigestSettings(ctx context.Context, settings *types.DigestSettings) (*types.DigestSettings, error)
+
+	// GetDigestSettings retrieves the daily digest settings of the user.
+	// Returns the settings or an error if not found or the operation fails.
+	GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error)
+
+	// CreateResource saves the new bookable resource.
+	// Returns the stored resource or an error if the operation fails.
+	CreateResource(ctx context.Context, resource *types.Resource) (*types.Resource, error)
+
+	// GetResource retrieves the bookable resource by ID.
+	// Returns the resource or an error if not found or the operation fails.
+	GetResource(ctx context.Context, id uuid.UUID) (*types.Resource, error)
+
+	// GetResources retrieves all bookable resources, sorted by the name.
+	// Returns a slice of resources, which is empty if there are none, or an error if the operation fails.
+	GetResources(ctx context.Context) ([]*types.Resource, error)
+
+	// DeleteResource deletes the bookable resource by ID, unless it is booked by the events ending after since.
+	// Past bookings of the resource are kept by the events.
+	// Returns an error if not found, still booked or the operation fails.
+	DeleteResource(ctx context.Context, id uuid.UUID, since time.Time) error
+
+	// GetResourceEvents retrieves the events booking the resource, which overlap with [dateStart, dateEnd),
+	// sorted by the datetime.
+	// Returns a slice of events, which is empty if there are none, or an error if the operation fails.
+	GetResourceEvents(ctx context.Context, resourceID uuid.UUID, dateStart, dateEnd time.Time) ([]*types.Event, error)
+
+	// CreateWebhook saves the new webhook subscription.
+	// Returns the stored subscription or an error if the operation fails.
+	CreateWebhook(ctx context.Context, webhook *types.Webhook) (*types.Webhook, error)
+
+	// GetWebhook retrieves the webhook subscription by ID.
+	// Returns the subscription or an error if not found or the operation fails.
+	GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error)
+
+	// GetWebhooks retrieves all webhook subscriptions sorted by the creation time.
+	// Returns a slice of subscriptions, which is empty if there are none, or an error if the operation fails.
+	GetWebhooks(ctx context.Context) ([]*types.Webhook, error)
+
+	// DeleteWebhook deletes the webhook subscription along with its delivery log.
+	// Returns an error if the subscription is not found or the operation fails.
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+
+	// UpdateWebhookState sets whether the subscription is enabled and its number of consecutive failures.
+	// Returns an error if the subscription is not found or the operation fails.
+	UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error
+
+	// GetWebhookDeliveries retrieves the latest delivery attempts of the subscription, newest first.
+	// limit <= 0 means no limit. Returns a slice of attempts or an error if the operation fails.
+	GetWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*types.WebhookDelivery, error)
+}
+
+// Notifier represents an interface of webhook notifier visible to the app.
+type Notifier interface {
+	// NotifyEvent queues the delivery of the event change to the webhook subscriptions without blocking.
+	NotifyEvent(ctx context.Context, eventType types.WebhookEventType, event *types.Event)
+}
+
+// Renderer represents an interface of notification renderer visible to the app.
+type Renderer interface {
+	// Render renders the notification for the given channel with the optional locale and time zone overrides.
+	// Returns the rendered message or an error if there is no suitable template or rendering fails.
+	Render(ctx context.Context, channel string, n *types.Notification,
+		settings *types.UserSettings) (*types.Message, error)
 }
 
 // Logger represents an interface of logger visible to the app.
@@ -0,0 +1,42 @@
+package app
+
+import "sync"
+
+// userLocks serializes the operations of the same user, letting the operations of different users run concurrently.
+// The zero value is ready to use.
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[string]*userLock
+}
+
+type userLock struct {
+	mu   sync.Mutex
+	refs int // Number of the holders and waiters. The lock is removed once there are none.
+}
+
+// lock acquires the lock of the user. Returns the function releasing it.
+func (l *userLocks) lock(userID string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*userLock)
+	}
+	ul, ok := l.locks[userID]
+	if !ok {
+		ul = &userLock{}
+		l.locks[userID] = ul
+	}
+	ul.refs++
+	l.mu.Unlock()
+
+	ul.mu.Lock()
+	return func() {
+		ul.mu.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		ul.refs--
+		if ul.refs == 0 {
+			delete(l.locks, userID)
+		}
+	}
+}
@@ -72,29 +72,1057 @@ func (_c *Storage_Connect_Call) RunAndReturn(run func(context.Context) error) *S
 	return _c
 }
 
+// CountEventsForNotification provides a mock function with given fields: ctx
+func (_m *Storage) CountEventsForNotification(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountEventsForNotification")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_CountEventsForNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountEventsForNotification'
+type Storage_CountEventsForNotification_Call struct {
+	*mock.Call
+}
+
+// CountEventsForNotification is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Storage_Expecter) CountEventsForNotification(ctx interface{}) *Storage_CountEventsForNotification_Call {
+	return &Storage_CountEventsForNotification_Call{Call: _e.mock.On("CountEventsForNotification", ctx)}
+}
+
+func (_c *Storage_CountEventsForNotification_Call) Run(run func(ctx context.Context)) *Storage_CountEventsForNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storage_CountEventsForNotification_Call) Return(_a0 int64, _a1 error) *Storage_CountEventsForNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_CountEventsForNotification_Call) RunAndReturn(run func(context.Context) (int64, error)) *Storage_CountEventsForNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUserEvents provides a mock function with given fields: ctx, userID
+func (_m *Storage) CountUserEvents(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUserEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_CountUserEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUserEvents'
+type Storage_CountUserEvents_Call struct {
+	*mock.Call
+}
+
+// CountUserEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Storage_Expecter) CountUserEvents(ctx interface{}, userID interface{}) *Storage_CountUserEvents_Call {
+	return &Storage_CountUserEvents_Call{Call: _e.mock.On("CountUserEvents", ctx, userID)}
+}
+
+func (_c *Storage_CountUserEvents_Call) Run(run func(ctx context.Context, userID string)) *Storage_CountUserEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storage_CountUserEvents_Call) Return(_a0 int64, _a1 error) *Storage_CountUserEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_CountUserEvents_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *Storage_CountUserEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEvent provides a mock function with given fields: ctx, event
 func (_m *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.Event, error) {
 	ret := _m.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateEvent")
+		panic("no return value specified for CreateEvent")
+	}
+
+	var r0 *types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Event) (*types.Event, error)); ok {
+		return rf(ctx, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Event) *types.Event); ok {
+		r0 = rf(ctx, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.Event) error); ok {
+		r1 = rf(ctx, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
+type Storage_CreateEvent_Call struct {
+	*mock.Call
+}
+
+// CreateEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *types.Event
+func (_e *Storage_Expecter) CreateEvent(ctx interface{}, event interface{}) *Storage_CreateEvent_Call {
+	return &Storage_CreateEvent_Call{Call: _e.mock.On("CreateEvent", ctx, event)}
+}
+
+func (_c *Storage_CreateEvent_Call) Run(run func(ctx context.Context, event *types.Event)) *Storage_CreateEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.Event))
+	})
+	return _c
+}
+
+func (_c *Storage_CreateEvent_Call) Return(_a0 *types.Event, _a1 error) *Storage_CreateEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_CreateEvent_Call) RunAndReturn(run func(context.Context, *types.Event) (*types.Event, error)) *Storage_CreateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateResource provides a mock function with given fields: ctx, resource
+func (_m *Storage) CreateResource(ctx context.Context, resource *types.Resource) (*types.Resource, error) {
+	ret := _m.Called(ctx, resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateResource")
+	}
+
+	var r0 *types.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Resource) (*types.Resource, error)); ok {
+		return rf(ctx, resource)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Resource) *types.Resource); ok {
+		r0 = rf(ctx, resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.Resource) error); ok {
+		r1 = rf(ctx, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_CreateResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateResource'
+type Storage_CreateResource_Call struct {
+	*mock.Call
+}
+
+// CreateResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resource *types.Resource
+func (_e *Storage_Expecter) CreateResource(ctx interface{}, resource interface{}) *Storage_CreateResource_Call {
+	return &Storage_CreateResource_Call{Call: _e.mock.On("CreateResource", ctx, resource)}
+}
+
+func (_c *Storage_CreateResource_Call) Run(run func(ctx context.Context, resource *types.Resource)) *Storage_CreateResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.Resource))
+	})
+	return _c
+}
+
+func (_c *Storage_CreateResource_Call) Return(_a0 *types.Resource, _a1 error) *Storage_CreateResource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_CreateResource_Call) RunAndReturn(run func(context.Context, *types.Resource) (*types.Resource, error)) *Storage_CreateResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *Storage) CreateWebhook(ctx context.Context, webhook *types.Webhook) (*types.Webhook, error) {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *types.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Webhook) (*types.Webhook, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Webhook) *types.Webhook); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.Webhook) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type Storage_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook *types.Webhook
+func (_e *Storage_Expecter) CreateWebhook(ctx interface{}, webhook interface{}) *Storage_CreateWebhook_Call {
+	return &Storage_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, webhook)}
+}
+
+func (_c *Storage_CreateWebhook_Call) Run(run func(ctx context.Context, webhook *types.Webhook)) *Storage_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.Webhook))
+	})
+	return _c
+}
+
+func (_c *Storage_CreateWebhook_Call) Return(_a0 *types.Webhook, _a1 error) *Storage_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_CreateWebhook_Call) RunAndReturn(run func(context.Context, *types.Webhook) (*types.Webhook, error)) *Storage_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEvent provides a mock function with given fields: ctx, id
+func (_m *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storage_DeleteEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEvent'
+type Storage_DeleteEvent_Call struct {
+	*mock.Call
+}
+
+// DeleteEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Storage_Expecter) DeleteEvent(ctx interface{}, id interface{}) *Storage_DeleteEvent_Call {
+	return &Storage_DeleteEvent_Call{Call: _e.mock.On("DeleteEvent", ctx, id)}
+}
+
+func (_c *Storage_DeleteEvent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_DeleteEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Storage_DeleteEvent_Call) Return(_a0 error) *Storage_DeleteEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Storage_DeleteEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Storage_DeleteEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOldEvents provides a mock function with given fields: ctx, date
+func (_m *Storage) DeleteOldEvents(ctx context.Context, date time.Time) (int64, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOldEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, date)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_DeleteOldEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOldEvents'
+type Storage_DeleteOldEvents_Call struct {
+	*mock.Call
+}
+
+// DeleteOldEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *Storage_Expecter) DeleteOldEvents(ctx interface{}, date interface{}) *Storage_DeleteOldEvents_Call {
+	return &Storage_DeleteOldEvents_Call{Call: _e.mock.On("DeleteOldEvents", ctx, date)}
+}
+
+func (_c *Storage_DeleteOldEvents_Call) Run(run func(ctx context.Context, date time.Time)) *Storage_DeleteOldEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storage_DeleteOldEvents_Call) Return(_a0 int64, _a1 error) *Storage_DeleteOldEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_DeleteOldEvents_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *Storage_DeleteOldEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteResource provides a mock function with given fields: ctx, id, since
+func (_m *Storage) DeleteResource(ctx context.Context, id uuid.UUID, since time.Time) error {
+	ret := _m.Called(ctx, id, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteResource")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, id, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storage_DeleteResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteResource'
+type Storage_DeleteResource_Call struct {
+	*mock.Call
+}
+
+// DeleteResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - since time.Time
+func (_e *Storage_Expecter) DeleteResource(ctx interface{}, id interface{}, since interface{}) *Storage_DeleteResource_Call {
+	return &Storage_DeleteResource_Call{Call: _e.mock.On("DeleteResource", ctx, id, since)}
+}
+
+func (_c *Storage_DeleteResource_Call) Run(run func(ctx context.Context, id uuid.UUID, since time.Time)) *Storage_DeleteResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storage_DeleteResource_Call) Return(_a0 error) *Storage_DeleteResource_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Storage_DeleteResource_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *Storage_DeleteResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *Storage) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storage_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type Storage_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Storage_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *Storage_DeleteWebhook_Call {
+	return &Storage_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
+}
+
+func (_c *Storage_DeleteWebhook_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Storage_DeleteWebhook_Call) Return(_a0 error) *Storage_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Storage_DeleteWebhook_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Storage_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllUserEvents provides a mock function with given fields: ctx, userID
+func (_m *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllUserEvents")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*types.Event, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*types.Event); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetAllUserEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUserEvents'
+type Storage_GetAllUserEvents_Call struct {
+	*mock.Call
+}
+
+// GetAllUserEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Storage_Expecter) GetAllUserEvents(ctx interface{}, userID interface{}) *Storage_GetAllUserEvents_Call {
+	return &Storage_GetAllUserEvents_Call{Call: _e.mock.On("GetAllUserEvents", ctx, userID)}
+}
+
+func (_c *Storage_GetAllUserEvents_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetAllUserEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storage_GetAllUserEvents_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetAllUserEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetAllUserEvents_Call) RunAndReturn(run func(context.Context, string) ([]*types.Event, error)) *Storage_GetAllUserEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDigestSettings provides a mock function with given fields: ctx, userID
+func (_m *Storage) GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestSettings")
+	}
+
+	var r0 *types.DigestSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.DigestSettings, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.DigestSettings); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DigestSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetDigestSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigestSettings'
+type Storage_GetDigestSettings_Call struct {
+	*mock.Call
+}
+
+// GetDigestSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Storage_Expecter) GetDigestSettings(ctx interface{}, userID interface{}) *Storage_GetDigestSettings_Call {
+	return &Storage_GetDigestSettings_Call{Call: _e.mock.On("GetDigestSettings", ctx, userID)}
+}
+
+func (_c *Storage_GetDigestSettings_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetDigestSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storage_GetDigestSettings_Call) Return(_a0 *types.DigestSettings, _a1 error) *Storage_GetDigestSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetDigestSettings_Call) RunAndReturn(run func(context.Context, string) (*types.DigestSettings, error)) *Storage_GetDigestSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEvent provides a mock function with given fields: ctx, id
+func (_m *Storage) GetEvent(ctx context.Context, id uuid.UUID) (*types.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvent")
+	}
+
+	var r0 *types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*types.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *types.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvent'
+type Storage_GetEvent_Call struct {
+	*mock.Call
+}
+
+// GetEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Storage_Expecter) GetEvent(ctx interface{}, id interface{}) *Storage_GetEvent_Call {
+	return &Storage_GetEvent_Call{Call: _e.mock.On("GetEvent", ctx, id)}
+}
+
+func (_c *Storage_GetEvent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_GetEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEvent_Call) Return(_a0 *types.Event, _a1 error) *Storage_GetEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*types.Event, error)) *Storage_GetEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventChanges provides a mock function with given fields: ctx, userID, since
+func (_m *Storage) GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventChanges")
+	}
+
+	var r0 *types.EventChanges
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*types.EventChanges, error)); ok {
+		return rf(ctx, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *types.EventChanges); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.EventChanges)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEventChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventChanges'
+type Storage_GetEventChanges_Call struct {
+	*mock.Call
+}
+
+// GetEventChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - since int64
+func (_e *Storage_Expecter) GetEventChanges(ctx interface{}, userID interface{}, since interface{}) *Storage_GetEventChanges_Call {
+	return &Storage_GetEventChanges_Call{Call: _e.mock.On("GetEventChanges", ctx, userID, since)}
+}
+
+func (_c *Storage_GetEventChanges_Call) Run(run func(ctx context.Context, userID string, since int64)) *Storage_GetEventChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEventChanges_Call) Return(_a0 *types.EventChanges, _a1 error) *Storage_GetEventChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEventChanges_Call) RunAndReturn(run func(context.Context, string, int64) (*types.EventChanges, error)) *Storage_GetEventChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForDay provides a mock function with given fields: ctx, date, userID, filter
+func (_m *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string, filter *types.EventFilter) ([]*types.Event, error) {
+	ret := _m.Called(ctx, date, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForDay")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string, *types.EventFilter) ([]*types.Event, error)); ok {
+		return rf(ctx, date, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string, *types.EventFilter) []*types.Event); ok {
+		r0 = rf(ctx, date, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *string, *types.EventFilter) error); ok {
+		r1 = rf(ctx, date, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEventsForDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForDay'
+type Storage_GetEventsForDay_Call struct {
+	*mock.Call
+}
+
+// GetEventsForDay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+//   - userID *string
+//   - filter *types.EventFilter
+func (_e *Storage_Expecter) GetEventsForDay(ctx interface{}, date interface{}, userID interface{}, filter interface{}) *Storage_GetEventsForDay_Call {
+	return &Storage_GetEventsForDay_Call{Call: _e.mock.On("GetEventsForDay", ctx, date, userID, filter)}
+}
+
+func (_c *Storage_GetEventsForDay_Call) Run(run func(ctx context.Context, date time.Time, userID *string, filter *types.EventFilter)) *Storage_GetEventsForDay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*string), args[3].(*types.EventFilter))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEventsForDay_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForDay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEventsForDay_Call) RunAndReturn(run func(context.Context, time.Time, *string, *types.EventFilter) ([]*types.Event, error)) *Storage_GetEventsForDay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForMonth provides a mock function with given fields: ctx, date, userID, filter
+func (_m *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string, filter *types.EventFilter) ([]*types.Event, error) {
+	ret := _m.Called(ctx, date, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForMonth")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string, *types.EventFilter) ([]*types.Event, error)); ok {
+		return rf(ctx, date, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string, *types.EventFilter) []*types.Event); ok {
+		r0 = rf(ctx, date, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *string, *types.EventFilter) error); ok {
+		r1 = rf(ctx, date, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEventsForMonth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForMonth'
+type Storage_GetEventsForMonth_Call struct {
+	*mock.Call
+}
+
+// GetEventsForMonth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+//   - userID *string
+//   - filter *types.EventFilter
+func (_e *Storage_Expecter) GetEventsForMonth(ctx interface{}, date interface{}, userID interface{}, filter interface{}) *Storage_GetEventsForMonth_Call {
+	return &Storage_GetEventsForMonth_Call{Call: _e.mock.On("GetEventsForMonth", ctx, date, userID, filter)}
+}
+
+func (_c *Storage_GetEventsForMonth_Call) Run(run func(ctx context.Context, date time.Time, userID *string, filter *types.EventFilter)) *Storage_GetEventsForMonth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*string), args[3].(*types.EventFilter))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEventsForMonth_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForMonth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEventsForMonth_Call) RunAndReturn(run func(context.Context, time.Time, *string, *types.EventFilter) ([]*types.Event, error)) *Storage_GetEventsForMonth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForNotification provides a mock function with given fields: ctx
+func (_m *Storage) GetEventsForNotification(ctx context.Context) ([]*types.Event, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForNotification")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*types.Event, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*types.Event); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEventsForNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForNotification'
+type Storage_GetEventsForNotification_Call struct {
+	*mock.Call
+}
+
+// GetEventsForNotification is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Storage_Expecter) GetEventsForNotification(ctx interface{}) *Storage_GetEventsForNotification_Call {
+	return &Storage_GetEventsForNotification_Call{Call: _e.mock.On("GetEventsForNotification", ctx)}
+}
+
+func (_c *Storage_GetEventsForNotification_Call) Run(run func(ctx context.Context)) *Storage_GetEventsForNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEventsForNotification_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEventsForNotification_Call) RunAndReturn(run func(context.Context) ([]*types.Event, error)) *Storage_GetEventsForNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForPeriod provides a mock function with given fields: ctx, dateStart, dateEnd, userID, filter
+func (_m *Storage) GetEventsForPeriod(ctx context.Context, dateStart time.Time, dateEnd time.Time, userID *string, filter *types.EventFilter) ([]*types.Event, error) {
+	ret := _m.Called(ctx, dateStart, dateEnd, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForPeriod")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, *string, *types.EventFilter) ([]*types.Event, error)); ok {
+		return rf(ctx, dateStart, dateEnd, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, *string, *types.EventFilter) []*types.Event); ok {
+		r0 = rf(ctx, dateStart, dateEnd, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, *string, *types.EventFilter) error); ok {
+		r1 = rf(ctx, dateStart, dateEnd, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEventsForPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForPeriod'
+type Storage_GetEventsForPeriod_Call struct {
+	*mock.Call
+}
+
+// GetEventsForPeriod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dateStart time.Time
+//   - dateEnd time.Time
+//   - userID *string
+//   - filter *types.EventFilter
+func (_e *Storage_Expecter) GetEventsForPeriod(ctx interface{}, dateStart interface{}, dateEnd interface{}, userID interface{}, filter interface{}) *Storage_GetEventsForPeriod_Call {
+	return &Storage_GetEventsForPeriod_Call{Call: _e.mock.On("GetEventsForPeriod", ctx, dateStart, dateEnd, userID, filter)}
+}
+
+func (_c *Storage_GetEventsForPeriod_Call) Run(run func(ctx context.Context, dateStart time.Time, dateEnd time.Time, userID *string, filter *types.EventFilter)) *Storage_GetEventsForPeriod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(*string), args[4].(*types.EventFilter))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEventsForPeriod_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForPeriod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEventsForPeriod_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, *string, *types.EventFilter) ([]*types.Event, error)) *Storage_GetEventsForPeriod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForReminderPeriod provides a mock function with given fields: ctx, dateStart, dateEnd
+func (_m *Storage) GetEventsForReminderPeriod(ctx context.Context, dateStart time.Time, dateEnd time.Time) ([]*types.Event, error) {
+	ret := _m.Called(ctx, dateStart, dateEnd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForReminderPeriod")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*types.Event, error)); ok {
+		return rf(ctx, dateStart, dateEnd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*types.Event); ok {
+		r0 = rf(ctx, dateStart, dateEnd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, dateStart, dateEnd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetEventsForReminderPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForReminderPeriod'
+type Storage_GetEventsForReminderPeriod_Call struct {
+	*mock.Call
+}
+
+// GetEventsForReminderPeriod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dateStart time.Time
+//   - dateEnd time.Time
+func (_e *Storage_Expecter) GetEventsForReminderPeriod(ctx interface{}, dateStart interface{}, dateEnd interface{}) *Storage_GetEventsForReminderPeriod_Call {
+	return &Storage_GetEventsForReminderPeriod_Call{Call: _e.mock.On("GetEventsForReminderPeriod", ctx, dateStart, dateEnd)}
+}
+
+func (_c *Storage_GetEventsForReminderPeriod_Call) Run(run func(ctx context.Context, dateStart time.Time, dateEnd time.Time)) *Storage_GetEventsForReminderPeriod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storage_GetEventsForReminderPeriod_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForReminderPeriod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetEventsForReminderPeriod_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) ([]*types.Event, error)) *Storage_GetEventsForReminderPeriod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForWeek provides a mock function with given fields: ctx, date, userID, filter
+func (_m *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string, filter *types.EventFilter) ([]*types.Event, error) {
+	ret := _m.Called(ctx, date, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForWeek")
 	}
 
-	var r0 *types.Event
+	var r0 []*types.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *types.Event) (*types.Event, error)); ok {
-		return rf(ctx, event)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string, *types.EventFilter) ([]*types.Event, error)); ok {
+		return rf(ctx, date, userID, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *types.Event) *types.Event); ok {
-		r0 = rf(ctx, event)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string, *types.EventFilter) []*types.Event); ok {
+		r0 = rf(ctx, date, userID, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Event)
+			r0 = ret.Get(0).([]*types.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *types.Event) error); ok {
-		r1 = rf(ctx, event)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *string, *types.EventFilter) error); ok {
+		r1 = rf(ctx, date, userID, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,105 +1130,175 @@ func (_m *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.
 	return r0, r1
 }
 
-// Storage_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
-type Storage_CreateEvent_Call struct {
+// Storage_GetEventsForWeek_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForWeek'
+type Storage_GetEventsForWeek_Call struct {
 	*mock.Call
 }
 
-// CreateEvent is a helper method to define mock.On call
+// GetEventsForWeek is a helper method to define mock.On call
 //   - ctx context.Context
-//   - event *types.Event
-func (_e *Storage_Expecter) CreateEvent(ctx interface{}, event interface{}) *Storage_CreateEvent_Call {
-	return &Storage_CreateEvent_Call{Call: _e.mock.On("CreateEvent", ctx, event)}
+//   - date time.Time
+//   - userID *string
+//   - filter *types.EventFilter
+func (_e *Storage_Expecter) GetEventsForWeek(ctx interface{}, date interface{}, userID interface{}, filter interface{}) *Storage_GetEventsForWeek_Call {
+	return &Storage_GetEventsForWeek_Call{Call: _e.mock.On("GetEventsForWeek", ctx, date, userID, filter)}
 }
 
-func (_c *Storage_CreateEvent_Call) Run(run func(ctx context.Context, event *types.Event)) *Storage_CreateEvent_Call {
+func (_c *Storage_GetEventsForWeek_Call) Run(run func(ctx context.Context, date time.Time, userID *string, filter *types.EventFilter)) *Storage_GetEventsForWeek_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*types.Event))
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*string), args[3].(*types.EventFilter))
 	})
 	return _c
 }
 
-func (_c *Storage_CreateEvent_Call) Return(_a0 *types.Event, _a1 error) *Storage_CreateEvent_Call {
+func (_c *Storage_GetEventsForWeek_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForWeek_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_CreateEvent_Call) RunAndReturn(run func(context.Context, *types.Event) (*types.Event, error)) *Storage_CreateEvent_Call {
+func (_c *Storage_GetEventsForWeek_Call) RunAndReturn(run func(context.Context, time.Time, *string, *types.EventFilter) ([]*types.Event, error)) *Storage_GetEventsForWeek_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteEvent provides a mock function with given fields: ctx, id
-func (_m *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+// GetNextReminderTime provides a mock function with given fields: ctx
+func (_m *Storage) GetNextReminderTime(ctx context.Context) (time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextReminderTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_GetNextReminderTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextReminderTime'
+type Storage_GetNextReminderTime_Call struct {
+	*mock.Call
+}
+
+// GetNextReminderTime is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Storage_Expecter) GetNextReminderTime(ctx interface{}) *Storage_GetNextReminderTime_Call {
+	return &Storage_GetNextReminderTime_Call{Call: _e.mock.On("GetNextReminderTime", ctx)}
+}
+
+func (_c *Storage_GetNextReminderTime_Call) Run(run func(ctx context.Context)) *Storage_GetNextReminderTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storage_GetNextReminderTime_Call) Return(_a0 time.Time, _a1 error) *Storage_GetNextReminderTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_GetNextReminderTime_Call) RunAndReturn(run func(context.Context) (time.Time, error)) *Storage_GetNextReminderTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetResource provides a mock function with given fields: ctx, id
+func (_m *Storage) GetResource(ctx context.Context, id uuid.UUID) (*types.Resource, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteEvent")
+		panic("no return value specified for GetResource")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+	var r0 *types.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*types.Resource, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *types.Resource); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Resource)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Storage_DeleteEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEvent'
-type Storage_DeleteEvent_Call struct {
+// Storage_GetResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResource'
+type Storage_GetResource_Call struct {
 	*mock.Call
 }
 
-// DeleteEvent is a helper method to define mock.On call
+// GetResource is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *Storage_Expecter) DeleteEvent(ctx interface{}, id interface{}) *Storage_DeleteEvent_Call {
-	return &Storage_DeleteEvent_Call{Call: _e.mock.On("DeleteEvent", ctx, id)}
+func (_e *Storage_Expecter) GetResource(ctx interface{}, id interface{}) *Storage_GetResource_Call {
+	return &Storage_GetResource_Call{Call: _e.mock.On("GetResource", ctx, id)}
 }
 
-func (_c *Storage_DeleteEvent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_DeleteEvent_Call {
+func (_c *Storage_GetResource_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_GetResource_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *Storage_DeleteEvent_Call) Return(_a0 error) *Storage_DeleteEvent_Call {
-	_c.Call.Return(_a0)
+func (_c *Storage_GetResource_Call) Return(_a0 *types.Resource, _a1 error) *Storage_GetResource_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_DeleteEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Storage_DeleteEvent_Call {
+func (_c *Storage_GetResource_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*types.Resource, error)) *Storage_GetResource_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllUserEvents provides a mock function with given fields: ctx, userID
-func (_m *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
-	ret := _m.Called(ctx, userID)
+// GetResourceEvents provides a mock function with given fields: ctx, resourceID, dateStart, dateEnd
+func (_m *Storage) GetResourceEvents(ctx context.Context, resourceID uuid.UUID, dateStart time.Time, dateEnd time.Time) ([]*types.Event, error) {
+	ret := _m.Called(ctx, resourceID, dateStart, dateEnd)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllUserEvents")
+		panic("no return value specified for GetResourceEvents")
 	}
 
 	var r0 []*types.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*types.Event, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]*types.Event, error)); ok {
+		return rf(ctx, resourceID, dateStart, dateEnd)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*types.Event); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []*types.Event); ok {
+		r0 = rf(ctx, resourceID, dateStart, dateEnd)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, resourceID, dateStart, dateEnd)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -208,58 +1306,60 @@ func (_m *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*type
 	return r0, r1
 }
 
-// Storage_GetAllUserEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUserEvents'
-type Storage_GetAllUserEvents_Call struct {
+// Storage_GetResourceEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResourceEvents'
+type Storage_GetResourceEvents_Call struct {
 	*mock.Call
 }
 
-// GetAllUserEvents is a helper method to define mock.On call
+// GetResourceEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *Storage_Expecter) GetAllUserEvents(ctx interface{}, userID interface{}) *Storage_GetAllUserEvents_Call {
-	return &Storage_GetAllUserEvents_Call{Call: _e.mock.On("GetAllUserEvents", ctx, userID)}
+//   - resourceID uuid.UUID
+//   - dateStart time.Time
+//   - dateEnd time.Time
+func (_e *Storage_Expecter) GetResourceEvents(ctx interface{}, resourceID interface{}, dateStart interface{}, dateEnd interface{}) *Storage_GetResourceEvents_Call {
+	return &Storage_GetResourceEvents_Call{Call: _e.mock.On("GetResourceEvents", ctx, resourceID, dateStart, dateEnd)}
 }
 
-func (_c *Storage_GetAllUserEvents_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetAllUserEvents_Call {
+func (_c *Storage_GetResourceEvents_Call) Run(run func(ctx context.Context, resourceID uuid.UUID, dateStart time.Time, dateEnd time.Time)) *Storage_GetResourceEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Storage_GetAllUserEvents_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetAllUserEvents_Call {
+func (_c *Storage_GetResourceEvents_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetResourceEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_GetAllUserEvents_Call) RunAndReturn(run func(context.Context, string) ([]*types.Event, error)) *Storage_GetAllUserEvents_Call {
+func (_c *Storage_GetResourceEvents_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, time.Time) ([]*types.Event, error)) *Storage_GetResourceEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEvent provides a mock function with given fields: ctx, id
-func (_m *Storage) GetEvent(ctx context.Context, id uuid.UUID) (*types.Event, error) {
-	ret := _m.Called(ctx, id)
+// GetResources provides a mock function with given fields: ctx
+func (_m *Storage) GetResources(ctx context.Context) ([]*types.Resource, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEvent")
+		panic("no return value specified for GetResources")
 	}
 
-	var r0 *types.Event
+	var r0 []*types.Resource
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*types.Event, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*types.Resource, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *types.Event); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) []*types.Resource); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Event)
+			r0 = ret.Get(0).([]*types.Resource)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -267,58 +1367,57 @@ func (_m *Storage) GetEvent(ctx context.Context, id uuid.UUID) (*types.Event, er
 	return r0, r1
 }
 
-// Storage_GetEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvent'
-type Storage_GetEvent_Call struct {
+// Storage_GetResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResources'
+type Storage_GetResources_Call struct {
 	*mock.Call
 }
 
-// GetEvent is a helper method to define mock.On call
+// GetResources is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *Storage_Expecter) GetEvent(ctx interface{}, id interface{}) *Storage_GetEvent_Call {
-	return &Storage_GetEvent_Call{Call: _e.mock.On("GetEvent", ctx, id)}
+func (_e *Storage_Expecter) GetResources(ctx interface{}) *Storage_GetResources_Call {
+	return &Storage_GetResources_Call{Call: _e.mock.On("GetResources", ctx)}
 }
 
-func (_c *Storage_GetEvent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_GetEvent_Call {
+func (_c *Storage_GetResources_Call) Run(run func(ctx context.Context)) *Storage_GetResources_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *Storage_GetEvent_Call) Return(_a0 *types.Event, _a1 error) *Storage_GetEvent_Call {
+func (_c *Storage_GetResources_Call) Return(_a0 []*types.Resource, _a1 error) *Storage_GetResources_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_GetEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*types.Event, error)) *Storage_GetEvent_Call {
+func (_c *Storage_GetResources_Call) RunAndReturn(run func(context.Context) ([]*types.Resource, error)) *Storage_GetResources_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForDay provides a mock function with given fields: ctx, date, userID
-func (_m *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
-	ret := _m.Called(ctx, date, userID)
+// GetWebhook provides a mock function with given fields: ctx, id
+func (_m *Storage) GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForDay")
+		panic("no return value specified for GetWebhook")
 	}
 
-	var r0 []*types.Event
+	var r0 *types.Webhook
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string) ([]*types.Event, error)); ok {
-		return rf(ctx, date, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*types.Webhook, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string) []*types.Event); ok {
-		r0 = rf(ctx, date, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *types.Webhook); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*types.Event)
+			r0 = ret.Get(0).(*types.Webhook)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *string) error); ok {
-		r1 = rf(ctx, date, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -326,59 +1425,58 @@ func (_m *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *
 	return r0, r1
 }
 
-// Storage_GetEventsForDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForDay'
-type Storage_GetEventsForDay_Call struct {
+// Storage_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type Storage_GetWebhook_Call struct {
 	*mock.Call
 }
 
-// GetEventsForDay is a helper method to define mock.On call
+// GetWebhook is a helper method to define mock.On call
 //   - ctx context.Context
-//   - date time.Time
-//   - userID *string
-func (_e *Storage_Expecter) GetEventsForDay(ctx interface{}, date interface{}, userID interface{}) *Storage_GetEventsForDay_Call {
-	return &Storage_GetEventsForDay_Call{Call: _e.mock.On("GetEventsForDay", ctx, date, userID)}
+//   - id uuid.UUID
+func (_e *Storage_Expecter) GetWebhook(ctx interface{}, id interface{}) *Storage_GetWebhook_Call {
+	return &Storage_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, id)}
 }
 
-func (_c *Storage_GetEventsForDay_Call) Run(run func(ctx context.Context, date time.Time, userID *string)) *Storage_GetEventsForDay_Call {
+func (_c *Storage_GetWebhook_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Storage_GetWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time), args[2].(*string))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *Storage_GetEventsForDay_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForDay_Call {
+func (_c *Storage_GetWebhook_Call) Return(_a0 *types.Webhook, _a1 error) *Storage_GetWebhook_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_GetEventsForDay_Call) RunAndReturn(run func(context.Context, time.Time, *string) ([]*types.Event, error)) *Storage_GetEventsForDay_Call {
+func (_c *Storage_GetWebhook_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*types.Webhook, error)) *Storage_GetWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForMonth provides a mock function with given fields: ctx, date, userID
-func (_m *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
-	ret := _m.Called(ctx, date, userID)
+// GetWebhookDeliveries provides a mock function with given fields: ctx, webhookID, limit
+func (_m *Storage) GetWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*types.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForMonth")
+		panic("no return value specified for GetWebhookDeliveries")
 	}
 
-	var r0 []*types.Event
+	var r0 []*types.WebhookDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string) ([]*types.Event, error)); ok {
-		return rf(ctx, date, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]*types.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string) []*types.Event); ok {
-		r0 = rf(ctx, date, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []*types.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*types.Event)
+			r0 = ret.Get(0).([]*types.WebhookDelivery)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *string) error); ok {
-		r1 = rf(ctx, date, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, webhookID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -386,59 +1484,59 @@ func (_m *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID
 	return r0, r1
 }
 
-// Storage_GetEventsForMonth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForMonth'
-type Storage_GetEventsForMonth_Call struct {
+// Storage_GetWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveries'
+type Storage_GetWebhookDeliveries_Call struct {
 	*mock.Call
 }
 
-// GetEventsForMonth is a helper method to define mock.On call
+// GetWebhookDeliveries is a helper method to define mock.On call
 //   - ctx context.Context
-//   - date time.Time
-//   - userID *string
-func (_e *Storage_Expecter) GetEventsForMonth(ctx interface{}, date interface{}, userID interface{}) *Storage_GetEventsForMonth_Call {
-	return &Storage_GetEventsForMonth_Call{Call: _e.mock.On("GetEventsForMonth", ctx, date, userID)}
+//   - webhookID uuid.UUID
+//   - limit int
+func (_e *Storage_Expecter) GetWebhookDeliveries(ctx interface{}, webhookID interface{}, limit interface{}) *Storage_GetWebhookDeliveries_Call {
+	return &Storage_GetWebhookDeliveries_Call{Call: _e.mock.On("GetWebhookDeliveries", ctx, webhookID, limit)}
 }
 
-func (_c *Storage_GetEventsForMonth_Call) Run(run func(ctx context.Context, date time.Time, userID *string)) *Storage_GetEventsForMonth_Call {
+func (_c *Storage_GetWebhookDeliveries_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, limit int)) *Storage_GetWebhookDeliveries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time), args[2].(*string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Storage_GetEventsForMonth_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForMonth_Call {
+func (_c *Storage_GetWebhookDeliveries_Call) Return(_a0 []*types.WebhookDelivery, _a1 error) *Storage_GetWebhookDeliveries_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_GetEventsForMonth_Call) RunAndReturn(run func(context.Context, time.Time, *string) ([]*types.Event, error)) *Storage_GetEventsForMonth_Call {
+func (_c *Storage_GetWebhookDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) ([]*types.WebhookDelivery, error)) *Storage_GetWebhookDeliveries_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForPeriod provides a mock function with given fields: ctx, dateStart, dateEnd, userID
-func (_m *Storage) GetEventsForPeriod(ctx context.Context, dateStart time.Time, dateEnd time.Time, userID *string) ([]*types.Event, error) {
-	ret := _m.Called(ctx, dateStart, dateEnd, userID)
+// GetWebhooks provides a mock function with given fields: ctx
+func (_m *Storage) GetWebhooks(ctx context.Context) ([]*types.Webhook, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForPeriod")
+		panic("no return value specified for GetWebhooks")
 	}
 
-	var r0 []*types.Event
+	var r0 []*types.Webhook
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, *string) ([]*types.Event, error)); ok {
-		return rf(ctx, dateStart, dateEnd, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*types.Webhook, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, *string) []*types.Event); ok {
-		r0 = rf(ctx, dateStart, dateEnd, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) []*types.Webhook); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*types.Event)
+			r0 = ret.Get(0).([]*types.Webhook)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, *string) error); ok {
-		r1 = rf(ctx, dateStart, dateEnd, userID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -446,60 +1544,57 @@ func (_m *Storage) GetEventsForPeriod(ctx context.Context, dateStart time.Time,
 	return r0, r1
 }
 
-// Storage_GetEventsForPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForPeriod'
-type Storage_GetEventsForPeriod_Call struct {
+// Storage_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type Storage_GetWebhooks_Call struct {
 	*mock.Call
 }
 
-// GetEventsForPeriod is a helper method to define mock.On call
+// GetWebhooks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - dateStart time.Time
-//   - dateEnd time.Time
-//   - userID *string
-func (_e *Storage_Expecter) GetEventsForPeriod(ctx interface{}, dateStart interface{}, dateEnd interface{}, userID interface{}) *Storage_GetEventsForPeriod_Call {
-	return &Storage_GetEventsForPeriod_Call{Call: _e.mock.On("GetEventsForPeriod", ctx, dateStart, dateEnd, userID)}
+func (_e *Storage_Expecter) GetWebhooks(ctx interface{}) *Storage_GetWebhooks_Call {
+	return &Storage_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", ctx)}
 }
 
-func (_c *Storage_GetEventsForPeriod_Call) Run(run func(ctx context.Context, dateStart time.Time, dateEnd time.Time, userID *string)) *Storage_GetEventsForPeriod_Call {
+func (_c *Storage_GetWebhooks_Call) Run(run func(ctx context.Context)) *Storage_GetWebhooks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(*string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *Storage_GetEventsForPeriod_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForPeriod_Call {
+func (_c *Storage_GetWebhooks_Call) Return(_a0 []*types.Webhook, _a1 error) *Storage_GetWebhooks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_GetEventsForPeriod_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, *string) ([]*types.Event, error)) *Storage_GetEventsForPeriod_Call {
+func (_c *Storage_GetWebhooks_Call) RunAndReturn(run func(context.Context) ([]*types.Webhook, error)) *Storage_GetWebhooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForWeek provides a mock function with given fields: ctx, date, userID
-func (_m *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
-	ret := _m.Called(ctx, date, userID)
+// SetDigestSettings provides a mock function with given fields: ctx, settings
+func (_m *Storage) SetDigestSettings(ctx context.Context, settings *types.DigestSettings) (*types.DigestSettings, error) {
+	ret := _m.Called(ctx, settings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForWeek")
+		panic("no return value specified for SetDigestSettings")
 	}
 
-	var r0 []*types.Event
+	var r0 *types.DigestSettings
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string) ([]*types.Event, error)); ok {
-		return rf(ctx, date, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DigestSettings) (*types.DigestSettings, error)); ok {
+		return rf(ctx, settings)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *string) []*types.Event); ok {
-		r0 = rf(ctx, date, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DigestSettings) *types.DigestSettings); ok {
+		r0 = rf(ctx, settings)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*types.Event)
+			r0 = ret.Get(0).(*types.DigestSettings)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *string) error); ok {
-		r1 = rf(ctx, date, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, *types.DigestSettings) error); ok {
+		r1 = rf(ctx, settings)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -507,32 +1602,31 @@ func (_m *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID
 	return r0, r1
 }
 
-// Storage_GetEventsForWeek_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForWeek'
-type Storage_GetEventsForWeek_Call struct {
+// Storage_SetDigestSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigestSettings'
+type Storage_SetDigestSettings_Call struct {
 	*mock.Call
 }
 
-// GetEventsForWeek is a helper method to define mock.On call
+// SetDigestSettings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - date time.Time
-//   - userID *string
-func (_e *Storage_Expecter) GetEventsForWeek(ctx interface{}, date interface{}, userID interface{}) *Storage_GetEventsForWeek_Call {
-	return &Storage_GetEventsForWeek_Call{Call: _e.mock.On("GetEventsForWeek", ctx, date, userID)}
+//   - settings *types.DigestSettings
+func (_e *Storage_Expecter) SetDigestSettings(ctx interface{}, settings interface{}) *Storage_SetDigestSettings_Call {
+	return &Storage_SetDigestSettings_Call{Call: _e.mock.On("SetDigestSettings", ctx, settings)}
 }
 
-func (_c *Storage_GetEventsForWeek_Call) Run(run func(ctx context.Context, date time.Time, userID *string)) *Storage_GetEventsForWeek_Call {
+func (_c *Storage_SetDigestSettings_Call) Run(run func(ctx context.Context, settings *types.DigestSettings)) *Storage_SetDigestSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time), args[2].(*string))
+		run(args[0].(context.Context), args[1].(*types.DigestSettings))
 	})
 	return _c
 }
 
-func (_c *Storage_GetEventsForWeek_Call) Return(_a0 []*types.Event, _a1 error) *Storage_GetEventsForWeek_Call {
+func (_c *Storage_SetDigestSettings_Call) Return(_a0 *types.DigestSettings, _a1 error) *Storage_SetDigestSettings_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Storage_GetEventsForWeek_Call) RunAndReturn(run func(context.Context, time.Time, *string) ([]*types.Event, error)) *Storage_GetEventsForWeek_Call {
+func (_c *Storage_SetDigestSettings_Call) RunAndReturn(run func(context.Context, *types.DigestSettings) (*types.DigestSettings, error)) *Storage_SetDigestSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -597,6 +1691,55 @@ func (_c *Storage_UpdateEvent_Call) RunAndReturn(run func(context.Context, uuid.
 	return _c
 }
 
+// UpdateWebhookState provides a mock function with given fields: ctx, id, enabled, failures
+func (_m *Storage) UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error {
+	ret := _m.Called(ctx, id, enabled, failures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, int) error); ok {
+		r0 = rf(ctx, id, enabled, failures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storage_UpdateWebhookState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookState'
+type Storage_UpdateWebhookState_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - enabled bool
+//   - failures int
+func (_e *Storage_Expecter) UpdateWebhookState(ctx interface{}, id interface{}, enabled interface{}, failures interface{}) *Storage_UpdateWebhookState_Call {
+	return &Storage_UpdateWebhookState_Call{Call: _e.mock.On("UpdateWebhookState", ctx, id, enabled, failures)}
+}
+
+func (_c *Storage_UpdateWebhookState_Call) Run(run func(ctx context.Context, id uuid.UUID, enabled bool, failures int)) *Storage_UpdateWebhookState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Storage_UpdateWebhookState_Call) Return(_a0 error) *Storage_UpdateWebhookState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Storage_UpdateWebhookState_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool, int) error) *Storage_UpdateWebhookState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStorage(t interface {
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// CreateResource is trying to validate and store the new bookable resource.
+// Returns the stored resource, nil on success and nil, error otherwise.
+func (a *App) CreateResource(ctx context.Context, input *dto.CreateResourceInput) (*types.Resource, error) {
+	method := "CreateResource"
+	msg := method + ": %w"
+
+	if input == nil {
+		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
+	}
+
+	resource, err := types.NewResource(input.Name, input.Kind, input.Capacity, input.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	var res *types.Resource
+	err = a.withRetries(ctx, method, func() error {
+		stored, err := a.s.CreateResource(ctx, resource)
+		if err != nil {
+			return err
+		}
+		res = stored
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
+
+// ListResources is trying to get the bookable resources from the storage, sorted by the name.
+func (a *App) ListResources(ctx context.Context) ([]*types.Resource, error) {
+	method := "ListResources"
+	msg := method + ": %w"
+
+	var res []*types.Resource
+	err := a.withRetries(ctx, method, func() error {
+		resources, err := a.s.GetResources(ctx)
+		if err != nil {
+			return err
+		}
+		res = resources
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
+
+// DeleteResource is trying to delete the bookable resource with the given ID.
+// Past bookings are kept by the events.
+//
+// Returns ErrResourceInUse if the resource is booked by the upcoming events, which are to be updated first.
+func (a *App) DeleteResource(ctx context.Context, id string) error {
+	method := "DeleteResource"
+	msg := method + ": %w"
+
+	uuidID, err := idFromString(id)
+	if err != nil {
+		return fmt.Errorf(msg, err)
+	}
+
+	err = a.withRetries(ctx, method, func() error {
+		// Bookings are checked by the storage in the same transaction, so the resource can't be booked meanwhile.
+		return a.s.DeleteResource(ctx, *uuidID, time.Now())
+	})
+	if err != nil {
+		return fmt.Errorf(msg, err)
+	}
+
+	return nil
+}
+
+// GetResourceAvailability is trying to get the bookings of the resources within the given period.
+// Resources are limited by the optional filters of the input. Explicitly requested resources must exist.
+//
+// Returns the availability of the matching resources, sorted by the name, nil on success and nil, error otherwise.
+func (a *App) GetResourceAvailability(
+	ctx context.Context,
+	input *dto.ResourceAvailabilityInput,
+) ([]*types.ResourceAvailability, error) {
+	method := "GetResourceAvailability"
+	msg := method + ": %w"
+
+	filter, err := resourceFilter(input)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	var res []*types.ResourceAvailability
+	err = a.withRetries(ctx, method, func() error {
+		resources, err := a.s.GetResources(ctx)
+		if err != nil {
+			return err
+		}
+		res = make([]*types.ResourceAvailability, 0, len(resources))
+		for _, resource := range resources {
+			if !filter.Match(resource) {
+				continue
+			}
+			events, err := a.s.GetResourceEvents(ctx, resource.ID, input.DateStart, input.DateEnd)
+			if err != nil {
+				return err
+			}
+			res = append(res, newResourceAvailability(resource, events))
+		}
+		// Requested resources, filtered out by the other filters, are still known.
+		for _, id := range filter.IDs {
+			if !containsResource(resources, id) {
+				return fmt.Errorf("%w: id=%s", projectErrors.ErrResourceNotFound, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
+
+// resourceFilter validates the availability query and converts it to the resource filter.
+func resourceFilter(input *dto.ResourceAvailabilityInput) (*types.ResourceFilter, error) {
+	if input == nil {
+		return nil, projectErrors.ErrNoData
+	}
+	if input.DateStart.IsZero() || input.DateEnd.IsZero() {
+		return nil, fmt.Errorf("%w: date_start, date_end", projectErrors.ErrEmptyField)
+	}
+	if !input.DateStart.Before(input.DateEnd) {
+		return nil, fmt.Errorf("%w: date_start must be before date_end", projectErrors.ErrInvalidFieldData)
+	}
+	if input.MinCapacity < 0 {
+		return nil, fmt.Errorf("%w: min_capacity", projectErrors.ErrInvalidFieldData)
+	}
+
+	filter := &types.ResourceFilter{
+		MinCapacity: input.MinCapacity,
+		Attributes:  types.NormalizeTags(input.Attributes),
+	}
+	// Empty kind means any kind here, unlike on the resource creation.
+	if input.Kind != "" {
+		kind, err := types.ParseResourceKind(input.Kind)
+		if err != nil {
+			return nil, err
+		}
+		filter.Kind = kind
+	}
+	ids, err := types.NormalizeResources(input.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		filter.IDs = append(filter.IDs, uuid.MustParse(id))
+	}
+	return filter, nil
+}
+
+// newResourceAvailability converts the events booking the resource to its availability.
+func newResourceAvailability(resource *types.Resource, events []*types.Event) *types.ResourceAvailability {
+	bookings := make([]types.ResourceBooking, len(events))
+	for i, event := range events {
+		bookings[i] = types.ResourceBooking{
+			EventID: event.ID,
+			Start:   event.Datetime,
+			End:     event.Datetime.Add(event.Duration),
+		}
+	}
+	return &types.ResourceAvailability{Resource: resource, Bookings: bookings}
+}
+
+// containsResource reports whether the resource with the given ID is in the slice.
+func containsResource(resources []*types.Resource, id uuid.UUID) bool {
+	for _, resource := range resources {
+		if resource.ID == id {
+			return true
+		}
+	}
+	return false
+}
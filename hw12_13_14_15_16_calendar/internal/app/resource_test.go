@@ -0,0 +1,164 @@
+//nolint:depguard,nolintlint
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app/mocks"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+func TestResources(t *testing.T) {
+	ctx := context.Background()
+	room := &types.Resource{
+		ID: uuid.New(), Name: "Blue room", Kind: types.ResourceKindRoom, Capacity: 8,
+		Attributes: types.Tags{"projector", "whiteboard"},
+	}
+	projector := &types.Resource{ID: uuid.New(), Name: "Projector", Kind: types.ResourceKindEquipment}
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	booking := &types.Event{ID: uuid.New(), EventData: types.EventData{Datetime: start, Duration: time.Hour}}
+
+	t.Run("create", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateResource", mock.Anything, mock.MatchedBy(func(r *types.Resource) bool {
+			return r.Name == "Blue room" && r.Kind == types.ResourceKindRoom && r.Capacity == 8 &&
+				len(r.Attributes) == 2
+		})).Return(room, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.CreateResource(ctx, &dto.CreateResourceInput{
+			Name: "Blue room", Capacity: 8, Attributes: []string{"Whiteboard", "projector"},
+		})
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, room, res)
+
+		_, err = app.CreateResource(ctx, &dto.CreateResourceInput{Name: "Blue room", Kind: "car"})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.CreateResource(ctx, &dto.CreateResourceInput{Name: "Blue room", Capacity: -1})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.CreateResource(ctx, &dto.CreateResourceInput{})
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("DeleteResource", mock.Anything, projector.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		err := app.DeleteResource(ctx, projector.ID.String())
+		require.NoError(t, err, "expected nil, got error")
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("delete booked", func(t *testing.T) {
+		inUse := fmt.Errorf("%w: events=%v", projectErrors.ErrResourceInUse, []string{booking.ID.String()})
+		storage := new(mocks.Storage)
+		storage.On("DeleteResource", mock.Anything, room.ID, mock.AnythingOfType("time.Time")).Return(inUse).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		err := app.DeleteResource(ctx, room.ID.String())
+		require.ErrorIs(t, err, projectErrors.ErrResourceInUse)
+		require.ErrorContains(t, err, booking.ID.String())
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("delete unknown", func(t *testing.T) {
+		unknown := uuid.New()
+		storage := new(mocks.Storage)
+		storage.On("DeleteResource", mock.Anything, unknown, mock.AnythingOfType("time.Time")).
+			Return(projectErrors.ErrResourceNotFound).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		err := app.DeleteResource(ctx, unknown.String())
+		require.ErrorIs(t, err, projectErrors.ErrResourceNotFound)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("availability", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetResources", mock.Anything).Return([]*types.Resource{room, projector}, nil).Twice()
+		storage.On("GetResourceEvents", mock.Anything, room.ID, start, start.Add(types.Day)).
+			Return([]*types.Event{booking}, nil).Twice()
+		storage.On("GetResourceEvents", mock.Anything, projector.ID, start, start.Add(types.Day)).
+			Return([]*types.Event{}, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{
+			DateStart: start, DateEnd: start.Add(types.Day),
+		})
+		require.NoError(t, err, "expected nil, got error")
+		require.Len(t, res, 2)
+		require.Equal(t, room, res[0].Resource)
+		require.False(t, res[0].Available())
+		require.Equal(t, []types.ResourceBooking{{EventID: booking.ID, Start: start, End: start.Add(time.Hour)}},
+			res[0].Bookings)
+		require.True(t, res[1].Available())
+
+		res, err = app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{
+			DateStart: start, DateEnd: start.Add(types.Day), Kind: "room", MinCapacity: 4,
+			Attributes: []string{"Projector"},
+		})
+		require.NoError(t, err, "expected nil, got error")
+		require.Len(t, res, 1, "resources are not filtered")
+		require.Equal(t, room, res[0].Resource)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("availability unknown resource", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetResources", mock.Anything).Return([]*types.Resource{room}, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		_, err := app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{
+			DateStart: start, DateEnd: start.Add(types.Day), ResourceIDs: []string{uuid.NewString()},
+		})
+		require.ErrorIs(t, err, projectErrors.ErrResourceNotFound)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("availability validation", func(t *testing.T) {
+		app := &App{s: new(mocks.Storage), l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		_, err := app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{DateStart: start})
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+		_, err = app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{DateStart: start, DateEnd: start})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{
+			DateStart: start, DateEnd: start.Add(time.Hour), ResourceIDs: []string{"room"},
+		})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{
+			DateStart: start, DateEnd: start.Add(time.Hour), Kind: "car",
+		})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+	})
+
+	t.Run("event resources", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateEvent", mock.Anything, mock.MatchedBy(func(e *types.Event) bool {
+			return len(e.Resources) == 1 && e.Resources[0] == room.ID.String()
+		})).Return(booking, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		input := &dto.CreateEventInput{
+			Title: "Planning", Datetime: start, Duration: time.Hour, UserID: "user1",
+			Resources: []string{room.ID.String(), room.ID.String()},
+		}
+		_, err := app.CreateEvent(ctx, input)
+		require.NoError(t, err, "expected nil, got error")
+
+		input.Resources = []string{"room"}
+		_, err = app.CreateEvent(ctx, input)
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		storage.AssertExpectations(t)
+	})
+}
@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
 	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
 )
 
@@ -23,7 +25,14 @@ func (a *App) isBusiness(err error) bool {
 	return errors.Is(err, projectErrors.ErrDateBusy) ||
 		errors.Is(err, projectErrors.ErrPermissionDenied) ||
 		errors.Is(err, projectErrors.ErrEventNotFound) ||
-		errors.Is(err, projectErrors.ErrNoData)
+		errors.Is(err, projectErrors.ErrNoData) ||
+		errors.Is(err, projectErrors.ErrQuotaExceeded) ||
+		errors.Is(err, projectErrors.ErrIdempotencyConflict) ||
+		errors.Is(err, projectErrors.ErrDigestSettingsNotFound) ||
+		errors.Is(err, projectErrors.ErrWebhookNotFound) ||
+		errors.Is(err, projectErrors.ErrResourceNotFound) ||
+		errors.Is(err, projectErrors.ErrResourceInUse) ||
+		errors.Is(err, projectErrors.ErrChangesExpired)
 }
 
 // safeDereference returns zero value if ptr is nil.
@@ -42,3 +51,52 @@ func idFromString(id string) (*uuid.UUID, error) {
 	}
 	return &res, nil
 }
+
+// setOverlapOptions validates and sets the busy status and the overlap policy of the event data.
+// Falls back to the app default overlap policy if no policy is requested.
+func (a *App) setOverlapOptions(data *types.EventData, busyStatus, overlapPolicy *string) error {
+	status, err := types.ParseBusyStatus(safeDereference(busyStatus))
+	if err != nil {
+		return err
+	}
+
+	policy := a.overlapPolicy
+	if overlapPolicy != nil && *overlapPolicy != "" {
+		policy, err = types.ParseOverlapPolicy(*overlapPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	data.BusyStatus = status
+	data.OverlapPolicy = policy
+	return nil
+}
+
+// checkQuota returns ErrQuotaExceeded if the user has already reached the maximum number of events.
+func (a *App) checkQuota(ctx context.Context, method, userID string) error {
+	var count int64
+	err := a.withRetries(ctx, method, func() error {
+		localCount, err := a.s.CountUserEvents(ctx, userID)
+		if err != nil {
+			return err
+		}
+		count = localCount
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if count >= int64(a.maxEventsPerUser) {
+		return fmt.Errorf("%w: limit=%d", projectErrors.ErrQuotaExceeded, a.maxEventsPerUser)
+	}
+	return nil
+}
+
+// notify passes the event change to the webhook notifier, if it is set.
+func (a *App) notify(ctx context.Context, eventType types.WebhookEventType, event *types.Event) {
+	if a.n != nil && event != nil {
+		a.n.NotifyEvent(ctx, eventType, event)
+	}
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// CreateWebhook is trying to validate and store the new webhook subscription.
+// Returns the stored subscription, including its secret, nil on success and nil, error otherwise.
+func (a *App) CreateWebhook(ctx context.Context, input *dto.CreateWebhookInput) (*types.Webhook, error) {
+	method := "CreateWebhook"
+	msg := method + ": %w"
+
+	if input == nil {
+		return nil, fmt.Errorf(msg, projectErrors.ErrNoData)
+	}
+
+	webhook, err := types.NewWebhook(input.UserID, input.URL, input.Secret, input.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	var res *types.Webhook
+	err = a.withRetries(ctx, method, func() error {
+		stored, err := a.s.CreateWebhook(ctx, webhook)
+		if err != nil {
+			return err
+		}
+		res = stored
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
+
+// ListWebhooks is trying to get the webhook subscriptions from the storage, sorted by the creation time.
+// Non-empty user ID limits the result to the subscriptions of the user, excluding the ones of all users.
+func (a *App) ListWebhooks(ctx context.Context, userID string) ([]*types.Webhook, error) {
+	method := "ListWebhooks"
+	msg := method + ": %w"
+
+	var webhooks []*types.Webhook
+	err := a.withRetries(ctx, method, func() error {
+		res, err := a.s.GetWebhooks(ctx)
+		if err != nil {
+			return err
+		}
+		webhooks = res
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	if userID == "" {
+		return webhooks, nil
+	}
+	res := make([]*types.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.UserID == userID {
+			res = append(res, webhook)
+		}
+	}
+	return res, nil
+}
+
+// DeleteWebhook is trying to delete the webhook subscription with the given ID along with its delivery log.
+// Returns nil on success and error otherwise.
+func (a *App) DeleteWebhook(ctx context.Context, id string) error {
+	method := "DeleteWebhook"
+	msg := method + ": %w"
+
+	uuidID, err := idFromString(id)
+	if err != nil {
+		return fmt.Errorf(msg, err)
+	}
+
+	err = a.withRetries(ctx, method, func() error {
+		return a.s.DeleteWebhook(ctx, *uuidID)
+	})
+	if err != nil {
+		return fmt.Errorf(msg, err)
+	}
+
+	return nil
+}
+
+// EnableWebhook is trying to enable the webhook subscription with the given ID, resetting its failures.
+// Used to resume the deliveries to the subscriptions, disabled after repeated failures.
+//
+// Returns the updated subscription, nil on success and nil, error otherwise.
+func (a *App) EnableWebhook(ctx context.Context, id string) (*types.Webhook, error) {
+	method := "EnableWebhook"
+	msg := method + ": %w"
+
+	uuidID, err := idFromString(id)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	var res *types.Webhook
+	err = a.withRetries(ctx, method, func() error {
+		if err := a.s.UpdateWebhookState(ctx, *uuidID, true, 0); err != nil {
+			return err
+		}
+		webhook, err := a.s.GetWebhook(ctx, *uuidID)
+		if err != nil {
+			return err
+		}
+		res = webhook
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
+
+// ListWebhookDeliveries is trying to get the latest delivery attempts of the webhook subscription
+// with the given ID from the storage, newest first. limit <= 0 means no limit.
+//
+// Returns ErrWebhookNotFound if the subscription does not exist.
+func (a *App) ListWebhookDeliveries(ctx context.Context, id string, limit int) ([]*types.WebhookDelivery, error) {
+	method := "ListWebhookDeliveries"
+	msg := method + ": %w"
+
+	uuidID, err := idFromString(id)
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	var res []*types.WebhookDelivery
+	err = a.withRetries(ctx, method, func() error {
+		// The log of the unknown subscription is empty, so its existence is checked explicitly.
+		if _, err := a.s.GetWebhook(ctx, *uuidID); err != nil {
+			return err
+		}
+		deliveries, err := a.s.GetWebhookDeliveries(ctx, *uuidID, limit)
+		if err != nil {
+			return err
+		}
+		res = deliveries
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,150 @@
+//nolint:depguard,nolintlint
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app/mocks"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// fakeNotifier records the notified event changes.
+type fakeNotifier struct {
+	changes []types.WebhookEventType
+	events  []*types.Event
+}
+
+func (n *fakeNotifier) NotifyEvent(_ context.Context, eventType types.WebhookEventType, event *types.Event) {
+	n.changes = append(n.changes, eventType)
+	n.events = append(n.events, event)
+}
+
+func TestWebhooks(t *testing.T) {
+	ctx := context.Background()
+	webhook1 := &types.Webhook{ID: uuid.New(), UserID: "user1", URL: "http://localhost/1", Enabled: true}
+	webhook2 := &types.Webhook{ID: uuid.New(), URL: "http://localhost/2", Enabled: false, Failures: 5}
+
+	t.Run("create", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(w *types.Webhook) bool {
+			return w.UserID == "user1" && w.URL == "http://localhost/1" && w.Secret != "" && w.Enabled &&
+				len(w.EventTypes) == 4
+		})).Return(webhook1, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.CreateWebhook(ctx, &dto.CreateWebhookInput{UserID: "user1", URL: "http://localhost/1"})
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, webhook1, res)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("GetWebhooks", mock.Anything).Return([]*types.Webhook{webhook1, webhook2}, nil).Twice()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.ListWebhooks(ctx, "")
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, []*types.Webhook{webhook1, webhook2}, res)
+		res, err = app.ListWebhooks(ctx, "user1")
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, []*types.Webhook{webhook1}, res, "webhooks are not filtered by user")
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("enable", func(t *testing.T) {
+		enabled := *webhook2
+		enabled.Enabled, enabled.Failures = true, 0
+		storage := new(mocks.Storage)
+		storage.On("UpdateWebhookState", mock.Anything, webhook2.ID, true, 0).Return(nil).Once()
+		storage.On("GetWebhook", mock.Anything, webhook2.ID).Return(&enabled, nil).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.EnableWebhook(ctx, webhook2.ID.String())
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, &enabled, res)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		deliveries := []*types.WebhookDelivery{{DeliveryID: uuid.New(), Attempt: 1, WebhookID: webhook1.ID}}
+		unknown := uuid.New()
+		storage := new(mocks.Storage)
+		storage.On("GetWebhook", mock.Anything, webhook1.ID).Return(webhook1, nil).Once()
+		storage.On("GetWebhookDeliveries", mock.Anything, webhook1.ID, 10).Return(deliveries, nil).Once()
+		storage.On("GetWebhook", mock.Anything, unknown).Return(nil, projectErrors.ErrWebhookNotFound).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		res, err := app.ListWebhookDeliveries(ctx, webhook1.ID.String(), 10)
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, deliveries, res)
+		_, err = app.ListWebhookDeliveries(ctx, unknown.String(), 10)
+		require.ErrorIs(t, err, projectErrors.ErrWebhookNotFound)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		storage := new(mocks.Storage)
+		storage.On("DeleteWebhook", mock.Anything, webhook1.ID).Return(projectErrors.ErrWebhookNotFound).Once()
+		app := &App{s: storage, l: new(mocks.Logger), retryTimeout: time.Millisecond}
+
+		_, err := app.CreateWebhook(ctx, nil)
+		require.ErrorIs(t, err, projectErrors.ErrNoData)
+		_, err = app.CreateWebhook(ctx, &dto.CreateWebhookInput{})
+		require.ErrorIs(t, err, projectErrors.ErrEmptyField)
+		_, err = app.CreateWebhook(ctx, &dto.CreateWebhookInput{URL: "ftp://localhost"})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.CreateWebhook(ctx, &dto.CreateWebhookInput{URL: "http://localhost", EventTypes: []string{"moved"}})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		_, err = app.EnableWebhook(ctx, "invalid")
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+		err = app.DeleteWebhook(ctx, webhook1.ID.String())
+		require.ErrorIs(t, err, projectErrors.ErrWebhookNotFound)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestEventChanges_Notify(t *testing.T) {
+	ctx := context.Background()
+	event := newCachedEvent("user1")
+	notifier := &fakeNotifier{}
+
+	storage := new(mocks.Storage)
+	storage.On("CreateEvent", mock.Anything, mock.Anything).Return(event, nil).Once()
+	storage.On("UpdateEvent", mock.Anything, event.ID, mock.Anything).Return(event, nil).Once()
+	storage.On("GetEvent", mock.Anything, event.ID).Return(event, nil).Once()
+	storage.On("DeleteEvent", mock.Anything, event.ID).Return(nil).Once()
+	storage.On("DeleteEvent", mock.Anything, event.ID).Return(projectErrors.ErrEventNotFound).Once()
+	storage.On("GetEvent", mock.Anything, event.ID).Return(nil, projectErrors.ErrEventNotFound).Once()
+	a, err := NewApp(new(mocks.Logger), storage, map[string]any{
+		"retries":       0,
+		"retry_timeout": time.Millisecond,
+	}, WithNotifier(notifier))
+	require.NoError(t, err)
+
+	_, err = a.CreateEvent(ctx, &dto.CreateEventInput{
+		Title: event.Title, Datetime: event.Datetime, Duration: event.Duration, UserID: event.UserID,
+	})
+	require.NoError(t, err)
+	title := "Updated"
+	_, err = a.UpdateEvent(ctx, &dto.UpdateEventInput{
+		ID: event.ID, Title: &title, Datetime: &event.Datetime, Duration: &event.Duration, UserID: &event.UserID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, a.DeleteEvent(ctx, event.ID.String()))
+
+	// Failed changes are not notified.
+	require.ErrorIs(t, a.DeleteEvent(ctx, event.ID.String()), projectErrors.ErrEventNotFound)
+
+	require.Equal(t, []types.WebhookEventType{
+		types.WebhookEventCreated, types.WebhookEventUpdated, types.WebhookEventDeleted,
+	}, notifier.changes)
+	require.Equal(t, []*types.Event{event, event, event}, notifier.events)
+}
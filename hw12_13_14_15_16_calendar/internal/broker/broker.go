@@ -0,0 +1,101 @@
+// Package broker provides a message broker interface and factory method for message broker construction.
+package broker
+
+import (
+	"fmt"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"      //nolint:depguard,nolintlint
+)
+
+// ClientType represents a message broker client type. The values match the client types of the broker packages.
+type ClientType int
+
+const (
+	// FullClient is a client, which both produces and consumes messages.
+	FullClient ClientType = iota
+	// ProducerOnly is a client, which only produces messages.
+	ProducerOnly
+	// ConsumerOnly is a client, which only consumes messages.
+	ConsumerOnly
+)
+
+// NewBroker creates a new message broker client based on the provided configuration.
+// The args map must contain a "type" key specifying the broker type ("rabbitmq", "nats", "kafka" or "memory")
+// and the section of the same name with the broker settings.
+// Returns an error wrapped with ErrCorruptedConfig if configuration is invalid,
+// or ErrBrokerInitFailed if initialization fails.
+func NewBroker(logger Logger, args map[string]any, typ ClientType) (Broker, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: no logger received", errors.ErrBrokerInitFailed)
+	}
+
+	brokerType, brokerArgs, err := parseConfig(args, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	var b backend
+	switch brokerType {
+	case "rabbitmq":
+		b, err = newRabbitMQ(logger, brokerArgs, typ)
+	case "nats":
+		b, err = newNATS(logger, brokerArgs, typ)
+	case "kafka":
+		b, err = newKafka(logger, brokerArgs, typ)
+	case "memory":
+		b, err = newMemoryBroker(logger, brokerArgs)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s broker: %w: %w", brokerType, errors.ErrBrokerInitFailed, err)
+	}
+
+	return &client{backend: b, name: brokerType, typ: typ}, nil
+}
+
+// ValidateConfig validates the message broker config without creating a client.
+// Key problems are returned as *config.ValidationError with the keys relative to the broker config.
+func ValidateConfig(args map[string]any, typ ClientType) error {
+	_, _, err := parseConfig(args, typ)
+	return err
+}
+
+// parseConfig extracts and validates the broker type and the settings of the chosen broker.
+func parseConfig(args map[string]any, typ ClientType) (string, map[string]any, error) {
+	if args == nil {
+		return "", nil, fmt.Errorf("%w: no broker configuration received", errors.ErrCorruptedConfig)
+	}
+
+	brokerType, ok := args["type"].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: no broker type received: %w", errors.ErrCorruptedConfig,
+			&config.ValidationError{Missing: []string{"type"}})
+	}
+
+	var validate func(map[string]any) error
+	switch brokerType {
+	case "rabbitmq":
+		validate = func(cfg map[string]any) error { return validateRabbitMQ(cfg, typ) }
+	case "nats":
+		validate = func(cfg map[string]any) error { return validateNATS(cfg, typ) }
+	case "kafka":
+		validate = func(cfg map[string]any) error { return validateKafka(cfg, typ) }
+	case "memory":
+		validate = validateMemoryBroker
+	default:
+		return "", nil, fmt.Errorf("%w: unknown broker type %q: %w", errors.ErrCorruptedConfig, brokerType,
+			&config.ValidationError{InvalidValue: []string{"type"}})
+	}
+
+	brokerArgs, ok := args[brokerType].(map[string]any)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: no broker configuration received: %w", errors.ErrCorruptedConfig,
+			&config.ValidationError{Missing: []string{brokerType}})
+	}
+	if err := validate(brokerArgs); err != nil {
+		return "", nil, fmt.Errorf("%w: %w", errors.ErrCorruptedConfig, prefixError(brokerType, err))
+	}
+
+	return brokerType, brokerArgs, nil
+}
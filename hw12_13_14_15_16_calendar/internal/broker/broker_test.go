@@ -0,0 +1,241 @@
+package broker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/broker"               //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                    //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// discardLogger is a logger, which drops all messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+func connectionArgs() map[string]any {
+	return map[string]any{
+		"host":          "localhost",
+		"port":          "1234",
+		"user":          "user",
+		"password":      "pass",
+		"timeout":       time.Second,
+		"retry_timeout": 100 * time.Millisecond,
+		"retries":       3,
+		"topic":         "calendar",
+		"resub_timeout": time.Second,
+		"auto_ack":      false,
+		"requeue":       true,
+	}
+}
+
+func defaultArgs() map[string]any {
+	rmqArgs := connectionArgs()
+	rmqArgs["durable"] = true
+	rmqArgs["content_type"] = "application/json"
+	rmqArgs["routing_key"] = "scheduler"
+
+	natsArgs := connectionArgs()
+	natsArgs["subject"] = "calendar.notifications"
+	natsArgs["durable"] = true
+	natsArgs["consumer"] = "sender"
+
+	kafkaArgs := connectionArgs()
+	kafkaArgs["partitions"] = 1
+	kafkaArgs["replication_factor"] = 1
+	kafkaArgs["group"] = "sender"
+
+	return map[string]any{
+		"rabbitmq": rmqArgs,
+		"nats":     natsArgs,
+		"kafka":    kafkaArgs,
+		"memory": map[string]any{
+			"queue_size": 10,
+			"timeout":    time.Second,
+			"auto_ack":   false,
+			"requeue":    true,
+		},
+	}
+}
+
+func argsOfType(brokerType string) map[string]any {
+	args := defaultArgs()
+	args["type"] = brokerType
+	return args
+}
+
+func TestNewBroker(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          map[string]any
+		typ           broker.ClientType
+		expectedError error
+		expectedKeys  *config.ValidationError
+	}{
+		{name: "no config passed", args: nil, expectedError: projectErrors.ErrCorruptedConfig},
+		{
+			name:          "missing type",
+			args:          defaultArgs(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{Missing: []string{"type"}},
+		},
+		{
+			name:          "unknown broker type",
+			args:          argsOfType("invalid"),
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{InvalidValue: []string{"type"}},
+		},
+		{
+			name: "missing broker section",
+			args: func() map[string]any {
+				args := argsOfType("nats")
+				delete(args, "nats")
+				return args
+			}(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{Missing: []string{"nats"}},
+		},
+		{name: "rabbitmq/valid", args: argsOfType("rabbitmq"), typ: broker.FullClient},
+		{name: "nats/valid", args: argsOfType("nats"), typ: broker.FullClient},
+		{name: "kafka/valid", args: argsOfType("kafka"), typ: broker.FullClient},
+		{name: "memory/valid", args: argsOfType("memory"), typ: broker.FullClient},
+		{
+			name: "nats/producer without consumer fields",
+			args: func() map[string]any {
+				args := argsOfType("nats")
+				delete(args["nats"].(map[string]any), "consumer")
+				delete(args["nats"].(map[string]any), "resub_timeout")
+				return args
+			}(),
+			typ: broker.ProducerOnly,
+		},
+		{
+			name: "nats/consumer without consumer name",
+			args: func() map[string]any {
+				args := argsOfType("nats")
+				args["nats"].(map[string]any)["consumer"] = ""
+				return args
+			}(),
+			typ:           broker.ConsumerOnly,
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{InvalidValue: []string{"nats.consumer"}},
+		},
+		{
+			name: "kafka/consumer without group",
+			args: func() map[string]any {
+				args := argsOfType("kafka")
+				delete(args["kafka"].(map[string]any), "group")
+				return args
+			}(),
+			typ:           broker.ConsumerOnly,
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{Missing: []string{"kafka.group"}},
+		},
+		{
+			name: "kafka/invalid retry timeout",
+			args: func() map[string]any {
+				args := argsOfType("kafka")
+				args["kafka"].(map[string]any)["retry_timeout"] = time.Duration(0)
+				return args
+			}(),
+			typ:           broker.ProducerOnly,
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{InvalidValue: []string{"kafka.retry_timeout"}},
+		},
+		{
+			name: "rabbitmq/invalid field type",
+			args: func() map[string]any {
+				args := argsOfType("rabbitmq")
+				args["rabbitmq"].(map[string]any)["retries"] = "5"
+				return args
+			}(),
+			typ:           broker.ProducerOnly,
+			expectedError: projectErrors.ErrCorruptedConfig,
+			expectedKeys:  &config.ValidationError{InvalidType: []string{"rabbitmq.retries"}},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			b, err := broker.NewBroker(discardLogger{}, tC.args, tC.typ)
+			validationErr := broker.ValidateConfig(tC.args, tC.typ)
+			if tC.expectedError == nil {
+				require.NoError(t, err)
+				require.NotNil(t, b)
+				require.NoError(t, validationErr)
+				return
+			}
+
+			require.ErrorIs(t, err, tC.expectedError)
+			require.Nil(t, b)
+			require.ErrorIs(t, validationErr, tC.expectedError)
+			if tC.expectedKeys == nil {
+				return
+			}
+			var ve *config.ValidationError
+			require.ErrorAs(t, validationErr, &ve)
+			require.ElementsMatch(t, tC.expectedKeys.Missing, ve.Missing)
+			require.ElementsMatch(t, tC.expectedKeys.InvalidType, ve.InvalidType)
+			require.ElementsMatch(t, tC.expectedKeys.InvalidValue, ve.InvalidValue)
+		})
+	}
+
+	_, err := broker.NewBroker(nil, argsOfType("memory"), broker.FullClient)
+	require.ErrorIs(t, err, projectErrors.ErrBrokerInitFailed)
+}
+
+func TestReload(t *testing.T) {
+	t.Run("reloadable broker", func(t *testing.T) {
+		b, err := broker.NewBroker(discardLogger{}, argsOfType("nats"), broker.ConsumerOnly)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"nats.retries", "nats.retry_timeout", "nats.resub_timeout"}, b.ReloadKeys())
+
+		args := argsOfType("nats")
+		args["nats"].(map[string]any)["retries"] = 10
+		require.NoError(t, b.Reload(args))
+
+		args["nats"].(map[string]any)["resub_timeout"] = time.Duration(0)
+		require.Error(t, b.Reload(args))
+
+		delete(args, "nats")
+		require.Error(t, b.Reload(args))
+	})
+
+	t.Run("producer keys", func(t *testing.T) {
+		b, err := broker.NewBroker(discardLogger{}, argsOfType("kafka"), broker.ProducerOnly)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"kafka.retries", "kafka.retry_timeout"}, b.ReloadKeys())
+	})
+
+	t.Run("not reloadable broker", func(t *testing.T) {
+		b, err := broker.NewBroker(discardLogger{}, argsOfType("memory"), broker.FullClient)
+		require.NoError(t, err)
+		require.Empty(t, b.ReloadKeys())
+		require.NoError(t, b.Reload(map[string]any{}))
+	})
+}
+
+func TestMemoryBroker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b, err := broker.NewBroker(discardLogger{}, argsOfType("memory"), broker.FullClient)
+	require.NoError(t, err)
+	require.NoError(t, b.Connect(ctx))
+	defer b.Close(ctx)
+
+	require.NoError(t, b.Produce(ctx, []byte("msg")))
+	msgs, _ := b.Consume(ctx)
+	select {
+	case msg := <-msgs:
+		require.Equal(t, "msg", string(msg))
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+}
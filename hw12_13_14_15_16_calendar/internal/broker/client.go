@@ -0,0 +1,48 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+)
+
+// client wraps the backend of the particular type, providing the common Broker interface.
+type client struct {
+	backend
+	name string // Broker type, which is also the name of its config section.
+	typ  ClientType
+}
+
+// ProduceWithContentType sends a message with the given content type, if the backend supports it.
+// Otherwise the message is sent without the content type.
+func (c *client) ProduceWithContentType(ctx context.Context, payload []byte, contentType string) error {
+	if p, ok := c.backend.(contentTypeProducer); ok {
+		return p.ProduceWithContentType(ctx, payload, contentType)
+	}
+	return c.backend.Produce(ctx, payload)
+}
+
+// Reload applies the runtime settings from the section of the configured broker type.
+// The broker type itself cannot be changed at runtime. Backends without reloadable settings ignore the call.
+func (c *client) Reload(cfg map[string]any) error {
+	r, ok := c.backend.(reloadable)
+	if !ok {
+		return nil
+	}
+	brokerArgs, ok := cfg[c.name].(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid broker config: missing=[%s]", c.name)
+	}
+	return r.Reload(brokerArgs)
+}
+
+// ReloadKeys returns the keys of the configured broker section, which are applied by Reload.
+func (c *client) ReloadKeys() []string {
+	if _, ok := c.backend.(reloadable); !ok {
+		return []string{}
+	}
+	keys := []string{c.name + ".retries", c.name + ".retry_timeout"}
+	if c.typ != ProducerOnly {
+		keys = append(keys, c.name+".resub_timeout")
+	}
+	return keys
+}
@@ -0,0 +1,63 @@
+package broker
+
+import "context"
+
+// Broker represents a universal message broker interface.
+type Broker interface {
+	// Connect establishes a connection to the message broker.
+	Connect(ctx context.Context) error
+
+	// Close closes the connection to the message broker.
+	Close(ctx context.Context) error
+
+	// Produce sends a message to the message broker.
+	// Returns an error if the operation fails.
+	Produce(ctx context.Context, payload []byte) error
+
+	// ProduceWithContentType sends a message with the given content type to the message broker.
+	// Brokers without message metadata support send the message as is.
+	// Returns an error if the operation fails.
+	ProduceWithContentType(ctx context.Context, payload []byte, contentType string) error
+
+	// Consume opens a channel to receive messages from the message broker.
+	// Returns data and error channels.
+	Consume(ctx context.Context) (<-chan []byte, <-chan error)
+
+	// Reload applies the broker config settings, which are safe to change at runtime.
+	// Expects the whole broker config section, the same as the factory receives.
+	Reload(cfg map[string]any) error
+
+	// ReloadKeys returns the broker config keys, which are applied by Reload.
+	// Keys are relative to the broker config section. Empty slice means nothing might be reloaded.
+	ReloadKeys() []string
+}
+
+// Logger represents an interface of logger visible to the message broker.
+type Logger interface {
+	// Info logs a message with level Info on the standard logger.
+	Info(ctx context.Context, msg string, args ...any)
+	// Debug logs a message with level Debug on the standard logger.
+	Debug(ctx context.Context, msg string, args ...any)
+	// Warn logs a message with level Warn on the standard logger.
+	Warn(ctx context.Context, msg string, args ...any)
+	// Error logs a message with level Error on the standard logger.
+	Error(ctx context.Context, msg string, args ...any)
+}
+
+// backend is a message broker client of the particular type.
+type backend interface {
+	Connect(ctx context.Context) error
+	Close(ctx context.Context) error
+	Produce(ctx context.Context, payload []byte) error
+	Consume(ctx context.Context) (<-chan []byte, <-chan error)
+}
+
+// contentTypeProducer is a backend, which supports message content type.
+type contentTypeProducer interface {
+	ProduceWithContentType(ctx context.Context, payload []byte, contentType string) error
+}
+
+// reloadable is a backend, which supports runtime settings reload.
+type reloadable interface {
+	Reload(cfg map[string]any) error
+}
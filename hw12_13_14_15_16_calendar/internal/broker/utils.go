@@ -0,0 +1,74 @@
+package broker
+
+import (
+	"errors"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"    //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/kafka"     //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/membroker" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/natsjs"    //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/rabbitmq"  //nolint:depguard,nolintlint
+)
+
+// newRabbitMQ creates a new RabbitMQ client. args are expected to be validated.
+func newRabbitMQ(logger Logger, args map[string]any, typ ClientType) (backend, error) {
+	return rabbitmq.NewRabbitMQ(logger, args, rabbitmq.ClientType(typ))
+}
+
+// validateRabbitMQ validates the RabbitMQ client config.
+func validateRabbitMQ(args map[string]any, typ ClientType) error {
+	return rabbitmq.ValidateConfig(args, rabbitmq.ClientType(typ))
+}
+
+// newNATS creates a new NATS JetStream client. args are expected to be validated.
+func newNATS(logger Logger, args map[string]any, typ ClientType) (backend, error) {
+	return natsjs.NewJetStream(logger, args, natsjs.ClientType(typ))
+}
+
+// validateNATS validates the NATS JetStream client config.
+func validateNATS(args map[string]any, typ ClientType) error {
+	return natsjs.ValidateConfig(args, natsjs.ClientType(typ))
+}
+
+// newKafka creates a new Kafka client. args are expected to be validated.
+func newKafka(logger Logger, args map[string]any, typ ClientType) (backend, error) {
+	return kafka.NewKafka(logger, args, kafka.ClientType(typ))
+}
+
+// validateKafka validates the Kafka client config.
+func validateKafka(args map[string]any, typ ClientType) error {
+	return kafka.ValidateConfig(args, kafka.ClientType(typ))
+}
+
+// newMemoryBroker creates a new in-process broker, which is always a full client. args are expected to be validated.
+func newMemoryBroker(logger Logger, args map[string]any) (backend, error) {
+	return membroker.NewBroker(logger, args)
+}
+
+// validateMemoryBroker validates the in-process broker config.
+func validateMemoryBroker(args map[string]any) error {
+	return membroker.ValidateConfig(args)
+}
+
+// prefixError returns the validation error of the nested config section with the section prefix in the keys,
+// e.g. "rabbitmq.host". Other errors are returned as is.
+func prefixError(section string, err error) error {
+	var ve *config.ValidationError
+	if !errors.As(err, &ve) {
+		return err
+	}
+	return &config.ValidationError{
+		Missing:      prefixKeys(section, ve.Missing),
+		InvalidType:  prefixKeys(section, ve.InvalidType),
+		InvalidValue: prefixKeys(section, ve.InvalidValue),
+	}
+}
+
+// prefixKeys returns the keys of the nested config section with the section prefix, e.g. "rabbitmq.host".
+func prefixKeys(section string, keys []string) []string {
+	res := make([]string, len(keys))
+	for i, key := range keys {
+		res[i] = section + "." + key
+	}
+	return res
+}
@@ -7,11 +7,13 @@ import (
 
 // Config is a config for calendar service.
 type Config struct {
-	Logger  LoggerConf  `mapstructure:"logger"`
-	Storage StorageConf `mapstructure:"storage"`
-	App     AppConf     `mapstructure:"app"`
-	HTTP    HTTPConf    `mapstructure:"http"`
-	GRPC    GRPCConf    `mapstructure:"grpc"`
+	Logger    LoggerConf    `mapstructure:"logger"`
+	Storage   StorageConf   `mapstructure:"storage"`
+	App       AppConf       `mapstructure:"app"`
+	HTTP      HTTPConf      `mapstructure:"http"`
+	GRPC      GRPCConf      `mapstructure:"grpc"`
+	Templates TemplatesConf `mapstructure:"templates"`
+	Webhooks  WebhooksConf  `mapstructure:"webhooks"`
 }
 
 // LoggerConf is a config for logger.
@@ -27,28 +29,55 @@ type StorageConf struct {
 	Type   string     `mapstructure:"type"`
 	SQL    SQLConf    `mapstructure:"sql"`
 	Memory MemoryConf `mapstructure:"memory"`
+	Bolt   BoltConf   `mapstructure:"bolt"`
 }
 
 // SQLConf represents a database configuration used to build DSN string.
 type SQLConf struct {
-	Host     string        `mapstructure:"host"`
-	Port     string        `mapstructure:"port"`
-	User     string        `mapstructure:"user"`
-	Password string        `mapstructure:"password"`
-	DBname   string        `mapstructure:"dbname"`
-	Timeout  time.Duration `mapstructure:"timeout"` // 0 means timeout will be disabled.
-	Driver   string        `mapstructure:"driver"`
+	Host            string        `mapstructure:"host"`
+	Port            string        `mapstructure:"port"`
+	User            string        `mapstructure:"user"`
+	Password        string        `mapstructure:"password"`
+	DBname          string        `mapstructure:"dbname"`
+	Timeout         time.Duration `mapstructure:"timeout"` // 0 means timeout will be disabled.
+	Driver          string        `mapstructure:"driver"`
+	AutoMigrate     bool          `mapstructure:"auto_migrate"`      // Apply pending schema migrations on connect.
+	Replicas        []string      `mapstructure:"replicas"`          // Read replicas in "host" or "host:port" format.
+	SSLMode         string        `mapstructure:"sslmode"`           // Empty means "disable".
+	SSLRootCert     string        `mapstructure:"sslrootcert"`       // Root CA certificate path.
+	SSLCert         string        `mapstructure:"sslcert"`           // Client certificate path.
+	SSLKey          string        `mapstructure:"sslkey"`            // Client key path.
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`    // 0 means no limit.
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`    // 0 keeps the driver default.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"` // 0 means connections are reused forever.
 }
 
 // MemoryConf is a config for memory storage.
 type MemoryConf struct {
-	Size int `mapstructure:"size"`
+	Size              int           `mapstructure:"size"`
+	WALDir            string        `mapstructure:"wal_dir"`            // Empty means the data is not persisted.
+	Fsync             string        `mapstructure:"fsync"`              // always, interval or never. Empty means "interval".
+	FsyncInterval     time.Duration `mapstructure:"fsync_interval"`     // 0 means 1 second.
+	SnapshotInterval  time.Duration `mapstructure:"snapshot_interval"`  // 0 disables periodic snapshots.
+	SnapshotThreshold int           `mapstructure:"snapshot_threshold"` // 0 disables snapshots by WAL size.
+}
+
+// BoltConf is a config for bolt storage.
+type BoltConf struct {
+	Path    string        `mapstructure:"path"`
+	Timeout time.Duration `mapstructure:"timeout"` // 0 means waiting for the file lock indefinitely.
 }
 
 // AppConf is a config for the global app settings, like retry timeout and number of retries.
 type AppConf struct {
-	RetryTimeout time.Duration `mapstructure:"retry_timeout"`
-	Retries      int           `mapstructure:"retries"`
+	RetryTimeout     time.Duration `mapstructure:"retry_timeout"`
+	Retries          int           `mapstructure:"retries"`
+	OverlapPolicy    string        `mapstructure:"overlap_policy"`      // Default policy for overlapping events.
+	MaxEventsPerUser int           `mapstructure:"max_events_per_user"` // 0 means no limit.
+	IdempotencyTTL   time.Duration `mapstructure:"idempotency_ttl"`     // 0 means 24 hours.
+	CacheSize        int           `mapstructure:"cache_size"`          // 0 disables the storage cache.
+	CacheTTL         time.Duration `mapstructure:"cache_ttl"`           // 0 means 1 minute.
+	CleanupRetention time.Duration `mapstructure:"cleanup_retention"`   // 0 means 30 days.
 }
 
 // HTTPConf is a config for http server.
@@ -59,6 +88,9 @@ type HTTPConf struct {
 	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 	IdleTimeout     time.Duration `mapstructure:"idle_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	RateLimit       float64       `mapstructure:"rate_limit"`       // Requests per second per client. 0 disables limiting.
+	RateLimitBurst  int           `mapstructure:"rate_limit_burst"` // 0 means the rate, but not less than 1.
+	TrustedProxies  []string      `mapstructure:"trusted_proxies"`  // IPs or CIDRs trusted to set X-Forwarded-For.
 }
 
 // GRPCConf is a config for gRPC server.
@@ -66,4 +98,28 @@ type GRPCConf struct {
 	Host            string        `mapstructure:"host"`
 	Port            string        `mapstructure:"port"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	RateLimit       float64       `mapstructure:"rate_limit"`       // Requests per second per client. 0 disables limiting.
+	RateLimitBurst  int           `mapstructure:"rate_limit_burst"` // 0 means the rate, but not less than 1.
+	TrustedProxies  []string      `mapstructure:"trusted_proxies"`  // IPs or CIDRs trusted to set X-Forwarded-For.
+	AdminToken      string        `mapstructure:"admin_token"`      // Required by the admin methods. Empty disables them.
+}
+
+// TemplatesConf is a config for notification templates.
+type TemplatesConf struct {
+	Source          string `mapstructure:"source"`            // dir or storage.
+	Dir             string `mapstructure:"dir"`               // Templates directory, used by the dir source.
+	DefaultLocale   string `mapstructure:"default_locale"`    // Locale of the users without settings.
+	DefaultTimeZone string `mapstructure:"default_time_zone"` // IANA time zone of the users without settings.
+}
+
+// WebhooksConf is a config for the webhook deliveries.
+type WebhooksConf struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Workers     int           `mapstructure:"workers"`      // 0 means 1.
+	QueueSize   int           `mapstructure:"queue_size"`   // 0 means 100.
+	Timeout     time.Duration `mapstructure:"timeout"`      // 0 means 10 seconds.
+	Retries     int           `mapstructure:"retries"`      // 0 means no retries.
+	Backoff     time.Duration `mapstructure:"backoff"`      // 0 means 1 second.
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`  // 0 means 1 minute, but not less than backoff.
+	MaxFailures int           `mapstructure:"max_failures"` // 0 means subscriptions are never disabled.
 }
@@ -0,0 +1,20 @@
+// Package calendarctl provides configuration structures for the admin CLI.
+package calendarctl
+
+import (
+	"time"
+)
+
+// Config is a config for calendarctl.
+type Config struct {
+	Client ClientConf `mapstructure:"client"`
+}
+
+// ClientConf is a config for the gRPC client of the calendar service.
+type ClientConf struct {
+	Address    string        `mapstructure:"address"`     // Calendar gRPC endpoint in "host:port" format.
+	Timeout    time.Duration `mapstructure:"timeout"`     // 0 means timeout will be disabled.
+	UserID     string        `mapstructure:"user_id"`     // Sent as x-user-id metadata. Empty means no metadata.
+	Output     string        `mapstructure:"output"`      // table, json or yaml. Empty means "table".
+	AdminToken string        `mapstructure:"admin_token"` // Sent as x-admin-token metadata. Empty means no metadata.
+}
@@ -0,0 +1,33 @@
+package calendarctl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+func TestGetSubConfig(t *testing.T) {
+	cfg := &Config{
+		Client: ClientConf{
+			Address:    "localhost:9090",
+			Timeout:    5 * time.Second,
+			UserID:     "admin",
+			Output:     "json",
+			AdminToken: "secret",
+		},
+	}
+
+	subCfg, err := cfg.GetSubConfig("client")
+	require.NoError(t, err, "expected no error when extracting subconfig")
+	require.Equal(t, map[string]any{
+		"address":     "localhost:9090",
+		"timeout":     5 * time.Second,
+		"user_id":     "admin",
+		"output":      "json",
+		"admin_token": "secret",
+	}, subCfg, "expected and actual configs do not match")
+
+	_, err = cfg.GetSubConfig("nonexistent")
+	require.Error(t, err, "expected error for the missing section")
+}
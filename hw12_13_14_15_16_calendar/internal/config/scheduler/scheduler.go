@@ -7,10 +7,11 @@ import (
 
 // Config is a config for calendar service.
 type Config struct {
-	Logger  LoggerConf  `mapstructure:"logger"`
-	Storage StorageConf `mapstructure:"storage"`
-	RMQ     RMQConf     `mapstructure:"rmq"`
-	App     AppConf     `mapstructure:"app"`
+	Logger   LoggerConf   `mapstructure:"logger"`
+	Storage  StorageConf  `mapstructure:"storage"`
+	Broker   BrokerConf   `mapstructure:"broker"`
+	App      AppConf      `mapstructure:"app"`
+	Webhooks WebhooksConf `mapstructure:"webhooks"`
 }
 
 // LoggerConf is a config for logger.
@@ -26,26 +27,55 @@ type StorageConf struct {
 	Type   string     `mapstructure:"type"`
 	SQL    SQLConf    `mapstructure:"sql"`
 	Memory MemoryConf `mapstructure:"memory"`
+	Bolt   BoltConf   `mapstructure:"bolt"`
 }
 
 // SQLConf represents a database configuration used to build DSN string.
 type SQLConf struct {
-	Host     string        `mapstructure:"host"`
-	Port     string        `mapstructure:"port"`
-	User     string        `mapstructure:"user"`
-	Password string        `mapstructure:"password"`
-	DBname   string        `mapstructure:"dbname"`
-	Timeout  time.Duration `mapstructure:"timeout"` // 0 means timeout will be disabled.
-	Driver   string        `mapstructure:"driver"`
+	Host            string        `mapstructure:"host"`
+	Port            string        `mapstructure:"port"`
+	User            string        `mapstructure:"user"`
+	Password        string        `mapstructure:"password"`
+	DBname          string        `mapstructure:"dbname"`
+	Timeout         time.Duration `mapstructure:"timeout"` // 0 means timeout will be disabled.
+	Driver          string        `mapstructure:"driver"`
+	AutoMigrate     bool          `mapstructure:"auto_migrate"`      // Apply pending schema migrations on connect.
+	Replicas        []string      `mapstructure:"replicas"`          // Read replicas in "host" or "host:port" format.
+	SSLMode         string        `mapstructure:"sslmode"`           // Empty means "disable".
+	SSLRootCert     string        `mapstructure:"sslrootcert"`       // Root CA certificate path.
+	SSLCert         string        `mapstructure:"sslcert"`           // Client certificate path.
+	SSLKey          string        `mapstructure:"sslkey"`            // Client key path.
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`    // 0 means no limit.
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`    // 0 keeps the driver default.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"` // 0 means connections are reused forever.
 }
 
 // MemoryConf is a config for memory storage.
 type MemoryConf struct {
-	Size int `mapstructure:"size"`
+	Size              int           `mapstructure:"size"`
+	WALDir            string        `mapstructure:"wal_dir"`            // Empty means the data is not persisted.
+	Fsync             string        `mapstructure:"fsync"`              // always, interval or never. Empty means "interval".
+	FsyncInterval     time.Duration `mapstructure:"fsync_interval"`     // 0 means 1 second.
+	SnapshotInterval  time.Duration `mapstructure:"snapshot_interval"`  // 0 disables periodic snapshots.
+	SnapshotThreshold int           `mapstructure:"snapshot_threshold"` // 0 disables snapshots by WAL size.
 }
 
-// RMQConf is a config for Rabbit MQ client.
-type RMQConf struct {
+// BoltConf is a config for bolt storage.
+type BoltConf struct {
+	Path    string        `mapstructure:"path"`
+	Timeout time.Duration `mapstructure:"timeout"` // 0 means waiting for the file lock indefinitely.
+}
+
+// BrokerConf is a config for message broker containing broker type.
+type BrokerConf struct {
+	Type     string       `mapstructure:"type"`
+	RabbitMQ RabbitMQConf `mapstructure:"rabbitmq"`
+	NATS     NATSConf     `mapstructure:"nats"`
+	Kafka    KafkaConf    `mapstructure:"kafka"`
+}
+
+// RabbitMQConf is a config for Rabbit MQ client.
+type RabbitMQConf struct {
 	Host         string        `mapstructure:"host"`
 	Port         string        `mapstructure:"port"`
 	User         string        `mapstructure:"user"`
@@ -59,6 +89,34 @@ type RMQConf struct {
 	RoutingKey   string        `mapstructure:"routing_key"`
 }
 
+// NATSConf is a config for NATS JetStream client.
+type NATSConf struct {
+	Host         string        `mapstructure:"host"`
+	Port         string        `mapstructure:"port"`
+	User         string        `mapstructure:"user"`
+	Password     string        `mapstructure:"password"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	RetryTimeout time.Duration `mapstructure:"retry_timeout"`
+	Retries      int           `mapstructure:"retries"`
+	Topic        string        `mapstructure:"topic"`   // Stream name.
+	Subject      string        `mapstructure:"subject"` // Subject the messages are published to.
+	Durable      bool          `mapstructure:"durable"` // File storage for the stream, memory storage otherwise.
+}
+
+// KafkaConf is a config for Kafka client.
+type KafkaConf struct {
+	Host              string        `mapstructure:"host"`
+	Port              string        `mapstructure:"port"`
+	User              string        `mapstructure:"user"`
+	Password          string        `mapstructure:"password"`
+	Timeout           time.Duration `mapstructure:"timeout"`
+	RetryTimeout      time.Duration `mapstructure:"retry_timeout"`
+	Retries           int           `mapstructure:"retries"`
+	Topic             string        `mapstructure:"topic"`
+	Partitions        int           `mapstructure:"partitions"`         // Used on topic creation. 0 means 1.
+	ReplicationFactor int           `mapstructure:"replication_factor"` // Used on topic creation. 0 means 1.
+}
+
 // AppConf is a config for the global app settings, like retry timeout and number of retries,
 // as well as queue intervals.
 type AppConf struct {
@@ -66,4 +124,19 @@ type AppConf struct {
 	Retries         int           `mapstructure:"retries"`
 	QueueInterval   time.Duration `mapstructure:"queue_interval"`
 	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	DigestInterval  time.Duration `mapstructure:"digest_interval"`
+	CatchUpPolicy   string        `mapstructure:"catch_up_policy"` // always, skip or grace. Empty means "always".
+	CatchUpGrace    time.Duration `mapstructure:"catch_up_grace"`  // Used by the grace policy only.
+}
+
+// WebhooksConf is a config for the webhook deliveries.
+type WebhooksConf struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Workers     int           `mapstructure:"workers"`      // 0 means 1.
+	QueueSize   int           `mapstructure:"queue_size"`   // 0 means 100.
+	Timeout     time.Duration `mapstructure:"timeout"`      // 0 means 10 seconds.
+	Retries     int           `mapstructure:"retries"`      // 0 means no retries.
+	Backoff     time.Duration `mapstructure:"backoff"`      // 0 means 1 second.
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`  // 0 means 1 minute, but not less than backoff.
+	MaxFailures int           `mapstructure:"max_failures"` // 0 means subscriptions are never disabled.
 }
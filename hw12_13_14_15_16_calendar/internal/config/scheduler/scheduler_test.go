@@ -55,16 +55,34 @@ func TestGetSubConfig_Success(t *testing.T) {
 			Expected: map[string]any{
 				"type": "sql",
 				"sql": map[string]any{
-					"driver":   "postgres",
-					"host":     "localhost",
-					"port":     "5432",
-					"user":     "user",
-					"password": "pass",
-					"dbname":   "calendar",
-					"timeout":  defaultTimeout,
+					"driver":            "postgres",
+					"host":              "localhost",
+					"port":              "5432",
+					"user":              "user",
+					"password":          "pass",
+					"dbname":            "calendar",
+					"timeout":           defaultTimeout,
+					"auto_migrate":      false,
+					"replicas":          []string(nil),
+					"sslmode":           "",
+					"sslrootcert":       "",
+					"sslcert":           "",
+					"sslkey":            "",
+					"max_open_conns":    0,
+					"max_idle_conns":    0,
+					"conn_max_lifetime": time.Duration(0),
 				},
 				"memory": map[string]any{
-					"size": 0,
+					"size":               0,
+					"wal_dir":            "",
+					"fsync":              "",
+					"fsync_interval":     time.Duration(0),
+					"snapshot_interval":  time.Duration(0),
+					"snapshot_threshold": 0,
+				},
+				"bolt": map[string]any{
+					"path":    "",
+					"timeout": time.Duration(0),
 				},
 			},
 		},
@@ -7,8 +7,10 @@ import (
 
 // Config is a config for calendar service.
 type Config struct {
-	Logger LoggerConf `mapstructure:"logger"`
-	RMQ    RMQConf    `mapstructure:"rmq"`
+	Logger    LoggerConf    `mapstructure:"logger"`
+	Broker    BrokerConf    `mapstructure:"broker"`
+	Templates TemplatesConf `mapstructure:"templates"`
+	Storage   StorageConf   `mapstructure:"storage"` // Used by the storage templates source only.
 }
 
 // LoggerConf is a config for logger.
@@ -19,8 +21,16 @@ type LoggerConf struct {
 	LogStream    string `mapstructure:"log_stream"`
 }
 
-// RMQConf is a config for Rabbit MQ client.
-type RMQConf struct {
+// BrokerConf is a config for message broker containing broker type.
+type BrokerConf struct {
+	Type     string       `mapstructure:"type"`
+	RabbitMQ RabbitMQConf `mapstructure:"rabbitmq"`
+	NATS     NATSConf     `mapstructure:"nats"`
+	Kafka    KafkaConf    `mapstructure:"kafka"`
+}
+
+// RabbitMQConf is a config for Rabbit MQ client.
+type RabbitMQConf struct {
 	Host         string        `mapstructure:"host"`
 	Port         string        `mapstructure:"port"`
 	User         string        `mapstructure:"user"`
@@ -34,3 +44,70 @@ type RMQConf struct {
 	AutoAck      bool          `mapstructure:"auto_ack"`
 	Requeue      bool          `mapstructure:"requeue"`
 }
+
+// NATSConf is a config for NATS JetStream client.
+type NATSConf struct {
+	Host         string        `mapstructure:"host"`
+	Port         string        `mapstructure:"port"`
+	User         string        `mapstructure:"user"`
+	Password     string        `mapstructure:"password"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	RetryTimeout time.Duration `mapstructure:"retry_timeout"`
+	Retries      int           `mapstructure:"retries"`
+	Topic        string        `mapstructure:"topic"`    // Stream name.
+	Subject      string        `mapstructure:"subject"`  // Subject the consumer is filtered by.
+	Consumer     string        `mapstructure:"consumer"` // Durable consumer name.
+	ResubTimeout time.Duration `mapstructure:"resub_timeout"`
+	AutoAck      bool          `mapstructure:"auto_ack"`
+	Requeue      bool          `mapstructure:"requeue"`
+}
+
+// KafkaConf is a config for Kafka client.
+type KafkaConf struct {
+	Host         string        `mapstructure:"host"`
+	Port         string        `mapstructure:"port"`
+	User         string        `mapstructure:"user"`
+	Password     string        `mapstructure:"password"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	RetryTimeout time.Duration `mapstructure:"retry_timeout"`
+	Retries      int           `mapstructure:"retries"`
+	Topic        string        `mapstructure:"topic"`
+	Group        string        `mapstructure:"group"` // Consumer group ID.
+	ResubTimeout time.Duration `mapstructure:"resub_timeout"`
+	AutoAck      bool          `mapstructure:"auto_ack"`
+	Requeue      bool          `mapstructure:"requeue"`
+}
+
+// TemplatesConf is a config for notification templates.
+type TemplatesConf struct {
+	Source          string `mapstructure:"source"`            // dir or storage.
+	Dir             string `mapstructure:"dir"`               // Templates directory, used by the dir source.
+	DefaultLocale   string `mapstructure:"default_locale"`    // Locale of the users without settings.
+	DefaultTimeZone string `mapstructure:"default_time_zone"` // IANA time zone of the users without settings.
+}
+
+// StorageConf is a config for storage containing storage type.
+type StorageConf struct {
+	Type string  `mapstructure:"type"`
+	SQL  SQLConf `mapstructure:"sql"`
+}
+
+// SQLConf represents a database configuration used to build DSN string.
+type SQLConf struct {
+	Host            string        `mapstructure:"host"`
+	Port            string        `mapstructure:"port"`
+	User            string        `mapstructure:"user"`
+	Password        string        `mapstructure:"password"`
+	DBname          string        `mapstructure:"dbname"`
+	Timeout         time.Duration `mapstructure:"timeout"` // 0 means timeout will be disabled.
+	Driver          string        `mapstructure:"driver"`
+	AutoMigrate     bool          `mapstructure:"auto_migrate"`      // Apply pending schema migrations on connect.
+	Replicas        []string      `mapstructure:"replicas"`          // Read replicas in "host" or "host:port" format.
+	SSLMode         string        `mapstructure:"sslmode"`           // Empty means "disable".
+	SSLRootCert     string        `mapstructure:"sslrootcert"`       // Root CA certificate path.
+	SSLCert         string        `mapstructure:"sslcert"`           // Client certificate path.
+	SSLKey          string        `mapstructure:"sslkey"`            // Client key path.
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`    // 0 means no limit.
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`    // 0 keeps the driver default.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"` // 0 means connections are reused forever.
+}
@@ -33,6 +33,71 @@ func TestGetSubConfig_Success(t *testing.T) {
 				"log_stream":    "stdout",
 			},
 		},
+		{
+			Name: "nested sections",
+			Config: Config{
+				Broker: BrokerConf{
+					Type: "kafka",
+					Kafka: KafkaConf{
+						Host:         "kafka",
+						Port:         "9092",
+						Timeout:      time.Second,
+						RetryTimeout: time.Second,
+						Retries:      3,
+						Topic:        "calendar",
+						Group:        "sender",
+						ResubTimeout: time.Second,
+					},
+				},
+			},
+			Key: "broker",
+			Expected: map[string]any{
+				"type": "kafka",
+				"kafka": map[string]any{
+					"host":          "kafka",
+					"port":          "9092",
+					"user":          "",
+					"password":      "",
+					"timeout":       time.Second,
+					"retry_timeout": time.Second,
+					"retries":       3,
+					"topic":         "calendar",
+					"group":         "sender",
+					"resub_timeout": time.Second,
+					"auto_ack":      false,
+					"requeue":       false,
+				},
+				"rabbitmq": map[string]any{
+					"host":          "",
+					"port":          "",
+					"user":          "",
+					"password":      "",
+					"timeout":       time.Duration(0),
+					"retry_timeout": time.Duration(0),
+					"retries":       0,
+					"topic":         "",
+					"durable":       false,
+					"resub_timeout": time.Duration(0),
+					"auto_ack":      false,
+					"requeue":       false,
+				},
+				"nats": map[string]any{
+					"host":          "",
+					"port":          "",
+					"user":          "",
+					"password":      "",
+					"timeout":       time.Duration(0),
+					"retry_timeout": time.Duration(0),
+					"retries":       0,
+					"topic":         "",
+					"subject":       "",
+					"consumer":      "",
+					"resub_timeout": time.Duration(0),
+					"auto_ack":      false,
+					"requeue":       false,
+				},
+			},
+		},
 	}
 
 	for _, tC := range testCases {
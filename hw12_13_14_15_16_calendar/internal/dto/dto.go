@@ -32,34 +32,55 @@ func (p Period) MarshalJSON() ([]byte, error) {
 //
 //nolint:tagliatelle
 type CreateEventInput struct {
-	Title       string         `json:"title"`
-	Datetime    time.Time      `json:"start_date"`
-	Duration    time.Duration  `json:"end_date"`
-	UserID      string         `json:"user_id"`
-	Description *string        `json:"description,omitempty"`
-	RemindIn    *time.Duration `json:"remind_in,omitempty"`
+	ID            *uuid.UUID     `json:"id,omitempty"` // Client-chosen event ID, generated if nil.
+	Title         string         `json:"title"`
+	Datetime      time.Time      `json:"start_date"`
+	Duration      time.Duration  `json:"end_date"`
+	UserID        string         `json:"user_id"`
+	Description   *string        `json:"description,omitempty"`
+	RemindIn      *time.Duration `json:"remind_in,omitempty"`
+	BusyStatus    *string        `json:"busy_status,omitempty"`
+	OverlapPolicy *string        `json:"overlap_policy,omitempty"`
+	AllDay        bool           `json:"all_day"`
+	Tags          []string       `json:"tags,omitempty"`
+	Resources     []string       `json:"resources,omitempty"` // IDs of the booked resources.
+	Color         *string        `json:"color,omitempty"`
+	Location      *string        `json:"location,omitempty"`
+	URL           *string        `json:"url,omitempty"`
+	// IdempotencyKey deduplicates the repeated requests. It is not a part of the request payload.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdateEventInput represents the input for updating an event.
 //
 //nolint:tagliatelle
 type UpdateEventInput struct {
-	ID          uuid.UUID      `json:"id"`
-	Title       *string        `json:"title,omitempty"`
-	Datetime    *time.Time     `json:"start_date,omitempty"`
-	Duration    *time.Duration `json:"end_date,omitempty"`
-	UserID      *string        `json:"user_id,omitempty"`
-	Description *string        `json:"description,omitempty"`
-	RemindIn    *time.Duration `json:"remind_in,omitempty"`
+	ID            uuid.UUID      `json:"id"`
+	Title         *string        `json:"title,omitempty"`
+	Datetime      *time.Time     `json:"start_date,omitempty"`
+	Duration      *time.Duration `json:"end_date,omitempty"`
+	UserID        *string        `json:"user_id,omitempty"`
+	Description   *string        `json:"description,omitempty"`
+	RemindIn      *time.Duration `json:"remind_in,omitempty"`
+	BusyStatus    *string        `json:"busy_status,omitempty"`
+	OverlapPolicy *string        `json:"overlap_policy,omitempty"`
+	AllDay        *bool          `json:"all_day,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`
+	Resources     []string       `json:"resources,omitempty"` // IDs of the booked resources.
+	Color         *string        `json:"color,omitempty"`
+	Location      *string        `json:"location,omitempty"`
+	URL           *string        `json:"url,omitempty"`
 }
 
 // DateFilterInput represents the input for getters by a fixed period, starting from a specific date.
 //
 //nolint:tagliatelle
 type DateFilterInput struct {
-	Date   time.Time `json:"date"`
-	UserID *string   `json:"user_id"`
-	Period Period    `json:"period"`
+	Date     time.Time `json:"date"`
+	UserID   *string   `json:"user_id"`
+	Period   Period    `json:"period"`
+	Tags     []string  `json:"tags,omitempty"`
+	Location *string   `json:"location,omitempty"`
 }
 
 // DateRangeInput represents the input for getters by a range of dates.
@@ -69,4 +90,116 @@ type DateRangeInput struct {
 	DateStart time.Time `json:"date_start"`
 	DateEnd   time.Time `json:"date_end"`
 	UserID    *string   `json:"user_id"`
+	Tags      []string  `json:"tags,omitempty"`
+	Location  *string   `json:"location,omitempty"`
+}
+
+// SchedulerStatus represents the state of the notification queue, processed by the scheduler.
+//
+//nolint:tagliatelle
+type SchedulerStatus struct {
+	DueNotifications int64      `json:"due_notifications"` // Events with due, but not sent reminders.
+	NextReminder     *time.Time `json:"next_reminder"`     // Nil if no events are waiting for notification.
+}
+
+// CacheStats represents the state of the storage cache of the app.
+//
+//nolint:tagliatelle
+type CacheStats struct {
+	Enabled bool          `json:"enabled"`
+	Hits    int64         `json:"hits"`    // Requests served from the cache.
+	Misses  int64         `json:"misses"`  // Requests passed to the storage.
+	Events  int           `json:"events"`  // Events cached by ID.
+	Periods int           `json:"periods"` // Cached results of the period queries.
+	Size    int           `json:"size"`    // Maximum number of the events and of the period query results.
+	TTL     time.Duration `json:"ttl"`
+}
+
+// MissedRemindersInput represents the input for the report of the reminders missed during the outage.
+// Empty catch-up policy means "always", the grace window is used by the "grace" policy only.
+//
+//nolint:tagliatelle
+type MissedRemindersInput struct {
+	OutageStart   time.Time     `json:"outage_start"`
+	OutageEnd     time.Time     `json:"outage_end"`
+	CatchUpPolicy string        `json:"catch_up_policy,omitempty"`
+	CatchUpGrace  time.Duration `json:"catch_up_grace,omitempty"`
+}
+
+// Possible outcomes of the missed reminder.
+const (
+	MissedReminderDelayed = "delayed" // Sent after the outage, before the event start.
+	MissedReminderLate    = "late"    // Sent after the outage with the late marker.
+	MissedReminderSkipped = "skipped" // Skipped by the catch-up policy.
+)
+
+// MissedReminder represents the reminder, which was due during the outage, and its outcome
+// once the reminders are handled at the end of the outage.
+//
+//nolint:tagliatelle
+type MissedReminder struct {
+	EventID  string        `json:"event_id"`
+	Title    string        `json:"title"`
+	UserID   string        `json:"user_id"`
+	Start    time.Time     `json:"start"`
+	RemindAt time.Time     `json:"remind_at"`
+	Delay    time.Duration `json:"delay"`    // Time between the reminder and the end of the outage.
+	Notified bool          `json:"notified"` // The reminder is already handled by the scheduler.
+	Outcome  string        `json:"outcome"`
+}
+
+// DigestSettingsInput represents the input data for the daily digest settings update.
+// Time is the local time in HH:MM format, empty time zone means UTC.
+//
+//nolint:tagliatelle
+type DigestSettingsInput struct {
+	UserID   string `json:"user_id"`
+	Enabled  bool   `json:"enabled"`
+	Time     string `json:"time"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// CreateWebhookInput represents the input data for the webhook subscription registration.
+// Empty user ID subscribes to the changes of all users, empty event types mean all types
+// and empty secret is generated.
+//
+//nolint:tagliatelle
+type CreateWebhookInput struct {
+	UserID     string   `json:"user_id,omitempty"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// PreviewNotificationInput represents the input data for the notification preview.
+// Empty locale and time zone mean the settings of the event owner or the defaults.
+//
+//nolint:tagliatelle
+type PreviewNotificationInput struct {
+	EventID  string `json:"event_id"`
+	Channel  string `json:"channel"`
+	Locale   string `json:"locale,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// CreateResourceInput represents the input data for the bookable resource registration.
+// Empty kind means a room, zero capacity means the capacity is not applicable.
+type CreateResourceInput struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind,omitempty"`
+	Capacity   int      `json:"capacity,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+}
+
+// ResourceAvailabilityInput represents the input data for the resource availability query.
+// Resources are limited by the optional IDs, kind, minimal capacity and the attributes they must all have.
+//
+//nolint:tagliatelle
+type ResourceAvailabilityInput struct {
+	DateStart   time.Time `json:"date_start"`
+	DateEnd     time.Time `json:"date_end"`
+	ResourceIDs []string  `json:"resource_ids,omitempty"`
+	Kind        string    `json:"kind,omitempty"`
+	MinCapacity int       `json:"min_capacity,omitempty"`
+	Attributes  []string  `json:"attributes,omitempty"`
 }
@@ -1,7 +1,10 @@
 // Package errors contains errors used in the project.
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Setup errors.
 // CMD level.
@@ -11,12 +14,25 @@ var (
 	ErrCorruptedConfig = errors.New("config data is invalid")
 	// ErrStorageInitFailed is returned when the storage initialization fails.
 	ErrStorageInitFailed = errors.New("storage initialization failed")
+	// ErrBrokerInitFailed is returned when the message broker initialization fails.
+	ErrBrokerInitFailed = errors.New("message broker initialization failed")
 	// ErrAppInitFailed is returned when the app initialization fails.
 	ErrAppInitFailed = errors.New("app initialization failed")
 	// ErrServerInitFailed is returned when the server initialization fails.
 	ErrServerInitFailed = errors.New("server initialization failed")
 	// ErrUnsupportedDriver is returned when the DB driver is not supported.
-	ErrUnsupportedDriver = errors.New("unsupported driver, only 'postgres' and 'postgresql' are supported")
+	ErrUnsupportedDriver = errors.New(
+		"unsupported driver, expected 'postgres', 'postgresql', 'mysql', 'mariadb', 'sqlite' or 'sqlite3'")
+	// ErrMigrationUnsupported is returned when the storage does not support migrations.
+	ErrMigrationUnsupported = errors.New("storage does not support migrations")
+	// ErrUnknownMigrationCommand is returned when the migration command is not supported.
+	ErrUnknownMigrationCommand = errors.New("unknown migration command, expected 'up', 'down', 'status' or 'redo'")
+	// ErrPersistenceCorrupted is returned when the persisted storage data cannot be restored.
+	ErrPersistenceCorrupted = errors.New("persisted storage data is corrupted")
+	// ErrTemplatesInitFailed is returned when the notification templates cannot be loaded.
+	ErrTemplatesInitFailed = errors.New("notification templates initialization failed")
+	// ErrTemplatesUnsupported is returned when the storage does not keep the notification templates.
+	ErrTemplatesUnsupported = errors.New("storage does not keep notification templates")
 )
 
 // Storage operational errors - critical.
@@ -25,6 +41,8 @@ var (
 var (
 	// ErrStorageFull is returned when the storage is full and cannot accept new events.
 	ErrStorageFull = errors.New("storage is full")
+	// ErrWatchUnsupported is returned when the storage is unable to notify about the changes.
+	ErrWatchUnsupported = errors.New("storage does not support change notifications")
 )
 
 // Business errors.
@@ -39,6 +57,20 @@ var (
 	ErrPermissionDenied = errors.New("cannot modify another user's event")
 	// ErrNoData is returned when no data is passed to any of the CRUD methods.
 	ErrNoData = errors.New("no data passed")
+	// ErrQuotaExceeded is returned when the user has reached the maximum number of events.
+	ErrQuotaExceeded = errors.New("user event quota exceeded")
+	// ErrIdempotencyConflict is returned when the idempotency key is reused with a different request payload.
+	ErrIdempotencyConflict = errors.New("idempotency key is already used with another request")
+	// ErrDigestSettingsNotFound is returned when the user has no daily digest settings.
+	ErrDigestSettingsNotFound = errors.New("digest settings were not found")
+	// ErrWebhookNotFound is returned when the webhook subscription with requested ID does not exist.
+	ErrWebhookNotFound = errors.New("webhook subscription was not found")
+	// ErrResourceNotFound is returned when the bookable resource with requested ID does not exist.
+	ErrResourceNotFound = errors.New("requested resource was not found")
+	// ErrResourceInUse is returned when the resource to delete is booked by the upcoming events.
+	ErrResourceInUse = errors.New("resource is booked by upcoming events")
+	// ErrChangesExpired is returned when the changes since the requested point are no longer kept by the storage.
+	ErrChangesExpired = errors.New("requested changes are no longer available")
 )
 
 // Data validation errors.
@@ -59,6 +91,26 @@ var (
 	ErrRetriesExceeded = errors.New("maximum retries exceeded")
 )
 
+// Notification decoding errors.
+// Sender level.
+// WARN.
+var (
+	// ErrInvalidNotification is returned when the notification message cannot be decoded.
+	ErrInvalidNotification = errors.New("invalid notification message")
+	// ErrUnsupportedSchema is returned when the notification schema version is not supported by the sender.
+	ErrUnsupportedSchema = errors.New("unsupported notification schema version")
+)
+
+// Notification rendering errors.
+// Sender and App level.
+// WARN on Sender, INFO on App - potential 40x codes.
+var (
+	// ErrTemplateNotFound is returned when there is no template for the requested channel and locale.
+	ErrTemplateNotFound = errors.New("notification template was not found")
+	// ErrInvalidTemplate is returned when the notification template cannot be parsed or executed.
+	ErrInvalidTemplate = errors.New("notification template is invalid")
+)
+
 // Errors, which breaks the normal execution flow.
 // App level. Not retryable.
 // ERROR.
@@ -88,3 +140,27 @@ var (
 	// ErrGenerateID is returned when the event ID generation fails.
 	ErrGenerateID = errors.New("failed to generate new event id")
 )
+
+// DateBusyError is returned when the event overlaps with existing events of the user.
+// It keeps the IDs of the conflicting events and wraps ErrDateBusy.
+type DateBusyError struct {
+	ConflictingIDs []string
+}
+
+// NewDateBusyError creates a new DateBusyError with the given conflicting event IDs.
+func NewDateBusyError(ids ...string) error {
+	return &DateBusyError{ConflictingIDs: ids}
+}
+
+// Error implements the error interface.
+func (e *DateBusyError) Error() string {
+	if len(e.ConflictingIDs) == 0 {
+		return ErrDateBusy.Error()
+	}
+	return ErrDateBusy.Error() + ": conflicting_ids=" + strings.Join(e.ConflictingIDs, ",")
+}
+
+// Unwrap allows errors.Is to match ErrDateBusy.
+func (e *DateBusyError) Unwrap() error {
+	return ErrDateBusy
+}
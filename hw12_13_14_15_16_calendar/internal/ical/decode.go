@@ -0,0 +1,339 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+)
+
+// reDuration matches DURATION values: [+-]P[nW] or [+-]P[nD][T[nH][nM][nS]].
+var reDuration = regexp.MustCompile(`^([+-])?P(?:(\d+)W|(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?)$`)
+
+// reColor matches the colors supported by the event data.
+var reColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Decode reads the VCALENDAR object and returns its main VEVENT: the first one without RECURRENCE-ID.
+//
+// Times with TZID parameter are converted from the named IANA time zone, unknown zones and floating times
+// are treated as UTC. DATE value of DTSTART makes the event an all-day one.
+// Colors are kept only in #RRGGBB format, the reminder is taken from the first VALARM preceding the start.
+//
+// Returns ErrInvalidCalendar if the object is malformed or has no events.
+func Decode(r io.Reader) (*Event, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read iCalendar object: %w", err)
+	}
+
+	root, err := parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range root.components {
+		if c.name == "VEVENT" && c.prop("RECURRENCE-ID") == nil {
+			return decodeEvent(c)
+		}
+	}
+	return nil, fmt.Errorf("%w: no VEVENT component", ErrInvalidCalendar)
+}
+
+// decodeEvent converts the VEVENT component into the event.
+func decodeEvent(c *component) (*Event, error) {
+	uid := c.value("UID")
+	if uid == "" {
+		return nil, fmt.Errorf("%w: no UID property", ErrInvalidCalendar)
+	}
+
+	dtStart := c.prop("DTSTART")
+	if dtStart == nil {
+		return nil, fmt.Errorf("%w: no DTSTART property", ErrInvalidCalendar)
+	}
+	start, allDay, err := parseTime(dtStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var duration time.Duration
+	if dtEnd := c.prop("DTEND"); dtEnd != nil {
+		end, _, err := parseTime(dtEnd)
+		if err != nil {
+			return nil, err
+		}
+		duration = end.Sub(start)
+	} else if durationValue := c.value("DURATION"); durationValue != "" {
+		if duration, err = parseDuration(durationValue); err != nil {
+			return nil, err
+		}
+	} else if allDay {
+		duration = types.Day
+	}
+
+	ev := &Event{
+		UID: uid,
+		Data: types.EventData{
+			Title:       unescapeText(c.value("SUMMARY")),
+			Datetime:    start,
+			Duration:    duration,
+			Description: unescapeText(c.value("DESCRIPTION")),
+			Location:    unescapeText(c.value("LOCATION")),
+			URL:         c.value("URL"),
+			AllDay:      allDay,
+			BusyStatus:  decodeBusyStatus(c),
+		},
+	}
+	for _, p := range c.props {
+		if p.name == "CATEGORIES" {
+			ev.Data.Tags = append(ev.Data.Tags, splitText(p.value)...)
+		}
+	}
+	if color := c.value("COLOR"); reColor.MatchString(color) {
+		ev.Data.Color = color
+	}
+	if ev.Data.RemindIn, err = decodeReminder(c, start, duration); err != nil {
+		return nil, err
+	}
+
+	return ev, nil
+}
+
+// decodeBusyStatus converts the transparency and status properties into the busy status.
+func decodeBusyStatus(c *component) types.BusyStatus {
+	switch strings.ToUpper(c.value("X-MICROSOFT-CDO-BUSYSTATUS")) {
+	case "OOF":
+		return types.BusyStatusOutOfOffice
+	case "TENTATIVE":
+		return types.BusyStatusTentative
+	case "FREE":
+		return types.BusyStatusFree
+	}
+	if strings.EqualFold(c.value("STATUS"), "TENTATIVE") {
+		return types.BusyStatusTentative
+	}
+	if strings.EqualFold(c.value("TRANSP"), "TRANSPARENT") {
+		return types.BusyStatusFree
+	}
+	return types.BusyStatusBusy
+}
+
+// decodeReminder returns the time before the start of the first alarm, which triggers before the start.
+// Returns 0 if there is no such alarm.
+func decodeReminder(c *component, start time.Time, duration time.Duration) (time.Duration, error) {
+	for _, alarm := range c.components {
+		if alarm.name != "VALARM" {
+			continue
+		}
+		trigger := alarm.prop("TRIGGER")
+		if trigger == nil {
+			continue
+		}
+
+		var at time.Time
+		if strings.EqualFold(trigger.param("VALUE"), "DATE-TIME") {
+			t, _, err := parseTime(trigger)
+			if err != nil {
+				return 0, err
+			}
+			at = t
+		} else {
+			offset, err := parseDuration(trigger.value)
+			if err != nil {
+				return 0, err
+			}
+			at = start.Add(offset)
+			if strings.EqualFold(trigger.param("RELATED"), "END") {
+				at = at.Add(duration)
+			}
+		}
+
+		if remindIn := start.Sub(at); remindIn > 0 {
+			return remindIn, nil
+		}
+	}
+	return 0, nil
+}
+
+// parse unfolds the content lines and builds the component tree. Returns the VCALENDAR component.
+func parse(data string) (*component, error) {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.NewReplacer("\n ", "", "\n\t", "").Replace(data)
+
+	var root *component
+	stack := make([]*component, 0)
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidCalendar, i+1, err)
+		}
+
+		switch p.name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(p.value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.components = append(parent.components, c)
+			} else if c.name != "VCALENDAR" || root != nil {
+				return nil, fmt.Errorf("%w: unexpected %s component", ErrInvalidCalendar, c.name)
+			} else {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(p.value) {
+				return nil, fmt.Errorf("%w: unexpected end of %s component", ErrInvalidCalendar, p.value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: property %s outside of the component", ErrInvalidCalendar, p.name)
+			}
+			c := stack[len(stack)-1]
+			c.props = append(c.props, p)
+		}
+	}
+
+	if root == nil || len(stack) > 0 {
+		return nil, fmt.Errorf("%w: incomplete VCALENDAR component", ErrInvalidCalendar)
+	}
+	return root, nil
+}
+
+// parseLine parses the unfolded content line: name *(";" param) ":" value.
+// Parameter values might be quoted, so the delimiters are searched outside the quotes only.
+func parseLine(line string) (*property, error) {
+	p := &property{params: make(map[string]string)}
+
+	inQuotes := false
+	fields := make([]string, 0, 2)
+	begin := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';', ':':
+			if inQuotes {
+				continue
+			}
+			fields = append(fields, line[begin:i])
+			begin = i + 1
+			if line[i] == ':' {
+				p.value = line[begin:]
+				return p.fill(fields)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no value delimiter in %q", line)
+}
+
+// fill sets the name and the parameters of the property from the fields preceding the value.
+func (p *property) fill(fields []string) (*property, error) {
+	p.name = strings.ToUpper(fields[0])
+	if p.name == "" {
+		return nil, fmt.Errorf("empty property name")
+	}
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid parameter %q of %s", field, p.name)
+		}
+		p.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return p, nil
+}
+
+// parseTime parses DATE or DATE-TIME value of the property. Returns true for DATE values.
+func parseTime(p *property) (time.Time, bool, error) {
+	value := p.value
+	if strings.EqualFold(p.param("VALUE"), "DATE") || len(value) == len(dateFormat) {
+		t, err := time.Parse(dateFormat, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: invalid %s date: %w", ErrInvalidCalendar, p.name, err)
+		}
+		return t, true, nil
+	}
+
+	loc := time.UTC
+	if utcValue, ok := strings.CutSuffix(value, "Z"); ok {
+		value = utcValue
+	} else if tzid := strings.TrimPrefix(p.param("TZID"), "/"); tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeFormat, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: invalid %s date-time: %w", ErrInvalidCalendar, p.name, err)
+	}
+	return t, false, nil
+}
+
+// parseDuration parses DURATION value.
+func parseDuration(value string) (time.Duration, error) {
+	m := reDuration.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("%w: invalid duration %q", ErrInvalidCalendar, value)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * types.Day, types.Day, time.Hour, time.Minute, time.Second} {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid duration %q", ErrInvalidCalendar, value)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// unescapeText unescapes the TEXT value.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// splitText splits the list of TEXT values by the unescaped commas and unescapes them.
+func splitText(s string) []string {
+	res := make([]string, 0)
+	begin := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			res = append(res, unescapeText(s[begin:i]))
+			begin = i + 1
+		}
+	}
+	return append(res, unescapeText(s[begin:]))
+}
@@ -0,0 +1,159 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+)
+
+// maxLineLength is the maximum length of the content line in octets, excluding the line break.
+const maxLineLength = 75
+
+// Encode writes the events as a single VCALENDAR object.
+//
+// stamp is used as DTSTAMP of all events. Event ID is used as UID.
+// All-day events are encoded with DATE values, other events - with UTC DATE-TIME values.
+func Encode(w io.Writer, stamp time.Time, events ...*types.Event) error {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN", "VCALENDAR")
+	writeLine(&buf, "VERSION", "2.0")
+	writeLine(&buf, "PRODID", prodID)
+	writeLine(&buf, "CALSCALE", "GREGORIAN")
+	for _, event := range events {
+		if event != nil {
+			encodeEvent(&buf, stamp, event)
+		}
+	}
+	writeLine(&buf, "END", "VCALENDAR")
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write iCalendar object: %w", err)
+	}
+	return nil
+}
+
+// encodeEvent writes the event as VEVENT component.
+func encodeEvent(buf *bytes.Buffer, stamp time.Time, event *types.Event) {
+	writeLine(buf, "BEGIN", "VEVENT")
+	writeLine(buf, "UID", event.ID.String())
+	writeLine(buf, "DTSTAMP", formatDateTime(stamp))
+	if event.AllDay {
+		writeLine(buf, "DTSTART;VALUE=DATE", event.Datetime.UTC().Format(dateFormat))
+		writeLine(buf, "DTEND;VALUE=DATE", event.Datetime.Add(event.Duration).UTC().Format(dateFormat))
+	} else {
+		writeLine(buf, "DTSTART", formatDateTime(event.Datetime))
+		writeLine(buf, "DTEND", formatDateTime(event.Datetime.Add(event.Duration)))
+	}
+	writeLine(buf, "SUMMARY", escapeText(event.Title))
+	if event.Description != "" {
+		writeLine(buf, "DESCRIPTION", escapeText(event.Description))
+	}
+	if event.Location != "" {
+		writeLine(buf, "LOCATION", escapeText(event.Location))
+	}
+	if event.URL != "" {
+		writeLine(buf, "URL", event.URL)
+	}
+	if len(event.Tags) > 0 {
+		tags := make([]string, len(event.Tags))
+		for i, tag := range event.Tags {
+			tags[i] = escapeText(tag)
+		}
+		writeLine(buf, "CATEGORIES", strings.Join(tags, ","))
+	}
+	if event.Color != "" {
+		writeLine(buf, "COLOR", event.Color)
+	}
+
+	switch event.BusyStatus {
+	case types.BusyStatusFree:
+		writeLine(buf, "TRANSP", "TRANSPARENT")
+	case types.BusyStatusTentative:
+		writeLine(buf, "TRANSP", "OPAQUE")
+		writeLine(buf, "STATUS", "TENTATIVE")
+	case types.BusyStatusOutOfOffice:
+		writeLine(buf, "TRANSP", "OPAQUE")
+		writeLine(buf, "X-MICROSOFT-CDO-BUSYSTATUS", "OOF")
+	default:
+		writeLine(buf, "TRANSP", "OPAQUE")
+	}
+
+	if event.RemindIn > 0 {
+		writeLine(buf, "BEGIN", "VALARM")
+		writeLine(buf, "ACTION", "DISPLAY")
+		writeLine(buf, "DESCRIPTION", escapeText(event.Title))
+		writeLine(buf, "TRIGGER", formatDuration(-event.RemindIn))
+		writeLine(buf, "END", "VALARM")
+	}
+	writeLine(buf, "END", "VEVENT")
+}
+
+// writeLine writes the content line, folding it by maxLineLength octets without splitting UTF-8 characters.
+func writeLine(buf *bytes.Buffer, name, value string) {
+	line := name + ":" + value
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1 // The leading space of the continuation line is counted.
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// escapeText escapes the TEXT value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// formatDateTime formats the time as UTC DATE-TIME value.
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat) + "Z"
+}
+
+// formatDuration formats the duration as DURATION value with the seconds precision.
+func formatDuration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteByte('P')
+
+	d = d.Truncate(time.Second)
+	days := d / types.Day
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d -= days * types.Day
+	}
+	if d == 0 {
+		if days == 0 {
+			b.WriteString("T0S")
+		}
+		return b.String()
+	}
+
+	b.WriteByte('T')
+	if hours := d / time.Hour; hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		fmt.Fprintf(&b, "%dS", d/time.Second)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Package ical provides encoding and decoding of the calendar events in iCalendar format (RFC 5545).
+//
+// Only the VEVENT properties, which have a counterpart in the event data, are supported:
+// UID, DTSTART, DTEND or DURATION, SUMMARY, DESCRIPTION, LOCATION, URL, CATEGORIES, COLOR (RFC 7986),
+// TRANSP, STATUS, X-MICROSOFT-CDO-BUSYSTATUS and the first display VALARM as the reminder.
+// Other properties and components, including recurrence rules, are ignored on decoding.
+package ical
+
+import (
+	"errors"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+)
+
+// ContentType is the MIME type of the iCalendar objects.
+const ContentType = "text/calendar; charset=utf-8"
+
+// prodID identifies the product, which created the iCalendar object.
+const prodID = "-//Averlex//Calendar//EN"
+
+// Date and date-time value formats.
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+)
+
+// ErrInvalidCalendar is returned if the iCalendar object is malformed or contains no events.
+var ErrInvalidCalendar = errors.New("invalid iCalendar object")
+
+// Event is a decoded VEVENT component.
+type Event struct {
+	// UID is the unique identifier of the event set by the client.
+	UID string
+	// Data is the event data. UserID, IsNotified and OverlapPolicy are never set.
+	Data types.EventData
+}
+
+// property is a single content line of the iCalendar object.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// param returns the parameter value by its case-insensitive name.
+func (p *property) param(name string) string {
+	return p.params[name]
+}
+
+// component is a parsed iCalendar component with its properties and subcomponents.
+type component struct {
+	name       string
+	props      []*property
+	components []*component
+}
+
+// prop returns the first property with the given name or nil.
+func (c *component) prop(name string) *property {
+	for _, p := range c.props {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// value returns the value of the first property with the given name or an empty string.
+func (c *component) value(name string) string {
+	if p := c.prop(name); p != nil {
+		return p.value
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                   //nolint:depguard,nolintlint
+)
+
+// TestRoundTrip tests that the encoded events are decoded back unchanged.
+func TestRoundTrip(t *testing.T) {
+	stamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name string
+		data types.EventData
+	}{
+		{
+			name: "minimal",
+			data: types.EventData{
+				Title:      "Meeting",
+				Datetime:   time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC),
+				Duration:   time.Hour,
+				BusyStatus: types.BusyStatusBusy,
+			},
+		},
+		{
+			name: "all fields",
+			data: types.EventData{
+				Title:       "Planning; sprint, 42",
+				Datetime:    time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC),
+				Duration:    90 * time.Minute,
+				Description: "Line 1\nLine 2 with \\ backslash",
+				Location:    "Room 1",
+				URL:         "https://example.com/meeting",
+				Tags:        []string{"work", "a,b"},
+				Color:       "#AABBCC",
+				RemindIn:    24*time.Hour + 15*time.Minute,
+				BusyStatus:  types.BusyStatusTentative,
+			},
+		},
+		{
+			name: "all-day out of office",
+			data: types.EventData{
+				Title:      "Vacation",
+				Datetime:   time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+				Duration:   3 * types.Day,
+				AllDay:     true,
+				BusyStatus: types.BusyStatusOutOfOffice,
+			},
+		},
+		{
+			name: "free with long unicode title",
+			data: types.EventData{
+				Title:      strings.Repeat("Встреча ", 30),
+				Datetime:   time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC),
+				Duration:   time.Hour,
+				BusyStatus: types.BusyStatusFree,
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			event := &types.Event{ID: uuid.New(), EventData: tC.data}
+			var buf bytes.Buffer
+			require.NoError(t, Encode(&buf, stamp, event))
+
+			for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+				require.LessOrEqual(t, len(line), maxLineLength, "line is not folded: %q", line)
+			}
+
+			decoded, err := Decode(&buf)
+			require.NoError(t, err)
+			require.Equal(t, event.ID.String(), decoded.UID)
+			require.True(t, tC.data.Datetime.Equal(decoded.Data.Datetime), "unexpected datetime: %v", decoded.Data.Datetime)
+			decoded.Data.Datetime = tC.data.Datetime
+			require.Equal(t, tC.data, decoded.Data)
+		})
+	}
+}
+
+// TestDecode tests decoding of the objects created by the other clients.
+func TestDecode(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		object   string
+		expected types.EventData
+		err      bool
+	}{
+		{
+			name: "tzid with duration and folded line",
+			object: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:1\r\n" +
+				"DTSTART;TZID=\"Europe/Moscow\":20250110T100000\r\nDURATION:PT30M\r\n" +
+				"SUMMARY:Long\r\n  title\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			expected: types.EventData{
+				Title:      "Long title",
+				Datetime:   time.Date(2025, 1, 10, 10, 0, 0, 0, moscow),
+				Duration:   30 * time.Minute,
+				BusyStatus: types.BusyStatusBusy,
+			},
+		},
+		{
+			name: "all-day without end and alarm related to end",
+			object: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:2\nDTSTART;VALUE=DATE:20250110\nSUMMARY:Day off\n" +
+				"TRANSP:TRANSPARENT\nBEGIN:VALARM\nACTION:DISPLAY\nTRIGGER;RELATED=END:-P1DT1H\nEND:VALARM\n" +
+				"END:VEVENT\nEND:VCALENDAR\n",
+			expected: types.EventData{
+				Title:      "Day off",
+				Datetime:   time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+				Duration:   types.Day,
+				AllDay:     true,
+				RemindIn:   time.Hour,
+				BusyStatus: types.BusyStatusFree,
+			},
+		},
+		{
+			name: "override is skipped and named color is ignored",
+			object: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:3\nRECURRENCE-ID:20250111T100000Z\n" +
+				"DTSTART:20250111T100000Z\nSUMMARY:Override\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nUID:3\nDTSTART:20250110T100000Z\nDTEND:20250110T110000Z\nSUMMARY:Master\n" +
+				"COLOR:red\nRRULE:FREQ=DAILY\nEND:VEVENT\nEND:VCALENDAR\n",
+			expected: types.EventData{
+				Title:      "Master",
+				Datetime:   time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC),
+				Duration:   time.Hour,
+				BusyStatus: types.BusyStatusBusy,
+			},
+		},
+		{
+			name:   "no events",
+			object: "BEGIN:VCALENDAR\nVERSION:2.0\nEND:VCALENDAR\n",
+			err:    true,
+		},
+		{
+			name:   "unterminated calendar",
+			object: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:4\nDTSTART:20250110T100000Z\n",
+			err:    true,
+		},
+		{
+			name:   "no start",
+			object: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:5\nEND:VEVENT\nEND:VCALENDAR\n",
+			err:    true,
+		},
+		{
+			name:   "invalid duration",
+			object: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:6\nDTSTART:20250110T100000Z\nDURATION:P1H\nEND:VEVENT\nEND:VCALENDAR\n",
+			err:    true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			decoded, err := Decode(strings.NewReader(tC.object))
+			if tC.err {
+				require.ErrorIs(t, err, ErrInvalidCalendar)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tC.expected.Datetime.Equal(decoded.Data.Datetime), "unexpected datetime: %v", decoded.Data.Datetime)
+			decoded.Data.Datetime = tC.expected.Datetime
+			require.Equal(t, tC.expected, decoded.Data)
+		})
+	}
+}
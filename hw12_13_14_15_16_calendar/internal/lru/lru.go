@@ -0,0 +1,143 @@
+// Package lru provides a generic LRU cache with the entries expiration.
+//
+// The cache follows the hw04_lru_cache design: a doubly-linked list keeping the items in the order of use
+// and a map from keys to the list items. It is generalized to typed keys and values and items, which live
+// longer than the TTL, are treated as missing.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache is an interface for an LRU cache.
+type Cache[K comparable, V any] interface {
+	// Set adds or updates the value of the key. Returns true if the key was already present in the cache.
+	Set(key K, value V) bool
+	// Get returns the value of the key if it is present and not expired.
+	Get(key K) (V, bool)
+	// Remove removes the key from the cache. Returns true if the key was present in the cache.
+	Remove(key K) bool
+	// Clear removes all stored items from the cache.
+	Clear()
+	// Len returns the number of stored items, including the expired ones, which were not accessed yet.
+	Len() int
+}
+
+type lruCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration // 0 means the items do not expire.
+	queue    *list.List
+	items    map[K]*list.Element
+	now      func() time.Time
+}
+
+type cacheListItem[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// NewCache returns a new Cache with the given capacity and TTL of the items. If the capacity is less than 1,
+// it returns nil. TTL <= 0 means the items do not expire and are removed only to sustain the capacity.
+func NewCache[K comparable, V any](capacity int, ttl time.Duration) Cache[K, V] {
+	if capacity < 1 {
+		return nil
+	}
+
+	return &lruCache[K, V]{
+		capacity: capacity,
+		ttl:      max(0, ttl),
+		queue:    list.New(),
+		items:    make(map[K]*list.Element, capacity),
+		now:      time.Now,
+	}
+}
+
+// Set adds a key-value pair to the cache. If the key already exists, it updates the value, resets its TTL
+// and moves the item to the front of the queue. If the cache exceeds its capacity, it removes
+// the least recently used item. Returns true if the key was already present in the cache, false otherwise.
+func (c *lruCache[K, V]) Set(key K, value V) bool {
+	listItem := &cacheListItem[K, V]{key: key, value: value}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl > 0 {
+		listItem.expiresAt = c.now().Add(c.ttl)
+	}
+
+	// The element is present in the cache -> updating it's value, moving it to the front.
+	if v, ok := c.items[key]; ok {
+		v.Value = listItem
+		c.queue.MoveToFront(v)
+		return true
+	}
+
+	c.items[key] = c.queue.PushFront(listItem)
+
+	// Removing the oldest cache item to sustain the capacity.
+	if c.queue.Len() > c.capacity {
+		c.removeElement(c.queue.Back())
+	}
+
+	return false
+}
+
+// Get returns a value for a key if it exists in the cache and is not expired, also moves the accessed item
+// to the front of the queue. Expired items are removed. Otherwise, returns zero value and false.
+func (c *lruCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	v, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	listItem := v.Value.(*cacheListItem[K, V])
+	if c.ttl > 0 && !c.now().Before(listItem.expiresAt) {
+		c.removeElement(v)
+		return zero, false
+	}
+
+	c.queue.MoveToFront(v)
+	return listItem.value, true
+}
+
+// Remove removes the key from the cache. Returns true if the key was present in the cache, false otherwise.
+func (c *lruCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.items[key]
+	if ok {
+		c.removeElement(v)
+	}
+	return ok
+}
+
+// Clear removes all stored items from the cache.
+func (c *lruCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queue.Init()
+	c.items = make(map[K]*list.Element, c.capacity)
+}
+
+// Len returns the number of stored items. Expired items are counted until they are accessed or evicted.
+func (c *lruCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.queue.Len()
+}
+
+// removeElement removes the list element and its key. Requires the lock to be held.
+func (c *lruCache[K, V]) removeElement(e *list.Element) {
+	delete(c.items, e.Value.(*cacheListItem[K, V]).key)
+	c.queue.Remove(e)
+}
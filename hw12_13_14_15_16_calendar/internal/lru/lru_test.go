@@ -0,0 +1,128 @@
+package lru
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+func TestCache(t *testing.T) {
+	t.Run("incorrect capacity", func(t *testing.T) {
+		require.Nil(t, NewCache[string, int](0, time.Minute))
+		require.Nil(t, NewCache[string, int](-1, time.Minute))
+	})
+
+	t.Run("simple", func(t *testing.T) {
+		c := NewCache[string, int](5, 0)
+
+		_, ok := c.Get("aaa")
+		require.False(t, ok)
+
+		require.False(t, c.Set("aaa", 100))
+		require.False(t, c.Set("bbb", 200))
+		require.True(t, c.Set("aaa", 300))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 300, val)
+		val, ok = c.Get("bbb")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+		require.Equal(t, 2, c.Len())
+	})
+
+	t.Run("remove and clear", func(t *testing.T) {
+		c := NewCache[string, int](3, 0)
+		c.Set("key1", 100)
+		c.Set("key2", 200)
+		c.Set("key3", 300)
+
+		require.True(t, c.Remove("key2"))
+		require.False(t, c.Remove("key2"))
+		_, ok := c.Get("key2")
+		require.False(t, ok)
+		require.Equal(t, 2, c.Len())
+
+		c.Clear()
+		require.Equal(t, 0, c.Len())
+		_, ok = c.Get("key1")
+		require.False(t, ok)
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		c := NewCache[int, string](3, 0)
+		c.Set(1, "1")
+		c.Set(2, "2")
+		c.Set(3, "3")
+
+		// Using the oldest item, so the second one becomes the least recently used.
+		_, ok := c.Get(1)
+		require.True(t, ok)
+		c.Set(4, "4")
+
+		_, ok = c.Get(2)
+		require.False(t, ok, "least recently used item is not evicted")
+		for _, key := range []int{1, 3, 4} {
+			_, ok = c.Get(key)
+			require.True(t, ok, "item %d is evicted", key)
+		}
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		c := NewCache[string, int](3, time.Minute)
+		c.(*lruCache[string, int]).now = func() time.Time { return now }
+
+		c.Set("aaa", 100)
+		now = now.Add(30 * time.Second)
+		c.Set("bbb", 200)
+
+		now = now.Add(30 * time.Second)
+		_, ok := c.Get("aaa")
+		require.False(t, ok, "expired item is returned")
+		require.Equal(t, 1, c.Len(), "expired item is not removed on access")
+
+		val, ok := c.Get("bbb")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+
+		// Updating the value resets its TTL.
+		now = now.Add(20 * time.Second)
+		c.Set("bbb", 300)
+		now = now.Add(50 * time.Second)
+		val, ok = c.Get("bbb")
+		require.True(t, ok)
+		require.Equal(t, 300, val)
+	})
+}
+
+func TestCacheMultithreading(t *testing.T) {
+	c := NewCache[string, int](10, time.Minute)
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10_000; i++ {
+			c.Set(strconv.Itoa(i), i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10_000; i++ {
+			c.Get(strconv.Itoa(i % 100))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10_000; i++ {
+			c.Remove(strconv.Itoa(i % 50))
+		}
+	}()
+
+	wg.Wait()
+	require.LessOrEqual(t, c.Len(), 10)
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// legacyTimeFormat is the datetime format of the legacy JSON notifications. It carries no time zone,
+// so the datetime is treated as UTC.
+const legacyTimeFormat = "02.01.2006 15:04:05.000"
+
+// legacyNotification is the legacy JSON notification, produced before the schema was versioned.
+type legacyNotification struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	UserID   string `json:"user_id"` //nolint:tagliatelle
+	Datetime string `json:"datetime"`
+}
+
+// unmarshalLegacy decodes the legacy JSON notification. Legacy notifications were sent before the event start only.
+func unmarshalLegacy(data []byte) (*types.Notification, error) {
+	var msg legacyNotification
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrInvalidNotification, err)
+	}
+	datetime, err := time.Parse(legacyTimeFormat, msg.Datetime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: datetime: %w", projectErrors.ErrInvalidNotification, err)
+	}
+	return &types.Notification{
+		ID:           msg.ID,
+		Title:        msg.Title,
+		UserID:       msg.UserID,
+		Datetime:     datetime,
+		ReminderKind: types.ReminderBeforeStart,
+	}, nil
+}
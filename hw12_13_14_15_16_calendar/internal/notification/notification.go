@@ -0,0 +1,130 @@
+// Package notification provides encoding of the notifications sent via the message broker.
+//
+// Notifications are encoded as notification.v1.Notification protobuf messages with the schema version.
+// Daily digests are encoded as the same messages with the agenda of the day.
+// During the migration the decoder also accepts the legacy JSON notifications.
+package notification
+
+import (
+	"bytes"
+	"fmt"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/notification/v1"        //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/proto"                                                     //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/types/known/durationpb"                                    //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/types/known/timestamppb"                                   //nolint:depguard,nolintlint
+)
+
+const (
+	// SchemaVersion is the version of the notification schema produced by Marshal.
+	SchemaVersion uint32 = 1
+	// ContentType is the content type of the notifications produced by Marshal.
+	ContentType = "application/x-protobuf; proto=notification.v1.Notification"
+	// LegacyContentType is the content type of the legacy JSON notifications.
+	LegacyContentType = "application/json"
+)
+
+// Marshal encodes the notification with the current schema version.
+func Marshal(n *types.Notification) ([]byte, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%w: no notification passed", projectErrors.ErrInvalidNotification)
+	}
+	msg := toMessage(n)
+	msg.SchemaVersion = SchemaVersion
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrInvalidNotification, err)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes the notification of any supported schema version or the legacy JSON notification.
+// JSON is recognized by the leading brace, as encoded protobuf message never starts with it.
+//
+// Returns a wrapped ErrInvalidNotification if the data cannot be decoded
+// and a wrapped ErrUnsupportedSchema if the schema version is unknown.
+func Unmarshal(data []byte) (*types.Notification, error) {
+	if trimmed := bytes.TrimLeft(data, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '{' {
+		return unmarshalLegacy(trimmed)
+	}
+
+	var msg pb.Notification
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrInvalidNotification, err)
+	}
+	if msg.GetSchemaVersion() != SchemaVersion {
+		return nil, fmt.Errorf("%w: %d", projectErrors.ErrUnsupportedSchema, msg.GetSchemaVersion())
+	}
+	return fromMessage(&msg)
+}
+
+// toMessage converts the notification and its agenda to the protobuf message without the schema version.
+func toMessage(n *types.Notification) *pb.Notification {
+	msg := &pb.Notification{
+		Id:           n.ID,
+		Title:        n.Title,
+		UserId:       n.UserID,
+		Datetime:     timestamppb.New(n.Datetime),
+		Duration:     durationpb.New(n.Duration),
+		ReminderKind: fromReminderKind(n.ReminderKind),
+		TimeZone:     n.TimeZone,
+		Late:         n.Late,
+	}
+	for _, item := range n.Agenda {
+		if item != nil {
+			msg.Agenda = append(msg.Agenda, toMessage(item))
+		}
+	}
+	return msg
+}
+
+// fromMessage converts the protobuf message and its agenda to the notification.
+func fromMessage(msg *pb.Notification) (*types.Notification, error) {
+	if err := msg.GetDatetime().CheckValid(); err != nil {
+		return nil, fmt.Errorf("%w: datetime: %w", projectErrors.ErrInvalidNotification, err)
+	}
+
+	n := &types.Notification{
+		ID:           msg.GetId(),
+		Title:        msg.GetTitle(),
+		UserID:       msg.GetUserId(),
+		Datetime:     msg.GetDatetime().AsTime(),
+		Duration:     msg.GetDuration().AsDuration(),
+		ReminderKind: toReminderKind(msg.GetReminderKind()),
+		TimeZone:     msg.GetTimeZone(),
+		Late:         msg.GetLate(),
+	}
+	for _, item := range msg.GetAgenda() {
+		agendaItem, err := fromMessage(item)
+		if err != nil {
+			return nil, fmt.Errorf("agenda item %q: %w", item.GetId(), err)
+		}
+		n.Agenda = append(n.Agenda, agendaItem)
+	}
+	return n, nil
+}
+
+func fromReminderKind(kind types.ReminderKind) pb.ReminderKind {
+	switch kind {
+	case types.ReminderBeforeStart:
+		return pb.ReminderKind_REMINDER_KIND_BEFORE_START
+	case types.ReminderDailyDigest:
+		return pb.ReminderKind_REMINDER_KIND_DAILY_DIGEST
+	default:
+		return pb.ReminderKind_REMINDER_KIND_UNSPECIFIED
+	}
+}
+
+// toReminderKind converts the reminder kind. Unknown kinds are left empty.
+func toReminderKind(kind pb.ReminderKind) types.ReminderKind {
+	switch kind {
+	case pb.ReminderKind_REMINDER_KIND_BEFORE_START:
+		return types.ReminderBeforeStart
+	case pb.ReminderKind_REMINDER_KIND_DAILY_DIGEST:
+		return types.ReminderDailyDigest
+	default:
+		return ""
+	}
+}
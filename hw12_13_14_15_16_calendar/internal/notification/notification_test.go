@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/notification/v1"        //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/proto"                                                     //nolint:depguard,nolintlint
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	zone := time.FixedZone("UTC+3", 3*60*60)
+	n := &types.Notification{
+		ID:           "0b4a3d39-8b7f-4f0e-9a51-1e7b8a6f2c11",
+		Title:        "Meeting",
+		UserID:       "user123",
+		Datetime:     time.Date(2025, 1, 1, 12, 30, 0, 0, zone),
+		Duration:     90 * time.Minute,
+		ReminderKind: types.ReminderBeforeStart,
+		Late:         true,
+	}
+
+	data, err := Marshal(n)
+	require.NoError(t, err)
+
+	var msg pb.Notification
+	require.NoError(t, proto.Unmarshal(data, &msg))
+	require.Equal(t, SchemaVersion, msg.GetSchemaVersion())
+	require.Equal(t, pb.ReminderKind_REMINDER_KIND_BEFORE_START, msg.GetReminderKind())
+	require.True(t, msg.GetLate())
+
+	res, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.True(t, n.Datetime.Equal(res.Datetime), "datetime instant is kept")
+	res.Datetime = n.Datetime
+	require.Equal(t, n, res)
+
+	_, err = Marshal(nil)
+	require.ErrorIs(t, err, projectErrors.ErrInvalidNotification)
+}
+
+func TestMarshalUnmarshal_Digest(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	n := &types.Notification{
+		ID:           "digest",
+		UserID:       "user123",
+		Datetime:     day,
+		Duration:     24 * time.Hour,
+		ReminderKind: types.ReminderDailyDigest,
+		TimeZone:     "Europe/Moscow",
+		Agenda: []*types.Notification{
+			{ID: "1", Title: "Breakfast", UserID: "user123", Datetime: day.Add(8 * time.Hour), Duration: time.Hour},
+			{ID: "2", Title: "Meeting", UserID: "user123", Datetime: day.Add(12 * time.Hour), Duration: time.Hour},
+		},
+	}
+
+	data, err := Marshal(n)
+	require.NoError(t, err)
+
+	var msg pb.Notification
+	require.NoError(t, proto.Unmarshal(data, &msg))
+	require.Equal(t, pb.ReminderKind_REMINDER_KIND_DAILY_DIGEST, msg.GetReminderKind())
+	require.Len(t, msg.GetAgenda(), 2)
+
+	res, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, n, res)
+
+	msg.Agenda[1].Datetime = nil
+	data, err = proto.Marshal(&msg)
+	require.NoError(t, err)
+	_, err = Unmarshal(data)
+	require.ErrorIs(t, err, projectErrors.ErrInvalidNotification, "invalid agenda item")
+}
+
+func TestUnmarshal_Legacy(t *testing.T) {
+	data := []byte(` {"id":"0b4a3d39-8b7f-4f0e-9a51-1e7b8a6f2c11","title":"Meeting","user_id":"user123",` +
+		`"datetime":"01.01.2025 12:30:00.000"}`)
+
+	res, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, &types.Notification{
+		ID:           "0b4a3d39-8b7f-4f0e-9a51-1e7b8a6f2c11",
+		Title:        "Meeting",
+		UserID:       "user123",
+		Datetime:     time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC),
+		ReminderKind: types.ReminderBeforeStart,
+	}, res)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	unknownVersion, err := proto.Marshal(&pb.Notification{SchemaVersion: SchemaVersion + 1, Id: "id"})
+	require.NoError(t, err)
+	noVersion, err := proto.Marshal(&pb.Notification{Id: "id"})
+	require.NoError(t, err)
+	noDatetime, err := proto.Marshal(&pb.Notification{SchemaVersion: SchemaVersion, Id: "id"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{name: "unknown version", data: unknownVersion, expected: projectErrors.ErrUnsupportedSchema},
+		{name: "no version", data: noVersion, expected: projectErrors.ErrUnsupportedSchema},
+		{name: "no datetime", data: noDatetime, expected: projectErrors.ErrInvalidNotification},
+		{name: "garbage", data: []byte{0xff, 0xff, 0xff}, expected: projectErrors.ErrInvalidNotification},
+		{name: "invalid json", data: []byte(`{"id":`), expected: projectErrors.ErrInvalidNotification},
+		{
+			name:     "invalid legacy datetime",
+			data:     []byte(`{"id":"id","datetime":"2025-01-01T12:30:00Z"}`),
+			expected: projectErrors.ErrInvalidNotification,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			_, err := Unmarshal(tC.data)
+			require.ErrorIs(t, err, tC.expected)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies is a list of the networks of the proxies, whose forwarded client addresses are trusted.
+// Empty list trusts no proxies.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses the IP addresses and the CIDR networks of the trusted proxies.
+func ParseTrustedProxies(values []string) (TrustedProxies, error) {
+	res := make(TrustedProxies, 0, len(values))
+	for _, value := range values {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			res = append(res, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", value)
+		}
+		addr = addr.Unmap()
+		res = append(res, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return res, nil
+}
+
+// Contains reports whether the given IP address belongs to any of the trusted networks.
+func (p TrustedProxies) Contains(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address of the client, given the address of the peer and the X-Forwarded-For value.
+//
+// The forwarded addresses are honoured only if the peer is trusted. They are checked from the right one,
+// appended by the closest proxy, skipping the trusted proxies, so the addresses forged by the client are ignored.
+// The leftmost address is returned if all of them are trusted.
+func (p TrustedProxies) ClientIP(peerIP, forwardedFor string) string {
+	if forwardedFor == "" || !p.Contains(peerIP) {
+		return peerIP
+	}
+	addrs := strings.Split(forwardedFor, ",")
+	for i := len(addrs) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(addrs[i])
+		if _, err := netip.ParseAddr(addr); err != nil {
+			// The rest of the chain is not trusted, the peer is the last known address.
+			return peerIP
+		}
+		if i == 0 || !p.Contains(addr) {
+			return addr
+		}
+	}
+	return peerIP
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"127.0.0.1", "::1", "10.0.0.0/8"})
+	require.NoError(t, err, "expected nil, got error")
+	require.Len(t, proxies, 3)
+
+	_, err = ParseTrustedProxies([]string{"localhost"})
+	require.Error(t, err, "expected error, got nil")
+
+	proxies, err = ParseTrustedProxies(nil)
+	require.NoError(t, err, "expected nil, got error")
+	require.False(t, proxies.Contains("127.0.0.1"), "empty list trusts the proxy")
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"127.0.0.1", "10.0.0.0/8"})
+	require.NoError(t, err, "expected nil, got error")
+
+	testCases := []struct {
+		name         string
+		peerIP       string
+		forwardedFor string
+		expected     string
+	}{
+		{"no forwarded addresses", "127.0.0.1", "", "127.0.0.1"},
+		{"untrusted peer", "203.0.113.7", "198.51.100.1", "203.0.113.7"},
+		{"trusted peer", "127.0.0.1", "198.51.100.1", "198.51.100.1"},
+		{"forged addresses", "127.0.0.1", "192.0.2.1, 198.51.100.1", "198.51.100.1"},
+		{"trusted proxies are skipped", "127.0.0.1", "192.0.2.1, 198.51.100.1, 10.0.0.2", "198.51.100.1"},
+		{"all trusted", "127.0.0.1", "10.0.0.3, 10.0.0.2", "10.0.0.3"},
+		{"invalid address", "127.0.0.1", "unknown, 10.0.0.2", "127.0.0.1"},
+		{"mapped IPv4 peer", "::ffff:127.0.0.1", "198.51.100.1", "198.51.100.1"},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, proxies.ClientIP(tC.peerIP, tC.forwardedFor))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package ratelimit provides a token bucket rate limiter with a separate bucket per client key.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// cleanupInterval is the minimal interval between the removals of the idle buckets.
+const cleanupInterval = time.Minute
+
+// Limiter limits the rate of the requests per client key, e.g. user ID or IP address.
+//
+// Each key owns a bucket of burst tokens, refilled at the given rate. A request takes a single token.
+// Buckets refilled up to the burst are equivalent to the missing ones, so they are removed periodically.
+type Limiter struct {
+	mu          sync.Mutex
+	rate        float64 // Tokens per second.
+	burst       float64 // Bucket capacity.
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time // Time of the last refill.
+}
+
+// NewLimiter creates a new limiter allowing rate requests per second with bursts up to burst requests.
+// If burst is not positive, it is set to the rate, but not less than 1.
+//
+// Returns nil if rate is not positive, which means the rate is not limited. Nil limiter allows every request.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = max(1, int(math.Ceil(rate)))
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of each given key, e.g. of both the user and the IP address of the request.
+// Tokens are taken only if every bucket has one, so a rejected request does not consume the tokens.
+// Returns true on success, false and the time until the tokens are available otherwise.
+func (l *Limiter) Allow(keys ...string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanup(now)
+
+	buckets := make([]*bucket, len(keys))
+	var wait time.Duration
+	for i, key := range keys {
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &bucket{tokens: l.burst, last: now}
+			l.buckets[key] = b
+		}
+		b.refill(now, l.rate, l.burst)
+		if b.tokens < 1 {
+			wait = max(wait, time.Duration((1-b.tokens)/l.rate*float64(time.Second)))
+		}
+		buckets[i] = b
+	}
+	if wait > 0 {
+		return false, wait
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return true, 0
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time, rate, burst float64) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(burst, b.tokens+elapsed.Seconds()*rate)
+		b.last = now
+	}
+}
+
+// cleanup removes the buckets refilled up to the burst. It is performed not more often than cleanupInterval.
+func (l *Limiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < cleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+
+	for key, b := range l.buckets {
+		b.refill(now, l.rate, l.burst)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RetryAfterSeconds converts the wait time to the value of Retry-After header: whole seconds, at least 1.
+func RetryAfterSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
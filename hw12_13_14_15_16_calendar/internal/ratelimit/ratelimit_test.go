@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+// newTestLimiter creates a limiter with a manually controlled clock.
+func newTestLimiter(rate float64, burst int) (*Limiter, *time.Time) {
+	now := time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+	l := NewLimiter(rate, burst)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestNewLimiter(t *testing.T) {
+	require.Nil(t, NewLimiter(0, 10), "expected nil limiter for zero rate")
+	require.Nil(t, NewLimiter(-1, 10), "expected nil limiter for negative rate")
+
+	var nilLimiter *Limiter
+	ok, wait := nilLimiter.Allow("key")
+	require.True(t, ok, "nil limiter must allow every request")
+	require.Zero(t, wait, "unexpected wait time")
+
+	require.InDelta(t, 3.0, NewLimiter(2.5, 0).burst, 0, "unexpected default burst")
+	require.InDelta(t, 1.0, NewLimiter(0.1, 0).burst, 0, "unexpected default burst")
+}
+
+func TestAllow(t *testing.T) {
+	l, now := newTestLimiter(2, 3)
+
+	for i := range 3 {
+		ok, _ := l.Allow("user1")
+		require.True(t, ok, "request %d within burst is rejected", i+1)
+	}
+
+	ok, wait := l.Allow("user1")
+	require.False(t, ok, "request over burst is allowed")
+	require.Equal(t, 500*time.Millisecond, wait, "unexpected wait time")
+
+	// Buckets of the different keys are independent.
+	ok, _ = l.Allow("user2")
+	require.True(t, ok, "request of another key is rejected")
+
+	*now = now.Add(wait)
+	ok, _ = l.Allow("user1")
+	require.True(t, ok, "request after refill is rejected")
+	ok, _ = l.Allow("user1")
+	require.False(t, ok, "request over refilled tokens is allowed")
+
+	// Bucket is not refilled over the burst.
+	*now = now.Add(time.Hour)
+	for range 3 {
+		ok, _ = l.Allow("user1")
+		require.True(t, ok, "request within burst is rejected")
+	}
+	ok, _ = l.Allow("user1")
+	require.False(t, ok, "request over burst is allowed")
+}
+
+func TestAllow_MultipleKeys(t *testing.T) {
+	l, now := newTestLimiter(1, 2)
+
+	// Users behind the same IP address share its bucket.
+	for i := range 2 {
+		ok, _ := l.Allow("ip:10.0.0.1", "user:user1")
+		require.True(t, ok, "request %d within burst is rejected", i+1)
+	}
+	ok, wait := l.Allow("ip:10.0.0.1", "user:user2")
+	require.False(t, ok, "request over the IP burst is allowed")
+	require.Equal(t, time.Second, wait, "unexpected wait time")
+
+	// Rejected request does not take the token of the user.
+	ok, _ = l.Allow("ip:10.0.0.2", "user:user2")
+	require.True(t, ok, "request of another IP address is rejected")
+	ok, _ = l.Allow("ip:10.0.0.3", "user:user2")
+	require.True(t, ok, "token of the rejected request is taken")
+
+	// Changing the IP address does not bypass the user bucket.
+	ok, wait = l.Allow("ip:10.0.0.4", "user:user1")
+	require.False(t, ok, "request over the user burst is allowed")
+	require.Equal(t, time.Second, wait, "unexpected wait time")
+
+	*now = now.Add(wait)
+	ok, _ = l.Allow("ip:10.0.0.1", "user:user1")
+	require.True(t, ok, "request after refill is rejected")
+}
+
+func TestCleanup(t *testing.T) {
+	l, now := newTestLimiter(1, 2)
+
+	l.Allow("idle")
+	l.Allow("active")
+	l.Allow("active")
+
+	*now = now.Add(cleanupInterval)
+	l.Allow("active")
+	require.Len(t, l.buckets, 1, "idle bucket is not removed")
+	require.Contains(t, l.buckets, "active", "active bucket is removed")
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	require.Equal(t, 1, RetryAfterSeconds(0))
+	require.Equal(t, 1, RetryAfterSeconds(100*time.Millisecond))
+	require.Equal(t, 2, RetryAfterSeconds(1100*time.Millisecond))
+}
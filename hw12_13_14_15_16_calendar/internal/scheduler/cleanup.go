@@ -14,10 +14,13 @@ func (sch *Scheduler) StartCleanup(ctx context.Context) {
 	go func() {
 		defer sch.wg.Done()
 		for {
+			st, reloaded := sch.currentSettings()
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(sch.cleanupInterval):
+			case <-reloaded:
+				// The wait is restarted with the new interval.
+			case <-time.After(st.cleanupInterval):
 				sch.handleStorageCleanup(ctx)
 			}
 		}
@@ -8,4 +8,11 @@ var expectedFields = map[string]any{
 	"retry_timeout":    time.Duration(0),
 	"queue_interval":   time.Duration(0),
 	"cleanup_interval": time.Duration(0),
+	"digest_interval":  time.Duration(0),
+}
+
+// optionalFields is a map of optional configuration fields and their default values.
+var optionalFields = map[string]any{
+	"catch_up_policy": "",
+	"catch_up_grace":  time.Duration(0),
 }
@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/notification"         //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// digestTitle is the title of the daily digest notification.
+const digestTitle = "Daily agenda"
+
+// StartDigest starts the daily digest goroutine. Non-blocking. Requires call to Scheduler.Wait().
+//
+// Goroutine checks the digest subscriptions each digest interval and sends a single digest notification
+// with the events of the day to each user whose local digest time has come. The digest is sent once a day:
+// if the scheduler was down at the digest time or the digest was enabled after it, the digest is sent
+// on the next check of the same day.
+func (sch *Scheduler) StartDigest(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		for {
+			st, reloaded := sch.currentSettings()
+			select {
+			case <-ctx.Done():
+				return
+			case <-reloaded:
+				// The wait is restarted with the new interval.
+			case <-time.After(st.digestInterval):
+				sch.handleDigests(ctx)
+			}
+		}
+	}()
+}
+
+// handleDigests sends the digests which are due and marks them as sent for the user's local day.
+// Failed digests are not marked, so they are retried on the next check.
+func (sch *Scheduler) handleDigests(ctx context.Context) {
+	var subscriptions []*types.DigestSettings
+	err := sch.withRetries(ctx, "GetDigestSubscriptions", func() error {
+		localSubscriptions, localErr := sch.s.GetDigestSubscriptions(ctx)
+		if localErr != nil {
+			return localErr
+		}
+		subscriptions = localSubscriptions
+		return nil
+	})
+	if err != nil {
+		sch.l.Error(ctx, "get digest subscriptions", slog.Any("error", err))
+		return
+	}
+
+	now := time.Now()
+	sentCount := 0
+	for _, settings := range subscriptions {
+		if !settings.IsDue(now) {
+			continue
+		}
+		if err := sch.sendDigest(ctx, settings, now); err != nil {
+			sch.l.Error(ctx, "send daily digest", slog.String("user_id", settings.UserID), slog.Any("error", err))
+			continue
+		}
+		sentCount++
+	}
+
+	if sentCount == 0 {
+		sch.l.Debug(ctx, "no daily digests to send")
+		return
+	}
+	sch.l.Info(ctx, "sent daily digests", slog.Int("count", sentCount))
+}
+
+// sendDigest collects the events of the user's local day, sends the digest to the broker
+// and marks it as sent.
+func (sch *Scheduler) sendDigest(ctx context.Context, settings *types.DigestSettings, now time.Time) error {
+	local := now.In(settings.Location())
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	date := dayStart.Format(types.DigestDateLayout)
+
+	var events []*types.Event
+	err := sch.withRetries(ctx, "GetEventsForDay", func() error {
+		localEvents, localErr := sch.s.GetEventsForDay(ctx, dayStart, &settings.UserID, nil)
+		if localErr != nil && !errors.Is(localErr, projectErrors.ErrEventNotFound) {
+			return localErr
+		}
+		events = localEvents
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("get events for day: %w", err)
+	}
+
+	agenda, _ := convertEventsToNotifications(events)
+	digest := &types.Notification{
+		ID:           fmt.Sprintf("digest:%s:%s", settings.UserID, date),
+		Title:        digestTitle,
+		UserID:       settings.UserID,
+		Datetime:     dayStart,
+		Duration:     dayStart.AddDate(0, 0, 1).Sub(dayStart),
+		ReminderKind: types.ReminderDailyDigest,
+		TimeZone:     settings.TimeZone,
+		Agenda:       agenda,
+	}
+	messageData, err := notification.Marshal(digest)
+	if err != nil {
+		return fmt.Errorf("marshal digest: %w", err)
+	}
+	if err := sch.broker.ProduceWithContentType(ctx, messageData, notification.ContentType); err != nil {
+		return fmt.Errorf("produce digest: %w", err)
+	}
+
+	err = sch.withRetries(ctx, "UpdateDigestSent", func() error {
+		return sch.s.UpdateDigestSent(ctx, settings.UserID, date)
+	})
+	if err != nil {
+		// The digest might be sent again on the next check, which is preferred to losing it.
+		return fmt.Errorf("update digest sent date: %w", err)
+	}
+	sch.l.Debug(ctx, "daily digest sent",
+		slog.String("user_id", settings.UserID),
+		slog.String("date", date),
+		slog.Int("events", len(agenda)),
+	)
+	return nil
+}
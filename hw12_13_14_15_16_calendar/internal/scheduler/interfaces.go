@@ -17,10 +17,31 @@ type Storage interface {
 	// Returns a slice of events or an error if not found or the operation fails.
 	GetEventsForNotification(context.Context) ([]*types.Event, error)
 
+	// GetNextReminderTime retrieves the earliest reminder time among the events waiting for notification.
+	// Returns the reminder time or an error if not found or the operation fails.
+	GetNextReminderTime(context.Context) (time.Time, error)
+
+	// WatchChanges subscribes to the changes of events waiting for notification.
+	// Returns a channel, receiving a signal on each change, or an error if the subscription fails.
+	WatchChanges(context.Context) (<-chan struct{}, error)
+
 	// UpdateNotifiedEvents updates notified events in the storage.
 	// Returns the number of updated events or an error if the operation fails.
 	UpdateNotifiedEvents(context.Context, []uuid.UUID) (int64, error)
 
+	// GetEventsForDay retrieves events for a specific day, optionally filtered by user ID and filter.
+	// Returns a slice of events or an error if not found or the operation fails.
+	GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
+
+	// GetDigestSubscriptions retrieves the settings of all enabled daily digests.
+	// Returns a slice of settings, which is empty if there are no subscriptions, or an error if the operation fails.
+	GetDigestSubscriptions(context.Context) ([]*types.DigestSettings, error)
+
+	// UpdateDigestSent stores the local date of the last sent digest of the user.
+	// Returns an error if the settings are not found or the operation fails.
+	UpdateDigestSent(ctx context.Context, userID, date string) error
+
 	// DeleteOldEvents deletes old events from the storage.
 	// Returns the number of deleted events or an error if the operation fails.
 	DeleteOldEvents(context.Context, time.Time) (int64, error)
@@ -40,7 +61,13 @@ type Logger interface {
 
 // MessageBroker represents a universal message broker interface.
 type MessageBroker interface {
-	// Produce sends a message to the message broker.
+	// ProduceWithContentType sends a message with the given content type to the message broker.
 	// Returns an error if the operation fails.
-	Produce(context.Context, []byte) error
+	ProduceWithContentType(context.Context, []byte, string) error
+}
+
+// Notifier represents an interface of the webhook notifier of the sent reminders.
+type Notifier interface {
+	// NotifyReminder queues the delivery of the sent reminder. The method must not block.
+	NotifyReminder(context.Context, *types.Notification)
 }
@@ -2,12 +2,12 @@ package scheduler
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/notification"         //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
 	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
 )
@@ -15,6 +15,7 @@ import (
 type queueTransport struct {
 	Notifications []*types.Notification
 	IDs           []uuid.UUID
+	Done          chan struct{} // Closed by the producer once the batch is processed.
 }
 
 // StartProducer starts the producer goroutine. Non-blocking. Requires call to Scheduler.Wait().
@@ -41,6 +42,7 @@ func (sch *Scheduler) StartProducer(ctx context.Context) {
 
 				// Updating the events with the IDs that were successfully sent to the broker.
 				sch.handleStorageUpdate(ctx, successIDs, data)
+				close(data.Done)
 			}
 		}
 	}()
@@ -49,39 +51,74 @@ func (sch *Scheduler) StartProducer(ctx context.Context) {
 // runNotificationQueue starts notification queue in a separate goroutine. Non-blocking.
 // Requires a Scheduler.Wait() call to wait for the goroutine to finish.
 //
+// Instead of polling the storage with a fixed interval, the queue sleeps until the next reminder is due
+// and wakes up early when the storage signals about the events changes. Queue interval is used as an upper
+// bound for the sleep, so the storage is still polled if the changes subscription is unavailable.
+//
 // Returns a read-only channel for []Notification transport.
 func (sch *Scheduler) runNotificationQueue(ctx context.Context) <-chan *queueTransport {
 	ch := make(chan *queueTransport)
 
-	sch.mu.RLock()
-	queueInterval := sch.queueInterval
-	sch.mu.RUnlock()
+	changes := sch.watchChanges(ctx)
 
 	sch.wg.Add(1)
 
 	go func() {
 		defer close(ch)
-		sch.wg.Done()
+		defer sch.wg.Done()
+
+		var lastFetch time.Time
+		stalled := 0 // Number of consecutive fetches, after which the due reminders were left unsent.
 		for {
+			st, reloaded := sch.currentSettings()
+			retryDelay := retryBackoff(st.retryTimeout, st.queueInterval, stalled)
+			wait, isStalled := sch.nextWakeup(ctx, lastFetch, st.queueInterval, retryDelay)
+			sch.l.Debug(ctx, "notification queue is waiting", slog.Duration("wait", wait))
+
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(queueInterval):
-				events := sch.handleNotificationsGet(ctx)
-				if len(events) == 0 {
-					sch.l.Debug(ctx, "got no events for notification")
-					continue
-				}
-				notifications, ids := convertEventsToNotifications(events)
-				data := &queueTransport{
-					Notifications: notifications,
-					IDs:           ids,
-				}
-				select {
-				case <-ctx.Done():
-					return
-				case ch <- data:
+			case _, ok := <-changes:
+				if !ok {
+					// Subscription is over, falling back to polling.
+					changes = nil
 				}
+				continue
+			case <-reloaded:
+				// The wait is recalculated with the new intervals.
+				continue
+			case <-time.After(wait):
+			}
+
+			// Backing off while the due reminders are left unsent, e.g. when the broker is down.
+			if isStalled {
+				stalled++
+			} else {
+				stalled = 0
+			}
+			lastFetch = time.Now()
+			events := sch.handleNotificationsGet(ctx)
+			if len(events) == 0 {
+				sch.l.Debug(ctx, "got no events for notification")
+				continue
+			}
+			notifications, ids := convertEventsToNotifications(events)
+			data := &queueTransport{
+				Notifications: notifications,
+				IDs:           ids,
+				Done:          make(chan struct{}),
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- data:
+			}
+
+			// Waiting for the batch to be processed, otherwise the same events would be fetched again.
+			select {
+			case <-ctx.Done():
+				return
+			case <-data.Done:
 			}
 		}
 	}()
@@ -89,6 +126,56 @@ func (sch *Scheduler) runNotificationQueue(ctx context.Context) <-chan *queueTra
 	return ch
 }
 
+// watchChanges subscribes to the storage events changes.
+// Returns nil channel if the subscription failed, so the queue relies on polling only.
+func (sch *Scheduler) watchChanges(ctx context.Context) <-chan struct{} {
+	changes, err := sch.s.WatchChanges(ctx)
+	if err != nil {
+		sch.l.Warn(ctx, "unable to watch storage changes, falling back to polling", slog.Any("error", err))
+		return nil
+	}
+	return changes
+}
+
+// nextWakeup calculates the time to wait until the next reminder is due, limited by the queue interval.
+//
+// Reminders which were already due on the last fetch are not handled immediately but after the retry delay,
+// since they are most likely left after the failed sending. Returns the wait and whether such reminders exist.
+func (sch *Scheduler) nextWakeup(ctx context.Context, lastFetch time.Time,
+	queueInterval, retryDelay time.Duration,
+) (time.Duration, bool) {
+	var next time.Time
+	err := sch.withRetries(ctx, "GetNextReminderTime", func() error {
+		localNext, localErr := sch.s.GetNextReminderTime(ctx)
+		if localErr != nil {
+			return localErr
+		}
+		next = localNext
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, projectErrors.ErrEventNotFound) {
+			sch.l.Error(ctx, "get next reminder time", slog.Any("error", err))
+		}
+		return queueInterval, false
+	}
+
+	if !next.After(lastFetch) {
+		return min(retryDelay, queueInterval), true
+	}
+	return min(max(time.Until(next), 0), queueInterval), false
+}
+
+// retryBackoff returns the delay before fetching the reminders left unsent after the given number
+// of consecutive fetches: the retry timeout doubled on each fetch, limited by the queue interval.
+func retryBackoff(retryTimeout, queueInterval time.Duration, stalled int) time.Duration {
+	delay := retryTimeout
+	for i := 0; i < stalled && delay < queueInterval; i++ {
+		delay *= 2
+	}
+	return min(delay, queueInterval)
+}
+
 // handleNotificationsGet gets events from the storage for notification queue.
 func (sch *Scheduler) handleNotificationsGet(ctx context.Context) []*types.Event {
 	var events []*types.Event
@@ -111,25 +198,47 @@ func (sch *Scheduler) handleNotificationsGet(ctx context.Context) []*types.Event
 
 // handleNotificationsSending gets the events from the internal queue,
 // marshals them and sends notifications to the broker.
-// Returns a slice of IDs that were successfully sent to the broker.
+//
+// Reminders of the events, which have already started, are handled according to the catch-up policy:
+// they are either sent with the late marker or skipped. Skipped reminders are logged with WARN level.
+//
+// Returns a slice of IDs that were successfully sent to the broker or skipped.
 func (sch *Scheduler) handleNotificationsSending(ctx context.Context, data *queueTransport) []uuid.UUID {
+	st, _ := sch.currentSettings()
 	successIDs := make([]uuid.UUID, 0, len(data.Notifications))
-	for i, notification := range data.Notifications {
-		messageData, err := json.Marshal(notification)
+	for i, n := range data.Notifications {
+		send, late := st.catchUpPolicy.Decide(n.Datetime, time.Now(), st.catchUpGrace)
+		if !send {
+			sch.l.Warn(
+				ctx,
+				"late reminder skipped",
+				slog.String("id", n.ID),
+				slog.Time("start", n.Datetime),
+				slog.String("catch_up_policy", string(st.catchUpPolicy)),
+			)
+			successIDs = append(successIDs, data.IDs[i])
+			continue
+		}
+		n.Late = late
+
+		messageData, err := notification.Marshal(n)
 		if err != nil {
 			sch.l.Warn(ctx, "marshal notification", slog.Any("error", err))
 			continue
 		}
-		err = sch.broker.Produce(ctx, messageData)
+		err = sch.broker.ProduceWithContentType(ctx, messageData, notification.ContentType)
 		if err != nil {
 			sch.l.Error(
 				ctx,
 				"unexpected error on broker produce",
-				slog.String("id", notification.ID),
+				slog.String("id", n.ID),
 				slog.Any("error", err),
 			)
 			continue
 		}
+		if sch.n != nil {
+			sch.n.NotifyReminder(ctx, n)
+		}
 		successIDs = append(successIDs, data.IDs[i])
 	}
 	return successIDs
@@ -1,6 +1,6 @@
 // Package scheduler provides a calendar scheduler, which is responsible for
-// queuing the storage for events which need notifications and cleaning up
-// old events from the storage.
+// queuing the storage for events which need notifications, sending daily agenda digests
+// and cleaning up old events from the storage.
 package scheduler
 
 import (
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	pkgConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"          //nolint:depguard,nolintlint
 )
 
 // Scheduler is a calendar scheduler.
@@ -19,10 +21,25 @@ type Scheduler struct {
 	l               Logger
 	s               Storage
 	broker          MessageBroker
+	n               Notifier // Optional, nil means no reminder notifications.
 	retries         int
 	retryTimeout    time.Duration
 	queueInterval   time.Duration
 	cleanupInterval time.Duration
+	digestInterval  time.Duration
+	catchUpPolicy   types.CatchUpPolicy
+	catchUpGrace    time.Duration
+	reloaded        chan struct{} // Closed and replaced on each reload.
+}
+
+// Option defines a function that allows to configure optional Scheduler dependencies on construction.
+type Option func(sch *Scheduler)
+
+// WithNotifier sets the notifier of the sent reminders for the webhook subscriptions.
+func WithNotifier(n Notifier) Option {
+	return func(sch *Scheduler) {
+		sch.n = n
+	}
 }
 
 // NewScheduler creates a new calendar application after arguments validation.
@@ -33,6 +50,7 @@ func NewScheduler(
 	storage Storage,
 	messageBrocker MessageBroker,
 	config map[string]any,
+	opts ...Option,
 ) (*Scheduler, error) {
 	// Args validation.
 	missing := make([]string, 0)
@@ -53,46 +71,142 @@ func NewScheduler(
 			projectErrors.ErrAppInitFailed, missing)
 	}
 
-	// Field types validation.
-	missing, wrongType := validateFields(config, expectedFields)
-	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("%w: missing=%v invalid_type=%v",
-			projectErrors.ErrCorruptedConfig, missing, wrongType)
+	st, err := parseSettings(config)
+	if err != nil {
+		return nil, err
+	}
+
+	sch := &Scheduler{
+		l:               logger,
+		s:               storage,
+		broker:          messageBrocker,
+		retries:         st.retries,
+		retryTimeout:    st.retryTimeout,
+		queueInterval:   st.queueInterval,
+		cleanupInterval: st.cleanupInterval,
+		digestInterval:  st.digestInterval,
+		catchUpPolicy:   st.catchUpPolicy,
+		catchUpGrace:    st.catchUpGrace,
+		reloaded:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(sch)
 	}
 
+	return sch, nil
+}
+
+// settings are the scheduler settings, which might be changed at runtime.
+type settings struct {
+	retries         int
+	retryTimeout    time.Duration
+	queueInterval   time.Duration
+	cleanupInterval time.Duration
+	digestInterval  time.Duration
+	catchUpPolicy   types.CatchUpPolicy
+	catchUpGrace    time.Duration // Used by the grace catch-up policy only.
+}
+
+// ValidateConfig validates the scheduler config without creating a scheduler.
+// Problems are returned as *config.ValidationError wrapped with ErrCorruptedConfig.
+func ValidateConfig(config map[string]any) error {
+	_, err := parseSettings(config)
+	return err
+}
+
+// parseSettings validates and extracts the scheduler settings from the config, accumulating all problems.
+func parseSettings(config map[string]any) (*settings, error) {
+	// Field types validation.
+	ve := &pkgConfig.ValidationError{}
+	ve.Missing, ve.InvalidType = validateFields(config, expectedFields)
+	// Optional fields are zero valued if missing.
+	ve.InvalidType = append(ve.InvalidType, validateOptionalFields(config, optionalFields)...)
+
 	// Extract from config an normalize the value.
 	retries, _ := config["retries"].(int)
 	retries = max(0, retries)
-	retryTimeout, _ := config["retry_timeout"].(time.Duration)
-	queueInterval, _ := config["queue_interval"].(time.Duration)
-	cleanupInterval, _ := config["cleanup_interval"].(time.Duration)
 
-	// Validation.
-	invalidValues := make([]string, 0)
-	if retryTimeout <= 0 {
-		invalidValues = append(invalidValues, "retry_timeout")
+	retryTimeout, retryTimeoutOk := config["retry_timeout"].(time.Duration)
+	queueInterval, queueIntervalOk := config["queue_interval"].(time.Duration)
+	cleanupInterval, cleanupIntervalOk := config["cleanup_interval"].(time.Duration)
+	digestInterval, digestIntervalOk := config["digest_interval"].(time.Duration)
+	policyStr, _ := config["catch_up_policy"].(string)
+	catchUpGrace, _ := config["catch_up_grace"].(time.Duration)
+
+	// Validation. Missing and wrong type values are already reported.
+	if retryTimeoutOk && retryTimeout <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "retry_timeout")
+	}
+	if queueIntervalOk && queueInterval <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "queue_interval")
+	}
+	if cleanupIntervalOk && cleanupInterval <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "cleanup_interval")
+	}
+	if digestIntervalOk && digestInterval <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "digest_interval")
 	}
-	if queueInterval <= 0 {
-		invalidValues = append(invalidValues, "queue_interval")
+	catchUpPolicy, err := types.ParseCatchUpPolicy(policyStr)
+	if err != nil {
+		ve.InvalidValue = append(ve.InvalidValue, "catch_up_policy")
 	}
-	if cleanupInterval <= 0 {
-		invalidValues = append(invalidValues, "cleanup_interval")
+	if catchUpGrace < 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "catch_up_grace")
 	}
-	if len(invalidValues) > 0 {
-		return nil, fmt.Errorf("%w: invalid timeout values: %v", projectErrors.ErrCorruptedConfig, invalidValues)
+	if ve.HasErrors() {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
 	}
 
-	return &Scheduler{
-		l:               logger,
-		s:               storage,
-		broker:          messageBrocker,
+	return &settings{
 		retries:         retries,
 		retryTimeout:    retryTimeout,
 		queueInterval:   queueInterval,
 		cleanupInterval: cleanupInterval,
+		digestInterval:  digestInterval,
+		catchUpPolicy:   catchUpPolicy,
+		catchUpGrace:    catchUpGrace,
 	}, nil
 }
 
+// Reload applies the settings which might be changed at runtime: retries, retry_timeout, queue_interval,
+// cleanup_interval, digest_interval, catch_up_policy and catch_up_grace. The waiting notification queue,
+// cleanup and digest are woken up to use the new intervals.
+// Returns an error if the config is invalid, keeping the current settings.
+func (sch *Scheduler) Reload(config map[string]any) error {
+	st, err := parseSettings(config)
+	if err != nil {
+		return err
+	}
+
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	sch.retries = st.retries
+	sch.retryTimeout = st.retryTimeout
+	sch.queueInterval = st.queueInterval
+	sch.cleanupInterval = st.cleanupInterval
+	sch.digestInterval = st.digestInterval
+	sch.catchUpPolicy = st.catchUpPolicy
+	sch.catchUpGrace = st.catchUpGrace
+	close(sch.reloaded)
+	sch.reloaded = make(chan struct{})
+	return nil
+}
+
+// currentSettings returns the current settings and the channel, which is closed on the next reload.
+func (sch *Scheduler) currentSettings() (settings, <-chan struct{}) {
+	sch.mu.RLock()
+	defer sch.mu.RUnlock()
+	return settings{
+		retries:         sch.retries,
+		retryTimeout:    sch.retryTimeout,
+		queueInterval:   sch.queueInterval,
+		cleanupInterval: sch.cleanupInterval,
+		digestInterval:  sch.digestInterval,
+		catchUpPolicy:   sch.catchUpPolicy,
+		catchUpGrace:    sch.catchUpGrace,
+	}, sch.reloaded
+}
+
 // Wait waits for the scheduler goroutines to finish.
 func (sch *Scheduler) Wait(_ context.Context) {
 	sch.wg.Wait()
@@ -0,0 +1,232 @@
+//nolint:depguard,nolintlint
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/notification"   //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"          //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                         //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                            //nolint:depguard,nolintlint
+)
+
+// discardLogger is a logger, which drops all the messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+// fakeBroker records the produced messages or fails each produce with err.
+type fakeBroker struct {
+	mu       sync.Mutex
+	err      error
+	messages []*types.Notification
+}
+
+func (b *fakeBroker) ProduceWithContentType(_ context.Context, data []byte, _ string) error {
+	if b.err != nil {
+		return b.err
+	}
+	n, err := notification.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, n)
+	return nil
+}
+
+// newTestScheduler creates a scheduler with the memory storage and the given catch-up policy.
+func newTestScheduler(t *testing.T, broker MessageBroker, policy types.CatchUpPolicy) (*Scheduler, *memory.Storage) {
+	t.Helper()
+	storage, err := memory.NewStorage(0)
+	require.NoError(t, err, "expected nil, got error")
+	require.NoError(t, storage.Connect(context.Background()), "expected nil, got error")
+
+	sch, err := NewScheduler(discardLogger{}, storage, broker, map[string]any{
+		"retries":          0,
+		"retry_timeout":    10 * time.Millisecond,
+		"queue_interval":   time.Minute,
+		"cleanup_interval": time.Hour,
+		"digest_interval":  time.Hour,
+		"catch_up_policy":  string(policy),
+		"catch_up_grace":   time.Minute,
+	})
+	require.NoError(t, err, "expected nil, got error")
+	return sch, storage
+}
+
+// createEvent stores the event of the user starting at start with the given reminder.
+func createEvent(t *testing.T, storage *memory.Storage, userID string, start time.Time, remindIn time.Duration) {
+	t.Helper()
+	event, err := types.NewEvent("Meeting", start, time.Hour, "", userID, remindIn)
+	require.NoError(t, err, "expected nil, got error")
+	_, err = storage.CreateEvent(context.Background(), event)
+	require.NoError(t, err, "expected nil, got error")
+}
+
+func TestRetryBackoff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stalled  int
+		expected time.Duration
+	}{
+		{"first retry", 0, time.Second},
+		{"second retry", 1, 2 * time.Second},
+		{"third retry", 2, 4 * time.Second},
+		{"limited by queue interval", 4, 10 * time.Second},
+		{"long stall", 1000, 10 * time.Second},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, retryBackoff(time.Second, 10*time.Second, tC.stalled))
+		})
+	}
+}
+
+func TestNextWakeup(t *testing.T) {
+	ctx := context.Background()
+	queueInterval := time.Minute
+	retryDelay := time.Second
+
+	t.Run("no reminders", func(t *testing.T) {
+		sch, _ := newTestScheduler(t, &fakeBroker{}, types.CatchUpAlways)
+		wait, stalled := sch.nextWakeup(ctx, time.Time{}, queueInterval, retryDelay)
+		require.Equal(t, queueInterval, wait)
+		require.False(t, stalled)
+	})
+
+	t.Run("upcoming reminder", func(t *testing.T) {
+		sch, storage := newTestScheduler(t, &fakeBroker{}, types.CatchUpAlways)
+		createEvent(t, storage, "user1", time.Now().Add(time.Hour+30*time.Second), time.Hour)
+
+		wait, stalled := sch.nextWakeup(ctx, time.Now(), queueInterval, retryDelay)
+		require.False(t, stalled)
+		require.Greater(t, wait, 20*time.Second)
+		require.LessOrEqual(t, wait, 30*time.Second)
+	})
+
+	t.Run("distant reminder", func(t *testing.T) {
+		sch, storage := newTestScheduler(t, &fakeBroker{}, types.CatchUpAlways)
+		createEvent(t, storage, "user1", time.Now().Add(2*time.Hour), time.Hour)
+
+		wait, stalled := sch.nextWakeup(ctx, time.Now(), queueInterval, retryDelay)
+		require.False(t, stalled)
+		require.Equal(t, queueInterval, wait)
+	})
+
+	t.Run("reminder became due", func(t *testing.T) {
+		sch, storage := newTestScheduler(t, &fakeBroker{}, types.CatchUpAlways)
+		createEvent(t, storage, "user1", time.Now().Add(time.Hour), time.Hour+time.Second)
+
+		wait, stalled := sch.nextWakeup(ctx, time.Now().Add(-time.Minute), queueInterval, retryDelay)
+		require.False(t, stalled, "reminder due after the last fetch is handled immediately")
+		require.Zero(t, wait)
+	})
+
+	t.Run("reminder left unsent", func(t *testing.T) {
+		sch, storage := newTestScheduler(t, &fakeBroker{}, types.CatchUpAlways)
+		createEvent(t, storage, "user1", time.Now().Add(time.Hour), time.Hour+time.Second)
+
+		wait, stalled := sch.nextWakeup(ctx, time.Now(), queueInterval, retryDelay)
+		require.True(t, stalled)
+		require.Equal(t, retryDelay, wait)
+
+		wait, stalled = sch.nextWakeup(ctx, time.Now(), queueInterval, 2*queueInterval)
+		require.True(t, stalled)
+		require.Equal(t, queueInterval, wait, "retry delay is not limited by the queue interval")
+	})
+}
+
+func TestHandleNotificationsSending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	upcoming := &types.Notification{ID: uuid.NewString(), Title: "Upcoming", UserID: "user1",
+		Datetime: now.Add(time.Hour), Duration: time.Hour}
+	started := &types.Notification{ID: uuid.NewString(), Title: "Started", UserID: "user1",
+		Datetime: now.Add(-time.Hour), Duration: 2 * time.Hour}
+	// newTransport returns the fresh copies of the notifications, since the sending marks them as late.
+	newTransport := func() *queueTransport {
+		u, s := *upcoming, *started
+		return &queueTransport{
+			Notifications: []*types.Notification{&u, &s},
+			IDs:           []uuid.UUID{uuid.MustParse(u.ID), uuid.MustParse(s.ID)},
+		}
+	}
+
+	t.Run("always", func(t *testing.T) {
+		broker := &fakeBroker{}
+		sch, _ := newTestScheduler(t, broker, types.CatchUpAlways)
+		data := newTransport()
+
+		require.Equal(t, data.IDs, sch.handleNotificationsSending(ctx, data))
+		require.Len(t, broker.messages, 2)
+		require.False(t, broker.messages[0].Late)
+		require.True(t, broker.messages[1].Late, "started event is not marked as late")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		broker := &fakeBroker{}
+		sch, _ := newTestScheduler(t, broker, types.CatchUpSkip)
+		data := newTransport()
+
+		require.Equal(t, data.IDs, sch.handleNotificationsSending(ctx, data), "skipped reminder is not marked")
+		require.Len(t, broker.messages, 1)
+		require.Equal(t, upcoming.ID, broker.messages[0].ID)
+	})
+
+	t.Run("broker failure", func(t *testing.T) {
+		broker := &fakeBroker{err: errors.New("broker is down")}
+		sch, _ := newTestScheduler(t, broker, types.CatchUpSkip)
+		data := newTransport()
+
+		require.Equal(t, data.IDs[1:], sch.handleNotificationsSending(ctx, data),
+			"only the skipped reminder is expected")
+	})
+}
+
+func TestHandleDigests(t *testing.T) {
+	ctx := context.Background()
+	broker := &fakeBroker{}
+	sch, storage := newTestScheduler(t, broker, types.CatchUpAlways)
+
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	createEvent(t, storage, "user1", dayStart.Add(23*time.Hour), 0)
+	createEvent(t, storage, "user2", dayStart.Add(23*time.Hour), 0)
+
+	// Digest time of the day start is due at any moment of the day.
+	for userID, enabled := range map[string]bool{"user1": true, "user2": false} {
+		settings, err := types.NewDigestSettings(userID, enabled, "00:00", "UTC")
+		require.NoError(t, err, "expected nil, got error")
+		_, err = storage.SetDigestSettings(ctx, settings)
+		require.NoError(t, err, "expected nil, got error")
+	}
+
+	sch.handleDigests(ctx)
+	require.Len(t, broker.messages, 1)
+	digest := broker.messages[0]
+	require.Equal(t, types.ReminderDailyDigest, digest.ReminderKind)
+	require.Equal(t, "user1", digest.UserID)
+	require.True(t, digest.Datetime.Equal(dayStart))
+	require.Len(t, digest.Agenda, 1)
+
+	settings, err := storage.GetDigestSettings(ctx, "user1")
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, dayStart.Format(types.DigestDateLayout), settings.SentDate)
+
+	sch.handleDigests(ctx)
+	require.Len(t, broker.messages, 1, "digest is sent twice a day")
+
+	broker.err = errors.New("broker is down")
+	require.Error(t, sch.sendDigest(ctx, settings, now))
+}
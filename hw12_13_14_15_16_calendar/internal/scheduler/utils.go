@@ -17,5 +17,6 @@ func (sch *Scheduler) isUninitialized(err error) bool {
 
 func (sch *Scheduler) isBusiness(err error) bool {
 	return errors.Is(err, projectErrors.ErrEventNotFound) ||
-		errors.Is(err, projectErrors.ErrNoData)
+		errors.Is(err, projectErrors.ErrNoData) ||
+		errors.Is(err, projectErrors.ErrDigestSettingsNotFound)
 }
@@ -27,3 +27,17 @@ func validateFields(args map[string]any, requiredFields map[string]any) ([]strin
 
 	return missing, wrongType
 }
+
+// validateOptionalFields returns wrong type fields found in args.
+// optionalFields is a map of field names with their expected types. Missing fields are skipped.
+func validateOptionalFields(args map[string]any, optionalFields map[string]any) []string {
+	present := make(map[string]any, len(optionalFields))
+	for field, expectedVal := range optionalFields {
+		if _, exists := args[field]; exists {
+			present[field] = expectedVal
+		}
+	}
+
+	_, wrongType := validateFields(args, present)
+	return wrongType
+}
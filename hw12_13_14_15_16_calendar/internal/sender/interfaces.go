@@ -2,6 +2,8 @@ package sender
 
 import (
 	"context"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
 )
 
 // Logger represents an interface of logger visible to the app.
@@ -22,3 +24,10 @@ type MessageBroker interface {
 	// Returns data and error channels.
 	Consume(context.Context) (<-chan []byte, <-chan error)
 }
+
+// Renderer represents an interface of notification renderer visible to the sender.
+type Renderer interface {
+	// RenderAll renders the notification for all channels having the templates.
+	// Returns the rendered messages or an error if rendering fails.
+	RenderAll(ctx context.Context, n *types.Notification) ([]*types.Message, error)
+}
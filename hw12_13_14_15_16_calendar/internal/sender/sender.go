@@ -15,10 +15,11 @@ type Sender struct {
 	wg     sync.WaitGroup
 	l      Logger
 	broker MessageBroker
+	r      Renderer
 }
 
 // NewSender creates a new calendar sender after arguments validation.
-func NewSender(logger Logger, messageBrocker MessageBroker) (*Sender, error) {
+func NewSender(logger Logger, messageBrocker MessageBroker, renderer Renderer) (*Sender, error) {
 	// Args validation.
 	missing := make([]string, 0)
 	if logger == nil {
@@ -27,6 +28,9 @@ func NewSender(logger Logger, messageBrocker MessageBroker) (*Sender, error) {
 	if messageBrocker == nil {
 		missing = append(missing, "message_broker")
 	}
+	if renderer == nil {
+		missing = append(missing, "renderer")
+	}
 	if len(missing) > 0 {
 		return nil, fmt.Errorf("%w: some of the required parameters are missing: args=%v",
 			projectErrors.ErrAppInitFailed, missing)
@@ -35,6 +39,7 @@ func NewSender(logger Logger, messageBrocker MessageBroker) (*Sender, error) {
 	return &Sender{
 		l:      logger,
 		broker: messageBrocker,
+		r:      renderer,
 	}, nil
 }
 
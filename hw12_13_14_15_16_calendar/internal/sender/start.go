@@ -2,10 +2,9 @@ package sender
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 
-	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/notification" //nolint:depguard,nolintlint
 )
 
 // Start starts a goroutine which listens to the message queue and logs notifications.
@@ -38,27 +37,47 @@ func (s *Sender) Start(ctx context.Context) error {
 	return nil
 }
 
-// handleEventSending logs the notification.
-// If any error occurs on umnarshalling or data parsing, it will be logged with WARN level.
+// handleEventSending renders the notification for each channel and logs the messages.
+// Both the versioned and the legacy JSON notifications are accepted.
+// If any error occurs on decoding or rendering, it will be logged with WARN level.
 func (s *Sender) handleEventSending(ctx context.Context, data []byte) {
-	var message types.Notification
-	err := json.Unmarshal(data, &message)
+	message, err := notification.Unmarshal(data)
 	if err != nil {
 		s.l.Warn(ctx, "unmarshal notification", slog.Any("error", err))
 		return
 	}
-	datetime, err := message.GetDatetime()
+	attrs := slog.Group(
+		"notification",
+		slog.String("id", message.ID),
+		slog.String("title", message.Title),
+		slog.String("user_id", message.UserID),
+		slog.Time("datetime", message.Datetime),
+		slog.String("duration", message.Duration.String()),
+		slog.String("reminder_kind", string(message.ReminderKind)),
+		slog.Bool("late", message.Late),
+	)
+
+	msgs, err := s.r.RenderAll(ctx, message)
 	if err != nil {
-		s.l.Warn(ctx, "invalid datetime format", slog.String("datetime", message.Datetime), slog.Any("error", err))
+		s.l.Warn(ctx, "render notification", attrs, slog.Any("error", err))
+		return
+	}
+	if len(msgs) == 0 {
+		s.l.Info(ctx, "notification sent", attrs)
+		return
+	}
+	for _, msg := range msgs {
+		s.l.Info(
+			ctx, "notification sent",
+			attrs,
+			slog.Group(
+				"message",
+				slog.String("channel", msg.Channel),
+				slog.String("locale", msg.Locale),
+				slog.String("time_zone", msg.TimeZone),
+				slog.String("format", string(msg.Format)),
+				slog.String("body", msg.Body),
+			),
+		)
 	}
-	s.l.Info(
-		ctx, "notification sent",
-		slog.Group(
-			"notification",
-			slog.String("id", message.ID),
-			slog.String("title", message.Title),
-			slog.String("user_id", message.UserID),
-			slog.Time("datetime", datetime),
-		),
-	)
 }
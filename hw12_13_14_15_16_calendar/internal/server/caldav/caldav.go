@@ -0,0 +1,243 @@
+// Package caldav provides a subset of CalDAV (RFC 4791) for the native calendar clients.
+//
+// Each user has a single calendar with the following layout:
+//
+//	/dav/principals/<user>/                  - principal of the user
+//	/dav/calendars/<user>/                   - calendar home of the user
+//	/dav/calendars/<user>/events/            - the calendar
+//	/dav/calendars/<user>/events/<name>.ics  - an event
+//
+// Supported methods are OPTIONS, PROPFIND, PROPPATCH (read-only), REPORT (calendar-query,
+// calendar-multiget and sync-collection), GET, HEAD, PUT and DELETE. GET of the calendar returns
+// all its events as a single iCalendar object, which might be used for the read-only subscriptions.
+//
+// The user is identified by X-User-ID header, falling back to the basic auth username.
+// There is no authentication, so the password is not checked.
+package caldav
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ical"                 //nolint:depguard,nolintlint
+)
+
+const (
+	// Prefix is the root path of the CalDAV resources.
+	Prefix = "/dav"
+	// WellKnownPath is the service discovery path (RFC 6764), redirecting to Prefix.
+	WellKnownPath = "/.well-known/caldav"
+
+	userIDHeader  = "X-User-ID" // ID of the user, making the request.
+	calendarName  = "events"    // Name of the only calendar collection of the user.
+	objectExt     = ".ics"      // Extension of the event resources.
+	maxBodySize   = 1 << 20     // Maximum size of the request body in bytes.
+	davCapability = "1, 3, calendar-access"
+)
+
+// Methods are the HTTP methods served by the handler.
+var Methods = []string{
+	http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete,
+	"PROPFIND", "PROPPATCH", "REPORT", "MKCALENDAR",
+}
+
+// resourceKind is a kind of the CalDAV resource.
+type resourceKind int
+
+// Supported resource kinds.
+const (
+	kindRoot resourceKind = iota
+	kindPrincipal
+	kindHome
+	kindCalendar
+	kindObject
+)
+
+// Handler serves CalDAV requests, mapping them onto the application calls.
+type Handler struct {
+	l Logger
+	a Application
+}
+
+// NewHandler creates a new CalDAV handler.
+// If no error occurs, it returns *Handler, nil and nil, error otherwise.
+func NewHandler(logger Logger, app Application) (*Handler, error) {
+	missing := make([]string, 0)
+	if logger == nil {
+		missing = append(missing, "logger")
+	}
+	if app == nil {
+		missing = append(missing, "app")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: some of the required parameters are missing: args=%v",
+			projectErrors.ErrServerInitFailed, missing)
+	}
+
+	return &Handler{
+		l: logger,
+		a: app,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == WellKnownPath {
+		http.Redirect(w, r, Prefix+"/", http.StatusMovedPermanently)
+		return
+	}
+	if r.Method == http.MethodOptions {
+		h.serveOptions(w)
+		return
+	}
+
+	userID := requestUser(r)
+	if userID == "" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="calendar"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	res, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if res.kind == kindRoot {
+		res.user = userID
+	}
+	if res.user != userID {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	}
+
+	switch r.Method {
+	case "PROPFIND":
+		h.servePropfind(w, r, res)
+	case "PROPPATCH":
+		h.serveProppatch(w, r, res)
+	case "REPORT":
+		h.serveReport(w, r, res)
+	case http.MethodGet, http.MethodHead:
+		h.serveGet(w, r, res)
+	case http.MethodPut:
+		h.servePut(w, r, res)
+	case http.MethodDelete:
+		h.serveDelete(w, r, res)
+	case "MKCALENDAR":
+		// The only calendar of the user always exists.
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	default:
+		w.Header().Set("Allow", strings.Join(Methods, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// serveOptions advertises the supported DAV capabilities and methods.
+func (h *Handler) serveOptions(w http.ResponseWriter) {
+	w.Header().Set("DAV", davCapability)
+	w.Header().Set("Allow", strings.Join(Methods, ", "))
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeError writes the status code corresponding to the application error.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, ical.ErrInvalidCalendar), errors.Is(err, errInvalidRequest),
+		errors.Is(err, projectErrors.ErrEmptyField), errors.Is(err, projectErrors.ErrInvalidFieldData),
+		errors.Is(err, projectErrors.ErrNoData):
+		status = http.StatusBadRequest
+	case errors.Is(err, projectErrors.ErrEventNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, projectErrors.ErrPermissionDenied):
+		status = http.StatusForbidden
+	case errors.Is(err, projectErrors.ErrDateBusy):
+		status = http.StatusConflict
+	case errors.Is(err, projectErrors.ErrQuotaExceeded), errors.Is(err, projectErrors.ErrStorageFull):
+		status = http.StatusInsufficientStorage
+	case errors.Is(err, projectErrors.ErrRetriesExceeded):
+		h.l.Warn(r.Context(), "retries exceeded", slog.String("err", err.Error()))
+		status = http.StatusServiceUnavailable
+	default:
+		h.l.Error(r.Context(), "unknown error received", slog.String("err", err.Error()))
+		status = http.StatusInternalServerError
+	}
+
+	msg := http.StatusText(status)
+	if status == http.StatusBadRequest {
+		msg = err.Error()
+	}
+	http.Error(w, msg, status)
+}
+
+// requestUser returns the ID of the user, making the request. Returns an empty string if it is unknown.
+func requestUser(r *http.Request) string {
+	if userID := r.Header.Get(userIDHeader); userID != "" {
+		return userID
+	}
+	userID, _, _ := r.BasicAuth()
+	return userID
+}
+
+// parsePath returns the resource addressed by the path. Returns false if the path is unknown.
+func parsePath(path string) (*resource, bool) {
+	rel, ok := strings.CutPrefix(path, Prefix)
+	if !ok || (rel != "" && rel[0] != '/') {
+		return nil, false
+	}
+	rel = strings.Trim(rel, "/")
+	if rel == "" {
+		return &resource{kind: kindRoot, href: Prefix + "/"}, true
+	}
+
+	parts := strings.Split(rel, "/")
+	for _, part := range parts {
+		if part == "" {
+			return nil, false
+		}
+	}
+	switch {
+	case len(parts) == 2 && parts[0] == "principals":
+		return &resource{kind: kindPrincipal, user: parts[1], href: principalPath(parts[1])}, true
+	case len(parts) == 2 && parts[0] == "calendars":
+		return &resource{kind: kindHome, user: parts[1], href: homePath(parts[1])}, true
+	case len(parts) == 3 && parts[0] == "calendars" && parts[2] == calendarName:
+		return &resource{kind: kindCalendar, user: parts[1], href: calendarPath(parts[1])}, true
+	case len(parts) == 4 && parts[0] == "calendars" && parts[2] == calendarName &&
+		strings.HasSuffix(parts[3], objectExt):
+		return &resource{kind: kindObject, user: parts[1], name: parts[3], href: objectPath(parts[1], parts[3])}, true
+	default:
+		return nil, false
+	}
+}
+
+// principalPath returns the path of the user principal.
+func principalPath(userID string) string {
+	return Prefix + "/principals/" + escapeSegment(userID) + "/"
+}
+
+// homePath returns the path of the user calendar home.
+func homePath(userID string) string {
+	return Prefix + "/calendars/" + escapeSegment(userID) + "/"
+}
+
+// calendarPath returns the path of the user calendar.
+func calendarPath(userID string) string {
+	return homePath(userID) + calendarName + "/"
+}
+
+// objectPath returns the path of the event resource.
+func objectPath(userID, name string) string {
+	return calendarPath(userID) + escapeSegment(name)
+}
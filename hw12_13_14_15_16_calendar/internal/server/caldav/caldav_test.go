@@ -0,0 +1,327 @@
+package caldav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/app"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                         //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                            //nolint:depguard,nolintlint
+)
+
+const testUser = "user1"
+
+// reSyncToken extracts the sync token from the multi-status response.
+var reSyncToken = regexp.MustCompile(`<d:sync-token>([^<]+)</d:sync-token>`)
+
+// discardLogger is a logger, which drops all messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+// newTestHandler creates the handler on top of the application with the in-memory storage.
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	h, _ := newTestHandlerWithApp(t)
+	return h
+}
+
+// newTestHandlerWithApp creates the handler and returns it along with the underlying application.
+func newTestHandlerWithApp(t *testing.T) (*Handler, *app.App) {
+	t.Helper()
+	a, _ := newTestApp(t)
+	h, err := NewHandler(discardLogger{}, a)
+	require.NoError(t, err)
+	return h, a
+}
+
+// newTestApp creates the application and returns it along with the underlying in-memory storage.
+func newTestApp(t *testing.T) (*app.App, *memory.Storage) {
+	t.Helper()
+	storage, err := memory.NewStorage(1000)
+	require.NoError(t, err)
+	require.NoError(t, storage.Connect(context.Background()))
+	a, err := app.NewApp(discardLogger{}, storage, map[string]any{
+		"retries":       1,
+		"retry_timeout": time.Millisecond,
+	})
+	require.NoError(t, err)
+	return a, storage
+}
+
+// do performs the request on behalf of testUser. Headers are passed as key-value pairs.
+func do(h *Handler, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set(userIDHeader, testUser)
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// eventObject returns the iCalendar object of the hour-long event.
+func eventObject(title string, start time.Time) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\nBEGIN:VEVENT\r\n" +
+		"UID:" + uuid.NewString() + "\r\nDTSTAMP:20250101T000000Z\r\n" +
+		"DTSTART:" + start.UTC().Format("20060102T150405Z") + "\r\nDURATION:PT1H\r\n" +
+		"SUMMARY:" + title + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+}
+
+func TestDiscovery(t *testing.T) {
+	h := newTestHandler(t)
+
+	w := do(h, http.MethodGet, WellKnownPath, "")
+	require.Equal(t, http.StatusMovedPermanently, w.Code)
+	require.Equal(t, Prefix+"/", w.Header().Get("Location"))
+
+	w = do(h, http.MethodOptions, calendarPath(testUser), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("DAV"), "calendar-access")
+
+	r := httptest.NewRequest("PROPFIND", Prefix+"/", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusUnauthorized, w.Code, "anonymous request is not rejected")
+	require.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+	r = httptest.NewRequest("PROPFIND", Prefix+"/", nil)
+	r.SetBasicAuth(testUser, "any")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusMultiStatus, w.Code, "basic auth user is not accepted")
+	require.Contains(t, w.Body.String(), "<d:current-user-principal><d:href>"+principalPath(testUser))
+
+	body := `<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+		`<d:prop><c:calendar-home-set/><d:unknown/></d:prop></d:propfind>`
+	w = do(h, "PROPFIND", principalPath(testUser), body, "Depth", "0")
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+	require.Contains(t, w.Body.String(), "<c:calendar-home-set><d:href>"+homePath(testUser))
+	require.Contains(t, w.Body.String(), "HTTP/1.1 404 Not Found", "unknown property is not reported")
+
+	w = do(h, "PROPFIND", homePath(testUser), "", "Depth", "1")
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+	require.Contains(t, w.Body.String(), "<d:href>"+calendarPath(testUser)+"</d:href>")
+	require.Contains(t, w.Body.String(), "<c:calendar/>")
+
+	w = do(h, "PROPFIND", calendarPath("user2"), "")
+	require.Equal(t, http.StatusForbidden, w.Code, "calendar of another user is accessible")
+
+	w = do(h, "PROPFIND", Prefix+"/unknown/", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestObjectLifecycle(t *testing.T) {
+	h := newTestHandler(t)
+	path := objectPath(testUser, uuid.NewString()+objectExt)
+	start := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+
+	w := do(h, http.MethodPut, path, eventObject("Meeting", start), "If-None-Match", "*")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	w = do(h, http.MethodPut, path, eventObject("Meeting", start), "If-None-Match", "*")
+	require.Equal(t, http.StatusPreconditionFailed, w.Code, "existing event is overwritten")
+
+	w = do(h, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, etag, w.Header().Get("ETag"))
+	require.Contains(t, w.Body.String(), "SUMMARY:Meeting")
+
+	w = do(h, http.MethodPut, path, eventObject("Renamed", start), "If-Match", `"stale"`)
+	require.Equal(t, http.StatusPreconditionFailed, w.Code, "stale update is accepted")
+
+	w = do(h, http.MethodPut, path, eventObject("Renamed", start), "If-Match", etag)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	require.NotEqual(t, etag, w.Header().Get("ETag"), "etag is not changed on update")
+
+	w = do(h, http.MethodGet, calendarPath(testUser), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "SUMMARY:Renamed")
+
+	w = do(h, http.MethodPut, objectPath(testUser, "custom-name.ics"), eventObject("Other", start.Add(time.Hour)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = do(h, http.MethodGet, objectPath(testUser, "custom-name.ics"), "")
+	require.Equal(t, http.StatusOK, w.Code, "event is not available by the name it was created with")
+
+	w = do(h, http.MethodPut, objectPath(testUser, uuid.NewString()+objectExt), eventObject("Overlap", start))
+	require.Equal(t, http.StatusConflict, w.Code, "overlapping event is accepted")
+
+	w = do(h, http.MethodPut, objectPath(testUser, uuid.NewString()+objectExt), "BEGIN:VCALENDAR\r\n")
+	require.Equal(t, http.StatusBadRequest, w.Code, "malformed object is accepted")
+
+	w = do(h, http.MethodDelete, path, "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = do(h, http.MethodGet, path, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = do(h, http.MethodDelete, path, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateKeepsBookings(t *testing.T) {
+	ctx := context.Background()
+	h, a := newTestHandlerWithApp(t)
+
+	room, err := a.CreateResource(ctx, &dto.CreateResourceInput{Name: "Blue room"})
+	require.NoError(t, err)
+	name := uuid.NewString() + objectExt
+	id := objectID(testUser, name)
+	start := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	_, err = a.CreateEvent(ctx, &dto.CreateEventInput{
+		ID: &id, Title: "Meeting", Datetime: start, Duration: time.Hour, UserID: testUser,
+		Resources: []string{room.ID.String()},
+	})
+	require.NoError(t, err)
+
+	w := do(h, http.MethodPut, objectPath(testUser, name), eventObject("Renamed", start))
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	event, err := a.GetEvent(ctx, id.String())
+	require.NoError(t, err)
+	require.Equal(t, "Renamed", event.Title)
+	require.Equal(t, []string{room.ID.String()}, event.Resources, "bookings are dropped by the update")
+}
+
+func TestReports(t *testing.T) {
+	h := newTestHandler(t)
+	start := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	first := objectPath(testUser, uuid.NewString()+objectExt)
+	second := objectPath(testUser, uuid.NewString()+objectExt)
+	require.Equal(t, http.StatusCreated, do(h, http.MethodPut, first, eventObject("First", start)).Code)
+	require.Equal(t, http.StatusCreated, do(h, http.MethodPut, second, eventObject("Second", start.Add(48*time.Hour))).Code)
+
+	t.Run("calendar-query", func(t *testing.T) {
+		body := `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+			`<d:prop><d:getetag/><c:calendar-data/></d:prop>` +
+			`<c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT">` +
+			`<c:time-range start="20250110T000000Z" end="20250111T000000Z"/>` +
+			`</c:comp-filter></c:comp-filter></c:filter></c:calendar-query>`
+		w := do(h, "REPORT", calendarPath(testUser), body, "Depth", "1")
+		require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "<d:href>"+first+"</d:href>")
+		require.NotContains(t, w.Body.String(), "<d:href>"+second+"</d:href>", "time-range filter is ignored")
+		require.Contains(t, w.Body.String(), "SUMMARY:First")
+	})
+
+	t.Run("calendar-multiget", func(t *testing.T) {
+		missing := objectPath(testUser, uuid.NewString()+objectExt)
+		body := `<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+			`<d:prop><d:getetag/></d:prop><d:href>` + second + `</d:href><d:href>` + missing + `</d:href>` +
+			`</c:calendar-multiget>`
+		w := do(h, "REPORT", calendarPath(testUser), body, "Depth", "1")
+		require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "<d:href>"+second+"</d:href><d:propstat>")
+		require.Contains(t, w.Body.String(),
+			"<d:href>"+missing+"</d:href><d:status>HTTP/1.1 404 Not Found</d:status>")
+	})
+
+	t.Run("sync-collection", func(t *testing.T) {
+		syncBody := func(token string) string {
+			return `<d:sync-collection xmlns:d="DAV:"><d:sync-token>` + token + `</d:sync-token>` +
+				`<d:sync-level>1</d:sync-level><d:prop><d:getetag/></d:prop></d:sync-collection>`
+		}
+
+		w := do(h, "REPORT", calendarPath(testUser), syncBody(""))
+		require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), first)
+		require.Contains(t, w.Body.String(), second)
+		token := reSyncToken.FindStringSubmatch(w.Body.String())[1]
+
+		w = do(h, "PROPFIND", calendarPath(testUser), "", "Depth", "0")
+		require.Contains(t, w.Body.String(), "<cs:getctag>"+token+"</cs:getctag>", "ctag differs from sync token")
+
+		w = do(h, "REPORT", calendarPath(testUser), syncBody(token))
+		require.Equal(t, http.StatusMultiStatus, w.Code)
+		require.NotContains(t, w.Body.String(), "<d:response>", "unchanged calendar reports changes")
+
+		require.Equal(t, http.StatusNoContent, do(h, http.MethodPut, first, eventObject("Changed", start)).Code)
+		require.Equal(t, http.StatusNoContent, do(h, http.MethodDelete, second, "").Code)
+
+		w = do(h, "REPORT", calendarPath(testUser), syncBody(token))
+		require.Equal(t, http.StatusMultiStatus, w.Code)
+		require.Contains(t, w.Body.String(), "<d:href>"+first+"</d:href><d:propstat>")
+		require.Contains(t, w.Body.String(),
+			"<d:href>"+second+"</d:href><d:status>HTTP/1.1 404 Not Found</d:status>")
+		require.NotEqual(t, token, reSyncToken.FindStringSubmatch(w.Body.String())[1])
+
+		w = do(h, "REPORT", calendarPath(testUser), syncBody(syncTokenPrefix+"unknown"))
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Contains(t, w.Body.String(), "<d:valid-sync-token/>")
+	})
+
+	t.Run("unsupported report", func(t *testing.T) {
+		w := do(h, "REPORT", calendarPath(testUser), `<d:expand-property xmlns:d="DAV:"/>`)
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Contains(t, w.Body.String(), "<d:supported-report/>")
+	})
+}
+
+func TestSyncTokens(t *testing.T) {
+	a, storage := newTestApp(t)
+	h, err := NewHandler(discardLogger{}, a)
+	require.NoError(t, err)
+	start := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	first := objectPath(testUser, uuid.NewString()+objectExt)
+	second := objectPath(testUser, uuid.NewString()+objectExt)
+	require.Equal(t, http.StatusCreated, do(h, http.MethodPut, first, eventObject("First", start)).Code)
+
+	// sync requests the changes since the token and returns the response along with the next token.
+	sync := func(h *Handler, token string) (*httptest.ResponseRecorder, string) {
+		body := `<d:sync-collection xmlns:d="DAV:"><d:sync-token>` + token + `</d:sync-token>` +
+			`<d:sync-level>1</d:sync-level><d:prop><d:getetag/></d:prop></d:sync-collection>`
+		w := do(h, "REPORT", calendarPath(testUser), body)
+		if match := reSyncToken.FindStringSubmatch(w.Body.String()); match != nil {
+			return w, match[1]
+		}
+		return w, ""
+	}
+
+	w, initial := sync(h, "")
+	require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), "<d:href>"+first+"</d:href><d:propstat>")
+
+	// Tokens are issued by the storage, so they are valid for another handler instance.
+	restarted, err := NewHandler(discardLogger{}, a)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, do(h, http.MethodPut, second, eventObject("Second", start.Add(time.Hour))).Code)
+	w, token := sync(restarted, initial)
+	require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+	require.NotContains(t, w.Body.String(), "<d:href>"+first+"</d:href>", "unchanged event is reported")
+	require.Contains(t, w.Body.String(), "<d:href>"+second+"</d:href><d:propstat>")
+
+	require.Equal(t, http.StatusNoContent, do(h, http.MethodDelete, first, "").Code)
+	w, deleted := sync(h, token)
+	require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+	require.Equal(t, 1, strings.Count(w.Body.String(), "<d:response>"), "unexpected number of changes")
+	require.Contains(t, w.Body.String(), "<d:href>"+first+"</d:href><d:status>HTTP/1.1 404 Not Found</d:status>")
+
+	// The cleanup prunes the tombstones, so the tokens issued before the deletion expire.
+	_, err = storage.DeleteOldEvents(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	for _, expired := range []string{initial, token} {
+		w, _ = sync(h, expired)
+		require.Equal(t, http.StatusForbidden, w.Code, "expired token %s is accepted", expired)
+		require.Contains(t, w.Body.String(), "<d:valid-sync-token/>")
+	}
+	w, _ = sync(h, deleted)
+	require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), "<d:href>"+second+"</d:href><d:status>HTTP/1.1 404 Not Found</d:status>",
+		"deletion by the cleanup is not reported")
+
+	for _, invalid := range []string{"unknown", syncTokenPrefix + "-1", syncTokenPrefix + "1000", syncTokenPrefix} {
+		w, _ = sync(h, invalid)
+		require.Equal(t, http.StatusForbidden, w.Code, "invalid token %s is accepted", invalid)
+		require.Contains(t, w.Body.String(), "<d:valid-sync-token/>")
+	}
+}
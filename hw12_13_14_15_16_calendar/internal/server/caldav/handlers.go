@@ -0,0 +1,400 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                  //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ical"                 //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// maxTime is used as the end of the open time-range filters.
+var maxTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// servePropfind returns the properties of the resource and, unless Depth is 0, of its direct children.
+func (h *Handler) servePropfind(w http.ResponseWriter, r *http.Request, res *resource) {
+	var req propfindRequest
+	if _, err := decodeBody(r, &req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	// Infinite depth is not supported and is served as 1.
+	withChildren := r.Header.Get("Depth") != "0"
+	children := make([]*resource, 0)
+	switch res.kind {
+	case kindObject:
+		if err := h.loadObject(ctx, res); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	case kindCalendar:
+		objects, err := h.loadCalendar(ctx, res, withChildren)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		children = objects
+	case kindHome:
+		calendar := &resource{kind: kindCalendar, user: res.user, href: calendarPath(res.user)}
+		if _, err := h.loadCalendar(ctx, calendar, false); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		children = append(children, calendar)
+	case kindRoot, kindPrincipal:
+	}
+	if !withChildren {
+		children = children[:0]
+	}
+
+	ms := newMultistatus()
+	ms.addResource(res, &req.propRequest)
+	for _, child := range children {
+		ms.addResource(child, &req.propRequest)
+	}
+	ms.write(w, "")
+}
+
+// serveProppatch rejects the properties update, as all the properties are read-only.
+func (h *Handler) serveProppatch(w http.ResponseWriter, r *http.Request, res *resource) {
+	var req proppatchRequest
+	if _, err := decodeBody(r, &req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	names := make([]xml.Name, 0)
+	for _, update := range append(req.Set, req.Remove...) {
+		names = append(names, update.Prop.names()...)
+	}
+	ms := newMultistatus()
+	ms.addProps(res.href, nil, names, http.StatusForbidden)
+	ms.write(w, "")
+}
+
+// serveReport serves calendar-query, calendar-multiget and sync-collection reports of the calendar.
+func (h *Handler) serveReport(w http.ResponseWriter, r *http.Request, res *resource) {
+	unsupported := xml.Name{Space: nsDAV, Local: "supported-report"}
+	if res.kind != kindCalendar {
+		writeXMLError(w, http.StatusForbidden, unsupported)
+		return
+	}
+
+	var req reportRequest
+	ok, err := decodeBody(r, &req)
+	if err == nil && !ok {
+		err = fmt.Errorf("%w: empty report", errInvalidRequest)
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	switch req.XMLName {
+	case xml.Name{Space: nsCalDAV, Local: "calendar-query"}:
+		h.reportQuery(w, r, res, &req)
+	case xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}:
+		h.reportMultiget(w, r, res, &req)
+	case xml.Name{Space: nsDAV, Local: "sync-collection"}:
+		h.reportSync(w, r, res, &req)
+	default:
+		writeXMLError(w, http.StatusForbidden, unsupported)
+	}
+}
+
+// reportQuery returns the events of the calendar, matching the time-range filter if any.
+func (h *Handler) reportQuery(w http.ResponseWriter, r *http.Request, res *resource, req *reportRequest) {
+	ctx := r.Context()
+	start, end, ok, err := req.Filter.timeRange()
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var objects []*resource
+	if ok {
+		if end.IsZero() {
+			end = maxTime
+		}
+		var events []*types.Event
+		events, err = h.a.GetEventsForPeriod(ctx, &dto.DateRangeInput{
+			DateStart: start,
+			DateEnd:   end,
+			UserID:    &res.user,
+		})
+		if errors.Is(err, projectErrors.ErrEventNotFound) {
+			err = nil
+		}
+		objects = objectResources(res.user, events)
+	} else {
+		objects, err = h.listObjects(ctx, res.user)
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	ms := newMultistatus()
+	for _, obj := range objects {
+		ms.addResource(obj, &req.propRequest)
+	}
+	ms.write(w, "")
+}
+
+// reportMultiget returns the requested events of the calendar. Unknown events are reported with 404 status.
+func (h *Handler) reportMultiget(w http.ResponseWriter, r *http.Request, res *resource, req *reportRequest) {
+	ms := newMultistatus()
+	for _, href := range req.Hrefs {
+		u, err := url.Parse(strings.TrimSpace(href))
+		if err != nil {
+			ms.addStatus(href, http.StatusNotFound)
+			continue
+		}
+		obj, ok := parsePath(u.Path)
+		if !ok || obj.kind != kindObject || obj.user != res.user {
+			ms.addStatus(href, http.StatusNotFound)
+			continue
+		}
+
+		err = h.loadObject(r.Context(), obj)
+		switch {
+		case errors.Is(err, projectErrors.ErrEventNotFound):
+			ms.addStatus(href, http.StatusNotFound)
+		case errors.Is(err, projectErrors.ErrPermissionDenied):
+			ms.addStatus(href, http.StatusForbidden)
+		case err != nil:
+			h.writeError(w, r, err)
+			return
+		default:
+			// Clients match the responses by the requested hrefs.
+			obj.href = href
+			ms.addResource(obj, &req.propRequest)
+		}
+	}
+	ms.write(w, "")
+}
+
+// reportSync returns the events changed since the sync token state and the hrefs of the deleted ones.
+// All events are returned if the token is empty.
+func (h *Handler) reportSync(w http.ResponseWriter, r *http.Request, res *resource, req *reportRequest) {
+	invalidToken := xml.Name{Space: nsDAV, Local: "valid-sync-token"}
+	since, ok := parseSyncToken(strings.TrimSpace(req.SyncToken))
+	if !ok {
+		writeXMLError(w, http.StatusForbidden, invalidToken)
+		return
+	}
+
+	changes, err := h.a.GetEventChanges(r.Context(), res.user, since)
+	if errors.Is(err, projectErrors.ErrChangesExpired) {
+		writeXMLError(w, http.StatusForbidden, invalidToken)
+		return
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	ms := newMultistatus()
+	for _, obj := range objectResources(res.user, changes.Events) {
+		ms.addResource(obj, &req.propRequest)
+	}
+	for _, id := range changes.Deleted {
+		ms.addStatus(objectPath(res.user, id.String()+objectExt), http.StatusNotFound)
+	}
+	ms.write(w, syncToken(changes.Seq))
+}
+
+// serveGet returns the event or all events of the calendar as iCalendar object.
+func (h *Handler) serveGet(w http.ResponseWriter, r *http.Request, res *resource) {
+	var events []*types.Event
+	switch res.kind {
+	case kindObject:
+		if err := h.loadObject(r.Context(), res); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		events = append(events, res.event)
+		w.Header().Set("ETag", res.etag)
+	case kindCalendar:
+		objects, err := h.listObjects(r.Context(), res.user)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		for _, obj := range objects {
+			events = append(events, obj.event)
+		}
+	default:
+		h.methodNotAllowed(w)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, time.Now(), events...); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// servePut creates or fully replaces the event with the one from the request body.
+func (h *Handler) servePut(w http.ResponseWriter, r *http.Request, res *resource) {
+	if res.kind != kindObject {
+		h.methodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+	decoded, err := ical.Decode(r.Body)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	existing, err := h.findEvent(ctx, res.user, res.name)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if !preconditionsMet(r, existing) {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+
+	var event *types.Event
+	status := http.StatusCreated
+	if existing == nil {
+		event, err = h.a.CreateEvent(ctx, createInput(objectID(res.user, res.name), res.user, &decoded.Data))
+	} else {
+		// Calendar clients do not manage the booked resources, so the bookings are kept.
+		decoded.Data.Resources = existing.Resources
+		event, err = h.a.UpdateEvent(ctx, updateInput(existing.ID, res.user, &decoded.Data))
+		status = http.StatusNoContent
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", eventETag(event))
+	w.WriteHeader(status)
+}
+
+// serveDelete deletes the event.
+func (h *Handler) serveDelete(w http.ResponseWriter, r *http.Request, res *resource) {
+	switch res.kind {
+	case kindObject:
+	case kindCalendar:
+		// The only calendar of the user cannot be deleted.
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	default:
+		h.methodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+	existing, err := h.findEvent(ctx, res.user, res.name)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if existing == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if !preconditionsMet(r, existing) {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+	if err := h.a.DeleteEvent(ctx, existing.ID.String()); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// methodNotAllowed writes 405 status code for the methods not applicable to the resource.
+func (h *Handler) methodNotAllowed(w http.ResponseWriter) {
+	w.Header().Set("Allow", strings.Join(Methods, ", "))
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+// preconditionsMet checks If-Match and If-None-Match headers against the existing event, which might be nil.
+func preconditionsMet(r *http.Request, existing *types.Event) bool {
+	if r.Header.Get("If-None-Match") == "*" && existing != nil {
+		return false
+	}
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return true
+	}
+	if existing == nil {
+		return false
+	}
+	if ifMatch == "*" {
+		return true
+	}
+	etag := eventETag(existing)
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// createInput returns the input for creating the event from the decoded event data.
+func createInput(id uuid.UUID, userID string, data *types.EventData) *dto.CreateEventInput {
+	busyStatus := string(data.BusyStatus)
+	return &dto.CreateEventInput{
+		ID:          &id,
+		Title:       data.Title,
+		Datetime:    data.Datetime,
+		Duration:    data.Duration,
+		UserID:      userID,
+		Description: &data.Description,
+		RemindIn:    &data.RemindIn,
+		BusyStatus:  &busyStatus,
+		AllDay:      data.AllDay,
+		Tags:        data.Tags,
+		Color:       &data.Color,
+		Location:    &data.Location,
+		URL:         &data.URL,
+	}
+}
+
+// updateInput returns the input for replacing the event with the decoded event data.
+func updateInput(id uuid.UUID, userID string, data *types.EventData) *dto.UpdateEventInput {
+	busyStatus := string(data.BusyStatus)
+	return &dto.UpdateEventInput{
+		ID:          id,
+		Title:       &data.Title,
+		Datetime:    &data.Datetime,
+		Duration:    &data.Duration,
+		UserID:      &userID,
+		Description: &data.Description,
+		RemindIn:    &data.RemindIn,
+		BusyStatus:  &busyStatus,
+		AllDay:      &data.AllDay,
+		Tags:        data.Tags,
+		Resources:   data.Resources,
+		Color:       &data.Color,
+		Location:    &data.Location,
+		URL:         &data.URL,
+	}
+}
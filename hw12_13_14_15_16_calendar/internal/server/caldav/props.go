@@ -0,0 +1,195 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ical" //nolint:depguard,nolintlint
+)
+
+// supportedReports is the value of the calendar supported-report-set property.
+const supportedReports = "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+	"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>" +
+	"<d:supported-report><d:report><d:sync-collection/></d:report></d:supported-report>"
+
+// property is a supported resource property.
+type property struct {
+	name xml.Name
+	// value returns the raw XML value of the property. Returns false if the resource has no such property.
+	value func(res *resource) (string, bool)
+	// allProp reports whether the property is returned for allprop requests.
+	allProp bool
+}
+
+// properties are the supported properties in the order of allprop responses.
+var properties = []property{
+	{name: xml.Name{Space: nsDAV, Local: "resourcetype"}, value: resourceType, allProp: true},
+	{name: xml.Name{Space: nsDAV, Local: "displayname"}, value: displayName, allProp: true},
+	{
+		name: xml.Name{Space: nsDAV, Local: "current-user-principal"},
+		value: func(res *resource) (string, bool) {
+			return hrefValue(principalPath(res.user)), true
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "principal-URL"},
+		value: func(res *resource) (string, bool) {
+			return hrefValue(principalPath(res.user)), res.kind == kindPrincipal
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsCalDAV, Local: "calendar-home-set"},
+		value: func(res *resource) (string, bool) {
+			return hrefValue(homePath(res.user)), res.kind == kindPrincipal
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "owner"},
+		value: func(res *resource) (string, bool) {
+			return hrefValue(principalPath(res.user)), res.kind >= kindHome
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "current-user-privilege-set"},
+		value: func(res *resource) (string, bool) {
+			if res.kind < kindCalendar {
+				return "<d:privilege><d:read/></d:privilege>", true
+			}
+			return "<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>" +
+				"<d:privilege><d:write-content/></d:privilege>", true
+		},
+	},
+	{
+		name: xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"},
+		value: func(res *resource) (string, bool) {
+			return `<c:comp name="VEVENT"/>`, res.kind == kindCalendar
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "supported-report-set"},
+		value: func(res *resource) (string, bool) {
+			return supportedReports, res.kind == kindCalendar
+		},
+	},
+	{
+		name: xml.Name{Space: nsCalServer, Local: "getctag"},
+		value: func(res *resource) (string, bool) {
+			return escapeXML(res.syncToken), res.kind == kindCalendar
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "sync-token"},
+		value: func(res *resource) (string, bool) {
+			return escapeXML(res.syncToken), res.kind == kindCalendar
+		},
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "getetag"},
+		value: func(res *resource) (string, bool) {
+			return escapeXML(res.etag), res.kind == kindObject
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsDAV, Local: "getcontenttype"},
+		value: func(res *resource) (string, bool) {
+			return escapeXML(ical.ContentType + "; component=vevent"), res.kind == kindObject
+		},
+		allProp: true,
+	},
+	{
+		name: xml.Name{Space: nsCalDAV, Local: "calendar-data"},
+		value: func(res *resource) (string, bool) {
+			if res.kind != kindObject {
+				return "", false
+			}
+			return escapeXML(encodeObject(res)), true
+		},
+	},
+}
+
+// resourceType returns the resource type value.
+func resourceType(res *resource) (string, bool) {
+	switch res.kind {
+	case kindPrincipal:
+		return "<d:principal/>", true
+	case kindRoot, kindHome:
+		return "<d:collection/>", true
+	case kindCalendar:
+		return "<d:collection/><c:calendar/>", true
+	default:
+		return "", true
+	}
+}
+
+// displayName returns the display name of the collections.
+func displayName(res *resource) (string, bool) {
+	switch res.kind {
+	case kindPrincipal, kindHome:
+		return escapeXML(res.user), true
+	case kindCalendar:
+		return "Events", true
+	default:
+		return "", false
+	}
+}
+
+// encodeObject returns the iCalendar object of the loaded event resource.
+func encodeObject(res *resource) string {
+	var buf bytes.Buffer
+	// Encoding to the memory buffer never fails.
+	_ = ical.Encode(&buf, time.Now(), res.event)
+	return buf.String()
+}
+
+// addResource adds the response with the requested properties of the resource.
+func (m *multistatus) addResource(res *resource, req *propRequest) {
+	found := make([]propValue, 0)
+	switch {
+	case req.Prop != nil:
+		missing := make([]xml.Name, 0)
+		for _, name := range req.Prop.names() {
+			if value, ok := lookupProp(res, name); ok {
+				found = append(found, propValue{name: name, value: value})
+				continue
+			}
+			missing = append(missing, name)
+		}
+		m.addProps(res.href, found, missing, http.StatusNotFound)
+		return
+	case req.PropName != nil:
+		for _, p := range properties {
+			if _, ok := p.value(res); ok {
+				found = append(found, propValue{name: p.name})
+			}
+		}
+	default:
+		for _, p := range properties {
+			if !p.allProp {
+				continue
+			}
+			if value, ok := p.value(res); ok {
+				found = append(found, propValue{name: p.name, value: value})
+			}
+		}
+	}
+	m.addProps(res.href, found, nil, 0)
+}
+
+// lookupProp returns the value of the property by its name.
+func lookupProp(res *resource, name xml.Name) (string, bool) {
+	for _, p := range properties {
+		if p.name == name {
+			return p.value(res)
+		}
+	}
+	return "", false
+}
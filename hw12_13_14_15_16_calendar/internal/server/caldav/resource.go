@@ -0,0 +1,132 @@
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ical"                 //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// resource is a CalDAV resource addressed by the request.
+type resource struct {
+	kind resourceKind
+	user string
+	name string // Event resource name, including the extension.
+	href string
+
+	event     *types.Event // Set for the loaded event resources only.
+	etag      string       // Set for the loaded event resources only.
+	syncToken string       // Set for the loaded calendar only.
+}
+
+// objectID returns the event ID for the resource name.
+//
+// Events are listed by their IDs, so the names generated by the clients are usually IDs as well.
+// Other names are mapped onto the ID deterministically, so the event is still available by the name it was
+// created with, but is listed by its ID.
+func objectID(userID, name string) uuid.UUID {
+	if id, err := uuid.Parse(strings.TrimSuffix(name, objectExt)); err == nil {
+		return id
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(objectPath(userID, name)))
+}
+
+// objectResource returns the loaded resource of the event.
+func objectResource(userID string, event *types.Event) *resource {
+	name := event.ID.String() + objectExt
+	return &resource{
+		kind:  kindObject,
+		user:  userID,
+		name:  name,
+		href:  objectPath(userID, name),
+		event: event,
+		etag:  eventETag(event),
+	}
+}
+
+// eventETag returns the strong entity tag of the event, which changes with any of the exposed event fields.
+func eventETag(event *types.Event) string {
+	var buf bytes.Buffer
+	// Encoding to the memory buffer never fails.
+	_ = ical.Encode(&buf, time.Unix(0, 0), event)
+	sum := sha256.Sum256(buf.Bytes())
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// findEvent returns the event of the user by the resource name. Returns nil, nil if there is no such event.
+// Returns ErrPermissionDenied if the event belongs to another user.
+func (h *Handler) findEvent(ctx context.Context, userID, name string) (*types.Event, error) {
+	event, err := h.a.GetEvent(ctx, objectID(userID, name).String())
+	if errors.Is(err, projectErrors.ErrEventNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if event.UserID != userID {
+		return nil, projectErrors.ErrPermissionDenied
+	}
+	return event, nil
+}
+
+// loadObject loads the event of the resource. Returns ErrEventNotFound if there is no such event.
+func (h *Handler) loadObject(ctx context.Context, res *resource) error {
+	event, err := h.findEvent(ctx, res.user, res.name)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return projectErrors.ErrEventNotFound
+	}
+	res.event = event
+	res.etag = eventETag(event)
+	return nil
+}
+
+// listObjects returns the loaded resources of all user events.
+func (h *Handler) listObjects(ctx context.Context, userID string) ([]*resource, error) {
+	events, err := h.a.GetAllUserEvents(ctx, userID)
+	if err != nil && !errors.Is(err, projectErrors.ErrEventNotFound) {
+		return nil, err
+	}
+	return objectResources(userID, events), nil
+}
+
+// objectResources returns the loaded resources of the events.
+func objectResources(userID string, events []*types.Event) []*resource {
+	res := make([]*resource, 0, len(events))
+	for _, event := range events {
+		if event != nil {
+			res = append(res, objectResource(userID, event))
+		}
+	}
+	return res
+}
+
+// loadCalendar loads the sync token of the current calendar state and, if withObjects is set,
+// the resources of all calendar events.
+func (h *Handler) loadCalendar(ctx context.Context, res *resource, withObjects bool) ([]*resource, error) {
+	changes, err := h.a.GetEventChanges(ctx, res.user, 0)
+	if err != nil {
+		return nil, err
+	}
+	res.syncToken = syncToken(changes.Seq)
+	if !withObjects {
+		return nil, nil
+	}
+	return objectResources(res.user, changes.Events), nil
+}
+
+// escapeSegment escapes the path segment.
+func escapeSegment(s string) string {
+	return url.PathEscape(s)
+}
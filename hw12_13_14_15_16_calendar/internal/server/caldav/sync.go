@@ -0,0 +1,29 @@
+package caldav
+
+import (
+	"strconv"
+	"strings"
+)
+
+const syncTokenPrefix = "urn:calendar:sync:" // URI scheme of the sync tokens.
+
+// syncToken returns the sync token (RFC 6578) of the calendar state.
+//
+// The token is the sequence number of the storage change log, so it stays valid after a restart and
+// on any of the service instances, until the tombstones after it are pruned by the events cleanup.
+func syncToken(seq int64) string {
+	return syncTokenPrefix + strconv.FormatInt(seq, 10)
+}
+
+// parseSyncToken returns the change log sequence number of the sync token. Empty token means the initial
+// synchronization, which is the sequence number 0. Returns false if the token is malformed.
+func parseSyncToken(token string) (int64, bool) {
+	if token == "" {
+		return 0, true
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(token, syncTokenPrefix), 10, 64)
+	if !strings.HasPrefix(token, syncTokenPrefix) || err != nil || seq < 0 {
+		return 0, false
+	}
+	return seq, true
+}
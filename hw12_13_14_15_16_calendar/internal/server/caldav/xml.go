@@ -0,0 +1,257 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// XML namespaces of the supported elements.
+const (
+	nsDAV       = "DAV:"
+	nsCalDAV    = "urn:ietf:params:xml:ns:caldav"
+	nsCalServer = "http://calendarserver.org/ns/"
+)
+
+// timeRangeFormat is the format of the time-range filter bounds.
+const timeRangeFormat = "20060102T150405Z"
+
+// errInvalidRequest is returned if the request body cannot be parsed.
+var errInvalidRequest = errors.New("invalid request body")
+
+// prefixes are the namespace prefixes used in the responses.
+var prefixes = map[string]string{
+	nsDAV:       "d",
+	nsCalDAV:    "c",
+	nsCalServer: "cs",
+}
+
+// propNames is a list of the requested properties.
+type propNames struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+// names returns the names of the requested properties.
+func (p *propNames) names() []xml.Name {
+	res := make([]xml.Name, 0, len(p.Names))
+	for _, name := range p.Names {
+		res = append(res, name.XMLName)
+	}
+	return res
+}
+
+// propRequest is a set of properties, requested by PROPFIND or REPORT.
+type propRequest struct {
+	AllProp  *struct{}  `xml:"DAV: allprop"`
+	PropName *struct{}  `xml:"DAV: propname"`
+	Prop     *propNames `xml:"DAV: prop"`
+}
+
+// propfindRequest is a PROPFIND request body.
+type propfindRequest struct {
+	XMLName xml.Name `xml:"DAV: propfind"`
+	propRequest
+}
+
+// reportRequest is a REPORT request body. Fields not used by the report are empty.
+type reportRequest struct {
+	XMLName xml.Name
+	propRequest
+	Hrefs     []string  `xml:"DAV: href"`
+	SyncToken string    `xml:"DAV: sync-token"`
+	Filter    *filterEl `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+// proppatchRequest is a PROPPATCH request body.
+type proppatchRequest struct {
+	XMLName xml.Name     `xml:"DAV: propertyupdate"`
+	Set     []propUpdate `xml:"DAV: set"`
+	Remove  []propUpdate `xml:"DAV: remove"`
+}
+
+// propUpdate is a set or remove instruction of PROPPATCH.
+type propUpdate struct {
+	Prop propNames `xml:"DAV: prop"`
+}
+
+// filterEl is a calendar-query filter.
+type filterEl struct {
+	Comp *compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// compFilter is a component filter of the calendar-query.
+type compFilter struct {
+	Name      string        `xml:"name,attr"`
+	TimeRange *timeRangeEl  `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	Comps     []*compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// timeRangeEl is a time-range filter. The bounds are UTC date-time values.
+type timeRangeEl struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+// timeRange returns the bounds of the VEVENT time-range filter. Missing bounds are zero.
+// Returns false if there is no such filter.
+func (f *filterEl) timeRange() (time.Time, time.Time, bool, error) {
+	if f == nil || f.Comp == nil || !strings.EqualFold(f.Comp.Name, "VCALENDAR") {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	for _, comp := range f.Comp.Comps {
+		if !strings.EqualFold(comp.Name, "VEVENT") || comp.TimeRange == nil {
+			continue
+		}
+		var start, end time.Time
+		var err error
+		if comp.TimeRange.Start != "" {
+			if start, err = time.Parse(timeRangeFormat, comp.TimeRange.Start); err != nil {
+				return time.Time{}, time.Time{}, false, fmt.Errorf("%w: time-range start: %w", errInvalidRequest, err)
+			}
+		}
+		if comp.TimeRange.End != "" {
+			if end, err = time.Parse(timeRangeFormat, comp.TimeRange.End); err != nil {
+				return time.Time{}, time.Time{}, false, fmt.Errorf("%w: time-range end: %w", errInvalidRequest, err)
+			}
+		}
+		return start, end, true, nil
+	}
+	return time.Time{}, time.Time{}, false, nil
+}
+
+// decodeBody decodes the XML request body into v. Returns false if the body is empty.
+func decodeBody(r *http.Request, v any) (bool, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false, err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return false, nil
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return false, fmt.Errorf("%w: %w", errInvalidRequest, err)
+	}
+	return true, nil
+}
+
+// propValue is a property with its raw XML value.
+type propValue struct {
+	name  xml.Name
+	value string
+}
+
+// multistatus builds the multi-status response (RFC 4918).
+type multistatus struct {
+	buf bytes.Buffer
+}
+
+// newMultistatus starts a new multi-status response.
+func newMultistatus() *multistatus {
+	m := &multistatus{}
+	m.buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	m.buf.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="` + nsCalDAV + `" xmlns:cs="` + nsCalServer + `">`)
+	return m
+}
+
+// addProps adds the response with the found properties and the properties with the given status.
+func (m *multistatus) addProps(href string, found []propValue, failed []xml.Name, failedStatus int) {
+	m.buf.WriteString("<d:response>")
+	writeElement(&m.buf, xml.Name{Space: nsDAV, Local: "href"}, escapeXML(href))
+	if len(found) > 0 || len(failed) == 0 {
+		m.buf.WriteString("<d:propstat><d:prop>")
+		for _, p := range found {
+			writeElement(&m.buf, p.name, p.value)
+		}
+		m.buf.WriteString("</d:prop>")
+		writeElement(&m.buf, xml.Name{Space: nsDAV, Local: "status"}, statusLine(http.StatusOK))
+		m.buf.WriteString("</d:propstat>")
+	}
+	if len(failed) > 0 {
+		m.buf.WriteString("<d:propstat><d:prop>")
+		for _, name := range failed {
+			writeElement(&m.buf, name, "")
+		}
+		m.buf.WriteString("</d:prop>")
+		writeElement(&m.buf, xml.Name{Space: nsDAV, Local: "status"}, statusLine(failedStatus))
+		m.buf.WriteString("</d:propstat>")
+	}
+	m.buf.WriteString("</d:response>")
+}
+
+// addStatus adds the response with the status of the whole resource.
+func (m *multistatus) addStatus(href string, status int) {
+	m.buf.WriteString("<d:response>")
+	writeElement(&m.buf, xml.Name{Space: nsDAV, Local: "href"}, escapeXML(href))
+	writeElement(&m.buf, xml.Name{Space: nsDAV, Local: "status"}, statusLine(status))
+	m.buf.WriteString("</d:response>")
+}
+
+// write completes the response and writes it with 207 status code.
+// Sync token is added to the response if not empty.
+func (m *multistatus) write(w http.ResponseWriter, syncToken string) {
+	if syncToken != "" {
+		writeElement(&m.buf, xml.Name{Space: nsDAV, Local: "sync-token"}, escapeXML(syncToken))
+	}
+	m.buf.WriteString("</d:multistatus>")
+	writeXML(w, http.StatusMultiStatus, m.buf.Bytes())
+}
+
+// writeXMLError writes the error response with the failed precondition element.
+func writeXMLError(w http.ResponseWriter, status int, precondition xml.Name) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	buf.WriteString(`<d:error xmlns:d="DAV:" xmlns:c="` + nsCalDAV + `">`)
+	writeElement(&buf, precondition, "")
+	buf.WriteString("</d:error>")
+	writeXML(w, status, buf.Bytes())
+}
+
+// writeXML writes the XML response body.
+func writeXML(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", `application/xml; charset=utf-8`)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// writeElement writes the element with the raw XML value. Elements of the unknown namespaces
+// declare the namespace themselves.
+func writeElement(buf *bytes.Buffer, name xml.Name, value string) {
+	tag := name.Local
+	attrs := ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		attrs = ` xmlns:x="` + escapeXML(name.Space) + `"`
+	}
+	if value == "" {
+		buf.WriteString("<" + tag + attrs + "/>")
+		return
+	}
+	buf.WriteString("<" + tag + attrs + ">" + value + "</" + tag + ">")
+}
+
+// escapeXML escapes the text value.
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	// Writing to the memory buffer never fails.
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// hrefValue returns the raw XML value of the href property.
+func hrefValue(href string) string {
+	return "<d:href>" + escapeXML(href) + "</d:href>"
+}
+
+// statusLine returns the HTTP status line of the code.
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"       //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"     //nolint:depguard,nolintlint
 	"google.golang.org/protobuf/types/known/durationpb"                         //nolint:depguard,nolintlint
 	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard,nolintlint
@@ -35,13 +36,115 @@ func fromInternalEventData(data *types.EventData) *pb.EventData {
 		Description: data.Description,
 		UserId:      data.UserID,
 		RemindIn:    remindIn,
+		BusyStatus:  string(data.BusyStatus),
+		AllDay:      data.AllDay,
+		Tags:        data.Tags,
+		Color:       data.Color,
+		Location:    data.Location,
+		Url:         data.URL,
+		Resources:   data.Resources,
+	}
+}
+
+// missedRemindersToProto converts the missed reminders report to the protobuf messages.
+func missedRemindersToProto(reminders []*dto.MissedReminder) []*pb.MissedReminder {
+	res := make([]*pb.MissedReminder, len(reminders))
+	for i, reminder := range reminders {
+		res[i] = &pb.MissedReminder{
+			EventId:  reminder.EventID,
+			Title:    reminder.Title,
+			UserId:   reminder.UserID,
+			Start:    timestamppb.New(reminder.Start),
+			RemindAt: timestamppb.New(reminder.RemindAt),
+			Delay:    durationpb.New(reminder.Delay),
+			Notified: reminder.Notified,
+			Outcome:  reminder.Outcome,
+		}
+	}
+	return res
+}
+
+// digestSettingsToProto converts the internal digest settings to the protobuf ones.
+func digestSettingsToProto(settings *types.DigestSettings) *pb.DigestSettings {
+	return &pb.DigestSettings{
+		Enabled:  settings.Enabled,
+		Time:     settings.Time,
+		TimeZone: settings.TimeZone,
+		SentDate: settings.SentDate,
+	}
+}
+
+// webhookToProto converts the internal webhook subscription to the protobuf one.
+// The secret is included only if withSecret is set.
+func webhookToProto(webhook *types.Webhook, withSecret bool) *pb.Webhook {
+	res := &pb.Webhook{
+		Id:         webhook.ID.String(),
+		UserId:     webhook.UserID,
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Enabled:    webhook.Enabled,
+		Failures:   int64(webhook.Failures),
+		CreatedAt:  timestamppb.New(webhook.CreatedAt),
+	}
+	if withSecret {
+		res.Secret = webhook.Secret
+	}
+	return res
+}
+
+// webhookDeliveryToProto converts the internal delivery attempt to the protobuf one.
+func webhookDeliveryToProto(delivery *types.WebhookDelivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		DeliveryId: delivery.DeliveryID.String(),
+		Attempt:    int64(delivery.Attempt),
+		WebhookId:  delivery.WebhookID.String(),
+		EventType:  delivery.EventType,
+		EventId:    delivery.EventID.String(),
+		StatusCode: int64(delivery.StatusCode),
+		Error:      delivery.Error,
+		Success:    delivery.Success,
+		CreatedAt:  timestamppb.New(delivery.CreatedAt),
+	}
+}
+
+// resourceToProto converts the internal bookable resource to the protobuf one.
+func resourceToProto(resource *types.Resource) *pb.Resource {
+	return &pb.Resource{
+		Id:         resource.ID.String(),
+		Name:       resource.Name,
+		Kind:       string(resource.Kind),
+		Capacity:   int64(resource.Capacity),
+		Attributes: resource.Attributes,
+		CreatedAt:  timestamppb.New(resource.CreatedAt),
+	}
+}
+
+// resourceAvailabilityToProto converts the internal resource availability to the protobuf one.
+func resourceAvailabilityToProto(availability *types.ResourceAvailability) *pb.ResourceAvailability {
+	bookings := make([]*pb.ResourceBooking, len(availability.Bookings))
+	for i, booking := range availability.Bookings {
+		bookings[i] = &pb.ResourceBooking{
+			EventId: booking.EventID.String(),
+			Start:   timestamppb.New(booking.Start),
+			End:     timestamppb.New(booking.End),
+		}
+	}
+	return &pb.ResourceAvailability{
+		Resource:  resourceToProto(availability.Resource),
+		Available: availability.Available(),
+		Bookings:  bookings,
 	}
 }
 
 func setDesctription(description string) *string {
-	desc := description
-	if desc != "" {
-		return &desc
+	return setString(description)
+}
+
+// setString returns nil for empty strings and a pointer to the copy of the value otherwise.
+func setString(value string) *string {
+	res := value
+	if res != "" {
+		return &res
 	}
 	return nil
 }
@@ -8,3 +8,11 @@ var expectedFields = map[string]any{
 	"port":             "",
 	"shutdown_timeout": time.Duration(0),
 }
+
+// optionalFields is a map of optional configuration fields and their default values.
+var optionalFields = map[string]any{
+	"rate_limit":       float64(0),
+	"rate_limit_burst": int(0),
+	"admin_token":      "",
+	"trusted_proxies":  []string(nil),
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1"                //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"                      //nolint:depguard,nolintlint
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors"     //nolint:depguard,nolintlint
 	calendarGRPC "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc" //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc/mocks"        //nolint:depguard,nolintlint
@@ -16,9 +17,11 @@ import (
 	"github.com/stretchr/testify/mock"                                                         //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/require"                                                      //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/suite"                                                        //nolint:depguard,nolintlint
+	"google.golang.org/genproto/googleapis/rpc/errdetails"                                     //nolint:depguard,nolintlint
 	"google.golang.org/grpc"                                                                   //nolint:depguard,nolintlint
 	"google.golang.org/grpc/codes"                                                             //nolint:depguard,nolintlint
 	"google.golang.org/grpc/credentials/insecure"                                              //nolint:depguard,nolintlint
+	"google.golang.org/grpc/metadata"                                                          //nolint:depguard,nolintlint
 	"google.golang.org/grpc/status"                                                            //nolint:depguard,nolintlint
 	"google.golang.org/grpc/test/bufconn"                                                      //nolint:depguard,nolintlint
 	"google.golang.org/protobuf/types/known/durationpb"                                        //nolint:depguard,nolintlint
@@ -402,6 +405,90 @@ func (s *ServerSuite) TestCreateEvent() {
 	}
 }
 
+func (s *ServerSuite) TestDateBusyDetails() {
+	conflicts := []string{uuid.New().String(), uuid.New().String()}
+	req := &pb.CreateEventRequest{
+		Data: &pb.EventData{
+			Title:      "Test Event",
+			Datetime:   timestamppb.Now(),
+			Duration:   durationpb.New(time.Hour),
+			UserId:     basicUserID,
+			BusyStatus: string(types.BusyStatusTentative),
+		},
+		OverlapPolicy: func() *string { p := string(types.OverlapPolicyReject); return &p }(),
+	}
+
+	s.app.On("CreateEvent", mock.Anything, mock.Anything).
+		Return(nil, projectErrors.NewDateBusyError(conflicts...)).Once()
+	s.loggerMocks(s.T())
+
+	resp, err := s.client.CreateEvent(context.Background(), req)
+	s.Require().Error(err, "expected error, got nil")
+	s.Require().Nil(resp, "expected nil response on error, got non-nil")
+
+	st := status.Convert(err)
+	s.Require().Equal(codes.AlreadyExists, st.Code(), "unexpected error code")
+
+	ids := make([]string, 0, len(conflicts))
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ResourceInfo); ok {
+			ids = append(ids, info.GetResourceName())
+		}
+	}
+	s.Require().Equal(conflicts, ids, "conflicting event IDs mismatch")
+}
+
+func (s *ServerSuite) TestCreateEventIdempotencyKey() {
+	reqKey := "request-key"
+	data := &pb.EventData{
+		Title:    "Test Event",
+		Datetime: timestamppb.Now(),
+		Duration: durationpb.New(time.Hour),
+		UserId:   basicUserID,
+	}
+	event := &types.Event{ID: uuid.New(), EventData: types.EventData{Title: data.Title, UserID: basicUserID}}
+
+	testCases := []struct {
+		name        string
+		req         *pb.CreateEventRequest
+		md          metadata.MD
+		expectedKey string
+	}{
+		{"no key", &pb.CreateEventRequest{Data: data}, nil, ""},
+		{"request field", &pb.CreateEventRequest{Data: data, IdempotencyKey: &reqKey}, nil, reqKey},
+		{"metadata", &pb.CreateEventRequest{Data: data}, metadata.Pairs("idempotency-key", "md-key"), "md-key"},
+		{
+			"request field over metadata",
+			&pb.CreateEventRequest{Data: data, IdempotencyKey: &reqKey},
+			metadata.Pairs("idempotency-key", "md-key"),
+			reqKey,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.app.On("CreateEvent", mock.Anything, mock.MatchedBy(func(input *dto.CreateEventInput) bool {
+				return input.IdempotencyKey == tC.expectedKey
+			})).Return(event, nil).Once()
+			s.loggerMocks(s.T())
+
+			ctx := metadata.NewOutgoingContext(context.Background(), tC.md)
+			resp, err := s.client.CreateEvent(ctx, tC.req)
+			s.Require().NoError(err, "unexpected error on CreateEvent")
+			s.Require().Equal(event.ID.String(), resp.Event.Id, "event ID mismatch")
+		})
+	}
+
+	s.Run("conflict", func() {
+		s.app.On("CreateEvent", mock.Anything, mock.Anything).
+			Return(nil, projectErrors.ErrIdempotencyConflict).Once()
+		s.loggerMocks(s.T())
+
+		_, err := s.client.CreateEvent(context.Background(), &pb.CreateEventRequest{Data: data, IdempotencyKey: &reqKey})
+		s.Require().Equal(codes.Aborted, status.Code(err), "unexpected error code")
+	})
+}
+
 //nolint:funlen
 func (s *ServerSuite) TestUpdateEvent() {
 	id := uuid.New()
@@ -686,6 +773,374 @@ func (s *ServerSuite) TestDeleteEvent() {
 	}
 }
 
+func (s *ServerSuite) TestCleanupEvents() {
+	before := time.Date(2030, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	s.Run("success", func() {
+		s.app.On("CleanupEvents", mock.Anything, before).Return(int64(5), nil).Once()
+		resp, err := s.client.CleanupEvents(context.Background(),
+			&pb.CleanupEventsRequest{Before: timestamppb.New(before)})
+		s.Require().NoError(err, "unexpected error on CleanupEvents")
+		s.Require().Equal(int64(5), resp.Deleted, "unexpected number of deleted events")
+	})
+
+	s.Run("default border", func() {
+		s.app.On("CleanupEvents", mock.Anything, time.Time{}).Return(int64(0), nil).Once()
+		resp, err := s.client.CleanupEvents(context.Background(), &pb.CleanupEventsRequest{})
+		s.Require().NoError(err, "unexpected error on CleanupEvents")
+		s.Require().Zero(resp.Deleted, "unexpected number of deleted events")
+	})
+
+	s.Run("internal error", func() {
+		s.app.On("CleanupEvents", mock.Anything, mock.Anything).Return(int64(0), projectErrors.ErrInconsistentState).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.CleanupEvents(context.Background(), &pb.CleanupEventsRequest{})
+		s.Require().Equal(codes.Internal, status.Code(err), "unexpected error code")
+	})
+}
+
+func (s *ServerSuite) TestGetSchedulerStatus() {
+	next := time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+
+	s.Run("waiting events", func() {
+		s.app.On("GetSchedulerStatus", mock.Anything).
+			Return(&dto.SchedulerStatus{DueNotifications: 2, NextReminder: &next}, nil).Once()
+		resp, err := s.client.GetSchedulerStatus(context.Background(), &pb.GetSchedulerStatusRequest{})
+		s.Require().NoError(err, "unexpected error on GetSchedulerStatus")
+		s.Require().Equal(int64(2), resp.DueNotifications, "unexpected number of due notifications")
+		s.Require().Equal(next, resp.NextReminder.AsTime(), "unexpected next reminder")
+	})
+
+	s.Run("no waiting events", func() {
+		s.app.On("GetSchedulerStatus", mock.Anything).Return(&dto.SchedulerStatus{}, nil).Once()
+		resp, err := s.client.GetSchedulerStatus(context.Background(), &pb.GetSchedulerStatusRequest{})
+		s.Require().NoError(err, "unexpected error on GetSchedulerStatus")
+		s.Require().Zero(resp.DueNotifications, "unexpected number of due notifications")
+		s.Require().Nil(resp.NextReminder, "unexpected next reminder")
+	})
+}
+
+func (s *ServerSuite) TestGetCacheStats() {
+	s.Run("enabled cache", func() {
+		s.app.On("GetCacheStats", mock.Anything).Return(&dto.CacheStats{
+			Enabled: true,
+			Hits:    3,
+			Misses:  2,
+			Events:  1,
+			Periods: 1,
+			Size:    100,
+			TTL:     time.Minute,
+		}, nil).Once()
+		resp, err := s.client.GetCacheStats(context.Background(), &pb.GetCacheStatsRequest{})
+		s.Require().NoError(err, "unexpected error on GetCacheStats")
+		s.Require().True(resp.Enabled, "cache is not enabled")
+		s.Require().Equal(int64(3), resp.Hits, "unexpected hits")
+		s.Require().Equal(int64(2), resp.Misses, "unexpected misses")
+		s.Require().Equal(int64(100), resp.Size, "unexpected size")
+		s.Require().Equal(time.Minute, resp.Ttl.AsDuration(), "unexpected TTL")
+	})
+
+	s.Run("disabled cache", func() {
+		s.app.On("GetCacheStats", mock.Anything).Return(&dto.CacheStats{}, nil).Once()
+		resp, err := s.client.GetCacheStats(context.Background(), &pb.GetCacheStatsRequest{})
+		s.Require().NoError(err, "unexpected error on GetCacheStats")
+		s.Require().False(resp.Enabled, "cache is enabled")
+		s.Require().Nil(resp.Ttl, "unexpected TTL")
+	})
+}
+
+func (s *ServerSuite) TestGetMissedReminders() {
+	outageStart := time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+	outageEnd := outageStart.Add(time.Hour)
+	input := &dto.MissedRemindersInput{
+		OutageStart:   outageStart,
+		OutageEnd:     outageEnd,
+		CatchUpPolicy: "grace",
+		CatchUpGrace:  15 * time.Minute,
+	}
+	req := &pb.GetMissedRemindersRequest{
+		OutageStart:   timestamppb.New(outageStart),
+		OutageEnd:     timestamppb.New(outageEnd),
+		CatchUpPolicy: "grace",
+		CatchUpGrace:  durationpb.New(15 * time.Minute),
+	}
+
+	s.Run("success", func() {
+		s.app.On("GetMissedReminders", mock.Anything, input).Return([]*dto.MissedReminder{{
+			EventID:  uuid.New().String(),
+			Title:    "Meeting",
+			UserID:   basicUserID,
+			Start:    outageStart.Add(30 * time.Minute),
+			RemindAt: outageStart,
+			Delay:    time.Hour,
+			Notified: true,
+			Outcome:  dto.MissedReminderSkipped,
+		}}, nil).Once()
+		resp, err := s.client.GetMissedReminders(context.Background(), req)
+		s.Require().NoError(err, "unexpected error on GetMissedReminders")
+		s.Require().Len(resp.Reminders, 1, "unexpected number of reminders")
+		s.Require().Equal(outageStart, resp.Reminders[0].RemindAt.AsTime(), "unexpected reminder time")
+		s.Require().Equal(time.Hour, resp.Reminders[0].Delay.AsDuration(), "unexpected delay")
+		s.Require().Equal("skipped", resp.Reminders[0].Outcome, "unexpected outcome")
+	})
+
+	s.Run("invalid window", func() {
+		s.app.On("GetMissedReminders", mock.Anything, input).Return(nil, projectErrors.ErrInvalidFieldData).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.GetMissedReminders(context.Background(), req)
+		s.Require().Equal(codes.InvalidArgument, status.Code(err), "unexpected error code")
+	})
+}
+
+func (s *ServerSuite) TestPreviewNotification() {
+	input := &dto.PreviewNotificationInput{
+		EventID:  uuid.New().String(),
+		Channel:  "email",
+		Locale:   "ru",
+		TimeZone: "Europe/Moscow",
+	}
+	req := &pb.PreviewNotificationRequest{
+		EventId:  input.EventID,
+		Channel:  input.Channel,
+		Locale:   input.Locale,
+		TimeZone: input.TimeZone,
+	}
+
+	s.Run("success", func() {
+		s.app.On("PreviewNotification", mock.Anything, input).Return(&types.Message{
+			Channel:  "email",
+			Locale:   "ru",
+			TimeZone: "Europe/Moscow",
+			Format:   types.TemplateHTML,
+			Body:     "<p>Meeting</p>",
+		}, nil).Once()
+		resp, err := s.client.PreviewNotification(context.Background(), req)
+		s.Require().NoError(err, "unexpected error on PreviewNotification")
+		s.Require().Equal("html", resp.Format, "unexpected format")
+		s.Require().Equal("<p>Meeting</p>", resp.Body, "unexpected body")
+		s.Require().Equal("Europe/Moscow", resp.TimeZone, "unexpected time zone")
+	})
+
+	testCases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"template not found", projectErrors.ErrTemplateNotFound, codes.NotFound},
+		{"invalid template", projectErrors.ErrInvalidTemplate, codes.FailedPrecondition},
+		{"invalid time zone", projectErrors.ErrInvalidFieldData, codes.InvalidArgument},
+		{"event not found", projectErrors.ErrEventNotFound, codes.NotFound},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.app.On("PreviewNotification", mock.Anything, input).Return(nil, tC.err).Once()
+			s.loggerMocks(s.T())
+			_, err := s.client.PreviewNotification(context.Background(), req)
+			s.Require().Equal(tC.code, status.Code(err), "unexpected error code")
+		})
+	}
+}
+
+func (s *ServerSuite) TestDigestSettings() {
+	settings := &types.DigestSettings{
+		UserID: basicUserID, Enabled: true, Time: "08:30", TimeZone: "Europe/Moscow", SentDate: "2030-01-16",
+	}
+	input := &dto.DigestSettingsInput{UserID: basicUserID, Enabled: true, Time: "08:30", TimeZone: "Europe/Moscow"}
+	req := &pb.SetDigestSettingsRequest{
+		UserId:   basicUserID,
+		Settings: &pb.DigestSettings{Enabled: true, Time: "08:30", TimeZone: "Europe/Moscow"},
+	}
+
+	s.Run("set", func() {
+		s.app.On("SetDigestSettings", mock.Anything, input).Return(settings, nil).Once()
+		resp, err := s.client.SetDigestSettings(context.Background(), req)
+		s.Require().NoError(err, "unexpected error on SetDigestSettings")
+		s.Require().True(resp.Settings.Enabled, "unexpected enabled flag")
+		s.Require().Equal("08:30", resp.Settings.Time, "unexpected time")
+		s.Require().Equal("Europe/Moscow", resp.Settings.TimeZone, "unexpected time zone")
+		s.Require().Equal("2030-01-16", resp.Settings.SentDate, "unexpected sent date")
+	})
+
+	s.Run("get", func() {
+		s.app.On("GetDigestSettings", mock.Anything, basicUserID).Return(settings, nil).Once()
+		resp, err := s.client.GetDigestSettings(context.Background(), &pb.GetDigestSettingsRequest{UserId: basicUserID})
+		s.Require().NoError(err, "unexpected error on GetDigestSettings")
+		s.Require().True(resp.Settings.Enabled, "unexpected enabled flag")
+		s.Require().Equal("08:30", resp.Settings.Time, "unexpected time")
+		s.Require().Equal("Europe/Moscow", resp.Settings.TimeZone, "unexpected time zone")
+		s.Require().Equal("2030-01-16", resp.Settings.SentDate, "unexpected sent date")
+	})
+
+	s.Run("not found", func() {
+		s.app.On("GetDigestSettings", mock.Anything, basicUserID).
+			Return(nil, projectErrors.ErrDigestSettingsNotFound).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.GetDigestSettings(context.Background(), &pb.GetDigestSettingsRequest{UserId: basicUserID})
+		s.Require().Equal(codes.NotFound, status.Code(err), "unexpected error code")
+	})
+
+	s.Run("invalid settings", func() {
+		s.app.On("SetDigestSettings", mock.Anything, input).Return(nil, projectErrors.ErrInvalidFieldData).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.SetDigestSettings(context.Background(), req)
+		s.Require().Equal(codes.InvalidArgument, status.Code(err), "unexpected error code")
+	})
+}
+
+func (s *ServerSuite) TestWebhooks() {
+	webhook := &types.Webhook{
+		ID:         uuid.New(),
+		UserID:     basicUserID,
+		URL:        "https://example.com/hook",
+		Secret:     "secret",
+		EventTypes: types.Tags{"created", "reminder"},
+		Enabled:    true,
+		CreatedAt:  time.Date(2030, 1, 16, 12, 0, 0, 0, time.UTC),
+	}
+	input := &dto.CreateWebhookInput{
+		UserID: basicUserID, URL: webhook.URL, EventTypes: []string{"created", "reminder"},
+	}
+
+	s.Run("create", func() {
+		s.app.On("CreateWebhook", mock.Anything, input).Return(webhook, nil).Once()
+		resp, err := s.client.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{
+			UserId: basicUserID, Url: webhook.URL, EventTypes: []string{"created", "reminder"},
+		})
+		s.Require().NoError(err, "unexpected error on CreateWebhook")
+		s.Require().Equal(webhook.ID.String(), resp.Webhook.Id, "unexpected id")
+		s.Require().Equal("secret", resp.Webhook.Secret, "secret is not returned on creation")
+		s.Require().Equal([]string{"created", "reminder"}, resp.Webhook.EventTypes, "unexpected event types")
+		s.Require().True(resp.Webhook.CreatedAt.AsTime().Equal(webhook.CreatedAt), "unexpected creation time")
+	})
+
+	s.Run("list", func() {
+		s.app.On("ListWebhooks", mock.Anything, basicUserID).Return([]*types.Webhook{webhook}, nil).Once()
+		resp, err := s.client.ListWebhooks(context.Background(), &pb.ListWebhooksRequest{UserId: basicUserID})
+		s.Require().NoError(err, "unexpected error on ListWebhooks")
+		s.Require().Len(resp.Webhooks, 1, "unexpected number of webhooks")
+		s.Require().Equal(webhook.URL, resp.Webhooks[0].Url, "unexpected url")
+		s.Require().Empty(resp.Webhooks[0].Secret, "secret is returned on listing")
+	})
+
+	s.Run("enable", func() {
+		s.app.On("EnableWebhook", mock.Anything, webhook.ID.String()).Return(webhook, nil).Once()
+		resp, err := s.client.EnableWebhook(context.Background(), &pb.EnableWebhookRequest{Id: webhook.ID.String()})
+		s.Require().NoError(err, "unexpected error on EnableWebhook")
+		s.Require().True(resp.Webhook.Enabled, "unexpected enabled flag")
+		s.Require().Empty(resp.Webhook.Secret, "secret is returned on enabling")
+	})
+
+	s.Run("deliveries", func() {
+		delivery := &types.WebhookDelivery{
+			DeliveryID: uuid.New(),
+			Attempt:    2,
+			WebhookID:  webhook.ID,
+			EventType:  "created",
+			EventID:    uuid.New(),
+			StatusCode: 500,
+			Error:      "unexpected status code: 500",
+			CreatedAt:  webhook.CreatedAt,
+		}
+		s.app.On("ListWebhookDeliveries", mock.Anything, webhook.ID.String(), 10).
+			Return([]*types.WebhookDelivery{delivery}, nil).Once()
+		resp, err := s.client.ListWebhookDeliveries(context.Background(), &pb.ListWebhookDeliveriesRequest{
+			Id: webhook.ID.String(), Limit: 10,
+		})
+		s.Require().NoError(err, "unexpected error on ListWebhookDeliveries")
+		s.Require().Len(resp.Deliveries, 1, "unexpected number of deliveries")
+		s.Require().Equal(int64(2), resp.Deliveries[0].Attempt, "unexpected attempt")
+		s.Require().Equal(int64(500), resp.Deliveries[0].StatusCode, "unexpected status code")
+		s.Require().Equal(delivery.Error, resp.Deliveries[0].Error, "unexpected error")
+		s.Require().False(resp.Deliveries[0].Success, "unexpected success flag")
+	})
+
+	s.Run("not found", func() {
+		s.app.On("DeleteWebhook", mock.Anything, webhook.ID.String()).Return(projectErrors.ErrWebhookNotFound).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.DeleteWebhook(context.Background(), &pb.DeleteWebhookRequest{Id: webhook.ID.String()})
+		s.Require().Equal(codes.NotFound, status.Code(err), "unexpected error code")
+	})
+
+	s.Run("invalid url", func() {
+		s.app.On("CreateWebhook", mock.Anything, &dto.CreateWebhookInput{URL: "ftp://example.com"}).
+			Return(nil, projectErrors.ErrInvalidFieldData).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{Url: "ftp://example.com"})
+		s.Require().Equal(codes.InvalidArgument, status.Code(err), "unexpected error code")
+	})
+}
+
+func (s *ServerSuite) TestResources() {
+	room := &types.Resource{
+		ID:         uuid.New(),
+		Name:       "Blue room",
+		Kind:       types.ResourceKindRoom,
+		Capacity:   8,
+		Attributes: types.Tags{"projector"},
+		CreatedAt:  time.Date(2030, 1, 16, 12, 0, 0, 0, time.UTC),
+	}
+	start := time.Date(2030, 1, 17, 9, 0, 0, 0, time.UTC)
+
+	s.Run("create", func() {
+		input := &dto.CreateResourceInput{Name: room.Name, Capacity: 8, Attributes: []string{"projector"}}
+		s.app.On("CreateResource", mock.Anything, input).Return(room, nil).Once()
+		resp, err := s.client.CreateResource(context.Background(), &pb.CreateResourceRequest{
+			Name: room.Name, Capacity: 8, Attributes: []string{"projector"},
+		})
+		s.Require().NoError(err, "unexpected error on CreateResource")
+		s.Require().Equal(room.ID.String(), resp.Resource.Id, "unexpected id")
+		s.Require().Equal("room", resp.Resource.Kind, "unexpected kind")
+		s.Require().Equal(int64(8), resp.Resource.Capacity, "unexpected capacity")
+		s.Require().Equal([]string{"projector"}, resp.Resource.Attributes, "unexpected attributes")
+	})
+
+	s.Run("list", func() {
+		s.app.On("ListResources", mock.Anything).Return([]*types.Resource{room}, nil).Once()
+		resp, err := s.client.ListResources(context.Background(), &pb.ListResourcesRequest{})
+		s.Require().NoError(err, "unexpected error on ListResources")
+		s.Require().Len(resp.Resources, 1, "unexpected number of resources")
+		s.Require().Equal(room.Name, resp.Resources[0].Name, "unexpected name")
+	})
+
+	s.Run("availability", func() {
+		eventID := uuid.New()
+		input := &dto.ResourceAvailabilityInput{
+			DateStart: start, DateEnd: start.Add(types.Day), Kind: "room", MinCapacity: 4,
+		}
+		s.app.On("GetResourceAvailability", mock.Anything, input).Return([]*types.ResourceAvailability{{
+			Resource: room,
+			Bookings: []types.ResourceBooking{{EventID: eventID, Start: start, End: start.Add(time.Hour)}},
+		}}, nil).Once()
+		resp, err := s.client.GetResourceAvailability(context.Background(), &pb.GetResourceAvailabilityRequest{
+			DateStart:   timestamppb.New(start),
+			DateEnd:     timestamppb.New(start.Add(types.Day)),
+			Kind:        "room",
+			MinCapacity: 4,
+		})
+		s.Require().NoError(err, "unexpected error on GetResourceAvailability")
+		s.Require().Len(resp.Availability, 1, "unexpected number of resources")
+		s.Require().False(resp.Availability[0].Available, "booked resource is available")
+		s.Require().Len(resp.Availability[0].Bookings, 1, "unexpected number of bookings")
+		s.Require().Equal(eventID.String(), resp.Availability[0].Bookings[0].EventId, "unexpected event id")
+		s.Require().True(resp.Availability[0].Bookings[0].End.AsTime().Equal(start.Add(time.Hour)),
+			"unexpected booking end")
+	})
+
+	s.Run("in use", func() {
+		s.app.On("DeleteResource", mock.Anything, room.ID.String()).Return(projectErrors.ErrResourceInUse).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.DeleteResource(context.Background(), &pb.DeleteResourceRequest{Id: room.ID.String()})
+		s.Require().Equal(codes.FailedPrecondition, status.Code(err), "unexpected error code")
+	})
+
+	s.Run("not found", func() {
+		s.app.On("DeleteResource", mock.Anything, room.ID.String()).Return(projectErrors.ErrResourceNotFound).Once()
+		s.loggerMocks(s.T())
+		_, err := s.client.DeleteResource(context.Background(), &pb.DeleteResourceRequest{Id: room.ID.String()})
+		s.Require().Equal(codes.NotFound, status.Code(err), "unexpected error code")
+	})
+}
+
 //nolint:funlen
 func (s *ServerSuite) TestGetAllUserEvents() {
 	userID := basicUserID
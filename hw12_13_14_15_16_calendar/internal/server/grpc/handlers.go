@@ -8,6 +8,7 @@ import (
 
 	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"       //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/types/known/durationpb"                         //nolint:depguard,nolintlint
 	"google.golang.org/protobuf/types/known/timestamppb"                        //nolint:depguard,nolintlint
 )
 
@@ -19,12 +20,22 @@ func (s *Server) CreateEvent(ctx context.Context, event *pb.CreateEventRequest)
 		duration = *reqDuration
 	}
 	obj := dto.CreateEventInput{
-		Title:       event.Data.Title,
-		Datetime:    setTime(event.Data.Datetime),
-		Duration:    duration,
-		Description: setDesctription(event.Data.Description),
-		RemindIn:    setDuration(event.Data.RemindIn),
-		UserID:      event.Data.UserId,
+		Title:         event.Data.Title,
+		Datetime:      setTime(event.Data.Datetime),
+		Duration:      duration,
+		Description:   setDesctription(event.Data.Description),
+		RemindIn:      setDuration(event.Data.RemindIn),
+		UserID:        event.Data.UserId,
+		BusyStatus:    setString(event.Data.BusyStatus),
+		OverlapPolicy: event.OverlapPolicy,
+		AllDay:        event.Data.AllDay,
+		Tags:          event.Data.Tags,
+		Resources:     event.Data.Resources,
+		Color:         setString(event.Data.Color),
+		Location:      setString(event.Data.Location),
+		URL:           setString(event.Data.Url),
+
+		IdempotencyKey: idempotencyKey(ctx, event.IdempotencyKey),
 	}
 
 	res, err := s.a.CreateEvent(ctx, &obj)
@@ -47,13 +58,21 @@ func (s *Server) UpdateEvent(ctx context.Context, data *pb.UpdateEventRequest) (
 	datetime := setTime(data.Data.Datetime)
 	reqDuration := setDuration(data.Data.Duration)
 	obj := dto.UpdateEventInput{
-		ID:          id,
-		Title:       &data.Data.Title,
-		Datetime:    &datetime,
-		Duration:    reqDuration,
-		Description: setDesctription(data.Data.Description),
-		RemindIn:    setDuration(data.Data.RemindIn),
-		UserID:      &data.Data.UserId,
+		ID:            id,
+		Title:         &data.Data.Title,
+		Datetime:      &datetime,
+		Duration:      reqDuration,
+		Description:   setDesctription(data.Data.Description),
+		RemindIn:      setDuration(data.Data.RemindIn),
+		UserID:        &data.Data.UserId,
+		BusyStatus:    setString(data.Data.BusyStatus),
+		OverlapPolicy: data.OverlapPolicy,
+		AllDay:        &data.Data.AllDay,
+		Tags:          data.Data.Tags,
+		Resources:     data.Data.Resources,
+		Color:         setString(data.Data.Color),
+		Location:      setString(data.Data.Location),
+		URL:           setString(data.Data.Url),
 	}
 
 	res, err := s.a.UpdateEvent(ctx, &obj)
@@ -118,12 +137,16 @@ func (s *Server) getEventsByPeriod(
 	ctx context.Context,
 	date *timestamppb.Timestamp,
 	userID *string,
+	tags []string,
+	location *string,
 	period dto.Period,
 ) ([]*pb.Event, error) {
 	obj := dto.DateFilterInput{
-		Date:   setTime(date),
-		UserID: userID,
-		Period: period,
+		Date:     setTime(date),
+		UserID:   userID,
+		Period:   period,
+		Tags:     tags,
+		Location: location,
 	}
 
 	res, err := s.a.ListEvents(ctx, &obj)
@@ -139,7 +162,7 @@ func (s *Server) GetEventsForDay(
 	ctx context.Context,
 	data *pb.GetEventsForDayRequest,
 ) (*pb.GetEventsForDayResponse, error) {
-	events, err := s.getEventsByPeriod(ctx, data.Date, data.UserId, dto.Day)
+	events, err := s.getEventsByPeriod(ctx, data.Date, data.UserId, data.Tags, data.Location, dto.Day)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +177,7 @@ func (s *Server) GetEventsForWeek(
 	ctx context.Context,
 	data *pb.GetEventsForWeekRequest,
 ) (*pb.GetEventsForWeekResponse, error) {
-	events, err := s.getEventsByPeriod(ctx, data.Date, data.UserId, dto.Week)
+	events, err := s.getEventsByPeriod(ctx, data.Date, data.UserId, data.Tags, data.Location, dto.Week)
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +192,7 @@ func (s *Server) GetEventsForMonth(
 	ctx context.Context,
 	data *pb.GetEventsForMonthRequest,
 ) (*pb.GetEventsForMonthResponse, error) {
-	events, err := s.getEventsByPeriod(ctx, data.Date, data.UserId, dto.Month)
+	events, err := s.getEventsByPeriod(ctx, data.Date, data.UserId, data.Tags, data.Location, dto.Month)
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +211,8 @@ func (s *Server) GetEventsForPeriod(
 		DateStart: setTime(data.StartDate),
 		DateEnd:   setTime(data.EndDate),
 		UserID:    data.UserId,
+		Tags:      data.Tags,
+		Location:  data.Location,
 	}
 
 	res, err := s.a.GetEventsForPeriod(ctx, &obj)
@@ -199,3 +224,281 @@ func (s *Server) GetEventsForPeriod(
 		Events: convertEventsToPB(res),
 	}, nil
 }
+
+// CleanupEvents tries to delete the events starting before the given time from the storage.
+func (s *Server) CleanupEvents(ctx context.Context, data *pb.CleanupEventsRequest) (*pb.CleanupEventsResponse, error) {
+	deleted, err := s.a.CleanupEvents(ctx, setTime(data.Before))
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.CleanupEventsResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+// GetSchedulerStatus tries to get the state of the notification queue from the storage.
+func (s *Server) GetSchedulerStatus(
+	ctx context.Context,
+	_ *pb.GetSchedulerStatusRequest,
+) (*pb.GetSchedulerStatusResponse, error) {
+	res, err := s.a.GetSchedulerStatus(ctx)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	resp := &pb.GetSchedulerStatusResponse{
+		DueNotifications: res.DueNotifications,
+	}
+	if res.NextReminder != nil {
+		resp.NextReminder = timestamppb.New(*res.NextReminder)
+	}
+	return resp, nil
+}
+
+// GetCacheStats tries to get the state of the storage cache.
+func (s *Server) GetCacheStats(ctx context.Context, _ *pb.GetCacheStatsRequest) (*pb.GetCacheStatsResponse, error) {
+	res, err := s.a.GetCacheStats(ctx)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+	if !res.Enabled {
+		return &pb.GetCacheStatsResponse{}, nil
+	}
+
+	return &pb.GetCacheStatsResponse{
+		Enabled: true,
+		Hits:    res.Hits,
+		Misses:  res.Misses,
+		Events:  int64(res.Events),
+		Periods: int64(res.Periods),
+		Size:    int64(res.Size),
+		Ttl:     durationpb.New(res.TTL),
+	}, nil
+}
+
+// PreviewNotification tries to render the reminder of the event for the given channel without sending it.
+func (s *Server) PreviewNotification(
+	ctx context.Context,
+	data *pb.PreviewNotificationRequest,
+) (*pb.PreviewNotificationResponse, error) {
+	res, err := s.a.PreviewNotification(ctx, &dto.PreviewNotificationInput{
+		EventID:  data.EventId,
+		Channel:  data.Channel,
+		Locale:   data.Locale,
+		TimeZone: data.TimeZone,
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.PreviewNotificationResponse{
+		Channel:  res.Channel,
+		Locale:   res.Locale,
+		TimeZone: res.TimeZone,
+		Format:   string(res.Format),
+		Body:     res.Body,
+	}, nil
+}
+
+// GetMissedReminders reports the reminders, which were due during the scheduler outage.
+func (s *Server) GetMissedReminders(
+	ctx context.Context,
+	data *pb.GetMissedRemindersRequest,
+) (*pb.GetMissedRemindersResponse, error) {
+	input := &dto.MissedRemindersInput{
+		OutageStart:   setTime(data.OutageStart),
+		OutageEnd:     setTime(data.OutageEnd),
+		CatchUpPolicy: data.CatchUpPolicy,
+	}
+	if grace := setDuration(data.CatchUpGrace); grace != nil {
+		input.CatchUpGrace = *grace
+	}
+
+	res, err := s.a.GetMissedReminders(ctx, input)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.GetMissedRemindersResponse{Reminders: missedRemindersToProto(res)}, nil
+}
+
+// GetDigestSettings returns the daily digest settings of the user.
+func (s *Server) GetDigestSettings(
+	ctx context.Context,
+	data *pb.GetDigestSettingsRequest,
+) (*pb.GetDigestSettingsResponse, error) {
+	res, err := s.a.GetDigestSettings(ctx, data.UserId)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.GetDigestSettingsResponse{Settings: digestSettingsToProto(res)}, nil
+}
+
+// SetDigestSettings creates or replaces the daily digest settings of the user.
+func (s *Server) SetDigestSettings(
+	ctx context.Context,
+	data *pb.SetDigestSettingsRequest,
+) (*pb.SetDigestSettingsResponse, error) {
+	// Missing settings are reported by the app as the empty time.
+	res, err := s.a.SetDigestSettings(ctx, &dto.DigestSettingsInput{
+		UserID:   data.UserId,
+		Enabled:  data.Settings.GetEnabled(),
+		Time:     data.Settings.GetTime(),
+		TimeZone: data.Settings.GetTimeZone(),
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.SetDigestSettingsResponse{Settings: digestSettingsToProto(res)}, nil
+}
+
+// CreateWebhook registers the webhook subscription. The response includes the secret the deliveries are signed with.
+func (s *Server) CreateWebhook(
+	ctx context.Context,
+	data *pb.CreateWebhookRequest,
+) (*pb.CreateWebhookResponse, error) {
+	res, err := s.a.CreateWebhook(ctx, &dto.CreateWebhookInput{
+		UserID:     data.UserId,
+		URL:        data.Url,
+		Secret:     data.Secret,
+		EventTypes: data.EventTypes,
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.CreateWebhookResponse{Webhook: webhookToProto(res, true)}, nil
+}
+
+// ListWebhooks returns the webhook subscriptions without their secrets.
+func (s *Server) ListWebhooks(
+	ctx context.Context,
+	data *pb.ListWebhooksRequest,
+) (*pb.ListWebhooksResponse, error) {
+	res, err := s.a.ListWebhooks(ctx, data.UserId)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	webhooks := make([]*pb.Webhook, 0, len(res))
+	for _, webhook := range res {
+		webhooks = append(webhooks, webhookToProto(webhook, false))
+	}
+	return &pb.ListWebhooksResponse{Webhooks: webhooks}, nil
+}
+
+// DeleteWebhook deletes the webhook subscription along with its delivery log.
+func (s *Server) DeleteWebhook(
+	ctx context.Context,
+	data *pb.DeleteWebhookRequest,
+) (*pb.DeleteWebhookResponse, error) {
+	if err := s.a.DeleteWebhook(ctx, data.Id); err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+// EnableWebhook enables the webhook subscription, disabled after repeated failures.
+func (s *Server) EnableWebhook(
+	ctx context.Context,
+	data *pb.EnableWebhookRequest,
+) (*pb.EnableWebhookResponse, error) {
+	res, err := s.a.EnableWebhook(ctx, data.Id)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.EnableWebhookResponse{Webhook: webhookToProto(res, false)}, nil
+}
+
+// ListWebhookDeliveries returns the latest delivery attempts of the webhook subscription, newest first.
+func (s *Server) ListWebhookDeliveries(
+	ctx context.Context,
+	data *pb.ListWebhookDeliveriesRequest,
+) (*pb.ListWebhookDeliveriesResponse, error) {
+	res, err := s.a.ListWebhookDeliveries(ctx, data.Id, int(data.Limit))
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	deliveries := make([]*pb.WebhookDelivery, 0, len(res))
+	for _, delivery := range res {
+		deliveries = append(deliveries, webhookDeliveryToProto(delivery))
+	}
+	return &pb.ListWebhookDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+// CreateResource registers the bookable resource.
+func (s *Server) CreateResource(
+	ctx context.Context,
+	data *pb.CreateResourceRequest,
+) (*pb.CreateResourceResponse, error) {
+	res, err := s.a.CreateResource(ctx, &dto.CreateResourceInput{
+		Name:       data.Name,
+		Kind:       data.Kind,
+		Capacity:   int(data.Capacity),
+		Attributes: data.Attributes,
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.CreateResourceResponse{Resource: resourceToProto(res)}, nil
+}
+
+// ListResources returns the bookable resources sorted by the name.
+func (s *Server) ListResources(
+	ctx context.Context,
+	_ *pb.ListResourcesRequest,
+) (*pb.ListResourcesResponse, error) {
+	res, err := s.a.ListResources(ctx)
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	resources := make([]*pb.Resource, 0, len(res))
+	for _, resource := range res {
+		resources = append(resources, resourceToProto(resource))
+	}
+	return &pb.ListResourcesResponse{Resources: resources}, nil
+}
+
+// DeleteResource deletes the bookable resource, which is not booked by the upcoming events.
+func (s *Server) DeleteResource(
+	ctx context.Context,
+	data *pb.DeleteResourceRequest,
+) (*pb.DeleteResourceResponse, error) {
+	if err := s.a.DeleteResource(ctx, data.Id); err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	return &pb.DeleteResourceResponse{}, nil
+}
+
+// GetResourceAvailability returns the bookings of the matching resources within the requested period.
+func (s *Server) GetResourceAvailability(
+	ctx context.Context,
+	data *pb.GetResourceAvailabilityRequest,
+) (*pb.GetResourceAvailabilityResponse, error) {
+	res, err := s.a.GetResourceAvailability(ctx, &dto.ResourceAvailabilityInput{
+		DateStart:   setTime(data.DateStart),
+		DateEnd:     setTime(data.DateEnd),
+		ResourceIDs: data.ResourceIds,
+		Kind:        data.Kind,
+		MinCapacity: int(data.MinCapacity),
+		Attributes:  data.Attributes,
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, err).Err()
+	}
+
+	availability := make([]*pb.ResourceAvailability, 0, len(res))
+	for _, item := range res {
+		availability = append(availability, resourceAvailabilityToProto(item))
+	}
+	return &pb.GetResourceAvailabilityResponse{Availability: availability}, nil
+}
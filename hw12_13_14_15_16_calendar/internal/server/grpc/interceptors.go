@@ -2,18 +2,50 @@ package grpc
 
 import (
 	"context"
+	"crypto/subtle"
 	"log/slog"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"        //nolint:depguard,nolintlint
-	"google.golang.org/grpc"        //nolint:depguard,nolintlint
-	"google.golang.org/grpc/peer"   //nolint:depguard,nolintlint
-	"google.golang.org/grpc/status" //nolint:depguard,nolintlint
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ratelimit" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                    //nolint:depguard,nolintlint
+	"google.golang.org/genproto/googleapis/rpc/errdetails"                      //nolint:depguard,nolintlint
+	"google.golang.org/grpc"                                                    //nolint:depguard,nolintlint
+	"google.golang.org/grpc/codes"                                              //nolint:depguard,nolintlint
+	"google.golang.org/grpc/metadata"                                           //nolint:depguard,nolintlint
+	"google.golang.org/grpc/peer"                                               //nolint:depguard,nolintlint
+	"google.golang.org/grpc/status"                                             //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/types/known/durationpb"                         //nolint:depguard,nolintlint
 )
 
 // requestDataKey is a key for storing request data in the context.
 var requestDataKey = "grpc_request_id"
 
+// Metadata keys used by the rate limiting, the request deduplication and the admin authorization.
+const (
+	idempotencyMetadataKey  = "idempotency-key" // Idempotency key of the create request.
+	userIDMetadataKey       = "x-user-id"       // ID of the user, making the request.
+	forwardedForMetadataKey = "x-forwarded-for" // Client address, trusted only if set by the trusted proxies.
+	retryAfterMetadataKey   = "retry-after"     // Number of seconds until the next allowed request.
+	adminTokenMetadataKey   = "x-admin-token"   // Operator credential of the admin methods.
+)
+
+// adminMethods are the full names of the methods, available to the operators only.
+var adminMethods = map[string]struct{}{
+	pb.CalendarService_CleanupEvents_FullMethodName:      {},
+	pb.CalendarService_GetSchedulerStatus_FullMethodName: {},
+	pb.CalendarService_GetMissedReminders_FullMethodName: {},
+	// Webhook subscriptions deliver the events of any user to any URL, so they are managed by the operators.
+	pb.CalendarService_CreateWebhook_FullMethodName:         {},
+	pb.CalendarService_ListWebhooks_FullMethodName:          {},
+	pb.CalendarService_DeleteWebhook_FullMethodName:         {},
+	pb.CalendarService_EnableWebhook_FullMethodName:         {},
+	pb.CalendarService_ListWebhookDeliveries_FullMethodName: {},
+}
+
 // RequestData represents a data structure for storing request data.
 type RequestData struct {
 	ClientIP   string
@@ -75,3 +107,94 @@ func (s *Server) loggingUnaryInterceptor(
 
 	return resp, err
 }
+
+// rateLimitUnaryInterceptor rejects the requests exceeding the client rate limit with ResourceExhausted code.
+// The time until the next allowed request is sent in retry-after header and RetryInfo status details.
+func (s *Server) rateLimitUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if s.limiter == nil {
+		return handler(ctx, req)
+	}
+
+	ok, wait := s.limiter.Allow(s.clientKeys(ctx)...)
+	if ok {
+		return handler(ctx, req)
+	}
+
+	retryAfter := ratelimit.RetryAfterSeconds(wait)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterMetadataKey, strconv.Itoa(retryAfter)))
+
+	st := status.New(codes.ResourceExhausted, "Too many requests. Please, try again later")
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Duration(retryAfter) * time.Second),
+	})
+	if err != nil {
+		s.l.Error(ctx, "failed to add error details", slog.String("err", err.Error()))
+		return nil, st.Err()
+	}
+	return nil, detailed.Err()
+}
+
+// adminAuthUnaryInterceptor rejects the calls of the admin methods without the configured admin token.
+// A missing or a wrong token is rejected with Unauthenticated code. All admin calls are rejected
+// with PermissionDenied code if no token is configured. Other methods are passed as is.
+func (s *Server) adminAuthUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if _, ok := adminMethods[info.FullMethod]; !ok {
+		return handler(ctx, req)
+	}
+	if s.adminToken == "" {
+		return nil, status.Error(codes.PermissionDenied, "Admin methods are disabled")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(adminTokenMetadataKey)
+	if len(tokens) == 0 || subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(s.adminToken)) != 1 {
+		s.l.Warn(ctx, "admin method call rejected", slog.String("method", info.FullMethod))
+		return nil, status.Error(codes.Unauthenticated, "Invalid or missing admin token")
+	}
+	return handler(ctx, req)
+}
+
+// clientKeys returns the rate limiting keys of the client: its IP address and its user ID if provided.
+//
+// There is no authentication, so the user ID is trusted as-is. The IP address limit is applied anyway
+// and the address is taken from the connection, so the forged user IDs and addresses do not bypass the limit.
+func (s *Server) clientKeys(ctx context.Context) []string {
+	keys := make([]string, 0, 2)
+	if ip := s.clientIP(ctx); ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(userIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+		keys = append(keys, "user:"+ids[0])
+	}
+	if len(keys) == 0 {
+		return []string{"unknown"}
+	}
+	return keys
+}
+
+// clientIP returns the IP address of the peer. The address forwarded by the gateway is used instead,
+// if the peer is one of the trusted proxies. Returns an empty string if the address is unknown.
+func (s *Server) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	return s.trustedProxies.ClientIP(host, strings.Join(md.Get(forwardedForMetadataKey), ","))
+}
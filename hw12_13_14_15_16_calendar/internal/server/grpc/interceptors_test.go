@@ -0,0 +1,222 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1"            //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ratelimit"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/grpc/mocks"    //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+	"google.golang.org/genproto/googleapis/rpc/errdetails"                                 //nolint:depguard,nolintlint
+	"google.golang.org/grpc"                                                               //nolint:depguard,nolintlint
+	"google.golang.org/grpc/codes"                                                         //nolint:depguard,nolintlint
+	"google.golang.org/grpc/metadata"                                                      //nolint:depguard,nolintlint
+	"google.golang.org/grpc/peer"                                                          //nolint:depguard,nolintlint
+	"google.golang.org/grpc/status"                                                        //nolint:depguard,nolintlint
+)
+
+func TestNewServerRateLimitConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      map[string]any
+		withLimiter bool
+		expectedErr error
+	}{
+		{"disabled by default", map[string]any{}, false, nil},
+		{"enabled", map[string]any{"rate_limit": 10.0, "rate_limit_burst": 20}, true, nil},
+		{"rate limit not a float", map[string]any{"rate_limit": 10}, false, projectErrors.ErrCorruptedConfig},
+		{"burst not an int", map[string]any{"rate_limit": 10.0, "rate_limit_burst": "20"}, false,
+			projectErrors.ErrCorruptedConfig},
+		{"trusted proxies", map[string]any{"trusted_proxies": []string{"127.0.0.1", "10.0.0.0/8"}}, false, nil},
+		{"invalid trusted proxy", map[string]any{"trusted_proxies": []string{"gateway"}}, false,
+			projectErrors.ErrCorruptedConfig},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			config := map[string]any{
+				"host":             "localhost",
+				"port":             "0",
+				"shutdown_timeout": time.Second,
+			}
+			for k, v := range tC.config {
+				config[k] = v
+			}
+
+			s, err := NewServer(&mocks.Logger{}, &mocks.Application{}, config)
+			if tC.expectedErr != nil {
+				require.ErrorIs(t, err, tC.expectedErr, "unexpected error")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+			require.Equal(t, tC.withLimiter, s.limiter != nil, "unexpected limiter state")
+		})
+	}
+}
+
+func TestRateLimitUnaryInterceptor(t *testing.T) {
+	s, err := NewServer(&mocks.Logger{}, &mocks.Application{}, map[string]any{
+		"host":             "localhost",
+		"port":             "0",
+		"shutdown_timeout": time.Second,
+		"rate_limit":       0.5,
+		"rate_limit_burst": 2,
+	})
+	require.NoError(t, err, "error on server creation")
+
+	var calls int
+	handler := func(_ context.Context, _ any) (any, error) {
+		calls++
+		return "ok", nil
+	}
+	call := func(ctx context.Context) error {
+		_, err := s.rateLimitUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		return err
+	}
+
+	user1 := metadata.NewIncomingContext(context.Background(), metadata.Pairs(userIDMetadataKey, "user1"))
+	user2 := metadata.NewIncomingContext(context.Background(), metadata.Pairs(userIDMetadataKey, "user2"))
+
+	require.NoError(t, call(user1), "request within burst is rejected")
+	require.NoError(t, call(user1), "request within burst is rejected")
+
+	err = call(user1)
+	st, ok := status.FromError(err)
+	require.True(t, ok, "expected gRPC status error")
+	require.Equal(t, codes.ResourceExhausted, st.Code(), "unexpected status code")
+	require.Len(t, st.Details(), 1, "expected retry info details")
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok, "unexpected details type")
+	require.Equal(t, 2*time.Second, retryInfo.GetRetryDelay().AsDuration(), "unexpected retry delay")
+
+	require.NoError(t, call(user2), "request of another user is rejected")
+	require.Equal(t, 3, calls, "unexpected number of handler calls")
+
+	// Changing the user ID and the forwarded address does not bypass the limit of the client address.
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 5000},
+	})
+	forged := func(userID, addr string) context.Context {
+		return metadata.NewIncomingContext(peerCtx, metadata.Pairs(
+			userIDMetadataKey, userID,
+			forwardedForMetadataKey, addr,
+		))
+	}
+	require.NoError(t, call(forged("user3", "192.168.0.1")), "request within burst is rejected")
+	require.NoError(t, call(forged("user4", "192.168.0.2")), "request within burst is rejected")
+	require.Error(t, call(forged("user5", "192.168.0.3")), "request over the address burst is allowed")
+	require.Equal(t, 5, calls, "unexpected number of handler calls")
+}
+
+func TestClientKeys(t *testing.T) {
+	trusted, err := ratelimit.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err, "expected nil, got error")
+	s := &Server{trustedProxies: trusted}
+
+	newPeerCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 5000},
+		})
+	}
+	gatewayCtx, clientCtx := newPeerCtx("10.0.0.1"), newPeerCtx("203.0.113.7")
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected []string
+	}{
+		{"no client data", context.Background(), []string{"unknown"}},
+		{"peer address", clientCtx, []string{"ip:203.0.113.7"}},
+		{
+			"forwarded by trusted proxy",
+			metadata.NewIncomingContext(gatewayCtx, metadata.Pairs(forwardedForMetadataKey, "192.168.0.1, 10.0.0.2")),
+			[]string{"ip:192.168.0.1"},
+		},
+		{
+			"forwarded by untrusted peer",
+			metadata.NewIncomingContext(clientCtx, metadata.Pairs(forwardedForMetadataKey, "192.168.0.1")),
+			[]string{"ip:203.0.113.7"},
+		},
+		{
+			"user ID",
+			metadata.NewIncomingContext(gatewayCtx, metadata.Pairs(
+				userIDMetadataKey, "user1",
+				forwardedForMetadataKey, "192.168.0.1",
+			)),
+			[]string{"ip:192.168.0.1", "user:user1"},
+		},
+		{
+			"user ID without address",
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs(userIDMetadataKey, "user1")),
+			[]string{"user:user1"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, s.clientKeys(tC.ctx))
+		})
+	}
+}
+
+func TestAdminAuthUnaryInterceptor(t *testing.T) {
+	adminInfo := &grpc.UnaryServerInfo{FullMethod: pb.CalendarService_CleanupEvents_FullMethodName}
+	userInfo := &grpc.UnaryServerInfo{FullMethod: pb.CalendarService_CreateEvent_FullMethodName}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(adminTokenMetadataKey, token))
+	}
+
+	testCases := []struct {
+		name       string
+		adminToken string
+		ctx        context.Context
+		info       *grpc.UnaryServerInfo
+		expected   codes.Code
+	}{
+		{"valid token", "secret", withToken("secret"), adminInfo, codes.OK},
+		{"wrong token", "secret", withToken("secret2"), adminInfo, codes.Unauthenticated},
+		{"empty token", "secret", withToken(""), adminInfo, codes.Unauthenticated},
+		{"missing token", "secret", context.Background(), adminInfo, codes.Unauthenticated},
+		{"admin methods disabled", "", withToken(""), adminInfo, codes.PermissionDenied},
+		{"user method", "secret", context.Background(), userInfo, codes.OK},
+		{"user method with admin methods disabled", "", context.Background(), userInfo, codes.OK},
+		{"scheduler status", "secret", context.Background(),
+			&grpc.UnaryServerInfo{FullMethod: pb.CalendarService_GetSchedulerStatus_FullMethodName}, codes.Unauthenticated},
+		{"missed reminders", "secret", context.Background(),
+			&grpc.UnaryServerInfo{FullMethod: pb.CalendarService_GetMissedReminders_FullMethodName}, codes.Unauthenticated},
+		{"create webhook", "secret", context.Background(),
+			&grpc.UnaryServerInfo{FullMethod: pb.CalendarService_CreateWebhook_FullMethodName}, codes.Unauthenticated},
+		{"webhook deliveries", "", context.Background(),
+			&grpc.UnaryServerInfo{FullMethod: pb.CalendarService_ListWebhookDeliveries_FullMethodName}, codes.PermissionDenied},
+		{"list webhooks with token", "secret", withToken("secret"),
+			&grpc.UnaryServerInfo{FullMethod: pb.CalendarService_ListWebhooks_FullMethodName}, codes.OK},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			logger := &mocks.Logger{}
+			logger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+			s, err := NewServer(logger, &mocks.Application{}, map[string]any{
+				"host":             "localhost",
+				"port":             "0",
+				"shutdown_timeout": time.Second,
+				"admin_token":      tC.adminToken,
+			})
+			require.NoError(t, err, "error on server creation")
+
+			var called bool
+			handler := func(_ context.Context, _ any) (any, error) {
+				called = true
+				return "ok", nil
+			}
+			_, err = s.adminAuthUnaryInterceptor(tC.ctx, nil, tC.info, handler)
+			require.Equal(t, tC.expected, status.Code(err), "unexpected status code")
+			require.Equal(t, tC.expected == codes.OK, called, "unexpected handler call")
+		})
+	}
+}
@@ -5,6 +5,7 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"   //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
@@ -44,4 +45,53 @@ type Application interface {
 
 	// GetEventsForPeriod is trying to get all events for a given period from the storage.
 	GetEventsForPeriod(ctx context.Context, input *dto.DateRangeInput) ([]*types.Event, error)
+
+	// CleanupEvents is trying to delete the events starting before the given time from the storage.
+	CleanupEvents(ctx context.Context, before time.Time) (int64, error)
+
+	// GetSchedulerStatus is trying to get the state of the notification queue from the storage.
+	GetSchedulerStatus(ctx context.Context) (*dto.SchedulerStatus, error)
+
+	// GetMissedReminders is trying to report the reminders, which were due during the scheduler outage.
+	GetMissedReminders(ctx context.Context, input *dto.MissedRemindersInput) ([]*dto.MissedReminder, error)
+
+	// GetCacheStats returns the state and the hit/miss counters of the storage cache.
+	GetCacheStats(ctx context.Context) (*dto.CacheStats, error)
+
+	// PreviewNotification is trying to render the reminder of the event for the given channel without sending it.
+	PreviewNotification(ctx context.Context, input *dto.PreviewNotificationInput) (*types.Message, error)
+
+	// SetDigestSettings is trying to validate and store the daily digest settings of the user.
+	SetDigestSettings(ctx context.Context, input *dto.DigestSettingsInput) (*types.DigestSettings, error)
+
+	// GetDigestSettings is trying to get the daily digest settings of the user from the storage.
+	GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error)
+
+	// CreateWebhook is trying to validate and store the new webhook subscription.
+	CreateWebhook(ctx context.Context, input *dto.CreateWebhookInput) (*types.Webhook, error)
+
+	// ListWebhooks is trying to get the webhook subscriptions, optionally limited to the ones of the user.
+	ListWebhooks(ctx context.Context, userID string) ([]*types.Webhook, error)
+
+	// DeleteWebhook is trying to delete the webhook subscription with the given ID from the storage.
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// EnableWebhook is trying to enable the webhook subscription with the given ID, resetting its failures.
+	EnableWebhook(ctx context.Context, id string) (*types.Webhook, error)
+
+	// ListWebhookDeliveries is trying to get the latest delivery attempts of the webhook subscription.
+	ListWebhookDeliveries(ctx context.Context, id string, limit int) ([]*types.WebhookDelivery, error)
+
+	// CreateResource is trying to validate and store the new bookable resource.
+	CreateResource(ctx context.Context, input *dto.CreateResourceInput) (*types.Resource, error)
+
+	// ListResources is trying to get the bookable resources, sorted by the name.
+	ListResources(ctx context.Context) ([]*types.Resource, error)
+
+	// DeleteResource is trying to delete the bookable resource, which is not booked by the upcoming events.
+	DeleteResource(ctx context.Context, id string) error
+
+	// GetResourceAvailability is trying to get the bookings of the matching resources within the period.
+	GetResourceAvailability(ctx context.Context,
+		input *dto.ResourceAvailabilityInput) ([]*types.ResourceAvailability, error)
 }
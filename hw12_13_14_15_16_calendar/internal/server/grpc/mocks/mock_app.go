@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	dto "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/dto"
 
@@ -25,6 +26,63 @@ func (_m *Application) EXPECT() *Application_Expecter {
 	return &Application_Expecter{mock: &_m.Mock}
 }
 
+// CleanupEvents provides a mock function with given fields: ctx, before
+func (_m *Application) CleanupEvents(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanupEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_CleanupEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanupEvents'
+type Application_CleanupEvents_Call struct {
+	*mock.Call
+}
+
+// CleanupEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *Application_Expecter) CleanupEvents(ctx interface{}, before interface{}) *Application_CleanupEvents_Call {
+	return &Application_CleanupEvents_Call{Call: _e.mock.On("CleanupEvents", ctx, before)}
+}
+
+func (_c *Application_CleanupEvents_Call) Run(run func(ctx context.Context, before time.Time)) *Application_CleanupEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Application_CleanupEvents_Call) Return(_a0 int64, _a1 error) *Application_CleanupEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_CleanupEvents_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *Application_CleanupEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEvent provides a mock function with given fields: ctx, input
 func (_m *Application) CreateEvent(ctx context.Context, input *dto.CreateEventInput) (*types.Event, error) {
 	ret := _m.Called(ctx, input)
@@ -84,6 +142,124 @@ func (_c *Application_CreateEvent_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// CreateResource provides a mock function with given fields: ctx, input
+func (_m *Application) CreateResource(ctx context.Context, input *dto.CreateResourceInput) (*types.Resource, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateResource")
+	}
+
+	var r0 *types.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.CreateResourceInput) (*types.Resource, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.CreateResourceInput) *types.Resource); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.CreateResourceInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_CreateResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateResource'
+type Application_CreateResource_Call struct {
+	*mock.Call
+}
+
+// CreateResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.CreateResourceInput
+func (_e *Application_Expecter) CreateResource(ctx interface{}, input interface{}) *Application_CreateResource_Call {
+	return &Application_CreateResource_Call{Call: _e.mock.On("CreateResource", ctx, input)}
+}
+
+func (_c *Application_CreateResource_Call) Run(run func(ctx context.Context, input *dto.CreateResourceInput)) *Application_CreateResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.CreateResourceInput))
+	})
+	return _c
+}
+
+func (_c *Application_CreateResource_Call) Return(_a0 *types.Resource, _a1 error) *Application_CreateResource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_CreateResource_Call) RunAndReturn(run func(context.Context, *dto.CreateResourceInput) (*types.Resource, error)) *Application_CreateResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, input
+func (_m *Application) CreateWebhook(ctx context.Context, input *dto.CreateWebhookInput) (*types.Webhook, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *types.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.CreateWebhookInput) (*types.Webhook, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.CreateWebhookInput) *types.Webhook); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.CreateWebhookInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type Application_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.CreateWebhookInput
+func (_e *Application_Expecter) CreateWebhook(ctx interface{}, input interface{}) *Application_CreateWebhook_Call {
+	return &Application_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, input)}
+}
+
+func (_c *Application_CreateWebhook_Call) Run(run func(ctx context.Context, input *dto.CreateWebhookInput)) *Application_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.CreateWebhookInput))
+	})
+	return _c
+}
+
+func (_c *Application_CreateWebhook_Call) Return(_a0 *types.Webhook, _a1 error) *Application_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_CreateWebhook_Call) RunAndReturn(run func(context.Context, *dto.CreateWebhookInput) (*types.Webhook, error)) *Application_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteEvent provides a mock function with given fields: ctx, id
 func (_m *Application) DeleteEvent(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -131,83 +307,118 @@ func (_c *Application_DeleteEvent_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
-// GetAllUserEvents provides a mock function with given fields: ctx, userID
-func (_m *Application) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
-	ret := _m.Called(ctx, userID)
+// DeleteResource provides a mock function with given fields: ctx, id
+func (_m *Application) DeleteResource(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllUserEvents")
+		panic("no return value specified for DeleteResource")
 	}
 
-	var r0 []*types.Event
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*types.Event, error)); ok {
-		return rf(ctx, userID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*types.Event); ok {
-		r0 = rf(ctx, userID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*types.Event)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+	return r0
+}
+
+// Application_DeleteResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteResource'
+type Application_DeleteResource_Call struct {
+	*mock.Call
+}
+
+// DeleteResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Application_Expecter) DeleteResource(ctx interface{}, id interface{}) *Application_DeleteResource_Call {
+	return &Application_DeleteResource_Call{Call: _e.mock.On("DeleteResource", ctx, id)}
+}
+
+func (_c *Application_DeleteResource_Call) Run(run func(ctx context.Context, id string)) *Application_DeleteResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Application_DeleteResource_Call) Return(_a0 error) *Application_DeleteResource_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_DeleteResource_Call) RunAndReturn(run func(context.Context, string) error) *Application_DeleteResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *Application) DeleteWebhook(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Application_GetAllUserEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUserEvents'
-type Application_GetAllUserEvents_Call struct {
+// Application_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type Application_DeleteWebhook_Call struct {
 	*mock.Call
 }
 
-// GetAllUserEvents is a helper method to define mock.On call
+// DeleteWebhook is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *Application_Expecter) GetAllUserEvents(ctx interface{}, userID interface{}) *Application_GetAllUserEvents_Call {
-	return &Application_GetAllUserEvents_Call{Call: _e.mock.On("GetAllUserEvents", ctx, userID)}
+//   - id string
+func (_e *Application_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *Application_DeleteWebhook_Call {
+	return &Application_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
 }
 
-func (_c *Application_GetAllUserEvents_Call) Run(run func(ctx context.Context, userID string)) *Application_GetAllUserEvents_Call {
+func (_c *Application_DeleteWebhook_Call) Run(run func(ctx context.Context, id string)) *Application_DeleteWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Application_GetAllUserEvents_Call) Return(_a0 []*types.Event, _a1 error) *Application_GetAllUserEvents_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Application_DeleteWebhook_Call) Return(_a0 error) *Application_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Application_GetAllUserEvents_Call) RunAndReturn(run func(context.Context, string) ([]*types.Event, error)) *Application_GetAllUserEvents_Call {
+func (_c *Application_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string) error) *Application_DeleteWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEvent provides a mock function with given fields: ctx, id
-func (_m *Application) GetEvent(ctx context.Context, id string) (*types.Event, error) {
+// EnableWebhook provides a mock function with given fields: ctx, id
+func (_m *Application) EnableWebhook(ctx context.Context, id string) (*types.Webhook, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEvent")
+		panic("no return value specified for EnableWebhook")
 	}
 
-	var r0 *types.Event
+	var r0 *types.Webhook
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.Event, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.Webhook, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *types.Event); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.Webhook); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Event)
+			r0 = ret.Get(0).(*types.Webhook)
 		}
 	}
 
@@ -220,58 +431,58 @@ func (_m *Application) GetEvent(ctx context.Context, id string) (*types.Event, e
 	return r0, r1
 }
 
-// Application_GetEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvent'
-type Application_GetEvent_Call struct {
+// Application_EnableWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableWebhook'
+type Application_EnableWebhook_Call struct {
 	*mock.Call
 }
 
-// GetEvent is a helper method to define mock.On call
+// EnableWebhook is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *Application_Expecter) GetEvent(ctx interface{}, id interface{}) *Application_GetEvent_Call {
-	return &Application_GetEvent_Call{Call: _e.mock.On("GetEvent", ctx, id)}
+func (_e *Application_Expecter) EnableWebhook(ctx interface{}, id interface{}) *Application_EnableWebhook_Call {
+	return &Application_EnableWebhook_Call{Call: _e.mock.On("EnableWebhook", ctx, id)}
 }
 
-func (_c *Application_GetEvent_Call) Run(run func(ctx context.Context, id string)) *Application_GetEvent_Call {
+func (_c *Application_EnableWebhook_Call) Run(run func(ctx context.Context, id string)) *Application_EnableWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Application_GetEvent_Call) Return(_a0 *types.Event, _a1 error) *Application_GetEvent_Call {
+func (_c *Application_EnableWebhook_Call) Return(_a0 *types.Webhook, _a1 error) *Application_EnableWebhook_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Application_GetEvent_Call) RunAndReturn(run func(context.Context, string) (*types.Event, error)) *Application_GetEvent_Call {
+func (_c *Application_EnableWebhook_Call) RunAndReturn(run func(context.Context, string) (*types.Webhook, error)) *Application_EnableWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForPeriod provides a mock function with given fields: ctx, input
-func (_m *Application) GetEventsForPeriod(ctx context.Context, input *dto.DateRangeInput) ([]*types.Event, error) {
-	ret := _m.Called(ctx, input)
+// GetAllUserEvents provides a mock function with given fields: ctx, userID
+func (_m *Application) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForPeriod")
+		panic("no return value specified for GetAllUserEvents")
 	}
 
 	var r0 []*types.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateRangeInput) ([]*types.Event, error)); ok {
-		return rf(ctx, input)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*types.Event, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateRangeInput) []*types.Event); ok {
-		r0 = rf(ctx, input)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*types.Event); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *dto.DateRangeInput) error); ok {
-		r1 = rf(ctx, input)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -279,58 +490,58 @@ func (_m *Application) GetEventsForPeriod(ctx context.Context, input *dto.DateRa
 	return r0, r1
 }
 
-// Application_GetEventsForPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForPeriod'
-type Application_GetEventsForPeriod_Call struct {
+// Application_GetAllUserEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUserEvents'
+type Application_GetAllUserEvents_Call struct {
 	*mock.Call
 }
 
-// GetEventsForPeriod is a helper method to define mock.On call
+// GetAllUserEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input *dto.DateRangeInput
-func (_e *Application_Expecter) GetEventsForPeriod(ctx interface{}, input interface{}) *Application_GetEventsForPeriod_Call {
-	return &Application_GetEventsForPeriod_Call{Call: _e.mock.On("GetEventsForPeriod", ctx, input)}
+//   - userID string
+func (_e *Application_Expecter) GetAllUserEvents(ctx interface{}, userID interface{}) *Application_GetAllUserEvents_Call {
+	return &Application_GetAllUserEvents_Call{Call: _e.mock.On("GetAllUserEvents", ctx, userID)}
 }
 
-func (_c *Application_GetEventsForPeriod_Call) Run(run func(ctx context.Context, input *dto.DateRangeInput)) *Application_GetEventsForPeriod_Call {
+func (_c *Application_GetAllUserEvents_Call) Run(run func(ctx context.Context, userID string)) *Application_GetAllUserEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*dto.DateRangeInput))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Application_GetEventsForPeriod_Call) Return(_a0 []*types.Event, _a1 error) *Application_GetEventsForPeriod_Call {
+func (_c *Application_GetAllUserEvents_Call) Return(_a0 []*types.Event, _a1 error) *Application_GetAllUserEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Application_GetEventsForPeriod_Call) RunAndReturn(run func(context.Context, *dto.DateRangeInput) ([]*types.Event, error)) *Application_GetEventsForPeriod_Call {
+func (_c *Application_GetAllUserEvents_Call) RunAndReturn(run func(context.Context, string) ([]*types.Event, error)) *Application_GetAllUserEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListEvents provides a mock function with given fields: ctx, input
-func (_m *Application) ListEvents(ctx context.Context, input *dto.DateFilterInput) ([]*types.Event, error) {
-	ret := _m.Called(ctx, input)
+// GetCacheStats provides a mock function with given fields: ctx
+func (_m *Application) GetCacheStats(ctx context.Context) (*dto.CacheStats, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListEvents")
+		panic("no return value specified for GetCacheStats")
 	}
 
-	var r0 []*types.Event
+	var r0 *dto.CacheStats
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateFilterInput) ([]*types.Event, error)); ok {
-		return rf(ctx, input)
+	if rf, ok := ret.Get(0).(func(context.Context) (*dto.CacheStats, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateFilterInput) []*types.Event); ok {
-		r0 = rf(ctx, input)
+	if rf, ok := ret.Get(0).(func(context.Context) *dto.CacheStats); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*types.Event)
+			r0 = ret.Get(0).(*dto.CacheStats)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *dto.DateFilterInput) error); ok {
-		r1 = rf(ctx, input)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -338,12 +549,423 @@ func (_m *Application) ListEvents(ctx context.Context, input *dto.DateFilterInpu
 	return r0, r1
 }
 
-// Application_ListEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEvents'
-type Application_ListEvents_Call struct {
+// Application_GetCacheStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCacheStats'
+type Application_GetCacheStats_Call struct {
 	*mock.Call
 }
 
-// ListEvents is a helper method to define mock.On call
+// GetCacheStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Application_Expecter) GetCacheStats(ctx interface{}) *Application_GetCacheStats_Call {
+	return &Application_GetCacheStats_Call{Call: _e.mock.On("GetCacheStats", ctx)}
+}
+
+func (_c *Application_GetCacheStats_Call) Run(run func(ctx context.Context)) *Application_GetCacheStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Application_GetCacheStats_Call) Return(_a0 *dto.CacheStats, _a1 error) *Application_GetCacheStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetCacheStats_Call) RunAndReturn(run func(context.Context) (*dto.CacheStats, error)) *Application_GetCacheStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDigestSettings provides a mock function with given fields: ctx, userID
+func (_m *Application) GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestSettings")
+	}
+
+	var r0 *types.DigestSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.DigestSettings, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.DigestSettings); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DigestSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetDigestSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigestSettings'
+type Application_GetDigestSettings_Call struct {
+	*mock.Call
+}
+
+// GetDigestSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Application_Expecter) GetDigestSettings(ctx interface{}, userID interface{}) *Application_GetDigestSettings_Call {
+	return &Application_GetDigestSettings_Call{Call: _e.mock.On("GetDigestSettings", ctx, userID)}
+}
+
+func (_c *Application_GetDigestSettings_Call) Run(run func(ctx context.Context, userID string)) *Application_GetDigestSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Application_GetDigestSettings_Call) Return(_a0 *types.DigestSettings, _a1 error) *Application_GetDigestSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetDigestSettings_Call) RunAndReturn(run func(context.Context, string) (*types.DigestSettings, error)) *Application_GetDigestSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEvent provides a mock function with given fields: ctx, id
+func (_m *Application) GetEvent(ctx context.Context, id string) (*types.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvent")
+	}
+
+	var r0 *types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvent'
+type Application_GetEvent_Call struct {
+	*mock.Call
+}
+
+// GetEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Application_Expecter) GetEvent(ctx interface{}, id interface{}) *Application_GetEvent_Call {
+	return &Application_GetEvent_Call{Call: _e.mock.On("GetEvent", ctx, id)}
+}
+
+func (_c *Application_GetEvent_Call) Run(run func(ctx context.Context, id string)) *Application_GetEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Application_GetEvent_Call) Return(_a0 *types.Event, _a1 error) *Application_GetEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetEvent_Call) RunAndReturn(run func(context.Context, string) (*types.Event, error)) *Application_GetEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForPeriod provides a mock function with given fields: ctx, input
+func (_m *Application) GetEventsForPeriod(ctx context.Context, input *dto.DateRangeInput) ([]*types.Event, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForPeriod")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateRangeInput) ([]*types.Event, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateRangeInput) []*types.Event); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.DateRangeInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetEventsForPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForPeriod'
+type Application_GetEventsForPeriod_Call struct {
+	*mock.Call
+}
+
+// GetEventsForPeriod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.DateRangeInput
+func (_e *Application_Expecter) GetEventsForPeriod(ctx interface{}, input interface{}) *Application_GetEventsForPeriod_Call {
+	return &Application_GetEventsForPeriod_Call{Call: _e.mock.On("GetEventsForPeriod", ctx, input)}
+}
+
+func (_c *Application_GetEventsForPeriod_Call) Run(run func(ctx context.Context, input *dto.DateRangeInput)) *Application_GetEventsForPeriod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.DateRangeInput))
+	})
+	return _c
+}
+
+func (_c *Application_GetEventsForPeriod_Call) Return(_a0 []*types.Event, _a1 error) *Application_GetEventsForPeriod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetEventsForPeriod_Call) RunAndReturn(run func(context.Context, *dto.DateRangeInput) ([]*types.Event, error)) *Application_GetEventsForPeriod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMissedReminders provides a mock function with given fields: ctx, input
+func (_m *Application) GetMissedReminders(ctx context.Context, input *dto.MissedRemindersInput) ([]*dto.MissedReminder, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissedReminders")
+	}
+
+	var r0 []*dto.MissedReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.MissedRemindersInput) ([]*dto.MissedReminder, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.MissedRemindersInput) []*dto.MissedReminder); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.MissedReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.MissedRemindersInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetMissedReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMissedReminders'
+type Application_GetMissedReminders_Call struct {
+	*mock.Call
+}
+
+// GetMissedReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.MissedRemindersInput
+func (_e *Application_Expecter) GetMissedReminders(ctx interface{}, input interface{}) *Application_GetMissedReminders_Call {
+	return &Application_GetMissedReminders_Call{Call: _e.mock.On("GetMissedReminders", ctx, input)}
+}
+
+func (_c *Application_GetMissedReminders_Call) Run(run func(ctx context.Context, input *dto.MissedRemindersInput)) *Application_GetMissedReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.MissedRemindersInput))
+	})
+	return _c
+}
+
+func (_c *Application_GetMissedReminders_Call) Return(_a0 []*dto.MissedReminder, _a1 error) *Application_GetMissedReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetMissedReminders_Call) RunAndReturn(run func(context.Context, *dto.MissedRemindersInput) ([]*dto.MissedReminder, error)) *Application_GetMissedReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetResourceAvailability provides a mock function with given fields: ctx, input
+func (_m *Application) GetResourceAvailability(ctx context.Context, input *dto.ResourceAvailabilityInput) ([]*types.ResourceAvailability, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResourceAvailability")
+	}
+
+	var r0 []*types.ResourceAvailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ResourceAvailabilityInput) ([]*types.ResourceAvailability, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ResourceAvailabilityInput) []*types.ResourceAvailability); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.ResourceAvailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ResourceAvailabilityInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetResourceAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResourceAvailability'
+type Application_GetResourceAvailability_Call struct {
+	*mock.Call
+}
+
+// GetResourceAvailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.ResourceAvailabilityInput
+func (_e *Application_Expecter) GetResourceAvailability(ctx interface{}, input interface{}) *Application_GetResourceAvailability_Call {
+	return &Application_GetResourceAvailability_Call{Call: _e.mock.On("GetResourceAvailability", ctx, input)}
+}
+
+func (_c *Application_GetResourceAvailability_Call) Run(run func(ctx context.Context, input *dto.ResourceAvailabilityInput)) *Application_GetResourceAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.ResourceAvailabilityInput))
+	})
+	return _c
+}
+
+func (_c *Application_GetResourceAvailability_Call) Return(_a0 []*types.ResourceAvailability, _a1 error) *Application_GetResourceAvailability_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetResourceAvailability_Call) RunAndReturn(run func(context.Context, *dto.ResourceAvailabilityInput) ([]*types.ResourceAvailability, error)) *Application_GetResourceAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSchedulerStatus provides a mock function with given fields: ctx
+func (_m *Application) GetSchedulerStatus(ctx context.Context) (*dto.SchedulerStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedulerStatus")
+	}
+
+	var r0 *dto.SchedulerStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*dto.SchedulerStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *dto.SchedulerStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.SchedulerStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetSchedulerStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSchedulerStatus'
+type Application_GetSchedulerStatus_Call struct {
+	*mock.Call
+}
+
+// GetSchedulerStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Application_Expecter) GetSchedulerStatus(ctx interface{}) *Application_GetSchedulerStatus_Call {
+	return &Application_GetSchedulerStatus_Call{Call: _e.mock.On("GetSchedulerStatus", ctx)}
+}
+
+func (_c *Application_GetSchedulerStatus_Call) Run(run func(ctx context.Context)) *Application_GetSchedulerStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Application_GetSchedulerStatus_Call) Return(_a0 *dto.SchedulerStatus, _a1 error) *Application_GetSchedulerStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetSchedulerStatus_Call) RunAndReturn(run func(context.Context) (*dto.SchedulerStatus, error)) *Application_GetSchedulerStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEvents provides a mock function with given fields: ctx, input
+func (_m *Application) ListEvents(ctx context.Context, input *dto.DateFilterInput) ([]*types.Event, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEvents")
+	}
+
+	var r0 []*types.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateFilterInput) ([]*types.Event, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DateFilterInput) []*types.Event); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.DateFilterInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_ListEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEvents'
+type Application_ListEvents_Call struct {
+	*mock.Call
+}
+
+// ListEvents is a helper method to define mock.On call
 //   - ctx context.Context
 //   - input *dto.DateFilterInput
 func (_e *Application_Expecter) ListEvents(ctx interface{}, input interface{}) *Application_ListEvents_Call {
@@ -367,6 +989,301 @@ func (_c *Application_ListEvents_Call) RunAndReturn(run func(context.Context, *d
 	return _c
 }
 
+// ListResources provides a mock function with given fields: ctx
+func (_m *Application) ListResources(ctx context.Context) ([]*types.Resource, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListResources")
+	}
+
+	var r0 []*types.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*types.Resource, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*types.Resource); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_ListResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListResources'
+type Application_ListResources_Call struct {
+	*mock.Call
+}
+
+// ListResources is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Application_Expecter) ListResources(ctx interface{}) *Application_ListResources_Call {
+	return &Application_ListResources_Call{Call: _e.mock.On("ListResources", ctx)}
+}
+
+func (_c *Application_ListResources_Call) Run(run func(ctx context.Context)) *Application_ListResources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Application_ListResources_Call) Return(_a0 []*types.Resource, _a1 error) *Application_ListResources_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_ListResources_Call) RunAndReturn(run func(context.Context) ([]*types.Resource, error)) *Application_ListResources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, id, limit
+func (_m *Application) ListWebhookDeliveries(ctx context.Context, id string, limit int) ([]*types.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []*types.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*types.WebhookDelivery, error)); ok {
+		return rf(ctx, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*types.WebhookDelivery); ok {
+		r0 = rf(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type Application_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - limit int
+func (_e *Application_Expecter) ListWebhookDeliveries(ctx interface{}, id interface{}, limit interface{}) *Application_ListWebhookDeliveries_Call {
+	return &Application_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, id, limit)}
+}
+
+func (_c *Application_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, id string, limit int)) *Application_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Application_ListWebhookDeliveries_Call) Return(_a0 []*types.WebhookDelivery, _a1 error) *Application_ListWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, string, int) ([]*types.WebhookDelivery, error)) *Application_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, userID
+func (_m *Application) ListWebhooks(ctx context.Context, userID string) ([]*types.Webhook, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []*types.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*types.Webhook, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*types.Webhook); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type Application_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Application_Expecter) ListWebhooks(ctx interface{}, userID interface{}) *Application_ListWebhooks_Call {
+	return &Application_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx, userID)}
+}
+
+func (_c *Application_ListWebhooks_Call) Run(run func(ctx context.Context, userID string)) *Application_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Application_ListWebhooks_Call) Return(_a0 []*types.Webhook, _a1 error) *Application_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_ListWebhooks_Call) RunAndReturn(run func(context.Context, string) ([]*types.Webhook, error)) *Application_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewNotification provides a mock function with given fields: ctx, input
+func (_m *Application) PreviewNotification(ctx context.Context, input *dto.PreviewNotificationInput) (*types.Message, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewNotification")
+	}
+
+	var r0 *types.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PreviewNotificationInput) (*types.Message, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PreviewNotificationInput) *types.Message); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.PreviewNotificationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_PreviewNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewNotification'
+type Application_PreviewNotification_Call struct {
+	*mock.Call
+}
+
+// PreviewNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.PreviewNotificationInput
+func (_e *Application_Expecter) PreviewNotification(ctx interface{}, input interface{}) *Application_PreviewNotification_Call {
+	return &Application_PreviewNotification_Call{Call: _e.mock.On("PreviewNotification", ctx, input)}
+}
+
+func (_c *Application_PreviewNotification_Call) Run(run func(ctx context.Context, input *dto.PreviewNotificationInput)) *Application_PreviewNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.PreviewNotificationInput))
+	})
+	return _c
+}
+
+func (_c *Application_PreviewNotification_Call) Return(_a0 *types.Message, _a1 error) *Application_PreviewNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_PreviewNotification_Call) RunAndReturn(run func(context.Context, *dto.PreviewNotificationInput) (*types.Message, error)) *Application_PreviewNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDigestSettings provides a mock function with given fields: ctx, input
+func (_m *Application) SetDigestSettings(ctx context.Context, input *dto.DigestSettingsInput) (*types.DigestSettings, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDigestSettings")
+	}
+
+	var r0 *types.DigestSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DigestSettingsInput) (*types.DigestSettings, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DigestSettingsInput) *types.DigestSettings); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DigestSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.DigestSettingsInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_SetDigestSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigestSettings'
+type Application_SetDigestSettings_Call struct {
+	*mock.Call
+}
+
+// SetDigestSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dto.DigestSettingsInput
+func (_e *Application_Expecter) SetDigestSettings(ctx interface{}, input interface{}) *Application_SetDigestSettings_Call {
+	return &Application_SetDigestSettings_Call{Call: _e.mock.On("SetDigestSettings", ctx, input)}
+}
+
+func (_c *Application_SetDigestSettings_Call) Run(run func(ctx context.Context, input *dto.DigestSettingsInput)) *Application_SetDigestSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.DigestSettingsInput))
+	})
+	return _c
+}
+
+func (_c *Application_SetDigestSettings_Call) Return(_a0 *types.DigestSettings, _a1 error) *Application_SetDigestSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_SetDigestSettings_Call) RunAndReturn(run func(context.Context, *dto.DigestSettingsInput) (*types.DigestSettings, error)) *Application_SetDigestSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateEvent provides a mock function with given fields: ctx, input
 func (_m *Application) UpdateEvent(ctx context.Context, input *dto.UpdateEventInput) (*types.Event, error) {
 	ret := _m.Called(ctx, input)
@@ -11,6 +11,8 @@ import (
 
 	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1"            //nolint:depguard,nolintlint
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ratelimit"            //nolint:depguard,nolintlint
+	pkgConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"          //nolint:depguard,nolintlint
 	"google.golang.org/grpc"                                                               //nolint:depguard,nolintlint
 	"google.golang.org/grpc/reflection"                                                    //nolint:depguard,nolintlint
 )
@@ -28,6 +30,9 @@ type Server struct {
 
 	addr            string
 	shutdownTimeout time.Duration
+	limiter         *ratelimit.Limiter // Nil if the rate is not limited.
+	adminToken      string             // Empty if the admin methods are disabled.
+	trustedProxies  ratelimit.TrustedProxies
 }
 
 // NewServer creates a new gRPC server. The function performs validation of the input parameters.
@@ -49,45 +54,91 @@ func NewServer(logger Logger, app Application, config map[string]any) (*Server,
 			projectErrors.ErrServerInitFailed, missing)
 	}
 
-	// Field types validation.
-	missing, wrongType := validateFields(config, expectedFields)
-	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("%w: missing=%v invalid_type=%v",
-			projectErrors.ErrCorruptedConfig, missing, wrongType)
+	sc, err := parseConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
+	return &Server{
+		a:               app,
+		l:               logger,
+		shutdownTimeout: sc.shutdownTimeout,
+		addr:            sc.addr,
+		limiter:         ratelimit.NewLimiter(sc.rateLimit, sc.rateLimitBurst),
+		adminToken:      sc.adminToken,
+		trustedProxies:  sc.trustedProxies,
+	}, nil
+}
+
+// ValidateConfig validates the server config without creating a server.
+// Problems are returned as *config.ValidationError wrapped with ErrCorruptedConfig.
+func ValidateConfig(config map[string]any) error {
+	_, err := parseConfig(config)
+	return err
+}
+
+// serverSettings are the server settings parsed from the config.
+type serverSettings struct {
+	addr            string
+	shutdownTimeout time.Duration
+	rateLimit       float64
+	rateLimitBurst  int
+	adminToken      string
+	trustedProxies  ratelimit.TrustedProxies
+}
+
+// parseConfig validates and extracts the server settings from the config, accumulating all problems.
+func parseConfig(config map[string]any) (*serverSettings, error) {
+	// Field types validation.
+	ve := &pkgConfig.ValidationError{}
+	ve.Missing, ve.InvalidType = validateFields(config, expectedFields)
+	ve.InvalidType = append(ve.InvalidType, validateOptionalFields(config, optionalFields)...)
+
 	/// Extract from config an normalize the value.
-	host, _ := config["host"].(string)
-	port, _ := config["port"].(string)
+	host, hostOk := config["host"].(string)
+	port, portOk := config["port"].(string)
 	shutdownTimeout, _ := config["shutdown_timeout"].(time.Duration)
+	// Optional fields are zero valued if missing. Zero rate disables the limiting.
+	rateLimit, _ := config["rate_limit"].(float64)
+	rateLimitBurst, _ := config["rate_limit_burst"].(int)
+	// Empty admin token disables the admin methods.
+	adminToken, _ := config["admin_token"].(string)
+	// Forwarded client addresses are not trusted by default.
+	proxies, _ := config["trusted_proxies"].([]string)
+	trustedProxies, err := ratelimit.ParseTrustedProxies(proxies)
+	if err != nil {
+		ve.InvalidValue = append(ve.InvalidValue, "trusted_proxies")
+	}
 
-	invalidValues := make([]string, 0)
-	if host == "" {
-		invalidValues = append(invalidValues, "host")
+	// Missing and wrong type values are already reported.
+	if hostOk && host == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "host")
 	}
-	if port == "" {
-		invalidValues = append(invalidValues, "port")
+	if portOk && port == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "port")
 	}
-	if len(invalidValues) > 0 {
-		return nil, fmt.Errorf("%w: invalid values=%v", projectErrors.ErrCorruptedConfig, invalidValues)
+	if ve.HasErrors() {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
 	}
 
-	return &Server{
-		a:               app,
-		l:               logger,
-		shutdownTimeout: shutdownTimeout,
+	return &serverSettings{
 		addr:            fmt.Sprintf("%s:%s", host, port),
+		shutdownTimeout: shutdownTimeout,
+		rateLimit:       rateLimit,
+		rateLimitBurst:  rateLimitBurst,
+		adminToken:      adminToken,
+		trustedProxies:  trustedProxies,
 	}, nil
 }
 
 // Start starts the gRPC server. Start blocks the calling goroutine until the error returns.
 func (s *Server) Start(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	addr := s.addr
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
+		s.mu.Unlock()
 		return fmt.Errorf("listen tcp at %v: %w", addr, err)
 	}
 
@@ -95,6 +146,8 @@ func (s *Server) Start(ctx context.Context) error {
 		grpc.ChainUnaryInterceptor(
 			s.requestContextUnaryInterceptor,
 			s.loggingUnaryInterceptor,
+			s.rateLimitUnaryInterceptor,
+			s.adminAuthUnaryInterceptor,
 		),
 	)
 
@@ -104,6 +157,9 @@ func (s *Server) Start(ctx context.Context) error {
 	s.lis = lis
 	reflection.Register(s.server)
 
+	// The lock is released before serving, so Stop is able to shut the server down.
+	s.mu.Unlock()
+
 	// Avoiding server start.
 	select {
 	case <-ctx.Done():
@@ -6,10 +6,12 @@ import (
 	"log/slog"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"google.golang.org/genproto/googleapis/rpc/errdetails"                                 //nolint:depguard,nolintlint
 	"google.golang.org/grpc"                                                               //nolint:depguard,nolintlint
 	"google.golang.org/grpc/codes"                                                         //nolint:depguard,nolintlint
 	"google.golang.org/grpc/metadata"                                                      //nolint:depguard,nolintlint
 	"google.golang.org/grpc/status"                                                        //nolint:depguard,nolintlint
+	"google.golang.org/protobuf/protoadapt"                                                //nolint:depguard,nolintlint
 	"google.golang.org/protobuf/types/known/anypb"                                         //nolint:depguard,nolintlint
 	"google.golang.org/protobuf/types/known/wrapperspb"                                    //nolint:depguard,nolintlint
 )
@@ -26,6 +28,8 @@ func (s *Server) wrapError(ctx context.Context, err error) *status.Status {
 		return status.New(codes.Internal, "Failed to wrap orignal error")
 	}
 
+	details := []protoadapt.MessageV1{anyDetail}
+
 	var st *status.Status
 	// Processing different kinds of errors.
 	switch {
@@ -50,14 +54,32 @@ func (s *Server) wrapError(ctx context.Context, err error) *status.Status {
 		st = status.New(codes.NotFound, "Requested event was not found")
 	case errors.Is(err, projectErrors.ErrDateBusy):
 		st = status.New(codes.AlreadyExists, "Requested event date is already busy")
+		details = append(details, conflictDetails(err)...)
 	case errors.Is(err, projectErrors.ErrPermissionDenied):
 		st = status.New(codes.PermissionDenied, "Cannot modify another user's event")
+	case errors.Is(err, projectErrors.ErrQuotaExceeded):
+		st = status.New(codes.ResourceExhausted, "User event quota exceeded")
+	case errors.Is(err, projectErrors.ErrIdempotencyConflict):
+		st = status.New(codes.Aborted, "Idempotency key is already used with another request")
+	case errors.Is(err, projectErrors.ErrDigestSettingsNotFound):
+		st = status.New(codes.NotFound, "Daily digest settings were not found")
+	case errors.Is(err, projectErrors.ErrWebhookNotFound):
+		st = status.New(codes.NotFound, "Requested webhook was not found")
+	case errors.Is(err, projectErrors.ErrResourceNotFound):
+		st = status.New(codes.NotFound, "Requested resource was not found")
+	case errors.Is(err, projectErrors.ErrResourceInUse):
+		st = status.New(codes.FailedPrecondition, "Resource is booked by upcoming events")
+	case errors.Is(err, projectErrors.ErrTemplateNotFound):
+		st = status.New(codes.NotFound, "Notification template was not found")
+	case errors.Is(err, projectErrors.ErrInvalidTemplate):
+		s.l.Warn(ctx, "invalid notification template", slog.String("err", err.Error()))
+		st = status.New(codes.FailedPrecondition, "Notification template is invalid")
 	default:
 		s.l.Error(ctx, "unknown error received", slog.String("err", err.Error()))
 		st = status.New(codes.Internal, "Unexpected internal error occurred")
 	}
 
-	resSt, err := st.WithDetails(anyDetail)
+	resSt, err := st.WithDetails(details...)
 	if err != nil {
 		s.l.Error(ctx, "failed to add error details", slog.String("err", err.Error()))
 		return st
@@ -67,9 +89,39 @@ func (s *Server) wrapError(ctx context.Context, err error) *status.Status {
 	return st
 }
 
+// conflictDetails returns the resource info details for each event conflicting with the requested one.
+func conflictDetails(err error) []protoadapt.MessageV1 {
+	var busyErr *projectErrors.DateBusyError
+	if !errors.As(err, &busyErr) {
+		return nil
+	}
+
+	details := make([]protoadapt.MessageV1, 0, len(busyErr.ConflictingIDs))
+	for _, id := range busyErr.ConflictingIDs {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: "event",
+			ResourceName: id,
+			Description:  "conflicting event",
+		})
+	}
+	return details
+}
+
 // handleError wraps the error and sets gRPC headers.
 func (s *Server) handleError(ctx context.Context, err error) *status.Status {
 	st := s.wrapError(ctx, err)
 	_ = grpc.SetHeader(ctx, metadata.MD{})
 	return st
 }
+
+// idempotencyKey returns the idempotency key of the request, falling back to the request metadata.
+func idempotencyKey(ctx context.Context, reqKey *string) string {
+	if reqKey != nil && *reqKey != "" {
+		return *reqKey
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get(idempotencyMetadataKey); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
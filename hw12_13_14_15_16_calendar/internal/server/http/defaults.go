@@ -4,6 +4,15 @@ import "time"
 
 const swaggerPath = "api/calendar/v1/CalendarService.swagger.json"
 
+// Headers used by the rate limiting, the request deduplication and the admin authorization.
+const (
+	userIDHeader       = "X-User-ID"       // ID of the user, making the request. Forwarded to the gRPC server.
+	retryAfterHeader   = "Retry-After"     // Number of seconds until the next allowed request.
+	idempotencyHeader  = "Idempotency-Key" // Idempotency key of the create request. Forwarded to the gRPC server.
+	adminTokenHeader   = "X-Admin-Token"   // Operator credential of the admin methods. Forwarded to the gRPC server.
+	forwardedForHeader = "X-Forwarded-For" // Client address. Trusted only if set by the trusted proxies.
+)
+
 // expectedHTTPFields is a map of expected configuration fields and their default values.
 var expectedHTTPFields = map[string]any{
 	"host":             "",
@@ -14,6 +23,13 @@ var expectedHTTPFields = map[string]any{
 	"idle_timeout":     time.Duration(0),
 }
 
+// optionalHTTPFields is a map of optional configuration fields and their default values.
+var optionalHTTPFields = map[string]any{
+	"rate_limit":       float64(0),
+	"rate_limit_burst": int(0),
+	"trusted_proxies":  []string(nil),
+}
+
 // expectedGRPCFields is a map of expected configuration fields and their default values for a linked gRPC server.
 var expectedGRPCFields = map[string]any{
 	"host": "",
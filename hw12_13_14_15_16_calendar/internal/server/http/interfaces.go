@@ -36,6 +36,10 @@ type Application interface {
 	// GetAllUserEvents is trying to get all events for a given user ID from the storage.
 	GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error)
 
+	// GetEventChanges is trying to get the events of a given user ID, changed after the since sequence number,
+	// and the IDs of the deleted ones from the storage. since = 0 means all events of the user.
+	GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error)
+
 	// ListEvents is trying to get all events for a given user ID from the storage.
 	ListEvents(ctx context.Context, input *dto.DateFilterInput) ([]*types.Event, error)
 
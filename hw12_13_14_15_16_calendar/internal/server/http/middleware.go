@@ -3,10 +3,13 @@ package http
 import (
 	"context"
 	"log/slog"
+	"net/http"
+	"strconv"
 	"time"
 
-	"github.com/gin-gonic/gin" //nolint:depguard,nolintlint
-	"github.com/google/uuid"   //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ratelimit" //nolint:depguard,nolintlint
+	"github.com/gin-gonic/gin"                                                  //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                    //nolint:depguard,nolintlint
 )
 
 // requestDataKey is a key for storing request data in the context.
@@ -57,3 +60,33 @@ func (s *Server) loggingMiddleware() gin.HandlerFunc {
 		)
 	}
 }
+
+// rateLimitMiddleware rejects the requests exceeding the client rate limit with 429 status code
+// and Retry-After header.
+//
+// Requests are limited by the client IP and, if X-User-ID header is provided, by the user as well.
+// There is no authentication, so the header might be forged, but the IP limit is applied anyway.
+// X-Forwarded-For header is honoured only for the requests of the trusted proxies, so it can't reset the IP limit.
+func (s *Server) rateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.limiter == nil {
+			c.Next()
+			return
+		}
+
+		keys := []string{"ip:" + c.ClientIP()}
+		if userID := c.GetHeader(userIDHeader); userID != "" {
+			keys = append(keys, "user:"+userID)
+		}
+
+		ok, wait := s.limiter.Allow(keys...)
+		if !ok {
+			c.Header(retryAfterHeader, strconv.Itoa(ratelimit.RetryAfterSeconds(wait)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "too many requests, please try again later",
+			})
+			return
+		}
+		c.Next()
+	}
+}
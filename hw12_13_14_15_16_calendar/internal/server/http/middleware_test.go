@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ratelimit" //nolint:depguard,nolintlint
+	"github.com/gin-gonic/gin"                                                  //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                       //nolint:depguard,nolintlint
+)
+
+// nopLogger discards all messages.
+type nopLogger struct{}
+
+func (nopLogger) Info(context.Context, string, ...any)  {}
+func (nopLogger) Debug(context.Context, string, ...any) {}
+func (nopLogger) Warn(context.Context, string, ...any)  {}
+func (nopLogger) Error(context.Context, string, ...any) {}
+
+func newTestEngine(t *testing.T, trustedProxies []string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	s := &Server{l: nopLogger{}, limiter: ratelimit.NewLimiter(0.5, 2), trustedProxies: trustedProxies}
+	engine, err := s.newEngine(context.Background())
+	require.NoError(t, err, "expected nil, got error")
+	engine.GET("/v1/ping", func(c *gin.Context) {
+		req := forwardedRequest(c)
+		c.String(http.StatusOK, req.Header.Get(forwardedForHeader)+"|"+req.RemoteAddr)
+	})
+	return engine
+}
+
+func doRequest(engine *gin.Engine, remoteAddr, forwardedFor, userID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/ping", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set(forwardedForHeader, forwardedFor)
+	}
+	if userID != "" {
+		req.Header.Set(userIDHeader, userID)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewEngineInvalidTrustedProxies(t *testing.T) {
+	s := &Server{l: nopLogger{}, trustedProxies: []string{"gateway"}}
+	_, err := s.newEngine(context.Background())
+	require.Error(t, err, "expected error, got nil")
+}
+
+func TestRateLimitMiddlewareForgedHeaders(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   []string
+		expectedIP     string
+	}{
+		{
+			name:         "no trusted proxies",
+			remoteAddr:   "203.0.113.7:5000",
+			forwardedFor: []string{"192.168.0.1", "192.168.0.2", "192.168.0.3"},
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:           "forged address before trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.1:5000",
+			forwardedFor:   []string{"192.168.0.1, 198.51.100.1", "192.168.0.2, 198.51.100.1", "192.168.0.3, 198.51.100.1"},
+			expectedIP:     "198.51.100.1",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			engine := newTestEngine(t, tC.trustedProxies)
+
+			// Changing the user ID and the forwarded address does not reset the limit of the client address.
+			for i, forwardedFor := range tC.forwardedFor {
+				w := doRequest(engine, tC.remoteAddr, forwardedFor, "user"+forwardedFor)
+				if i < 2 {
+					require.Equal(t, http.StatusOK, w.Code, "request within burst is rejected")
+					// The gRPC server gets only the resolved client address.
+					require.Equal(t, tC.expectedIP+"|", w.Body.String(), "unexpected forwarded address")
+					continue
+				}
+				require.Equal(t, http.StatusTooManyRequests, w.Code, "request over the address burst is allowed")
+				require.NotEmpty(t, w.Header().Get(retryAfterHeader), "expected Retry-After header")
+			}
+
+			// Another client address has its own limit.
+			w := doRequest(engine, "198.51.100.2:5000", "", "")
+			require.Equal(t, http.StatusOK, w.Code, "request of another client is rejected")
+		})
+	}
+}
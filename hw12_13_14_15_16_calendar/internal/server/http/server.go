@@ -7,11 +7,15 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	pb "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/api/calendar/v1"            //nolint:depguard,nolintlint
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/ratelimit"            //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/server/caldav"        //nolint:depguard,nolintlint
+	pkgConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"          //nolint:depguard,nolintlint
 	"github.com/gin-gonic/gin"                                                             //nolint:depguard,nolintlint
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"                                    //nolint:depguard,nolintlint
 	"google.golang.org/grpc"                                                               //nolint:depguard,nolintlint
@@ -34,6 +38,9 @@ type Server struct {
 
 	httpAddr string
 	grpcAddr string
+
+	limiter        *ratelimit.Limiter // Nil if the rate is not limited.
+	trustedProxies []string           // Proxies, whose X-Forwarded-For addresses are trusted. None by default.
 }
 
 // NewServer creates a new HTTP server. The function performs validation of the input parameters.
@@ -58,63 +65,117 @@ func NewServer(logger Logger, app Application, httpConfig map[string]any, grpcCo
 			projectErrors.ErrServerInitFailed, missing)
 	}
 
-	// Field types validation.
-	missing, wrongType := validateFields(httpConfig, expectedHTTPFields)         // HTTP config.
-	grpcMissing, grpcWrongType := validateFields(grpcConfig, expectedGRPCFields) // gRPC config.
-	missing = append(missing, grpcMissing...)
-	wrongType = append(wrongType, grpcWrongType...)
-	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("%w: missing=%v invalid_type=%v",
-			projectErrors.ErrCorruptedConfig, missing, wrongType)
-	}
-
-	// Extract from config an normalize the value.
-	httpHost, _ := httpConfig["host"].(string)
-	httpPort, _ := httpConfig["port"].(string)
-	shutdownTimeout, _ := httpConfig["shutdown_timeout"].(time.Duration)
-	readTimeout, _ := httpConfig["read_timeout"].(time.Duration)
-	writeTimeout, _ := httpConfig["write_timeout"].(time.Duration)
-	idleTimeout, _ := httpConfig["idle_timeout"].(time.Duration)
-	shutdownTimeout = max(0, shutdownTimeout)
-	grpcHost, _ := grpcConfig["host"].(string)
-	grpcPort, _ := grpcConfig["port"].(string)
-
-	invalidValues := make([]string, 0)
-	if httpHost == "" {
-		invalidValues = append(invalidValues, "host")
+	// The linked gRPC server fields are reported with the "grpc_" prefix.
+	ve := &pkgConfig.ValidationError{}
+	hc := parseHTTPConfig(httpConfig, ve)
+	grpcMissing, grpcWrongType := validateFields(grpcConfig, expectedGRPCFields)
+	for _, field := range grpcMissing {
+		ve.Missing = append(ve.Missing, "grpc_"+field)
 	}
-	if httpPort == "" {
-		invalidValues = append(invalidValues, "port")
+	for _, field := range grpcWrongType {
+		ve.InvalidType = append(ve.InvalidType, "grpc_"+field)
 	}
-	if grpcHost == "" {
-		invalidValues = append(invalidValues, "grpc_host")
+	grpcHost, grpcHostOk := grpcConfig["host"].(string)
+	grpcPort, grpcPortOk := grpcConfig["port"].(string)
+	if grpcHostOk && grpcHost == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "grpc_host")
 	}
-	if grpcPort == "" {
-		invalidValues = append(invalidValues, "grpc_port")
+	if grpcPortOk && grpcPort == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "grpc_port")
 	}
-	if len(invalidValues) > 0 {
-		return nil, fmt.Errorf("%w: invalid values=%v", projectErrors.ErrCorruptedConfig, invalidValues)
+	if ve.HasErrors() {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
 	}
 
 	return &Server{
 		a:               app,
 		l:               logger,
-		shutdownTimeout: shutdownTimeout,
+		shutdownTimeout: hc.shutdownTimeout,
+		readTimeout:     hc.readTimeout,
+		writeTimeout:    hc.writeTimeout,
+		idleTimeout:     hc.idleTimeout,
+		httpAddr:        hc.addr,
+		grpcAddr:        fmt.Sprintf("%s:%s", grpcHost, grpcPort),
+		limiter:         ratelimit.NewLimiter(hc.rateLimit, hc.rateLimitBurst),
+		trustedProxies:  hc.trustedProxies,
+	}, nil
+}
+
+// ValidateConfig validates the HTTP server config without creating a server.
+// The linked gRPC server config is validated by the gRPC server package.
+// Problems are returned as *config.ValidationError wrapped with ErrCorruptedConfig.
+func ValidateConfig(httpConfig map[string]any) error {
+	ve := &pkgConfig.ValidationError{}
+	parseHTTPConfig(httpConfig, ve)
+	if ve.HasErrors() {
+		return fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
+	}
+	return nil
+}
+
+// httpSettings are the HTTP server settings parsed from the config.
+type httpSettings struct {
+	addr            string
+	shutdownTimeout time.Duration
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	idleTimeout     time.Duration
+	rateLimit       float64
+	rateLimitBurst  int
+	trustedProxies  []string
+}
+
+// parseHTTPConfig validates and extracts the HTTP server settings from the config. Problems are added to ve.
+func parseHTTPConfig(config map[string]any, ve *pkgConfig.ValidationError) *httpSettings {
+	// Field types validation.
+	missing, wrongType := validateFields(config, expectedHTTPFields)
+	ve.Missing = append(ve.Missing, missing...)
+	ve.InvalidType = append(ve.InvalidType, wrongType...)
+	ve.InvalidType = append(ve.InvalidType, validateOptionalFields(config, optionalHTTPFields)...)
+
+	// Extract from config an normalize the value.
+	host, hostOk := config["host"].(string)
+	port, portOk := config["port"].(string)
+	shutdownTimeout, _ := config["shutdown_timeout"].(time.Duration)
+	readTimeout, _ := config["read_timeout"].(time.Duration)
+	writeTimeout, _ := config["write_timeout"].(time.Duration)
+	idleTimeout, _ := config["idle_timeout"].(time.Duration)
+	// Optional fields are zero valued if missing. Zero rate disables the limiting.
+	rateLimit, _ := config["rate_limit"].(float64)
+	rateLimitBurst, _ := config["rate_limit_burst"].(int)
+	// Forwarded client addresses are not trusted by default.
+	trustedProxies, _ := config["trusted_proxies"].([]string)
+
+	// Missing and wrong type values are already reported.
+	if hostOk && host == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "host")
+	}
+	if portOk && port == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "port")
+	}
+	if _, err := ratelimit.ParseTrustedProxies(trustedProxies); err != nil {
+		ve.InvalidValue = append(ve.InvalidValue, "trusted_proxies")
+	}
+
+	return &httpSettings{
+		addr:            fmt.Sprintf("%s:%s", host, port),
+		shutdownTimeout: max(0, shutdownTimeout),
 		readTimeout:     readTimeout,
 		writeTimeout:    writeTimeout,
 		idleTimeout:     idleTimeout,
-		httpAddr:        fmt.Sprintf("%s:%s", httpHost, httpPort),
-		grpcAddr:        fmt.Sprintf("%s:%s", grpcHost, grpcPort),
-	}, nil
+		rateLimit:       rateLimit,
+		rateLimitBurst:  rateLimitBurst,
+		trustedProxies:  trustedProxies,
+	}
 }
 
 // Start starts the HTTP server. Start blocks the calling goroutine until the error returns.
 func (s *Server) Start(ctx context.Context) error {
 	gin.SetMode(gin.ReleaseMode)
-	engine := gin.New()
-
-	engine.Use(s.requestContextMiddleware(ctx))
-	engine.Use(s.loggingMiddleware())
+	engine, err := s.newEngine(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Test endpoints.
 	engine.GET("/hello", func(c *gin.Context) {
@@ -151,9 +212,22 @@ func (s *Server) Start(ctx context.Context) error {
 			})
 			return
 		}
-		gwHandler.ServeHTTP(c.Writer, c.Request)
+		gwHandler.ServeHTTP(c.Writer, forwardedRequest(c))
 	})
 
+	// Register CalDAV endpoints. gin.Any does not cover the WebDAV methods, so they are listed explicitly.
+	davHandler, err := caldav.NewHandler(s.l, s.a)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to init CalDAV handler: %w", err)
+	}
+	dav := gin.WrapH(davHandler)
+	for _, method := range caldav.Methods {
+		engine.Handle(method, caldav.Prefix, dav)
+		engine.Handle(method, caldav.Prefix+"/*path", dav)
+		engine.Handle(method, caldav.WellKnownPath, dav)
+	}
+
 	s.engine = engine
 	httpAddr := s.httpAddr
 	s.srv = &http.Server{
@@ -171,6 +245,31 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.srv.ListenAndServe()
 }
 
+// newEngine creates the gin engine with the common middleware chain.
+// Client IP is resolved by the X-Forwarded-For header only for the requests of the trusted proxies.
+func (s *Server) newEngine(ctx context.Context) (*gin.Engine, error) {
+	engine := gin.New()
+	// gin trusts all proxies by default.
+	if err := engine.SetTrustedProxies(s.trustedProxies); err != nil {
+		return nil, fmt.Errorf("failed to set trusted proxies: %w", err)
+	}
+
+	engine.Use(s.requestContextMiddleware(ctx))
+	engine.Use(s.loggingMiddleware())
+	engine.Use(s.rateLimitMiddleware())
+	return engine, nil
+}
+
+// forwardedRequest returns the copy of the request, passing the resolved client IP to the gRPC server
+// as the only X-Forwarded-For address. Otherwise, the gateway forwards the addresses sent by the client.
+func forwardedRequest(c *gin.Context) *http.Request {
+	req := c.Request.Clone(c.Request.Context())
+	req.Header.Set(forwardedForHeader, c.ClientIP())
+	// The gateway appends the remote address to X-Forwarded-For.
+	req.RemoteAddr = ""
+	return req
+}
+
 // Stop gracefully shuts down the HTTP server.
 func (s *Server) Stop(ctx context.Context) error {
 	s.mu.RLock()
@@ -196,7 +295,10 @@ func (s *Server) Stop(ctx context.Context) error {
 
 func (s *Server) initGRPCGateway(ctx context.Context, grpcEndpoint string) (http.Handler, error) {
 	// Register the gRPC server endpoint.
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	}
@@ -207,3 +309,22 @@ func (s *Server) initGRPCGateway(ctx context.Context, grpcEndpoint string) (http
 
 	return mux, nil
 }
+
+// incomingHeaderMatcher forwards the user ID, the idempotency key and the admin token headers to the gRPC server
+// along with the default headers.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, userIDHeader) || strings.EqualFold(key, idempotencyHeader) ||
+		strings.EqualFold(key, adminTokenHeader) {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher passes the Retry-After header of the gRPC server as-is,
+// other headers are prefixed by the default gateway rules.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, retryAfterHeader) {
+		return retryAfterHeader, true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
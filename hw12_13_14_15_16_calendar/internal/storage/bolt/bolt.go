@@ -0,0 +1,116 @@
+// Package bolt provides a persistent storage implementation based on the embedded bbolt key-value store.
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+// Buckets of the storage.
+//
+// Events are stored by ID, while indexes contain only keys referencing the events bucket.
+// Index keys are built to keep the events sorted by Datetime and ID, see datetimeKey.
+var (
+	bucketEvents   = []byte("events")      // Events encoded as JSON by ID.
+	bucketDatetime = []byte("by_datetime") // Index by datetime and ID.
+	bucketUser     = []byte("by_user")     // Index by user ID, datetime and ID.
+	bucketReminder = []byte("by_reminder") // Index of the events waiting for notification by reminder time and ID.
+	bucketMeta     = []byte("meta")        // Storage-wide values, such as the longest event duration.
+	bucketDigests  = []byte("digests")     // Daily digest settings encoded as JSON by user ID.
+
+	bucketResources = []byte("resources")   // Bookable resources encoded as JSON by ID.
+	bucketResource  = []byte("by_resource") // Index of the events by booked resource ID, datetime and ID.
+
+	bucketWebhooks   = []byte("webhooks")           // Webhook subscriptions encoded as JSON by ID.
+	bucketDeliveries = []byte("webhook_deliveries") // Webhook delivery log by subscription ID, time and attempt.
+
+	bucketChanges     = []byte("changes")        // Latest change of each event encoded as JSON by event ID.
+	bucketUserChanges = []byte("by_user_change") // Index of the changes by user ID, sequence number and event ID.
+)
+
+// Storage represents a persistent storage for events based on bbolt database file.
+type Storage struct {
+	mu      sync.RWMutex
+	db      *bbolt.DB
+	path    string
+	timeout time.Duration
+
+	watchMu  sync.Mutex
+	watchers map[chan struct{}]struct{} // Subscribers of the events changes.
+}
+
+// NewStorage creates a new Storage instance for the database file located at the given path.
+//
+// Timeout limits the time of waiting for the database file lock on connection, 0 means waiting indefinitely.
+// No connection is established upon the call.
+func NewStorage(path string, timeout time.Duration) (*Storage, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: empty database path", projectErrors.ErrCorruptedConfig)
+	}
+	return &Storage{
+		path:    path,
+		timeout: max(0, timeout),
+	}, nil
+}
+
+// Connect opens the database file, creating it and all the necessary buckets if they do not exist.
+// Method does nothing if the storage is already connected.
+//
+// The database file is locked exclusively, so it cannot be shared between several processes.
+func (s *Storage) Connect(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("storage connection: %w: %w", projectErrors.ErrTimeoutExceeded, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db != nil {
+		return nil
+	}
+
+	db, err := bbolt.Open(s.path, 0o600, &bbolt.Options{Timeout: s.timeout})
+	if err != nil {
+		return fmt.Errorf("storage connection: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		// Databases created before the reminder index are indexed on the first connection.
+		reindex := tx.Bucket(bucketReminder) == nil
+		for _, bucket := range [][]byte{
+			bucketEvents, bucketDatetime, bucketUser, bucketReminder, bucketMeta, bucketDigests,
+			bucketResources, bucketResource, bucketWebhooks, bucketDeliveries, bucketChanges, bucketUserChanges,
+		} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		if reindex {
+			return reindexEvents(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("storage connection: create buckets: %w", err)
+	}
+
+	s.db = db
+	return nil
+}
+
+// Close closes the database file. It is safe to call multiple times.
+func (s *Storage) Close(_ context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db != nil {
+		s.db.Close()
+		s.db = nil
+	}
+}
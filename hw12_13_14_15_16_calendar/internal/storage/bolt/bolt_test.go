@@ -0,0 +1,339 @@
+package bolt_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/bolt"         //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/suite"                                                    //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+type BoltSuite struct {
+	suite.Suite
+	path    string
+	storage *bolt.Storage
+	ctx     context.Context
+	start   time.Time
+}
+
+func TestBoltStorage(t *testing.T) {
+	suite.Run(t, new(BoltSuite))
+}
+
+func (s *BoltSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.path = filepath.Join(s.T().TempDir(), "calendar.db")
+	s.start = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var err error
+	s.storage, err = bolt.NewStorage(s.path, time.Second)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	s.Require().NoError(s.storage.Connect(s.ctx), "expected nil, got error on Connect")
+}
+
+func (s *BoltSuite) TearDownTest() {
+	s.storage.Close(s.ctx)
+}
+
+// newEvent creates and saves a new event of the given user starting at the given offset from s.start.
+func (s *BoltSuite) newEvent(userID string, offset time.Duration) *types.Event {
+	event, err := types.NewEvent("Test Event", s.start.Add(offset), time.Hour, "Description", userID, 30*time.Minute)
+	s.Require().NoError(err, "failed to create valid event")
+	_, err = s.storage.CreateEvent(s.ctx, event)
+	s.Require().NoError(err, "failed to save event")
+	return event
+}
+
+// ids returns the IDs of the given events.
+func ids(events []*types.Event) []uuid.UUID {
+	res := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		res = append(res, event.ID)
+	}
+	return res
+}
+
+func (s *BoltSuite) TestNewStorage() {
+	_, err := bolt.NewStorage("", time.Second)
+	s.Require().ErrorIs(err, projectErrors.ErrCorruptedConfig, "unexpected error type")
+}
+
+func (s *BoltSuite) TestUninitialized() {
+	storage, err := bolt.NewStorage(filepath.Join(s.T().TempDir(), "other.db"), time.Second)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+
+	_, err = storage.GetEvent(s.ctx, uuid.New())
+	s.Require().ErrorIs(err, projectErrors.ErrStorageUninitialized, "unexpected error type")
+
+	s.Require().NoError(storage.Connect(s.ctx), "expected nil, got error on Connect")
+	storage.Close(s.ctx)
+	storage.Close(s.ctx) // Safe to call multiple times.
+
+	_, err = storage.GetEventsForNotification(s.ctx)
+	s.Require().ErrorIs(err, projectErrors.ErrStorageUninitialized, "unexpected error type")
+}
+
+func (s *BoltSuite) TestPersistence() {
+	event := s.newEvent("user1", 0)
+	s.storage.Close(s.ctx)
+
+	storage, err := bolt.NewStorage(s.path, time.Second)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	s.Require().NoError(storage.Connect(s.ctx), "expected nil, got error on Connect")
+	defer storage.Close(s.ctx)
+
+	result, err := storage.GetEvent(s.ctx, event.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(event.ID, result.ID, "event ID mismatch")
+	s.Require().True(event.Datetime.Equal(result.Datetime), "event datetime mismatch")
+	s.Require().Equal(event.Duration, result.Duration, "event duration mismatch")
+
+	events, err := storage.GetAllUserEvents(s.ctx, "user1")
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{event.ID}, ids(events), "user index was not persisted")
+}
+
+func (s *BoltSuite) TestCreateEvent() {
+	event := s.newEvent("user1", 0)
+
+	s.Run("nil event", func() {
+		_, err := s.storage.CreateEvent(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "unexpected error type")
+	})
+
+	s.Run("duplicate ID", func() {
+		duplicate := types.DeepCopyEvent(event)
+		duplicate.Datetime = s.start.Add(24 * time.Hour)
+		_, err := s.storage.CreateEvent(s.ctx, duplicate)
+		s.Require().ErrorIs(err, projectErrors.ErrDataExists, "unexpected error type")
+	})
+
+	s.Run("overlapping event", func() {
+		overlapping, err := types.NewEvent("Overlap", s.start.Add(30*time.Minute), time.Hour, "", "user1", 0)
+		s.Require().NoError(err, "failed to create valid event")
+		_, err = s.storage.CreateEvent(s.ctx, overlapping)
+		s.Require().ErrorIs(err, projectErrors.ErrDateBusy, "unexpected error type")
+
+		overlapping.OverlapPolicy = types.OverlapPolicyAllow
+		_, err = s.storage.CreateEvent(s.ctx, overlapping)
+		s.Require().NoError(err, "overlap allowed by policy was rejected")
+	})
+
+	s.Run("back-to-back and other user events", func() {
+		s.newEvent("user1", -time.Hour)
+		s.newEvent("user2", 0)
+	})
+
+	s.Run("canceled context", func() {
+		ctx, cancel := context.WithCancel(s.ctx)
+		cancel()
+		other, err := types.NewEvent("Canceled", s.start.Add(48*time.Hour), time.Hour, "", "user1", 0)
+		s.Require().NoError(err, "failed to create valid event")
+		_, err = s.storage.CreateEvent(ctx, other)
+		s.Require().ErrorIs(err, projectErrors.ErrTimeoutExceeded, "unexpected error type")
+		_, err = s.storage.GetEvent(s.ctx, other.ID)
+		s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "event was saved despite canceled context")
+	})
+}
+
+func (s *BoltSuite) TestUpdateEvent() {
+	event := s.newEvent("user1", 0)
+	other := s.newEvent("user1", 2*time.Hour)
+
+	s.Run("not found", func() {
+		_, err := s.storage.UpdateEvent(s.ctx, uuid.New(), &event.EventData)
+		s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "unexpected error type")
+	})
+
+	s.Run("another user", func() {
+		data := types.DeepCopyEvent(event).EventData
+		data.UserID = "user2"
+		_, err := s.storage.UpdateEvent(s.ctx, event.ID, &data)
+		s.Require().ErrorIs(err, projectErrors.ErrPermissionDenied, "unexpected error type")
+	})
+
+	s.Run("overlap", func() {
+		data := types.DeepCopyEvent(event).EventData
+		data.Datetime = other.Datetime.Add(30 * time.Minute)
+		_, err := s.storage.UpdateEvent(s.ctx, event.ID, &data)
+		s.Require().ErrorIs(err, projectErrors.ErrDateBusy, "unexpected error type")
+	})
+
+	s.Run("datetime change reorders indexes", func() {
+		data := types.DeepCopyEvent(event).EventData
+		data.Datetime = s.start.Add(4 * time.Hour)
+		data.Title = "Updated"
+		updated, err := s.storage.UpdateEvent(s.ctx, event.ID, &data)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Equal("Updated", updated.Title, "title was not updated")
+
+		events, err := s.storage.GetAllUserEvents(s.ctx, "user1")
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Equal([]uuid.UUID{other.ID, event.ID}, ids(events), "unexpected events order")
+
+		_, err = s.storage.GetEventsForPeriod(s.ctx, s.start, s.start.Add(time.Hour), nil, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "stale datetime index entry was found")
+	})
+}
+
+func (s *BoltSuite) TestDeleteEvent() {
+	event := s.newEvent("user1", 0)
+
+	s.Require().NoError(s.storage.DeleteEvent(s.ctx, event.ID), "expected nil, got error")
+	s.Require().ErrorIs(s.storage.DeleteEvent(s.ctx, event.ID), projectErrors.ErrEventNotFound,
+		"unexpected error type")
+
+	_, err := s.storage.GetAllUserEvents(s.ctx, "user1")
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "user index entry was not deleted")
+}
+
+func (s *BoltSuite) TestGetEventsForPeriod() {
+	// User IDs sharing the prefix must not be mixed up.
+	early := s.newEvent("user1", -50*365*24*time.Hour) // Event before 1970 to check the keys order.
+	first := s.newEvent("user1", 0)
+	second := s.newEvent("user1", 3*time.Hour)
+	alt := s.newEvent("user10", time.Hour)
+
+	multiDay, err := types.NewEvent("Conference", s.start.Add(-48*time.Hour), 3*types.Day, "", "user2", 0)
+	s.Require().NoError(err, "failed to create valid event")
+	s.Require().NoError(multiDay.SetDetails([]string{"work"}, "", "", ""), "failed to set event details")
+	_, err = s.storage.CreateEvent(s.ctx, multiDay)
+	s.Require().NoError(err, "failed to save event")
+
+	user1 := "user1"
+	testCases := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		userID   *string
+		filter   *types.EventFilter
+		expected []uuid.UUID
+	}{
+		{"all users", s.start, s.start.Add(4 * time.Hour), nil, nil, []uuid.UUID{multiDay.ID, first.ID, alt.ID, second.ID}},
+		{"single user", s.start, s.start.Add(4 * time.Hour), &user1, nil, []uuid.UUID{first.ID, second.ID}},
+		{"with filter", s.start, s.start.Add(4 * time.Hour), nil, types.NewEventFilter([]string{"work"}, nil),
+			[]uuid.UUID{multiDay.ID}},
+		{"before 1970", early.Datetime, early.Datetime.Add(time.Minute), &user1, nil, []uuid.UUID{early.ID}},
+		{"no events", s.start.Add(24 * time.Hour), s.start.Add(48 * time.Hour), &user1, nil, nil},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := s.storage.GetEventsForPeriod(s.ctx, tC.start, tC.end, tC.userID, tC.filter)
+			if len(tC.expected) == 0 {
+				s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "unexpected error type")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().Equal(tC.expected, ids(events), "unexpected events")
+		})
+	}
+
+	s.Run("day, week and month", func() {
+		events, err := s.storage.GetEventsForDay(s.ctx, s.start, &user1, nil)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Len(events, 2, "unexpected events count for day")
+
+		events, err = s.storage.GetEventsForWeek(s.ctx, s.start, nil, nil)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Len(events, 4, "unexpected events count for week")
+
+		events, err = s.storage.GetEventsForMonth(s.ctx, s.start, nil, nil)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Len(events, 4, "unexpected events count for month")
+	})
+}
+
+func (s *BoltSuite) TestNotifications() {
+	due := s.newEvent("user1", 10*time.Minute)          // Reminder is already due.
+	future := s.newEvent("user1", 100*365*24*time.Hour) // Reminder is far in the future.
+	past := s.newEvent("user2", -24*time.Hour)          // Already notified event.
+	_, err := s.storage.UpdateNotifiedEvents(s.ctx, []uuid.UUID{past.ID})
+	s.Require().NoError(err, "expected nil, got error")
+
+	next, err := s.storage.GetNextReminderTime(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().True(due.Datetime.Add(-due.RemindIn).Equal(next), "unexpected next reminder time")
+
+	events, err := s.storage.GetEventsForNotification(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{due.ID}, ids(events), "unexpected events for notification")
+
+	count, err := s.storage.UpdateNotifiedEvents(s.ctx, []uuid.UUID{due.ID, uuid.New()})
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(int64(1), count, "unexpected updated events count")
+
+	_, err = s.storage.GetEventsForNotification(s.ctx)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "notified event was returned")
+
+	next, err = s.storage.GetNextReminderTime(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().True(future.Datetime.Add(-future.RemindIn).Equal(next), "unexpected next reminder time")
+
+	_, err = s.storage.UpdateNotifiedEvents(s.ctx, nil)
+	s.Require().ErrorIs(err, projectErrors.ErrNoData, "unexpected error type")
+}
+
+func (s *BoltSuite) TestDeleteOldEvents() {
+	old := s.newEvent("user1", -48*time.Hour)
+	boundary := s.newEvent("user2", 0) // Starts exactly at the given date, so it is kept.
+	actual := s.newEvent("user1", time.Hour)
+
+	count, err := s.storage.DeleteOldEvents(s.ctx, s.start)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(int64(1), count, "unexpected deleted events count")
+
+	_, err = s.storage.GetEvent(s.ctx, old.ID)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "old event was not deleted")
+	_, err = s.storage.GetEvent(s.ctx, boundary.ID)
+	s.Require().NoError(err, "boundary event was deleted")
+
+	events, err := s.storage.GetAllUserEvents(s.ctx, "user1")
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{actual.ID}, ids(events), "unexpected user events")
+}
+
+func (s *BoltSuite) TestReindex() {
+	long, err := types.NewEvent("Conference", s.start.Add(-48*time.Hour), 3*types.Day, "", "user1", time.Hour)
+	s.Require().NoError(err, "failed to create valid event")
+	_, err = s.storage.CreateEvent(s.ctx, long)
+	s.Require().NoError(err, "failed to save event")
+	short := s.newEvent("user2", 0)
+
+	// Imitating the database created before the reminder index.
+	s.storage.Close(s.ctx)
+	db, err := bbolt.Open(s.path, 0o600, nil)
+	s.Require().NoError(err, "failed to open database")
+	s.Require().NoError(db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket([]byte("by_reminder")); err != nil {
+			return err
+		}
+		return tx.DeleteBucket([]byte("meta"))
+	}), "failed to drop the reminder index")
+	s.Require().NoError(db.Close(), "failed to close database")
+	s.Require().NoError(s.storage.Connect(s.ctx), "expected nil, got error on Connect")
+
+	next, err := s.storage.GetNextReminderTime(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().True(long.Datetime.Add(-long.RemindIn).Equal(next), "reminder index is not rebuilt")
+
+	// The longest duration is restored, so the long event is found by the lookups starting after its start.
+	events, err := s.storage.GetEventsForPeriod(s.ctx, s.start, s.start.Add(time.Hour), nil, nil)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{long.ID, short.ID}, ids(events), "unexpected events")
+
+	overlapping, err := types.NewEvent("Overlapping", s.start.Add(-time.Hour), time.Hour, "", "user1", 0)
+	s.Require().NoError(err, "failed to create valid event")
+	_, err = s.storage.CreateEvent(s.ctx, overlapping)
+	s.Require().ErrorIs(err, projectErrors.ErrDateBusy, "overlap with the long event is not detected")
+
+	events, err = s.storage.GetEventsForReminderPeriod(s.ctx, s.start.Add(-time.Hour), s.start)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{short.ID}, ids(events), "unexpected events for reminder period")
+}
@@ -0,0 +1,210 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+const seqKeyLen = 8 // Length of the encoded sequence number.
+
+// metaPrunedSeq is the key of the sequence number of the last pruned tombstone in the meta bucket.
+var metaPrunedSeq = []byte("pruned_seq")
+
+// GetEventChanges retrieves the events of the given user, changed after the since sequence number,
+// and the IDs of the deleted ones, using the user change index. since = 0 means all events of the user.
+//
+// Returns ErrChangesExpired if since is unknown to the storage or the tombstones after it are already pruned.
+func (s *Storage) GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error) {
+	method := "get event changes: %w"
+
+	var res types.EventChanges
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		prunedSeq := getPrunedSeq(tx)
+		if since < 0 || since > currentSeq(tx) || (since > 0 && since < prunedSeq) {
+			return projectErrors.ErrChangesExpired
+		}
+		res.Seq = max(since, prunedSeq)
+
+		prefix := userPrefix(userID)
+		c := tx.Bucket(bucketUserChanges).Cursor()
+		for k, _ := c.Seek(append(prefix, seqKey(since+1)...)); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			key := k[len(prefix):]
+			if len(key) != seqKeyLen+idKeyLen {
+				return fmt.Errorf("%w: corrupted index key in bucket %s", projectErrors.ErrQeuryError, bucketUserChanges)
+			}
+			res.Seq = max(res.Seq, int64(binary.BigEndian.Uint64(key[:seqKeyLen]))) //nolint:gosec
+			if since == 0 {
+				continue
+			}
+
+			id, err := uuid.FromBytes(key[seqKeyLen:])
+			if err != nil {
+				return fmt.Errorf("%w: corrupted index key in bucket %s: %w", projectErrors.ErrQeuryError,
+					bucketUserChanges, err)
+			}
+			event, err := getEvent(tx, id)
+			if err != nil {
+				return err
+			}
+			if event == nil {
+				res.Deleted = append(res.Deleted, id)
+				continue
+			}
+			res.Events = append(res.Events, event)
+		}
+
+		if since > 0 {
+			return nil
+		}
+		return scanIndex(tx, bucketUser, prefix, time.Time{}, time.Time{}, func(event *types.Event) (bool, error) {
+			res.Events = append(res.Events, event)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &res, nil
+}
+
+// seqKey encodes the sequence number so the byte-wise order of the keys matches the numeric one.
+func seqKey(seq int64) []byte {
+	key := make([]byte, seqKeyLen)
+	binary.BigEndian.PutUint64(key, uint64(seq)) //nolint:gosec
+	return key
+}
+
+// userChangeKey returns the key of the change in the user change index.
+func userChangeKey(change *types.EventChange) []byte {
+	return append(append(userPrefix(change.UserID), seqKey(change.Seq)...), change.EventID[:]...)
+}
+
+// currentSeq returns the sequence number of the last change.
+func currentSeq(tx *bbolt.Tx) int64 {
+	return int64(tx.Bucket(bucketChanges).Sequence()) //nolint:gosec
+}
+
+// nextSeq returns the sequence number of the next change.
+func nextSeq(tx *bbolt.Tx) (int64, error) {
+	seq, err := tx.Bucket(bucketChanges).NextSequence()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return int64(seq), nil //nolint:gosec
+}
+
+// getPrunedSeq returns the sequence number of the last pruned tombstone, 0 if none were pruned.
+func getPrunedSeq(tx *bbolt.Tx) int64 {
+	data := tx.Bucket(bucketMeta).Get(metaPrunedSeq)
+	if len(data) != seqKeyLen {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(data)) //nolint:gosec
+}
+
+// getChange retrieves the latest change of the event with the given ID.
+// Returns (nil, nil) if the event has no changes.
+func getChange(tx *bbolt.Tx, id uuid.UUID) (*types.EventChange, error) {
+	data := tx.Bucket(bucketChanges).Get(id[:])
+	if data == nil {
+		return nil, nil
+	}
+	var change types.EventChange
+	if err := json.Unmarshal(data, &change); err != nil {
+		return nil, fmt.Errorf("%w: decode event change %s: %w", projectErrors.ErrQeuryError, id, err)
+	}
+	return &change, nil
+}
+
+// recordNextChange records the change of the event with the next sequence number.
+func recordNextChange(tx *bbolt.Tx, event *types.Event, deleted bool) error {
+	seq, err := nextSeq(tx)
+	if err != nil {
+		return err
+	}
+	return recordChange(tx, event, deleted, seq, time.Now())
+}
+
+// recordChange replaces the latest change of the event and its user change index entry.
+func recordChange(tx *bbolt.Tx, event *types.Event, deleted bool, seq int64, changedAt time.Time) error {
+	if err := deleteChange(tx, event.ID); err != nil {
+		return err
+	}
+
+	change := &types.EventChange{
+		EventID:   event.ID,
+		UserID:    event.UserID,
+		Seq:       seq,
+		Deleted:   deleted,
+		ChangedAt: changedAt,
+	}
+	data, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("%w: encode event change %s: %w", projectErrors.ErrQeuryError, event.ID, err)
+	}
+	if err := tx.Bucket(bucketChanges).Put(event.ID[:], data); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := tx.Bucket(bucketUserChanges).Put(userChangeKey(change), nil); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// deleteChange removes the latest change of the event with the given ID and its user change index entry, if any.
+func deleteChange(tx *bbolt.Tx, id uuid.UUID) error {
+	change, err := getChange(tx, id)
+	if err != nil || change == nil {
+		return err
+	}
+	if err := tx.Bucket(bucketChanges).Delete(id[:]); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := tx.Bucket(bucketUserChanges).Delete(userChangeKey(change)); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// pruneChanges removes the tombstones of the events deleted before the given date.
+// Changes since the last pruned tombstone remain available.
+func pruneChanges(tx *bbolt.Tx, date time.Time) error {
+	prunedSeq := getPrunedSeq(tx)
+	// Collecting the tombstones first, since the bucket must not be modified during the iteration.
+	var pruned []uuid.UUID
+	err := tx.Bucket(bucketChanges).ForEach(func(k, data []byte) error {
+		var change types.EventChange
+		if err := json.Unmarshal(data, &change); err != nil {
+			return fmt.Errorf("%w: decode event change %x: %w", projectErrors.ErrQeuryError, k, err)
+		}
+		if change.Deleted && change.ChangedAt.Before(date) {
+			pruned = append(pruned, change.EventID)
+			prunedSeq = max(prunedSeq, change.Seq)
+		}
+		return nil
+	})
+	if err != nil || len(pruned) == 0 {
+		return err
+	}
+
+	for _, id := range pruned {
+		if err := deleteChange(tx, id); err != nil {
+			return err
+		}
+	}
+	if err := tx.Bucket(bucketMeta).Put(metaPrunedSeq, seqKey(prunedSeq)); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package bolt_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"             //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/bolt"        //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/storagetest" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                 //nolint:depguard,nolintlint
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		t.Helper()
+		s, err := bolt.NewStorage(filepath.Join(t.TempDir(), "calendar.db"), time.Second)
+		require.NoError(t, err, "failed to create storage")
+		return s
+	})
+}
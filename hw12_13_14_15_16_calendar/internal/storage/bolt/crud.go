@@ -0,0 +1,231 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+// CreateEvent saves a new event in the storage within a single transaction.
+//
+// If the event already exists, it returns ErrDataExists.
+// If any of the booked resources does not exist, it returns ErrResourceNotFound.
+// If the event overlaps with another event and the event overlap policy forbids it,
+// or with another booking of the same resource, it returns DateBusyError wrapping ErrDateBusy.
+func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.Event, error) {
+	method := "create event: %w"
+	if event == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		existing, err := getEvent(tx, event.ID)
+		if err != nil {
+			return err
+		}
+		// Event with given ID already exists.
+		if existing != nil {
+			return projectErrors.ErrDataExists
+		}
+		if err := checkResources(tx, event.Resources); err != nil {
+			return err
+		}
+
+		conflicts, err := findConflicts(tx, event)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return projectErrors.NewDateBusyError(conflicts...)
+		}
+
+		if err := putEvent(tx, event); err != nil {
+			return err
+		}
+		return recordNextChange(tx, event, false)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+	s.notifyWatchers()
+
+	return types.DeepCopyEvent(event), nil
+}
+
+// UpdateEvent updates the event with the given ID within a single transaction.
+//
+// If the event does not exist, it returns ErrEventNotFound. If the event belongs to another user,
+// it returns ErrPermissionDenied. If any of the newly booked resources does not exist, it returns ErrResourceNotFound.
+// If it overlaps with another event and the event overlap policy forbids it,
+// or with another booking of the same resource, it returns DateBusyError wrapping ErrDateBusy.
+func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.EventData) (*types.Event, error) {
+	method := "update event: %w"
+	if data == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	var event *types.Event
+
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		existing, err := getEvent(tx, id)
+		if err != nil {
+			return err
+		}
+		// Event with given ID not exists.
+		if existing == nil {
+			return projectErrors.ErrEventNotFound
+		}
+
+		// Attempting to modify another user's event.
+		if existing.UserID != data.UserID {
+			return projectErrors.ErrPermissionDenied
+		}
+
+		event, err = types.UpdateEvent(id, data)
+		if err != nil {
+			return fmt.Errorf("unexpected error occurred: %w", err)
+		}
+		if err := checkResources(tx, event.AddedResources(&existing.EventData)); err != nil {
+			return err
+		}
+
+		conflicts, err := findConflicts(tx, event)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return projectErrors.NewDateBusyError(conflicts...)
+		}
+
+		// Index keys depend on the datetime, so the old entries are removed first.
+		if err := deleteEvent(tx, existing); err != nil {
+			return err
+		}
+		if err := putEvent(tx, event); err != nil {
+			return err
+		}
+		return recordNextChange(tx, event, false)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+	s.notifyWatchers()
+
+	return types.DeepCopyEvent(event), nil
+}
+
+// DeleteEvent deletes the event with the given ID from the storage.
+//
+// If the event does not exist, it returns ErrEventNotFound.
+func (s *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+	method := "delete event: %w"
+
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		event, err := getEvent(tx, id)
+		if err != nil {
+			return err
+		}
+		// Event with given ID not exists.
+		if event == nil {
+			return projectErrors.ErrEventNotFound
+		}
+		if err := deleteEvent(tx, event); err != nil {
+			return err
+		}
+		return recordNextChange(tx, event, true)
+	})
+	if err != nil {
+		return fmt.Errorf(method, err)
+	}
+
+	return nil
+}
+
+// UpdateNotifiedEvents updates the events with the given IDs in the storage as notified ones.
+//
+// If some of the IDs are not found in the storage, they will be ignored.
+//
+// Returns the number of updated events and nil on success, 0 and any error otherwise.
+func (s *Storage) UpdateNotifiedEvents(ctx context.Context, notifiedEvents []uuid.UUID) (int64, error) {
+	method := "update notified events: %w"
+	if len(notifiedEvents) == 0 {
+		return 0, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	var updatedCount int64
+
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		updatedCount = 0
+		for _, id := range notifiedEvents {
+			event, err := getEvent(tx, id)
+			if err != nil {
+				return err
+			}
+			if event == nil {
+				continue
+			}
+			// Notified event is removed from the reminder index, other index keys are not affected.
+			event.IsNotified = true
+			if err := putEvent(tx, event); err != nil {
+				return err
+			}
+			updatedCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(method, err)
+	}
+
+	return updatedCount, nil
+}
+
+// DeleteOldEvents deletes all events starting before the given date from the storage.
+// Tombstones of the events deleted before the given date are pruned from the change log.
+// Returns the number of deleted events and nil on success, 0 and any error otherwise.
+func (s *Storage) DeleteOldEvents(ctx context.Context, date time.Time) (int64, error) {
+	method := "delete old events: %w"
+
+	var deletedCount int64
+
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		// Collecting the events first, since the bucket must not be modified during the iteration.
+		var events []*types.Event
+		err := scanIndex(tx, bucketDatetime, nil, time.Time{}, date, func(event *types.Event) (bool, error) {
+			events = append(events, event)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := pruneChanges(tx, date); err != nil {
+			return err
+		}
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		changedAt := time.Now()
+		for _, event := range events {
+			if err := deleteEvent(tx, event); err != nil {
+				return err
+			}
+			if err := recordChange(tx, event, true, seq, changedAt); err != nil {
+				return err
+			}
+		}
+		deletedCount = int64(len(events))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(method, err)
+	}
+
+	return deletedCount, nil
+}
@@ -0,0 +1,136 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+// SetDigestSettings creates or replaces the daily digest settings of the user in the storage.
+// The date of the last sent digest is kept from the current settings.
+//
+// Returns the stored settings and nil on success, nil and any error otherwise.
+func (s *Storage) SetDigestSettings(
+	ctx context.Context,
+	settings *types.DigestSettings,
+) (*types.DigestSettings, error) {
+	method := "set digest settings: %w"
+	if settings == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *settings
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		stored.SentDate = ""
+		current, err := getDigestSettings(tx, stored.UserID)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			stored.SentDate = current.SentDate
+		}
+		return putDigestSettings(tx, &stored)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &stored, nil
+}
+
+// GetDigestSettings retrieves the daily digest settings of the user from the storage.
+// Returns nil and ErrDigestSettingsNotFound if the user has no settings.
+func (s *Storage) GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error) {
+	var res *types.DigestSettings
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		settings, err := getDigestSettings(tx, userID)
+		if err != nil {
+			return err
+		}
+		if settings == nil {
+			return projectErrors.ErrDigestSettingsNotFound
+		}
+		res = settings
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get digest settings: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetDigestSubscriptions retrieves the daily digest settings of all users with the digest enabled from the storage.
+// Returns the settings sorted by user ID.
+func (s *Storage) GetDigestSubscriptions(ctx context.Context) ([]*types.DigestSettings, error) {
+	var res []*types.DigestSettings
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		res = make([]*types.DigestSettings, 0)
+		return tx.Bucket(bucketDigests).ForEach(func(k, v []byte) error {
+			var settings types.DigestSettings
+			if err := json.Unmarshal(v, &settings); err != nil {
+				return fmt.Errorf("%w: decode digest settings %s: %w", projectErrors.ErrQeuryError, k, err)
+			}
+			if settings.Enabled {
+				res = append(res, &settings)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get digest subscriptions: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateDigestSent sets the local date of the last sent daily digest of the user in the storage.
+// Returns ErrDigestSettingsNotFound if the user has no settings.
+func (s *Storage) UpdateDigestSent(ctx context.Context, userID string, date string) error {
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		settings, err := getDigestSettings(tx, userID)
+		if err != nil {
+			return err
+		}
+		if settings == nil {
+			return projectErrors.ErrDigestSettingsNotFound
+		}
+		settings.SentDate = date
+		return putDigestSettings(tx, settings)
+	})
+	if err != nil {
+		return fmt.Errorf("update digest sent: %w", err)
+	}
+
+	return nil
+}
+
+// getDigestSettings retrieves the digest settings of the user from the digests bucket.
+// Returns (nil, nil) if the settings do not exist.
+func getDigestSettings(tx *bbolt.Tx, userID string) (*types.DigestSettings, error) {
+	data := tx.Bucket(bucketDigests).Get([]byte(userID))
+	if data == nil {
+		return nil, nil
+	}
+	var settings types.DigestSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("%w: decode digest settings %s: %w", projectErrors.ErrQeuryError, userID, err)
+	}
+	return &settings, nil
+}
+
+// putDigestSettings saves the digest settings of the user.
+func putDigestSettings(tx *bbolt.Tx, settings *types.DigestSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("%w: encode digest settings %s: %w", projectErrors.ErrQeuryError, settings.UserID, err)
+	}
+	if err := tx.Bucket(bucketDigests).Put([]byte(settings.UserID), data); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+type txMode int
+
+// Transaction types for helper calls.
+const (
+	readTx  txMode = iota // For read-only operations.
+	writeTx               // For operations modifying the data.
+)
+
+// withTx executes the given function in a database transaction of the given type.
+//
+// It checks if the storage is initialized and checks the context before the execution and before the commit,
+// so no changes are applied if the context is canceled. Any error returned by the function rolls the transaction back.
+func (s *Storage) withTx(ctx context.Context, mode txMode, fn func(tx *bbolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.db == nil {
+		return projectErrors.ErrStorageUninitialized
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrTimeoutExceeded, err)
+	}
+
+	exec := s.db.View
+	if mode == writeTx {
+		exec = s.db.Update
+	}
+
+	return exec(func(tx *bbolt.Tx) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		// Check context before applying changes.
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrTimeoutExceeded, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,333 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+// GetEvent retrieves the event with the given ID from the storage.
+//
+// If the event does not exist, it returns ErrEventNotFound.
+func (s *Storage) GetEvent(ctx context.Context, id uuid.UUID) (*types.Event, error) {
+	method := "get event: %w"
+
+	var event *types.Event
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		var err error
+		event, err = getEvent(tx, id)
+		if err != nil {
+			return err
+		}
+		// Event with given ID does not exist.
+		if event == nil {
+			return projectErrors.ErrEventNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return event, nil
+}
+
+// GetAllUserEvents retrieves all events for the given user from the storage using the user index.
+//
+// Returns a slice of events sorted by Datetime. If the user has no events, it returns an nil and ErrEventNotFound.
+func (s *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
+	method := "get all user events: %w"
+
+	var events []*types.Event
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		prefix := userPrefix(userID)
+		err := scanIndex(tx, bucketUser, prefix, time.Time{}, time.Time{}, func(event *types.Event) (bool, error) {
+			events = append(events, event)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		// No events for the user.
+		if len(events) == 0 {
+			return projectErrors.ErrEventNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return events, nil
+}
+
+// CountUserEvents counts the events of the given user by the keys of the user index, without reading the events.
+//
+// Returns the number of events, which is 0 if the user has no events.
+func (s *Storage) CountUserEvents(ctx context.Context, userID string) (int64, error) {
+	var count int64
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		prefix := userPrefix(userID)
+		c := tx.Bucket(bucketUser).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count user events: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetEventsForDay retrieves events for the specified day from the storage.
+// If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
+//
+// Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
+func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	dateStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	dateEnd := dateStart.AddDate(0, 0, 1)
+
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get events for day: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetEventsForWeek retrieves events for the week containing the specified date from the storage.
+// If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
+//
+// Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
+func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	// Weekday considering Monday as the first day of the week.
+	weekday := (int(date.Weekday()-time.Monday) + 7) % 7
+
+	// Truncating the date to the start of the week.
+	dateStart := date.AddDate(0, 0, -weekday).Truncate(24 * time.Hour)
+	dateEnd := dateStart.AddDate(0, 0, 7)
+
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get events for week: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetEventsForMonth retrieves events for the month containing the specified date from the storage.
+// If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
+//
+// Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
+func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	// Truncating the date to the start of the month.
+	dateStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	dateEnd := dateStart.AddDate(0, 1, 0)
+
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get events for month: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetEventsForPeriod retrieves events within the specified time period from the storage.
+// If userID is provided, it filters events for that user using the user index;
+// otherwise, it returns events for all users using the datetime index.
+// If filter is provided, only events matching it are returned.
+//
+// Returns a slice of events sorted by Datetime, considering all events intersecting [dateStart, dateEnd),
+// not only those starting within it. All-day events are matched by the calendar dates of the period.
+// If no events are found, it returns nil and ErrEventNotFound.
+func (s *Storage) GetEventsForPeriod(ctx context.Context,
+	dateStart, dateEnd time.Time,
+	userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
+	method := "get events for period: %w"
+
+	var events []*types.Event
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		bucket, prefix := bucketDatetime, []byte(nil)
+		if userID != nil {
+			bucket, prefix = bucketUser, userPrefix(*userID)
+		}
+
+		// All-day events might start earlier or later than the period due to date-only semantics.
+		allDayStart, allDayEnd := types.AllDayWindow(dateStart, dateEnd)
+		start, bound := dateStart, dateEnd
+		if allDayStart.Before(start) {
+			start = allDayStart
+		}
+		if allDayEnd.After(bound) {
+			bound = allDayEnd
+		}
+
+		// No events before the lower bound last long enough to intersect the period.
+		err := scanIndex(tx, bucket, prefix, lowerBound(tx, start), bound, func(event *types.Event) (bool, error) {
+			if event.Intersects(dateStart, dateEnd) && filter.Match(&event.EventData) {
+				events = append(events, event)
+			}
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return projectErrors.ErrEventNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return events, nil
+}
+
+// GetEventsForNotification retrieves events that need to be notified from the storage.
+//
+// Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
+func (s *Storage) GetEventsForNotification(ctx context.Context) ([]*types.Event, error) {
+	method := "get events for notification: %w"
+
+	var events []*types.Event
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		// Reminder index contains only the events waiting for notification. Bound includes the current time.
+		bound := time.Now().Add(time.Nanosecond)
+		err := scanIndex(tx, bucketReminder, nil, time.Time{}, bound, func(event *types.Event) (bool, error) {
+			events = append(events, event)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return projectErrors.ErrEventNotFound
+		}
+		slices.SortFunc(events, func(a, b *types.Event) int {
+			return bytes.Compare(datetimeKey(a), datetimeKey(b))
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return events, nil
+}
+
+// CountEventsForNotification counts the events that need to be notified by the keys of the reminder index,
+// without reading the events.
+//
+// Returns the number of events, which is 0 if there are none.
+func (s *Storage) CountEventsForNotification(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		// Reminder index contains only the events waiting for notification. Bound includes the current time.
+		boundKey := timeKey(time.Now().Add(time.Nanosecond))
+		c := tx.Bucket(bucketReminder).Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k[:timeKeyLen], boundKey) < 0; k, _ = c.Next() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count events for notification: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetNextReminderTime returns the earliest reminder time among the events which are still waiting for notification.
+//
+// If there are no such events, it returns ErrEventNotFound.
+func (s *Storage) GetNextReminderTime(ctx context.Context) (time.Time, error) {
+	method := "get next reminder time: %w"
+
+	var next time.Time
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		// The first event of the reminder index has the earliest reminder time.
+		err := scanIndex(tx, bucketReminder, nil, time.Time{}, time.Time{}, func(event *types.Event) (bool, error) {
+			next = event.Datetime.Add(-event.RemindIn)
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+		if next.IsZero() {
+			return projectErrors.ErrEventNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf(method, err)
+	}
+
+	return next, nil
+}
+
+// GetEventsForReminderPeriod returns the events whose reminder time is within [dateStart, dateEnd),
+// notified or not, sorted by the reminder time.
+//
+// If there are no such events, it returns ErrEventNotFound.
+func (s *Storage) GetEventsForReminderPeriod(ctx context.Context, dateStart, dateEnd time.Time) ([]*types.Event, error) {
+	method := "get events for reminder period: %w"
+
+	var events []*types.Event
+
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		// The reminder index contains only the events waiting for notification, so the datetime index is used.
+		// Events start not earlier than their reminder time and not later than the longest reminder offset after it.
+		bound := dateEnd.Add(getMax(tx, metaMaxRemindIn))
+		err := scanIndex(tx, bucketDatetime, nil, dateStart, bound, func(event *types.Event) (bool, error) {
+			remindAt := event.Datetime.Add(-event.RemindIn)
+			if event.RemindIn > 0 && !remindAt.Before(dateStart) && remindAt.Before(dateEnd) {
+				events = append(events, event)
+			}
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return projectErrors.ErrEventNotFound
+		}
+		slices.SortStableFunc(events, func(a, b *types.Event) int {
+			return a.Datetime.Add(-a.RemindIn).Compare(b.Datetime.Add(-b.RemindIn))
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return events, nil
+}
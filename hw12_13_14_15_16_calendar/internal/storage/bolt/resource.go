@@ -0,0 +1,158 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+// CreateResource saves the new bookable resource in the storage.
+// Returns the stored resource and nil on success, nil and any error otherwise.
+func (s *Storage) CreateResource(ctx context.Context, resource *types.Resource) (*types.Resource, error) {
+	method := "create resource: %w"
+	if resource == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *resource
+	stored.Attributes = slices.Clone(resource.Attributes)
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		if tx.Bucket(bucketResources).Get(stored.ID[:]) != nil {
+			return projectErrors.ErrDataExists
+		}
+		data, err := json.Marshal(&stored)
+		if err != nil {
+			return fmt.Errorf("%w: encode resource %s: %w", projectErrors.ErrQeuryError, stored.ID, err)
+		}
+		if err := tx.Bucket(bucketResources).Put(stored.ID[:], data); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &stored, nil
+}
+
+// GetResource retrieves the bookable resource from the storage.
+// Returns nil and ErrResourceNotFound if the resource does not exist.
+func (s *Storage) GetResource(ctx context.Context, id uuid.UUID) (*types.Resource, error) {
+	var res *types.Resource
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		data := tx.Bucket(bucketResources).Get(id[:])
+		if data == nil {
+			return projectErrors.ErrResourceNotFound
+		}
+		var resource types.Resource
+		if err := json.Unmarshal(data, &resource); err != nil {
+			return fmt.Errorf("%w: decode resource %s: %w", projectErrors.ErrQeuryError, id, err)
+		}
+		res = &resource
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get resource: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetResources retrieves all bookable resources from the storage.
+// Returns the resources sorted by the name.
+func (s *Storage) GetResources(ctx context.Context) ([]*types.Resource, error) {
+	var res []*types.Resource
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		res = make([]*types.Resource, 0)
+		return tx.Bucket(bucketResources).ForEach(func(k, v []byte) error {
+			var resource types.Resource
+			if err := json.Unmarshal(v, &resource); err != nil {
+				return fmt.Errorf("%w: decode resource %x: %w", projectErrors.ErrQeuryError, k, err)
+			}
+			res = append(res, &resource)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get resources: %w", err)
+	}
+
+	slices.SortFunc(res, func(a, b *types.Resource) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	return res, nil
+}
+
+// DeleteResource deletes the bookable resource from the storage. Past bookings of the resource are kept by the events.
+// The bookings are checked in the same transaction, so the resource can't be booked concurrently.
+// Returns ErrResourceNotFound if the resource does not exist, ErrResourceInUse if it is booked by the events
+// ending after since.
+func (s *Storage) DeleteResource(ctx context.Context, id uuid.UUID, since time.Time) error {
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		if tx.Bucket(bucketResources).Get(id[:]) == nil {
+			return projectErrors.ErrResourceNotFound
+		}
+		var ids []string
+		prefix, from := resourcePrefix(id.String()), lowerBound(tx, since)
+		err := scanIndex(tx, bucketResource, prefix, from, time.Time{}, func(event *types.Event) (bool, error) {
+			if event.Datetime.Add(event.Duration).After(since) {
+				ids = append(ids, event.ID.String())
+			}
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			return fmt.Errorf("%w: events=%v", projectErrors.ErrResourceInUse, ids)
+		}
+		if err := tx.Bucket(bucketResources).Delete(id[:]); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete resource: %w", err)
+	}
+
+	return nil
+}
+
+// GetResourceEvents retrieves the events booking the resource, which overlap with [dateStart, dateEnd).
+// Returns the events sorted by Datetime, the slice is empty if there are none.
+func (s *Storage) GetResourceEvents(
+	ctx context.Context,
+	resourceID uuid.UUID,
+	dateStart, dateEnd time.Time,
+) ([]*types.Event, error) {
+	var res []*types.Event
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		res = make([]*types.Event, 0)
+		prefix := resourcePrefix(resourceID.String())
+		from := lowerBound(tx, dateStart)
+		return scanIndex(tx, bucketResource, prefix, from, dateEnd, func(event *types.Event) (bool, error) {
+			if event.Datetime.Add(event.Duration).After(dateStart) {
+				res = append(res, event)
+			}
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get resource events: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,317 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+const (
+	timeKeyLen = 8  // Length of the encoded time.
+	idKeyLen   = 16 // Length of the encoded UUID.
+)
+
+// Keys of the meta bucket.
+var (
+	metaMaxDuration = []byte("max_duration")  // The longest duration of the stored events.
+	metaMaxRemindIn = []byte("max_remind_in") // The longest reminder offset of the stored events.
+)
+
+// timeKey encodes the time so the byte-wise order of the keys matches the chronological one.
+// The sign bit of the Unix nanoseconds is flipped to keep the order for times before 1970.
+func timeKey(t time.Time) []byte {
+	key := make([]byte, timeKeyLen)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano())^(1<<63)) //nolint:gosec
+	return key
+}
+
+// datetimeKey returns the key of the event in the datetime index.
+// Byte-wise order of the UUIDs matches the order of their string representations,
+// so the events are sorted the same way as in the memory storage.
+func datetimeKey(event *types.Event) []byte {
+	return append(timeKey(event.Datetime), event.ID[:]...)
+}
+
+// userPrefix returns the prefix of all keys of the given user in the user index.
+func userPrefix(userID string) []byte {
+	return append([]byte(userID), 0)
+}
+
+// userKey returns the key of the event in the user index.
+func userKey(event *types.Event) []byte {
+	return append(userPrefix(event.UserID), datetimeKey(event)...)
+}
+
+// resourcePrefix returns the prefix of all keys of the given resource in the resource index.
+func resourcePrefix(resourceID string) []byte {
+	return append([]byte(resourceID), 0)
+}
+
+// resourceKey returns the key of the event booking the given resource in the resource index.
+func resourceKey(resourceID string, event *types.Event) []byte {
+	return append(resourcePrefix(resourceID), datetimeKey(event)...)
+}
+
+// reminderKey returns the key of the event in the reminder index.
+func reminderKey(event *types.Event) []byte {
+	return append(timeKey(event.Datetime.Add(-event.RemindIn)), event.ID[:]...)
+}
+
+// isPending reports whether the event is waiting for notification, so it is kept in the reminder index.
+func isPending(event *types.Event) bool {
+	return event.RemindIn > 0 && !event.IsNotified
+}
+
+// getMax returns the duration stored in the meta bucket by the given key, 0 if it is not stored.
+func getMax(tx *bbolt.Tx, key []byte) time.Duration {
+	data := tx.Bucket(bucketMeta).Get(key)
+	if len(data) != 8 {
+		return 0
+	}
+	return time.Duration(binary.BigEndian.Uint64(data)) //nolint:gosec
+}
+
+// trackMax updates the duration stored in the meta bucket by the given key, if the given one is longer.
+// It is never decreased on removal, which only makes the lookups less narrow.
+func trackMax(tx *bbolt.Tx, key []byte, d time.Duration) error {
+	if d <= getMax(tx, key) {
+		return nil
+	}
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(d)) //nolint:gosec
+	if err := tx.Bucket(bucketMeta).Put(key, data); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// lowerBound returns the time to start the lookup of the events, which might end after the given time.
+// Preceding events start at least the longest stored duration before it, so they end before it.
+func lowerBound(tx *bbolt.Tx, t time.Time) time.Time {
+	return t.Add(-getMax(tx, metaMaxDuration))
+}
+
+// reindexEvents builds the reminder index and the meta values from the stored events.
+func reindexEvents(tx *bbolt.Tx) error {
+	return tx.Bucket(bucketEvents).ForEach(func(k, data []byte) error {
+		var event types.Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("%w: decode event %x: %w", projectErrors.ErrQeuryError, k, err)
+		}
+		return putIndexes(tx, &event)
+	})
+}
+
+// getEvent retrieves the event with the given ID from the events bucket.
+// Returns (nil, nil) if the event does not exist.
+func getEvent(tx *bbolt.Tx, id uuid.UUID) (*types.Event, error) {
+	data := tx.Bucket(bucketEvents).Get(id[:])
+	if data == nil {
+		return nil, nil
+	}
+	var event types.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("%w: decode event %s: %w", projectErrors.ErrQeuryError, id, err)
+	}
+	return &event, nil
+}
+
+// putEvent saves the event and its index entries.
+func putEvent(tx *bbolt.Tx, event *types.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w: encode event %s: %w", projectErrors.ErrQeuryError, event.ID, err)
+	}
+	if err := tx.Bucket(bucketEvents).Put(event.ID[:], data); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := putIndexes(tx, event); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketDatetime).Put(datetimeKey(event), nil); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := tx.Bucket(bucketUser).Put(userKey(event), nil); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	for _, resourceID := range event.Resources {
+		if err := tx.Bucket(bucketResource).Put(resourceKey(resourceID, event), nil); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+	}
+	return nil
+}
+
+// putIndexes updates the reminder index entry and the meta values with the event.
+// The reminder index entry is removed once the event is notified.
+func putIndexes(tx *bbolt.Tx, event *types.Event) error {
+	if err := trackMax(tx, metaMaxDuration, event.Duration); err != nil {
+		return err
+	}
+	if err := trackMax(tx, metaMaxRemindIn, event.RemindIn); err != nil {
+		return err
+	}
+	var err error
+	if isPending(event) {
+		err = tx.Bucket(bucketReminder).Put(reminderKey(event), nil)
+	} else {
+		err = tx.Bucket(bucketReminder).Delete(reminderKey(event))
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// deleteEvent removes the event and its index entries.
+func deleteEvent(tx *bbolt.Tx, event *types.Event) error {
+	if err := tx.Bucket(bucketEvents).Delete(event.ID[:]); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := tx.Bucket(bucketDatetime).Delete(datetimeKey(event)); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := tx.Bucket(bucketUser).Delete(userKey(event)); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if err := tx.Bucket(bucketReminder).Delete(reminderKey(event)); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	for _, resourceID := range event.Resources {
+		if err := tx.Bucket(bucketResource).Delete(resourceKey(resourceID, event)); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+	}
+	return nil
+}
+
+// scanIndex iterates over the events referenced by the index bucket keys with the given prefix
+// in ascending order by the key time and ID.
+//
+// If from is not zero, iteration starts at the first key with the time >= from.
+// If bound is not zero, iteration stops at the first key with the time >= bound.
+// Iteration also stops if fn returns false or an error.
+func scanIndex(tx *bbolt.Tx, bucket, prefix []byte, from, bound time.Time,
+	fn func(event *types.Event) (bool, error),
+) error {
+	var boundKey []byte
+	if !bound.IsZero() {
+		boundKey = timeKey(bound)
+	}
+	start := prefix
+	if !from.IsZero() {
+		start = append(slices.Clone(prefix), timeKey(from)...)
+	}
+
+	c := tx.Bucket(bucket).Cursor()
+	for k, _ := c.Seek(start); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		key := k[len(prefix):]
+		if len(key) != timeKeyLen+idKeyLen {
+			return fmt.Errorf("%w: corrupted index key in bucket %s", projectErrors.ErrQeuryError, bucket)
+		}
+		if boundKey != nil && bytes.Compare(key[:timeKeyLen], boundKey) >= 0 {
+			return nil
+		}
+
+		id, err := uuid.FromBytes(key[timeKeyLen:])
+		if err != nil {
+			return fmt.Errorf("%w: corrupted index key in bucket %s: %w", projectErrors.ErrQeuryError, bucket, err)
+		}
+		event, err := getEvent(tx, id)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			return fmt.Errorf("%w: index references missing event %s", projectErrors.ErrQeuryError, id)
+		}
+
+		next, err := fn(event)
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+// isOverlaps checks if the given event overlaps with any of the user events
+// and returns the IDs of the conflicting events according to the event overlap policy.
+// Returns nil if there are no conflicts (excluding the event itself).
+//
+// The same as in memory storage, the user events starting before the end of the given event and not earlier
+// than the longest stored duration before its start are checked, back-to-back events are not considered as overlapping.
+func isOverlaps(tx *bbolt.Tx, elem *types.Event) ([]string, error) {
+	if elem.OverlapPolicy == types.OverlapPolicyAllow {
+		return nil, nil
+	}
+	return overlapping(tx, bucketUser, userPrefix(elem.UserID), elem, func(event *types.Event) bool {
+		return elem.OverlapPolicy.IsConflict(elem.BusyStatus, event.BusyStatus)
+	})
+}
+
+// findConflicts returns the IDs of the events conflicting with the given one: the overlapping events of the same user,
+// forbidden by the overlap policy, followed by the overlapping bookings of the same resources.
+// Bookings of the same resource always conflict, regardless of the overlap policy and the busy statuses.
+func findConflicts(tx *bbolt.Tx, elem *types.Event) ([]string, error) {
+	res, err := isOverlaps(tx, elem)
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceID := range elem.Resources {
+		ids, err := overlapping(tx, bucketResource, resourcePrefix(resourceID), elem, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if !slices.Contains(res, id) {
+				res = append(res, id)
+			}
+		}
+	}
+	return res, nil
+}
+
+// overlapping returns the IDs of the events referenced by the index keys with the given prefix,
+// which overlap with the given event and satisfy the given condition.
+// Nil condition is satisfied by any event. The event itself is skipped.
+func overlapping(tx *bbolt.Tx, bucket, prefix []byte, elem *types.Event,
+	isConflict func(event *types.Event) bool,
+) ([]string, error) {
+	var conflicts []string
+	elemEnd := elem.Datetime.Add(elem.Duration)
+	err := scanIndex(tx, bucket, prefix, lowerBound(tx, elem.Datetime), elemEnd, func(event *types.Event) (bool, error) {
+		if event.ID == elem.ID || !event.Datetime.Add(event.Duration).After(elem.Datetime) {
+			return true, nil
+		}
+		if isConflict == nil || isConflict(event) {
+			conflicts = append(conflicts, event.ID.String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// checkResources returns ErrResourceNotFound if any of the given resources does not exist.
+func checkResources(tx *bbolt.Tx, ids []string) error {
+	for _, id := range ids {
+		resourceID, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("%w: id=%q", projectErrors.ErrResourceNotFound, id)
+		}
+		if tx.Bucket(bucketResources).Get(resourceID[:]) == nil {
+			return fmt.Errorf("%w: id=%s", projectErrors.ErrResourceNotFound, id)
+		}
+	}
+	return nil
+}
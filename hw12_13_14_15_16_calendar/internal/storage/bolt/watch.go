@@ -0,0 +1,51 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+)
+
+// WatchChanges subscribes to the events changes in the storage.
+// A signal is sent to the returned channel each time an event is created or updated.
+//
+// Signals are coalesced: a new one is dropped if the previous one has not been received yet.
+// The subscription is canceled and the channel is closed once the context is done.
+func (s *Storage) WatchChanges(ctx context.Context) (<-chan struct{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("watch changes: %w: %w", projectErrors.ErrTimeoutExceeded, err)
+	}
+
+	ch := make(chan struct{}, 1)
+
+	s.watchMu.Lock()
+	if s.watchers == nil {
+		s.watchers = make(map[chan struct{}]struct{})
+	}
+	s.watchers[ch] = struct{}{}
+	s.watchMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.watchMu.Lock()
+		defer s.watchMu.Unlock()
+		delete(s.watchers, ch)
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// notifyWatchers sends a non-blocking signal to all subscribers of the events changes.
+func (s *Storage) notifyWatchers() {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	for ch := range s.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"go.etcd.io/bbolt"                                                                     //nolint:depguard,nolintlint
+)
+
+// CreateWebhook saves the new webhook subscription in the storage.
+// Returns the stored subscription and nil on success, nil and any error otherwise.
+func (s *Storage) CreateWebhook(ctx context.Context, webhook *types.Webhook) (*types.Webhook, error) {
+	method := "create webhook: %w"
+	if webhook == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *webhook
+	stored.EventTypes = slices.Clone(webhook.EventTypes)
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		return putWebhook(tx, &stored)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &stored, nil
+}
+
+// GetWebhook retrieves the webhook subscription from the storage.
+// Returns nil and ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error) {
+	var res *types.Webhook
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		webhook, err := getWebhook(tx, id)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			return projectErrors.ErrWebhookNotFound
+		}
+		res = webhook
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWebhooks retrieves all webhook subscriptions from the storage.
+// Returns the subscriptions sorted by the creation time.
+func (s *Storage) GetWebhooks(ctx context.Context) ([]*types.Webhook, error) {
+	var res []*types.Webhook
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		res = make([]*types.Webhook, 0)
+		return tx.Bucket(bucketWebhooks).ForEach(func(k, v []byte) error {
+			var webhook types.Webhook
+			if err := json.Unmarshal(v, &webhook); err != nil {
+				return fmt.Errorf("%w: decode webhook %x: %w", projectErrors.ErrQeuryError, k, err)
+			}
+			res = append(res, &webhook)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+
+	slices.SortFunc(res, func(a, b *types.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	return res, nil
+}
+
+// DeleteWebhook deletes the webhook subscription along with its delivery log from the storage.
+// Returns ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		if tx.Bucket(bucketWebhooks).Get(id[:]) == nil {
+			return projectErrors.ErrWebhookNotFound
+		}
+		if err := tx.Bucket(bucketWebhooks).Delete(id[:]); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+
+		// Keys are collected first, as the bucket must not be modified during the iteration.
+		keys := make([][]byte, 0)
+		c := tx.Bucket(bucketDeliveries).Cursor()
+		for k, _ := c.Seek(id[:]); k != nil && bytes.HasPrefix(k, id[:]); k, _ = c.Next() {
+			keys = append(keys, slices.Clone(k))
+		}
+		for _, key := range keys {
+			if err := tx.Bucket(bucketDeliveries).Delete(key); err != nil {
+				return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhookState sets whether the webhook subscription is enabled and its number of consecutive failures
+// in the storage. Returns ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error {
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		webhook, err := getWebhook(tx, id)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			return projectErrors.ErrWebhookNotFound
+		}
+		webhook.Enabled = enabled
+		webhook.Failures = failures
+		return putWebhook(tx, webhook)
+	})
+	if err != nil {
+		return fmt.Errorf("update webhook state: %w", err)
+	}
+
+	return nil
+}
+
+// AddWebhookDelivery appends the delivery attempt to the delivery log in the storage.
+// Only the latest MaxWebhookDeliveries attempts of the subscription are kept.
+func (s *Storage) AddWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error {
+	method := "add webhook delivery: %w"
+	if delivery == nil {
+		return fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	err := s.withTx(ctx, writeTx, func(tx *bbolt.Tx) error {
+		data, err := json.Marshal(delivery)
+		if err != nil {
+			return fmt.Errorf("%w: encode webhook delivery %s: %w", projectErrors.ErrQeuryError, delivery.DeliveryID, err)
+		}
+		if err := tx.Bucket(bucketDeliveries).Put(deliveryKey(delivery), data); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return trimDeliveries(tx, delivery.WebhookID)
+	})
+	if err != nil {
+		return fmt.Errorf(method, err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the latest delivery attempts of the webhook subscription from the storage,
+// newest first. limit <= 0 means no limit.
+func (s *Storage) GetWebhookDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	limit int,
+) ([]*types.WebhookDelivery, error) {
+	var res []*types.WebhookDelivery
+	err := s.withTx(ctx, readTx, func(tx *bbolt.Tx) error {
+		res = make([]*types.WebhookDelivery, 0)
+		prefix := webhookID[:]
+
+		// Seeking to the key right after the prefix range and iterating backwards.
+		c := tx.Bucket(bucketDeliveries).Cursor()
+		k, v := c.Seek(prefixEnd(prefix))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil && bytes.HasPrefix(k, prefix); k, v = c.Prev() {
+			if limit > 0 && len(res) == limit {
+				break
+			}
+			var delivery types.WebhookDelivery
+			if err := json.Unmarshal(v, &delivery); err != nil {
+				return fmt.Errorf("%w: decode webhook delivery %x: %w", projectErrors.ErrQeuryError, k, err)
+			}
+			res = append(res, &delivery)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	return res, nil
+}
+
+// getWebhook retrieves the webhook subscription from the webhooks bucket.
+// Returns (nil, nil) if the subscription does not exist.
+func getWebhook(tx *bbolt.Tx, id uuid.UUID) (*types.Webhook, error) {
+	data := tx.Bucket(bucketWebhooks).Get(id[:])
+	if data == nil {
+		return nil, nil
+	}
+	var webhook types.Webhook
+	if err := json.Unmarshal(data, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: decode webhook %s: %w", projectErrors.ErrQeuryError, id, err)
+	}
+	return &webhook, nil
+}
+
+// putWebhook saves the webhook subscription.
+func putWebhook(tx *bbolt.Tx, webhook *types.Webhook) error {
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return fmt.Errorf("%w: encode webhook %s: %w", projectErrors.ErrQeuryError, webhook.ID, err)
+	}
+	if err := tx.Bucket(bucketWebhooks).Put(webhook.ID[:], data); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// deliveryKey returns the key of the delivery attempt, which keeps the attempts of the subscription
+// sorted by the time.
+func deliveryKey(delivery *types.WebhookDelivery) []byte {
+	key := make([]byte, 0, 2*idKeyLen+timeKeyLen+4)
+	key = append(key, delivery.WebhookID[:]...)
+	key = append(key, timeKey(delivery.CreatedAt)...)
+	key = append(key, delivery.DeliveryID[:]...)
+	return binary.BigEndian.AppendUint32(key, uint32(delivery.Attempt)) //nolint:gosec
+}
+
+// trimDeliveries deletes the oldest delivery attempts of the subscription beyond MaxWebhookDeliveries.
+func trimDeliveries(tx *bbolt.Tx, webhookID uuid.UUID) error {
+	prefix := webhookID[:]
+	var keys [][]byte
+	c := tx.Bucket(bucketDeliveries).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, k)
+	}
+
+	// Keys are ordered by the creation time, so the oldest attempts are the first ones.
+	for _, k := range keys[:max(0, len(keys)-types.MaxWebhookDeliveries)] {
+		if err := tx.Bucket(bucketDeliveries).Delete(k); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+	}
+	return nil
+}
+
+// prefixEnd returns the smallest key greater than all keys with the given prefix.
+// Prefix must not consist of 0xff bytes only.
+func prefixEnd(prefix []byte) []byte {
+	end := slices.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return end
+}
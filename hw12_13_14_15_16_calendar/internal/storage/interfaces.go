@@ -36,26 +36,56 @@ type Storage interface {
 	// Returns a slice of events or an error if not found or the operation fails.
 	GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error)
 
-	// GetEventsForDay retrieves events for a specific day, optionally filtered by user ID.
+	// CountUserEvents counts the events of a given user ID.
+	// Returns the number of events, which is 0 if the user has no events, or an error if the operation fails.
+	CountUserEvents(ctx context.Context, userID string) (int64, error)
+
+	// GetEventChanges retrieves the events of a given user ID, changed after the since sequence number,
+	// and the IDs of the deleted ones. since = 0 means all events of the user.
+	// Returns the changes or an error if the changes since the point are no longer kept or the operation fails.
+	GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error)
+
+	// GetEventsForDay retrieves events for a specific day, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForDay(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
-	// GetEventsForWeek retrieves events for a specific week, optionally filtered by user ID.
+	// GetEventsForWeek retrieves events for a specific week, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForWeek(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForWeek(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
-	// GetEventsForMonth retrieves events for a specific month, optionally filtered by user ID.
+	// GetEventsForMonth retrieves events for a specific month, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForMonth(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForMonth(ctx context.Context, date time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
-	// GetEventsForPeriod retrieves events for a given period, optionally filtered by user ID.
+	// GetEventsForPeriod retrieves events for a given period, optionally filtered by user ID and filter.
 	// Returns a slice of events or an error if not found or the operation fails.
-	GetEventsForPeriod(ctx context.Context, dateStart, dateEnd time.Time, userID *string) ([]*types.Event, error)
+	GetEventsForPeriod(ctx context.Context, dateStart, dateEnd time.Time, userID *string,
+		filter *types.EventFilter) ([]*types.Event, error)
 
 	// GetEventsForNotification retrieves events for notification, optionally filtered by user ID.
 	// Returns a slice of events or an error if not found or the operation fails.
 	GetEventsForNotification(ctx context.Context) ([]*types.Event, error)
 
+	// CountEventsForNotification counts the events, which require notification, without reading them.
+	// Returns the number of events, which is 0 if there are none, or an error if the operation fails.
+	CountEventsForNotification(ctx context.Context) (int64, error)
+
+	// GetNextReminderTime retrieves the earliest reminder time among the events waiting for notification.
+	// Returns the reminder time or an error if not found or the operation fails.
+	GetNextReminderTime(ctx context.Context) (time.Time, error)
+
+	// GetEventsForReminderPeriod retrieves events whose reminder time is within [dateStart, dateEnd),
+	// notified or not, sorted by the reminder time.
+	// Returns a slice of events or an error if not found or the operation fails.
+	GetEventsForReminderPeriod(ctx context.Context, dateStart, dateEnd time.Time) ([]*types.Event, error)
+
+	// WatchChanges subscribes to the changes of events waiting for notification.
+	// Returns a channel, receiving a signal on each change, or an error if the subscription fails.
+	WatchChanges(ctx context.Context) (<-chan struct{}, error)
+
 	// UpdateNotifiedEvents updates notified events in the storage.
 	// Returns the number of updated events or an error if the operation fails.
 	UpdateNotifiedEvents(ctx context.Context, notifiedEvents []uuid.UUID) (int64, error)
@@ -63,4 +93,80 @@ type Storage interface {
 	// DeleteOldEvents deletes old events from the storage.
 	// Returns the number of deleted events or an error if the operation fails.
 	DeleteOldEvents(ctx context.Context, date time.Time) (int64, error)
+
+	// SetDigestSettings creates or replaces the daily digest settings of the user,
+	// keeping the date of the last sent digest.
+	// Returns the stored settings or an error if the operation fails.
+	SetDigestSettings(ctx context.Context, settings *types.DigestSettings) (*types.DigestSettings, error)
+
+	// GetDigestSettings retrieves the daily digest settings of the user.
+	// Returns the settings or an error if not found or the operation fails.
+	GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error)
+
+	// GetDigestSubscriptions retrieves the daily digest settings of all users with the digest enabled.
+	// Returns a slice of settings, which is empty if there are no subscriptions, or an error if the operation fails.
+	GetDigestSubscriptions(ctx context.Context) ([]*types.DigestSettings, error)
+
+	// UpdateDigestSent sets the local date of the last sent daily digest of the user.
+	// Returns an error if the settings are not found or the operation fails.
+	UpdateDigestSent(ctx context.Context, userID string, date string) error
+
+	// CreateResource saves the new bookable resource.
+	// Returns the stored resource or an error if the operation fails.
+	CreateResource(ctx context.Context, resource *types.Resource) (*types.Resource, error)
+
+	// GetResource retrieves the bookable resource by ID.
+	// Returns the resource or an error if not found or the operation fails.
+	GetResource(ctx context.Context, id uuid.UUID) (*types.Resource, error)
+
+	// GetResources retrieves all bookable resources, sorted by the name.
+	// Returns a slice of resources, which is empty if there are none, or an error if the operation fails.
+	GetResources(ctx context.Context) ([]*types.Resource, error)
+
+	// DeleteResource deletes the bookable resource by ID, unless it is booked by the events ending after since.
+	// Past bookings of the resource are kept by the events.
+	// Returns an error if not found, still booked or the operation fails.
+	DeleteResource(ctx context.Context, id uuid.UUID, since time.Time) error
+
+	// GetResourceEvents retrieves the events booking the resource, which overlap with [dateStart, dateEnd),
+	// sorted by the datetime.
+	// Returns a slice of events, which is empty if there are none, or an error if the operation fails.
+	GetResourceEvents(ctx context.Context, resourceID uuid.UUID, dateStart, dateEnd time.Time) ([]*types.Event, error)
+
+	// CreateWebhook saves the new webhook subscription.
+	// Returns the stored subscription or an error if the operation fails.
+	CreateWebhook(ctx context.Context, webhook *types.Webhook) (*types.Webhook, error)
+
+	// GetWebhook retrieves the webhook subscription by ID.
+	// Returns the subscription or an error if not found or the operation fails.
+	GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error)
+
+	// GetWebhooks retrieves all webhook subscriptions, sorted by the creation time.
+	// Returns a slice of subscriptions, which is empty if there are none, or an error if the operation fails.
+	GetWebhooks(ctx context.Context) ([]*types.Webhook, error)
+
+	// DeleteWebhook deletes the webhook subscription by ID along with its delivery log.
+	// Returns an error if not found or the operation fails.
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+
+	// UpdateWebhookState sets whether the webhook subscription is enabled and its number of consecutive failures.
+	// Returns an error if not found or the operation fails.
+	UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error
+
+	// AddWebhookDelivery appends the delivery attempt to the delivery log.
+	// Returns an error if the operation fails.
+	AddWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error
+
+	// GetWebhookDeliveries retrieves the latest delivery attempts of the webhook subscription, newest first.
+	// limit <= 0 means no limit.
+	// Returns a slice of attempts, which is empty if there are none, or an error if the operation fails.
+	GetWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*types.WebhookDelivery, error)
+}
+
+// Migrator is implemented by the storages supporting schema migrations.
+type Migrator interface {
+	// Migrate executes the migration command ("up", "down", "status" or "redo") on the schema migrations
+	// or on the test data ones if seed is set.
+	// Returns a human-readable report or an error if the operation fails.
+	Migrate(ctx context.Context, command string, seed bool) (string, error)
 }
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// GetEventChanges retrieves the events of the given user, changed after the since sequence number,
+// and the IDs of the deleted ones. since = 0 means all events of the user.
+// Method imitates transactional behavior, checking the context before returning the result.
+//
+// Returns ErrChangesExpired if since is unknown to the storage or the tombstones after it are already pruned.
+// Events are sorted by Datetime.
+func (s *Storage) GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error) {
+	method := "get event changes: %w"
+
+	var res types.EventChanges
+
+	err := s.withLockAndChecks(ctx, func() error {
+		if since < 0 || since > s.changeSeq || (since > 0 && since < s.prunedSeq) {
+			return projectErrors.ErrChangesExpired
+		}
+
+		changes := s.changes[userID]
+		res.Seq = max(since, s.prunedSeq)
+		for _, change := range changes {
+			res.Seq = max(res.Seq, change.Seq)
+			if change.Deleted && change.Seq > since && since > 0 {
+				res.Deleted = append(res.Deleted, change.EventID)
+			}
+		}
+		for _, event := range s.userIndex[userID] {
+			// Events restored from the data without the change log have no changes.
+			if change, ok := changes[event.ID]; since == 0 || (ok && change.Seq > since) {
+				res.Events = append(res.Events, event)
+			}
+		}
+		return nil
+	}, nil, nil, readLock)
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	res.Events = deepCopySliceEvents(res.Events)
+	return &res, nil
+}
+
+// recordChange records the latest change of the event in the change log.
+//
+// Sequence number 0 means the next one, which is the case for the WAL records written before the change log
+// was introduced.
+func (s *Storage) recordChange(event *types.Event, deleted bool, seq int64, changedAt time.Time) {
+	if seq == 0 {
+		seq = s.changeSeq + 1
+	}
+	s.putChange(&types.EventChange{
+		EventID:   event.ID,
+		UserID:    event.UserID,
+		Seq:       seq,
+		Deleted:   deleted,
+		ChangedAt: changedAt,
+	})
+}
+
+// putChange puts the change to the change log, replacing the previous change of the same event.
+func (s *Storage) putChange(change *types.EventChange) {
+	if s.changes[change.UserID] == nil {
+		s.changes[change.UserID] = make(map[uuid.UUID]*types.EventChange)
+	}
+	s.changes[change.UserID][change.EventID] = change
+	s.changeSeq = max(s.changeSeq, change.Seq)
+}
+
+// prunedSeqBefore returns the sequence number of the change log, which is no longer available
+// after the tombstones changed before the given date are pruned.
+func (s *Storage) prunedSeqBefore(date time.Time) int64 {
+	prunedSeq := s.prunedSeq
+	for _, changes := range s.changes {
+		for _, change := range changes {
+			if change.Deleted && change.ChangedAt.Before(date) {
+				prunedSeq = max(prunedSeq, change.Seq)
+			}
+		}
+	}
+	return prunedSeq
+}
+
+// pruneChanges removes the tombstones up to the given sequence number from the change log.
+func (s *Storage) pruneChanges(prunedSeq int64) {
+	if prunedSeq <= s.prunedSeq {
+		return
+	}
+	for userID, changes := range s.changes {
+		for id, change := range changes {
+			if change.Deleted && change.Seq <= prunedSeq {
+				delete(changes, id)
+			}
+		}
+		if len(changes) == 0 {
+			delete(s.changes, userID)
+		}
+	}
+	s.prunedSeq = prunedSeq
+}
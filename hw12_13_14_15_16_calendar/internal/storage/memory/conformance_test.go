@@ -0,0 +1,32 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"             //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory"      //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/storagetest" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                 //nolint:depguard,nolintlint
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		t.Helper()
+		s, err := memory.NewStorage(1000)
+		require.NoError(t, err, "failed to create storage")
+		return s
+	})
+}
+
+func TestConformancePersistent(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		t.Helper()
+		s, err := memory.NewStorage(1000, memory.WithPersistence(memory.PersistenceConfig{
+			Dir:               t.TempDir(),
+			Fsync:             memory.FsyncAlways,
+			SnapshotThreshold: 3,
+		}))
+		require.NoError(t, err, "failed to create storage")
+		return s
+	})
+}
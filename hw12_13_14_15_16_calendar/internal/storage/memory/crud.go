@@ -14,7 +14,9 @@ import (
 // checking the context before applying changes.
 //
 // If the event already exists or the storage is full, it returns ErrDataExists or ErrStorageFull respectively.
-// If the event overlaps with another event, it returns ErrDateBusy.
+// If any of the booked resources does not exist, it returns ErrResourceNotFound.
+// If the event overlaps with another event and the event overlap policy forbids it,
+// or with another booking of the same resource, it returns DateBusyError wrapping ErrDateBusy.
 //
 // The event is inserted in a sorted order by Datetime, and if Datetime is equal,
 // it uses ID for deterministic ordering.
@@ -25,6 +27,8 @@ func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.E
 	}
 
 	var position, userPosition int // Positions for inserting the event in the inner data structure.
+	var seq int64                  // Sequence number of the change.
+	changedAt := time.Now()
 
 	err := s.withLockAndChecks(ctx, func() error {
 		// Event with given ID already exists.
@@ -32,8 +36,8 @@ func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.E
 			return projectErrors.ErrDataExists
 		}
 
-		// Storage is already full.
-		if len(s.events) == s.size {
+		// Storage is already full. Restored data might exceed the size if it was decreased.
+		if len(s.events) >= s.size {
 			return projectErrors.ErrStorageFull
 		}
 
@@ -41,23 +45,33 @@ func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.E
 		if s.userIndex[event.UserID] == nil {
 			s.userIndex[event.UserID] = []*types.Event{}
 		}
-		userPosition = s.findInsertPosition(s.userIndex[event.UserID], event)
-		if s.isOverlaps(s.userIndex[event.UserID], event, userPosition) {
-			return projectErrors.ErrDateBusy
+		if err := s.checkResources(event.Resources); err != nil {
+			return err
+		}
+		if conflicts := s.conflicts(s.userIndex[event.UserID], event); len(conflicts) > 0 {
+			return projectErrors.NewDateBusyError(conflicts...)
 		}
+		userPosition = s.findInsertPosition(s.userIndex[event.UserID], event)
 		position = s.findInsertPosition(s.events, event)
+		seq = s.changeSeq + 1
+		s.stage(&walRecord{Op: walPut, Event: event, Seq: seq, Time: changedAt})
 		return nil
 	},
 		func() {
 			// Applying changes.
 			s.idIndex[event.ID] = event
+			s.trackDuration(event)
 			s.events = s.insertElem(s.events, event, position)
 			s.userIndex[event.UserID] = s.insertElem(s.userIndex[event.UserID], event, userPosition)
+			s.addToTagIndex(event)
+			s.addToResourceIndex(event)
+			s.recordChange(event, false, seq, changedAt)
 		},
 		nil, writeLock)
 	if err != nil {
 		return nil, fmt.Errorf(method, err)
 	}
+	s.notifyWatchers()
 
 	return types.DeepCopyEvent(event), nil
 }
@@ -65,7 +79,12 @@ func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.E
 // UpdateEvent updates the event with the given ID in the in-memory storage.
 // Method is imitation transactional behaviour, checking the context before applying changes.
 //
-// If the event does not exist, it returns ErrEventNotFound. If it overlaps with another event, it returns ErrDateBusy.
+// If the event does not exist, it returns ErrEventNotFound. If any of the newly booked resources does not exist,
+// it returns ErrResourceNotFound. If it overlaps with another event and the event overlap policy forbids it,
+// or with another booking of the same resource, it returns DateBusyError wrapping ErrDateBusy.
+//
+// Resources booked by the event before the update are not checked for existence,
+// so the event remains updatable after its resource is deleted.
 func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.EventData) (*types.Event, error) {
 	method := "update event: %w"
 	if data == nil {
@@ -77,6 +96,8 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 	var sourceIndex int       // Index of the event in the userIndex slice.
 	var userPosition int      // Position for inserting the updated event in the userIndex slice.
 	var isRollbackNeeded bool // Flag to indicate if rollback is needed.
+	var seq int64             // Sequence number of the change.
+	changedAt := time.Now()
 
 	err := s.withLockAndChecks(ctx, func() error {
 		var ok bool
@@ -95,6 +116,9 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 		if err != nil {
 			return fmt.Errorf("unexpected error occurred: %w", err)
 		}
+		if err = s.checkResources(tmpEvent.AddedResources(&event.EventData)); err != nil {
+			return err
+		}
 
 		// Deleting the old event. No other inner structures are modified since the last possible error.
 		sourceIndex = s.getIndex(s.userIndex[event.UserID], event)
@@ -103,10 +127,13 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 		isRollbackNeeded = true // Since data was deleted, rollback is needed if the new event cannot be added.
 
 		// Determining if the new event overlaps with existing events.
-		userPosition = s.findInsertPosition(s.userIndex[event.UserID], tmpEvent)
-		if s.isOverlaps(s.userIndex[event.UserID], tmpEvent, userPosition) {
-			return projectErrors.ErrDateBusy
+		// The old event is still in the resource index, but it is skipped by ID.
+		if conflicts := s.conflicts(s.userIndex[event.UserID], tmpEvent); len(conflicts) > 0 {
+			return projectErrors.NewDateBusyError(conflicts...)
 		}
+		userPosition = s.findInsertPosition(s.userIndex[event.UserID], tmpEvent)
+		seq = s.changeSeq + 1
+		s.stage(&walRecord{Op: walPut, Event: tmpEvent, Seq: seq, Time: changedAt})
 
 		return nil
 	}, func() {
@@ -114,12 +141,18 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 		delete(s.idIndex, event.ID)
 		oldIndex := s.getIndex(s.events, event)
 		s.events = s.deleteElem(s.events, oldIndex)
+		s.removeFromTagIndex(event)
+		s.removeFromResourceIndex(event)
 
 		// Adding new event data.
 		s.idIndex[tmpEvent.ID] = tmpEvent
+		s.trackDuration(tmpEvent)
 		newIndex := s.findInsertPosition(s.events, tmpEvent)
 		s.events = s.insertElem(s.events, tmpEvent, newIndex)
 		s.userIndex[tmpEvent.UserID] = s.insertElem(s.userIndex[tmpEvent.UserID], tmpEvent, userPosition)
+		s.addToTagIndex(tmpEvent)
+		s.addToResourceIndex(tmpEvent)
+		s.recordChange(tmpEvent, false, seq, changedAt)
 	}, func() {
 		if !isRollbackNeeded {
 			return
@@ -129,6 +162,7 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 	if err != nil {
 		return nil, fmt.Errorf(method, err)
 	}
+	s.notifyWatchers()
 
 	return types.DeepCopyEvent(tmpEvent), nil
 }
@@ -141,6 +175,8 @@ func (s *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 	method := "delete event: %w"
 
 	var event *types.Event // Event to delete.
+	var seq int64          // Sequence number of the change.
+	changedAt := time.Now()
 
 	err := s.withLockAndChecks(ctx, func() error {
 		var ok bool
@@ -148,16 +184,12 @@ func (s *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 		if event, ok = s.idIndex[id]; !ok {
 			return projectErrors.ErrEventNotFound
 		}
+		seq = s.changeSeq + 1
+		s.stage(&walRecord{Op: walDelete, IDs: []uuid.UUID{id}, Seq: seq, Time: changedAt})
 		return nil
 	}, func() {
-		// Deleting old event data.
-		delete(s.idIndex, event.ID)
-		s.events = s.deleteElem(s.events, s.getIndex(s.events, event))
-		s.userIndex[event.UserID] = s.deleteElem(s.userIndex[event.UserID], s.getIndex(s.userIndex[event.UserID], event))
-		// User cache clean up.
-		if len(s.userIndex[event.UserID]) == 0 {
-			delete(s.userIndex, event.UserID)
-		}
+		s.removeEvent(event)
+		s.recordChange(event, true, seq, changedAt)
 	}, nil, writeLock)
 	if err != nil {
 		return fmt.Errorf(method, err)
@@ -177,18 +209,24 @@ func (s *Storage) UpdateNotifiedEvents(ctx context.Context, notifiedEvents []uui
 		return 0, fmt.Errorf(method, projectErrors.ErrNoData)
 	}
 
-	var updatedCount int64
+	var updatedIDs []uuid.UUID // IDs of the events existing in the storage.
 
 	err := s.withLockAndChecks(ctx,
-		nil,
-		func() {
-			// Updating the events with the given IDs if they exist in the storage.
+		func() error {
 			for _, id := range notifiedEvents {
 				if _, ok := s.idIndex[id]; ok {
-					s.idIndex[id].IsNotified = true
-					updatedCount++
+					updatedIDs = append(updatedIDs, id)
 				}
 			}
+			if len(updatedIDs) > 0 {
+				s.stage(&walRecord{Op: walNotify, IDs: updatedIDs})
+			}
+			return nil
+		},
+		func() {
+			for _, id := range updatedIDs {
+				s.idIndex[id].IsNotified = true
+			}
 		},
 		nil,
 		writeLock,
@@ -197,40 +235,46 @@ func (s *Storage) UpdateNotifiedEvents(ctx context.Context, notifiedEvents []uui
 		return 0, fmt.Errorf(method, err)
 	}
 
-	return updatedCount, nil
+	return int64(len(updatedIDs)), nil
 }
 
-// DeleteOldEvents deletes all events older than the given date from the database.
+// DeleteOldEvents deletes all events starting before the given date from the in-memory storage.
+// Tombstones of the events deleted before the given date are pruned from the change log.
 // Returns the number of deleted events and nil on success, 0 and any error otherwise.
 func (s *Storage) DeleteOldEvents(ctx context.Context, date time.Time) (int64, error) {
 	method := "delete old events: %w"
 
 	var events []*types.Event // Events to delete.
 	var deletedCount int64    // Number of deleted events.
+	var seq, prunedSeq int64  // Sequence numbers of the change and of the last pruned tombstone.
+	changedAt := time.Now()
 
 	err := s.withLockAndChecks(
 		ctx,
 		func() error {
 			// Creating a temporary event for getting the right index of the event to delete.
-			tmpEvent, _ := types.UpdateEvent(uuid.Max, &types.EventData{Datetime: date})
+			// Nil ID precedes any other one, so the events starting at the given date are kept.
+			tmpEvent, _ := types.UpdateEvent(uuid.Nil, &types.EventData{Datetime: date})
 			// Getting events to delete. If insert position == 0 -> all events are newer than requested.
+			var ids []uuid.UUID
 			for i := range s.findInsertPosition(s.events, tmpEvent) {
 				events = append(events, s.events[i])
+				ids = append(ids, s.events[i].ID)
+			}
+			seq = s.changeSeq + 1
+			prunedSeq = s.prunedSeqBefore(date)
+			if len(ids) > 0 || prunedSeq > s.prunedSeq {
+				s.stage(&walRecord{Op: walDelete, IDs: ids, Seq: seq, Time: changedAt, PrunedSeq: prunedSeq})
 			}
 			return nil
 		},
 		func() {
+			s.pruneChanges(prunedSeq)
 			// This part might be optimized but it is kept as-is for simplicity due to rare storage clean up.
 			for _, event := range events {
-				// Deleting old event data.
-				delete(s.idIndex, event.ID)
-				s.events = s.deleteElem(s.events, s.getIndex(s.events, event))
-				s.userIndex[event.UserID] = s.deleteElem(s.userIndex[event.UserID], s.getIndex(s.userIndex[event.UserID], event))
+				s.removeEvent(event)
+				s.recordChange(event, true, seq, changedAt)
 				deletedCount++
-				// User cache clean up.
-				if len(s.userIndex[event.UserID]) == 0 {
-					delete(s.userIndex, event.UserID)
-				}
 			}
 		},
 		nil,
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// SetDigestSettings creates or replaces the daily digest settings of the user in the in-memory storage.
+// The date of the last sent digest is kept from the current settings.
+//
+// Returns a copy of the stored settings and nil on success, nil and any error otherwise.
+func (s *Storage) SetDigestSettings(
+	ctx context.Context,
+	settings *types.DigestSettings,
+) (*types.DigestSettings, error) {
+	method := "set digest settings: %w"
+	if settings == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *settings
+	stored.SentDate = ""
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			if current, ok := s.digests[stored.UserID]; ok {
+				stored.SentDate = current.SentDate
+			}
+			s.stage(&walRecord{Op: walDigest, Digest: &stored})
+			return nil
+		},
+		func() {
+			s.digests[stored.UserID] = &stored
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	res := stored
+	return &res, nil
+}
+
+// GetDigestSettings retrieves a copy of the daily digest settings of the user from the in-memory storage.
+// Returns nil and ErrDigestSettingsNotFound if the user has no settings.
+func (s *Storage) GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error) {
+	var res *types.DigestSettings
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			settings, ok := s.digests[userID]
+			if !ok {
+				return projectErrors.ErrDigestSettingsNotFound
+			}
+			copied := *settings
+			res = &copied
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get digest settings: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetDigestSubscriptions retrieves copies of the daily digest settings of all users with the digest enabled
+// from the in-memory storage. Returns the settings sorted by user ID.
+func (s *Storage) GetDigestSubscriptions(ctx context.Context) ([]*types.DigestSettings, error) {
+	res := make([]*types.DigestSettings, 0)
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			for _, settings := range s.digests {
+				if settings.Enabled {
+					copied := *settings
+					res = append(res, &copied)
+				}
+			}
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get digest subscriptions: %w", err)
+	}
+
+	slices.SortFunc(res, func(a, b *types.DigestSettings) int { return cmp.Compare(a.UserID, b.UserID) })
+	return res, nil
+}
+
+// UpdateDigestSent sets the local date of the last sent daily digest of the user in the in-memory storage.
+// Returns ErrDigestSettingsNotFound if the user has no settings.
+func (s *Storage) UpdateDigestSent(ctx context.Context, userID string, date string) error {
+	var updated types.DigestSettings
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			settings, ok := s.digests[userID]
+			if !ok {
+				return projectErrors.ErrDigestSettingsNotFound
+			}
+			updated = *settings
+			updated.SentDate = date
+			s.stage(&walRecord{Op: walDigest, Digest: &updated})
+			return nil
+		},
+		func() {
+			s.digests[userID] = &updated
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return fmt.Errorf("update digest sent: %w", err)
+	}
+
+	return nil
+}
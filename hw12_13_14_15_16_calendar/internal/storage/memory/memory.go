@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
@@ -16,31 +17,79 @@ const defaultStorageSize = 10000 // Default maximum number of events in memory s
 // Storage represents an in-memory storage for events.
 type Storage struct {
 	mu        sync.RWMutex
-	size      int                        // Maximum number of events allowed.
-	events    []*types.Event             // Sorted slice of events (by Datetime).
-	idIndex   map[uuid.UUID]*types.Event // Index for fast lookup by event ID.
-	userIndex map[string][]*types.Event  // Index for fast lookup by user ID.
+	size      int                              // Maximum number of events allowed.
+	events    []*types.Event                   // Sorted slice of events (by Datetime).
+	idIndex   map[uuid.UUID]*types.Event       // Index for fast lookup by event ID.
+	userIndex map[string][]*types.Event        // Index for fast lookup by user ID.
+	tagIndex  map[string][]*types.Event        // Index for fast lookup by tag. Sorted the same way as userIndex.
+	digests   map[string]*types.DigestSettings // Daily digest settings by user ID.
+
+	// Longest duration of the events stored since the connection. Bounds the lookups of the intersecting events.
+	maxDuration time.Duration
+
+	resources     map[uuid.UUID]*types.Resource // Bookable resources by ID.
+	resourceIndex map[string][]*types.Event     // Index of the events by booked resource ID. Sorted as userIndex.
+
+	webhooks   map[uuid.UUID]*types.Webhook           // Webhook subscriptions by ID.
+	deliveries map[uuid.UUID][]*types.WebhookDelivery // Webhook delivery log by subscription ID, oldest first.
+
+	changes   map[string]map[uuid.UUID]*types.EventChange // Latest change of each event by user ID and event ID.
+	changeSeq int64                                       // Sequence number of the last change.
+	prunedSeq int64                                       // Sequence number of the last pruned tombstone.
+
+	watchMu  sync.Mutex
+	watchers map[chan struct{}]struct{} // Subscribers of the events changes.
+
+	persistence *PersistenceConfig // Nil if the data is not persisted.
+	wal         *wal               // Nil until Connect is called or if the data is not persisted.
+	pending     *walRecord         // Record of the current mutation, written before the changes are applied.
 }
 
+// StorageOption defines a function that allows to configure the Storage on construction.
+type StorageOption func(s *Storage)
+
 // NewStorage creates a new in-memory Storage instance with a maximum event limit.
 // If maxEvents is 0 or negative, a default limit of 10,000 is used.
 // No initialization of data structures is performed until Connect is called.
-func NewStorage(maxEvents int) (*Storage, error) {
+//
+// Returns ErrCorruptedConfig if the persistence settings are invalid.
+func NewStorage(maxEvents int, opts ...StorageOption) (*Storage, error) {
 	if maxEvents <= 0 {
 		maxEvents = defaultStorageSize
 	}
-	return &Storage{
+	s := &Storage{
 		size: maxEvents,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.persistence != nil {
+		if err := s.persistence.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
 }
 
 // Connect initializes the in-memory storage by creating the event slice and indexes.
 // It checks the context before applying initialization.
 // If the context is canceled, no changes are applied.
+//
+// If persistence is enabled, the data is restored from the last snapshot and the WAL.
+// Method does nothing in this case if the storage is already connected.
 func (s *Storage) Connect(ctx context.Context) error {
 	events := make([]*types.Event, 0)
 	idIndex := make(map[uuid.UUID]*types.Event)
 	userIndex := make(map[string][]*types.Event)
+	tagIndex := make(map[string][]*types.Event)
+	digests := make(map[string]*types.DigestSettings)
+	resources := make(map[uuid.UUID]*types.Resource)
+	resourceIndex := make(map[string][]*types.Event)
+	webhooks := make(map[uuid.UUID]*types.Webhook)
+	deliveries := make(map[uuid.UUID][]*types.WebhookDelivery)
+	changes := make(map[string]map[uuid.UUID]*types.EventChange)
 
 	if err := ctx.Err(); err != nil {
 		return fmt.Errorf("storage connection: %w: %w", projectErrors.ErrTimeoutExceeded, err)
@@ -48,19 +97,75 @@ func (s *Storage) Connect(ctx context.Context) error {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	if s.wal != nil {
+		return nil
+	}
+
 	s.events = events
+	s.maxDuration = 0
 	s.idIndex = idIndex
 	s.userIndex = userIndex
+	s.tagIndex = tagIndex
+	s.digests = digests
+	s.resources = resources
+	s.resourceIndex = resourceIndex
+	s.webhooks = webhooks
+	s.deliveries = deliveries
+	s.changes = changes
+	s.changeSeq, s.prunedSeq = 0, 0
+
+	if s.persistence == nil {
+		return nil
+	}
+	if err := s.restore(); err != nil {
+		s.events = nil
+		s.idIndex = nil
+		s.userIndex = nil
+		s.tagIndex = nil
+		s.digests = nil
+		s.resources = nil
+		s.resourceIndex = nil
+		s.webhooks = nil
+		s.deliveries = nil
+		s.changes = nil
+		return fmt.Errorf("storage connection: %w", err)
+	}
 	return nil
 }
 
 // Close clears the in-memory storage and releases resources.
 // It is safe to call multiple times.
+//
+// If persistence is enabled, a snapshot of the data is written before clearing it.
 func (s *Storage) Close(_ context.Context) {
+	// Background tasks acquire the storage lock, so they are stopped before locking it.
+	s.mu.RLock()
+	w := s.wal
+	s.mu.RUnlock()
+	if w != nil {
+		w.stop()
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.wal != nil {
+		// Data is still recoverable from the WAL on failure.
+		_ = s.writeSnapshot()
+		s.wal.close()
+		s.wal = nil
+	}
+
 	s.events = nil
+	s.maxDuration = 0
 	s.idIndex = nil
 	s.userIndex = nil
+	s.tagIndex = nil
+	s.digests = nil
+	s.resources = nil
+	s.resourceIndex = nil
+	s.webhooks = nil
+	s.deliveries = nil
+	s.changes = nil
 }
@@ -302,6 +302,94 @@ func (s *MemorySuite) TestCreateEvent() {
 	}
 }
 
+//nolint:funlen
+func (s *MemorySuite) TestOverlapPolicy() {
+	baseTime := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	newEvent := func(start time.Time, duration time.Duration, status types.BusyStatus,
+		policy types.OverlapPolicy,
+	) *types.Event {
+		event := s.createValidEvent()
+		event.Datetime = start
+		event.Duration = duration
+		event.BusyStatus = status
+		event.OverlapPolicy = policy
+		return event
+	}
+
+	testCases := []struct {
+		name      string
+		existing  []*types.Event
+		event     *types.Event
+		conflicts []int // Indexes of the existing events expected to conflict.
+	}{
+		{
+			name:      "reject overlaps with free event",
+			existing:  []*types.Event{newEvent(baseTime, time.Hour, types.BusyStatusFree, types.OverlapPolicyAllow)},
+			event:     newEvent(baseTime, time.Hour, types.BusyStatusBusy, types.OverlapPolicyReject),
+			conflicts: []int{0},
+		},
+		{
+			name:     "allow overlaps with busy event",
+			existing: []*types.Event{newEvent(baseTime, time.Hour, types.BusyStatusBusy, types.OverlapPolicyAllow)},
+			event:    newEvent(baseTime, time.Hour, types.BusyStatusBusy, types.OverlapPolicyAllow),
+		},
+		{
+			name:     "allow if free with free existing event",
+			existing: []*types.Event{newEvent(baseTime, time.Hour, types.BusyStatusFree, types.OverlapPolicyAllow)},
+			event:    newEvent(baseTime, time.Hour, types.BusyStatusBusy, types.OverlapPolicyAllowIfFree),
+		},
+		{
+			name:     "allow if free with tentative new event",
+			existing: []*types.Event{newEvent(baseTime, time.Hour, types.BusyStatusOutOfOffice, types.OverlapPolicyAllow)},
+			event:    newEvent(baseTime, time.Hour, types.BusyStatusTentative, types.OverlapPolicyAllowIfFree),
+		},
+		{
+			name:      "allow if free with both blocking events",
+			existing:  []*types.Event{newEvent(baseTime, time.Hour, types.BusyStatusOutOfOffice, types.OverlapPolicyAllow)},
+			event:     newEvent(baseTime, time.Hour, types.BusyStatusBusy, types.OverlapPolicyAllowIfFree),
+			conflicts: []int{0},
+		},
+		{
+			name: "conflict with non-adjacent event",
+			existing: []*types.Event{
+				newEvent(baseTime, 4*time.Hour, types.BusyStatusBusy, types.OverlapPolicyAllow),
+				newEvent(baseTime.Add(time.Hour), 30*time.Minute, types.BusyStatusBusy, types.OverlapPolicyAllow),
+			},
+			event:     newEvent(baseTime.Add(2*time.Hour), time.Hour, types.BusyStatusBusy, types.OverlapPolicyReject),
+			conflicts: []int{0},
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			storage, err := memory.NewStorage(s.defaultStorageSize)
+			s.Require().NoError(err, "expected nil, got error on NewStorage")
+			err = storage.Connect(context.Background())
+			s.Require().NoError(err, "expected nil, got error on Connect")
+
+			for _, event := range tC.existing {
+				_, err = storage.CreateEvent(context.Background(), event)
+				s.Require().NoError(err, "failed to prepare existing event")
+			}
+
+			_, err = storage.CreateEvent(context.Background(), tC.event)
+			if len(tC.conflicts) == 0 {
+				s.Require().NoError(err, "expected nil, got error")
+				return
+			}
+
+			s.Require().ErrorIs(err, errors.ErrDateBusy, "unexpected error type")
+			var busyErr *errors.DateBusyError
+			s.Require().ErrorAs(err, &busyErr, "expected DateBusyError")
+			expected := make([]string, 0, len(tC.conflicts))
+			for _, i := range tC.conflicts {
+				expected = append(expected, tC.existing[i].ID.String())
+			}
+			s.Require().Equal(expected, busyErr.ConflictingIDs, "unexpected conflicting IDs")
+		})
+	}
+}
+
 //nolint:gocognit,funlen
 func (s *MemorySuite) TestUpdateEvent() {
 	existingEventID := uuid.New()
@@ -1068,7 +1156,7 @@ func (s *MemorySuite) TestGetEventsForPeriod() {
 				tC.prepare(storage)
 			}
 
-			result, err := storage.GetEventsForPeriod(tC.ctx, tC.startDate, tC.endDate, tC.userID)
+			result, err := storage.GetEventsForPeriod(tC.ctx, tC.startDate, tC.endDate, tC.userID, nil)
 			if tC.wantErr != nil {
 				s.Require().ErrorIs(err, tC.wantErr, "unexpected error")
 				s.Require().Nil(result, "expected nil events")
@@ -1083,6 +1171,170 @@ func (s *MemorySuite) TestGetEventsForPeriod() {
 	}
 }
 
+func (s *MemorySuite) TestGetEventsForPeriod_Intersections() {
+	storage, err := memory.NewStorage(s.defaultStorageSize)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	err = storage.Connect(context.Background())
+	s.Require().NoError(err, "expected nil, got error on Connect")
+
+	// Multi-day conference, starting before the requested day.
+	conference := s.createValidEvent()
+	conference.Datetime = time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	conference.Duration = 3 * types.Day
+	_, err = storage.CreateEvent(context.Background(), conference)
+	s.Require().NoError(err, "failed to create multi-day event")
+
+	// All-day holiday of another user.
+	holiday := s.createValidEvent()
+	holiday.UserID = s.altUserID
+	holiday.Datetime = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	holiday.SetAllDay(true)
+	_, err = storage.CreateEvent(context.Background(), holiday)
+	s.Require().NoError(err, "failed to create all-day event")
+
+	newYork := time.FixedZone("UTC-5", -5*60*60)
+	testCases := []struct {
+		name     string
+		date     time.Time
+		expected []uuid.UUID
+	}{
+		{"start day", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), []uuid.UUID{conference.ID}},
+		{"middle day", time.Date(2025, 1, 2, 12, 0, 0, 0, newYork), []uuid.UUID{conference.ID, holiday.ID}},
+		{"end day", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), []uuid.UUID{conference.ID}},
+		{"day after end", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), nil},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := storage.GetEventsForDay(context.Background(), tC.date, nil, nil)
+			if len(tC.expected) == 0 {
+				s.Require().ErrorIs(err, errors.ErrEventNotFound, "unexpected error type")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+
+			ids := make([]uuid.UUID, 0, len(events))
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			s.Require().Equal(tC.expected, ids, "unexpected events for the day")
+		})
+	}
+}
+
+func (s *MemorySuite) TestGetEventsForPeriod_LongEvents() {
+	ctx := context.Background()
+	storage, err := memory.NewStorage(s.defaultStorageSize)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	err = storage.Connect(ctx)
+	s.Require().NoError(err, "expected nil, got error on Connect")
+
+	// Short events precede the requested day, so only the long ones might intersect it.
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		event := s.createValidEvent()
+		event.Datetime = start.Add(time.Duration(i) * time.Hour)
+		event.Duration = 30 * time.Minute
+		_, err = storage.CreateEvent(ctx, event)
+		s.Require().NoError(err, "failed to create short event")
+	}
+	day := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	_, err = storage.GetEventsForDay(ctx, day, nil, nil)
+	s.Require().ErrorIs(err, errors.ErrEventNotFound, "short events intersect the day")
+
+	// Event extended by the update must be found by its new end.
+	vacation := s.createValidEvent()
+	vacation.UserID = s.altUserID
+	vacation.Datetime = start
+	vacation.Duration = time.Hour
+	_, err = storage.CreateEvent(ctx, vacation)
+	s.Require().NoError(err, "failed to create event")
+	data := vacation.EventData
+	data.Duration = 10 * types.Day
+	_, err = storage.UpdateEvent(ctx, vacation.ID, &data)
+	s.Require().NoError(err, "failed to extend event")
+
+	events, err := storage.GetEventsForDay(ctx, day, nil, nil)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Len(events, 1, "unexpected number of events")
+	s.Require().Equal(vacation.ID, events[0].ID, "extended event is not found")
+}
+
+func (s *MemorySuite) TestGetEventsForPeriod_Filters() {
+	storage, err := memory.NewStorage(s.defaultStorageSize)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	err = storage.Connect(context.Background())
+	s.Require().NoError(err, "expected nil, got error on Connect")
+
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newEvent := func(userID string, offset time.Duration, tags []string, location string) *types.Event {
+		event := s.createValidEvent()
+		event.UserID = userID
+		event.Datetime = day.Add(offset)
+		s.Require().NoError(event.SetDetails(tags, "", location, ""), "failed to set event details")
+		_, err := storage.CreateEvent(context.Background(), event)
+		s.Require().NoError(err, "failed to create event")
+		return event
+	}
+
+	meeting := newEvent(s.userID, 9*time.Hour, []string{"Work", "meeting"}, "Room 1")
+	review := newEvent(s.userID, 11*time.Hour, []string{"work"}, "room 2")
+	gym := newEvent(s.userID, 18*time.Hour, []string{"personal"}, "")
+	alt := newEvent(s.altUserID, 9*time.Hour, []string{"work"}, "Room 1")
+
+	location := "ROOM 1"
+	testCases := []struct {
+		name     string
+		userID   *string
+		filter   *types.EventFilter
+		expected []uuid.UUID
+	}{
+		{"no filter", &s.userID, nil, []uuid.UUID{meeting.ID, review.ID, gym.ID}},
+		{"single tag", &s.userID, types.NewEventFilter([]string{"work"}, nil), []uuid.UUID{meeting.ID, review.ID}},
+		{"all tags", &s.userID, types.NewEventFilter([]string{"work", "meeting"}, nil), []uuid.UUID{meeting.ID}},
+		{"location", nil, types.NewEventFilter(nil, &location), []uuid.UUID{meeting.ID, alt.ID}},
+		{"tag of all users", nil, types.NewEventFilter([]string{" WORK "}, nil), []uuid.UUID{meeting.ID, alt.ID, review.ID}},
+		{"no matches", &s.userID, types.NewEventFilter([]string{"unknown"}, nil), nil},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := storage.GetEventsForDay(context.Background(), day, tC.userID, tC.filter)
+			if len(tC.expected) == 0 {
+				s.Require().ErrorIs(err, errors.ErrEventNotFound, "unexpected error type")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+
+			ids := make([]uuid.UUID, 0, len(events))
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			s.Require().ElementsMatch(tC.expected, ids, "unexpected events for the filter")
+		})
+	}
+
+	s.Run("tag index follows updates and deletions", func() {
+		data := &types.DeepCopyEvent(review).EventData
+		s.Require().NoError(data.SetDetails([]string{"personal"}, "", "", ""), "failed to set event details")
+		_, err := storage.UpdateEvent(context.Background(), review.ID, data)
+		s.Require().NoError(err, "failed to update event")
+		s.Require().NoError(storage.DeleteEvent(context.Background(), gym.ID), "failed to delete event")
+
+		filter := types.NewEventFilter([]string{"personal"}, nil)
+		events, err := storage.GetEventsForDay(context.Background(), day, &s.userID, filter)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Len(events, 1, "unexpected events count")
+		s.Require().Equal(review.ID, events[0].ID, "unexpected event")
+
+		filter = types.NewEventFilter([]string{"work"}, nil)
+		events, err = storage.GetEventsForDay(context.Background(), day, &s.userID, filter)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Len(events, 1, "unexpected events count")
+		s.Require().Equal(meeting.ID, events[0].ID, "unexpected event")
+	})
+}
+
 func (s *MemorySuite) TestGetEventsForPeriod_VariousSizes() {
 	startDate := time.Now().AddDate(0, 0, 1)
 	endDate := startDate.AddDate(0, 0, 10)
@@ -1159,7 +1411,7 @@ func (s *MemorySuite) TestGetEventsForPeriod_VariousSizes() {
 
 	for _, tC := range testCases {
 		s.Run(tC.name, func() {
-			result, err := storage.GetEventsForPeriod(context.Background(), tC.startDate, tC.endDate, tC.userID)
+			result, err := storage.GetEventsForPeriod(context.Background(), tC.startDate, tC.endDate, tC.userID, nil)
 			s.Require().NoError(err, "unexpected error")
 			if tC.isLessOrEqual {
 				s.Require().LessOrEqual(len(result), tC.eventCount, "event count exceeds expected")
@@ -1184,7 +1436,7 @@ func (s *MemorySuite) TestGetEventsForPeriod_VariousSizes() {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				_, err := storage.GetEventsForPeriod(context.Background(), startDate, endDate, &userID)
+				_, err := storage.GetEventsForPeriod(context.Background(), startDate, endDate, &userID, nil)
 				if err != nil {
 					errCh <- err
 				}
@@ -1197,3 +1449,79 @@ func (s *MemorySuite) TestGetEventsForPeriod_VariousSizes() {
 		}
 	})
 }
+
+func (s *MemorySuite) TestGetNextReminderTime() {
+	storage, err := memory.NewStorage(s.defaultStorageSize)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	err = storage.Connect(context.Background())
+	s.Require().NoError(err, "expected nil, got error on Connect")
+
+	_, err = storage.GetNextReminderTime(context.Background())
+	s.Require().ErrorIs(err, errors.ErrEventNotFound, "unexpected error type")
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Earlier event with the later reminder.
+	early := s.createValidEvent()
+	early.Datetime = start
+	early.RemindIn = time.Minute
+	_, err = storage.CreateEvent(context.Background(), early)
+	s.Require().NoError(err, "failed to create event")
+
+	// Later event with the earlier reminder.
+	late := s.createValidEvent()
+	late.Datetime = start.Add(2 * time.Hour)
+	late.RemindIn = 3 * time.Hour
+	_, err = storage.CreateEvent(context.Background(), late)
+	s.Require().NoError(err, "failed to create event")
+
+	// Event without reminder.
+	noReminder := s.createValidEvent()
+	noReminder.Datetime = start.Add(-24 * time.Hour)
+	noReminder.RemindIn = 0
+	_, err = storage.CreateEvent(context.Background(), noReminder)
+	s.Require().NoError(err, "failed to create event")
+
+	next, err := storage.GetNextReminderTime(context.Background())
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(start.Add(-time.Hour), next, "unexpected next reminder time")
+
+	_, err = storage.UpdateNotifiedEvents(context.Background(), []uuid.UUID{late.ID})
+	s.Require().NoError(err, "failed to update notified events")
+
+	next, err = storage.GetNextReminderTime(context.Background())
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(start.Add(-time.Minute), next, "notified event was not skipped")
+}
+
+func (s *MemorySuite) TestWatchChanges() {
+	storage, err := memory.NewStorage(s.defaultStorageSize)
+	s.Require().NoError(err, "expected nil, got error on NewStorage")
+	err = storage.Connect(context.Background())
+	s.Require().NoError(err, "expected nil, got error on Connect")
+
+	_, err = storage.WatchChanges(canceledContext())
+	s.Require().ErrorIs(err, errors.ErrTimeoutExceeded, "unexpected error type")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := storage.WatchChanges(ctx)
+	s.Require().NoError(err, "expected nil, got error")
+
+	// Several changes are coalesced into a single signal.
+	event := s.createValidEvent()
+	_, err = storage.CreateEvent(context.Background(), event)
+	s.Require().NoError(err, "failed to create event")
+	_, err = storage.UpdateEvent(context.Background(), event.ID, &event.EventData)
+	s.Require().NoError(err, "failed to update event")
+
+	s.Require().Len(changes, 1, "signals were not coalesced")
+	<-changes
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		s.Require().False(ok, "unexpected signal received")
+	case <-time.After(time.Second):
+		s.Fail("channel was not closed")
+	}
+}
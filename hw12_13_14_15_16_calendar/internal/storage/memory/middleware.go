@@ -15,6 +15,8 @@ import (
 // If any rollback function is provided, it will be called in case of an error during the operation or due to timeout.
 //
 // Both rollback and afterCtx functions are optional and can be nil. They also should not return any errors and panic.
+//
+// If beforeCtx stages a WAL record, it is written after the context check. Changes are not applied on write failure.
 func (s *Storage) withLockAndChecks(ctx context.Context,
 	beforeCtx func() error, afterCtx, rollback func(),
 	muMode mutexMode,
@@ -23,6 +25,7 @@ func (s *Storage) withLockAndChecks(ctx context.Context,
 	if muMode == writeLock {
 		s.mu.Lock()
 		defer s.mu.Unlock()
+		s.pending = nil
 	} else {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
@@ -56,6 +59,16 @@ func (s *Storage) withLockAndChecks(ctx context.Context,
 		return fmt.Errorf("%w: %w", projectErrors.ErrTimeoutExceeded, err)
 	}
 
+	// Persisting the changes before applying them.
+	if muMode == writeLock {
+		if err := s.flushPending(); err != nil {
+			if rollback != nil {
+				rollback()
+			}
+			return err
+		}
+	}
+
 	// Executing final actions.
 	if afterCtx != nil {
 		afterCtx()
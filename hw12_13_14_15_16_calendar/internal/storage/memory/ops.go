@@ -1,9 +1,14 @@
 package memory
 
 import (
+	"fmt"
+	"slices"
 	"sort"
+	"time"
 
-	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
 )
 
 // findInsertPosition finds the index in the events slice where a new event should be inserted
@@ -21,41 +26,96 @@ func (s *Storage) findInsertPosition(arr []*types.Event, elem *types.Event) int
 	})
 }
 
-// isOverlaps checks if the given event overlaps with any event in the sorted slice at the specified insertion position.
-// Returns true if there is an overlap (excluding the event itself), false otherwise.
+// isOverlaps checks if the given event overlaps with any event in the sorted slice
+// and returns the IDs of the conflicting events according to the event overlap policy.
+// Returns nil if there are no conflicts (excluding the event itself).
 //
 // IMPORTANT: case, where nextStart == prevEnd is not considered as overlap.
 // Therefore, if the event starts at the same time as the previous event ends,
 // it is considered as non-overlapping.
 // This behavior is consistent with the original implementation and allows for events to be scheduled back-to-back.
-func (s *Storage) isOverlaps(arr []*types.Event, elem *types.Event, pos int) bool {
-	elemEnd := elem.Datetime.Add(elem.Duration)
+func (s *Storage) isOverlaps(arr []*types.Event, elem *types.Event) []string {
+	if elem.OverlapPolicy == types.OverlapPolicyAllow {
+		return nil
+	}
+	return s.overlapping(arr, elem, func(event *types.Event) bool {
+		return elem.OverlapPolicy.IsConflict(elem.BusyStatus, event.BusyStatus)
+	})
+}
 
-	// Check for overlap with the previous event (if it exists).
-	if pos > 0 {
-		prev := arr[pos-1]
-		// Skip if prev is the same event.
-		if prev.ID != elem.ID {
-			prevEnd := prev.Datetime.Add(prev.Duration)
-			if prevEnd.After(elem.Datetime) && elemEnd.After(prev.Datetime) {
-				return true
+// isResourceOverlaps checks if the given event overlaps with any other event booking the same resources
+// and returns the IDs of the conflicting events. Bookings of the same resource always conflict,
+// regardless of the overlap policy and the busy statuses of the events.
+// Returns nil if there are no conflicts (excluding the event itself).
+func (s *Storage) isResourceOverlaps(elem *types.Event) []string {
+	var conflicts []string
+	for _, resource := range elem.Resources {
+		for _, id := range s.overlapping(s.resourceIndex[resource], elem, nil) {
+			if !slices.Contains(conflicts, id) {
+				conflicts = append(conflicts, id)
 			}
 		}
 	}
+	return conflicts
+}
 
-	// Check for overlap with the next event (if it exists).
-	if pos < len(arr) {
-		next := arr[pos]
-		// Skip if next is the same event.
-		if next.ID != elem.ID {
-			nextEnd := next.Datetime.Add(next.Duration)
-			if nextEnd.After(elem.Datetime) && elemEnd.After(next.Datetime) {
-				return true
-			}
+// conflicts returns the IDs of the events conflicting with the given one: the overlapping events of the same user,
+// forbidden by the overlap policy, followed by the overlapping bookings of the same resources.
+func (s *Storage) conflicts(userEvents []*types.Event, elem *types.Event) []string {
+	conflicts := s.isOverlaps(userEvents, elem)
+	for _, id := range s.isResourceOverlaps(elem) {
+		if !slices.Contains(conflicts, id) {
+			conflicts = append(conflicts, id)
+		}
+	}
+	return conflicts
+}
+
+// overlapping returns the IDs of the events in the sorted slice, which overlap with the given event
+// and satisfy the given condition. Nil condition is satisfied by any event. The event itself is skipped.
+//
+// Since overlaps might be allowed, checking only the neighbours of the insertion position
+// is not enough: any preceding event might last long enough to intersect with the given one.
+// Therefore, all events starting before the end of the given event and not earlier than the longest stored
+// duration before its start are checked.
+func (s *Storage) overlapping(arr []*types.Event, elem *types.Event, isConflict func(*types.Event) bool) []string {
+	elemEnd := elem.Datetime.Add(elem.Duration)
+	// Events starting at elemEnd or later cannot overlap with the given one.
+	bound := sort.Search(len(arr), func(i int) bool {
+		return !arr[i].Datetime.Before(elemEnd)
+	})
+	left := min(s.lowerBound(arr, elem.Datetime), bound)
+
+	var conflicts []string
+	for _, event := range arr[left:bound] {
+		// Skip if it is the same event.
+		if event.ID == elem.ID {
+			continue
+		}
+		if !event.Datetime.Add(event.Duration).After(elem.Datetime) {
+			continue
+		}
+		if isConflict == nil || isConflict(event) {
+			conflicts = append(conflicts, event.ID.String())
 		}
 	}
 
-	return false
+	return conflicts
+}
+
+// lowerBound returns the index of the first event in the sorted slice, which might end after the given time.
+// Preceding events start at least the longest stored duration before it, so they end before it.
+func (s *Storage) lowerBound(arr []*types.Event, t time.Time) int {
+	earliest := t.Add(-s.maxDuration)
+	return sort.Search(len(arr), func(i int) bool {
+		return arr[i].Datetime.After(earliest)
+	})
+}
+
+// trackDuration updates the longest duration of the stored events with the given event.
+// It is never decreased on removal, which only makes the lookups less narrow.
+func (s *Storage) trackDuration(event *types.Event) {
+	s.maxDuration = max(s.maxDuration, event.Duration)
 }
 
 // insertElem inserts a new event into the sorted slice at the specified position.
@@ -88,3 +148,106 @@ func (s *Storage) getIndex(arr []*types.Event, elem *types.Event) int {
 		return false
 	})
 }
+
+// putEvent inserts the event into all the inner structures, replacing the stored event with the same ID.
+// No overlap and size checks are performed.
+func (s *Storage) putEvent(event *types.Event) {
+	if old, ok := s.idIndex[event.ID]; ok {
+		s.removeEvent(old)
+	}
+	s.idIndex[event.ID] = event
+	s.trackDuration(event)
+	s.events = s.insertElem(s.events, event, s.findInsertPosition(s.events, event))
+	userEvents := s.userIndex[event.UserID]
+	s.userIndex[event.UserID] = s.insertElem(userEvents, event, s.findInsertPosition(userEvents, event))
+	s.addToTagIndex(event)
+	s.addToResourceIndex(event)
+}
+
+// removeEvent removes the event from all the inner structures. Empty user lists are removed from the index.
+func (s *Storage) removeEvent(event *types.Event) {
+	delete(s.idIndex, event.ID)
+	s.events = s.deleteElem(s.events, s.getIndex(s.events, event))
+	s.userIndex[event.UserID] = s.deleteElem(s.userIndex[event.UserID], s.getIndex(s.userIndex[event.UserID], event))
+	s.removeFromTagIndex(event)
+	s.removeFromResourceIndex(event)
+	// User cache clean up.
+	if len(s.userIndex[event.UserID]) == 0 {
+		delete(s.userIndex, event.UserID)
+	}
+}
+
+// addToTagIndex inserts the event into the tag index for each of its tags.
+func (s *Storage) addToTagIndex(elem *types.Event) {
+	for _, tag := range elem.Tags {
+		pos := s.findInsertPosition(s.tagIndex[tag], elem)
+		s.tagIndex[tag] = s.insertElem(s.tagIndex[tag], elem, pos)
+	}
+}
+
+// removeFromTagIndex removes the event from the tag index for each of its tags.
+// Empty tag lists are removed from the index.
+func (s *Storage) removeFromTagIndex(elem *types.Event) {
+	for _, tag := range elem.Tags {
+		s.tagIndex[tag] = s.deleteElem(s.tagIndex[tag], s.getIndex(s.tagIndex[tag], elem))
+		if len(s.tagIndex[tag]) == 0 {
+			delete(s.tagIndex, tag)
+		}
+	}
+}
+
+// addToResourceIndex inserts the event into the resource index for each of its booked resources.
+func (s *Storage) addToResourceIndex(elem *types.Event) {
+	for _, resource := range elem.Resources {
+		pos := s.findInsertPosition(s.resourceIndex[resource], elem)
+		s.resourceIndex[resource] = s.insertElem(s.resourceIndex[resource], elem, pos)
+	}
+}
+
+// removeFromResourceIndex removes the event from the resource index for each of its booked resources.
+// Empty resource lists are removed from the index.
+func (s *Storage) removeFromResourceIndex(elem *types.Event) {
+	for _, resource := range elem.Resources {
+		s.resourceIndex[resource] = s.deleteElem(s.resourceIndex[resource],
+			s.getIndex(s.resourceIndex[resource], elem))
+		if len(s.resourceIndex[resource]) == 0 {
+			delete(s.resourceIndex, resource)
+		}
+	}
+}
+
+// checkResources returns ErrResourceNotFound if any of the given resources does not exist.
+func (s *Storage) checkResources(ids []string) error {
+	for _, id := range ids {
+		resourceID, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("%w: id=%q", projectErrors.ErrResourceNotFound, id)
+		}
+		if _, ok := s.resources[resourceID]; !ok {
+			return fmt.Errorf("%w: id=%s", projectErrors.ErrResourceNotFound, id)
+		}
+	}
+	return nil
+}
+
+// selectSource returns the smallest sorted slice of events containing all events satisfying
+// the given user ID and the filter tags. Returns nil if any of the required indexes is empty.
+//
+// Returned slice might contain events of other users or without some of the tags, so the caller must filter it.
+func (s *Storage) selectSource(userID *string, filter *types.EventFilter) []*types.Event {
+	source := s.events
+	if userID != nil {
+		source = s.userIndex[*userID]
+	}
+	if filter == nil {
+		return source
+	}
+
+	for _, tag := range filter.Tags {
+		tagEvents := s.tagIndex[tag]
+		if len(tagEvents) < len(source) {
+			source = tagEvents
+		}
+	}
+	return source
+}
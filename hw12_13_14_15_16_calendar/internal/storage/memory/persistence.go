@@ -0,0 +1,561 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+const (
+	walFileName          = "wal.log"       // Name of the write-ahead log file in the persistence directory.
+	snapshotFileName     = "snapshot.json" // Name of the snapshot file in the persistence directory.
+	defaultFsyncInterval = time.Second     // Default interval of the WAL fsync for FsyncInterval policy.
+)
+
+// FsyncPolicy defines when the WAL is flushed to the disk.
+type FsyncPolicy string
+
+// Supported fsync policies.
+const (
+	FsyncAlways   FsyncPolicy = "always"   // Every record is flushed before the changes are applied.
+	FsyncInterval FsyncPolicy = "interval" // Records are flushed periodically. Default policy.
+	FsyncNever    FsyncPolicy = "never"    // Flushing is left to the OS.
+)
+
+// PersistenceConfig holds the settings of the storage persistence.
+type PersistenceConfig struct {
+	Dir               string        // Directory of the WAL and the snapshot files. Created if not exists.
+	Fsync             FsyncPolicy   // Empty means FsyncInterval.
+	FsyncInterval     time.Duration // Used with FsyncInterval policy. 0 means 1 second.
+	SnapshotInterval  time.Duration // Interval of the periodic snapshots. 0 disables them.
+	SnapshotThreshold int           // Number of WAL records triggering a snapshot. 0 disables the trigger.
+}
+
+// WithPersistence enables the storage persistence: every mutation is appended to the WAL before it is applied,
+// the WAL is compacted into a snapshot periodically and on Close, and both are replayed on Connect.
+func WithPersistence(cfg PersistenceConfig) StorageOption {
+	return func(s *Storage) {
+		s.persistence = &cfg
+	}
+}
+
+// validate checks the settings and fills the default values.
+func (c *PersistenceConfig) validate() error {
+	if c.Dir == "" {
+		return fmt.Errorf("%w: empty persistence directory", projectErrors.ErrCorruptedConfig)
+	}
+
+	switch c.Fsync {
+	case "":
+		c.Fsync = FsyncInterval
+	case FsyncAlways, FsyncInterval, FsyncNever:
+	default:
+		return fmt.Errorf("%w: unknown fsync policy %q, expected %q, %q or %q",
+			projectErrors.ErrCorruptedConfig, c.Fsync, FsyncAlways, FsyncInterval, FsyncNever)
+	}
+
+	if c.FsyncInterval <= 0 {
+		c.FsyncInterval = defaultFsyncInterval
+	}
+	c.SnapshotInterval = max(0, c.SnapshotInterval)
+	c.SnapshotThreshold = max(0, c.SnapshotThreshold)
+	return nil
+}
+
+type walOp string
+
+// WAL operations. Each of them is idempotent, so the WAL might be safely replayed over a newer snapshot.
+const (
+	walPut    walOp = "put"    // Event is created or replaced.
+	walDelete walOp = "delete" // Events are deleted. The tombstones up to the pruned sequence number are pruned.
+	walNotify walOp = "notify" // Events are marked as notified.
+	walDigest walOp = "digest" // Digest settings are created or replaced.
+
+	walResource       walOp = "resource"        // Resource is created.
+	walResourceDelete walOp = "resource_delete" // Resources are deleted.
+
+	walWebhook       walOp = "webhook"        // Webhook subscription is created or replaced.
+	walWebhookDelete walOp = "webhook_delete" // Webhook subscriptions are deleted along with their delivery logs.
+	walDelivery      walOp = "delivery"       // Webhook delivery attempt is appended to the log.
+)
+
+// walRecord is a single WAL entry, stored as a JSON line.
+type walRecord struct {
+	Op     walOp                 `json:"op"`
+	Event  *types.Event          `json:"event,omitempty"`
+	IDs    []uuid.UUID           `json:"ids,omitempty"`
+	Digest *types.DigestSettings `json:"digest,omitempty"`
+
+	// Change log position of the put and delete operations. Empty for the records written before the change log.
+	Seq       int64     `json:"seq,omitempty"`
+	Time      time.Time `json:"time,omitzero"`
+	PrunedSeq int64     `json:"pruned_seq,omitempty"` //nolint:tagliatelle
+
+	Resource *types.Resource        `json:"resource,omitempty"`
+	Webhook  *types.Webhook         `json:"webhook,omitempty"`
+	Delivery *types.WebhookDelivery `json:"delivery,omitempty"`
+}
+
+// snapshot is the content of the snapshot file.
+type snapshot struct {
+	Events  []*types.Event          `json:"events"`
+	Digests []*types.DigestSettings `json:"digests,omitempty"`
+
+	Resources  []*types.Resource        `json:"resources,omitempty"`
+	Webhooks   []*types.Webhook         `json:"webhooks,omitempty"`
+	Deliveries []*types.WebhookDelivery `json:"deliveries,omitempty"` // Oldest first.
+
+	Changes   []*types.EventChange `json:"changes,omitempty"`
+	ChangeSeq int64                `json:"change_seq,omitempty"` //nolint:tagliatelle
+	PrunedSeq int64                `json:"pruned_seq,omitempty"` //nolint:tagliatelle
+}
+
+// wal is an append-only log of the storage mutations.
+type wal struct {
+	mu      sync.Mutex
+	file    *os.File
+	size    int64 // Size of the file after the last successful write.
+	records int   // Number of records written since the last snapshot.
+	dirty   bool  // Whether there are records not flushed to the disk.
+	cfg     PersistenceConfig
+
+	compact  chan struct{} // Signals the threshold of records is reached.
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// openWAL opens the WAL file for appending. The file is expected to be replayed already.
+func openWAL(cfg PersistenceConfig, records int) (*wal, error) {
+	file, err := os.OpenFile(filepath.Join(cfg.Dir, walFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open wal: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("open wal: %w", err)
+	}
+
+	return &wal{
+		file:    file,
+		size:    info.Size(),
+		records: records,
+		cfg:     cfg,
+		compact: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// append writes the record to the log, flushing it if required by the fsync policy.
+// A partially written record is truncated, so the log stays readable.
+func (w *wal) append(rec *walRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode wal record: %w", err)
+	}
+	data = append(data, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.file.Write(data); err != nil {
+		_ = w.file.Truncate(w.size)
+		return fmt.Errorf("write wal record: %w", err)
+	}
+	if w.cfg.Fsync == FsyncAlways {
+		if err := w.file.Sync(); err != nil {
+			_ = w.file.Truncate(w.size)
+			return fmt.Errorf("sync wal: %w", err)
+		}
+	} else {
+		w.dirty = true
+	}
+	w.size += int64(len(data))
+	w.records++
+
+	if w.cfg.SnapshotThreshold > 0 && w.records >= w.cfg.SnapshotThreshold {
+		select {
+		case w.compact <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// sync flushes the written records to the disk.
+func (w *wal) sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.dirty {
+		return nil
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("sync wal: %w", err)
+	}
+	w.dirty = false
+	return nil
+}
+
+// reset truncates the log after its records were saved to a snapshot.
+func (w *wal) reset() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.file.Truncate(0); err != nil {
+		return fmt.Errorf("truncate wal: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("sync wal: %w", err)
+	}
+	w.size = 0
+	w.records = 0
+	w.dirty = false
+	return nil
+}
+
+// stop stops the background tasks and waits for them to finish. It is safe to call multiple times.
+func (w *wal) stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+	})
+	w.wg.Wait()
+}
+
+// close flushes and closes the log file.
+func (w *wal) close() {
+	_ = w.sync()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.file.Close()
+}
+
+// stage prepares the WAL record of the current mutation.
+// The record is written by withLockAndChecks right before the changes are applied.
+// Method does nothing if the persistence is disabled.
+func (s *Storage) stage(rec *walRecord) {
+	if s.wal != nil {
+		s.pending = rec
+	}
+}
+
+// flushPending writes the staged WAL record, if any. Storage lock is expected to be held for writing.
+func (s *Storage) flushPending() error {
+	rec := s.pending
+	s.pending = nil
+	if rec == nil || s.wal == nil {
+		return nil
+	}
+	if err := s.wal.append(rec); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// restore loads the snapshot, replays the WAL over it and starts the background tasks.
+// Storage structures are expected to be initialized and empty. Storage lock is expected to be held.
+func (s *Storage) restore() error {
+	cfg := *s.persistence
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return fmt.Errorf("create persistence directory: %w", err)
+	}
+
+	if err := s.loadSnapshot(); err != nil {
+		return err
+	}
+	records, err := s.replayWAL()
+	if err != nil {
+		return err
+	}
+
+	w, err := openWAL(cfg, records)
+	if err != nil {
+		return err
+	}
+	s.wal = w
+
+	w.wg.Add(1)
+	go s.runBackground(w)
+	return nil
+}
+
+// loadSnapshot applies the snapshot file content, if it exists.
+func (s *Storage) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.persistence.Dir, snapshotFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("%w: decode snapshot: %w", projectErrors.ErrPersistenceCorrupted, err)
+	}
+	for _, event := range snap.Events {
+		if event == nil {
+			return fmt.Errorf("%w: empty event in snapshot", projectErrors.ErrPersistenceCorrupted)
+		}
+		s.putEvent(event)
+	}
+	for _, settings := range snap.Digests {
+		if settings == nil {
+			return fmt.Errorf("%w: empty digest settings in snapshot", projectErrors.ErrPersistenceCorrupted)
+		}
+		s.digests[settings.UserID] = settings
+	}
+	for _, resource := range snap.Resources {
+		if resource == nil {
+			return fmt.Errorf("%w: empty resource in snapshot", projectErrors.ErrPersistenceCorrupted)
+		}
+		s.resources[resource.ID] = resource
+	}
+	for _, webhook := range snap.Webhooks {
+		if webhook == nil {
+			return fmt.Errorf("%w: empty webhook in snapshot", projectErrors.ErrPersistenceCorrupted)
+		}
+		s.webhooks[webhook.ID] = webhook
+	}
+	for _, delivery := range snap.Deliveries {
+		if delivery == nil {
+			return fmt.Errorf("%w: empty webhook delivery in snapshot", projectErrors.ErrPersistenceCorrupted)
+		}
+		s.appendDelivery(delivery)
+	}
+	for _, change := range snap.Changes {
+		if change == nil {
+			return fmt.Errorf("%w: empty event change in snapshot", projectErrors.ErrPersistenceCorrupted)
+		}
+		s.putChange(change)
+	}
+	s.changeSeq = max(s.changeSeq, snap.ChangeSeq)
+	s.prunedSeq = snap.PrunedSeq
+	return nil
+}
+
+// replayWAL applies the WAL records and returns their number.
+//
+// A torn record at the end of the log (a crash in the middle of the write) is discarded.
+// Any other malformed record is reported as ErrPersistenceCorrupted.
+func (s *Storage) replayWAL() (int, error) {
+	path := filepath.Join(s.persistence.Dir, walFileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read wal: %w", err)
+	}
+
+	var records int
+	var offset int
+	for offset < len(data) {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			// Torn record: no line ending is written yet.
+			if err := os.Truncate(path, int64(offset)); err != nil {
+				return 0, fmt.Errorf("truncate torn wal record: %w", err)
+			}
+			break
+		}
+
+		var rec walRecord
+		if err := json.Unmarshal(data[offset:offset+end], &rec); err != nil {
+			return 0, fmt.Errorf("%w: decode wal record %d: %w", projectErrors.ErrPersistenceCorrupted, records+1, err)
+		}
+		if err := s.applyRecord(&rec); err != nil {
+			return 0, fmt.Errorf("%w: apply wal record %d: %w", projectErrors.ErrPersistenceCorrupted, records+1, err)
+		}
+		records++
+		offset += end + 1
+	}
+
+	return records, nil
+}
+
+// applyRecord applies the WAL record to the storage. No overlap and size checks are performed,
+// since the record was already checked before it was written.
+func (s *Storage) applyRecord(rec *walRecord) error {
+	switch rec.Op {
+	case walPut:
+		if rec.Event == nil {
+			return errors.New("no event in put record")
+		}
+		s.putEvent(rec.Event)
+		s.recordChange(rec.Event, false, rec.Seq, rec.Time)
+	case walDelete:
+		s.pruneChanges(rec.PrunedSeq)
+		seq := rec.Seq
+		if seq == 0 {
+			seq = s.changeSeq + 1
+		}
+		for _, id := range rec.IDs {
+			if event, ok := s.idIndex[id]; ok {
+				s.removeEvent(event)
+				s.recordChange(event, true, seq, rec.Time)
+			}
+		}
+	case walNotify:
+		for _, id := range rec.IDs {
+			if event, ok := s.idIndex[id]; ok {
+				event.IsNotified = true
+			}
+		}
+	case walDigest:
+		if rec.Digest == nil {
+			return errors.New("no digest settings in digest record")
+		}
+		s.digests[rec.Digest.UserID] = rec.Digest
+	case walResource:
+		if rec.Resource == nil {
+			return errors.New("no resource in resource record")
+		}
+		s.resources[rec.Resource.ID] = rec.Resource
+	case walResourceDelete:
+		for _, id := range rec.IDs {
+			delete(s.resources, id)
+		}
+	case walWebhook:
+		if rec.Webhook == nil {
+			return errors.New("no webhook in webhook record")
+		}
+		s.webhooks[rec.Webhook.ID] = rec.Webhook
+	case walWebhookDelete:
+		for _, id := range rec.IDs {
+			delete(s.webhooks, id)
+			delete(s.deliveries, id)
+		}
+	case walDelivery:
+		if rec.Delivery == nil {
+			return errors.New("no delivery in delivery record")
+		}
+		if !s.hasDelivery(rec.Delivery) {
+			s.appendDelivery(rec.Delivery)
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", rec.Op)
+	}
+	return nil
+}
+
+// writeSnapshot atomically replaces the snapshot with the current storage content and resets the WAL.
+// Storage lock is expected to be held, at least for reading.
+func (s *Storage) writeSnapshot() error {
+	snap := snapshot{
+		Events:    s.events,
+		Digests:   slices.Collect(maps.Values(s.digests)),
+		Resources: slices.Collect(maps.Values(s.resources)),
+		Webhooks:  slices.Collect(maps.Values(s.webhooks)),
+		ChangeSeq: s.changeSeq,
+		PrunedSeq: s.prunedSeq,
+	}
+	for _, deliveries := range s.deliveries {
+		snap.Deliveries = append(snap.Deliveries, deliveries...)
+	}
+	for _, changes := range s.changes {
+		snap.Changes = slices.AppendSeq(snap.Changes, maps.Values(changes))
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	path := filepath.Join(s.persistence.Dir, snapshotFileName)
+	tmpPath := path + ".tmp"
+	if err := writeFileSync(tmpPath, data); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("replace snapshot: %w", err)
+	}
+	if err := syncDir(s.persistence.Dir); err != nil {
+		return fmt.Errorf("sync persistence directory: %w", err)
+	}
+
+	// Records are idempotent, so a crash before the reset only leads to their repeated replay.
+	return s.wal.reset()
+}
+
+// runBackground flushes the WAL and takes the snapshots according to the persistence settings until the WAL is stopped.
+//
+// Errors are not reported: the records are kept in the WAL until the next successful snapshot.
+func (s *Storage) runBackground(w *wal) {
+	defer w.wg.Done()
+
+	var fsyncC, snapshotC <-chan time.Time
+	if w.cfg.Fsync == FsyncInterval {
+		ticker := time.NewTicker(w.cfg.FsyncInterval)
+		defer ticker.Stop()
+		fsyncC = ticker.C
+	}
+	if w.cfg.SnapshotInterval > 0 {
+		ticker := time.NewTicker(w.cfg.SnapshotInterval)
+		defer ticker.Stop()
+		snapshotC = ticker.C
+	}
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-fsyncC:
+			_ = w.sync()
+		case <-snapshotC:
+			s.compact()
+		case <-w.compact:
+			s.compact()
+		}
+	}
+}
+
+// compact saves the storage content to the snapshot, blocking the mutations meanwhile.
+func (s *Storage) compact() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.wal == nil {
+		return
+	}
+	_ = s.writeSnapshot()
+}
+
+// writeFileSync writes the data to the file and flushes it to the disk.
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir flushes the directory entries, making the file renames durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
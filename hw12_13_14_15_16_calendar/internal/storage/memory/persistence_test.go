@@ -0,0 +1,405 @@
+package memory_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory"       //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// newPersistentStorage creates and connects a storage persisted in the given directory.
+func newPersistentStorage(t *testing.T, cfg memory.PersistenceConfig) *memory.Storage {
+	t.Helper()
+	s, err := memory.NewStorage(1000, memory.WithPersistence(cfg))
+	require.NoError(t, err, "failed to create storage")
+	require.NoError(t, s.Connect(context.Background()), "failed to connect to storage")
+	return s
+}
+
+// newPersistedEvent creates a valid event starting at the given hour of the test day.
+func newPersistedEvent(t *testing.T, hour int) *types.Event {
+	t.Helper()
+	event, err := types.NewEvent("Test Event", time.Date(2030, 1, 16, hour, 0, 0, 0, time.UTC),
+		time.Hour, "Description", "user1", 30*time.Minute)
+	require.NoError(t, err, "failed to create event")
+	return event
+}
+
+// copyDir copies the files of the persistence directory, imitating the state left after a crash.
+func copyDir(t *testing.T, src string) string {
+	t.Helper()
+	dst := t.TempDir()
+	entries, err := os.ReadDir(src)
+	require.NoError(t, err, "failed to read directory")
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(src, entry.Name()))
+		require.NoError(t, err, "failed to read file")
+		require.NoError(t, os.WriteFile(filepath.Join(dst, entry.Name()), data, 0o600), "failed to write file")
+	}
+	return dst
+}
+
+func TestNewStorageWithPersistence(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         memory.PersistenceConfig
+		expectedErr error
+	}{
+		{"defaults", memory.PersistenceConfig{Dir: "data"}, nil},
+		{"all options", memory.PersistenceConfig{
+			Dir:               "data",
+			Fsync:             memory.FsyncNever,
+			FsyncInterval:     time.Second,
+			SnapshotInterval:  time.Minute,
+			SnapshotThreshold: 100,
+		}, nil},
+		{"empty directory", memory.PersistenceConfig{}, projectErrors.ErrCorruptedConfig},
+		{"unknown fsync policy", memory.PersistenceConfig{Dir: "data", Fsync: "sometimes"}, projectErrors.ErrCorruptedConfig},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			s, err := memory.NewStorage(0, memory.WithPersistence(tC.cfg))
+			if tC.expectedErr != nil {
+				require.ErrorIs(t, err, tC.expectedErr, "unexpected error")
+				require.Nil(t, s, "expected nil storage")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+			require.NotNil(t, s, "expected non-nil storage")
+		})
+	}
+}
+
+func TestPersistenceRestore(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name  string
+		crash bool // Whether the storage is restored without Close, from the WAL only.
+	}{
+		{"after close", false},
+		{"after crash", true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := newPersistentStorage(t, memory.PersistenceConfig{Dir: dir, Fsync: memory.FsyncAlways})
+
+			kept, updated, deleted := newPersistedEvent(t, 10), newPersistedEvent(t, 12), newPersistedEvent(t, 14)
+			for _, event := range []*types.Event{kept, updated, deleted} {
+				_, err := s.CreateEvent(ctx, event)
+				require.NoError(t, err, "failed to create event")
+			}
+			data := updated.EventData
+			data.Title = "Updated"
+			data.Datetime = data.Datetime.Add(time.Hour)
+			_, err := s.UpdateEvent(ctx, updated.ID, &data)
+			require.NoError(t, err, "failed to update event")
+			require.NoError(t, s.DeleteEvent(ctx, deleted.ID), "failed to delete event")
+			_, err = s.UpdateNotifiedEvents(ctx, []uuid.UUID{kept.ID})
+			require.NoError(t, err, "failed to update notified events")
+
+			restoreDir := dir
+			if tC.crash {
+				restoreDir = copyDir(t, dir)
+				defer s.Close(ctx)
+			} else {
+				s.Close(ctx)
+			}
+
+			restored := newPersistentStorage(t, memory.PersistenceConfig{Dir: restoreDir})
+			defer restored.Close(ctx)
+
+			events, err := restored.GetAllUserEvents(ctx, "user1")
+			require.NoError(t, err, "failed to get events")
+			require.Len(t, events, 2, "unexpected number of events")
+
+			require.Equal(t, kept.ID, events[0].ID, "unexpected first event")
+			require.True(t, events[0].IsNotified, "notified flag is not restored")
+			require.Equal(t, updated.ID, events[1].ID, "unexpected second event")
+			require.Equal(t, "Updated", events[1].Title, "update is not restored")
+			require.True(t, data.Datetime.Equal(events[1].Datetime), "update is not restored")
+
+			_, err = restored.GetEvent(ctx, deleted.ID)
+			require.ErrorIs(t, err, projectErrors.ErrEventNotFound, "deletion is not restored")
+
+			// Restored indexes are usable for the new mutations.
+			_, err = restored.CreateEvent(ctx, newPersistedEvent(t, 10))
+			require.ErrorIs(t, err, projectErrors.ErrDateBusy, "overlap is not detected after restore")
+		})
+	}
+}
+
+func TestPersistenceTornRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := newPersistentStorage(t, memory.PersistenceConfig{Dir: dir, Fsync: memory.FsyncAlways})
+	event := newPersistedEvent(t, 10)
+	_, err := s.CreateEvent(ctx, event)
+	require.NoError(t, err, "failed to create event")
+
+	crashDir := copyDir(t, dir)
+	s.Close(ctx)
+
+	walPath := filepath.Join(crashDir, "wal.log")
+	valid, err := os.ReadFile(walPath)
+	require.NoError(t, err, "failed to read wal")
+	require.NoError(t, os.WriteFile(walPath, append(valid, []byte(`{"op":"put","event":{"id":`)...), 0o600))
+
+	restored := newPersistentStorage(t, memory.PersistenceConfig{Dir: crashDir})
+	defer restored.Close(ctx)
+
+	_, err = restored.GetEvent(ctx, event.ID)
+	require.NoError(t, err, "event before the torn record is lost")
+
+	// The torn record is discarded, so the new records are appended after the valid ones.
+	_, err = restored.CreateEvent(ctx, newPersistedEvent(t, 12))
+	require.NoError(t, err, "failed to create event")
+	data, err := os.ReadFile(walPath)
+	require.NoError(t, err, "failed to read wal")
+	require.True(t, strings.HasPrefix(string(data), string(valid)+`{"op":"put"`), "torn record is kept")
+}
+
+func TestPersistenceCorruptedRecord(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wal.log"), []byte("not a record\n{\"op\":\"delete\"}\n"), 0o600))
+
+	s, err := memory.NewStorage(0, memory.WithPersistence(memory.PersistenceConfig{Dir: dir}))
+	require.NoError(t, err, "failed to create storage")
+	err = s.Connect(context.Background())
+	require.ErrorIs(t, err, projectErrors.ErrPersistenceCorrupted, "unexpected error")
+
+	_, err = s.GetAllUserEvents(context.Background(), "user1")
+	require.ErrorIs(t, err, projectErrors.ErrStorageUninitialized, "storage is usable after failed restore")
+}
+
+func TestPersistenceCompaction(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	walPath := filepath.Join(dir, "wal.log")
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+
+	s := newPersistentStorage(t, memory.PersistenceConfig{Dir: dir, SnapshotThreshold: 3})
+	ids := make([]uuid.UUID, 0, 3)
+	for _, hour := range []int{10, 12, 14} {
+		event, err := s.CreateEvent(ctx, newPersistedEvent(t, hour))
+		require.NoError(t, err, "failed to create event")
+		ids = append(ids, event.ID)
+	}
+
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(walPath)
+		return err == nil && info.Size() == 0
+	}, 5*time.Second, 10*time.Millisecond, "wal is not compacted")
+	require.FileExists(t, snapshotPath, "snapshot is not written")
+
+	// Records after the snapshot are replayed over it.
+	require.NoError(t, s.DeleteEvent(ctx, ids[0]), "failed to delete event")
+	crashDir := copyDir(t, dir)
+	s.Close(ctx)
+
+	for _, restoreDir := range []string{dir, crashDir} {
+		restored := newPersistentStorage(t, memory.PersistenceConfig{Dir: restoreDir})
+		events, err := restored.GetAllUserEvents(ctx, "user1")
+		require.NoError(t, err, "failed to get events")
+		require.Len(t, events, 2, "unexpected number of events")
+		require.Equal(t, ids[1], events[0].ID, "unexpected first event")
+		require.Equal(t, ids[2], events[1].ID, "unexpected second event")
+		restored.Close(ctx)
+	}
+}
+
+func TestPersistenceWebhooks(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name  string
+		crash bool // Whether the storage is restored without Close, from the WAL only.
+	}{
+		{"after close", false},
+		{"after crash", true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := newPersistentStorage(t, memory.PersistenceConfig{Dir: dir, Fsync: memory.FsyncAlways})
+
+			kept, err := types.NewWebhook("user1", "http://localhost/hook", "secret", nil)
+			require.NoError(t, err, "failed to create webhook")
+			deleted, err := types.NewWebhook("", "http://localhost/all", "", nil)
+			require.NoError(t, err, "failed to create webhook")
+			for _, webhook := range []*types.Webhook{kept, deleted} {
+				_, err := s.CreateWebhook(ctx, webhook)
+				require.NoError(t, err, "failed to create webhook")
+				delivery := &types.WebhookDelivery{
+					DeliveryID: uuid.New(), Attempt: 1, WebhookID: webhook.ID, EventType: "created",
+					EventID: uuid.New(), Error: "connection refused", CreatedAt: time.Now().UTC(),
+				}
+				require.NoError(t, s.AddWebhookDelivery(ctx, delivery), "failed to add delivery")
+			}
+			require.NoError(t, s.UpdateWebhookState(ctx, kept.ID, false, 5), "failed to update webhook state")
+			require.NoError(t, s.DeleteWebhook(ctx, deleted.ID), "failed to delete webhook")
+
+			restoreDir := dir
+			if tC.crash {
+				restoreDir = copyDir(t, dir)
+				defer s.Close(ctx)
+			} else {
+				s.Close(ctx)
+			}
+
+			restored := newPersistentStorage(t, memory.PersistenceConfig{Dir: restoreDir})
+			defer restored.Close(ctx)
+
+			webhooks, err := restored.GetWebhooks(ctx)
+			require.NoError(t, err, "failed to get webhooks")
+			require.Len(t, webhooks, 1, "deletion is not restored")
+			require.Equal(t, kept.ID, webhooks[0].ID, "unexpected webhook")
+			require.False(t, webhooks[0].Enabled, "state is not restored")
+			require.Equal(t, 5, webhooks[0].Failures, "state is not restored")
+
+			deliveries, err := restored.GetWebhookDeliveries(ctx, kept.ID, 0)
+			require.NoError(t, err, "failed to get deliveries")
+			require.Len(t, deliveries, 1, "delivery log is not restored")
+			deliveries, err = restored.GetWebhookDeliveries(ctx, deleted.ID, 0)
+			require.NoError(t, err, "failed to get deliveries")
+			require.Empty(t, deliveries, "delivery log of the deleted webhook is restored")
+		})
+	}
+}
+
+func TestPersistenceResources(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name  string
+		crash bool // Whether the storage is restored without Close, from the WAL only.
+	}{
+		{"after close", false},
+		{"after crash", true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := newPersistentStorage(t, memory.PersistenceConfig{Dir: dir, Fsync: memory.FsyncAlways})
+
+			kept, err := types.NewResource("Room", "room", 6, []string{"projector"})
+			require.NoError(t, err, "failed to create resource")
+			deleted, err := types.NewResource("Projector", "equipment", 0, nil)
+			require.NoError(t, err, "failed to create resource")
+			for _, resource := range []*types.Resource{kept, deleted} {
+				_, err := s.CreateResource(ctx, resource)
+				require.NoError(t, err, "failed to create resource")
+			}
+			event := newPersistedEvent(t, 10)
+			event.Resources = []string{kept.ID.String()}
+			_, err = s.CreateEvent(ctx, event)
+			require.NoError(t, err, "failed to create event")
+			require.NoError(t, s.DeleteResource(ctx, deleted.ID, time.Now()), "failed to delete resource")
+
+			restoreDir := dir
+			if tC.crash {
+				restoreDir = copyDir(t, dir)
+				defer s.Close(ctx)
+			} else {
+				s.Close(ctx)
+			}
+
+			restored := newPersistentStorage(t, memory.PersistenceConfig{Dir: restoreDir})
+			defer restored.Close(ctx)
+
+			resources, err := restored.GetResources(ctx)
+			require.NoError(t, err, "failed to get resources")
+			require.Equal(t, []*types.Resource{kept}, resources, "resources are not restored")
+
+			// The resource index is rebuilt, so the restored booking still conflicts.
+			other := newPersistedEvent(t, 10)
+			other.UserID = "user2"
+			other.Resources = []string{kept.ID.String()}
+			_, err = restored.CreateEvent(ctx, other)
+			require.ErrorIs(t, err, projectErrors.ErrDateBusy, "resource index is not restored")
+		})
+	}
+}
+
+func TestPersistenceEventChanges(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name  string
+		crash bool // Whether the storage is restored without Close, from the WAL only.
+	}{
+		{"after close", false},
+		{"after crash", true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := newPersistentStorage(t, memory.PersistenceConfig{Dir: dir, Fsync: memory.FsyncAlways})
+
+			old, deleted, updated := newPersistedEvent(t, 10), newPersistedEvent(t, 12), newPersistedEvent(t, 14)
+			for _, event := range []*types.Event{old, deleted, updated} {
+				_, err := s.CreateEvent(ctx, event)
+				require.NoError(t, err, "failed to create event")
+			}
+			full, err := s.GetEventChanges(ctx, "user1", 0)
+			require.NoError(t, err, "failed to get changes")
+			require.NoError(t, s.DeleteEvent(ctx, deleted.ID), "failed to delete event")
+			afterDelete, err := s.GetEventChanges(ctx, "user1", full.Seq)
+			require.NoError(t, err, "failed to get changes")
+
+			// The tombstone of the deleted event is pruned, the old event is deleted.
+			_, err = s.DeleteOldEvents(ctx, time.Date(2030, 1, 16, 11, 0, 0, 0, time.UTC))
+			require.NoError(t, err, "failed to delete old events")
+			data := updated.EventData
+			data.Title = "Updated"
+			_, err = s.UpdateEvent(ctx, updated.ID, &data)
+			require.NoError(t, err, "failed to update event")
+			expected, err := s.GetEventChanges(ctx, "user1", afterDelete.Seq)
+			require.NoError(t, err, "failed to get changes")
+
+			restoreDir := dir
+			if tC.crash {
+				restoreDir = copyDir(t, dir)
+				defer s.Close(ctx)
+			} else {
+				s.Close(ctx)
+			}
+
+			restored := newPersistentStorage(t, memory.PersistenceConfig{Dir: restoreDir})
+			defer restored.Close(ctx)
+
+			_, err = restored.GetEventChanges(ctx, "user1", full.Seq)
+			require.ErrorIs(t, err, projectErrors.ErrChangesExpired, "pruning is not restored")
+
+			changes, err := restored.GetEventChanges(ctx, "user1", afterDelete.Seq)
+			require.NoError(t, err, "failed to get changes")
+			require.Equal(t, expected, changes, "changes are not restored")
+			require.Len(t, changes.Events, 1, "unexpected number of changed events")
+			require.Equal(t, updated.ID, changes.Events[0].ID, "unexpected changed event")
+			require.Equal(t, []uuid.UUID{old.ID}, changes.Deleted, "unexpected deleted events")
+
+			// Sequence numbers continue after restore.
+			_, err = restored.CreateEvent(ctx, newPersistedEvent(t, 16))
+			require.NoError(t, err, "failed to create event")
+			next, err := restored.GetEventChanges(ctx, "user1", changes.Seq)
+			require.NoError(t, err, "failed to get changes")
+			require.Len(t, next.Events, 1, "unexpected number of changed events")
+			require.Greater(t, next.Seq, changes.Seq, "sequence number is not restored")
+		})
+	}
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
@@ -58,49 +59,73 @@ func (s *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*types
 	return deepCopySliceEvents(events), nil
 }
 
+// CountUserEvents counts the events of the given user in the in-memory storage.
+// Method imitates transactional behavior, checking the context before returning the result.
+//
+// Returns the number of events, which is 0 if the user has no events.
+func (s *Storage) CountUserEvents(ctx context.Context, userID string) (int64, error) {
+	var count int64
+
+	err := s.withLockAndChecks(ctx, func() error {
+		count = int64(len(s.userIndex[userID]))
+		return nil
+	}, nil, nil, readLock)
+	if err != nil {
+		return 0, fmt.Errorf("count user events: %w", err)
+	}
+
+	return count, nil
+}
+
 // GetEventsForPeriod retrieves events within the specified time period from the in-memory storage.
 // If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
 // Method imitates transactional behavior, checking the context before returning the result.
 //
-// Returns a slice of events sorted by Datetime, considering only events that start within [dateStart, dateEnd].
+// Returns a slice of events sorted by Datetime, considering all events intersecting [dateStart, dateEnd),
+// not only those starting within it. All-day events are matched by the calendar dates of the period.
 // If no events are found, it returns nil and ErrEventNotFound.
 func (s *Storage) GetEventsForPeriod(ctx context.Context,
 	dateStart, dateEnd time.Time,
 	userID *string,
+	filter *types.EventFilter,
 ) ([]*types.Event, error) {
 	method := "get events for period: %w"
 
 	var events []*types.Event
 
 	err := s.withLockAndChecks(ctx, func() error {
-		var sourceEvents []*types.Event
-		if userID != nil {
-			if userEvents, ok := s.userIndex[*userID]; ok {
-				sourceEvents = userEvents
-			}
-		} else {
-			sourceEvents = s.events
-		}
+		sourceEvents := s.selectSource(userID, filter)
 
-		if len(sourceEvents) == 0 {
-			return errors.ErrEventNotFound
+		// All-day events might start earlier or later than the period due to date-only semantics.
+		allDayStart, allDayEnd := types.AllDayWindow(dateStart, dateEnd)
+		start, bound := dateStart, dateEnd
+		if allDayStart.Before(start) {
+			start = allDayStart
+		}
+		if allDayEnd.After(bound) {
+			bound = allDayEnd
 		}
 
-		// Create temporary events for binary search.
-		startEvent, _ := types.UpdateEvent(uuid.New(), &types.EventData{Datetime: dateStart})
-		endEvent, _ := types.UpdateEvent(uuid.New(), &types.EventData{Datetime: dateEnd})
-
-		// Find left boundary: first event where Datetime >= dateStart.
-		leftIdx := s.findInsertPosition(sourceEvents, startEvent)
+		// Find right boundary: first event where Datetime >= bound. No events after it might intersect the period.
+		rightIdx := sort.Search(len(sourceEvents), func(i int) bool {
+			return !sourceEvents[i].Datetime.Before(bound)
+		})
+		// Find left boundary: no events before it last long enough to intersect the period.
+		leftIdx := min(s.lowerBound(sourceEvents, start), rightIdx)
 
-		// Find right boundary: first event where Datetime > dateEnd.
-		rightIdx := s.findInsertPosition(sourceEvents, endEvent)
+		for _, event := range sourceEvents[leftIdx:rightIdx] {
+			if userID != nil && event.UserID != *userID {
+				continue
+			}
+			if event.Intersects(dateStart, dateEnd) && filter.Match(&event.EventData) {
+				events = append(events, event)
+			}
+		}
 
-		if leftIdx >= len(sourceEvents) || leftIdx >= rightIdx {
+		if len(events) == 0 {
 			return errors.ErrEventNotFound
 		}
-
-		events = sourceEvents[leftIdx:rightIdx]
 		return nil
 	}, nil, nil, readLock)
 	if err != nil {
@@ -112,14 +137,17 @@ func (s *Storage) GetEventsForPeriod(ctx context.Context,
 
 // GetEventsForDay retrieves events for the specified day from the in-memory storage.
 // If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
 // Method imitates transactional behavior, checking the context before returning the result.
 //
 // Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
-func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
+func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
 	dateStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	dateEnd := dateStart.AddDate(0, 0, 1)
 
-	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID)
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get events for day: %w", err)
 	}
@@ -129,10 +157,13 @@ func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *s
 
 // GetEventsForWeek retrieves events for the week containing the specified date from the in-memory storage.
 // If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
 // Method imitates transactional behavior, checking the context before returning the result.
 //
 // Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
-func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
+func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
 	// Weekday considering Monday as the first day of the week.
 	weekday := (int(date.Weekday()-time.Monday) + 7) % 7
 
@@ -140,7 +171,7 @@ func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *
 	dateStart := date.AddDate(0, 0, -weekday).Truncate(24 * time.Hour)
 	dateEnd := dateStart.AddDate(0, 0, 7)
 
-	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID)
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get events for week: %w", err)
 	}
@@ -150,15 +181,18 @@ func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *
 
 // GetEventsForMonth retrieves events for the month containing the specified date from the in-memory storage.
 // If userID is provided, it filters events for that user; otherwise, it returns events for all users.
+// If filter is provided, only events matching it are returned.
 // Method imitates transactional behavior, checking the context before returning the result.
 //
 // Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
-func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
+func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
 	// Truncating the date to the start of the month.
 	dateStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
 	dateEnd := dateStart.AddDate(0, 1, 0)
 
-	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID)
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get events for month: %w", err)
 	}
@@ -169,7 +203,7 @@ func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID
 // GetEventsForNotification retrieves events that need to be notified from the in-memory storage.
 // Method imitates transactional behavior, checking the context before returning the result.
 //
-// Returns a slice of events sorted by Datetime.
+// Returns a slice of events sorted by Datetime. If no events are found, it returns nil and ErrEventNotFound.
 func (s *Storage) GetEventsForNotification(ctx context.Context) ([]*types.Event, error) {
 	method := "get events for notification: %w"
 
@@ -190,6 +224,97 @@ func (s *Storage) GetEventsForNotification(ctx context.Context) ([]*types.Event,
 			}
 		}
 
+		if len(events) == 0 {
+			return errors.ErrEventNotFound
+		}
+		return nil
+	}, nil, nil, readLock)
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return events, nil
+}
+
+// CountEventsForNotification counts the events that need to be notified in the in-memory storage,
+// without copying them.
+// Method imitates transactional behavior, checking the context before returning the result.
+//
+// Returns the number of events, which is 0 if there are none.
+func (s *Storage) CountEventsForNotification(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := s.withLockAndChecks(ctx, func() error {
+		currentTime := time.Now()
+		for _, event := range s.events {
+			if event.RemindIn > 0 && !event.IsNotified && !event.Datetime.Add(-event.RemindIn).After(currentTime) {
+				count++
+			}
+		}
+		return nil
+	}, nil, nil, readLock)
+	if err != nil {
+		return 0, fmt.Errorf("count events for notification: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetNextReminderTime returns the earliest reminder time among the events which are still waiting for notification.
+// Method imitates transactional behavior, checking the context before returning the result.
+//
+// If there are no such events, it returns ErrEventNotFound.
+func (s *Storage) GetNextReminderTime(ctx context.Context) (time.Time, error) {
+	method := "get next reminder time: %w"
+
+	var next time.Time
+
+	err := s.withLockAndChecks(ctx, func() error {
+		// Events are sorted by Datetime, not by reminder time, so all of them are checked.
+		for _, event := range s.events {
+			if event.RemindIn <= 0 || event.IsNotified {
+				continue
+			}
+			remindAt := event.Datetime.Add(-event.RemindIn)
+			if next.IsZero() || remindAt.Before(next) {
+				next = remindAt
+			}
+		}
+		if next.IsZero() {
+			return errors.ErrEventNotFound
+		}
+		return nil
+	}, nil, nil, readLock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(method, err)
+	}
+
+	return next, nil
+}
+
+// GetEventsForReminderPeriod returns the events whose reminder time is within [dateStart, dateEnd),
+// notified or not, sorted by the reminder time.
+// Method imitates transactional behavior, checking the context before returning the result.
+//
+// If there are no such events, it returns nil and ErrEventNotFound.
+func (s *Storage) GetEventsForReminderPeriod(ctx context.Context, dateStart, dateEnd time.Time) ([]*types.Event, error) {
+	method := "get events for reminder period: %w"
+
+	var events []*types.Event
+
+	err := s.withLockAndChecks(ctx, func() error {
+		for _, event := range s.events {
+			remindAt := event.Datetime.Add(-event.RemindIn)
+			if event.RemindIn > 0 && !remindAt.Before(dateStart) && remindAt.Before(dateEnd) {
+				events = append(events, types.DeepCopyEvent(event))
+			}
+		}
+		if len(events) == 0 {
+			return errors.ErrEventNotFound
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Datetime.Add(-events[i].RemindIn).Before(events[j].Datetime.Add(-events[j].RemindIn))
+		})
 		return nil
 	}, nil, nil, readLock)
 	if err != nil {
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// CreateResource saves the new bookable resource in the in-memory storage.
+// Returns a copy of the stored resource and nil on success, nil and any error otherwise.
+func (s *Storage) CreateResource(ctx context.Context, resource *types.Resource) (*types.Resource, error) {
+	method := "create resource: %w"
+	if resource == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := copyResource(resource)
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			if _, ok := s.resources[stored.ID]; ok {
+				return projectErrors.ErrDataExists
+			}
+			s.stage(&walRecord{Op: walResource, Resource: stored})
+			return nil
+		},
+		func() {
+			s.resources[stored.ID] = stored
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return copyResource(stored), nil
+}
+
+// GetResource retrieves a copy of the bookable resource from the in-memory storage.
+// Returns nil and ErrResourceNotFound if the resource does not exist.
+func (s *Storage) GetResource(ctx context.Context, id uuid.UUID) (*types.Resource, error) {
+	var res *types.Resource
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			resource, ok := s.resources[id]
+			if !ok {
+				return projectErrors.ErrResourceNotFound
+			}
+			res = copyResource(resource)
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get resource: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetResources retrieves copies of all bookable resources from the in-memory storage.
+// Returns the resources sorted by the name.
+func (s *Storage) GetResources(ctx context.Context) ([]*types.Resource, error) {
+	res := make([]*types.Resource, 0)
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			for _, resource := range s.resources {
+				res = append(res, copyResource(resource))
+			}
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get resources: %w", err)
+	}
+
+	slices.SortFunc(res, compareResources)
+	return res, nil
+}
+
+// DeleteResource deletes the bookable resource from the in-memory storage.
+// Past bookings of the resource are kept by the events. The bookings are checked under the same write lock,
+// so the resource can't be booked concurrently.
+// Returns ErrResourceNotFound if the resource does not exist, ErrResourceInUse if it is booked by the events
+// ending after since.
+func (s *Storage) DeleteResource(ctx context.Context, id uuid.UUID, since time.Time) error {
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			if _, ok := s.resources[id]; !ok {
+				return projectErrors.ErrResourceNotFound
+			}
+			var ids []string
+			for _, event := range s.resourceIndex[id.String()] {
+				if event.Datetime.Add(event.Duration).After(since) {
+					ids = append(ids, event.ID.String())
+				}
+			}
+			if len(ids) > 0 {
+				return fmt.Errorf("%w: events=%v", projectErrors.ErrResourceInUse, ids)
+			}
+			s.stage(&walRecord{Op: walResourceDelete, IDs: []uuid.UUID{id}})
+			return nil
+		},
+		func() {
+			delete(s.resources, id)
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return fmt.Errorf("delete resource: %w", err)
+	}
+
+	return nil
+}
+
+// GetResourceEvents retrieves copies of the events booking the resource, which overlap with [dateStart, dateEnd).
+// Returns the events sorted by Datetime, the slice is empty if there are none.
+func (s *Storage) GetResourceEvents(
+	ctx context.Context,
+	resourceID uuid.UUID,
+	dateStart, dateEnd time.Time,
+) ([]*types.Event, error) {
+	res := make([]*types.Event, 0)
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			events := s.resourceIndex[resourceID.String()]
+			// Events starting at dateEnd or later cannot overlap with the period.
+			bound := sort.Search(len(events), func(i int) bool {
+				return !events[i].Datetime.Before(dateEnd)
+			})
+			for _, event := range events[:bound] {
+				if event.Datetime.Add(event.Duration).After(dateStart) {
+					res = append(res, types.DeepCopyEvent(event))
+				}
+			}
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get resource events: %w", err)
+	}
+
+	return res, nil
+}
+
+// copyResource returns a copy of the resource, including its attributes.
+func copyResource(resource *types.Resource) *types.Resource {
+	res := *resource
+	res.Attributes = slices.Clone(resource.Attributes)
+	return &res
+}
+
+// compareResources orders the resources by the name and ID.
+func compareResources(a, b *types.Resource) int {
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return slices.Compare(a.ID[:], b.ID[:])
+}
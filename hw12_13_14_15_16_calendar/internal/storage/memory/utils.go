@@ -16,7 +16,7 @@ const (
 // checkState checks if the storage is initialized and ready for operations.
 // Returns ErrStorageUninitialized on failure.
 func (s *Storage) checkState() error {
-	if s.events == nil || s.userIndex == nil || s.idIndex == nil {
+	if s.events == nil || s.userIndex == nil || s.idIndex == nil || s.tagIndex == nil {
 		return projectErrors.ErrStorageUninitialized
 	}
 	return nil
@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// CreateWebhook saves the new webhook subscription in the in-memory storage.
+// Returns a copy of the stored subscription and nil on success, nil and any error otherwise.
+func (s *Storage) CreateWebhook(ctx context.Context, webhook *types.Webhook) (*types.Webhook, error) {
+	method := "create webhook: %w"
+	if webhook == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := copyWebhook(webhook)
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			s.stage(&walRecord{Op: walWebhook, Webhook: stored})
+			return nil
+		},
+		func() {
+			s.webhooks[stored.ID] = stored
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return copyWebhook(stored), nil
+}
+
+// GetWebhook retrieves a copy of the webhook subscription from the in-memory storage.
+// Returns nil and ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error) {
+	var res *types.Webhook
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			webhook, ok := s.webhooks[id]
+			if !ok {
+				return projectErrors.ErrWebhookNotFound
+			}
+			res = copyWebhook(webhook)
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWebhooks retrieves copies of all webhook subscriptions from the in-memory storage.
+// Returns the subscriptions sorted by the creation time.
+func (s *Storage) GetWebhooks(ctx context.Context) ([]*types.Webhook, error) {
+	res := make([]*types.Webhook, 0)
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			for _, webhook := range s.webhooks {
+				res = append(res, copyWebhook(webhook))
+			}
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+
+	slices.SortFunc(res, compareWebhooks)
+	return res, nil
+}
+
+// DeleteWebhook deletes the webhook subscription along with its delivery log from the in-memory storage.
+// Returns ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			if _, ok := s.webhooks[id]; !ok {
+				return projectErrors.ErrWebhookNotFound
+			}
+			s.stage(&walRecord{Op: walWebhookDelete, IDs: []uuid.UUID{id}})
+			return nil
+		},
+		func() {
+			delete(s.webhooks, id)
+			delete(s.deliveries, id)
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhookState sets whether the webhook subscription is enabled and its number of consecutive failures
+// in the in-memory storage. Returns ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error {
+	var updated *types.Webhook
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			webhook, ok := s.webhooks[id]
+			if !ok {
+				return projectErrors.ErrWebhookNotFound
+			}
+			updated = copyWebhook(webhook)
+			updated.Enabled = enabled
+			updated.Failures = failures
+			s.stage(&walRecord{Op: walWebhook, Webhook: updated})
+			return nil
+		},
+		func() {
+			s.webhooks[id] = updated
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return fmt.Errorf("update webhook state: %w", err)
+	}
+
+	return nil
+}
+
+// AddWebhookDelivery appends the delivery attempt to the delivery log in the in-memory storage.
+// Only the latest MaxWebhookDeliveries attempts of the subscription are kept.
+func (s *Storage) AddWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error {
+	method := "add webhook delivery: %w"
+	if delivery == nil {
+		return fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *delivery
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			s.stage(&walRecord{Op: walDelivery, Delivery: &stored})
+			return nil
+		},
+		func() {
+			s.appendDelivery(&stored)
+		},
+		nil,
+		writeLock,
+	)
+	if err != nil {
+		return fmt.Errorf(method, err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves copies of the latest delivery attempts of the webhook subscription
+// from the in-memory storage, newest first. limit <= 0 means no limit.
+func (s *Storage) GetWebhookDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	limit int,
+) ([]*types.WebhookDelivery, error) {
+	res := make([]*types.WebhookDelivery, 0)
+
+	err := s.withLockAndChecks(ctx,
+		func() error {
+			deliveries := s.deliveries[webhookID]
+			for i := len(deliveries) - 1; i >= 0; i-- {
+				if limit > 0 && len(res) == limit {
+					break
+				}
+				copied := *deliveries[i]
+				res = append(res, &copied)
+			}
+			return nil
+		},
+		nil,
+		nil,
+		readLock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	return res, nil
+}
+
+// hasDelivery reports whether the delivery attempt is already in the log. Used on the WAL replay.
+func (s *Storage) hasDelivery(delivery *types.WebhookDelivery) bool {
+	return slices.ContainsFunc(s.deliveries[delivery.WebhookID], func(d *types.WebhookDelivery) bool {
+		return d.DeliveryID == delivery.DeliveryID && d.Attempt == delivery.Attempt
+	})
+}
+
+// appendDelivery appends the delivery attempt to the log of its subscription, keeping only
+// the latest MaxWebhookDeliveries attempts. Attempts older than the kept ones are skipped,
+// so the replayed log is trimmed the same way.
+func (s *Storage) appendDelivery(delivery *types.WebhookDelivery) {
+	deliveries := s.deliveries[delivery.WebhookID]
+	if len(deliveries) >= types.MaxWebhookDeliveries && delivery.CreatedAt.Before(deliveries[0].CreatedAt) {
+		return
+	}
+	deliveries = append(deliveries, delivery)
+	if excess := len(deliveries) - types.MaxWebhookDeliveries; excess > 0 {
+		deliveries = slices.Delete(deliveries, 0, excess)
+	}
+	s.deliveries[delivery.WebhookID] = deliveries
+}
+
+// copyWebhook returns a copy of the webhook subscription, including its event types.
+func copyWebhook(webhook *types.Webhook) *types.Webhook {
+	res := *webhook
+	res.EventTypes = slices.Clone(webhook.EventTypes)
+	return &res
+}
+
+// compareWebhooks orders the webhook subscriptions by the creation time and ID.
+func compareWebhooks(a, b *types.Webhook) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return slices.Compare(a.ID[:], b.ID[:])
+}
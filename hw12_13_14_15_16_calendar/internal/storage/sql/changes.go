@@ -0,0 +1,185 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// SQL queries for the change log of the events.
+const (
+	queryNextChangeSeq    = "UPDATE event_changes_state SET seq = seq + 1"
+	queryGetChangeState   = "SELECT seq, pruned_seq FROM event_changes_state"
+	queryGetUserChangeSeq = "SELECT COALESCE(MAX(seq), 0) FROM event_changes WHERE user_id = :user_id"
+	queryGetChangedEvents = `
+	SELECT *
+	FROM events
+	WHERE id IN (
+		SELECT event_id FROM event_changes WHERE user_id = :user_id AND seq > :since AND NOT deleted
+	)
+	`
+	queryGetDeletedEvents = "SELECT event_id FROM event_changes WHERE user_id = :user_id AND seq > :since AND deleted"
+	queryPruneChanges     = "DELETE FROM event_changes WHERE deleted AND seq <= :pruned_seq"
+	queryUpdatePrunedSeq  = "UPDATE event_changes_state SET pruned_seq = :pruned_seq WHERE pruned_seq < :pruned_seq"
+)
+
+// changeState is the state of the change log.
+type changeState struct {
+	Seq       int64 `db:"seq"`        // Sequence number of the last change.
+	PrunedSeq int64 `db:"pruned_seq"` // Sequence number of the last pruned tombstone.
+}
+
+// GetEventChanges retrieves the events of the given user ID, changed after the since sequence number,
+// and the IDs of the deleted ones. since = 0 means all events of the user.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+// The query is executed on the primary database, since the replicas might not have the changes yet.
+//
+// Returns ErrChangesExpired if since is unknown to the storage or the tombstones after it are already pruned.
+func (s *Storage) GetEventChanges(ctx context.Context, userID string, since int64) (*types.EventChanges, error) {
+	var res types.EventChanges
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		state, err := s.getChangeState(localCtx, tx)
+		if err != nil {
+			return err
+		}
+		if since < 0 || since > state.Seq || (since > 0 && since < state.PrunedSeq) {
+			return projectErrors.ErrChangesExpired
+		}
+
+		// The sequence number is read before the changes, so the changes committed meanwhile are not skipped.
+		args := struct {
+			UserID string `db:"user_id"`
+			Since  int64  `db:"since"`
+		}{userID, since}
+		var userSeq int64
+		query, qArgs, err := s.rebindQuery(queryGetUserChangeSeq, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.GetContext(localCtx, &userSeq, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		res.Seq = max(since, state.PrunedSeq, userSeq)
+
+		var dbEvents []*types.DBEvent
+		query = queryGetChangedEvents
+		if since == 0 {
+			query = s.dialect.queries().getAllUserEvents
+		}
+		query, qArgs, err = s.rebindQuery(query, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.SelectContext(localCtx, &dbEvents, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		res.Events = make([]*types.Event, len(dbEvents))
+		for i, dbEvent := range dbEvents {
+			res.Events[i] = dbEvent.ToEvent()
+		}
+		if since == 0 {
+			return nil
+		}
+
+		query, qArgs, err = s.rebindQuery(queryGetDeletedEvents, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.SelectContext(localCtx, &res.Deleted, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+
+		// Tombstones might be pruned after the state was read.
+		state, err = s.getChangeState(localCtx, tx)
+		if err != nil {
+			return err
+		}
+		if since < state.PrunedSeq {
+			return projectErrors.ErrChangesExpired
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get event changes: %w", err)
+	}
+
+	return &res, nil
+}
+
+// getChangeState reads the state of the change log.
+func (s *Storage) getChangeState(ctx context.Context, tx Tx) (*changeState, error) {
+	var state changeState
+	if err := tx.GetContext(ctx, &state, queryGetChangeState); err != nil {
+		return nil, fmt.Errorf("%w: change log state: %w", projectErrors.ErrQeuryError, err)
+	}
+	return &state, nil
+}
+
+// nextChangeSeq reserves the sequence number of the next change.
+//
+// The state row stays locked until the end of the transaction, so the changes are committed in the order
+// of their sequence numbers. It must be reserved before the events are modified to avoid deadlocks.
+func (s *Storage) nextChangeSeq(ctx context.Context, tx Tx) (int64, error) {
+	if _, err := tx.ExecContext(ctx, queryNextChangeSeq); err != nil {
+		return 0, fmt.Errorf("%w: change log sequence: %w", projectErrors.ErrQeuryError, err)
+	}
+	state, err := s.getChangeState(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	return state.Seq, nil
+}
+
+// recordChange records the latest change of the event in the change log.
+func (s *Storage) recordChange(ctx context.Context, tx Tx, event *types.Event, deleted bool, seq int64) error {
+	args := &types.EventChange{
+		EventID:   event.ID,
+		UserID:    event.UserID,
+		Seq:       seq,
+		Deleted:   deleted,
+		ChangedAt: time.Now(),
+	}
+	if _, err := tx.NamedExecContext(ctx, s.dialect.queries().upsertEventChange, args); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// recordOldEventsDeleted records the deletion of the events starting before the given date in the change log
+// and prunes the tombstones of the events deleted before it.
+func (s *Storage) recordOldEventsDeleted(ctx context.Context, tx Tx, date time.Time, seq int64) error {
+	var prunedSeq int64
+	query, qArgs, err := s.rebindQuery(s.dialect.queries().getPrunedSeq, struct {
+		Date time.Time `db:"date"`
+	}{date})
+	if err != nil {
+		return err
+	}
+	if err := tx.GetContext(ctx, &prunedSeq, query, qArgs...); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if prunedSeq > 0 {
+		pruneArgs := struct {
+			PrunedSeq int64 `db:"pruned_seq"`
+		}{prunedSeq}
+		if _, err := tx.NamedExecContext(ctx, queryPruneChanges, &pruneArgs); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		if _, err := tx.NamedExecContext(ctx, queryUpdatePrunedSeq, &pruneArgs); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+	}
+
+	args := struct {
+		Date      time.Time `db:"date"`
+		Seq       int64     `db:"seq"`
+		ChangedAt time.Time `db:"changed_at"`
+	}{date, seq, time.Now()}
+	if _, err := tx.NamedExecContext(ctx, s.dialect.queries().markOldEventsDeleted, &args); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
@@ -13,13 +13,17 @@ import (
 // SQL queries for basic CRUD operations on events.
 const (
 	queryCreateEvent = `
-	INSERT INTO events (id, title, datetime, duration, description, user_id, remind_in)
-	VALUES (:id, :title, :datetime, :duration, :description, :user_id, :remind_in)
+	INSERT INTO events (id, title, datetime, duration, description, user_id, remind_in, busy_status, all_day,
+		tags, color, location, url, resources)
+	VALUES (:id, :title, :datetime, :duration, :description, :user_id, :remind_in, :busy_status, :all_day,
+		:tags, :color, :location, :url, :resources)
 	`
 	queryUpdateEvent = `
 	UPDATE events
 	SET title = :title, datetime = :datetime, duration = :duration, 
-	description = :description, user_id = :user_id, remind_in = :remind_in, is_notified = :is_notified
+	description = :description, user_id = :user_id, remind_in = :remind_in, is_notified = :is_notified,
+	busy_status = :busy_status, all_day = :all_day, tags = :tags, color = :color, location = :location, url = :url,
+	resources = :resources
 	WHERE id = :id
 	`
 	queryUpdateNotifiedEvents = `
@@ -27,8 +31,7 @@ const (
 	SET is_notified = :is_notified
 	WHERE id IN (:id_list)
 	`
-	queryDeleteEvent     = "DELETE FROM events WHERE id = :id"
-	queryDeleteOldEvents = "DELETE FROM events WHERE datetime < :date"
+	queryDeleteEvent = "DELETE FROM events WHERE id = :id"
 )
 
 // CreateEvent creates a new event in the database. Method uses context with timeout set for Storage.
@@ -36,15 +39,20 @@ const (
 // Returns a wrapped ErrNoData error if no data passed.
 //
 // If the query is successful but the given ID is already present in the DB,
-// it returns ErrDataExists.
+// it returns ErrDataExists. If any of the booked resources does not exist, it returns ErrResourceNotFound.
 //
 // Method uses transaction to ensure the atomicity of the operation over DB.
+// Booked resources are locked for the transaction, so the concurrent bookings do not overlap.
 func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.Event, error) {
 	if event == nil {
 		return nil, fmt.Errorf("create event: %w", projectErrors.ErrNoData)
 	}
 
 	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if err := s.lockResources(localCtx, tx, event.Resources); err != nil {
+			return err
+		}
+
 		// Check if given ID is already present in DB.
 		existingEvent, err := s.getExistingEvent(localCtx, tx, event.ID)
 		if err != nil {
@@ -53,17 +61,25 @@ func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.E
 		if existingEvent != nil {
 			return projectErrors.ErrDataExists
 		}
+		if err := s.checkResources(localCtx, tx, event.Resources); err != nil {
+			return err
+		}
 
-		isOverlaps, err := s.isOverlaps(localCtx, tx, event)
+		conflicts, err := s.findConflicts(localCtx, tx, event)
 		if err != nil {
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
-		if isOverlaps {
-			return projectErrors.ErrDateBusy
+		if len(conflicts) > 0 {
+			return projectErrors.NewDateBusyError(conflicts...)
+		}
+
+		seq, err := s.nextChangeSeq(localCtx, tx)
+		if err != nil {
+			return err
 		}
 
 		query := queryCreateEvent
-		res, err := tx.NamedExecContext(localCtx, query, *event.ToDBEvent())
+		res, err := tx.NamedExecContext(localCtx, query, s.eventArgs(event.ToDBEvent()))
 		if err != nil {
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
@@ -72,7 +88,7 @@ func (s *Storage) CreateEvent(ctx context.Context, event *types.Event) (*types.E
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
 
-		return nil
+		return s.recordChange(localCtx, tx, event, false, seq)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create event: %w", err)
@@ -95,6 +111,10 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 	event, _ := types.UpdateEvent(id, data)
 
 	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if err := s.lockResources(localCtx, tx, event.Resources); err != nil {
+			return err
+		}
+
 		// Ensuring the event exists.
 		existingEvent, err := s.getExistingEvent(localCtx, tx, id)
 		if err != nil {
@@ -109,17 +129,27 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 			return projectErrors.ErrPermissionDenied
 		}
 
-		// Ensuring the event doesn't overlap with another one.
-		isOverlaps, err := s.isOverlaps(localCtx, tx, event)
+		// Resources booked before the update are not checked, so the event remains updatable after their deletion.
+		if err := s.checkResources(localCtx, tx, event.AddedResources(&existingEvent.EventData)); err != nil {
+			return err
+		}
+
+		// Ensuring the event doesn't overlap with another one or with another booking of its resources.
+		conflicts, err := s.findConflicts(localCtx, tx, event)
 		if err != nil {
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
-		if isOverlaps {
-			return projectErrors.ErrDateBusy
+		if len(conflicts) > 0 {
+			return projectErrors.NewDateBusyError(conflicts...)
+		}
+
+		seq, err := s.nextChangeSeq(localCtx, tx)
+		if err != nil {
+			return err
 		}
 
 		query := queryUpdateEvent
-		res, err := tx.NamedExecContext(localCtx, query, event.ToDBEvent())
+		res, err := tx.NamedExecContext(localCtx, query, s.eventArgs(event.ToDBEvent()))
 		if err != nil {
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
@@ -128,7 +158,7 @@ func (s *Storage) UpdateEvent(ctx context.Context, id uuid.UUID, data *types.Eve
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
 
-		return nil
+		return s.recordChange(localCtx, tx, event, false, seq)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("update event: %w", err)
@@ -152,6 +182,11 @@ func (s *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 			return projectErrors.ErrEventNotFound
 		}
 
+		seq, err := s.nextChangeSeq(localCtx, tx)
+		if err != nil {
+			return err
+		}
+
 		queryArgs := struct {
 			ID uuid.UUID `db:"id"`
 		}{
@@ -167,7 +202,7 @@ func (s *Storage) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
 		}
 
-		return nil
+		return s.recordChange(localCtx, tx, existingEvent, true, seq)
 	})
 	if err != nil {
 		return fmt.Errorf("delete event: %w", err)
@@ -228,14 +263,24 @@ func (s *Storage) UpdateNotifiedEvents(ctx context.Context, notifiedEvents []uui
 }
 
 // DeleteOldEvents deletes all events older than the given date from the database.
+// Tombstones of the events deleted before the given date are pruned from the change log.
 // Returns the number of deleted events and nil on success, 0 and any error otherwise.
 func (s *Storage) DeleteOldEvents(ctx context.Context, date time.Time) (int64, error) {
 	var deletedCount int64
 	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		seq, err := s.nextChangeSeq(localCtx, tx)
+		if err != nil {
+			return err
+		}
+		// Tombstones are recorded before the events are deleted, since they are selected from the events.
+		if err := s.recordOldEventsDeleted(localCtx, tx, date, seq); err != nil {
+			return err
+		}
+
 		queryArgs := struct {
 			Date time.Time `db:"date"`
 		}{date}
-		query := queryDeleteOldEvents
+		query := s.dialect.queries().deleteOldEvents
 		res, err := tx.NamedExecContext(localCtx, query, &queryArgs)
 		if err != nil {
 			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
@@ -0,0 +1,95 @@
+package sql
+
+import (
+	"encoding/json"
+	"io/fs"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/pressly/goose/v3/database"                                                 //nolint:depguard,nolintlint
+)
+
+// dialect describes the specifics of the database supported by the storage:
+// connection, query text, value encoding and migrations.
+type dialect interface {
+	// name returns the dialect name.
+	name() string
+	// driverName returns the database/sql driver name.
+	driverName() string
+	// dsn builds the data source name for the given host and port.
+	dsn(host, port string, conn connConfig) (string, error)
+	// queries returns the text of the queries, which differ between the dialects.
+	queries() *dialectQueries
+	// durationArg converts the duration to the query argument.
+	durationArg(d time.Duration) any
+	// tagsArg converts the tags to the query argument.
+	tagsArg(tags types.Tags) any
+	// migrations returns the schema and the seed migrations of the dialect. Seed is nil if not available.
+	migrations() (schema fs.FS, seed fs.FS, err error)
+	// gooseDialect returns the dialect of the migration tool.
+	gooseDialect() database.Dialect
+	// supportsListen reports whether the database is able to notify about the changes.
+	supportsListen() bool
+	// supportsReplicas reports whether the read replicas can be used with the database.
+	supportsReplicas() bool
+}
+
+// dialectQueries contains the text of the queries, which differ between the dialects.
+//
+// Queries with %s verb accept an additional filter clause.
+type dialectQueries struct {
+	isOverlaps               string // Events overlapping with the interval, the owner is limited by the filter.
+	getResourceEvents        string // Events overlapping with the period, booking the resources from the filter.
+	getEventsForPeriod       string
+	getAllUserEvents         string
+	getEventsForNotification string
+	getNextReminder          string
+	getEventsForReminders    string // Events with the reminder time within the period.
+	deleteOldEvents          string
+	tagsFilter               string // Filter clause, matching events with all of the given tags.
+	resourcesFilter          string // Filter clause, matching events booking all of the given resources.
+	lockResources            string // Locks the resources from :id_list. Empty if the transactions are serialized.
+	upsertEventChange        string // Replaces the latest change of the event.
+	markOldEventsDeleted     string // Replaces the latest changes of the events before :date with the tombstones.
+	getPrunedSeq             string // The last sequence number of the tombstones changed before :date, 0 if none.
+}
+
+// Change log queries of the dialects supporting the upsert clause.
+const (
+	changeColumns      = "event_id, user_id, seq, deleted, changed_at"
+	changeUpsertClause = `ON CONFLICT (event_id) DO UPDATE
+	SET user_id = excluded.user_id, seq = excluded.seq, deleted = excluded.deleted, changed_at = excluded.changed_at`
+	upsertEventChange = "INSERT INTO event_changes (" + changeColumns + ")" +
+		" VALUES (:event_id, :user_id, :seq, :deleted, :changed_at) " + changeUpsertClause
+)
+
+// newDialect returns the dialect for the given driver name.
+func newDialect(driver string) (dialect, error) {
+	switch driver {
+	//nolint:goconst,nolintlint
+	case "postgres", "postgresql":
+		return postgresDialect{}, nil
+	case "mysql", "mariadb":
+		return mysqlDialect{}, nil
+	case "sqlite", "sqlite3":
+		return sqliteDialect{}, nil
+	default:
+		return nil, projectErrors.ErrUnsupportedDriver
+	}
+}
+
+// secondsArg converts the duration to the number of seconds for the dialects without an interval type.
+func secondsArg(d time.Duration) any {
+	return int64(d / time.Second)
+}
+
+// jsonTagsArg converts the tags to JSON array for the dialects without an array type.
+func jsonTagsArg(tags types.Tags) any {
+	if tags == nil {
+		return "[]"
+	}
+	// Marshaling of a string slice never fails.
+	data, _ := json.Marshal([]string(tags))
+	return string(data)
+}
@@ -0,0 +1,211 @@
+package sql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/migrations"                    //nolint:depguard,nolintlint
+	"github.com/go-sql-driver/mysql"                                                       //nolint:depguard,nolintlint
+	"github.com/pressly/goose/v3/database"                                                 //nolint:depguard,nolintlint
+)
+
+// mysqlQueries contains MySQL/MariaDB queries. Durations are stored in seconds, tags and resources - as JSON arrays.
+var mysqlQueries = dialectQueries{
+	isOverlaps: `
+	SELECT id
+	FROM events
+	WHERE datetime < :end_time
+		AND DATE_ADD(datetime, INTERVAL duration SECOND) > :datetime
+		AND id != :id
+		%s
+	ORDER BY datetime, id
+	`,
+	getResourceEvents: `
+	SELECT *
+	FROM events
+	WHERE datetime < :date_end
+		AND DATE_ADD(datetime, INTERVAL duration SECOND) > :date_start
+		%s
+	ORDER BY datetime, id
+	`,
+	getEventsForPeriod: `
+	SELECT *
+	FROM events
+	WHERE (
+		(NOT all_day AND datetime < :date_end AND DATE_ADD(datetime, INTERVAL duration SECOND) > :date_start)
+		OR (all_day AND datetime < :all_day_end AND DATE_ADD(datetime, INTERVAL duration SECOND) > :all_day_start)
+	)
+	%s
+	ORDER BY datetime ASC
+	`,
+	getAllUserEvents: "SELECT * FROM events WHERE user_id = :user_id ORDER BY datetime ASC",
+	getEventsForNotification: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND is_notified = :is_notified
+		AND DATE_SUB(datetime, INTERVAL remind_in SECOND) <= :current_date
+	ORDER BY datetime ASC
+	`,
+	getNextReminder: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND is_notified = :is_notified
+	ORDER BY DATE_SUB(datetime, INTERVAL remind_in SECOND) ASC
+	LIMIT 1
+	`,
+	getEventsForReminders: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND DATE_SUB(datetime, INTERVAL remind_in SECOND) >= :date_start
+		AND DATE_SUB(datetime, INTERVAL remind_in SECOND) < :date_end
+	ORDER BY DATE_SUB(datetime, INTERVAL remind_in SECOND) ASC
+	`,
+	deleteOldEvents: "DELETE FROM events WHERE datetime < :date",
+	tagsFilter:      "AND JSON_CONTAINS(tags, :tags)",
+	resourcesFilter: "AND JSON_CONTAINS(resources, :resources)",
+	lockResources:   "SELECT id FROM resources WHERE id IN (:id_list) ORDER BY id FOR UPDATE",
+	markOldEventsDeleted: `
+	REPLACE INTO event_changes (` + changeColumns + `)
+	SELECT id, user_id, :seq, TRUE, :changed_at
+	FROM events
+	WHERE datetime < :date
+	`,
+	upsertEventChange: "REPLACE INTO event_changes (" + changeColumns + ")" +
+		" VALUES (:event_id, :user_id, :seq, :deleted, :changed_at)",
+	getPrunedSeq: "SELECT COALESCE(MAX(seq), 0) FROM event_changes WHERE deleted AND changed_at < :date",
+}
+
+// mysqlDialect is the MySQL/MariaDB dialect.
+type mysqlDialect struct{}
+
+func (mysqlDialect) name() string {
+	return "mysql"
+}
+
+func (mysqlDialect) driverName() string {
+	return "mysql"
+}
+
+// dsn builds the DSN with time values parsed in UTC.
+// SSL modes are mapped to the closest TLS settings of the driver.
+func (mysqlDialect) dsn(host, port string, conn connConfig) (string, error) {
+	tlsConfig, err := mysqlTLSConfig(host, conn)
+	if err != nil {
+		return "", err
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User = conn.user
+	cfg.Passwd = conn.password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(host, port)
+	cfg.DBName = conn.dbname
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	cfg.Timeout = conn.timeout
+	cfg.TLSConfig = tlsConfig
+	return cfg.FormatDSN(), nil
+}
+
+func (mysqlDialect) queries() *dialectQueries {
+	return &mysqlQueries
+}
+
+func (mysqlDialect) durationArg(d time.Duration) any {
+	return secondsArg(d)
+}
+
+func (mysqlDialect) tagsArg(tags types.Tags) any {
+	return jsonTagsArg(tags)
+}
+
+func (mysqlDialect) migrations() (fs.FS, fs.FS, error) {
+	schema, err := fs.Sub(migrations.MySQL, migrations.MySQLDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open schema migrations: %w", err)
+	}
+	return schema, nil, nil
+}
+
+func (mysqlDialect) gooseDialect() database.Dialect {
+	return database.DialectMySQL
+}
+
+func (mysqlDialect) supportsListen() bool {
+	return false
+}
+
+func (mysqlDialect) supportsReplicas() bool {
+	return true
+}
+
+// mysqlTLSConfig returns the name of the driver TLS config for the given SSL mode.
+//
+// verify-ca and verify-full modes register a custom config, using the given certificates.
+// verify-ca mode checks the server certificate chain, skipping the host name verification.
+func mysqlTLSConfig(host string, conn connConfig) (string, error) {
+	switch conn.sslMode {
+	case "disable":
+		return "false", nil
+	case "allow", "prefer":
+		return "preferred", nil
+	case "require":
+		return "skip-verify", nil
+	default:
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if conn.sslRootCert != "" {
+		pem, err := os.ReadFile(conn.sslRootCert)
+		if err != nil {
+			return "", fmt.Errorf("%w: read root certificate: %w", projectErrors.ErrCorruptedConfig, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return "", fmt.Errorf("%w: no root certificates found in %s", projectErrors.ErrCorruptedConfig, conn.sslRootCert)
+		}
+	}
+	if conn.sslCert != "" || conn.sslKey != "" {
+		cert, err := tls.LoadX509KeyPair(conn.sslCert, conn.sslKey)
+		if err != nil {
+			return "", fmt.Errorf("%w: load client certificate: %w", projectErrors.ErrCorruptedConfig, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if conn.sslMode == "verify-full" {
+		tlsConfig.ServerName = host
+	} else {
+		// The chain is verified manually below.
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+		roots := tlsConfig.RootCAs
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no server certificate received")
+			}
+			opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		}
+	}
+
+	name := "calendar-" + conn.sslMode + "-" + host
+	if err := mysql.RegisterTLSConfig(name, tlsConfig); err != nil {
+		return "", fmt.Errorf("%w: register TLS config: %w", projectErrors.ErrCorruptedConfig, err)
+	}
+	return name, nil
+}
@@ -0,0 +1,154 @@
+package sql
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/migrations"     //nolint:depguard,nolintlint
+	_ "github.com/lib/pq"                                                   //nolint:depguard,nolintlint
+	"github.com/pressly/goose/v3/database"                                  //nolint:depguard,nolintlint
+)
+
+// postgresQueries contains PostgreSQL queries, using INTERVAL arithmetic and array operators.
+var postgresQueries = dialectQueries{
+	isOverlaps: `
+	SELECT id
+	FROM events
+	WHERE datetime < :end_time
+		AND datetime + duration > :datetime
+		AND id != :id
+		%s
+	ORDER BY datetime, id
+	`,
+	getResourceEvents: `
+	SELECT *
+	FROM events
+	WHERE datetime < :date_end
+		AND datetime + duration > :date_start
+		%s
+	ORDER BY datetime, id
+	`,
+	getEventsForPeriod: `
+	SELECT *
+	FROM events
+	WHERE (
+		(NOT all_day AND datetime < :date_end AND datetime + duration > :date_start)
+		OR (all_day AND datetime < :all_day_end AND datetime + duration > :all_day_start)
+	)
+	%s
+	ORDER BY datetime ASC
+	`,
+	getAllUserEvents: "SELECT * FROM events WHERE user_id = :user_id ORDER BY datetime ASC",
+	getEventsForNotification: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND is_notified = :is_notified
+		AND datetime - remind_in <= :current_date
+	ORDER BY datetime ASC
+	`,
+	getNextReminder: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND is_notified = :is_notified
+	ORDER BY datetime - remind_in ASC
+	LIMIT 1
+	`,
+	getEventsForReminders: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND datetime - remind_in >= :date_start
+		AND datetime - remind_in < :date_end
+	ORDER BY datetime - remind_in ASC
+	`,
+	deleteOldEvents: "DELETE FROM events WHERE datetime < :date",
+	tagsFilter:      "AND tags @> :tags",
+	resourcesFilter: "AND resources @> :resources",
+	lockResources:   "SELECT id FROM resources WHERE id IN (:id_list) ORDER BY id FOR UPDATE",
+	markOldEventsDeleted: `
+	INSERT INTO event_changes (` + changeColumns + `)
+	SELECT id, user_id, :seq, TRUE, :changed_at
+	FROM events
+	WHERE datetime < :date
+	` + changeUpsertClause,
+	upsertEventChange: upsertEventChange,
+	getPrunedSeq:      "SELECT COALESCE(MAX(seq), 0) FROM event_changes WHERE deleted AND changed_at < :date",
+}
+
+// postgresDialect is the PostgreSQL dialect.
+type postgresDialect struct{}
+
+func (postgresDialect) name() string {
+	return "postgres"
+}
+
+func (postgresDialect) driverName() string {
+	return "postgres"
+}
+
+func (postgresDialect) dsn(host, port string, conn connConfig) (string, error) {
+	params := []string{
+		"host=" + quoteDSNValue(host),
+		"port=" + quoteDSNValue(port),
+		"user=" + quoteDSNValue(conn.user),
+		"password=" + quoteDSNValue(conn.password),
+		"dbname=" + quoteDSNValue(conn.dbname),
+		"sslmode=" + quoteDSNValue(conn.sslMode),
+		fmt.Sprintf("connect_timeout=%d", int(conn.timeout.Seconds())),
+	}
+	if conn.sslRootCert != "" {
+		params = append(params, "sslrootcert="+quoteDSNValue(conn.sslRootCert))
+	}
+	if conn.sslCert != "" {
+		params = append(params, "sslcert="+quoteDSNValue(conn.sslCert))
+	}
+	if conn.sslKey != "" {
+		params = append(params, "sslkey="+quoteDSNValue(conn.sslKey))
+	}
+	return strings.Join(params, " "), nil
+}
+
+func (postgresDialect) queries() *dialectQueries {
+	return &postgresQueries
+}
+
+func (postgresDialect) durationArg(d time.Duration) any {
+	return types.NewDuration(d)
+}
+
+func (postgresDialect) tagsArg(tags types.Tags) any {
+	return tags
+}
+
+func (postgresDialect) migrations() (fs.FS, fs.FS, error) {
+	seed, err := fs.Sub(migrations.Seed, migrations.SeedDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open seed migrations: %w", err)
+	}
+	return migrations.Schema, seed, nil
+}
+
+func (postgresDialect) gooseDialect() database.Dialect {
+	return database.DialectPostgres
+}
+
+func (postgresDialect) supportsListen() bool {
+	return true
+}
+
+func (postgresDialect) supportsReplicas() bool {
+	return true
+}
+
+// quoteDSNValue quotes the DSN value if it is empty or contains spaces, quotes or backslashes.
+func quoteDSNValue(val string) string {
+	if val != "" && !strings.ContainsAny(val, ` '\`) {
+		return val
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(val) + "'"
+}
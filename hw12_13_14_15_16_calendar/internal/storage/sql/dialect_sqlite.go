@@ -0,0 +1,164 @@
+package sql
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strconv"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/migrations"                    //nolint:depguard,nolintlint
+	"github.com/pressly/goose/v3/database"                                                 //nolint:depguard,nolintlint
+	_ "modernc.org/sqlite"                                                                 //nolint:depguard,nolintlint
+)
+
+// sqliteQueries contains SQLite queries. Durations are stored in seconds, tags and resources - as JSON arrays.
+//
+// Time values are stored as text with the time zone offset, so they are compared as unix time.
+var sqliteQueries = dialectQueries{
+	isOverlaps: `
+	SELECT id
+	FROM events
+	WHERE unixepoch(datetime, 'subsec') < unixepoch(:end_time, 'subsec')
+		AND unixepoch(datetime, 'subsec') + duration > unixepoch(:datetime, 'subsec')
+		AND id != :id
+		%s
+	ORDER BY unixepoch(datetime, 'subsec'), id
+	`,
+	getResourceEvents: `
+	SELECT *
+	FROM events
+	WHERE unixepoch(datetime, 'subsec') < unixepoch(:date_end, 'subsec')
+		AND unixepoch(datetime, 'subsec') + duration > unixepoch(:date_start, 'subsec')
+		%s
+	ORDER BY unixepoch(datetime, 'subsec'), id
+	`,
+	getEventsForPeriod: `
+	SELECT *
+	FROM events
+	WHERE (
+		(NOT all_day
+			AND unixepoch(datetime, 'subsec') < unixepoch(:date_end, 'subsec')
+			AND unixepoch(datetime, 'subsec') + duration > unixepoch(:date_start, 'subsec'))
+		OR (all_day
+			AND unixepoch(datetime, 'subsec') < unixepoch(:all_day_end, 'subsec')
+			AND unixepoch(datetime, 'subsec') + duration > unixepoch(:all_day_start, 'subsec'))
+	)
+	%s
+	ORDER BY unixepoch(datetime, 'subsec') ASC
+	`,
+	getAllUserEvents: "SELECT * FROM events WHERE user_id = :user_id ORDER BY unixepoch(datetime, 'subsec') ASC",
+	getEventsForNotification: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND is_notified = :is_notified
+		AND unixepoch(datetime, 'subsec') - remind_in <= unixepoch(:current_date, 'subsec')
+	ORDER BY unixepoch(datetime, 'subsec') ASC
+	`,
+	getNextReminder: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND is_notified = :is_notified
+	ORDER BY unixepoch(datetime, 'subsec') - remind_in ASC
+	LIMIT 1
+	`,
+	getEventsForReminders: `
+	SELECT *
+	FROM events
+	WHERE remind_in > :remind_threshold
+		AND unixepoch(datetime, 'subsec') - remind_in >= unixepoch(:date_start, 'subsec')
+		AND unixepoch(datetime, 'subsec') - remind_in < unixepoch(:date_end, 'subsec')
+	ORDER BY unixepoch(datetime, 'subsec') - remind_in ASC
+	`,
+	deleteOldEvents: "DELETE FROM events WHERE unixepoch(datetime, 'subsec') < unixepoch(:date, 'subsec')",
+	tagsFilter: `AND NOT EXISTS (
+		SELECT 1 FROM json_each(:tags) AS required
+		WHERE required.value NOT IN (SELECT value FROM json_each(events.tags))
+	)`,
+	resourcesFilter: `AND NOT EXISTS (
+		SELECT 1 FROM json_each(:resources) AS required
+		WHERE required.value NOT IN (SELECT value FROM json_each(events.resources))
+	)`,
+	// Write transactions lock the whole database.
+	lockResources: "",
+	markOldEventsDeleted: `
+	INSERT INTO event_changes (` + changeColumns + `)
+	SELECT id, user_id, :seq, TRUE, :changed_at
+	FROM events
+	WHERE unixepoch(datetime, 'subsec') < unixepoch(:date, 'subsec')
+	` + changeUpsertClause,
+	upsertEventChange: upsertEventChange,
+	getPrunedSeq: `
+	SELECT COALESCE(MAX(seq), 0)
+	FROM event_changes
+	WHERE deleted AND unixepoch(changed_at, 'subsec') < unixepoch(:date, 'subsec')
+	`,
+}
+
+// sqliteDialect is the SQLite dialect. Database name is the path to the database file.
+//
+// The driver is a pure Go one, so the service is built without cgo.
+type sqliteDialect struct{}
+
+func (sqliteDialect) name() string {
+	return "sqlite"
+}
+
+func (sqliteDialect) driverName() string {
+	return "sqlite"
+}
+
+// dsn builds the DSN of the database file. Host, port, credentials and SSL settings are ignored.
+//
+// Write transactions lock the database immediately, waiting for the other writers up to the storage timeout.
+// Time values are written in the SQLite format, which is understood by the SQLite date and time functions.
+func (sqliteDialect) dsn(_, _ string, conn connConfig) (string, error) {
+	if conn.dbname == "" {
+		return "", fmt.Errorf("%w: empty database file path", projectErrors.ErrCorruptedConfig)
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	if conn.timeout > 0 {
+		params.Add("_pragma", "busy_timeout("+strconv.FormatInt(conn.timeout.Milliseconds(), 10)+")")
+	}
+	params.Set("_txlock", "immediate")
+	params.Set("_time_format", "sqlite")
+	return "file:" + conn.dbname + "?" + params.Encode(), nil
+}
+
+func (sqliteDialect) queries() *dialectQueries {
+	return &sqliteQueries
+}
+
+func (sqliteDialect) durationArg(d time.Duration) any {
+	return secondsArg(d)
+}
+
+func (sqliteDialect) tagsArg(tags types.Tags) any {
+	return jsonTagsArg(tags)
+}
+
+func (sqliteDialect) migrations() (fs.FS, fs.FS, error) {
+	schema, err := fs.Sub(migrations.SQLite, migrations.SQLiteDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open schema migrations: %w", err)
+	}
+	return schema, nil, nil
+}
+
+func (sqliteDialect) gooseDialect() database.Dialect {
+	return database.DialectSQLite3
+}
+
+func (sqliteDialect) supportsListen() bool {
+	return false
+}
+
+func (sqliteDialect) supportsReplicas() bool {
+	return false
+}
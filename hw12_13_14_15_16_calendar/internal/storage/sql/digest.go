@@ -0,0 +1,177 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// Queries of the daily digest settings are the same for all dialects.
+const (
+	queryGetDigestSettings = `
+	SELECT user_id, enabled, send_time, time_zone, sent_date
+	FROM digest_settings
+	WHERE user_id = :user_id
+	`
+	queryGetDigestSubscriptions = `
+	SELECT user_id, enabled, send_time, time_zone, sent_date
+	FROM digest_settings
+	WHERE enabled = :enabled
+	ORDER BY user_id
+	`
+	queryInsertDigestSettings = `
+	INSERT INTO digest_settings (user_id, enabled, send_time, time_zone, sent_date)
+	VALUES (:user_id, :enabled, :send_time, :time_zone, :sent_date)
+	`
+	queryUpdateDigestSettings = `
+	UPDATE digest_settings
+	SET enabled = :enabled, send_time = :send_time, time_zone = :time_zone
+	WHERE user_id = :user_id
+	`
+	queryUpdateDigestSent = "UPDATE digest_settings SET sent_date = :sent_date WHERE user_id = :user_id"
+)
+
+// SetDigestSettings creates or replaces the daily digest settings of the user in the database.
+// The date of the last sent digest is kept from the current settings.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the stored settings and nil on success, nil and any error otherwise.
+func (s *Storage) SetDigestSettings(
+	ctx context.Context,
+	settings *types.DigestSettings,
+) (*types.DigestSettings, error) {
+	method := "set digest settings: %w"
+	if settings == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *settings
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		current, err := s.getDigestSettings(localCtx, tx, stored.UserID)
+		if err != nil {
+			return err
+		}
+
+		query := queryInsertDigestSettings
+		stored.SentDate = ""
+		if current != nil {
+			query = queryUpdateDigestSettings
+			stored.SentDate = current.SentDate
+		}
+		if _, err := tx.NamedExecContext(localCtx, query, &stored); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &stored, nil
+}
+
+// GetDigestSettings retrieves the daily digest settings of the user from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns nil and ErrDigestSettingsNotFound if the user has no settings.
+func (s *Storage) GetDigestSettings(ctx context.Context, userID string) (*types.DigestSettings, error) {
+	var res *types.DigestSettings
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		settings, err := s.getDigestSettings(localCtx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if settings == nil {
+			return projectErrors.ErrDigestSettingsNotFound
+		}
+		res = settings
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get digest settings: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetDigestSubscriptions retrieves the daily digest settings of all users with the digest enabled from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the settings sorted by user ID.
+func (s *Storage) GetDigestSubscriptions(ctx context.Context) ([]*types.DigestSettings, error) {
+	res := make([]*types.DigestSettings, 0)
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			Enabled bool `db:"enabled"`
+		}{true}
+		query, qArgs, err := s.rebindQuery(queryGetDigestSubscriptions, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.SelectContext(localCtx, &res, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get digest subscriptions: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateDigestSent sets the local date of the last sent daily digest of the user in the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns ErrDigestSettingsNotFound if the user has no settings.
+func (s *Storage) UpdateDigestSent(ctx context.Context, userID string, date string) error {
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		// Affected rows are not checked, since MySQL does not count the rows with unchanged values.
+		current, err := s.getDigestSettings(localCtx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return projectErrors.ErrDigestSettingsNotFound
+		}
+
+		args := struct {
+			UserID   string `db:"user_id"`
+			SentDate string `db:"sent_date"`
+		}{userID, date}
+		if _, err := tx.NamedExecContext(localCtx, queryUpdateDigestSent, args); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("update digest sent: %w", err)
+	}
+
+	return nil
+}
+
+// getDigestSettings retrieves the digest settings of the user within the transaction.
+// Returns (nil, nil) if the settings do not exist.
+func (s *Storage) getDigestSettings(ctx context.Context, tx Tx, userID string) (*types.DigestSettings, error) {
+	args := struct {
+		UserID string `db:"user_id"`
+	}{userID}
+	query, qArgs, err := s.rebindQuery(queryGetDigestSettings, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings types.DigestSettings
+	if err := tx.GetContext(ctx, &settings, query, qArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return &settings, nil
+}
@@ -16,6 +16,7 @@ import (
 type DB interface {
 	ConnectContext(ctx context.Context, driverName, dataSourceName string) (*sqlx.DB, error)
 	BeginTxx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+	PingContext(ctx context.Context) error
 	Close()
 }
 
@@ -33,13 +34,24 @@ type Tx interface {
 //
 //nolint:revive
 type SQLXWrapper struct {
-	db *sqlx.DB
+	db   *sqlx.DB
+	pool PoolConfig
 }
 
 // ConnectContext implements DB.ConnectContext.
+// Pool limits are applied to the established connection.
 func (w *SQLXWrapper) ConnectContext(ctx context.Context, driverName, dataSourceName string) (*sqlx.DB, error) {
 	db, err := sqlx.ConnectContext(ctx, driverName, dataSourceName)
 	if err == nil {
+		if w.pool.MaxOpenConns > 0 {
+			db.SetMaxOpenConns(w.pool.MaxOpenConns)
+		}
+		if w.pool.MaxIdleConns > 0 {
+			db.SetMaxIdleConns(w.pool.MaxIdleConns)
+		}
+		if w.pool.ConnMaxLifetime > 0 {
+			db.SetConnMaxLifetime(w.pool.ConnMaxLifetime)
+		}
 		w.db = db
 	}
 	return db, err
@@ -53,6 +65,14 @@ func (w *SQLXWrapper) BeginTxx(ctx context.Context, opts *sql.TxOptions) (Tx, er
 	return w.db.BeginTxx(ctx, opts)
 }
 
+// PingContext implements DB.PingContext.
+func (w *SQLXWrapper) PingContext(ctx context.Context) error {
+	if w.db == nil {
+		return fmt.Errorf("ping: %w", projectErrors.ErrStorageUninitialized)
+	}
+	return w.db.PingContext(ctx)
+}
+
 // Close implements DB.Close.
 func (w *SQLXWrapper) Close() {
 	if w.db != nil {
@@ -36,22 +36,49 @@ func (s *Storage) withTimeout(ctx context.Context, fn func(context.Context) erro
 	return nil
 }
 
-// execInTransaction executes the given function in a transaction.
+// errBeginTx is returned when the transaction could not be started.
+var errBeginTx = errors.New("transaction begin")
+
+// execInTransaction executes the given function in a transaction on the primary database.
 //
 // If the function returns an error, the transaction is rolled back and the error
 // is returned. If the function succeeds, the transaction is committed and
 // any error that occurs during the commit is returned after the rollback.
 func (s *Storage) execInTransaction(ctx context.Context, fn func(context.Context, Tx) error) error {
 	s.mu.RLock()
-	if s.db == nil {
+	db := s.db
+	s.mu.RUnlock()
+	if db == nil {
 		return projectErrors.ErrStorageUninitialized
 	}
-	s.mu.RUnlock()
 
+	return s.execInTransactionOn(ctx, db, fn)
+}
+
+// execInReadTransaction executes the given read-only function in a transaction on a healthy replica.
+//
+// If no replica is healthy or the transaction could not be started or failed on the replica due to the connection
+// error, the function is executed on the primary database. In the latter case the replica is marked as unhealthy.
+func (s *Storage) execInReadTransaction(ctx context.Context, fn func(context.Context, Tx) error) error {
+	r := s.nextReplica(ctx)
+	if r == nil {
+		return s.execInTransaction(ctx, fn)
+	}
+
+	err := s.execInTransactionOn(ctx, r.db, fn)
+	if errors.Is(err, errBeginTx) || isConnectionError(err) {
+		r.markUnhealthy()
+		return s.execInTransaction(ctx, fn)
+	}
+	return err
+}
+
+// execInTransactionOn executes the given function in a transaction on the given database.
+func (s *Storage) execInTransactionOn(ctx context.Context, db DB, fn func(context.Context, Tx) error) error {
 	return s.withTimeout(ctx, func(localCtx context.Context) error {
-		tx, err := s.db.BeginTxx(localCtx, nil)
+		tx, err := db.BeginTxx(localCtx, nil)
 		if err != nil {
-			return fmt.Errorf("transaction begin: %w", err)
+			return fmt.Errorf("%w: %w", errBeginTx, err)
 		}
 
 		// This code wraps the original error if it fails to rollback the transaction.
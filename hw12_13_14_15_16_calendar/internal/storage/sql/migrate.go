@@ -0,0 +1,156 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/pressly/goose/v3"                                                          //nolint:depguard,nolintlint
+	"github.com/pressly/goose/v3/database"                                                 //nolint:depguard,nolintlint
+)
+
+// Supported migration commands.
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+	MigrateRedo   = "redo"
+)
+
+// Version tables of the migration sets. Schema and seed migrations are versioned independently.
+const (
+	schemaVersionTable = "goose_db_version"
+	seedVersionTable   = "goose_seed_version"
+)
+
+// WithAutoMigrate enables applying pending schema migrations on Connect.
+func WithAutoMigrate(enabled bool) StorageOption {
+	return func(s *Storage) {
+		s.autoMigrate = enabled
+	}
+}
+
+// Migrate executes the migration command on the embedded migrations.
+//
+// Supported commands are "up", "down", "status" and "redo". If seed is set, the command is executed on
+// the test data migrations instead of the schema ones.
+//
+// Method uses its own connection, so it does not require the storage to be connected.
+// Returns a human-readable report of the command execution or an error if the command fails.
+func (s *Storage) Migrate(ctx context.Context, command string, seed bool) (string, error) {
+	method := "migrate " + command + ": %w"
+
+	provider, err := s.newMigrationProvider(seed)
+	if err != nil {
+		return "", fmt.Errorf(method, err)
+	}
+	defer provider.Close()
+
+	var report strings.Builder
+	switch command {
+	case MigrateUp:
+		results, err := provider.Up(ctx)
+		writeMigrationResults(&report, results...)
+		if err != nil {
+			return report.String(), fmt.Errorf(method, err)
+		}
+		if len(results) == 0 {
+			report.WriteString("no pending migrations\n")
+		}
+	case MigrateDown:
+		result, err := provider.Down(ctx)
+		if errors.Is(err, goose.ErrNoNextVersion) {
+			report.WriteString("no migrations to roll back\n")
+			return report.String(), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf(method, err)
+		}
+		writeMigrationResults(&report, result)
+	case MigrateRedo:
+		result, err := provider.Down(ctx)
+		if err != nil {
+			return "", fmt.Errorf(method, err)
+		}
+		writeMigrationResults(&report, result)
+		result, err = provider.ApplyVersion(ctx, result.Source.Version, true)
+		if err != nil {
+			return report.String(), fmt.Errorf(method, err)
+		}
+		writeMigrationResults(&report, result)
+	case MigrateStatus:
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return "", fmt.Errorf(method, err)
+		}
+		for _, status := range statuses {
+			appliedAt := "Pending"
+			if status.State == goose.StateApplied {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(&report, "%-19s -- %s\n", appliedAt, status.Source.Path)
+		}
+	default:
+		return "", fmt.Errorf(method, projectErrors.ErrUnknownMigrationCommand)
+	}
+
+	return report.String(), nil
+}
+
+// migrate applies all pending schema migrations.
+func (s *Storage) migrate(ctx context.Context) error {
+	provider, err := s.newMigrationProvider(false)
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+
+	_, err = provider.Up(ctx)
+	return err
+}
+
+// newMigrationProvider opens a new database connection and creates a migration provider for
+// the schema or the seed migrations.
+func (s *Storage) newMigrationProvider(seed bool) (*goose.Provider, error) {
+	schema, seedFS, err := s.dialect.migrations()
+	if err != nil {
+		return nil, err
+	}
+	fsys, table := schema, schemaVersionTable
+	if seed {
+		if seedFS == nil {
+			return nil, fmt.Errorf("%w: no seed migrations for %s", projectErrors.ErrMigrationUnsupported, s.dialect.name())
+		}
+		fsys, table = seedFS, seedVersionTable
+	}
+
+	store, err := database.NewStore(s.dialect.gooseDialect(), table)
+	if err != nil {
+		return nil, fmt.Errorf("create migration store: %w", err)
+	}
+
+	db, err := sql.Open(s.driver, s.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open migration connection: %w", err)
+	}
+
+	provider, err := goose.NewProvider("", db, fsys, goose.WithStore(store))
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create migration provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// writeMigrationResults writes the results of the applied migrations to the report.
+func writeMigrationResults(report *strings.Builder, results ...*goose.MigrationResult) {
+	for _, result := range results {
+		if result != nil {
+			report.WriteString(result.String() + "\n")
+		}
+	}
+}
@@ -177,6 +177,52 @@ func (_c *DB_ConnectContext_Call) RunAndReturn(run func(context.Context, string,
 	return _c
 }
 
+// PingContext provides a mock function with given fields: ctx
+func (_m *DB) PingContext(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PingContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_PingContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PingContext'
+type DB_PingContext_Call struct {
+	*mock.Call
+}
+
+// PingContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) PingContext(ctx interface{}) *DB_PingContext_Call {
+	return &DB_PingContext_Call{Call: _e.mock.On("PingContext", ctx)}
+}
+
+func (_c *DB_PingContext_Call) Run(run func(ctx context.Context)) *DB_PingContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_PingContext_Call) Return(_a0 error) *DB_PingContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_PingContext_Call) RunAndReturn(run func(context.Context) error) *DB_PingContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDB creates a new instance of DB. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDB(t interface {
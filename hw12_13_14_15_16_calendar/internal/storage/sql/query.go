@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
@@ -12,25 +13,6 @@ import (
 	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
 )
 
-const (
-	queryGetEventsForPeriod = `
-	SELECT *
-	FROM events
-	WHERE datetime >= :date_start AND datetime < :date_end
-	%s
-	ORDER BY datetime ASC
-	`
-	queryGetAllUserEvents         = "SELECT * FROM events WHERE user_id = :user_id"
-	queryGetEventsForNotification = `
-	SELECT *
-	FROM events
-	WHERE remind_in > :remind_threshold
-		AND is_notified = :is_notified
-		AND datetime - remind_in <= :current_date
-	ORDER BY datetime ASC
-	`
-)
-
 // GetEventsForDay retrieves all events occurring on the specified date from the database.
 // The method uses a transaction with a context and timeouts as configured in Storage.
 //
@@ -38,15 +20,18 @@ const (
 // ordered by datetime in ascending order.
 // Method truncates any given date to the start of the day.
 //
-// It accepts an optional userID parameter to filter events by user ID.
+// It accepts an optional userID parameter to filter events by user ID
+// and an optional filter to filter events by tags and location.
 //
 // Returns a slice of Event pointers and nil on success. If no events are found, it returns (nil, ErrEventNotFound).
 // Returns nil and any error encountered during the transaction or query execution.
-func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
+func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
 	dateStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	dateEnd := dateStart.AddDate(0, 0, 1)
 
-	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID)
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get events for day: %w", err)
 	}
@@ -61,11 +46,14 @@ func (s *Storage) GetEventsForDay(ctx context.Context, date time.Time, userID *s
 // ordered by datetime in ascending order.
 // Method truncates any given date to the start of the calendar week.
 //
-// It accepts an optional userID parameter to filter events by user ID.
+// It accepts an optional userID parameter to filter events by user ID
+// and an optional filter to filter events by tags and location.
 //
 // Returns a slice of Event pointers and nil on success. If no events are found, it returns (nil, ErrEventNotFound).
 // Returns nil and any error encountered during the transaction or query execution.
-func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
+func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
 	// Weekday considering Monday as the first day of the week.
 	weekday := (int(date.Weekday()-time.Monday) + 7) % 7
 
@@ -73,7 +61,7 @@ func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *
 	dateStart := date.AddDate(0, 0, -weekday).Truncate(24 * time.Hour)
 	dateEnd := dateStart.AddDate(0, 0, 7)
 
-	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID)
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get events for week: %w", err)
 	}
@@ -88,16 +76,19 @@ func (s *Storage) GetEventsForWeek(ctx context.Context, date time.Time, userID *
 // ordered by datetime in ascending order.
 // Method truncates any given date to the start of the calendar month.
 //
-// It accepts an optional userID parameter to filter events by user ID.
+// It accepts an optional userID parameter to filter events by user ID
+// and an optional filter to filter events by tags and location.
 //
 // Returns a slice of Event pointers and nil on success. If no events are found, it returns (nil, ErrEventNotFound).
 // Returns nil and any error encountered during the transaction or query execution.
-func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string) ([]*types.Event, error) {
+func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID *string,
+	filter *types.EventFilter,
+) ([]*types.Event, error) {
 	// Truncating the date to the start of the month.
 	dateStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
 	dateEnd := dateStart.AddDate(0, 1, 0)
 
-	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID)
+	res, err := s.GetEventsForPeriod(ctx, dateStart, dateEnd, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get events for month: %w", err)
 	}
@@ -107,31 +98,53 @@ func (s *Storage) GetEventsForMonth(ctx context.Context, date time.Time, userID
 
 // GetEventsForPeriod retrieves all events occurring on the given period from the database.
 // The method uses a transaction with a context and timeouts as configured in Storage.
+// The query is executed on a read replica if any is available.
 //
-// It fetches events where the datetime falls within the start and end of the given period,
-// ordered by datetime in ascending order.
+// It fetches events intersecting the given period, not only those starting within it,
+// ordered by datetime in ascending order. All-day events are matched by the calendar dates of the period.
 //
-// It accepts an optional userID parameter to filter events by user ID.
+// It accepts an optional userID parameter to filter events by user ID
+// and an optional filter to filter events by tags and location.
 //
 // Returns a slice of Event pointers and nil on success. If no events are found, it returns (nil, ErrEventNotFound).
 // Returns nil and any error encountered during the transaction or query execution.
 func (s *Storage) GetEventsForPeriod(ctx context.Context, dateStart, dateEnd time.Time,
-	userID *string,
+	userID *string, filter *types.EventFilter,
 ) ([]*types.Event, error) {
 	var dbEvents []*types.DBEvent
 	type Params struct {
-		UserID    *string   `db:"user_id"` // Optional, can be nil.
-		DateStart time.Time `db:"date_start"`
-		DateEnd   time.Time `db:"date_end"`
+		UserID      *string   `db:"user_id"` // Optional, can be nil.
+		DateStart   time.Time `db:"date_start"`
+		DateEnd     time.Time `db:"date_end"`
+		AllDayStart time.Time `db:"all_day_start"`
+		AllDayEnd   time.Time `db:"all_day_end"`
+		Tags        any       `db:"tags"`     // Optional, used only with filter.
+		Location    *string   `db:"location"` // Optional, used only with filter.
 	}
-	params := Params{userID, dateStart, dateEnd}
-	userIDClause := ""
+	allDayStart, allDayEnd := types.AllDayWindow(dateStart, dateEnd)
+	params := Params{
+		UserID:      userID,
+		DateStart:   dateStart,
+		DateEnd:     dateEnd,
+		AllDayStart: allDayStart,
+		AllDayEnd:   allDayEnd,
+	}
+	clauses := make([]string, 0, 3)
 	if userID != nil {
-		userIDClause = "AND user_id = :user_id"
+		clauses = append(clauses, "AND user_id = :user_id")
+	}
+	if filter != nil && len(filter.Tags) > 0 {
+		params.Tags = s.dialect.tagsArg(filter.Tags)
+		clauses = append(clauses, s.dialect.queries().tagsFilter)
+	}
+	if filter != nil && filter.Location != nil {
+		params.Location = filter.Location
+		clauses = append(clauses, "AND lower(location) = lower(:location)")
 	}
+	filterClause := strings.Join(clauses, " ")
 
-	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
-		query, qArgs, err := s.rebindQuery(fmt.Sprintf(queryGetEventsForPeriod, userIDClause), params)
+	err := s.execInReadTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		query, qArgs, err := s.rebindQuery(fmt.Sprintf(s.dialect.queries().getEventsForPeriod, filterClause), params)
 		if err != nil {
 			return err
 		}
@@ -182,18 +195,19 @@ func (s *Storage) GetEvent(ctx context.Context, id uuid.UUID) (*types.Event, err
 	return event, nil
 }
 
-// GetAllUserEvents retrieves all events for a given user ID from the database.
+// GetAllUserEvents retrieves all events for a given user ID from the database, ordered by datetime.
 // The method uses a transaction with a context and timeouts as configured in Storage.
+// The query is executed on a read replica if any is available.
 //
 // Returns a slice of Event pointers and nil on success, or nil and any error encountered during the transaction.
 // If no events for the given user ID are found, it returns (nil, ErrEventNotFound).
 func (s *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*types.Event, error) {
 	var dbEvents []*types.DBEvent
-	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+	err := s.execInReadTransaction(ctx, func(localCtx context.Context, tx Tx) error {
 		args := struct {
 			UserID string `db:"user_id"`
 		}{userID}
-		query, qArgs, err := s.rebindQuery(queryGetAllUserEvents, args)
+		query, qArgs, err := s.rebindQuery(s.dialect.queries().getAllUserEvents, args)
 		if err != nil {
 			return err
 		}
@@ -222,6 +236,33 @@ func (s *Storage) GetAllUserEvents(ctx context.Context, userID string) ([]*types
 	return events, nil
 }
 
+// CountUserEvents counts the events of the given user ID in the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+// The query is executed on the primary database, since the count is used for the quota checks.
+//
+// Returns the number of events, which is 0 if the user has no events, or 0 and any error encountered.
+func (s *Storage) CountUserEvents(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			UserID string `db:"user_id"`
+		}{userID}
+		query, qArgs, err := s.rebindQuery(queryCountUserEvents, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.GetContext(localCtx, &count, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count user events: %w", err)
+	}
+
+	return count, nil
+}
+
 // GetEventsForNotification retrieves all events, which require notification.
 // The method uses a transaction with a context and timeouts as configured in Storage.
 //
@@ -231,11 +272,11 @@ func (s *Storage) GetEventsForNotification(ctx context.Context) ([]*types.Event,
 	var dbEvents []*types.DBEvent
 	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
 		args := struct {
-			RimindThreshold types.Duration `db:"remind_threshold"`
-			IsNotified      bool           `db:"is_notified"`
-			CurrentDate     time.Time      `db:"current_date"`
-		}{types.NewDuration(time.Duration(0)), false, time.Now()}
-		query, qArgs, err := s.rebindQuery(queryGetEventsForNotification, args)
+			RimindThreshold any       `db:"remind_threshold"`
+			IsNotified      bool      `db:"is_notified"`
+			CurrentDate     time.Time `db:"current_date"`
+		}{s.dialect.durationArg(0), false, time.Now()}
+		query, qArgs, err := s.rebindQuery(s.dialect.queries().getEventsForNotification, args)
 		if err != nil {
 			return err
 		}
@@ -263,3 +304,112 @@ func (s *Storage) GetEventsForNotification(ctx context.Context) ([]*types.Event,
 
 	return events, nil
 }
+
+// CountEventsForNotification counts the events, which require notification, using the same conditions
+// as GetEventsForNotification. The method uses a read transaction on a healthy replica, if any.
+//
+// Returns the number of events, which is 0 if there are none, or 0 and any error encountered.
+func (s *Storage) CountEventsForNotification(ctx context.Context) (int64, error) {
+	var count int64
+	err := s.execInReadTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			RimindThreshold any       `db:"remind_threshold"`
+			IsNotified      bool      `db:"is_notified"`
+			CurrentDate     time.Time `db:"current_date"`
+		}{s.dialect.durationArg(0), false, time.Now()}
+		query := fmt.Sprintf(queryCountWrapper, s.dialect.queries().getEventsForNotification)
+		query, qArgs, err := s.rebindQuery(query, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.GetContext(localCtx, &count, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count events for notification: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetNextReminderTime retrieves the earliest reminder time among the events which are still waiting for notification.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the reminder time and nil on success, or zero time and any error encountered during the transaction.
+// If there are no such events, it returns (time.Time{}, ErrEventNotFound).
+func (s *Storage) GetNextReminderTime(ctx context.Context) (time.Time, error) {
+	var next *types.DBEvent
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			RimindThreshold any  `db:"remind_threshold"`
+			IsNotified      bool `db:"is_notified"`
+		}{s.dialect.durationArg(0), false}
+		query, qArgs, err := s.rebindQuery(s.dialect.queries().getNextReminder, args)
+		if err != nil {
+			return err
+		}
+		var dbEvent types.DBEvent
+		err = tx.GetContext(localCtx, &dbEvent, query, qArgs...)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		next = &dbEvent
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get next reminder time: %w", err)
+	}
+	if next == nil {
+		return time.Time{}, fmt.Errorf("get next reminder time: %w", projectErrors.ErrEventNotFound)
+	}
+
+	return next.Datetime.Add(-next.RemindIn.ToDuration()), nil
+}
+
+// GetEventsForReminderPeriod retrieves the events whose reminder time is within [dateStart, dateEnd),
+// notified or not, ordered by the reminder time.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns a slice of Event pointers and nil on success, or nil and any error encountered during the transaction.
+// If there are no such events, it returns (nil, ErrEventNotFound).
+func (s *Storage) GetEventsForReminderPeriod(ctx context.Context, dateStart, dateEnd time.Time) ([]*types.Event, error) {
+	var dbEvents []*types.DBEvent
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			RimindThreshold any       `db:"remind_threshold"`
+			DateStart       time.Time `db:"date_start"`
+			DateEnd         time.Time `db:"date_end"`
+		}{s.dialect.durationArg(0), dateStart, dateEnd}
+		query, qArgs, err := s.rebindQuery(s.dialect.queries().getEventsForReminders, args)
+		if err != nil {
+			return err
+		}
+		err = tx.SelectContext(localCtx, &dbEvents, query, qArgs...)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get events for reminder period: %w", err)
+	}
+	// If no events found, set the error to ErrEventNotFound.
+	if len(dbEvents) == 0 {
+		return nil, fmt.Errorf("get events for reminder period: %w", projectErrors.ErrEventNotFound)
+	}
+
+	events := make([]*types.Event, len(dbEvents))
+	for i := 0; i < len(dbEvents); i++ {
+		events[i] = dbEvents[i].ToEvent()
+	}
+
+	return events, nil
+}
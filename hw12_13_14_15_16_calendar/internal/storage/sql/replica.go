@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql" //nolint:depguard,nolintlint
+	"github.com/lib/pq"              //nolint:depguard,nolintlint
+)
+
+// replicaCheckInterval is the minimum interval between the health checks of a replica.
+const replicaCheckInterval = 5 * time.Second
+
+// replica represents a read replica connection with its health state.
+type replica struct {
+	mu        sync.Mutex
+	db        DB
+	dsn       string
+	connected bool
+	healthy   bool
+	checkedAt time.Time
+}
+
+// nextReplica returns the next healthy replica in the round-robin order.
+// Returns nil if no replicas are configured or none of them is healthy.
+func (s *Storage) nextReplica(ctx context.Context) *replica {
+	n := uint64(len(s.replicas))
+	if n == 0 {
+		return nil
+	}
+
+	start := s.replicaIdx.Add(1)
+	for i := range n {
+		r := s.replicas[(start+i)%n]
+		if s.checkReplica(ctx, r) {
+			return r
+		}
+	}
+	return nil
+}
+
+// checkReplica returns the health state of the replica.
+//
+// The state is refreshed if the last check is older than replicaCheckInterval:
+// the replica is connected if it was not yet, otherwise it is pinged.
+func (s *Storage) checkReplica(ctx context.Context, r *replica) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.checkedAt.IsZero() && time.Since(r.checkedAt) < replicaCheckInterval {
+		return r.healthy
+	}
+
+	err := s.withTimeout(ctx, func(localCtx context.Context) error {
+		if !r.connected {
+			_, err := r.db.ConnectContext(localCtx, s.driver, r.dsn)
+			r.connected = err == nil
+			return err
+		}
+		return r.db.PingContext(localCtx)
+	})
+
+	r.healthy = err == nil
+	r.checkedAt = time.Now()
+	return r.healthy
+}
+
+// markUnhealthy marks the replica as unhealthy until the next health check.
+func (r *replica) markUnhealthy() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.healthy = false
+	r.checkedAt = time.Now()
+}
+
+// isConnectionError reports whether the error is caused by the lost or unavailable connection
+// rather than by the query itself, so the query might succeed on another database.
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	// Connection exceptions and the server shutdown.
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Class() == "08" || pqErr.Code.Class() == "57"
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// Queries of the bookable resources are the same for all dialects.
+const (
+	queryGetResource = `
+	SELECT id, name, kind, capacity, attributes, created_at
+	FROM resources
+	WHERE id = :id
+	`
+	queryGetResources = `
+	SELECT id, name, kind, capacity, attributes, created_at
+	FROM resources
+	ORDER BY name, id
+	`
+	queryInsertResource = `
+	INSERT INTO resources (id, name, kind, capacity, attributes, created_at)
+	VALUES (:id, :name, :kind, :capacity, :attributes, :created_at)
+	`
+	queryDeleteResource = "DELETE FROM resources WHERE id = :id"
+)
+
+// bookingsHorizon is the end of the period, in which the upcoming bookings of the deleted resource are looked for.
+var bookingsHorizon = time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// resourceIDArgs represents the arguments of the queries by the resource ID.
+type resourceIDArgs struct {
+	ID uuid.UUID `db:"id"`
+}
+
+// CreateResource saves the new bookable resource in the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the stored resource and nil on success, nil and any error otherwise.
+func (s *Storage) CreateResource(ctx context.Context, resource *types.Resource) (*types.Resource, error) {
+	method := "create resource: %w"
+	if resource == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *resource
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		existing, err := s.getResource(localCtx, tx, stored.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return projectErrors.ErrDataExists
+		}
+
+		args := struct {
+			*types.Resource
+			Attributes any `db:"attributes"`
+		}{&stored, s.dialect.tagsArg(stored.Attributes)}
+		if _, err := tx.NamedExecContext(localCtx, queryInsertResource, args); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &stored, nil
+}
+
+// GetResource retrieves the bookable resource from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns nil and ErrResourceNotFound if the resource does not exist.
+func (s *Storage) GetResource(ctx context.Context, id uuid.UUID) (*types.Resource, error) {
+	var res *types.Resource
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		resource, err := s.getResource(localCtx, tx, id)
+		if err != nil {
+			return err
+		}
+		if resource == nil {
+			return projectErrors.ErrResourceNotFound
+		}
+		res = resource
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get resource: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetResources retrieves all bookable resources from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the resources sorted by the name.
+func (s *Storage) GetResources(ctx context.Context) ([]*types.Resource, error) {
+	res := make([]*types.Resource, 0)
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if err := tx.SelectContext(localCtx, &res, queryGetResources); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		for _, resource := range res {
+			resource.CreatedAt = resource.CreatedAt.UTC()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get resources: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteResource deletes the bookable resource from the database. Past bookings of the resource are kept by the events.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+// The resource is locked before the bookings check, so it can't be booked concurrently.
+//
+// Returns ErrResourceNotFound if the resource does not exist, ErrResourceInUse if it is booked by the events
+// ending after since.
+func (s *Storage) DeleteResource(ctx context.Context, id uuid.UUID, since time.Time) error {
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if err := s.lockResources(localCtx, tx, []string{id.String()}); err != nil {
+			return err
+		}
+		resource, err := s.getResource(localCtx, tx, id)
+		if err != nil {
+			return err
+		}
+		if resource == nil {
+			return projectErrors.ErrResourceNotFound
+		}
+
+		events, err := s.getResourceEvents(localCtx, tx, id, since, bookingsHorizon)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			ids := make([]string, len(events))
+			for i, event := range events {
+				ids[i] = event.ID.String()
+			}
+			return fmt.Errorf("%w: events=%v", projectErrors.ErrResourceInUse, ids)
+		}
+
+		res, err := tx.NamedExecContext(localCtx, queryDeleteResource, resourceIDArgs{id})
+		if err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		if affected == 0 {
+			return projectErrors.ErrResourceNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete resource: %w", err)
+	}
+
+	return nil
+}
+
+// GetResourceEvents retrieves the events booking the resource, which overlap with [dateStart, dateEnd),
+// from the database. The method uses a read transaction on a healthy replica, if any.
+//
+// Returns the events sorted by Datetime, the slice is empty if there are none.
+func (s *Storage) GetResourceEvents(
+	ctx context.Context,
+	resourceID uuid.UUID,
+	dateStart, dateEnd time.Time,
+) ([]*types.Event, error) {
+	var res []*types.Event
+	err := s.execInReadTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		var err error
+		res, err = s.getResourceEvents(localCtx, tx, resourceID, dateStart, dateEnd)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get resource events: %w", err)
+	}
+
+	return res, nil
+}
+
+// getResourceEvents gets the events booking the resource, which overlap with [dateStart, dateEnd),
+// sorted by Datetime.
+func (s *Storage) getResourceEvents(
+	ctx context.Context,
+	tx Tx,
+	resourceID uuid.UUID,
+	dateStart, dateEnd time.Time,
+) ([]*types.Event, error) {
+	var dbEvents []types.DBEvent
+	args := struct {
+		DateStart time.Time `db:"date_start"`
+		DateEnd   time.Time `db:"date_end"`
+		Resources any       `db:"resources"`
+	}{dateStart, dateEnd, s.dialect.tagsArg(types.Tags{resourceID.String()})}
+	queries := s.dialect.queries()
+
+	query, qArgs, err := s.rebindQuery(fmt.Sprintf(queries.getResourceEvents, queries.resourcesFilter), args)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SelectContext(ctx, &dbEvents, query, qArgs...); err != nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+
+	res := make([]*types.Event, len(dbEvents))
+	for i := range dbEvents {
+		res[i] = dbEvents[i].ToEvent()
+	}
+	return res, nil
+}
+
+// getResource gets the resource with the given ID from the database.
+// Returns (nil, nil) if the resource does not exist.
+func (s *Storage) getResource(ctx context.Context, tx Tx, id uuid.UUID) (*types.Resource, error) {
+	query, qArgs, err := s.rebindQuery(queryGetResource, resourceIDArgs{id})
+	if err != nil {
+		return nil, err
+	}
+
+	var resource types.Resource
+	if err := tx.GetContext(ctx, &resource, query, qArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	resource.CreatedAt = resource.CreatedAt.UTC()
+	return &resource, nil
+}
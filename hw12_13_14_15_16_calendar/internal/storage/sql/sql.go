@@ -4,20 +4,63 @@ package sql
 import (
 	"context"
 	"fmt"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
-	_ "github.com/lib/pq"                                                                  //nolint:depguard,nolintlint
 )
 
+// Supported SSL modes.
+var sslModes = map[string]struct{}{
+	"disable":     {},
+	"allow":       {},
+	"prefer":      {},
+	"require":     {},
+	"verify-ca":   {},
+	"verify-full": {},
+}
+
 // Storage represents a SQL database storage.
+//
+// Writes, notification queries and single event lookups are always executed on the primary database.
+// GetEvents* and GetAllUserEvents queries are routed to the read replicas, if any are configured,
+// falling back to the primary one when no replica passes the health check.
 type Storage struct {
-	mu      sync.RWMutex
-	driver  string
-	db      DB
-	dsn     string
-	timeout time.Duration
+	mu          sync.RWMutex
+	dialect     dialect
+	driver      string
+	db          DB
+	dsn         string
+	timeout     time.Duration
+	autoMigrate bool
+
+	conn         connConfig
+	pool         PoolConfig
+	replicaAddrs []string
+	replicaDBs   []DB
+	replicas     []*replica
+	replicaIdx   atomic.Uint64
+}
+
+// connConfig holds the connection parameters shared by the primary and the replica DSNs.
+type connConfig struct {
+	user        string
+	password    string
+	dbname      string
+	timeout     time.Duration
+	sslMode     string
+	sslRootCert string
+	sslCert     string
+	sslKey      string
+}
+
+// PoolConfig holds the connection pool limits. Zero values keep the driver defaults.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
 // StorageOption defines a function that allows to configure underlying Storage DB on construction.
@@ -31,40 +74,114 @@ func WithDB(db DB) StorageOption {
 	}
 }
 
+// WithReplicas sets the read replicas addresses in "host" or "host:port" format.
+// If the port is omitted, the port of the primary database is used.
+func WithReplicas(addrs ...string) StorageOption {
+	return func(s *Storage) {
+		s.replicaAddrs = addrs
+	}
+}
+
+// WithReplicaDBs allows to inject custom DB implementations of the replicas (for testing).
+// Each DB replaces the default one of the replica with the same index.
+func WithReplicaDBs(dbs ...DB) StorageOption {
+	return func(s *Storage) {
+		s.replicaDBs = dbs
+	}
+}
+
+// WithTLS sets the SSL mode and the certificates used for the connections.
+// Empty mode keeps the default "disable" one, empty paths are omitted.
+func WithTLS(mode, rootCert, cert, key string) StorageOption {
+	return func(s *Storage) {
+		if mode != "" {
+			s.conn.sslMode = mode
+		}
+		s.conn.sslRootCert = rootCert
+		s.conn.sslCert = cert
+		s.conn.sslKey = key
+	}
+}
+
+// WithPool sets the connection pool limits of the primary and the replica connections.
+func WithPool(pool PoolConfig) StorageOption {
+	return func(s *Storage) {
+		s.pool = pool
+	}
+}
+
 // NewStorage creates a new Storage instance based on the given args.
 //
 // If the arguments are empty, it returns an error.
 //
 // The function constructs a DSN based on the given arguments and
-// the default driver. No connection is established upon the call.
+// the dialect of the driver. No connection is established upon the call.
 //
-// Currently supported drivers are "postgres" or "postgresql".
+// Currently supported drivers are "postgres", "postgresql", "mysql", "mariadb", "sqlite" and "sqlite3".
+// For SQLite dbname is the path to the database file, host and port are ignored.
 func NewStorage(timeout time.Duration, driver, host, port, user, password, dbname string,
 	opts ...StorageOption,
 ) (*Storage, error) {
-	//nolint:goconst,nolintlint
-	if driver != "postgres" && driver != "postgresql" {
-		return nil, projectErrors.ErrUnsupportedDriver
+	d, err := newDialect(driver)
+	if err != nil {
+		return nil, err
 	}
 
-	// Normalizing driver name to "postgres" for consistency.
-	driver = "postgres"
-
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable connect_timeout=%d",
-		host, port, user, password, dbname, int(timeout.Seconds()),
-	)
-
 	storage := &Storage{
 		db:      &SQLXWrapper{},
-		driver:  driver,
-		dsn:     dsn,
+		dialect: d,
+		driver:  d.driverName(),
 		timeout: timeout,
+		conn: connConfig{
+			user:     user,
+			password: password,
+			dbname:   dbname,
+			timeout:  timeout,
+			sslMode:  "disable",
+		},
 	}
 
 	for _, opt := range opts {
 		opt(storage)
 	}
+
+	if _, ok := sslModes[storage.conn.sslMode]; !ok {
+		return nil, fmt.Errorf("%w: unsupported sslmode %q", projectErrors.ErrCorruptedConfig, storage.conn.sslMode)
+	}
+
+	if len(storage.replicaAddrs) > 0 && !d.supportsReplicas() {
+		return nil, fmt.Errorf("%w: replicas are not supported by %s", projectErrors.ErrCorruptedConfig, d.name())
+	}
+
+	storage.dsn, err = d.dsn(host, port, storage.conn)
+	if err != nil {
+		return nil, err
+	}
+	if w, ok := storage.db.(*SQLXWrapper); ok {
+		w.pool = storage.pool
+	}
+
+	for i, addr := range storage.replicaAddrs {
+		replicaHost, replicaPort, err := net.SplitHostPort(addr)
+		if err != nil {
+			// No port specified.
+			replicaHost, replicaPort = addr, port
+		}
+		if replicaHost == "" {
+			return nil, fmt.Errorf("%w: invalid replica address %q", projectErrors.ErrCorruptedConfig, addr)
+		}
+
+		var db DB = &SQLXWrapper{pool: storage.pool}
+		if i < len(storage.replicaDBs) {
+			db = storage.replicaDBs[i]
+		}
+		dsn, err := d.dsn(replicaHost, replicaPort, storage.conn)
+		if err != nil {
+			return nil, err
+		}
+		storage.replicas = append(storage.replicas, &replica{db: db, dsn: dsn})
+	}
+
 	return storage, nil
 }
 
@@ -73,14 +190,35 @@ func NewStorage(timeout time.Duration, driver, host, port, user, password, dbnam
 // If the connection is successful, it pings the database
 // to check if the connection is alive. If any error occurs during the connection
 // or pinging, it returns an error.
+//
+// Replicas are connected afterwards. Replica connection errors are not returned:
+// the unavailable replicas are skipped until they pass the health check.
+//
+// If auto migration is enabled, pending schema migrations are applied after the connection is established.
+// Migrations are not limited by the storage timeout.
 func (s *Storage) Connect(ctx context.Context) error {
-	return s.withTimeout(ctx, func(localCtx context.Context) error {
+	err := s.withTimeout(ctx, func(localCtx context.Context) error {
 		_, err := s.db.ConnectContext(localCtx, s.driver, s.dsn)
 		if err != nil {
 			return fmt.Errorf("storage connection: %w", err)
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range s.replicas {
+		s.checkReplica(ctx, r)
+	}
+
+	if !s.autoMigrate {
+		return nil
+	}
+	if err := s.migrate(ctx); err != nil {
+		return fmt.Errorf("storage auto migration: %w", err)
+	}
+	return nil
 }
 
 // Close closes the connection to the database.
@@ -92,4 +230,9 @@ func (s *Storage) Close(_ context.Context) {
 	if s.db != nil {
 		s.db.Close()
 	}
+	for _, r := range s.replicas {
+		if r.db != nil {
+			r.db.Close()
+		}
+	}
 }
@@ -3,8 +3,10 @@ package sql_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
 	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
 	"github.com/jmoiron/sqlx"                                                              //nolint:depguard,nolintlint
+	"github.com/lib/pq"                                                                    //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/mock"                                                     //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
 	"github.com/stretchr/testify/suite"                                                    //nolint:depguard,nolintlint
@@ -90,20 +93,20 @@ func (s *SQLSuite) mockEventNotExists() {
 }
 
 func (s *SQLSuite) mockEventOverlaps(isOverlaps bool) {
-	// We expect 7 arguments for the GetContext call:
+	// We expect 7 arguments for the SelectContext call:
 	// 3 necessary + variadic of 4 arguments.
 	callArgs := make([]any, 7)
 	for i := range callArgs {
 		callArgs[i] = mock.Anything
 	}
 	if !isOverlaps {
-		s.txMock.On("GetContext", callArgs...).Return(nil).Once()
+		s.txMock.On("SelectContext", callArgs...).Return(nil).Once()
 		return
 	}
-	s.txMock.On("GetContext", callArgs...).Run(func(args mock.Arguments) {
-		// Simulating query returning true for overlapping dates.
-		dest := args.Get(1).(*bool)
-		*dest = true
+	s.txMock.On("SelectContext", callArgs...).Run(func(args mock.Arguments) {
+		// Simulating query returning the conflicting event IDs.
+		dest := args.Get(1).(*[]uuid.UUID)
+		*dest = []uuid.UUID{uuid.New()}
 	}).Return(nil).Once()
 }
 
@@ -132,6 +135,18 @@ func (s *SQLSuite) mockGetEvents(events *[]*types.DBEvent, isFound bool, argLen
 		}).Return(nil).Once()
 }
 
+// mockChangeSeq is a helper function to mock the reservation of the next change sequence number.
+func (s *SQLSuite) mockChangeSeq() {
+	s.txMock.On("ExecContext", mock.Anything, mock.Anything).Return(ResultMock{rowsAffected: 1}, nil).Once()
+	s.txMock.On("GetContext", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+}
+
+// mockRecordChange is a helper function to mock recording the event change in the change log.
+func (s *SQLSuite) mockRecordChange() {
+	s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
+		Return(ResultMock{rowsAffected: 1}, nil).Once()
+}
+
 // mockBeginTx is a helper function to mock the beginning of a transaction.
 func (s *SQLSuite) mockBeginTx(success bool) {
 	if !success {
@@ -189,15 +204,35 @@ func (s *SQLSuite) TestNewStorage() {
 			expected: nil,
 		},
 		{
-			name:     "unsupported driver",
+			name:     "valid mysql driver",
 			driver:   "mysql",
+			expected: nil,
+		},
+		{
+			name:     "valid mariadb driver",
+			driver:   "mariadb",
+			expected: nil,
+		},
+		{
+			name:     "valid sqlite driver",
+			driver:   "sqlite",
+			expected: nil,
+		},
+		{
+			name:     "valid sqlite3 driver",
+			driver:   "sqlite3",
+			expected: nil,
+		},
+		{
+			name:     "unsupported driver",
+			driver:   "oracle",
 			expected: projectErrors.ErrUnsupportedDriver,
 		},
 	}
 
 	for _, tC := range testCases {
 		s.Run(tC.name, func() {
-			storage, err := tPkg.NewStorage(time.Second, tC.driver, "host", "port", "user", "pass", "dbname")
+			storage, err := tPkg.NewStorage(time.Second, tC.driver, "host", "3306", "user", "pass", "dbname")
 			if tC.expected != nil {
 				s.Require().Error(err, "expected error, got nil")
 				s.Require().ErrorIs(err, tC.expected, "expected error does not match")
@@ -210,6 +245,73 @@ func (s *SQLSuite) TestNewStorage() {
 	}
 }
 
+func (s *SQLSuite) TestNewStorageOptions() {
+	testCases := []struct {
+		name     string
+		driver   string
+		opts     []tPkg.StorageOption
+		expected error
+	}{
+		{
+			name: "valid options",
+			opts: []tPkg.StorageOption{
+				tPkg.WithTLS("verify-full", "/certs/root ca.pem", "/certs/client.pem", "/certs/client.key"),
+				tPkg.WithReplicas("replica1", "replica2:5433"),
+				tPkg.WithPool(tPkg.PoolConfig{MaxOpenConns: 10, MaxIdleConns: 5, ConnMaxLifetime: time.Minute}),
+			},
+			expected: nil,
+		},
+		{
+			name:     "unsupported sslmode",
+			opts:     []tPkg.StorageOption{tPkg.WithTLS("always", "", "", "")},
+			expected: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name:     "invalid replica address",
+			opts:     []tPkg.StorageOption{tPkg.WithReplicas(":5432")},
+			expected: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name:   "mysql options",
+			driver: "mysql",
+			opts: []tPkg.StorageOption{
+				tPkg.WithTLS("require", "", "", ""),
+				tPkg.WithReplicas("replica1", "replica2:3307"),
+			},
+			expected: nil,
+		},
+		{
+			name:     "mysql missing root certificate",
+			driver:   "mysql",
+			opts:     []tPkg.StorageOption{tPkg.WithTLS("verify-ca", "/nonexistent/root.pem", "", "")},
+			expected: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name:     "sqlite replicas",
+			driver:   "sqlite",
+			opts:     []tPkg.StorageOption{tPkg.WithReplicas("replica1")},
+			expected: projectErrors.ErrCorruptedConfig,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			driver := tC.driver
+			if driver == "" {
+				driver = "postgres"
+			}
+			storage, err := tPkg.NewStorage(time.Second, driver, "host", "port", "user", "pass", "dbname", tC.opts...)
+			if tC.expected != nil {
+				s.Require().ErrorIs(err, tC.expected, "expected error does not match")
+				s.Require().Nil(storage, "expected nil storage, got non-nil")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().NotNil(storage, "expected non-nil storage, got nil")
+		})
+	}
+}
+
 func (s *SQLSuite) TestConnect() {
 	testCases := []struct {
 		name     string
@@ -267,6 +369,11 @@ func (s *SQLSuite) TestClose() {
 
 func (s *SQLSuite) TestCreateEvent() {
 	event := s.newTestEvent("Create event", "user1")
+	allowedEvent := s.newTestEvent("Create event", "user1")
+	allowedEvent.OverlapPolicy = types.OverlapPolicyAllow
+	resourceID := uuid.New()
+	booking := s.newTestEvent("Create event", "user1")
+	booking.Resources = []string{resourceID.String()}
 
 	testCases := []struct {
 		name     string
@@ -284,8 +391,10 @@ func (s *SQLSuite) TestCreateEvent() {
 			txMockFn: func() {
 				s.mockEventNotExists()
 				s.mockEventOverlaps(false)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
 				s.mockCommit(true)
 			},
 			expected: nil,
@@ -322,6 +431,23 @@ func (s *SQLSuite) TestCreateEvent() {
 			},
 			expected: projectErrors.ErrDateBusy,
 		},
+		{
+			name:  "overlaps allowed by policy",
+			event: allowedEvent,
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			txMockFn: func() {
+				// No overlap check is expected.
+				s.mockEventNotExists()
+				s.mockChangeSeq()
+				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
+					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
+				s.mockCommit(true)
+			},
+			expected: nil,
+		},
 		{
 			name:  "query error",
 			event: event,
@@ -331,12 +457,28 @@ func (s *SQLSuite) TestCreateEvent() {
 			txMockFn: func() {
 				s.mockEventNotExists()
 				s.mockEventOverlaps(false)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 0}, errUnknownErr).Once()
 				s.mockRollback(true)
 			},
 			expected: projectErrors.ErrQeuryError,
 		},
+		{
+			name:  "resources lock error",
+			event: booking,
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			txMockFn: func() {
+				// Booked resources are locked before any other read of the transaction.
+				s.txMock.On("SelectContext", mock.Anything, mock.Anything, mock.MatchedBy(func(query string) bool {
+					return strings.Contains(query, "FOR UPDATE")
+				}), resourceID).Return(errUnknownErr).Once()
+				s.mockRollback(true)
+			},
+			expected: projectErrors.ErrQeuryError,
+		},
 		{
 			name:  "begin transaction error",
 			event: event,
@@ -354,8 +496,10 @@ func (s *SQLSuite) TestCreateEvent() {
 			txMockFn: func() {
 				s.mockEventNotExists()
 				s.mockEventOverlaps(false)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
 				s.mockCommit(false)
 				s.mockRollback(true)
 			},
@@ -420,8 +564,10 @@ func (s *SQLSuite) TestUpdateEvent() {
 			txMockFn: func() {
 				s.mockEventExists(event)
 				s.mockEventOverlaps(false)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
 				s.mockCommit(true)
 			},
 			expected: nil,
@@ -489,6 +635,7 @@ func (s *SQLSuite) TestUpdateEvent() {
 			txMockFn: func() {
 				s.mockEventExists(event)
 				s.mockEventOverlaps(false)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, errUnknownErr).Once()
 				s.mockRollback(true)
@@ -505,8 +652,10 @@ func (s *SQLSuite) TestUpdateEvent() {
 			txMockFn: func() {
 				s.mockEventExists(event)
 				s.mockEventOverlaps(false)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
 				s.mockCommit(false)
 				s.mockRollback(true)
 			},
@@ -570,8 +719,10 @@ func (s *SQLSuite) TestDeleteEvent() {
 			},
 			txMockFn: func() {
 				s.mockEventExists(event)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
 				s.mockCommit(true)
 			},
 			expected: nil,
@@ -596,12 +747,27 @@ func (s *SQLSuite) TestDeleteEvent() {
 			},
 			txMockFn: func() {
 				s.mockEventExists(event)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 0}, errUnknownErr).Once()
 				s.mockRollback(true)
 			},
 			expected: projectErrors.ErrQeuryError,
 		},
+		{
+			name: "change log error",
+			id:   event.ID,
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			txMockFn: func() {
+				// The sequence number is reserved before the event is deleted.
+				s.mockEventExists(event)
+				s.txMock.On("ExecContext", mock.Anything, mock.Anything).Return(nil, errUnknownErr).Once()
+				s.mockRollback(true)
+			},
+			expected: projectErrors.ErrQeuryError,
+		},
 		{
 			name: commitErrCase,
 			id:   event.ID,
@@ -610,8 +776,10 @@ func (s *SQLSuite) TestDeleteEvent() {
 			},
 			txMockFn: func() {
 				s.mockEventExists(event)
+				s.mockChangeSeq()
 				s.txMock.On("NamedExecContext", mock.Anything, mock.Anything, mock.Anything).
 					Return(ResultMock{rowsAffected: 1}, nil).Once()
+				s.mockRecordChange()
 				s.mockCommit(false)
 				s.mockRollback(true)
 			},
@@ -799,10 +967,12 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 		s.newTestEvent("Event 2", userID).ToDBEvent(),
 	}
 	userIDPtr := &userID
+	location := "Room 1"
 
 	testCases := []struct {
 		name     string
 		userID   *string
+		filter   *types.EventFilter
 		dbMockFn func()
 		txMockFn func()
 		expected error
@@ -814,7 +984,7 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 				s.mockBeginTx(true)
 			},
 			txMockFn: func() {
-				s.mockGetEvents(&events, true, 3)
+				s.mockGetEvents(&events, true, 5)
 				s.mockCommit(true)
 			},
 			expected: nil,
@@ -826,7 +996,20 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 				s.mockBeginTx(true)
 			},
 			txMockFn: func() {
-				s.mockGetEvents(&events, true, 3)
+				s.mockGetEvents(&events, true, 5)
+				s.mockCommit(true)
+			},
+			expected: nil,
+		},
+		{
+			name:   "valid get with filter",
+			userID: userIDPtr,
+			filter: types.NewEventFilter([]string{"work"}, &location),
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			txMockFn: func() {
+				s.mockGetEvents(&events, true, 7)
 				s.mockCommit(true)
 			},
 			expected: nil,
@@ -838,7 +1021,7 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 				s.mockBeginTx(true)
 			},
 			txMockFn: func() {
-				s.mockGetEvents(&events, false, 3)
+				s.mockGetEvents(&events, false, 5)
 				s.mockCommit(true)
 			},
 			expected: projectErrors.ErrEventNotFound,
@@ -850,7 +1033,7 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 				s.mockBeginTx(true)
 			},
 			txMockFn: func() {
-				s.mockGetEvents(&events, false, 3)
+				s.mockGetEvents(&events, false, 5)
 				s.mockCommit(true)
 			},
 			expected: projectErrors.ErrEventNotFound,
@@ -863,7 +1046,7 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 			},
 			txMockFn: func() {
 				s.txMock.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-					mock.Anything, mock.Anything, mock.Anything).
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(errUnknownErr).Once()
 				s.mockRollback(true)
 			},
@@ -877,7 +1060,7 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 			},
 			txMockFn: func() {
 				s.txMock.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-					mock.Anything, mock.Anything, mock.Anything).
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(errUnknownErr).Once()
 				s.mockRollback(true)
 			},
@@ -889,7 +1072,7 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 		s.Run(tC.name, func() {
 			tC.dbMockFn()
 			tC.txMockFn()
-			result, err := s.storage.GetEventsForPeriod(s.ctx, start, end, tC.userID)
+			result, err := s.storage.GetEventsForPeriod(s.ctx, start, end, tC.userID, tC.filter)
 			if tC.expected != nil {
 				s.Require().Error(err, "expected error, got nil")
 				s.Require().ErrorIs(err, tC.expected, "expected error does not match")
@@ -909,3 +1092,201 @@ func (s *SQLSuite) TestGetEventsForPeriod() {
 		})
 	}
 }
+
+func (s *SQLSuite) TestGetNextReminderTime() {
+	next := time.Now().Add(time.Hour).Truncate(time.Second)
+	callArgs := []any{mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything}
+
+	testCases := []struct {
+		name     string
+		txMockFn func()
+		expected error
+	}{
+		{
+			name: "valid get",
+			txMockFn: func() {
+				s.txMock.On("GetContext", callArgs...).Run(func(args mock.Arguments) {
+					dest := args.Get(1).(*types.DBEvent)
+					*dest = types.DBEvent{
+						DBEventData: types.DBEventData{
+							Datetime: next.Add(remindIn),
+							RemindIn: types.NewDuration(remindIn),
+						},
+					}
+				}).Return(nil).Once()
+				s.mockCommit(true)
+			},
+			expected: nil,
+		},
+		{
+			name: "no events",
+			txMockFn: func() {
+				s.txMock.On("GetContext", callArgs...).Return(sql.ErrNoRows).Once()
+				s.mockCommit(true)
+			},
+			expected: projectErrors.ErrEventNotFound,
+		},
+		{
+			name: "query error",
+			txMockFn: func() {
+				s.txMock.On("GetContext", callArgs...).Return(errUnknownErr).Once()
+				s.mockRollback(true)
+			},
+			expected: projectErrors.ErrQeuryError,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.mockBeginTx(true)
+			tC.txMockFn()
+			result, err := s.storage.GetNextReminderTime(s.ctx)
+			if tC.expected != nil {
+				s.Require().ErrorIs(err, tC.expected, "expected error does not match")
+				s.Require().True(result.IsZero(), "expected zero time, got non-zero")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().Equal(next, result, "next reminder time does not match the expected one")
+		})
+	}
+}
+
+func (s *SQLSuite) TestMigrate() {
+	// Commands applying migrations require a live database, so only the command validation is checked here.
+	for _, seed := range []bool{false, true} {
+		s.Run(fmt.Sprintf("unknown command, seed=%t", seed), func() {
+			report, err := s.storage.Migrate(s.ctx, "sideways", seed)
+			s.Require().ErrorIs(err, projectErrors.ErrUnknownMigrationCommand, "expected error does not match")
+			s.Require().Empty(report, "expected empty report")
+		})
+	}
+}
+
+func (s *SQLSuite) TestReplicaRouting() {
+	events := []*types.DBEvent{s.newTestEvent("Event 1", "user1").ToDBEvent()}
+	start := time.Now()
+	end := start.Add(24 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		replicaMockFn func(replicaMock *mocks.DB)
+		dbMockFn      func()
+		queryFn       func() error
+		argLen        int
+	}{
+		{
+			name: "read query on replica",
+			replicaMockFn: func(replicaMock *mocks.DB) {
+				replicaMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(&sqlx.DB{}, nil).Once()
+				replicaMock.On("BeginTxx", mock.Anything, mock.Anything).Return(s.txMock, nil).Once()
+			},
+			dbMockFn: func() {},
+			queryFn: func() error {
+				_, err := s.storage.GetEventsForPeriod(s.ctx, start, end, nil, nil)
+				return err
+			},
+			argLen: 4,
+		},
+		{
+			name: "unavailable replica",
+			replicaMockFn: func(replicaMock *mocks.DB) {
+				replicaMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(nil, errUnknownErr).Once()
+			},
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			queryFn: func() error {
+				_, err := s.storage.GetEventsForPeriod(s.ctx, start, end, nil, nil)
+				return err
+			},
+			argLen: 4,
+		},
+		{
+			name: "replica transaction failure",
+			replicaMockFn: func(replicaMock *mocks.DB) {
+				replicaMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(&sqlx.DB{}, nil).Once()
+				replicaMock.On("BeginTxx", mock.Anything, mock.Anything).Return(nil, errUnknownErr).Once()
+			},
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			queryFn: func() error {
+				_, err := s.storage.GetEventsForPeriod(s.ctx, start, end, nil, nil)
+				return err
+			},
+			argLen: 4,
+		},
+		{
+			name: "replica connection lost",
+			replicaMockFn: func(replicaMock *mocks.DB) {
+				replicaTx := mocks.NewTx(s.T())
+				replicaTx.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything, mock.Anything).Return(driver.ErrBadConn).Once()
+				replicaTx.On("Rollback").Return(nil).Once()
+				replicaMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(&sqlx.DB{}, nil).Once()
+				replicaMock.On("BeginTxx", mock.Anything, mock.Anything).Return(replicaTx, nil).Once()
+			},
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			queryFn: func() error {
+				_, err := s.storage.GetEventsForPeriod(s.ctx, start, end, nil, nil)
+				return err
+			},
+			argLen: 4,
+		},
+		{
+			name: "replica shutdown",
+			replicaMockFn: func(replicaMock *mocks.DB) {
+				replicaTx := mocks.NewTx(s.T())
+				replicaTx.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything, mock.Anything).Return(&pq.Error{Code: "57P01"}).Once()
+				replicaTx.On("Rollback").Return(nil).Once()
+				replicaMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(&sqlx.DB{}, nil).Once()
+				replicaMock.On("BeginTxx", mock.Anything, mock.Anything).Return(replicaTx, nil).Once()
+			},
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			queryFn: func() error {
+				_, err := s.storage.GetEventsForPeriod(s.ctx, start, end, nil, nil)
+				return err
+			},
+			argLen: 4,
+		},
+		{
+			name: "notification query on primary",
+			replicaMockFn: func(replicaMock *mocks.DB) {
+				replicaMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(&sqlx.DB{}, nil).Once()
+			},
+			dbMockFn: func() {
+				s.mockBeginTx(true)
+			},
+			queryFn: func() error {
+				_, err := s.storage.GetEventsForNotification(s.ctx)
+				return err
+			},
+			argLen: 3,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			replicaMock := mocks.NewDB(s.T())
+			var err error
+			s.storage, err = tPkg.NewStorage(time.Second, "postgres", "host", "port", "user", "pass", "dbname",
+				tPkg.WithDB(s.dbMock), tPkg.WithReplicas("replica"), tPkg.WithReplicaDBs(replicaMock))
+			s.Require().NoError(err, "expected no error creating storage")
+
+			s.dbMock.On("ConnectContext", mock.Anything, "postgres", mock.Anything).Return(&sqlx.DB{}, nil).Once()
+			tC.replicaMockFn(replicaMock)
+			s.Require().NoError(s.storage.Connect(s.ctx), "expected no error connecting storage")
+
+			tC.dbMockFn()
+			s.mockGetEvents(&events, true, tC.argLen)
+			s.mockCommit(true)
+			s.Require().NoError(tC.queryFn(), "expected nil, got error")
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package sql_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage"              //nolint:depguard,nolintlint
+	tPkg "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/sql"     //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/storagetest"  //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/jmoiron/sqlx"                                                              //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// newSQLiteStorage creates a storage on a new SQLite database file, created with the embedded migrations.
+func newSQLiteStorage(t *testing.T) *tPkg.Storage {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "calendar.db")
+	s, err := tPkg.NewStorage(5*time.Second, "sqlite", "", "", "", "", path, tPkg.WithAutoMigrate(true))
+	require.NoError(t, err, "failed to create storage")
+	return s
+}
+
+// TestSQLiteConformance runs the shared storage suite against the SQL storage,
+// using in-process SQLite as a stand-in for the database server.
+func TestSQLiteConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		t.Helper()
+		return newSQLiteStorage(t)
+	})
+}
+
+func TestSQLiteUnsupportedFeatures(t *testing.T) {
+	ctx := context.Background()
+	s := newSQLiteStorage(t)
+	require.NoError(t, s.Connect(ctx), "failed to connect to storage")
+	defer s.Close(ctx)
+
+	_, err := s.WatchChanges(ctx)
+	require.ErrorIs(t, err, projectErrors.ErrWatchUnsupported, "expected watch unsupported error")
+
+	_, err = s.Migrate(ctx, tPkg.MigrateStatus, true)
+	require.ErrorIs(t, err, projectErrors.ErrMigrationUnsupported, "expected migration unsupported error")
+
+	report, err := s.Migrate(ctx, tPkg.MigrateStatus, false)
+	require.NoError(t, err, "expected nil, got error")
+	require.Contains(t, report, "0001_init_schema.sql", "schema migration is missing in the report")
+}
+
+func TestSQLiteNotificationTemplates(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "calendar.db")
+	s, err := tPkg.NewStorage(5*time.Second, "sqlite", "", "", "", "", path, tPkg.WithAutoMigrate(true))
+	require.NoError(t, err, "failed to create storage")
+	require.NoError(t, s.Connect(ctx), "failed to connect to storage")
+	defer s.Close(ctx)
+
+	list, err := s.GetNotificationTemplates(ctx)
+	require.NoError(t, err, "expected nil, got error")
+	require.Empty(t, list, "expected no templates")
+	settings, err := s.GetUserSettings(ctx, "user")
+	require.NoError(t, err, "expected nil, got error")
+	require.Nil(t, settings, "expected no user settings")
+
+	db, err := sqlx.Open("sqlite", path)
+	require.NoError(t, err, "failed to open database")
+	defer db.Close()
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO notification_templates (channel, locale, format, body) VALUES ('email', 'en', 'html', '<p></p>');
+		INSERT INTO notification_templates (kind, channel, locale, body) VALUES ('digest', 'email', 'en', '');
+		INSERT INTO user_settings (user_id, locale, time_zone) VALUES ('user', 'ru', 'Europe/Moscow');
+	`)
+	require.NoError(t, err, "failed to insert test data")
+
+	list, err = s.GetNotificationTemplates(ctx)
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, []*types.NotificationTemplate{
+		{Kind: types.TemplateDigest, Channel: "email", Locale: "en", Format: types.TemplateText},
+		{Kind: types.TemplateReminder, Channel: "email", Locale: "en", Format: types.TemplateHTML, Body: "<p></p>"},
+	}, list, "unexpected templates")
+	settings, err = s.GetUserSettings(ctx, "user")
+	require.NoError(t, err, "expected nil, got error")
+	require.Equal(t, &types.UserSettings{UserID: "user", Locale: "ru", TimeZone: "Europe/Moscow"}, settings)
+}
@@ -0,0 +1,70 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// Queries of the notification templates and the user settings are the same for all dialects.
+const (
+	getNotificationTemplatesQuery = `
+	SELECT kind, channel, locale, format, body
+	FROM notification_templates
+	ORDER BY kind, channel, locale
+	`
+	getUserSettingsQuery = "SELECT user_id, locale, time_zone FROM user_settings WHERE user_id = :user_id"
+)
+
+// GetNotificationTemplates retrieves all notification templates from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns a slice of templates, which is empty if there are no templates, or any error encountered.
+func (s *Storage) GetNotificationTemplates(ctx context.Context) ([]*types.NotificationTemplate, error) {
+	res := make([]*types.NotificationTemplate, 0)
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if err := tx.SelectContext(localCtx, &res, getNotificationTemplatesQuery); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get notification templates: %w", err)
+	}
+	return res, nil
+}
+
+// GetUserSettings retrieves the settings of the user with the given ID from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns nil, nil if the user has no settings or nil and any error encountered.
+func (s *Storage) GetUserSettings(ctx context.Context, userID string) (*types.UserSettings, error) {
+	var res *types.UserSettings
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			UserID string `db:"user_id"`
+		}{userID}
+		query, qArgs, err := s.rebindQuery(getUserSettingsQuery, args)
+		if err != nil {
+			return err
+		}
+		var settings types.UserSettings
+		err = tx.GetContext(localCtx, &settings, query, qArgs...)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		res = &settings
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get user settings: %w", err)
+	}
+	return res, nil
+}
@@ -5,26 +5,26 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
-	"github.com/google/uuid"                                                //nolint:depguard,nolintlint
-	"github.com/jmoiron/sqlx"                                               //nolint:depguard,nolintlint
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/jmoiron/sqlx"                                                              //nolint:depguard,nolintlint
 )
 
 const (
 	queryGetExistingEvent = "SELECT * FROM events WHERE id = :id"
-	queryIsOverlaps       = `
-	SELECT EXISTS (
-		SELECT 1
-		FROM events
-		WHERE user_id = :user_id
-			AND datetime < :end_time
-			AND datetime + duration > :datetime
-			AND id != :id
-	) AS has_conflicts
-	`
+	queryResourceExists   = "SELECT COUNT(*) FROM resources WHERE id = :id"
+	queryCountUserEvents  = "SELECT COUNT(*) FROM events WHERE user_id = :user_id"
+	// queryCountWrapper counts the rows of the dialect query.
+	queryCountWrapper = "SELECT COUNT(*) FROM (%s) AS counted"
+	// userFilter limits the overlap check to the events of the same user.
+	userFilter = "AND user_id = :user_id"
+	// blockingStatusFilter limits the overlap check to the events which make the user unavailable.
+	blockingStatusFilter = "AND busy_status NOT IN ('free', 'tentative')"
 )
 
 // getExistingEvent gets the event with the given ID from the database.
@@ -50,26 +50,148 @@ func (s *Storage) getExistingEvent(ctx context.Context, tx Tx, id uuid.UUID) (*t
 	return dbEvent.ToEvent(), nil
 }
 
-// isOverlaps checks if the given user event overlaps with any of his existing events in the database.
-func (s *Storage) isOverlaps(ctx context.Context, tx Tx, event *types.Event) (bool, error) {
-	var hasConflicts bool
+// findConflicts returns the IDs of the events conflicting with the given one: the overlapping events of the same user,
+// forbidden by the overlap policy, followed by the overlapping bookings of the same resources.
+// Bookings of the same resource always conflict, regardless of the overlap policy and the busy statuses.
+func (s *Storage) findConflicts(ctx context.Context, tx Tx, event *types.Event) ([]string, error) {
+	res, err := s.isOverlaps(ctx, tx, event)
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceID := range event.Resources {
+		ids, err := s.overlapping(ctx, tx, event, s.dialect.queries().resourcesFilter, types.Tags{resourceID})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if !slices.Contains(res, id) {
+				res = append(res, id)
+			}
+		}
+	}
+	return res, nil
+}
+
+// isOverlaps checks if the given user event overlaps with any of his existing events in the database
+// and returns the IDs of the conflicting events according to the event overlap policy.
+func (s *Storage) isOverlaps(ctx context.Context, tx Tx, event *types.Event) ([]string, error) {
+	filter := userFilter
+	switch event.OverlapPolicy {
+	case types.OverlapPolicyAllow:
+		return nil, nil
+	case types.OverlapPolicyAllowIfFree:
+		// Non-blocking event may overlap with anything.
+		if !event.BusyStatus.IsBlocking() {
+			return nil, nil
+		}
+		filter += " " + blockingStatusFilter
+	default:
+	}
+
+	return s.overlapping(ctx, tx, event, filter, nil)
+}
+
+// overlapping returns the IDs of the events overlapping with the given one and matching the filter clause.
+// Resources are the argument of the resources filter, if it is used.
+func (s *Storage) overlapping(ctx context.Context, tx Tx, event *types.Event, filter string,
+	resources types.Tags,
+) ([]string, error) {
+	var conflicts []uuid.UUID
 	args := struct {
-		UserID   string    `db:"user_id"`
-		Datetime time.Time `db:"datetime"`
-		EndTime  time.Time `db:"end_time"`
-		ID       uuid.UUID `db:"id"`
-	}{event.UserID, event.Datetime, event.Datetime.Add(event.Duration), event.ID}
+		UserID    string    `db:"user_id"`
+		Datetime  time.Time `db:"datetime"`
+		EndTime   time.Time `db:"end_time"`
+		ID        uuid.UUID `db:"id"`
+		Resources any       `db:"resources"`
+	}{event.UserID, event.Datetime, event.Datetime.Add(event.Duration), event.ID, s.dialect.tagsArg(resources)}
 	// Check the interval, excluding intersections with the event itself.
-	query, qArgs, err := s.rebindQuery(queryIsOverlaps, args)
+	query, qArgs, err := s.rebindQuery(fmt.Sprintf(s.dialect.queries().isOverlaps, filter), args)
 	if err != nil {
-		return false, fmt.Errorf("event overlap check: %w", err)
+		return nil, fmt.Errorf("event overlap check: %w", err)
 	}
-	err = tx.GetContext(ctx, &hasConflicts, query, qArgs...)
+	err = tx.SelectContext(ctx, &conflicts, query, qArgs...)
 	if err != nil {
-		return false, fmt.Errorf("event overlap check: %w", err)
+		return nil, fmt.Errorf("event overlap check: %w", err)
 	}
 
-	return hasConflicts, nil
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	res := make([]string, len(conflicts))
+	for i, id := range conflicts {
+		res[i] = id.String()
+	}
+
+	return res, nil
+}
+
+// lockResources locks the rows of the given resources until the end of the transaction, so the concurrent
+// bookings of the same resources are checked for the conflicts one after another. Rows are locked in the order
+// of their IDs to avoid deadlocks. Invalid and missing resources are skipped.
+//
+// The lock must be taken before the other reads of the transaction, as MySQL reads the snapshot,
+// created by the first of them.
+func (s *Storage) lockResources(ctx context.Context, tx Tx, ids []string) error {
+	query := s.dialect.queries().lockResources
+	if query == "" {
+		return nil
+	}
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if resourceID, err := uuid.Parse(id); err == nil {
+			args = append(args, resourceID)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	// UUID arguments guarantee that no injections are possible for unnamed placeholders.
+	query = sqlx.Rebind(s.getBindvar(), s.replacePlaceholder(query, ":id_list", len(args)))
+	var locked []string
+	if err := tx.SelectContext(ctx, &locked, query, args...); err != nil {
+		return fmt.Errorf("%w: resources lock: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
+
+// checkResources returns ErrResourceNotFound if any of the given resources does not exist.
+func (s *Storage) checkResources(ctx context.Context, tx Tx, ids []string) error {
+	for _, id := range ids {
+		resourceID, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("%w: id=%q", projectErrors.ErrResourceNotFound, id)
+		}
+		query, qArgs, err := s.rebindQuery(queryResourceExists, resourceIDArgs{resourceID})
+		if err != nil {
+			return fmt.Errorf("%w: resource existence check: %w", projectErrors.ErrQeuryError, err)
+		}
+		var count int
+		if err := tx.GetContext(ctx, &count, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: resource existence check: %w", projectErrors.ErrQeuryError, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: id=%s", projectErrors.ErrResourceNotFound, id)
+		}
+	}
+	return nil
+}
+
+// eventArgs returns the query arguments of the event, encoding its durations, tags and resources
+// for the storage dialect.
+func (s *Storage) eventArgs(event *types.DBEvent) any {
+	return struct {
+		*types.DBEvent
+		Duration  any `db:"duration"`
+		RemindIn  any `db:"remind_in"`
+		Tags      any `db:"tags"`
+		Resources any `db:"resources"`
+	}{
+		DBEvent:   event,
+		Duration:  s.dialect.durationArg(event.Duration.ToDuration()),
+		RemindIn:  s.dialect.durationArg(event.RemindIn.ToDuration()),
+		Tags:      s.dialect.tagsArg(event.Tags),
+		Resources: s.dialect.tagsArg(event.Resources),
+	}
 }
 
 func (s *Storage) getBindvar() int {
@@ -81,7 +203,7 @@ func (s *Storage) getBindvar() int {
 	//nolint:goconst,nolintlint
 	case "pgx", "postgres", "postgresql":
 		return sqlx.DOLLAR // $1, $2, ...
-	case "mysql", "sqlite3":
+	case "mysql", "sqlite", "sqlite3":
 		return sqlx.QUESTION // ?
 	case "oci8", "ora": // Oracle.
 		return sqlx.NAMED // :arg1, :arg2.
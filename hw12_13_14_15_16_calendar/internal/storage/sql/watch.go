@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/lib/pq"                                                                    //nolint:depguard,nolintlint
+)
+
+const (
+	// eventsChangedChannel is the name of the channel, notified by the database trigger
+	// when an event waiting for notification is created or updated.
+	eventsChangedChannel = "events_changed"
+
+	listenerMinReconnect = time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// WatchChanges subscribes to the events changes in the database using LISTEN/NOTIFY.
+// A signal is sent to the returned channel each time an event waiting for notification is created or updated.
+//
+// Signals are coalesced: a new one is dropped if the previous one has not been received yet.
+// The listener connection is re-established automatically, a signal is sent after each reconnection
+// since the notifications might be lost meanwhile.
+//
+// The subscription is canceled and the channel is closed once the context is done.
+// Returns an error if the listener is unable to start within the storage timeout.
+// Returns ErrWatchUnsupported for the dialects without LISTEN/NOTIFY support.
+func (s *Storage) WatchChanges(ctx context.Context) (<-chan struct{}, error) {
+	method := "watch changes: %w"
+
+	if !s.dialect.supportsListen() {
+		return nil, fmt.Errorf(method, fmt.Errorf("%w: %s", projectErrors.ErrWatchUnsupported, s.dialect.name()))
+	}
+
+	s.mu.RLock()
+	dsn := s.dsn
+	timeout := s.timeout
+	s.mu.RUnlock()
+
+	listener := pq.NewListener(dsn, listenerMinReconnect, listenerMaxReconnect, nil)
+
+	// Listen blocks until the connection is established, so it is limited by the timeout here.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- listener.Listen(eventsChangedChannel)
+	}()
+
+	var timer <-chan time.Time
+	if timeout > 0 {
+		timer = time.After(timeout)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			listener.Close()
+			return nil, fmt.Errorf(method, fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err))
+		}
+	case <-timer:
+		listener.Close()
+		return nil, fmt.Errorf(method, projectErrors.ErrTimeoutExceeded)
+	case <-ctx.Done():
+		listener.Close()
+		return nil, fmt.Errorf(method, fmt.Errorf("%w: %w", projectErrors.ErrTimeoutExceeded, ctx.Err()))
+	}
+
+	ch := make(chan struct{}, 1)
+	go func() {
+		defer close(ch)
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-listener.NotificationChannel():
+				if !ok {
+					return
+				}
+				// Nil notification received after reconnection is treated as a change as well.
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
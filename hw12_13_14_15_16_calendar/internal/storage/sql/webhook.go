@@ -0,0 +1,307 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// Queries of the webhook subscriptions and their delivery log are the same for all dialects.
+const (
+	queryGetWebhook = `
+	SELECT id, user_id, url, secret, event_types, enabled, failures, created_at
+	FROM webhooks
+	WHERE id = :id
+	`
+	queryGetWebhooks = `
+	SELECT id, user_id, url, secret, event_types, enabled, failures, created_at
+	FROM webhooks
+	ORDER BY created_at, id
+	`
+	queryInsertWebhook = `
+	INSERT INTO webhooks (id, user_id, url, secret, event_types, enabled, failures, created_at)
+	VALUES (:id, :user_id, :url, :secret, :event_types, :enabled, :failures, :created_at)
+	`
+	queryUpdateWebhookState = "UPDATE webhooks SET enabled = :enabled, failures = :failures WHERE id = :id"
+	queryDeleteWebhook      = "DELETE FROM webhooks WHERE id = :id"
+
+	queryInsertDelivery = `
+	INSERT INTO webhook_deliveries
+		(delivery_id, attempt, webhook_id, event_type, event_id, status_code, error, success, created_at)
+	VALUES
+		(:delivery_id, :attempt, :webhook_id, :event_type, :event_id, :status_code, :error, :success, :created_at)
+	`
+	queryGetDeliveries = `
+	SELECT delivery_id, attempt, webhook_id, event_type, event_id, status_code, error, success, created_at
+	FROM webhook_deliveries
+	WHERE webhook_id = :webhook_id
+	ORDER BY created_at DESC, delivery_id DESC, attempt DESC
+	`
+	queryDeleteDeliveries = "DELETE FROM webhook_deliveries WHERE webhook_id = :webhook_id"
+	// Creation time of the oldest attempt, kept in the log. No rows if the log is not full.
+	queryDeliveriesBoundary = `
+	SELECT created_at
+	FROM webhook_deliveries
+	WHERE webhook_id = :webhook_id
+	ORDER BY created_at DESC
+	LIMIT 1 OFFSET :offset
+	`
+	queryTrimDeliveries = "DELETE FROM webhook_deliveries WHERE webhook_id = :webhook_id AND created_at < :created_at"
+)
+
+// webhookIDArgs represents the arguments of the queries by the webhook subscription ID.
+type webhookIDArgs struct {
+	ID uuid.UUID `db:"id"`
+}
+
+// CreateWebhook saves the new webhook subscription in the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the stored subscription and nil on success, nil and any error otherwise.
+func (s *Storage) CreateWebhook(ctx context.Context, webhook *types.Webhook) (*types.Webhook, error) {
+	method := "create webhook: %w"
+	if webhook == nil {
+		return nil, fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	stored := *webhook
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		args := struct {
+			*types.Webhook
+			EventTypes any `db:"event_types"`
+		}{&stored, s.dialect.tagsArg(stored.EventTypes)}
+		if _, err := tx.NamedExecContext(localCtx, queryInsertWebhook, args); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(method, err)
+	}
+
+	return &stored, nil
+}
+
+// GetWebhook retrieves the webhook subscription from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns nil and ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error) {
+	var res *types.Webhook
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		webhook, err := s.getWebhook(localCtx, tx, id)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			return projectErrors.ErrWebhookNotFound
+		}
+		res = webhook
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWebhooks retrieves all webhook subscriptions from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns the subscriptions sorted by the creation time.
+func (s *Storage) GetWebhooks(ctx context.Context) ([]*types.Webhook, error) {
+	res := make([]*types.Webhook, 0)
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if err := tx.SelectContext(localCtx, &res, queryGetWebhooks); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		for _, webhook := range res {
+			webhook.CreatedAt = webhook.CreatedAt.UTC()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteWebhook deletes the webhook subscription along with its delivery log from the database.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		// The log is deleted explicitly, since SQLite does not enforce the foreign keys by default.
+		deliveryArgs := struct {
+			WebhookID uuid.UUID `db:"webhook_id"`
+		}{id}
+		if _, err := tx.NamedExecContext(localCtx, queryDeleteDeliveries, deliveryArgs); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+
+		res, err := tx.NamedExecContext(localCtx, queryDeleteWebhook, webhookIDArgs{id})
+		if err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		if affected == 0 {
+			return projectErrors.ErrWebhookNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhookState sets whether the webhook subscription is enabled and its number of consecutive failures
+// in the database. The method uses a transaction with a context and timeouts as configured in Storage.
+//
+// Returns ErrWebhookNotFound if the subscription does not exist.
+func (s *Storage) UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error {
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		// Affected rows are not checked, since MySQL does not count the rows with unchanged values.
+		current, err := s.getWebhook(localCtx, tx, id)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return projectErrors.ErrWebhookNotFound
+		}
+
+		args := struct {
+			ID       uuid.UUID `db:"id"`
+			Enabled  bool      `db:"enabled"`
+			Failures int       `db:"failures"`
+		}{id, enabled, failures}
+		if _, err := tx.NamedExecContext(localCtx, queryUpdateWebhookState, args); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("update webhook state: %w", err)
+	}
+
+	return nil
+}
+
+// AddWebhookDelivery appends the delivery attempt to the delivery log in the database.
+// Only the latest MaxWebhookDeliveries attempts of the subscription are kept.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+func (s *Storage) AddWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error {
+	method := "add webhook delivery: %w"
+	if delivery == nil {
+		return fmt.Errorf(method, projectErrors.ErrNoData)
+	}
+
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		if _, err := tx.NamedExecContext(localCtx, queryInsertDelivery, delivery); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		return s.trimDeliveries(localCtx, tx, delivery.WebhookID)
+	})
+	if err != nil {
+		return fmt.Errorf(method, err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the latest delivery attempts of the webhook subscription from the database,
+// newest first. limit <= 0 means no limit.
+// The method uses a transaction with a context and timeouts as configured in Storage.
+func (s *Storage) GetWebhookDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	limit int,
+) ([]*types.WebhookDelivery, error) {
+	res := make([]*types.WebhookDelivery, 0)
+	err := s.execInTransaction(ctx, func(localCtx context.Context, tx Tx) error {
+		query := queryGetDeliveries
+		if limit > 0 {
+			query += "LIMIT :limit"
+		}
+		args := struct {
+			WebhookID uuid.UUID `db:"webhook_id"`
+			Limit     int       `db:"limit"`
+		}{webhookID, limit}
+		query, qArgs, err := s.rebindQuery(query, args)
+		if err != nil {
+			return err
+		}
+		if err := tx.SelectContext(localCtx, &res, query, qArgs...); err != nil {
+			return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+		}
+		for _, delivery := range res {
+			delivery.CreatedAt = delivery.CreatedAt.UTC()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	return res, nil
+}
+
+// getWebhook retrieves the webhook subscription within the transaction.
+// Returns (nil, nil) if the subscription does not exist.
+func (s *Storage) getWebhook(ctx context.Context, tx Tx, id uuid.UUID) (*types.Webhook, error) {
+	query, qArgs, err := s.rebindQuery(queryGetWebhook, webhookIDArgs{id})
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook types.Webhook
+	if err := tx.GetContext(ctx, &webhook, query, qArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	webhook.CreatedAt = webhook.CreatedAt.UTC()
+	return &webhook, nil
+}
+
+// trimDeliveries deletes the oldest delivery attempts of the subscription beyond MaxWebhookDeliveries.
+// Attempts created at the same time as the oldest kept one are kept as well.
+func (s *Storage) trimDeliveries(ctx context.Context, tx Tx, webhookID uuid.UUID) error {
+	args := struct {
+		WebhookID uuid.UUID `db:"webhook_id"`
+		Offset    int       `db:"offset"`
+		CreatedAt time.Time `db:"created_at"`
+	}{WebhookID: webhookID, Offset: types.MaxWebhookDeliveries - 1}
+
+	query, qArgs, err := s.rebindQuery(queryDeliveriesBoundary, args)
+	if err != nil {
+		return err
+	}
+	var boundary []time.Time
+	if err := tx.SelectContext(ctx, &boundary, query, qArgs...); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	if len(boundary) == 0 {
+		return nil
+	}
+
+	args.CreatedAt = boundary[0]
+	if _, err := tx.NamedExecContext(ctx, queryTrimDeliveries, args); err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrQeuryError, err)
+	}
+	return nil
+}
@@ -5,10 +5,11 @@ import (
 	"fmt"
 
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"      //nolint:depguard,nolintlint
 )
 
 // NewStorage creates a new storage instance based on the provided configuration.
-// The args map must contain a "type" key specifying the storage type ("memory" or "sql").
+// The args map must contain a "type" key specifying the storage type ("memory", "sql" or "bolt").
 // Returns an error wrapped with ErrCorruptedConfig if configuration is invalid,
 // or ErrStorageInitFailed if initialization fails.
 func NewStorage(args map[string]any) (Storage, error) {
@@ -18,7 +19,8 @@ func NewStorage(args map[string]any) (Storage, error) {
 
 	storageType, ok := args["type"]
 	if !ok {
-		return nil, fmt.Errorf("%w: no storage type received", errors.ErrCorruptedConfig)
+		return nil, fmt.Errorf("%w: no storage type received: %w", errors.ErrCorruptedConfig,
+			&config.ValidationError{Missing: []string{"type"}})
 	}
 
 	var s Storage
@@ -29,8 +31,11 @@ func NewStorage(args map[string]any) (Storage, error) {
 		s, err = newMemoryStorage(args)
 	case "sql":
 		s, err = newSQLStorage(args)
+	case "bolt":
+		s, err = newBoltStorage(args)
 	default:
-		return nil, fmt.Errorf("%w: unknown storage type %q", errors.ErrCorruptedConfig, storageType)
+		return nil, fmt.Errorf("%w: unknown storage type %q: %w", errors.ErrCorruptedConfig, storageType,
+			&config.ValidationError{InvalidValue: []string{"type"}})
 	}
 
 	if err != nil {
@@ -39,3 +44,11 @@ func NewStorage(args map[string]any) (Storage, error) {
 
 	return s, nil
 }
+
+// ValidateConfig validates the storage config without creating a storage. Storage constructors do not connect,
+// so the validation is the same as on NewStorage call.
+// Key problems are returned as *config.ValidationError with the keys relative to the storage config.
+func ValidateConfig(args map[string]any) error {
+	_, err := NewStorage(args)
+	return err
+}
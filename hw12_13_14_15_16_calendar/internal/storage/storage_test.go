@@ -42,6 +42,14 @@ func TestNewStorage(t *testing.T) {
 		},
 	}
 
+	defaultBoltArgs := map[string]any{
+		"type": "bolt",
+		"bolt": map[string]any{
+			"path":    "calendar.db",
+			"timeout": time.Second,
+		},
+	}
+
 	testCases := []struct {
 		name          string
 		args          map[string]any
@@ -77,6 +85,11 @@ func TestNewStorage(t *testing.T) {
 			args:          defaultSQLArgs,
 			expectedError: nil,
 		},
+		{
+			name:          "bolt/valid",
+			args:          defaultBoltArgs,
+			expectedError: nil,
+		},
 		{
 			name: "sql/alternative driver",
 			args: func() map[string]any {
@@ -86,6 +99,24 @@ func TestNewStorage(t *testing.T) {
 			}(),
 			expectedError: nil,
 		},
+		{
+			name: "sql/mysql driver",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				cfg["sql"].(map[string]any)["driver"] = "mysql"
+				return cfg
+			}(),
+			expectedError: nil,
+		},
+		{
+			name: "sql/sqlite driver",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				cfg["sql"].(map[string]any)["driver"] = "sqlite"
+				return cfg
+			}(),
+			expectedError: nil,
+		},
 		{
 			name: "memory/missing size",
 			args: func() map[string]any {
@@ -104,6 +135,39 @@ func TestNewStorage(t *testing.T) {
 			}(),
 			expectedError: projectErrors.ErrCorruptedConfig,
 		},
+		{
+			name: "memory/persistence",
+			args: func() map[string]any {
+				cfg := copyMap(defaultMemArgs)
+				memCfg := cfg["memory"].(map[string]any)
+				memCfg["wal_dir"] = "data"
+				memCfg["fsync"] = "always"
+				memCfg["snapshot_interval"] = time.Minute
+				memCfg["snapshot_threshold"] = 1000
+				return cfg
+			}(),
+			expectedError: nil,
+		},
+		{
+			name: "memory/unknown fsync policy",
+			args: func() map[string]any {
+				cfg := copyMap(defaultMemArgs)
+				memCfg := cfg["memory"].(map[string]any)
+				memCfg["wal_dir"] = "data"
+				memCfg["fsync"] = "sometimes"
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name: "memory/fsync interval not a duration",
+			args: func() map[string]any {
+				cfg := copyMap(defaultMemArgs)
+				cfg["memory"].(map[string]any)["fsync_interval"] = "1s"
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+		},
 		{
 			name: "sql/missing host",
 			args: func() map[string]any {
@@ -131,6 +195,56 @@ func TestNewStorage(t *testing.T) {
 			}(),
 			expectedError: projectErrors.ErrCorruptedConfig,
 		},
+		{
+			name: "sql/auto migrate",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				cfg["sql"].(map[string]any)["auto_migrate"] = true
+				return cfg
+			}(),
+			expectedError: nil,
+		},
+		{
+			name: "sql/auto migrate not a bool",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				cfg["sql"].(map[string]any)["auto_migrate"] = "true"
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name: "sql/replicas, tls and pool",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				sqlCfg := cfg["sql"].(map[string]any)
+				sqlCfg["replicas"] = []string{"replica1", "replica2:5433"}
+				sqlCfg["sslmode"] = "verify-full"
+				sqlCfg["sslrootcert"] = "/certs/root.pem"
+				sqlCfg["max_open_conns"] = 10
+				sqlCfg["conn_max_lifetime"] = time.Minute
+				return cfg
+			}(),
+			expectedError: nil,
+		},
+		{
+			name: "sql/replicas not a list",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				cfg["sql"].(map[string]any)["replicas"] = "replica1"
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name: "sql/unsupported sslmode",
+			args: func() map[string]any {
+				cfg := copyMap(defaultSQLArgs)
+				cfg["sql"].(map[string]any)["sslmode"] = "always"
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrStorageInitFailed,
+		},
 		{
 			name: "sql/usupported driver",
 			args: func() map[string]any {
@@ -140,6 +254,24 @@ func TestNewStorage(t *testing.T) {
 			}(),
 			expectedError: projectErrors.ErrStorageInitFailed,
 		},
+		{
+			name: "bolt/missing path",
+			args: func() map[string]any {
+				cfg := copyMap(defaultBoltArgs)
+				delete(cfg["bolt"].(map[string]any), "path")
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrCorruptedConfig,
+		},
+		{
+			name: "bolt/empty path",
+			args: func() map[string]any {
+				cfg := copyMap(defaultBoltArgs)
+				cfg["bolt"].(map[string]any)["path"] = ""
+				return cfg
+			}(),
+			expectedError: projectErrors.ErrStorageInitFailed,
+		},
 	}
 
 	for _, tC := range testCases {
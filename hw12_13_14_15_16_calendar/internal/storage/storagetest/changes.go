@@ -0,0 +1,122 @@
+package storagetest
+
+import (
+	"math"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// changes retrieves the changes of the user since the given sequence number.
+func (s *Suite) changes(userID string, since int64) *types.EventChanges {
+	changes, err := s.storage.GetEventChanges(s.ctx, userID, since)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().NotNil(changes, "expected non-nil changes")
+	s.Require().GreaterOrEqual(changes.Seq, since, "sequence number goes back")
+	return changes
+}
+
+// TestEventChanges checks that the changes since the sequence number contain the changed events of the user
+// and the tombstones of the deleted ones.
+func (s *Suite) TestEventChanges() {
+	empty := s.changes(user1, 0)
+	s.Require().Empty(empty.Events, "expected no events")
+	s.Require().Empty(empty.Deleted, "expected no deleted events")
+
+	first := s.create(s.newEvent("First", user1, 0))
+	second := s.create(s.newEvent("Second", user1, 2*time.Hour))
+	other := s.create(s.newEvent("Other user", user2, 0))
+
+	// Zero sequence number means all events of the user.
+	full := s.changes(user1, 0)
+	s.Require().Equal([]uuid.UUID{first.ID, second.ID}, ids(full.Events), "events do not match")
+	s.Require().Empty(full.Deleted, "expected no deleted events")
+	s.Require().Greater(full.Seq, empty.Seq, "sequence number is not changed")
+
+	unchanged := s.changes(user1, full.Seq)
+	s.Require().Empty(unchanged.Events, "expected no changed events")
+	s.Require().Empty(unchanged.Deleted, "expected no deleted events")
+	s.Require().Equal(full.Seq, unchanged.Seq, "sequence number is changed without changes")
+
+	// Marking the events as notified is not a change of the event.
+	_, err := s.storage.UpdateNotifiedEvents(s.ctx, []uuid.UUID{first.ID})
+	s.Require().NoError(err, "expected nil, got error")
+
+	updated := s.newEvent("First updated", user1, time.Hour)
+	updated.ID = first.ID
+	_, err = s.storage.UpdateEvent(s.ctx, first.ID, &updated.EventData)
+	s.Require().NoError(err, "expected nil, got error")
+	third := s.create(s.newEvent("Third", user1, 4*time.Hour))
+	s.Require().NoError(s.storage.DeleteEvent(s.ctx, second.ID), "expected nil, got error")
+	otherUpdated := s.newEvent("Other user updated", user2, time.Hour)
+	_, err = s.storage.UpdateEvent(s.ctx, other.ID, &otherUpdated.EventData)
+	s.Require().NoError(err, "expected nil, got error")
+
+	delta := s.changes(user1, full.Seq)
+	s.Require().ElementsMatch([]uuid.UUID{first.ID, third.ID}, ids(delta.Events), "changed events do not match")
+	for _, event := range delta.Events {
+		if event.ID == first.ID {
+			s.requireEqualEvent(updated, event)
+		}
+	}
+	s.Require().Equal([]uuid.UUID{second.ID}, delta.Deleted, "deleted events do not match")
+	s.Require().Greater(delta.Seq, full.Seq, "sequence number is not changed")
+
+	// Changes of another user are reported separately.
+	otherDelta := s.changes(user2, full.Seq)
+	s.Require().Equal([]uuid.UUID{other.ID}, ids(otherDelta.Events), "changed events of another user do not match")
+	s.Require().Empty(otherDelta.Deleted, "expected no deleted events of another user")
+
+	latest := s.changes(user1, delta.Seq)
+	s.Require().Empty(latest.Events, "expected no changed events")
+	s.Require().Empty(latest.Deleted, "expected no deleted events")
+
+	full = s.changes(user1, 0)
+	s.Require().Equal([]uuid.UUID{first.ID, third.ID}, ids(full.Events), "events do not match")
+	s.Require().Empty(full.Deleted, "full listing contains deleted events")
+	s.Require().Equal(delta.Seq, full.Seq, "sequence numbers of the same changes do not match")
+
+	for _, since := range []int64{-1, math.MaxInt64} {
+		_, err = s.storage.GetEventChanges(s.ctx, user1, since)
+		s.Require().ErrorIs(err, projectErrors.ErrChangesExpired, "expected error does not match")
+	}
+}
+
+// TestEventChangesCleanup checks that the cleanup of the old events records their deletion
+// and prunes the tombstones of the events deleted before the given date.
+func (s *Suite) TestEventChangesCleanup() {
+	old := s.create(s.newEvent("Old", user1, -48*time.Hour))
+	deleted := s.create(s.newEvent("Deleted", user1, 0))
+	kept := s.create(s.newEvent("Kept", user1, 2*time.Hour))
+	full := s.changes(user1, 0)
+
+	s.Require().NoError(s.storage.DeleteEvent(s.ctx, deleted.ID), "expected nil, got error")
+	afterDelete := s.changes(user1, full.Seq)
+
+	// Tombstones of the events deleted before the cleanup date are pruned, the tombstones of the deleted
+	// old events are kept.
+	n, err := s.storage.DeleteOldEvents(s.ctx, s.base.Add(-time.Hour))
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(int64(1), n, "deleted events count does not match")
+
+	_, err = s.storage.GetEventChanges(s.ctx, user1, full.Seq)
+	s.Require().ErrorIs(err, projectErrors.ErrChangesExpired, "expected error does not match")
+
+	delta := s.changes(user1, afterDelete.Seq)
+	s.Require().Empty(delta.Events, "expected no changed events")
+	s.Require().Equal([]uuid.UUID{old.ID}, delta.Deleted, "deleted events do not match")
+
+	full = s.changes(user1, 0)
+	s.Require().Equal([]uuid.UUID{kept.ID}, ids(full.Events), "events do not match")
+	s.Require().Equal(delta.Seq, full.Seq, "sequence numbers of the same changes do not match")
+
+	// Recreated event is reported as the changed one.
+	recreated := s.newEvent("Old", user1, -48*time.Hour)
+	recreated.ID = old.ID
+	s.create(recreated)
+	delta = s.changes(user1, afterDelete.Seq)
+	s.Require().Equal([]uuid.UUID{old.ID}, ids(delta.Events), "changed events do not match")
+	s.Require().Empty(delta.Deleted, "recreated event is reported as deleted")
+}
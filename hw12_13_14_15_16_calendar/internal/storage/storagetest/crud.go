@@ -0,0 +1,212 @@
+package storagetest
+
+import (
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// TestCreateEvent checks that the created event is stored with all of its fields.
+func (s *Suite) TestCreateEvent() {
+	event := s.newEvent("Create", user1, 0)
+	event.BusyStatus = types.BusyStatusTentative
+	s.Require().NoError(event.SetDetails([]string{"work", "planning"}, "#00ff00", "Office", "https://example.com"),
+		"failed to set event details")
+
+	created, err := s.storage.CreateEvent(s.ctx, event)
+	s.Require().NoError(err, "expected nil, got error")
+	s.requireEqualEvent(event, created)
+
+	stored, err := s.storage.GetEvent(s.ctx, event.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.requireEqualEvent(event, stored)
+
+	s.Run("duplicate ID", func() {
+		_, err := s.storage.CreateEvent(s.ctx, event)
+		s.Require().ErrorIs(err, projectErrors.ErrDataExists, "expected error does not match")
+	})
+	s.Run("no data", func() {
+		_, err := s.storage.CreateEvent(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "expected error does not match")
+	})
+}
+
+// TestCreateAllDayEvent checks that all-day events keep their date-only semantics.
+func (s *Suite) TestCreateAllDayEvent() {
+	event := s.newEvent("All day", user1, 0)
+	event.SetAllDay(true)
+	s.create(event)
+
+	stored, err := s.storage.GetEvent(s.ctx, event.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.requireEqualEvent(event, stored)
+}
+
+// TestGetEvent checks the lookup of the missing event.
+func (s *Suite) TestGetEvent() {
+	_, err := s.storage.GetEvent(s.ctx, uuid.New())
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+}
+
+// TestUpdateEvent checks the update of the event and the errors on invalid updates.
+func (s *Suite) TestUpdateEvent() {
+	event := s.create(s.newEvent("Update", user1, 0))
+
+	data := event.EventData
+	data.Title = "Updated"
+	data.Datetime = s.base.Add(30 * time.Minute)
+	data.Duration = 2 * time.Hour
+	data.RemindIn = time.Hour
+	data.Tags = []string{"updated"}
+
+	updated, err := s.storage.UpdateEvent(s.ctx, event.ID, &data)
+	s.Require().NoError(err, "expected nil, got error")
+	expected := &types.Event{ID: event.ID, EventData: data}
+	s.requireEqualEvent(expected, updated)
+
+	stored, err := s.storage.GetEvent(s.ctx, event.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.requireEqualEvent(expected, stored)
+
+	testCases := []struct {
+		name     string
+		id       uuid.UUID
+		data     func() *types.EventData
+		expected error
+	}{
+		{
+			name:     "no data",
+			id:       event.ID,
+			data:     func() *types.EventData { return nil },
+			expected: projectErrors.ErrNoData,
+		},
+		{
+			name:     "missing event",
+			id:       uuid.New(),
+			data:     func() *types.EventData { return &data },
+			expected: projectErrors.ErrEventNotFound,
+		},
+		{
+			name: "another user",
+			id:   event.ID,
+			data: func() *types.EventData {
+				other := data
+				other.UserID = user2
+				return &other
+			},
+			expected: projectErrors.ErrPermissionDenied,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			_, err := s.storage.UpdateEvent(s.ctx, tC.id, tC.data())
+			s.Require().ErrorIs(err, tC.expected, "expected error does not match")
+		})
+	}
+}
+
+// TestDeleteEvent checks the deletion of the existing and the missing events.
+func (s *Suite) TestDeleteEvent() {
+	event := s.create(s.newEvent("Delete", user1, 0))
+	kept := s.create(s.newEvent("Kept", user1, 2*time.Hour))
+
+	s.Require().NoError(s.storage.DeleteEvent(s.ctx, event.ID), "expected nil, got error")
+
+	_, err := s.storage.GetEvent(s.ctx, event.ID)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "deleted event is still available")
+
+	events, err := s.storage.GetAllUserEvents(s.ctx, user1)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{kept.ID}, ids(events), "user events do not match")
+
+	err = s.storage.DeleteEvent(s.ctx, event.ID)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+}
+
+// TestGetAllUserEvents checks that all events of the user are returned sorted by datetime and counted.
+func (s *Suite) TestGetAllUserEvents() {
+	late := s.create(s.newEvent("Late", user1, 48*time.Hour))
+	early := s.create(s.newEvent("Early", user1, -48*time.Hour))
+	s.create(s.newEvent("Other user", user2, 0))
+
+	events, err := s.storage.GetAllUserEvents(s.ctx, user1)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{early.ID, late.ID}, ids(events), "user events do not match")
+
+	_, err = s.storage.GetAllUserEvents(s.ctx, "unknown")
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+
+	count, err := s.storage.CountUserEvents(s.ctx, user1)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(int64(2), count, "user events count does not match")
+	count, err = s.storage.CountUserEvents(s.ctx, "unknown")
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Zero(count, "unknown user events count is not zero")
+}
+
+// TestOverlaps checks the overlap rules applied on the event creation and update.
+// Events are compared as [datetime, datetime + duration) intervals of the same user.
+func (s *Suite) TestOverlaps() {
+	existing := s.create(s.newEvent("Existing", user1, 0))
+
+	testCases := []struct {
+		name     string
+		userID   string
+		offset   time.Duration
+		status   types.BusyStatus
+		policy   types.OverlapPolicy
+		expected error
+	}{
+		{name: "overlapping start", userID: user1, offset: 30 * time.Minute, expected: projectErrors.ErrDateBusy},
+		{name: "overlapping end", userID: user1, offset: -30 * time.Minute, expected: projectErrors.ErrDateBusy},
+		{name: "same interval", userID: user1, expected: projectErrors.ErrDateBusy},
+		{name: "starts at the end", userID: user1, offset: duration},
+		{name: "ends at the start", userID: user1, offset: -duration},
+		{name: "another user", userID: user2, offset: 15 * time.Minute},
+		{name: "allow policy", userID: user1, offset: 10 * time.Minute, policy: types.OverlapPolicyAllow},
+		{
+			name: "allow if free, busy event", userID: user1, offset: 20 * time.Minute,
+			policy: types.OverlapPolicyAllowIfFree, expected: projectErrors.ErrDateBusy,
+		},
+		{
+			name: "allow if free, free event", userID: user1, offset: 20 * time.Minute,
+			status: types.BusyStatusFree, policy: types.OverlapPolicyAllowIfFree,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			event := s.newEvent(tC.name, tC.userID, tC.offset)
+			if tC.status != "" {
+				event.BusyStatus = tC.status
+			}
+			event.OverlapPolicy = tC.policy
+
+			_, err := s.storage.CreateEvent(s.ctx, event)
+			if tC.expected != nil {
+				s.Require().ErrorIs(err, tC.expected, "expected error does not match")
+				s.Require().ErrorContains(err, existing.ID.String(), "conflicting event ID is missing")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().NoError(s.storage.DeleteEvent(s.ctx, event.ID), "failed to clean up the event")
+		})
+	}
+
+	s.Run("update", func() {
+		other := s.create(s.newEvent("Other", user1, 2*time.Hour))
+
+		// The event does not overlap with itself.
+		data := other.EventData
+		data.Datetime = s.base.Add(90 * time.Minute)
+		_, err := s.storage.UpdateEvent(s.ctx, other.ID, &data)
+		s.Require().NoError(err, "expected nil, got error")
+
+		data.Datetime = s.base.Add(30 * time.Minute)
+		_, err = s.storage.UpdateEvent(s.ctx, other.ID, &data)
+		s.Require().ErrorIs(err, projectErrors.ErrDateBusy, "expected error does not match")
+	})
+}
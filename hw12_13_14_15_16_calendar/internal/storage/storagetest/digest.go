@@ -0,0 +1,66 @@
+package storagetest
+
+import (
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+)
+
+// TestDigestSettings checks the creation and the replacement of the digest settings,
+// keeping the date of the last sent digest.
+func (s *Suite) TestDigestSettings() {
+	_, err := s.storage.GetDigestSettings(s.ctx, user1)
+	s.Require().ErrorIs(err, projectErrors.ErrDigestSettingsNotFound, "expected error does not match")
+
+	settings := &types.DigestSettings{
+		UserID: user1, Enabled: true, Time: "08:00", TimeZone: "Europe/Moscow", SentDate: "2030-01-01",
+	}
+	stored, err := s.storage.SetDigestSettings(s.ctx, settings)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(stored.SentDate, "sent date is not set on creation")
+
+	s.Require().NoError(s.storage.UpdateDigestSent(s.ctx, user1, "2030-01-16"), "expected nil, got error")
+
+	settings.Time = "07:30"
+	settings.TimeZone = "UTC"
+	stored, err = s.storage.SetDigestSettings(s.ctx, settings)
+	s.Require().NoError(err, "expected nil, got error")
+	expected := &types.DigestSettings{
+		UserID: user1, Enabled: true, Time: "07:30", TimeZone: "UTC", SentDate: "2030-01-16",
+	}
+	s.Require().Equal(expected, stored, "sent date is kept on update")
+
+	got, err := s.storage.GetDigestSettings(s.ctx, user1)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(expected, got, "stored settings do not match")
+
+	s.Run("errors", func() {
+		_, err := s.storage.SetDigestSettings(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "expected error does not match")
+		err = s.storage.UpdateDigestSent(s.ctx, user2, "2030-01-16")
+		s.Require().ErrorIs(err, projectErrors.ErrDigestSettingsNotFound, "expected error does not match")
+	})
+}
+
+// TestDigestSubscriptions checks that only the users with the enabled digest are returned, sorted by user ID.
+func (s *Suite) TestDigestSubscriptions() {
+	subscriptions, err := s.storage.GetDigestSubscriptions(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(subscriptions, "expected no subscriptions")
+
+	for _, settings := range []*types.DigestSettings{
+		{UserID: user2, Enabled: true, Time: "09:00", TimeZone: "UTC"},
+		{UserID: "user3", Enabled: false, Time: "09:00", TimeZone: "UTC"},
+		{UserID: user1, Enabled: true, Time: "06:15", TimeZone: "Asia/Tokyo"},
+	} {
+		_, err := s.storage.SetDigestSettings(s.ctx, settings)
+		s.Require().NoError(err, "expected nil, got error")
+	}
+	s.Require().NoError(s.storage.UpdateDigestSent(s.ctx, user2, "2030-01-16"), "expected nil, got error")
+
+	subscriptions, err = s.storage.GetDigestSubscriptions(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.DigestSettings{
+		{UserID: user1, Enabled: true, Time: "06:15", TimeZone: "Asia/Tokyo"},
+		{UserID: user2, Enabled: true, Time: "09:00", TimeZone: "UTC", SentDate: "2030-01-16"},
+	}, subscriptions, "subscriptions do not match")
+}
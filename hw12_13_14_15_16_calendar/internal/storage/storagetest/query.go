@@ -0,0 +1,295 @@
+package storagetest
+
+import (
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// TestPeriodBoundaries checks that events intersecting the [start, end) period are returned:
+// an event ending at the period start or starting at the period end is not included.
+func (s *Suite) TestPeriodBoundaries() {
+	event := s.create(s.newEvent("Event", user1, 0))
+
+	testCases := []struct {
+		name    string
+		start   time.Duration // Offsets from the base time.
+		end     time.Duration
+		isFound bool
+	}{
+		{name: "period ends at the event start", start: -time.Hour, end: 0},
+		{name: "period starts at the event end", start: duration, end: 2 * duration},
+		{name: "event start inside", start: -time.Hour, end: time.Second, isFound: true},
+		{name: "event end inside", start: duration - time.Second, end: 2 * duration, isFound: true},
+		{name: "event covers the period", start: 10 * time.Minute, end: 20 * time.Minute, isFound: true},
+		{name: "period covers the event", start: -time.Hour, end: 2 * duration, isFound: true},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := s.storage.GetEventsForPeriod(s.ctx, s.base.Add(tC.start), s.base.Add(tC.end), nil, nil)
+			if !tC.isFound {
+				s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().Equal([]uuid.UUID{event.ID}, ids(events), "events do not match")
+		})
+	}
+}
+
+// TestAllDayPeriod checks that all-day events are matched by the calendar dates of the period.
+func (s *Suite) TestAllDayPeriod() {
+	event := s.newEvent("All day", user1, 0)
+	event.SetAllDay(true)
+	s.create(event)
+	date := event.Datetime
+
+	testCases := []struct {
+		name    string
+		start   time.Time
+		end     time.Time
+		isFound bool
+	}{
+		{name: "same date", start: date, end: date.AddDate(0, 0, 1), isFound: true},
+		{name: "part of the date", start: date.Add(20 * time.Hour), end: date.Add(21 * time.Hour), isFound: true},
+		{name: "previous date", start: date.AddDate(0, 0, -1), end: date},
+		{name: "next date", start: date.AddDate(0, 0, 1), end: date.AddDate(0, 0, 2)},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := s.storage.GetEventsForPeriod(s.ctx, tC.start, tC.end, nil, nil)
+			if !tC.isFound {
+				s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().Equal([]uuid.UUID{event.ID}, ids(events), "events do not match")
+		})
+	}
+}
+
+// TestPeriodFilters checks the ordering of the period events and their filtering by user, tags and location.
+func (s *Suite) TestPeriodFilters() {
+	late := s.newEvent("Late", user1, 3*time.Hour)
+	s.Require().NoError(late.SetDetails([]string{"work"}, "", "Home", ""), "failed to set event details")
+	early := s.newEvent("Early", user1, 0)
+	s.Require().NoError(early.SetDetails([]string{"work", "urgent"}, "", "Office", ""), "failed to set event details")
+	other := s.newEvent("Other user", user2, time.Hour)
+	s.Require().NoError(other.SetDetails([]string{"urgent"}, "", "office", ""), "failed to set event details")
+	for _, event := range []*types.Event{late, early, other} {
+		s.create(event)
+	}
+
+	userID, location := user1, "OFFICE"
+	start, end := s.base.Add(-time.Hour), s.base.Add(24*time.Hour)
+
+	testCases := []struct {
+		name     string
+		userID   *string
+		filter   *types.EventFilter
+		expected []uuid.UUID
+	}{
+		{name: "all events", expected: []uuid.UUID{early.ID, other.ID, late.ID}},
+		{name: "user events", userID: &userID, expected: []uuid.UUID{early.ID, late.ID}},
+		{
+			name:     "single tag",
+			filter:   types.NewEventFilter([]string{"urgent"}, nil),
+			expected: []uuid.UUID{early.ID, other.ID},
+		},
+		{
+			name:     "all of the tags",
+			filter:   types.NewEventFilter([]string{"urgent", "work"}, nil),
+			expected: []uuid.UUID{early.ID},
+		},
+		{
+			name:     "location",
+			filter:   types.NewEventFilter(nil, &location),
+			expected: []uuid.UUID{early.ID, other.ID},
+		},
+		{
+			name:     "user and location",
+			userID:   &userID,
+			filter:   types.NewEventFilter(nil, &location),
+			expected: []uuid.UUID{early.ID},
+		},
+		{name: "no matching events", filter: types.NewEventFilter([]string{"unknown"}, nil)},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := s.storage.GetEventsForPeriod(s.ctx, start, end, tC.userID, tC.filter)
+			if len(tC.expected) == 0 {
+				s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().Equal(tC.expected, ids(events), "events do not match")
+		})
+	}
+}
+
+// TestCalendarPeriods checks the day, week (starting on Monday) and month periods.
+func (s *Suite) TestCalendarPeriods() {
+	event := s.create(s.newEvent("Event", user1, 0))
+
+	testCases := []struct {
+		name    string
+		get     func(date time.Time) ([]*types.Event, error)
+		date    time.Time
+		isFound bool
+	}{
+		{name: "same day", get: s.forDay, date: s.base.Add(-9 * time.Hour), isFound: true},
+		{name: "next day", get: s.forDay, date: s.base.AddDate(0, 0, 1)},
+		{name: "same week", get: s.forWeek, date: s.base.AddDate(0, 0, -2), isFound: true},
+		{name: "next week", get: s.forWeek, date: s.base.AddDate(0, 0, 5)},
+		{name: "same month", get: s.forMonth, date: s.base.AddDate(0, 0, 14), isFound: true},
+		{name: "next month", get: s.forMonth, date: s.base.AddDate(0, 1, 0)},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			events, err := tC.get(tC.date)
+			if !tC.isFound {
+				s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().Equal([]uuid.UUID{event.ID}, ids(events), "events do not match")
+		})
+	}
+}
+
+func (s *Suite) forDay(date time.Time) ([]*types.Event, error) {
+	return s.storage.GetEventsForDay(s.ctx, date, nil, nil)
+}
+
+func (s *Suite) forWeek(date time.Time) ([]*types.Event, error) {
+	return s.storage.GetEventsForWeek(s.ctx, date, nil, nil)
+}
+
+func (s *Suite) forMonth(date time.Time) ([]*types.Event, error) {
+	return s.storage.GetEventsForMonth(s.ctx, date, nil, nil)
+}
+
+// TestNotifications checks the selection of the events waiting for notification and their marking.
+func (s *Suite) TestNotifications() {
+	now := time.Now().Truncate(time.Second)
+
+	_, err := s.storage.GetEventsForNotification(s.ctx)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+	_, err = s.storage.GetNextReminderTime(s.ctx)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+	s.requireDueCount(0)
+
+	// Reminder times: due - now-20m, overdue - now-50m, upcoming - now+1h, no reminder - none.
+	due := s.create(s.newEventAt("Due", user1, now.Add(10*time.Minute)))
+	overdue := s.create(s.newEventAt("Overdue", user2, now.Add(-20*time.Minute)))
+	upcoming := s.create(s.newEventAt("Upcoming", user1, now.Add(90*time.Minute)))
+	silent, err := types.NewEvent("No reminder", now.Add(-3*time.Hour), duration, description, user1, 0)
+	s.Require().NoError(err, "failed to construct event")
+	s.create(silent)
+
+	events, err := s.storage.GetEventsForNotification(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{overdue.ID, due.ID}, ids(events), "events for notification do not match")
+	s.requireDueCount(2)
+
+	next, err := s.storage.GetNextReminderTime(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().True(overdue.Datetime.Add(-remindIn).Equal(next), "next reminder time does not match")
+
+	s.Run("no data", func() {
+		_, err := s.storage.UpdateNotifiedEvents(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "expected error does not match")
+	})
+
+	// Missing IDs are ignored.
+	n, err := s.storage.UpdateNotifiedEvents(s.ctx, []uuid.UUID{overdue.ID, due.ID, uuid.New()})
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(int64(2), n, "notified events count does not match")
+
+	stored, err := s.storage.GetEvent(s.ctx, due.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().True(stored.IsNotified, "event is not marked as notified")
+
+	_, err = s.storage.GetEventsForNotification(s.ctx)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+	s.requireDueCount(0)
+
+	next, err = s.storage.GetNextReminderTime(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().True(upcoming.Datetime.Add(-remindIn).Equal(next), "next reminder time does not match")
+
+	// Notification flag is stored as given on update, so the event might be notified again.
+	data := stored.EventData
+	data.IsNotified = false
+	_, err = s.storage.UpdateEvent(s.ctx, due.ID, &data)
+	s.Require().NoError(err, "expected nil, got error")
+	events, err = s.storage.GetEventsForNotification(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{due.ID}, ids(events), "events for notification do not match")
+	s.requireDueCount(1)
+}
+
+// requireDueCount checks the number of the events waiting for notification.
+func (s *Suite) requireDueCount(expected int64) {
+	s.T().Helper()
+	count, err := s.storage.CountEventsForNotification(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(expected, count, "events for notification count does not match")
+}
+
+// TestReminderPeriod checks the selection of the events by the reminder time within [start, end),
+// regardless of the notification flag.
+func (s *Suite) TestReminderPeriod() {
+	start, end := s.base, s.base.Add(2*time.Hour)
+
+	_, err := s.storage.GetEventsForReminderPeriod(s.ctx, start, end)
+	s.Require().ErrorIs(err, projectErrors.ErrEventNotFound, "expected error does not match")
+
+	// Reminder times: first - start, notified - start+30m, last - end-1m, after - end, before - start-1m.
+	last := s.create(s.newEventAt("Last", user2, end.Add(remindIn-time.Minute)))
+	first := s.create(s.newEventAt("First", user1, start.Add(remindIn)))
+	notified := s.create(s.newEventAt("Notified", "user3", start.Add(2*remindIn)))
+	s.create(s.newEventAt("After", user1, end.Add(remindIn)))
+	s.create(s.newEventAt("Before", user2, start.Add(remindIn-time.Minute)))
+	silent, err := types.NewEvent("No reminder", start.Add(-2*time.Hour), duration, description, user1, 0)
+	s.Require().NoError(err, "failed to construct event")
+	s.create(silent)
+
+	_, err = s.storage.UpdateNotifiedEvents(s.ctx, []uuid.UUID{notified.ID})
+	s.Require().NoError(err, "expected nil, got error")
+
+	events, err := s.storage.GetEventsForReminderPeriod(s.ctx, start, end)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{first.ID, notified.ID, last.ID}, ids(events), "events for reminder period do not match")
+	s.Require().True(events[1].IsNotified, "notification flag is not returned")
+}
+
+// TestDeleteOldEvents checks that only the events starting strictly before the given date are deleted.
+func (s *Suite) TestDeleteOldEvents() {
+	n, err := s.storage.DeleteOldEvents(s.ctx, s.base)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Zero(n, "expected no deleted events")
+
+	s.create(s.newEvent("Old", user1, -48*time.Hour))
+	s.create(s.newEvent("Old other user", user2, -2*time.Hour))
+	boundary := s.create(s.newEvent("Boundary", user1, 0))
+	future := s.create(s.newEvent("Future", user2, 48*time.Hour))
+
+	n, err = s.storage.DeleteOldEvents(s.ctx, s.base)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(int64(2), n, "deleted events count does not match")
+
+	events, err := s.storage.GetEventsForPeriod(s.ctx, s.base.AddDate(0, 0, -7), s.base.AddDate(0, 0, 7), nil, nil)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{boundary.ID, future.ID}, ids(events), "remaining events do not match")
+
+	events, err = s.storage.GetAllUserEvents(s.ctx, user1)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{boundary.ID}, ids(events), "remaining user events do not match")
+}
@@ -0,0 +1,265 @@
+package storagetest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// newResource constructs and saves a new bookable resource.
+func (s *Suite) newResource(name string, kind types.ResourceKind, capacity int, attributes ...string) *types.Resource {
+	resource, err := types.NewResource(name, string(kind), capacity, attributes)
+	s.Require().NoError(err, "failed to construct resource")
+	resource.CreatedAt = s.base
+	stored, err := s.storage.CreateResource(s.ctx, resource)
+	s.Require().NoError(err, "failed to create resource %q", name)
+	return stored
+}
+
+// newBooking constructs a new event of the user, booking the given resources.
+func (s *Suite) newBooking(title, userID string, offset time.Duration, resources ...*types.Resource) *types.Event {
+	event := s.newEvent(title, userID, offset)
+	for _, resource := range resources {
+		event.Resources = append(event.Resources, resource.ID.String())
+	}
+	return event
+}
+
+// TestResources checks the creation, the retrieval and the deletion of the bookable resources.
+func (s *Suite) TestResources() {
+	resources, err := s.storage.GetResources(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(resources, "expected no resources")
+
+	room := s.newResource("Room 101", types.ResourceKindRoom, 8, "projector", "whiteboard")
+	projector := s.newResource("Projector", types.ResourceKindEquipment, 0)
+
+	got, err := s.storage.GetResource(s.ctx, room.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(room, got, "stored resource does not match")
+
+	resources, err = s.storage.GetResources(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.Resource{projector, room}, resources, "resources are not sorted by name")
+
+	s.Require().NoError(s.storage.DeleteResource(s.ctx, room.ID, s.base), "expected nil, got error")
+	_, err = s.storage.GetResource(s.ctx, room.ID)
+	s.Require().ErrorIs(err, projectErrors.ErrResourceNotFound, "expected error does not match")
+
+	s.Run("errors", func() {
+		_, err := s.storage.CreateResource(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "expected error does not match")
+		_, err = s.storage.CreateResource(s.ctx, projector)
+		s.Require().ErrorIs(err, projectErrors.ErrDataExists, "expected error does not match")
+		err = s.storage.DeleteResource(s.ctx, room.ID, s.base)
+		s.Require().ErrorIs(err, projectErrors.ErrResourceNotFound, "expected error does not match")
+	})
+
+	s.Run("booked", func() {
+		booking := s.create(s.newBooking("Booking", user1, time.Hour, projector))
+		end := booking.Datetime.Add(booking.Duration)
+
+		err := s.storage.DeleteResource(s.ctx, projector.ID, end.Add(-time.Minute))
+		s.Require().ErrorIs(err, projectErrors.ErrResourceInUse, "expected error does not match")
+		s.Require().ErrorContains(err, booking.ID.String(), "booking is not reported")
+		_, err = s.storage.GetResource(s.ctx, projector.ID)
+		s.Require().NoError(err, "booked resource is deleted")
+
+		// Past bookings do not prevent the deletion.
+		s.Require().NoError(s.storage.DeleteResource(s.ctx, projector.ID, end), "expected nil, got error")
+	})
+}
+
+// TestResourceOverlaps checks that the bookings of the same resource never overlap,
+// regardless of the users, the busy statuses and the overlap policies of the events.
+func (s *Suite) TestResourceOverlaps() {
+	room := s.newResource("Room", types.ResourceKindRoom, 4)
+	projector := s.newResource("Projector", types.ResourceKindEquipment, 0)
+	existing := s.create(s.newBooking("Existing", user1, 0, room))
+
+	got, err := s.storage.GetEvent(s.ctx, existing.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.requireEqualEvent(existing, got)
+
+	testCases := []struct {
+		name      string
+		userID    string
+		offset    time.Duration
+		resources []*types.Resource
+		status    types.BusyStatus
+		policy    types.OverlapPolicy
+		expected  error
+	}{
+		{
+			name: "another user", userID: user2, offset: 30 * time.Minute,
+			resources: []*types.Resource{room}, expected: projectErrors.ErrDateBusy,
+		},
+		{
+			name: "allow policy, free event", userID: user2, offset: 30 * time.Minute,
+			resources: []*types.Resource{projector, room}, status: types.BusyStatusFree,
+			policy: types.OverlapPolicyAllow, expected: projectErrors.ErrDateBusy,
+		},
+		{name: "starts at the end", userID: user2, offset: duration, resources: []*types.Resource{room}},
+		{name: "another resource", userID: user2, offset: 30 * time.Minute, resources: []*types.Resource{projector}},
+		{name: "no resources", userID: user2, offset: 30 * time.Minute},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			event := s.newBooking(tC.name, tC.userID, tC.offset, tC.resources...)
+			if tC.status != "" {
+				event.BusyStatus = tC.status
+			}
+			event.OverlapPolicy = tC.policy
+
+			_, err := s.storage.CreateEvent(s.ctx, event)
+			if tC.expected != nil {
+				s.Require().ErrorIs(err, tC.expected, "expected error does not match")
+				s.Require().ErrorContains(err, existing.ID.String(), "conflicting event ID is missing")
+				return
+			}
+			s.Require().NoError(err, "expected nil, got error")
+			s.Require().NoError(s.storage.DeleteEvent(s.ctx, event.ID), "failed to clean up the event")
+		})
+	}
+
+	s.Run("unknown resource", func() {
+		event := s.newEvent("Unknown", user2, 3*time.Hour)
+		event.Resources = []string{uuid.New().String()}
+		_, err := s.storage.CreateEvent(s.ctx, event)
+		s.Require().ErrorIs(err, projectErrors.ErrResourceNotFound, "expected error does not match")
+	})
+
+	s.Run("update", func() {
+		other := s.create(s.newBooking("Other", user2, 2*time.Hour, room))
+
+		// The booking does not overlap with itself.
+		data := other.EventData
+		data.Datetime = s.base.Add(90 * time.Minute)
+		_, err := s.storage.UpdateEvent(s.ctx, other.ID, &data)
+		s.Require().NoError(err, "expected nil, got error")
+
+		data.Datetime = s.base.Add(30 * time.Minute)
+		_, err = s.storage.UpdateEvent(s.ctx, other.ID, &data)
+		s.Require().ErrorIs(err, projectErrors.ErrDateBusy, "expected error does not match")
+
+		// Releasing the resource makes the interval available.
+		data.Resources = nil
+		_, err = s.storage.UpdateEvent(s.ctx, other.ID, &data)
+		s.Require().NoError(err, "expected nil, got error")
+	})
+
+	s.Run("deleted resource", func() {
+		end := existing.Datetime.Add(existing.Duration)
+		s.Require().NoError(s.storage.DeleteResource(s.ctx, room.ID, end), "expected nil, got error")
+
+		// Existing bookings of the deleted resource do not prevent the update.
+		data := existing.EventData
+		data.Title = "Updated"
+		_, err := s.storage.UpdateEvent(s.ctx, existing.ID, &data)
+		s.Require().NoError(err, "expected nil, got error")
+
+		// New bookings of the deleted resource are rejected.
+		event := s.newBooking("Deleted", user2, 3*time.Hour, room)
+		_, err = s.storage.CreateEvent(s.ctx, event)
+		s.Require().ErrorIs(err, projectErrors.ErrResourceNotFound, "expected error does not match")
+	})
+}
+
+// TestConcurrentBookings checks that only one of the concurrent overlapping bookings of the same resource
+// by different users succeeds.
+func (s *Suite) TestConcurrentBookings() {
+	const bookings = 8
+	room := s.newResource("Room", types.ResourceKindRoom, 4)
+
+	var wg sync.WaitGroup
+	errs := make([]error, bookings)
+	for i := range bookings {
+		event := s.newBooking("Booking", fmt.Sprintf("user-%d", i), time.Duration(i)*time.Minute, room)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = s.storage.CreateEvent(s.ctx, event)
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		s.Require().ErrorIs(err, projectErrors.ErrDateBusy, "expected error does not match")
+	}
+	s.Require().Equal(1, created, "unexpected number of the created bookings")
+
+	events, err := s.storage.GetResourceEvents(s.ctx, room.ID, s.base, s.base.Add(24*time.Hour))
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Len(events, 1, "resource is double-booked")
+}
+
+// TestConcurrentResourceDeletion checks that the resource is either booked or deleted,
+// when the booking and the deletion are concurrent.
+func (s *Suite) TestConcurrentResourceDeletion() {
+	const attempts = 8
+	for i := range attempts {
+		room := s.newResource(fmt.Sprintf("Room %d", i), types.ResourceKindRoom, 4)
+		event := s.newBooking("Booking", user1, time.Duration(i)*time.Hour, room)
+
+		var wg sync.WaitGroup
+		var createErr, deleteErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, createErr = s.storage.CreateEvent(s.ctx, event)
+		}()
+		go func() {
+			defer wg.Done()
+			deleteErr = s.storage.DeleteResource(s.ctx, room.ID, s.base)
+		}()
+		wg.Wait()
+
+		if createErr == nil {
+			s.Require().ErrorIs(deleteErr, projectErrors.ErrResourceInUse, "booked resource is deleted")
+			continue
+		}
+		s.Require().ErrorIs(createErr, projectErrors.ErrResourceNotFound, "expected error does not match")
+		s.Require().NoError(deleteErr, "expected nil, got error")
+	}
+}
+
+// TestResourceEvents checks that the bookings of the resource are returned by intersection with the period,
+// sorted by the datetime, and follow the event updates and deletions.
+func (s *Suite) TestResourceEvents() {
+	room := s.newResource("Room", types.ResourceKindRoom, 4)
+	projector := s.newResource("Projector", types.ResourceKindEquipment, 0)
+
+	late := s.create(s.newBooking("Late", user2, 3*time.Hour, room, projector))
+	early := s.create(s.newBooking("Early", user1, 0, room))
+	s.create(s.newBooking("Outside", user1, 6*time.Hour, room))
+	s.create(s.newEvent("Not booked", user1, time.Hour))
+
+	events, err := s.storage.GetResourceEvents(s.ctx, room.ID, s.base.Add(30*time.Minute), s.base.Add(5*time.Hour))
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]uuid.UUID{early.ID, late.ID}, ids(events), "bookings do not match")
+	s.requireEqualEvent(late, events[1])
+
+	events, err = s.storage.GetResourceEvents(s.ctx, projector.ID, s.base, s.base.Add(3*time.Hour))
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(events, "booking ending at the period start is not expected")
+
+	data := late.EventData
+	data.Resources = []string{projector.ID.String()}
+	_, err = s.storage.UpdateEvent(s.ctx, late.ID, &data)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().NoError(s.storage.DeleteEvent(s.ctx, early.ID), "expected nil, got error")
+
+	events, err = s.storage.GetResourceEvents(s.ctx, room.ID, s.base, s.base.Add(5*time.Hour))
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(events, "released bookings are not expected")
+}
@@ -0,0 +1,117 @@
+// Package storagetest provides the conformance test suite for storage.Storage implementations.
+//
+// The suite defines the behavior shared by all backends: CRUD errors, overlap rules,
+// [start, end) period boundaries, notification marking and cleanup of the old events.
+// A backend runs it from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.Storage {
+//			s, _ := memory.NewStorage(0)
+//			return s
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"   //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                  //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/suite"                                       //nolint:depguard,nolintlint
+)
+
+// Common test data.
+const (
+	user1       = "user1"
+	user2       = "user2"
+	description = "Description"
+	duration    = time.Hour
+	remindIn    = 30 * time.Minute
+)
+
+// Factory creates a new empty storage for a single test. The storage is connected by the suite
+// and closed once the test is finished.
+type Factory func(t *testing.T) storage.Storage
+
+// Suite is the conformance test suite. Use Run to execute it.
+type Suite struct {
+	suite.Suite
+	newStorage Factory
+	storage    storage.Storage
+	ctx        context.Context
+	base       time.Time // Start of the regular test events, Wednesday.
+}
+
+// Run runs the conformance suite against the storages created by the factory.
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+	suite.Run(t, &Suite{newStorage: factory})
+}
+
+// SetupTest creates and connects a new storage for the test.
+func (s *Suite) SetupTest() {
+	s.ctx = context.Background()
+	s.base = time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC)
+
+	s.storage = s.newStorage(s.T())
+	s.Require().NotNil(s.storage, "factory returned nil storage")
+	s.Require().NoError(s.storage.Connect(s.ctx), "failed to connect to storage")
+}
+
+// TearDownTest closes the storage of the test.
+func (s *Suite) TearDownTest() {
+	s.storage.Close(s.ctx)
+}
+
+// newEvent constructs a new event of the user, starting after the given offset from the base time.
+func (s *Suite) newEvent(title, userID string, offset time.Duration) *types.Event {
+	return s.newEventAt(title, userID, s.base.Add(offset))
+}
+
+// newEventAt constructs a new event of the user, starting at the given time.
+func (s *Suite) newEventAt(title, userID string, datetime time.Time) *types.Event {
+	event, err := types.NewEvent(title, datetime, duration, description, userID, remindIn)
+	s.Require().NoError(err, "failed to construct event")
+	return event
+}
+
+// create saves the event in the storage.
+func (s *Suite) create(event *types.Event) *types.Event {
+	_, err := s.storage.CreateEvent(s.ctx, event)
+	s.Require().NoError(err, "failed to create event %q", event.Title)
+	return event
+}
+
+// ids returns the IDs of the given events.
+func ids(events []*types.Event) []uuid.UUID {
+	res := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		res = append(res, event.ID)
+	}
+	return res
+}
+
+// requireEqualEvent checks that the stored event matches the expected one.
+// Time values are compared as instants, since the backends might return them in different locations.
+func (s *Suite) requireEqualEvent(expected, actual *types.Event) {
+	s.Require().NotNil(actual, "expected non-nil event")
+	s.Require().Equal(expected.ID, actual.ID, "ID does not match")
+	s.Require().Equal(expected.Title, actual.Title, "title does not match")
+	s.Require().True(expected.Datetime.Equal(actual.Datetime),
+		"datetime does not match: expected %v, got %v", expected.Datetime, actual.Datetime)
+	s.Require().Equal(expected.Duration, actual.Duration, "duration does not match")
+	s.Require().Equal(expected.Description, actual.Description, "description does not match")
+	s.Require().Equal(expected.UserID, actual.UserID, "user ID does not match")
+	s.Require().Equal(expected.RemindIn, actual.RemindIn, "remind_in does not match")
+	s.Require().Equal(expected.IsNotified, actual.IsNotified, "is_notified does not match")
+	s.Require().Equal(expected.BusyStatus, actual.BusyStatus, "busy status does not match")
+	s.Require().Equal(expected.AllDay, actual.AllDay, "all-day flag does not match")
+	s.Require().ElementsMatch(expected.Tags, actual.Tags, "tags do not match")
+	s.Require().Equal(expected.Color, actual.Color, "color does not match")
+	s.Require().Equal(expected.Location, actual.Location, "location does not match")
+	s.Require().Equal(expected.URL, actual.URL, "URL does not match")
+	s.Require().ElementsMatch(expected.Resources, actual.Resources, "resources do not match")
+}
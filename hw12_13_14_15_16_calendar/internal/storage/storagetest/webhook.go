@@ -0,0 +1,149 @@
+package storagetest
+
+import (
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// newWebhook constructs a new webhook subscription of the user, created at the given offset from the base time.
+func (s *Suite) newWebhook(userID string, offset time.Duration, eventTypes ...string) *types.Webhook {
+	webhook, err := types.NewWebhook(userID, "http://localhost:8080/hook", "", eventTypes)
+	s.Require().NoError(err, "failed to construct webhook")
+	webhook.CreatedAt = s.base.Add(offset)
+	return webhook
+}
+
+// TestWebhooks checks the creation, the state updates and the deletion of the webhook subscriptions.
+func (s *Suite) TestWebhooks() {
+	webhooks, err := s.storage.GetWebhooks(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(webhooks, "expected no webhooks")
+
+	second := s.newWebhook("", time.Hour)
+	first := s.newWebhook(user1, 0, "created", "reminder")
+	for _, webhook := range []*types.Webhook{second, first} {
+		stored, err := s.storage.CreateWebhook(s.ctx, webhook)
+		s.Require().NoError(err, "expected nil, got error")
+		s.Require().Equal(webhook, stored, "stored webhook does not match")
+	}
+
+	got, err := s.storage.GetWebhook(s.ctx, first.ID)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal(first, got, "stored webhook does not match")
+
+	s.Require().NoError(s.storage.UpdateWebhookState(s.ctx, first.ID, false, 3), "expected nil, got error")
+	first.Enabled = false
+	first.Failures = 3
+
+	webhooks, err = s.storage.GetWebhooks(s.ctx)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.Webhook{first, second}, webhooks, "webhooks are not sorted by creation time")
+
+	s.Require().NoError(s.storage.DeleteWebhook(s.ctx, first.ID), "expected nil, got error")
+	_, err = s.storage.GetWebhook(s.ctx, first.ID)
+	s.Require().ErrorIs(err, projectErrors.ErrWebhookNotFound, "expected error does not match")
+
+	s.Run("errors", func() {
+		_, err := s.storage.CreateWebhook(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "expected error does not match")
+		err = s.storage.DeleteWebhook(s.ctx, first.ID)
+		s.Require().ErrorIs(err, projectErrors.ErrWebhookNotFound, "expected error does not match")
+		err = s.storage.UpdateWebhookState(s.ctx, uuid.New(), true, 0)
+		s.Require().ErrorIs(err, projectErrors.ErrWebhookNotFound, "expected error does not match")
+		err = s.storage.AddWebhookDelivery(s.ctx, nil)
+		s.Require().ErrorIs(err, projectErrors.ErrNoData, "expected error does not match")
+	})
+}
+
+// TestWebhookDeliveries checks that the delivery log is returned newest first, limited
+// and deleted along with the subscription.
+func (s *Suite) TestWebhookDeliveries() {
+	webhook, other := s.newWebhook(user1, 0), s.newWebhook(user2, 0)
+	for _, w := range []*types.Webhook{webhook, other} {
+		_, err := s.storage.CreateWebhook(s.ctx, w)
+		s.Require().NoError(err, "expected nil, got error")
+	}
+
+	eventID, deliveryID := uuid.New(), uuid.New()
+	deliveries := make([]*types.WebhookDelivery, 0, 3)
+	for attempt := 1; attempt <= 3; attempt++ {
+		delivery := &types.WebhookDelivery{
+			DeliveryID: deliveryID,
+			Attempt:    attempt,
+			WebhookID:  webhook.ID,
+			EventType:  "created",
+			EventID:    eventID,
+			StatusCode: 500,
+			Error:      "unexpected status code",
+			Success:    attempt == 3,
+			CreatedAt:  s.base.Add(time.Duration(attempt) * time.Second),
+		}
+		if delivery.Success {
+			delivery.StatusCode = 200
+			delivery.Error = ""
+		}
+		s.Require().NoError(s.storage.AddWebhookDelivery(s.ctx, delivery), "expected nil, got error")
+		deliveries = append(deliveries, delivery)
+	}
+	otherDelivery := &types.WebhookDelivery{
+		DeliveryID: uuid.New(), Attempt: 1, WebhookID: other.ID, EventType: "deleted",
+		EventID: eventID, StatusCode: 204, Success: true, CreatedAt: s.base,
+	}
+	s.Require().NoError(s.storage.AddWebhookDelivery(s.ctx, otherDelivery), "expected nil, got error")
+
+	got, err := s.storage.GetWebhookDeliveries(s.ctx, webhook.ID, 0)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.WebhookDelivery{deliveries[2], deliveries[1], deliveries[0]}, got,
+		"deliveries are not sorted newest first")
+
+	got, err = s.storage.GetWebhookDeliveries(s.ctx, webhook.ID, 2)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.WebhookDelivery{deliveries[2], deliveries[1]}, got, "limit is not applied")
+
+	s.Require().NoError(s.storage.DeleteWebhook(s.ctx, webhook.ID), "expected nil, got error")
+	got, err = s.storage.GetWebhookDeliveries(s.ctx, webhook.ID, 0)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Empty(got, "delivery log is not deleted along with the webhook")
+
+	got, err = s.storage.GetWebhookDeliveries(s.ctx, other.ID, 0)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.WebhookDelivery{otherDelivery}, got, "delivery log of the other webhook is affected")
+}
+
+// TestWebhookDeliveriesRetention checks that only the latest delivery attempts of the subscription are kept.
+func (s *Suite) TestWebhookDeliveriesRetention() {
+	webhook, other := s.newWebhook(user1, 0), s.newWebhook(user2, 0)
+	for _, w := range []*types.Webhook{webhook, other} {
+		_, err := s.storage.CreateWebhook(s.ctx, w)
+		s.Require().NoError(err, "expected nil, got error")
+	}
+	otherDelivery := &types.WebhookDelivery{
+		DeliveryID: uuid.New(), Attempt: 1, WebhookID: other.ID, EventType: "deleted",
+		EventID: uuid.New(), StatusCode: 204, Success: true, CreatedAt: s.base,
+	}
+	s.Require().NoError(s.storage.AddWebhookDelivery(s.ctx, otherDelivery), "expected nil, got error")
+
+	const extra = 5
+	deliveries := make([]*types.WebhookDelivery, 0, types.MaxWebhookDeliveries+extra)
+	for i := range types.MaxWebhookDeliveries + extra {
+		delivery := &types.WebhookDelivery{
+			DeliveryID: uuid.New(), Attempt: 1, WebhookID: webhook.ID, EventType: "created",
+			EventID: uuid.New(), StatusCode: 200, Success: true, CreatedAt: s.base.Add(time.Duration(i) * time.Second),
+		}
+		s.Require().NoError(s.storage.AddWebhookDelivery(s.ctx, delivery), "expected nil, got error")
+		deliveries = append(deliveries, delivery)
+	}
+
+	got, err := s.storage.GetWebhookDeliveries(s.ctx, webhook.ID, 0)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Len(got, types.MaxWebhookDeliveries, "delivery log is not trimmed")
+	s.Require().Equal(deliveries[len(deliveries)-1], got[0], "newest delivery is not kept")
+	s.Require().Equal(deliveries[extra], got[len(got)-1], "unexpected oldest kept delivery")
+
+	got, err = s.storage.GetWebhookDeliveries(s.ctx, other.ID, 0)
+	s.Require().NoError(err, "expected nil, got error")
+	s.Require().Equal([]*types.WebhookDelivery{otherDelivery}, got, "delivery log of the other webhook is affected")
+}
@@ -5,8 +5,10 @@ import (
 	"time"
 
 	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors"                       //nolint:depguard,nolintlint
+	boltstorage "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/bolt"     //nolint:depguard,nolintlint
 	memorystorage "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory" //nolint:depguard,nolintlint
 	sqlstorage "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/sql"       //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                            //nolint:depguard,nolintlint
 )
 
 // newMemoryStorage creates a new memory storage instance.
@@ -15,19 +17,38 @@ import (
 func newMemoryStorage(args map[string]any) (Storage, error) {
 	memArgs, ok := args["memory"].(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("%w: no storage configuration received", errors.ErrCorruptedConfig)
+		return nil, fmt.Errorf("%w: no storage configuration received: %w", errors.ErrCorruptedConfig,
+			&config.ValidationError{Missing: []string{"memory"}})
 	}
 
 	missing, wrongType := validateMemoryConfig(memArgs)
 	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("%w: missing=%v invalid_type=%v",
-			errors.ErrCorruptedConfig, missing, wrongType)
+		return nil, fmt.Errorf("%w: %w", errors.ErrCorruptedConfig, &config.ValidationError{
+			Missing:     prefixKeys("memory", missing),
+			InvalidType: prefixKeys("memory", wrongType),
+		})
 	}
 
 	size, _ := memArgs["size"].(int)
 	size = max(0, size) // Ensure size is not negative.
 
-	return memorystorage.NewStorage(size)
+	// Optional fields are zero valued if missing. Empty WAL directory disables the persistence.
+	walDir, _ := memArgs["wal_dir"].(string)
+	if walDir == "" {
+		return memorystorage.NewStorage(size)
+	}
+	fsync, _ := memArgs["fsync"].(string)
+	fsyncInterval, _ := memArgs["fsync_interval"].(time.Duration)
+	snapshotInterval, _ := memArgs["snapshot_interval"].(time.Duration)
+	snapshotThreshold, _ := memArgs["snapshot_threshold"].(int)
+
+	return memorystorage.NewStorage(size, memorystorage.WithPersistence(memorystorage.PersistenceConfig{
+		Dir:               walDir,
+		Fsync:             memorystorage.FsyncPolicy(fsync),
+		FsyncInterval:     fsyncInterval,
+		SnapshotInterval:  snapshotInterval,
+		SnapshotThreshold: snapshotThreshold,
+	}))
 }
 
 // newSQLStorage creates a new sql storage instance.
@@ -36,13 +57,16 @@ func newMemoryStorage(args map[string]any) (Storage, error) {
 func newSQLStorage(args map[string]any) (Storage, error) {
 	sqlArgs, ok := args["sql"].(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("%w: no storage configuration received", errors.ErrCorruptedConfig)
+		return nil, fmt.Errorf("%w: no storage configuration received: %w", errors.ErrCorruptedConfig,
+			&config.ValidationError{Missing: []string{"sql"}})
 	}
 
 	missing, wrongType := validateSQLConfig(sqlArgs)
 	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("%w: missing=%v invalid_type=%v",
-			errors.ErrCorruptedConfig, missing, wrongType)
+		return nil, fmt.Errorf("%w: %w", errors.ErrCorruptedConfig, &config.ValidationError{
+			Missing:     prefixKeys("sql", missing),
+			InvalidType: prefixKeys("sql", wrongType),
+		})
 	}
 
 	callArgs := map[string]string{
@@ -57,6 +81,17 @@ func newSQLStorage(args map[string]any) (Storage, error) {
 	timeout, _ := sqlArgs["timeout"].(time.Duration)
 	timeout = max(0, timeout)
 
+	// Optional fields are zero valued if missing.
+	autoMigrate, _ := sqlArgs["auto_migrate"].(bool)
+	replicas, _ := sqlArgs["replicas"].([]string)
+	sslMode, _ := sqlArgs["sslmode"].(string)
+	sslRootCert, _ := sqlArgs["sslrootcert"].(string)
+	sslCert, _ := sqlArgs["sslcert"].(string)
+	sslKey, _ := sqlArgs["sslkey"].(string)
+	maxOpenConns, _ := sqlArgs["max_open_conns"].(int)
+	maxIdleConns, _ := sqlArgs["max_idle_conns"].(int)
+	connMaxLifetime, _ := sqlArgs["conn_max_lifetime"].(time.Duration)
+
 	return sqlstorage.NewStorage(
 		timeout,
 		callArgs["driver"],
@@ -65,5 +100,47 @@ func newSQLStorage(args map[string]any) (Storage, error) {
 		callArgs["user"],
 		callArgs["password"],
 		callArgs["dbname"],
+		sqlstorage.WithAutoMigrate(autoMigrate),
+		sqlstorage.WithReplicas(replicas...),
+		sqlstorage.WithTLS(sslMode, sslRootCert, sslCert, sslKey),
+		sqlstorage.WithPool(sqlstorage.PoolConfig{
+			MaxOpenConns:    max(0, maxOpenConns),
+			MaxIdleConns:    max(0, maxIdleConns),
+			ConnMaxLifetime: max(0, connMaxLifetime),
+		}),
 	)
 }
+
+// newBoltStorage creates a new bolt storage instance.
+// args is a map[string]any containing the configuration for the storage. All args are parsed and validated.
+// Returns (nil, nil) or (*Storage, nil) if no errors occurred, (nil, error) otherwise.
+func newBoltStorage(args map[string]any) (Storage, error) {
+	boltArgs, ok := args["bolt"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: no storage configuration received: %w", errors.ErrCorruptedConfig,
+			&config.ValidationError{Missing: []string{"bolt"}})
+	}
+
+	missing, wrongType := validateBoltConfig(boltArgs)
+	if len(missing) > 0 || len(wrongType) > 0 {
+		return nil, fmt.Errorf("%w: %w", errors.ErrCorruptedConfig, &config.ValidationError{
+			Missing:     prefixKeys("bolt", missing),
+			InvalidType: prefixKeys("bolt", wrongType),
+		})
+	}
+
+	path, _ := boltArgs["path"].(string)
+	timeout, _ := boltArgs["timeout"].(time.Duration)
+	timeout = max(0, timeout)
+
+	return boltstorage.NewStorage(path, timeout)
+}
+
+// prefixKeys returns the keys of the nested config section with the section prefix, e.g. "sql.host".
+func prefixKeys(section string, keys []string) []string {
+	res := make([]string, len(keys))
+	for i, key := range keys {
+		res[i] = section + "." + key
+	}
+	return res
+}
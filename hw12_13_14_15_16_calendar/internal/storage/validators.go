@@ -31,7 +31,22 @@ func validateFields(args map[string]any, requiredFields map[string]any) ([]strin
 	return missing, wrongType
 }
 
+// validateOptionalFields returns wrong type fields found in args.
+// optionalFields is a map of field names with their expected types. Missing fields are skipped.
+func validateOptionalFields(args map[string]any, optionalFields map[string]any) []string {
+	present := make(map[string]any, len(optionalFields))
+	for field, expectedVal := range optionalFields {
+		if _, exists := args[field]; exists {
+			present[field] = expectedVal
+		}
+	}
+
+	_, wrongType := validateFields(args, present)
+	return wrongType
+}
+
 // validateSQLConfig returns missing and wrong type fields of sql config found in args.
+// Optional fields are checked only for their type.
 func validateSQLConfig(args map[string]any) ([]string, []string) {
 	required := map[string]any{
 		"driver":   "",
@@ -42,15 +57,46 @@ func validateSQLConfig(args map[string]any) ([]string, []string) {
 		"dbname":   "",
 		"timeout":  time.Duration(0),
 	}
+	optional := map[string]any{
+		"auto_migrate":      false,
+		"replicas":          []string(nil),
+		"sslmode":           "",
+		"sslrootcert":       "",
+		"sslcert":           "",
+		"sslkey":            "",
+		"max_open_conns":    int(0),
+		"max_idle_conns":    int(0),
+		"conn_max_lifetime": time.Duration(0),
+	}
 
-	return validateFields(args, required)
+	missing, wrongType := validateFields(args, required)
+	return missing, append(wrongType, validateOptionalFields(args, optional)...)
 }
 
-// validateMemoryConfig returns missing and wrong type fields memory config found in args.
+// validateMemoryConfig returns missing and wrong type fields of memory config found in args.
+// Optional fields are checked only for their type.
 func validateMemoryConfig(args map[string]any) ([]string, []string) {
 	required := map[string]any{
 		"size": int(0),
 	}
+	optional := map[string]any{
+		"wal_dir":            "",
+		"fsync":              "",
+		"fsync_interval":     time.Duration(0),
+		"snapshot_interval":  time.Duration(0),
+		"snapshot_threshold": int(0),
+	}
+
+	missing, wrongType := validateFields(args, required)
+	return missing, append(wrongType, validateOptionalFields(args, optional)...)
+}
+
+// validateBoltConfig returns missing and wrong type fields of bolt config found in args.
+func validateBoltConfig(args map[string]any) ([]string, []string) {
+	required := map[string]any{
+		"path":    "",
+		"timeout": time.Duration(0),
+	}
 
 	return validateFields(args, required)
 }
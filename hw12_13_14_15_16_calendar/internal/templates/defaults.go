@@ -0,0 +1,4 @@
+package templates
+
+// expectedFields are the required configuration fields. All of them are strings.
+var expectedFields = []string{"source", "dir", "default_locale", "default_time_zone"}
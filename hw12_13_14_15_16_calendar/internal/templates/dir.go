@@ -0,0 +1,127 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+)
+
+// usersFile is the name of the file with the user settings in the templates directory.
+const usersFile = "users.json"
+
+// Template file extensions and the corresponding formats.
+var formatsByExt = map[string]types.TemplateFormat{
+	".txt":  types.TemplateText,
+	".tmpl": types.TemplateText,
+	".html": types.TemplateHTML,
+}
+
+// DirStore is a Store keeping the templates on disk.
+//
+// Templates are located in the directories named after the channels, each file is named after the locale:
+// <dir>/<channel>/<locale>.txt or .tmpl for the text templates and <dir>/<channel>/<locale>.html for HTML ones.
+// Event reminder templates are placed in the channel directory itself, daily digest ones - in its "digest"
+// subdirectory, e.g. <dir>/email/digest/en.html.
+// User settings are kept in <dir>/users.json as an object with user IDs as keys, e.g.
+// {"user": {"locale": "ru", "time_zone": "Europe/Moscow"}}. The file is optional.
+//
+// Files are read on each call, so the changes are applied on the next templates reload.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore creates a new store reading the templates from the given directory.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// GetNotificationTemplates reads all templates from the directory.
+// Files with unknown extensions and unknown subdirectories are ignored.
+func (s *DirStore) GetNotificationTemplates(_ context.Context) ([]*types.NotificationTemplate, error) {
+	channels, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read templates directory: %w", err)
+	}
+
+	res := make([]*types.NotificationTemplate, 0)
+	for _, channel := range channels {
+		if !channel.IsDir() {
+			continue
+		}
+		reminders, err := readTemplates(s.dir, channel.Name(), types.TemplateReminder, channel.Name())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, reminders...)
+
+		digestDir := filepath.Join(channel.Name(), string(types.TemplateDigest))
+		if info, err := os.Stat(filepath.Join(s.dir, digestDir)); err != nil || !info.IsDir() {
+			continue
+		}
+		digests, err := readTemplates(s.dir, digestDir, types.TemplateDigest, channel.Name())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, digests...)
+	}
+	return res, nil
+}
+
+// readTemplates reads the templates of the kind from the subdirectory of the templates directory.
+func readTemplates(dir, subdir string, kind types.TemplateKind, channel string) ([]*types.NotificationTemplate, error) {
+	files, err := os.ReadDir(filepath.Join(dir, subdir))
+	if err != nil {
+		return nil, fmt.Errorf("read %s directory: %w", subdir, err)
+	}
+
+	res := make([]*types.NotificationTemplate, 0, len(files))
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		format, ok := formatsByExt[ext]
+		if file.IsDir() || !ok {
+			continue
+		}
+		body, err := os.ReadFile(filepath.Join(dir, subdir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read %s/%s template: %w", subdir, file.Name(), err)
+		}
+		res = append(res, &types.NotificationTemplate{
+			Kind:    kind,
+			Channel: channel,
+			Locale:  strings.TrimSuffix(file.Name(), ext),
+			Format:  format,
+			Body:    string(body),
+		})
+	}
+	return res, nil
+}
+
+// GetUserSettings reads the settings of the user from the users file.
+// Returns nil, nil if the file does not exist or has no entry for the user.
+func (s *DirStore) GetUserSettings(_ context.Context, userID string) (*types.UserSettings, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, usersFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read user settings: %w", err)
+	}
+
+	users := make(map[string]*types.UserSettings)
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("decode user settings: %w", err)
+	}
+	settings, ok := users[userID]
+	if !ok || settings == nil {
+		return nil, nil
+	}
+	settings.UserID = userID
+	return settings, nil
+}
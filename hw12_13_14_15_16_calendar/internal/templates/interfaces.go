@@ -0,0 +1,18 @@
+package templates
+
+import (
+	"context"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+)
+
+// Store represents a source of the notification templates and the user settings.
+type Store interface {
+	// GetNotificationTemplates retrieves all notification templates.
+	// Returns a slice of templates or an error if the operation fails.
+	GetNotificationTemplates(ctx context.Context) ([]*types.NotificationTemplate, error)
+
+	// GetUserSettings retrieves the settings of the user with the given ID.
+	// Returns nil, nil if the user has no settings or an error if the operation fails.
+	GetUserSettings(ctx context.Context, userID string) (*types.UserSettings, error)
+}
@@ -0,0 +1,104 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// localeFormat describes how the event time is formatted for the language.
+type localeFormat struct {
+	months     [12]string // Month names in the "2 January" context.
+	weekdays   [7]string  // Weekday names, starting from Sunday.
+	dateLayout string     // fmt layout with weekday, day, month and year in this order, indexed explicitly.
+	timeLayout string     // time.Format layout.
+	dateTime   string     // fmt layout joining date and time.
+	units      [3]string  // Hours, minutes and seconds abbreviations.
+}
+
+// localeFormats are the supported languages. Other languages are formatted as English.
+var localeFormats = map[string]*localeFormat{
+	"en": {
+		months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		weekdays:   [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		dateLayout: "%[1]s, %[3]s %[2]d, %[4]d",
+		timeLayout: "3:04 PM",
+		dateTime:   "%s, %s",
+		units:      [3]string{"h", "min", "s"},
+	},
+	"ru": {
+		months: [12]string{
+			"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря",
+		},
+		weekdays:   [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		dateLayout: "%[1]s, %[2]d %[3]s %[4]d г.",
+		timeLayout: "15:04",
+		dateTime:   "%s, %s",
+		units:      [3]string{"ч", "мин", "с"},
+	},
+}
+
+// normalizeLocale converts the locale to the lowercase form with "-" separator, e.g. "en_US" to "en-us".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// baseLanguage returns the language part of the normalized locale, e.g. "en" for "en-us".
+func baseLanguage(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// formatFor returns the format of the locale language, falling back to English.
+func formatFor(locale string) *localeFormat {
+	if f, ok := localeFormats[baseLanguage(normalizeLocale(locale))]; ok {
+		return f
+	}
+	return localeFormats["en"]
+}
+
+// date formats the date with the weekday and the month name.
+func (f *localeFormat) date(t time.Time) string {
+	return fmt.Sprintf(f.dateLayout, f.weekdays[t.Weekday()], t.Day(), f.months[t.Month()-1], t.Year())
+}
+
+// time formats the time of day.
+func (f *localeFormat) time(t time.Time) string {
+	return t.Format(f.timeLayout)
+}
+
+// dateTimeString formats both the date and the time of day.
+func (f *localeFormat) dateTimeString(t time.Time) string {
+	return fmt.Sprintf(f.dateTime, f.date(t), f.time(t))
+}
+
+// duration formats the duration in hours, minutes and seconds, omitting zero parts, e.g. "1 h 30 min".
+func (f *localeFormat) duration(d time.Duration) string {
+	d = d.Round(time.Second)
+	parts := make([]string, 0, len(f.units))
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if value := d / unit; value > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", value, f.units[i]))
+			d -= value * unit
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("0 %s", f.units[1])
+	}
+	return strings.Join(parts, " ")
+}
+
+// funcs returns the template functions formatting the values for the locale.
+func (f *localeFormat) funcs() map[string]any {
+	return map[string]any{
+		"date":     f.date,
+		"time":     f.time,
+		"datetime": f.dateTimeString,
+		"duration": f.duration,
+		"format":   func(t time.Time, layout string) string { return t.Format(layout) },
+	}
+}
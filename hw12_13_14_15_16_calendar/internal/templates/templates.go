@@ -0,0 +1,461 @@
+// Package templates provides rendering of the notification messages with text/template and html/template.
+//
+// Templates are defined per kind (event reminders or daily digests), channel and locale and are loaded
+// from a Store: a directory on disk or the database.
+// The event time is formatted in the time zone and the language of the recipient. User settings are taken
+// from the same Store, falling back to the configured defaults.
+package templates
+
+import (
+	"context"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	textTemplate "text/template"
+	"time"
+	_ "time/tzdata" // User time zones do not depend on the time zone database of the host.
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                    //nolint:depguard,nolintlint
+)
+
+// Supported template sources.
+const (
+	// SourceDir matches the templates kept in the directory, see DirStore.
+	SourceDir = "dir"
+	// SourceStorage matches the templates kept in the service storage.
+	SourceStorage = "storage"
+)
+
+// Data is the data the templates are executed with.
+//
+// For the daily digest Start and End are the bounds of the day and Events are the events of the day.
+type Data struct {
+	ID           string
+	Title        string
+	UserID       string
+	Start        time.Time // Event start in the recipient time zone.
+	End          time.Time // Event end in the recipient time zone.
+	Duration     time.Duration
+	ReminderKind types.ReminderKind
+	Late         bool    // The reminder is sent after the event start.
+	Locale       string  // Recipient locale.
+	TimeZone     string  // Recipient time zone name.
+	Events       []*Data // Agenda of the digest, sorted by start.
+}
+
+// executor is a parsed text or HTML template.
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// template is a parsed template with its source attributes.
+type template struct {
+	executor
+	locale string
+	format types.TemplateFormat
+}
+
+// recipient is the resolved locale and time zone of the notification recipient.
+type recipient struct {
+	locale   string
+	timeZone string
+	location *time.Location
+}
+
+// Renderer renders the notifications with the templates loaded from the store.
+type Renderer struct {
+	mu        sync.RWMutex
+	store     Store
+	templates map[types.TemplateKind]map[string]map[string]*template // Templates by kind, channel and locale.
+	locale    string                                                 // Default locale.
+	timeZone  string                                                 // Default time zone name.
+	location  *time.Location                                         // Default time zone.
+}
+
+// NewRenderer creates a new renderer after arguments validation. Templates are not loaded until Load is called.
+func NewRenderer(store Store, cfg map[string]any) (*Renderer, error) {
+	if store == nil {
+		return nil, fmt.Errorf("%w: some of the required parameters are missing: args=[store]",
+			projectErrors.ErrTemplatesInitFailed)
+	}
+	st, err := parseConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{
+		store:     store,
+		templates: make(map[types.TemplateKind]map[string]map[string]*template),
+		locale:    st.locale,
+		timeZone:  st.timeZone,
+		location:  st.location,
+	}, nil
+}
+
+// ValidateConfig validates the templates config without creating a renderer.
+// Problems are returned as *config.ValidationError wrapped with ErrCorruptedConfig.
+func ValidateConfig(cfg map[string]any) error {
+	_, err := parseConfig(cfg)
+	return err
+}
+
+// settings are the renderer settings parsed from the config.
+type settings struct {
+	source   string
+	locale   string
+	timeZone string
+	location *time.Location
+}
+
+// parseConfig validates and extracts the renderer settings from the config, accumulating all problems.
+func parseConfig(cfg map[string]any) (*settings, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: no templates configuration received", projectErrors.ErrCorruptedConfig)
+	}
+
+	ve := &config.ValidationError{}
+	st := &settings{}
+	values := make(map[string]string, len(expectedFields))
+	for _, key := range expectedFields {
+		raw, ok := cfg[key]
+		if !ok {
+			ve.Missing = append(ve.Missing, key)
+			continue
+		}
+		value, ok := raw.(string)
+		if !ok {
+			ve.InvalidType = append(ve.InvalidType, key)
+			continue
+		}
+		values[key] = value
+	}
+
+	if source, ok := values["source"]; ok {
+		if source != SourceDir && source != SourceStorage {
+			ve.InvalidValue = append(ve.InvalidValue, "source")
+		}
+		st.source = source
+	}
+	if dir, ok := values["dir"]; ok && dir == "" && st.source == SourceDir {
+		ve.InvalidValue = append(ve.InvalidValue, "dir")
+	}
+	if locale, ok := values["default_locale"]; ok {
+		if st.locale = normalizeLocale(locale); st.locale == "" {
+			ve.InvalidValue = append(ve.InvalidValue, "default_locale")
+		}
+	}
+	if timeZone, ok := values["default_time_zone"]; ok {
+		if location, err := time.LoadLocation(timeZone); err == nil {
+			st.timeZone, st.location = location.String(), location
+		} else {
+			ve.InvalidValue = append(ve.InvalidValue, "default_time_zone")
+		}
+	}
+
+	if ve.HasErrors() {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
+	}
+	return st, nil
+}
+
+// NewStore returns the store matching the config source: DirStore for "dir" or the given storage for "storage".
+// The storage is required to implement Store, ErrTemplatesUnsupported is returned otherwise.
+func NewStore(cfg map[string]any, storage any) (Store, error) {
+	st, err := parseConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if st.source == SourceDir {
+		dir, _ := cfg["dir"].(string)
+		return NewDirStore(dir), nil
+	}
+	store, ok := storage.(Store)
+	if !ok || store == nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrTemplatesInitFailed, projectErrors.ErrTemplatesUnsupported)
+	}
+	return store, nil
+}
+
+// Load reads and parses all templates from the store, replacing the current ones.
+// If any template is invalid, an error is returned and the current templates are kept.
+func (r *Renderer) Load(ctx context.Context) error {
+	list, err := r.store.GetNotificationTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", projectErrors.ErrTemplatesInitFailed, err)
+	}
+
+	parsed := make(map[types.TemplateKind]map[string]map[string]*template)
+	for _, t := range list {
+		if t == nil {
+			continue
+		}
+		kind, err := types.ParseTemplateKind(string(t.Kind))
+		if err != nil {
+			return fmt.Errorf("%w: channel=%q locale=%q: %w", projectErrors.ErrInvalidTemplate, t.Channel, t.Locale, err)
+		}
+		locale := normalizeLocale(t.Locale)
+		if t.Channel == "" || locale == "" {
+			return fmt.Errorf("%w: kind=%q channel=%q locale=%q: channel and locale are required",
+				projectErrors.ErrInvalidTemplate, kind, t.Channel, t.Locale)
+		}
+		tmpl, err := parse(string(kind)+"/"+t.Channel+"/"+locale, t.Format, t.Body, formatFor(locale))
+		if err != nil {
+			return fmt.Errorf("%w: kind=%q channel=%q locale=%q: %w",
+				projectErrors.ErrInvalidTemplate, kind, t.Channel, t.Locale, err)
+		}
+		tmpl.locale = locale
+		if parsed[kind] == nil {
+			parsed[kind] = make(map[string]map[string]*template)
+		}
+		if parsed[kind][t.Channel] == nil {
+			parsed[kind][t.Channel] = make(map[string]*template)
+		}
+		parsed[kind][t.Channel][locale] = tmpl
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates = parsed
+	return nil
+}
+
+// Reload applies the new default locale and time zone and reloads the templates from the store.
+// Source and directory require a restart and are not reloaded.
+// Returns an error if the config or any of the templates is invalid, keeping the current settings.
+func (r *Renderer) Reload(cfg map[string]any) error {
+	st, err := parseConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if err := r.Load(context.Background()); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.locale = st.locale
+	r.timeZone = st.timeZone
+	r.location = st.location
+	return nil
+}
+
+// ReloadKeys returns the config keys applied by Reload.
+func (r *Renderer) ReloadKeys() []string {
+	return []string{"default_locale", "default_time_zone"}
+}
+
+// Channels returns the sorted names of the channels having at least one template of any kind.
+func (r *Renderer) Channels() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	channels := make(map[string]struct{})
+	for _, byChannel := range r.templates {
+		for channel := range byChannel {
+			channels[channel] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(channels))
+}
+
+// channelsOf returns the sorted names of the channels having at least one template of the given kind.
+func (r *Renderer) channelsOf(kind types.TemplateKind) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.templates[kind]))
+}
+
+// Render renders the notification for the given channel with the template of the notification kind.
+//
+// Locale and time zone are taken from settings, then from the user settings in the store and then from the defaults.
+// Time zone of the digest notification takes precedence over the stored and the default ones.
+// Empty fields of settings are ignored, settings might be nil. Invalid locale or time zone of settings
+// results in ErrInvalidFieldData, while invalid stored ones are replaced by the defaults.
+//
+// The template is chosen by the exact locale, then by its language and then by the default locale.
+// Returns ErrTemplateNotFound if there is no suitable template.
+func (r *Renderer) Render(
+	ctx context.Context,
+	channel string,
+	n *types.Notification,
+	settings *types.UserSettings,
+) (*types.Message, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%w: no notification passed", projectErrors.ErrInvalidNotification)
+	}
+	rcpt, err := r.resolveRecipient(ctx, n.UserID, withNotificationTimeZone(settings, n))
+	if err != nil {
+		return nil, err
+	}
+	return r.render(channel, n, rcpt)
+}
+
+// RenderAll renders the notification for all channels having the templates of the notification kind
+// with the user settings from the store. Rendering stops on the first error.
+func (r *Renderer) RenderAll(ctx context.Context, n *types.Notification) ([]*types.Message, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%w: no notification passed", projectErrors.ErrInvalidNotification)
+	}
+	rcpt, err := r.resolveRecipient(ctx, n.UserID, withNotificationTimeZone(nil, n))
+	if err != nil {
+		return nil, err
+	}
+
+	channels := r.channelsOf(types.TemplateKindOf(n.ReminderKind))
+	res := make([]*types.Message, 0, len(channels))
+	for _, channel := range channels {
+		msg, err := r.render(channel, n, rcpt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, msg)
+	}
+	return res, nil
+}
+
+// withNotificationTimeZone returns the settings with the time zone of the notification, if it has one
+// and the settings do not override it.
+func withNotificationTimeZone(settings *types.UserSettings, n *types.Notification) *types.UserSettings {
+	if n.TimeZone == "" || (settings != nil && settings.TimeZone != "") {
+		return settings
+	}
+	res := &types.UserSettings{UserID: n.UserID, TimeZone: n.TimeZone}
+	if settings != nil {
+		res.Locale = settings.Locale
+	}
+	return res
+}
+
+// resolveRecipient merges the given settings, the stored user settings and the defaults.
+func (r *Renderer) resolveRecipient(
+	ctx context.Context,
+	userID string,
+	settings *types.UserSettings,
+) (*recipient, error) {
+	r.mu.RLock()
+	rcpt := &recipient{locale: r.locale, timeZone: r.timeZone, location: r.location}
+	r.mu.RUnlock()
+
+	if settings == nil {
+		settings = &types.UserSettings{}
+	}
+	if settings.Locale != "" {
+		if rcpt.locale = normalizeLocale(settings.Locale); rcpt.locale == "" {
+			return nil, fmt.Errorf("%w: invalid locale %q", projectErrors.ErrInvalidFieldData, settings.Locale)
+		}
+	}
+	if settings.TimeZone != "" {
+		location, err := time.LoadLocation(settings.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid time zone %q", projectErrors.ErrInvalidFieldData, settings.TimeZone)
+		}
+		rcpt.timeZone, rcpt.location = location.String(), location
+	}
+	if settings.Locale != "" && settings.TimeZone != "" {
+		return rcpt, nil
+	}
+
+	stored, err := r.store.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user settings: %w", err)
+	}
+	if stored == nil {
+		return rcpt, nil
+	}
+	if locale := normalizeLocale(stored.Locale); settings.Locale == "" && locale != "" {
+		rcpt.locale = locale
+	}
+	if settings.TimeZone == "" && stored.TimeZone != "" {
+		if location, err := time.LoadLocation(stored.TimeZone); err == nil {
+			rcpt.timeZone, rcpt.location = location.String(), location
+		}
+	}
+	return rcpt, nil
+}
+
+// render executes the channel template of the notification kind for the recipient.
+func (r *Renderer) render(channel string, n *types.Notification, rcpt *recipient) (*types.Message, error) {
+	kind := types.TemplateKindOf(n.ReminderKind)
+	tmpl, err := r.lookup(kind, channel, rcpt.locale)
+	if err != nil {
+		return nil, err
+	}
+
+	data := newData(n, rcpt)
+	for _, item := range n.Agenda {
+		if item != nil {
+			data.Events = append(data.Events, newData(item, rcpt))
+		}
+	}
+
+	var body strings.Builder
+	if err := tmpl.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("%w: kind=%q channel=%q locale=%q: %w",
+			projectErrors.ErrInvalidTemplate, kind, channel, tmpl.locale, err)
+	}
+
+	return &types.Message{
+		Channel:  channel,
+		Locale:   tmpl.locale,
+		TimeZone: rcpt.timeZone,
+		Format:   tmpl.format,
+		Body:     body.String(),
+	}, nil
+}
+
+// newData converts the notification to the template data in the recipient time zone.
+func newData(n *types.Notification, rcpt *recipient) *Data {
+	start := n.Datetime.In(rcpt.location)
+	return &Data{
+		ID:           n.ID,
+		Title:        n.Title,
+		UserID:       n.UserID,
+		Start:        start,
+		End:          start.Add(n.Duration),
+		Duration:     n.Duration,
+		ReminderKind: n.ReminderKind,
+		Late:         n.Late,
+		Locale:       rcpt.locale,
+		TimeZone:     rcpt.timeZone,
+	}
+}
+
+// lookup finds the channel template of the kind by the exact locale, its language or the default locale.
+func (r *Renderer) lookup(kind types.TemplateKind, channel, locale string) (*template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byLocale, ok := r.templates[kind][channel]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown channel %q for %s templates", projectErrors.ErrTemplateNotFound, channel, kind)
+	}
+	for _, candidate := range []string{locale, baseLanguage(locale), r.locale, baseLanguage(r.locale)} {
+		if tmpl, ok := byLocale[candidate]; ok {
+			return tmpl, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: kind=%q channel=%q locale=%q", projectErrors.ErrTemplateNotFound, kind, channel, locale)
+}
+
+// parse parses the template body in the given format with the functions of the locale.
+func parse(name string, format types.TemplateFormat, body string, f *localeFormat) (*template, error) {
+	format, err := types.ParseTemplateFormat(string(format))
+	if err != nil {
+		return nil, err
+	}
+
+	var exec executor
+	if format == types.TemplateHTML {
+		exec, err = htmlTemplate.New(name).Funcs(f.funcs()).Parse(body)
+	} else {
+		exec, err = textTemplate.New(name).Funcs(f.funcs()).Parse(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template{executor: exec, format: format}, nil
+}
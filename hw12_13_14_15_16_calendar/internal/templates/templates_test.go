@@ -0,0 +1,352 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"                    //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// memStore is a Store keeping the templates and the user settings in memory.
+type memStore struct {
+	templates []*types.NotificationTemplate
+	users     map[string]*types.UserSettings
+}
+
+func (s *memStore) GetNotificationTemplates(context.Context) ([]*types.NotificationTemplate, error) {
+	return s.templates, nil
+}
+
+func (s *memStore) GetUserSettings(_ context.Context, userID string) (*types.UserSettings, error) {
+	return s.users[userID], nil
+}
+
+func testConfig() map[string]any {
+	return map[string]any{
+		"source":            SourceDir,
+		"dir":               "templates",
+		"default_locale":    "en",
+		"default_time_zone": "UTC",
+	}
+}
+
+func testNotification() *types.Notification {
+	return &types.Notification{
+		ID:           "0b4a3d39-8b7f-4f0e-9a51-1e7b8a6f2c11",
+		Title:        "Meeting <1:1>",
+		UserID:       "user123",
+		Datetime:     time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC),
+		Duration:     90 * time.Minute,
+		ReminderKind: types.ReminderBeforeStart,
+	}
+}
+
+func newTestRenderer(t *testing.T, store Store) *Renderer {
+	t.Helper()
+	r, err := NewRenderer(store, testConfig())
+	require.NoError(t, err)
+	require.NoError(t, r.Load(context.Background()))
+	return r
+}
+
+func TestRender(t *testing.T) {
+	store := &memStore{
+		templates: []*types.NotificationTemplate{
+			{Channel: "log", Locale: "en", Body: "{{.Title}} on {{datetime .Start}} for {{duration .Duration}}"},
+			{Channel: "log", Locale: "ru", Body: "{{.Title}}: {{datetime .Start}}, {{duration .Duration}}"},
+			{Channel: "email", Locale: "en", Format: types.TemplateHTML, Body: "<b>{{.Title}}</b> {{time .End}}"},
+		},
+		users: map[string]*types.UserSettings{
+			"user123": {UserID: "user123", Locale: "ru-RU", TimeZone: "Europe/Moscow"},
+			"broken":  {UserID: "broken", Locale: "fr", TimeZone: "Invalid/Zone"},
+		},
+	}
+	r := newTestRenderer(t, store)
+	require.Equal(t, []string{"email", "log"}, r.Channels())
+	ctx := context.Background()
+
+	t.Run("default settings", func(t *testing.T) {
+		n := testNotification()
+		n.UserID = "unknown"
+		msg, err := r.Render(ctx, "log", n, nil)
+		require.NoError(t, err)
+		require.Equal(t, &types.Message{
+			Channel:  "log",
+			Locale:   "en",
+			TimeZone: "UTC",
+			Format:   types.TemplateText,
+			Body:     "Meeting <1:1> on Monday, January 6, 2025, 9:30 AM for 1 h 30 min",
+		}, msg)
+	})
+
+	t.Run("user settings with language fallback", func(t *testing.T) {
+		msg, err := r.Render(ctx, "log", testNotification(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "ru", msg.Locale)
+		require.Equal(t, "Europe/Moscow", msg.TimeZone)
+		require.Equal(t, "Meeting <1:1>: понедельник, 6 января 2025 г., 12:30, 1 ч 30 мин", msg.Body)
+	})
+
+	t.Run("overridden settings", func(t *testing.T) {
+		msg, err := r.Render(ctx, "log", testNotification(), &types.UserSettings{Locale: "en_GB"})
+		require.NoError(t, err)
+		require.Equal(t, "en", msg.Locale)
+		require.Equal(t, "Europe/Moscow", msg.TimeZone, "time zone is kept from the user settings")
+		require.Contains(t, msg.Body, "12:30 PM")
+	})
+
+	t.Run("invalid stored settings", func(t *testing.T) {
+		n := testNotification()
+		n.UserID = "broken"
+		msg, err := r.Render(ctx, "log", n, nil)
+		require.NoError(t, err)
+		require.Equal(t, "en", msg.Locale, "default locale is used for the missing template")
+		require.Equal(t, "UTC", msg.TimeZone, "default time zone is used for the invalid one")
+	})
+
+	t.Run("html escaping", func(t *testing.T) {
+		msg, err := r.Render(ctx, "email", testNotification(), nil)
+		require.NoError(t, err)
+		require.Equal(t, types.TemplateHTML, msg.Format)
+		require.Equal(t, "<b>Meeting &lt;1:1&gt;</b> 2:00 PM", msg.Body)
+	})
+
+	t.Run("render all", func(t *testing.T) {
+		msgs, err := r.RenderAll(ctx, testNotification())
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		require.Equal(t, "email", msgs[0].Channel)
+		require.Equal(t, "log", msgs[1].Channel)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := r.Render(ctx, "sms", testNotification(), nil)
+		require.ErrorIs(t, err, projectErrors.ErrTemplateNotFound)
+
+		_, err = r.Render(ctx, "log", testNotification(), &types.UserSettings{TimeZone: "Mars/Olympus"})
+		require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+
+		_, err = r.Render(ctx, "log", nil, nil)
+		require.ErrorIs(t, err, projectErrors.ErrInvalidNotification)
+	})
+}
+
+func TestRender_Late(t *testing.T) {
+	store := &memStore{
+		templates: []*types.NotificationTemplate{
+			{Channel: "log", Locale: "en", Body: "{{if .Late}}[late] {{end}}{{.Title}}"},
+		},
+	}
+	r := newTestRenderer(t, store)
+
+	n := testNotification()
+	msg, err := r.Render(context.Background(), "log", n, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Meeting <1:1>", msg.Body)
+
+	n.Late = true
+	msg, err = r.Render(context.Background(), "log", n, nil)
+	require.NoError(t, err)
+	require.Equal(t, "[late] Meeting <1:1>", msg.Body)
+}
+
+func TestRender_Digest(t *testing.T) {
+	store := &memStore{
+		templates: []*types.NotificationTemplate{
+			{Channel: "log", Locale: "en", Body: "{{.Title}}"},
+			{
+				Kind: types.TemplateDigest, Channel: "log", Locale: "en",
+				Body: "{{date .Start}}:{{range .Events}} {{time .Start}} {{.Title}};{{end}}",
+			},
+			{Kind: types.TemplateDigest, Channel: "email", Locale: "en", Format: types.TemplateHTML, Body: "{{len .Events}}"},
+		},
+		users: map[string]*types.UserSettings{"user123": {UserID: "user123", TimeZone: "Europe/Moscow"}},
+	}
+	r := newTestRenderer(t, store)
+	require.Equal(t, []string{"email", "log"}, r.Channels())
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	day := time.Date(2025, 1, 7, 0, 0, 0, 0, tokyo)
+	digest := &types.Notification{
+		ID:           "digest",
+		UserID:       "user123",
+		Datetime:     day,
+		Duration:     24 * time.Hour,
+		ReminderKind: types.ReminderDailyDigest,
+		TimeZone:     "Asia/Tokyo",
+		Agenda: []*types.Notification{
+			{ID: "1", Title: "Breakfast", Datetime: day.Add(8 * time.Hour), Duration: time.Hour},
+			{ID: "2", Title: "Call <1:1>", Datetime: day.Add(15 * time.Hour), Duration: time.Hour},
+		},
+	}
+
+	msgs, err := r.RenderAll(context.Background(), digest)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2, "only the channels with the digest templates are rendered")
+	require.Equal(t, "2", msgs[0].Body)
+	require.Equal(t, "Asia/Tokyo", msgs[1].TimeZone, "digest time zone overrides the user one")
+	require.Equal(t, "Tuesday, January 7, 2025: 8:00 AM Breakfast; 3:00 PM Call <1:1>;", msgs[1].Body)
+
+	msg, err := r.Render(context.Background(), "log", digest, &types.UserSettings{TimeZone: "UTC"})
+	require.NoError(t, err)
+	require.Equal(t, "Monday, January 6, 2025: 11:00 PM Breakfast; 6:00 AM Call <1:1>;", msg.Body)
+
+	store.templates = store.templates[:1]
+	require.NoError(t, r.Load(context.Background()))
+	msgs, err = r.RenderAll(context.Background(), digest)
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+	_, err = r.Render(context.Background(), "log", digest, nil)
+	require.ErrorIs(t, err, projectErrors.ErrTemplateNotFound)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template *types.NotificationTemplate
+	}{
+		{"parse error", &types.NotificationTemplate{Channel: "log", Locale: "en", Body: "{{.Title"}},
+		{"unknown function", &types.NotificationTemplate{Channel: "log", Locale: "en", Body: "{{weekday .Start}}"}},
+		{"unknown format", &types.NotificationTemplate{Channel: "log", Locale: "en", Format: "markdown"}},
+		{"unknown kind", &types.NotificationTemplate{Kind: "weekly", Channel: "log", Locale: "en"}},
+		{"no channel", &types.NotificationTemplate{Locale: "en"}},
+		{"no locale", &types.NotificationTemplate{Channel: "log"}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			store := &memStore{templates: []*types.NotificationTemplate{
+				{Channel: "log", Locale: "en", Body: "{{.Title}}"},
+			}}
+			r := newTestRenderer(t, store)
+
+			store.templates = append(store.templates, tC.template)
+			err := r.Load(context.Background())
+			require.ErrorIs(t, err, projectErrors.ErrInvalidTemplate)
+			require.Equal(t, []string{"log"}, r.Channels(), "current templates are kept")
+		})
+	}
+}
+
+func TestRender_ExecutionError(t *testing.T) {
+	r := newTestRenderer(t, &memStore{templates: []*types.NotificationTemplate{
+		{Channel: "log", Locale: "en", Body: "{{.Missing}}"},
+	}})
+	_, err := r.Render(context.Background(), "log", testNotification(), nil)
+	require.ErrorIs(t, err, projectErrors.ErrInvalidTemplate)
+}
+
+func TestReload(t *testing.T) {
+	store := &memStore{templates: []*types.NotificationTemplate{
+		{Channel: "log", Locale: "en", Body: "{{time .Start}}"},
+	}}
+	r := newTestRenderer(t, store)
+
+	cfg := testConfig()
+	cfg["default_time_zone"] = "Asia/Tokyo"
+	store.templates = append(store.templates, &types.NotificationTemplate{Channel: "sms", Locale: "en"})
+	require.NoError(t, r.Reload(cfg))
+	require.Equal(t, []string{"log", "sms"}, r.Channels())
+
+	msg, err := r.Render(context.Background(), "log", testNotification(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "6:30 PM", msg.Body)
+
+	cfg["default_time_zone"] = "Nowhere"
+	require.ErrorIs(t, r.Reload(cfg), projectErrors.ErrCorruptedConfig)
+	msg, err = r.Render(context.Background(), "log", testNotification(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Tokyo", msg.TimeZone, "current settings are kept")
+}
+
+func TestValidateConfig(t *testing.T) {
+	require.NoError(t, ValidateConfig(testConfig()))
+
+	cfg := map[string]any{
+		"source":            "s3",
+		"dir":               1,
+		"default_locale":    " ",
+		"default_time_zone": "Nowhere",
+	}
+	err := ValidateConfig(cfg)
+	require.ErrorIs(t, err, projectErrors.ErrCorruptedConfig)
+	var ve *config.ValidationError
+	require.ErrorAs(t, err, &ve)
+	require.ElementsMatch(t, []string{"dir"}, ve.InvalidType)
+	require.ElementsMatch(t, []string{"source", "default_locale", "default_time_zone"}, ve.InvalidValue)
+
+	err = ValidateConfig(map[string]any{"source": SourceDir, "dir": ""})
+	require.ErrorAs(t, err, &ve)
+	require.ElementsMatch(t, []string{"default_locale", "default_time_zone"}, ve.Missing)
+	require.ElementsMatch(t, []string{"dir"}, ve.InvalidValue)
+}
+
+func TestNewStore(t *testing.T) {
+	store, err := NewStore(testConfig(), nil)
+	require.NoError(t, err)
+	require.IsType(t, &DirStore{}, store)
+
+	cfg := testConfig()
+	cfg["source"] = SourceStorage
+	mem := &memStore{}
+	store, err = NewStore(cfg, mem)
+	require.NoError(t, err)
+	require.Same(t, mem, store)
+
+	_, err = NewStore(cfg, struct{}{})
+	require.ErrorIs(t, err, projectErrors.ErrTemplatesUnsupported)
+}
+
+func TestDirStore(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"log/en.txt":           "{{.Title}}",
+		"log/ru.tmpl":          "{{.Title}}",
+		"log/README.md":        "ignored",
+		"email/en.html":        "<p>{{.Title}}</p>",
+		"email/digest/ru.html": "<ul>{{range .Events}}<li>{{.Title}}</li>{{end}}</ul>",
+		"email/other/en.txt":   "ignored",
+	}
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+	}
+	store := NewDirStore(dir)
+	ctx := context.Background()
+
+	list, err := store.GetNotificationTemplates(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*types.NotificationTemplate{
+		{Kind: types.TemplateReminder, Channel: "log", Locale: "en", Format: types.TemplateText, Body: "{{.Title}}"},
+		{Kind: types.TemplateReminder, Channel: "log", Locale: "ru", Format: types.TemplateText, Body: "{{.Title}}"},
+		{Kind: types.TemplateReminder, Channel: "email", Locale: "en", Format: types.TemplateHTML, Body: "<p>{{.Title}}</p>"},
+		{
+			Kind: types.TemplateDigest, Channel: "email", Locale: "ru", Format: types.TemplateHTML,
+			Body: "<ul>{{range .Events}}<li>{{.Title}}</li>{{end}}</ul>",
+		},
+	}, list)
+
+	settings, err := store.GetUserSettings(ctx, "user123")
+	require.NoError(t, err)
+	require.Nil(t, settings, "no users file")
+
+	users := `{"user123": {"locale": "ru", "time_zone": "Europe/Moscow"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, usersFile), []byte(users), 0o600))
+	settings, err = store.GetUserSettings(ctx, "user123")
+	require.NoError(t, err)
+	require.Equal(t, &types.UserSettings{UserID: "user123", Locale: "ru", TimeZone: "Europe/Moscow"}, settings)
+	settings, err = store.GetUserSettings(ctx, "other")
+	require.NoError(t, err)
+	require.Nil(t, settings)
+
+	_, err = NewDirStore(filepath.Join(dir, "missing")).GetNotificationTemplates(ctx)
+	require.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package types
+
+import "time"
+
+// Day is the duration of a single all-day event day.
+const Day = 24 * time.Hour
+
+// SetAllDay sets the all-day flag of the event data.
+//
+// All-day events have date-only semantics: the datetime is truncated to the midnight of its calendar date
+// and stored in UTC, so the event refers to the same date regardless of the time zone it was created in.
+// The duration is rounded up to whole days, any non-positive duration is treated as a single day.
+func (ed *EventData) SetAllDay(allDay bool) {
+	ed.AllDay = allDay
+	if !allDay {
+		return
+	}
+
+	ed.Datetime = toDate(ed.Datetime)
+	days := (ed.Duration + Day - 1) / Day
+	ed.Duration = max(days, 1) * Day
+}
+
+// Intersects reports whether the event intersects with the [start, end) time window.
+//
+// All-day events are matched by calendar dates of the window, see AllDayWindow.
+func (ed *EventData) Intersects(start, end time.Time) bool {
+	if ed.AllDay {
+		start, end = AllDayWindow(start, end)
+	}
+	return ed.Datetime.Before(end) && ed.Datetime.Add(ed.Duration).After(start)
+}
+
+// AllDayWindow converts the [start, end) time window to the window of calendar dates used to match all-day events.
+//
+// Dates are taken in the location of the given times, so the all-day event is found by its date
+// independently of the requester time zone. Partially covered end date is included in the window.
+func AllDayWindow(start, end time.Time) (time.Time, time.Time) {
+	dateStart := toDate(start)
+	dateEnd := toDate(end)
+	if !end.Equal(time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())) {
+		dateEnd = dateEnd.AddDate(0, 0, 1)
+	}
+	return dateStart, dateEnd
+}
+
+// toDate returns the UTC midnight of the calendar date of t in its own location.
+func toDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+// TestEventData_SetAllDay tests the normalization of all-day events.
+func TestEventData_SetAllDay(t *testing.T) {
+	moscow := time.FixedZone("UTC+3", 3*60*60)
+
+	testCases := []struct {
+		name             string
+		datetime         time.Time
+		duration         time.Duration
+		expectedDatetime time.Time
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "utc date",
+			datetime:         time.Date(2025, 1, 1, 15, 30, 0, 0, time.UTC),
+			duration:         time.Hour,
+			expectedDatetime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedDuration: Day,
+		},
+		{
+			name:             "local date is kept",
+			datetime:         time.Date(2025, 1, 1, 1, 0, 0, 0, moscow),
+			duration:         Day,
+			expectedDatetime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedDuration: Day,
+		},
+		{
+			name:             "multi-day duration is rounded up",
+			datetime:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			duration:         2*Day + time.Minute,
+			expectedDatetime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedDuration: 3 * Day,
+		},
+		{
+			name:             "zero duration",
+			datetime:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			duration:         0,
+			expectedDatetime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedDuration: Day,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			data := &EventData{Datetime: tC.datetime, Duration: tC.duration}
+			data.SetAllDay(true)
+			require.True(t, data.AllDay, "all-day flag is not set")
+			require.True(t, tC.expectedDatetime.Equal(data.Datetime), "unexpected datetime: %v", data.Datetime)
+			require.Equal(t, tC.expectedDuration, data.Duration, "unexpected duration")
+		})
+	}
+}
+
+// TestEventData_Intersects tests the period matching of regular and all-day events.
+func TestEventData_Intersects(t *testing.T) {
+	newYork := time.FixedZone("UTC-5", -5*60*60)
+	dayStart := func(loc *time.Location, day int) time.Time {
+		return time.Date(2025, 1, day, 0, 0, 0, 0, loc)
+	}
+
+	testCases := []struct {
+		name     string
+		data     EventData
+		start    time.Time
+		end      time.Time
+		expected bool
+	}{
+		{
+			name:     "starts within period",
+			data:     EventData{Datetime: dayStart(time.UTC, 1).Add(time.Hour), Duration: time.Hour},
+			start:    dayStart(time.UTC, 1),
+			end:      dayStart(time.UTC, 2),
+			expected: true,
+		},
+		{
+			name:     "started before period",
+			data:     EventData{Datetime: dayStart(time.UTC, 1), Duration: 3 * Day},
+			start:    dayStart(time.UTC, 2),
+			end:      dayStart(time.UTC, 3),
+			expected: true,
+		},
+		{
+			name:     "ends at period start",
+			data:     EventData{Datetime: dayStart(time.UTC, 1), Duration: Day},
+			start:    dayStart(time.UTC, 2),
+			end:      dayStart(time.UTC, 3),
+			expected: false,
+		},
+		{
+			name:     "all-day event in another time zone",
+			data:     EventData{Datetime: dayStart(time.UTC, 2), Duration: Day, AllDay: true},
+			start:    dayStart(newYork, 2),
+			end:      dayStart(newYork, 3),
+			expected: true,
+		},
+		{
+			name:     "all-day event on the previous date in another time zone",
+			data:     EventData{Datetime: dayStart(time.UTC, 2), Duration: Day, AllDay: true},
+			start:    dayStart(newYork, 1),
+			end:      dayStart(newYork, 2),
+			expected: false,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, tC.data.Intersects(tC.start, tC.end), "unexpected intersection result")
+		})
+	}
+}
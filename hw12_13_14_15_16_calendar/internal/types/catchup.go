@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+)
+
+// CatchUpPolicy defines how the reminders, which are handled after the event start, are treated by the scheduler.
+// Such reminders appear after the scheduler downtime or the broker unavailability.
+type CatchUpPolicy string
+
+// Possible values for CatchUpPolicy.
+const (
+	// CatchUpAlways sends all reminders, marking the ones of the started events as late. It is the default policy.
+	CatchUpAlways CatchUpPolicy = "always"
+	// CatchUpSkip skips the reminders of the started events.
+	CatchUpSkip CatchUpPolicy = "skip"
+	// CatchUpGrace sends the reminders of the events started within the grace window as late and skips the rest.
+	CatchUpGrace CatchUpPolicy = "grace"
+)
+
+// ParseCatchUpPolicy validates the given string and converts it to CatchUpPolicy.
+// Empty string is treated as CatchUpAlways.
+//
+// Returns a wrapped ErrInvalidFieldData error on unknown values.
+func ParseCatchUpPolicy(s string) (CatchUpPolicy, error) {
+	switch policy := CatchUpPolicy(s); policy {
+	case "":
+		return CatchUpAlways, nil
+	case CatchUpAlways, CatchUpSkip, CatchUpGrace:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: invalid=[catch_up_policy] value=%q", projectErrors.ErrInvalidFieldData, s)
+	}
+}
+
+// Decide reports whether the reminder of the event starting at start should be sent at now
+// and whether it is late, i.e. the event has already started. grace is used by CatchUpGrace only.
+func (p CatchUpPolicy) Decide(start, now time.Time, grace time.Duration) (send, late bool) {
+	if now.Before(start) {
+		return true, false
+	}
+	switch p {
+	case CatchUpSkip:
+		return false, true
+	case CatchUpGrace:
+		return now.Sub(start) <= grace, true
+	default:
+		return true, true
+	}
+}
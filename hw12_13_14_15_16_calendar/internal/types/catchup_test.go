@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// TestCatchUpPolicy_Decide tests the handling of the reminders of the started events by each policy.
+func TestCatchUpPolicy_Decide(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	grace := 15 * time.Minute
+
+	testCases := []struct {
+		name   string
+		policy CatchUpPolicy
+		now    time.Time
+		send   bool
+		late   bool
+	}{
+		{name: "skip, not started", policy: CatchUpSkip, now: start.Add(-time.Minute), send: true},
+		{name: "skip, started", policy: CatchUpSkip, now: start, late: true},
+		{name: "grace, not started", policy: CatchUpGrace, now: start.Add(-time.Minute), send: true},
+		{name: "grace, within window", policy: CatchUpGrace, now: start.Add(grace), send: true, late: true},
+		{name: "grace, out of window", policy: CatchUpGrace, now: start.Add(grace + time.Second), late: true},
+		{name: "always, not started", policy: CatchUpAlways, now: start.Add(-time.Minute), send: true},
+		{name: "always, ended", policy: CatchUpAlways, now: start.Add(24 * time.Hour), send: true, late: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			send, late := tC.policy.Decide(start, tC.now, grace)
+			require.Equal(t, tC.send, send, "unexpected send decision")
+			require.Equal(t, tC.late, late, "unexpected late marker")
+		})
+	}
+}
+
+// TestParseCatchUpPolicy tests the parsing of the policy names.
+func TestParseCatchUpPolicy(t *testing.T) {
+	policy, err := ParseCatchUpPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, CatchUpAlways, policy, "empty policy means always")
+
+	policy, err = ParseCatchUpPolicy("grace")
+	require.NoError(t, err)
+	require.Equal(t, CatchUpGrace, policy)
+
+	_, err = ParseCatchUpPolicy("never")
+	require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid" //nolint:depguard,nolintlint
+)
+
+// EventChange is the latest change of the event in the change log of the storage.
+//
+// Seq is the sequence number of the change, growing with each change across all users.
+// Deleted events are kept in the log as tombstones until they are pruned.
+type EventChange struct {
+	EventID   uuid.UUID `db:"event_id" json:"event_id"` //nolint:tagliatelle
+	UserID    string    `db:"user_id" json:"user_id"`   //nolint:tagliatelle
+	Seq       int64     `db:"seq" json:"seq"`
+	Deleted   bool      `db:"deleted" json:"deleted"`
+	ChangedAt time.Time `db:"changed_at" json:"changed_at"` //nolint:tagliatelle
+}
+
+// EventChanges is the set of the user events changed since the requested sequence number.
+//
+// Seq is the sequence number to request the next changes since. Events are the current state of the created
+// and updated events, Deleted are the IDs of the deleted ones.
+type EventChanges struct {
+	Seq     int64
+	Events  []*Event
+	Deleted []uuid.UUID
+}
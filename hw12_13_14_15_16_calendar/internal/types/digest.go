@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Digest time zones are resolved even if the system has no time zone database.
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+)
+
+const (
+	// DigestTimeLayout is the layout of the local time the digest is sent at.
+	DigestTimeLayout = "15:04"
+	// DigestDateLayout is the layout of the local date of the last sent digest.
+	DigestDateLayout = "2006-01-02"
+)
+
+// DigestSettings are the preferences of the daily agenda digest of the user.
+//
+// SentDate is maintained by the scheduler and is not changed on the settings update.
+type DigestSettings struct {
+	UserID   string `db:"user_id" json:"user_id"` //nolint:tagliatelle
+	Enabled  bool   `db:"enabled" json:"enabled"`
+	Time     string `db:"send_time" json:"time"`                // Local time in 15:04 format.
+	TimeZone string `db:"time_zone" json:"time_zone"`           //nolint:tagliatelle
+	SentDate string `db:"sent_date" json:"sent_date,omitempty"` //nolint:tagliatelle
+}
+
+// NewDigestSettings creates the digest settings after validation.
+// Empty time zone is treated as UTC.
+//
+// Returns a wrapped ErrEmptyField if the user ID or the time is empty
+// and a wrapped ErrInvalidFieldData if the time or the time zone is invalid.
+func NewDigestSettings(userID string, enabled bool, sendTime, timeZone string) (*DigestSettings, error) {
+	settings := &DigestSettings{
+		UserID:   strings.TrimSpace(userID),
+		Enabled:  enabled,
+		Time:     strings.TrimSpace(sendTime),
+		TimeZone: strings.TrimSpace(timeZone),
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Validate checks the settings, normalizing the time to 15:04 format and the empty time zone to UTC.
+func (s *DigestSettings) Validate() error {
+	missing := make([]string, 0)
+	if s.UserID == "" {
+		missing = append(missing, "user_id")
+	}
+	if s.Time == "" {
+		missing = append(missing, "time")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %v", projectErrors.ErrEmptyField, missing)
+	}
+
+	sendTime, err := time.Parse(DigestTimeLayout, s.Time)
+	if err != nil {
+		return fmt.Errorf("%w: time %q, expected HH:MM format", projectErrors.ErrInvalidFieldData, s.Time)
+	}
+	if s.TimeZone == "" {
+		s.TimeZone = time.UTC.String()
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return fmt.Errorf("%w: time zone %q: %w", projectErrors.ErrInvalidFieldData, s.TimeZone, err)
+	}
+
+	s.Time = sendTime.Format(DigestTimeLayout)
+	return nil
+}
+
+// Location returns the time zone of the digest. UTC is returned if the time zone is invalid.
+func (s *DigestSettings) Location() *time.Location {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// DueAt returns the time the digest of the day containing now is due at, in the digest time zone.
+// Returns zero time if the settings time is invalid.
+func (s *DigestSettings) DueAt(now time.Time) time.Time {
+	sendTime, err := time.Parse(DigestTimeLayout, s.Time)
+	if err != nil {
+		return time.Time{}
+	}
+	local := now.In(s.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), sendTime.Hour(), sendTime.Minute(), 0, 0,
+		local.Location())
+}
+
+// IsDue reports whether the digest of the day containing now is enabled, due and not sent yet.
+func (s *DigestSettings) IsDue(now time.Time) bool {
+	if !s.Enabled {
+		return false
+	}
+	dueAt := s.DueAt(now)
+	return !dueAt.IsZero() && !now.Before(dueAt) && s.SentDate != dueAt.Format(DigestDateLayout)
+}
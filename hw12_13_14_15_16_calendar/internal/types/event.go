@@ -4,6 +4,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
@@ -12,14 +13,23 @@ import (
 
 // EventData contains the data of the event whithout its ID.
 // Pointer fields are optional.
+// OverlapPolicy is applied by the storage on the event creation or update and is not persisted.
 type EventData struct {
-	Title       string
-	Datetime    time.Time
-	Duration    time.Duration
-	Description string
-	UserID      string        `db:"user_id" json:"user_id,omitempty"`     //nolint:tagliatelle
-	RemindIn    time.Duration `db:"remind_in" json:"remind_in,omitempty"` //nolint:tagliatelle
-	IsNotified  bool          `db:"is_notified" json:"is_notified"`       //nolint:tagliatelle
+	Title         string
+	Datetime      time.Time
+	Duration      time.Duration
+	Description   string
+	UserID        string        `db:"user_id" json:"user_id,omitempty"`     //nolint:tagliatelle
+	RemindIn      time.Duration `db:"remind_in" json:"remind_in,omitempty"` //nolint:tagliatelle
+	IsNotified    bool          `db:"is_notified" json:"is_notified"`       //nolint:tagliatelle
+	BusyStatus    BusyStatus    `db:"busy_status" json:"busy_status"`       //nolint:tagliatelle
+	AllDay        bool          `db:"all_day" json:"all_day"`               //nolint:tagliatelle
+	Tags          []string      `db:"tags" json:"tags,omitempty"`
+	Color         string        `db:"color" json:"color,omitempty"`
+	Location      string        `db:"location" json:"location,omitempty"`
+	URL           string        `db:"url" json:"url,omitempty"`
+	Resources     []string      `db:"resources" json:"resources,omitempty"`
+	OverlapPolicy OverlapPolicy `db:"-" json:"-"`
 }
 
 // DBEvent contains the data of the event with its ID.
@@ -39,6 +49,13 @@ type DBEventData struct {
 	UserID      string   `db:"user_id" json:"user_id,omitempty"`     //nolint:tagliatelle
 	RemindIn    Duration `db:"remind_in" json:"remind_in,omitempty"` //nolint:tagliatelle
 	IsNotified  bool     `db:"is_notified" json:"is_notified"`       //nolint:tagliatelle
+	BusyStatus  string   `db:"busy_status" json:"busy_status"`       //nolint:tagliatelle
+	AllDay      bool     `db:"all_day" json:"all_day"`               //nolint:tagliatelle
+	Tags        Tags     `db:"tags" json:"tags,omitempty"`
+	Color       string   `db:"color" json:"color,omitempty"`
+	Location    string   `db:"location" json:"location,omitempty"`
+	URL         string   `db:"url" json:"url,omitempty"`
+	Resources   Tags     `db:"resources" json:"resources,omitempty"`
 }
 
 // Event contains the data of the event with its ID.
@@ -94,6 +111,7 @@ func NewEventData(title string, datetime time.Time, duration time.Duration,
 		Description: description,
 		UserID:      userID,
 		RemindIn:    remindIn,
+		BusyStatus:  BusyStatusBusy,
 	}, nil
 }
 
@@ -152,12 +170,21 @@ func DeepCopyEvent(event *Event) *Event {
 	return &Event{
 		ID: event.ID,
 		EventData: EventData{
-			Title:       event.Title,
-			Datetime:    event.Datetime,
-			Duration:    event.Duration,
-			Description: event.Description,
-			UserID:      event.UserID,
-			RemindIn:    event.RemindIn,
+			Title:         event.Title,
+			Datetime:      event.Datetime,
+			Duration:      event.Duration,
+			Description:   event.Description,
+			UserID:        event.UserID,
+			RemindIn:      event.RemindIn,
+			IsNotified:    event.IsNotified,
+			BusyStatus:    event.BusyStatus,
+			AllDay:        event.AllDay,
+			Tags:          slices.Clone(event.Tags),
+			Color:         event.Color,
+			Location:      event.Location,
+			URL:           event.URL,
+			Resources:     slices.Clone(event.Resources),
+			OverlapPolicy: event.OverlapPolicy,
 		},
 	}
 }
@@ -165,10 +192,12 @@ func DeepCopyEvent(event *Event) *Event {
 // ToNotification converts the Event to Notification.
 func (e *Event) ToNotification() *Notification {
 	return &Notification{
-		ID:       e.ID.String(),
-		Title:    e.Title,
-		UserID:   e.UserID,
-		Datetime: e.Datetime.Format(timeFormat),
+		ID:           e.ID.String(),
+		Title:        e.Title,
+		UserID:       e.UserID,
+		Datetime:     e.Datetime,
+		Duration:     e.Duration,
+		ReminderKind: ReminderBeforeStart,
 	}
 }
 
@@ -190,6 +219,13 @@ func (ed *EventData) ToDBEventData() *DBEventData {
 		UserID:      ed.UserID,
 		RemindIn:    NewDuration(ed.RemindIn),
 		IsNotified:  ed.IsNotified,
+		BusyStatus:  string(ed.BusyStatus),
+		AllDay:      ed.AllDay,
+		Tags:        Tags(ed.Tags),
+		Color:       ed.Color,
+		Location:    ed.Location,
+		URL:         ed.URL,
+		Resources:   Tags(ed.Resources),
 	}
 }
 
@@ -205,6 +241,13 @@ func (de *DBEvent) ToEvent() *Event {
 			UserID:      de.UserID,
 			RemindIn:    de.RemindIn.ToDuration(),
 			IsNotified:  de.IsNotified,
+			BusyStatus:  BusyStatus(de.BusyStatus),
+			AllDay:      de.AllDay,
+			Tags:        de.tags(),
+			Color:       de.Color,
+			Location:    de.Location,
+			URL:         de.URL,
+			Resources:   de.resources(),
 		},
 	}
 }
@@ -219,5 +262,28 @@ func (de *DBEventData) ToEventData() *EventData {
 		UserID:      de.UserID,
 		RemindIn:    de.RemindIn.ToDuration(),
 		IsNotified:  de.IsNotified,
+		BusyStatus:  BusyStatus(de.BusyStatus),
+		AllDay:      de.AllDay,
+		Tags:        de.tags(),
+		Color:       de.Color,
+		Location:    de.Location,
+		URL:         de.URL,
+		Resources:   de.resources(),
+	}
+}
+
+// tags returns the tags of DBEventData as a plain slice. Empty tags are returned as nil.
+func (de *DBEventData) tags() []string {
+	if len(de.Tags) == 0 {
+		return nil
+	}
+	return []string(de.Tags)
+}
+
+// resources returns the resources of DBEventData as a plain slice. Empty resources are returned as nil.
+func (de *DBEventData) resources() []string {
+	if len(de.Resources) == 0 {
+		return nil
 	}
+	return []string(de.Resources)
 }
@@ -7,15 +7,31 @@ import (
 	"github.com/google/uuid" //nolint:depguard,nolintlint
 )
 
-// timeFormat is the default time format used for marshalling/unmarshalling.
-const timeFormat = "02.01.2006 15:04:05.000"
+// ReminderKind represents the reason the notification is sent.
+type ReminderKind string
+
+// Possible values for ReminderKind.
+const (
+	// ReminderBeforeStart is a reminder sent remind_in before the event start.
+	ReminderBeforeStart ReminderKind = "before_start"
+	// ReminderDailyDigest is a digest of the user's agenda for the day, sent at the user-configured local time.
+	ReminderDailyDigest ReminderKind = "daily_digest"
+)
 
 // Notification contains the data of the notification.
+//
+// For the daily digest Datetime and Duration cover the day in the user's time zone,
+// and Agenda contains the events of the day.
 type Notification struct {
-	ID       string `db:"id" json:"id"`
-	Title    string `db:"title" json:"title"`
-	UserID   string `db:"user_id" json:"user_id"` //nolint:tagliatelle
-	Datetime string `db:"datetime" json:"datetime"`
+	ID           string
+	Title        string
+	UserID       string
+	Datetime     time.Time
+	Duration     time.Duration
+	ReminderKind ReminderKind
+	TimeZone     string          // Time zone of the digest day. Empty for the event reminders.
+	Agenda       []*Notification // Events of the digest day.
+	Late         bool            // The reminder is sent after the event start.
 }
 
 // GetID returns the UUID of the notification and nil on success.
@@ -27,13 +43,3 @@ func (n *Notification) GetID() (uuid.UUID, error) {
 	}
 	return parsedID, nil
 }
-
-// GetDatetime returns the datetime of the notification and nil on success.
-// Returns an error if the datetime is invalid.
-func (n *Notification) GetDatetime() (time.Time, error) {
-	parsedTime, err := time.Parse(timeFormat, n.Datetime)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid datetime format in notification: %w", err)
-	}
-	return parsedTime, nil
-}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+)
+
+// BusyStatus represents the availability of the user during the event.
+type BusyStatus string
+
+// Possible values for BusyStatus.
+const (
+	BusyStatusBusy        BusyStatus = "busy"
+	BusyStatusFree        BusyStatus = "free"
+	BusyStatusTentative   BusyStatus = "tentative"
+	BusyStatusOutOfOffice BusyStatus = "out-of-office"
+)
+
+// OverlapPolicy defines how overlapping events of the same user are treated by the storage.
+type OverlapPolicy string
+
+// Possible values for OverlapPolicy.
+const (
+	// OverlapPolicyReject rejects any overlapping events. It is the default policy.
+	OverlapPolicyReject OverlapPolicy = "reject"
+	// OverlapPolicyAllow allows any overlapping events.
+	OverlapPolicyAllow OverlapPolicy = "allow"
+	// OverlapPolicyAllowIfFree allows overlaps unless both events are marked as blocking (busy or out-of-office).
+	OverlapPolicyAllowIfFree OverlapPolicy = "allow-if-free"
+)
+
+// ParseBusyStatus validates the given string and converts it to BusyStatus.
+// Empty string is treated as BusyStatusBusy.
+//
+// Returns a wrapped ErrInvalidFieldData error on unknown values.
+func ParseBusyStatus(s string) (BusyStatus, error) {
+	switch status := BusyStatus(s); status {
+	case "":
+		return BusyStatusBusy, nil
+	case BusyStatusBusy, BusyStatusFree, BusyStatusTentative, BusyStatusOutOfOffice:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w: invalid=[busy_status] value=%q", projectErrors.ErrInvalidFieldData, s)
+	}
+}
+
+// IsBlocking reports whether the status makes the user unavailable for other events.
+// Empty status is considered as busy one for the compatibility with the events created before statuses appeared.
+func (b BusyStatus) IsBlocking() bool {
+	switch b {
+	case BusyStatusFree, BusyStatusTentative:
+		return false
+	default:
+		return true
+	}
+}
+
+// ParseOverlapPolicy validates the given string and converts it to OverlapPolicy.
+// Empty string is treated as OverlapPolicyReject.
+//
+// Returns a wrapped ErrInvalidFieldData error on unknown values.
+func ParseOverlapPolicy(s string) (OverlapPolicy, error) {
+	switch policy := OverlapPolicy(s); policy {
+	case "":
+		return OverlapPolicyReject, nil
+	case OverlapPolicyReject, OverlapPolicyAllow, OverlapPolicyAllowIfFree:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: invalid=[overlap_policy] value=%q", projectErrors.ErrInvalidFieldData, s)
+	}
+}
+
+// IsConflict reports whether the new event with the given status conflicts
+// with the overlapping existing one under the policy.
+func (p OverlapPolicy) IsConflict(newStatus, existingStatus BusyStatus) bool {
+	switch p {
+	case OverlapPolicyAllow:
+		return false
+	case OverlapPolicyAllowIfFree:
+		return newStatus.IsBlocking() && existingStatus.IsBlocking()
+	default:
+		return true
+	}
+}
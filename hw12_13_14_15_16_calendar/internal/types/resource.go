@@ -0,0 +1,180 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// ResourceKind is the kind of the bookable resource.
+type ResourceKind string
+
+// Possible values for ResourceKind.
+const (
+	ResourceKindRoom      ResourceKind = "room"      // Meeting room.
+	ResourceKindEquipment ResourceKind = "equipment" // Shared equipment, e.g. a projector.
+)
+
+// ParseResourceKind validates the given string and converts it to ResourceKind.
+// Empty string is treated as ResourceKindRoom.
+//
+// Returns a wrapped ErrInvalidFieldData error on unknown values.
+func ParseResourceKind(s string) (ResourceKind, error) {
+	switch kind := ResourceKind(strings.ToLower(strings.TrimSpace(s))); kind {
+	case "":
+		return ResourceKindRoom, nil
+	case ResourceKindRoom, ResourceKindEquipment:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("%w: invalid=[kind] value=%q", projectErrors.ErrInvalidFieldData, s)
+	}
+}
+
+// Resource is a meeting room or a piece of shared equipment, booked by attaching it to the events.
+//
+// Bookings of the same resource must not overlap regardless of the busy statuses and the overlap policies
+// of the events. Capacity is the number of seats, 0 means it is not applicable.
+// Attributes are normalized as tags, e.g. "projector" or "whiteboard".
+type Resource struct {
+	ID         uuid.UUID    `db:"id" json:"id"`
+	Name       string       `db:"name" json:"name"`
+	Kind       ResourceKind `db:"kind" json:"kind"`
+	Capacity   int          `db:"capacity" json:"capacity"`
+	Attributes Tags         `db:"attributes" json:"attributes"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"` //nolint:tagliatelle
+}
+
+// NewResource creates a new resource after validation.
+//
+// Returns a wrapped ErrEmptyField if the name is empty and a wrapped ErrInvalidFieldData
+// if the kind is unknown or the capacity is negative.
+func NewResource(name, kind string, capacity int, attributes []string) (*Resource, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: missing=[name]", projectErrors.ErrEmptyField)
+	}
+	resourceKind, err := ParseResourceKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if capacity < 0 {
+		return nil, fmt.Errorf("%w: invalid=[capacity]", projectErrors.ErrInvalidFieldData)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrGenerateID, err)
+	}
+	attributes = NormalizeTags(attributes)
+	if attributes == nil {
+		attributes = []string{}
+	}
+
+	return &Resource{
+		ID:         id,
+		Name:       name,
+		Kind:       resourceKind,
+		Capacity:   capacity,
+		Attributes: attributes,
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}, nil
+}
+
+// ResourceFilter contains optional filters for the resource queries.
+// Zero values mean no filtering by the corresponding field.
+type ResourceFilter struct {
+	IDs         []uuid.UUID  // Resource must be one of the given ones.
+	Kind        ResourceKind // Resource must be of the given kind.
+	MinCapacity int          // Resource must have at least the given capacity.
+	Attributes  []string     // Resource must have all of the given attributes.
+}
+
+// Match reports whether the resource satisfies the filter. Nil filter matches any resource.
+func (f *ResourceFilter) Match(r *Resource) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, r.ID) {
+		return false
+	}
+	if f.Kind != "" && f.Kind != r.Kind {
+		return false
+	}
+	if r.Capacity < f.MinCapacity {
+		return false
+	}
+	for _, attr := range f.Attributes {
+		if !slices.Contains(r.Attributes, attr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeResources validates the resource IDs attached to the event, converting them to the canonical form.
+// Returns the sorted deduplicated IDs or nil if no IDs are given.
+//
+// Returns a wrapped ErrInvalidFieldData error if any of the IDs is invalid.
+func NormalizeResources(ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parsed, err := uuid.Parse(strings.TrimSpace(id))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid=[resources] value=%q", projectErrors.ErrInvalidFieldData, id)
+		}
+		res = append(res, parsed.String())
+	}
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
+
+// SetResources validates and sets the resources booked by the event. See NormalizeResources.
+func (ed *EventData) SetResources(ids []string) error {
+	resources, err := NormalizeResources(ids)
+	if err != nil {
+		return err
+	}
+	ed.Resources = resources
+	return nil
+}
+
+// AddedResources returns the resources of the event data, which are not booked by the given previous version
+// of the event. Nil previous version means all resources are added.
+func (ed *EventData) AddedResources(prev *EventData) []string {
+	if prev == nil {
+		return ed.Resources
+	}
+	res := make([]string, 0, len(ed.Resources))
+	for _, id := range ed.Resources {
+		if !slices.Contains(prev.Resources, id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// ResourceBooking is the time interval of the resource, booked by the event.
+type ResourceBooking struct {
+	EventID uuid.UUID
+	Start   time.Time
+	End     time.Time
+}
+
+// ResourceAvailability is the availability of the resource within the requested period.
+// The resource is available if it has no bookings within the period.
+type ResourceAvailability struct {
+	Resource *Resource
+	Bookings []ResourceBooking // Sorted by the start time.
+}
+
+// Available reports whether the resource has no bookings within the requested period.
+func (ra *ResourceAvailability) Available() bool {
+	return len(ra.Bookings) == 0
+}
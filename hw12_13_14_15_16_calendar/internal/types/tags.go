@@ -0,0 +1,146 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/lib/pq"                                                                    //nolint:depguard,nolintlint
+)
+
+// reColor matches colors in #RRGGBB format.
+var reColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tags represents a list of event tags with SQL compatibility.
+type Tags []string
+
+// Scan implements the sql.Scanner interface for converting SQL array or JSON array values to Tags.
+func (t *Tags) Scan(value any) error {
+	var v string
+	switch val := value.(type) {
+	case string:
+		v = val
+	case []uint8:
+		v = string(val)
+	case nil:
+		*t = Tags{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported scan type for Tags: %T", value)
+	}
+
+	// JSON format (e.g., in dialects without array types).
+	if strings.HasPrefix(v, "[") {
+		var res []string
+		if err := json.Unmarshal([]byte(v), &res); err != nil {
+			return fmt.Errorf("scan tags: %w", err)
+		}
+		*t = res
+		return nil
+	}
+
+	var arr pq.StringArray
+	if err := arr.Scan(v); err != nil {
+		return fmt.Errorf("scan tags: %w", err)
+	}
+	*t = Tags(arr)
+	return nil
+}
+
+// Value implements the driver.Valuer interface for converting Tags to SQL array value.
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	return pq.StringArray(t).Value()
+}
+
+// NormalizeTags trims, lowercases and deduplicates the given tags, dropping the empty ones.
+// Returns the sorted slice of tags or nil if no tags left.
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			res = append(res, tag)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// SetDetails validates and sets the categorization details of the event data.
+//
+// Tags are normalized with NormalizeTags. Color must be empty or in #RRGGBB format.
+// Link must be empty or an absolute http(s) URL.
+//
+// Returns a wrapped ErrInvalidFieldData error if validation fails.
+func (ed *EventData) SetDetails(tags []string, color, location, link string) error {
+	invalid := make([]string, 0)
+	if color != "" && !reColor.MatchString(color) {
+		invalid = append(invalid, "color")
+	}
+	if link != "" {
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalid = append(invalid, "url")
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: invalid=%v", projectErrors.ErrInvalidFieldData, invalid)
+	}
+
+	ed.Tags = NormalizeTags(tags)
+	ed.Color = strings.ToLower(color)
+	ed.Location = strings.TrimSpace(location)
+	ed.URL = link
+	return nil
+}
+
+// EventFilter contains optional filters for the event queries.
+// Zero values mean no filtering by the corresponding field.
+type EventFilter struct {
+	Tags     []string // Events must have all of the given tags.
+	Location *string  // Events must have the given location, case-insensitive.
+}
+
+// NewEventFilter creates a new EventFilter with normalized values.
+// Returns nil if no filters are set.
+func NewEventFilter(tags []string, location *string) *EventFilter {
+	tags = NormalizeTags(tags)
+	if location != nil {
+		trimmed := strings.TrimSpace(*location)
+		location = &trimmed
+	}
+	if len(tags) == 0 && location == nil {
+		return nil
+	}
+	return &EventFilter{
+		Tags:     tags,
+		Location: location,
+	}
+}
+
+// Match reports whether the event data satisfies the filter. Nil filter matches any event.
+func (f *EventFilter) Match(ed *EventData) bool {
+	if f == nil {
+		return true
+	}
+	if f.Location != nil && !strings.EqualFold(*f.Location, ed.Location) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(ed.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
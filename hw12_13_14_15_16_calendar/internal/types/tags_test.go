@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// TestEventData_SetDetails tests the validation and normalization of the event categorization details.
+func TestEventData_SetDetails(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tags         []string
+		color        string
+		link         string
+		expectedTags []string
+		isErr        bool
+	}{
+		{
+			name:         "tags are normalized",
+			tags:         []string{" Work", "meeting", "work", ""},
+			color:        "#FFAA00",
+			link:         "https://example.com/meeting",
+			expectedTags: []string{"meeting", "work"},
+		},
+		{name: "empty details"},
+		{name: "invalid color", color: "red", isErr: true},
+		{name: "relative url", link: "/meeting", isErr: true},
+		{name: "unsupported url scheme", link: "ftp://example.com", isErr: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			data := &EventData{}
+			err := data.SetDetails(tC.tags, tC.color, " Room 1 ", tC.link)
+			if tC.isErr {
+				require.ErrorIs(t, err, projectErrors.ErrInvalidFieldData, "unexpected error type")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+			require.Equal(t, tC.expectedTags, data.Tags, "unexpected tags")
+			require.Equal(t, "Room 1", data.Location, "unexpected location")
+		})
+	}
+}
+
+// TestEventFilter_Match tests the event filter matching.
+func TestEventFilter_Match(t *testing.T) {
+	data := &EventData{Tags: []string{"meeting", "work"}, Location: "Room 1"}
+	location := " room 1 "
+	otherLocation := "Room 2"
+
+	testCases := []struct {
+		name     string
+		filter   *EventFilter
+		expected bool
+	}{
+		{"nil filter", NewEventFilter(nil, nil), true},
+		{"subset of tags", NewEventFilter([]string{"WORK"}, nil), true},
+		{"missing tag", NewEventFilter([]string{"work", "personal"}, nil), false},
+		{"location", NewEventFilter(nil, &location), true},
+		{"other location", NewEventFilter([]string{"work"}, &otherLocation), false},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, tC.filter.Match(data), "unexpected match result")
+		})
+	}
+}
+
+// TestTags_Scan tests the conversion of SQL values to Tags.
+func TestTags_Scan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    any
+		expected Tags
+	}{
+		{"postgres array", []byte("{meeting,work}"), Tags{"meeting", "work"}},
+		{"json array", `["meeting","work"]`, Tags{"meeting", "work"}},
+		{"null", nil, Tags{}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			var tags Tags
+			require.NoError(t, tags.Scan(tC.value), "expected nil, got error")
+			require.Equal(t, tC.expected, tags, "unexpected tags")
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package types
+
+import "fmt"
+
+// TemplateFormat represents the format of the notification template.
+type TemplateFormat string
+
+// Possible values for TemplateFormat.
+const (
+	// TemplateText is a plain text template, rendered with text/template.
+	TemplateText TemplateFormat = "text"
+	// TemplateHTML is an HTML template, rendered with html/template, so the event data is escaped.
+	TemplateHTML TemplateFormat = "html"
+)
+
+// ParseTemplateFormat parses the template format. Empty string is treated as TemplateText.
+func ParseTemplateFormat(s string) (TemplateFormat, error) {
+	switch TemplateFormat(s) {
+	case "", TemplateText:
+		return TemplateText, nil
+	case TemplateHTML:
+		return TemplateHTML, nil
+	default:
+		return "", fmt.Errorf("invalid template format %q: expected %q or %q", s, TemplateText, TemplateHTML)
+	}
+}
+
+// TemplateKind represents the kind of the notifications the template is used for.
+type TemplateKind string
+
+// Possible values for TemplateKind.
+const (
+	// TemplateReminder is a template of the event reminders.
+	TemplateReminder TemplateKind = "reminder"
+	// TemplateDigest is a template of the daily agenda digests.
+	TemplateDigest TemplateKind = "digest"
+)
+
+// ParseTemplateKind parses the template kind. Empty string is treated as TemplateReminder.
+func ParseTemplateKind(s string) (TemplateKind, error) {
+	switch TemplateKind(s) {
+	case "", TemplateReminder:
+		return TemplateReminder, nil
+	case TemplateDigest:
+		return TemplateDigest, nil
+	default:
+		return "", fmt.Errorf("invalid template kind %q: expected %q or %q", s, TemplateReminder, TemplateDigest)
+	}
+}
+
+// TemplateKindOf returns the kind of the templates used for the notifications of the given reminder kind.
+func TemplateKindOf(kind ReminderKind) TemplateKind {
+	if kind == ReminderDailyDigest {
+		return TemplateDigest
+	}
+	return TemplateReminder
+}
+
+// NotificationTemplate is a template of the notification message for the given kind, channel and locale.
+// Empty kind is treated as TemplateReminder.
+type NotificationTemplate struct {
+	Kind    TemplateKind   `db:"kind"`
+	Channel string         `db:"channel"`
+	Locale  string         `db:"locale"`
+	Format  TemplateFormat `db:"format"`
+	Body    string         `db:"body"`
+}
+
+// UserSettings are the user preferences used on notification rendering. Empty values mean the defaults.
+type UserSettings struct {
+	UserID   string `db:"user_id" json:"-"`
+	Locale   string `db:"locale" json:"locale,omitempty"`
+	TimeZone string `db:"time_zone" json:"time_zone,omitempty"` //nolint:tagliatelle
+}
+
+// Message is the notification rendered for the given channel.
+type Message struct {
+	Channel  string
+	Locale   string // Locale of the template used.
+	TimeZone string // Time zone the event time is formatted in.
+	Format   TemplateFormat
+	Body     string
+}
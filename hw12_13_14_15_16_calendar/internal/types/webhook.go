@@ -0,0 +1,132 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// WebhookEventType is the type of the calendar change delivered to the webhook subscriptions.
+type WebhookEventType string
+
+// Webhook event types.
+const (
+	WebhookEventCreated  WebhookEventType = "created"  // Event is created.
+	WebhookEventUpdated  WebhookEventType = "updated"  // Event is updated.
+	WebhookEventDeleted  WebhookEventType = "deleted"  // Event is deleted.
+	WebhookEventReminder WebhookEventType = "reminder" // Reminder of the event is sent by the scheduler.
+)
+
+// webhookSecretSize is the size of the generated webhook secrets in bytes.
+const webhookSecretSize = 32
+
+// MaxWebhookDeliveries is the number of the latest delivery attempts kept in the log of each subscription.
+// Older attempts are dropped by the storages on the new ones.
+const MaxWebhookDeliveries = 100
+
+// WebhookEventTypes returns all webhook event types.
+func WebhookEventTypes() []string {
+	return []string{
+		string(WebhookEventCreated),
+		string(WebhookEventDeleted),
+		string(WebhookEventReminder),
+		string(WebhookEventUpdated),
+	}
+}
+
+// Webhook is a subscription of a third-party integration to the calendar changes.
+//
+// Failures is the number of consecutive failed deliveries. The subscription is disabled after too many of them
+// and is enabled back explicitly.
+type Webhook struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	UserID     string    `db:"user_id" json:"user_id,omitempty"` //nolint:tagliatelle // Empty means all users.
+	URL        string    `db:"url" json:"url"`
+	Secret     string    `db:"secret" json:"secret"`
+	EventTypes Tags      `db:"event_types" json:"event_types"` //nolint:tagliatelle
+	Enabled    bool      `db:"enabled" json:"enabled"`
+	Failures   int       `db:"failures" json:"failures"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"` //nolint:tagliatelle
+}
+
+// NewWebhook creates a new enabled webhook subscription after validation.
+//
+// The URL must be an absolute http(s) URL. Empty user ID subscribes to the changes of all users.
+// Event types are normalized, empty list means all types. Empty secret is generated.
+//
+// Returns a wrapped ErrEmptyField if the URL is empty and a wrapped ErrInvalidFieldData
+// if the URL or any of the event types is invalid.
+func NewWebhook(userID, rawURL, secret string, eventTypes []string) (*Webhook, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, fmt.Errorf("%w: [url]", projectErrors.ErrEmptyField)
+	}
+
+	invalid := make([]string, 0)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid = append(invalid, "url")
+	}
+	eventTypes = NormalizeTags(eventTypes)
+	for _, eventType := range eventTypes {
+		if !slices.Contains(WebhookEventTypes(), eventType) {
+			invalid = append(invalid, "event_types")
+			break
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("%w: invalid=%v", projectErrors.ErrInvalidFieldData, invalid)
+	}
+	if len(eventTypes) == 0 {
+		eventTypes = WebhookEventTypes()
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrGenerateID, err)
+	}
+	if secret == "" {
+		data := make([]byte, webhookSecretSize)
+		if _, err := rand.Read(data); err != nil {
+			return nil, fmt.Errorf("generate webhook secret: %w", err)
+		}
+		secret = hex.EncodeToString(data)
+	}
+
+	return &Webhook{
+		ID:         id,
+		UserID:     strings.TrimSpace(userID),
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Enabled:    true,
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}, nil
+}
+
+// Accepts reports whether the enabled subscription receives the changes of the given type of the user's events.
+func (w *Webhook) Accepts(eventType WebhookEventType, userID string) bool {
+	return w.Enabled && (w.UserID == "" || w.UserID == userID) && slices.Contains(w.EventTypes, string(eventType))
+}
+
+// WebhookDelivery is an attempt to deliver the calendar change to the webhook subscription.
+//
+// Attempts of the same delivery share the delivery ID. StatusCode is 0 if no response was received.
+type WebhookDelivery struct {
+	DeliveryID uuid.UUID `db:"delivery_id" json:"delivery_id"` //nolint:tagliatelle
+	Attempt    int       `db:"attempt" json:"attempt"`
+	WebhookID  uuid.UUID `db:"webhook_id" json:"webhook_id"`   //nolint:tagliatelle
+	EventType  string    `db:"event_type" json:"event_type"`   //nolint:tagliatelle
+	EventID    uuid.UUID `db:"event_id" json:"event_id"`       //nolint:tagliatelle
+	StatusCode int       `db:"status_code" json:"status_code"` //nolint:tagliatelle
+	Error      string    `db:"error" json:"error,omitempty"`
+	Success    bool      `db:"success" json:"success"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"` //nolint:tagliatelle
+}
@@ -0,0 +1,24 @@
+package webhook
+
+import "time"
+
+// optionalFields is a map of optional configuration fields and their default values.
+var optionalFields = map[string]any{
+	"enabled":      false,
+	"workers":      int(0),
+	"queue_size":   int(0),
+	"timeout":      time.Duration(0),
+	"retries":      int(0),
+	"backoff":      time.Duration(0),
+	"max_backoff":  time.Duration(0),
+	"max_failures": int(0),
+}
+
+// Defaults of the zero valued settings.
+const (
+	defaultWorkers    = 1
+	defaultQueueSize  = 100
+	defaultTimeout    = 10 * time.Second
+	defaultBackoff    = time.Second
+	defaultMaxBackoff = time.Minute
+)
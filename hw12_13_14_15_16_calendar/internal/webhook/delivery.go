@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                //nolint:depguard,nolintlint
+)
+
+// Headers of the delivery requests.
+const (
+	HeaderEvent     = "X-Webhook-Event"     // Type of the change.
+	HeaderDelivery  = "X-Webhook-Delivery"  // Delivery ID, shared by all attempts.
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix time of the attempt in seconds.
+	HeaderSignature = "X-Webhook-Signature" // Signature of the attempt, see Sign.
+)
+
+// maxResponseSize limits the size of the response body read to reuse the connection.
+const maxResponseSize = 64 << 10
+
+// Payload is the JSON body of the delivery request.
+type Payload struct {
+	DeliveryID uuid.UUID `json:"delivery_id"` //nolint:tagliatelle
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"` //nolint:tagliatelle
+	Event      *Event    `json:"event"`
+}
+
+// Event is the calendar event delivered to the subscriptions. Duration is a Go duration string.
+// Late is set for the reminders sent after the event start.
+type Event struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Datetime    time.Time `json:"datetime"`
+	Duration    string    `json:"duration"`
+	Description string    `json:"description,omitempty"`
+	UserID      string    `json:"user_id"`               //nolint:tagliatelle
+	BusyStatus  string    `json:"busy_status,omitempty"` //nolint:tagliatelle
+	AllDay      bool      `json:"all_day,omitempty"`     //nolint:tagliatelle
+	Tags        []string  `json:"tags,omitempty"`
+	Resources   []string  `json:"resources,omitempty"`
+	Color       string    `json:"color,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	Late        bool      `json:"late,omitempty"`
+}
+
+// newEvent converts the calendar event to the delivered one.
+func newEvent(event *types.Event) *Event {
+	return &Event{
+		ID:          event.ID,
+		Title:       event.Title,
+		Datetime:    event.Datetime.UTC(),
+		Duration:    event.Duration.String(),
+		Description: event.Description,
+		UserID:      event.UserID,
+		BusyStatus:  string(event.BusyStatus),
+		AllDay:      event.AllDay,
+		Tags:        event.Tags,
+		Resources:   event.Resources,
+		Color:       event.Color,
+		Location:    event.Location,
+		URL:         event.URL,
+	}
+}
+
+// newReminderEvent converts the reminder notification to the delivered event.
+func newReminderEvent(n *types.Notification) (*Event, error) {
+	id, err := n.GetID()
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		ID:       id,
+		Title:    n.Title,
+		Datetime: n.Datetime.UTC(),
+		Duration: n.Duration.String(),
+		UserID:   n.UserID,
+		Late:     n.Late,
+	}, nil
+}
+
+// Sign returns the signature of the delivery request: "sha256=" followed by the hex encoded
+// HMAC-SHA256 of the timestamp, a dot and the body, keyed by the subscription secret.
+//
+// Receivers should compare the signature in constant time and reject the requests with outdated timestamps.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver sends the change to the subscription, retrying with exponential backoff.
+// Each attempt is saved to the delivery log. Returns true if any attempt succeeds.
+func (d *Dispatcher) deliver(ctx context.Context, webhook *types.Webhook, j *job) bool {
+	deliveryID, err := uuid.NewRandom()
+	if err != nil {
+		d.l.Error(ctx, "generate delivery id", slog.Any("error", err))
+		return false
+	}
+	body, err := json.Marshal(&Payload{
+		DeliveryID: deliveryID,
+		Type:       string(j.eventType),
+		OccurredAt: j.occurredAt,
+		Event:      j.event,
+	})
+	if err != nil {
+		d.l.Error(ctx, "marshal webhook payload", slog.Any("error", err))
+		return false
+	}
+
+	for attempt := 1; attempt <= d.retries+1; attempt++ {
+		if attempt > 1 && !sleep(ctx, d.backoffFor(attempt-1)) {
+			return false
+		}
+
+		statusCode, sendErr := d.send(ctx, webhook, deliveryID, j.eventType, body)
+		// Shutdown interrupted the attempt, it is not logged.
+		if ctx.Err() != nil {
+			return false
+		}
+
+		delivery := &types.WebhookDelivery{
+			DeliveryID: deliveryID,
+			Attempt:    attempt,
+			WebhookID:  webhook.ID,
+			EventType:  string(j.eventType),
+			EventID:    j.event.ID,
+			StatusCode: statusCode,
+			Success:    sendErr == nil,
+			CreatedAt:  d.now().UTC().Truncate(time.Microsecond),
+		}
+		if sendErr != nil {
+			delivery.Error = sendErr.Error()
+		}
+		if err := d.s.AddWebhookDelivery(ctx, delivery); err != nil {
+			d.l.Error(ctx, "add webhook delivery", slog.String("id", webhook.ID.String()), slog.Any("error", err))
+		}
+
+		if sendErr == nil {
+			d.l.Debug(ctx, "webhook delivered",
+				slog.String("id", webhook.ID.String()),
+				slog.String("delivery_id", deliveryID.String()),
+				slog.Int("attempt", attempt),
+			)
+			return true
+		}
+		d.l.Debug(ctx, "webhook delivery failed",
+			slog.String("id", webhook.ID.String()),
+			slog.String("delivery_id", deliveryID.String()),
+			slog.Int("attempt", attempt),
+			slog.Any("error", sendErr),
+		)
+	}
+
+	d.l.Warn(ctx, "webhook delivery failed, retries exceeded",
+		slog.String("id", webhook.ID.String()),
+		slog.String("delivery_id", deliveryID.String()),
+	)
+	return false
+}
+
+// send makes a single signed delivery request. Any response other than 2xx is an error.
+// Returns the response status code, 0 if no response was received.
+func (d *Dispatcher) send(
+	ctx context.Context,
+	webhook *types.Webhook,
+	deliveryID uuid.UUID,
+	eventType types.WebhookEventType,
+	body []byte,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(eventType))
+	req.Header.Set(HeaderDelivery, deliveryID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoffFor returns the delay before the given retry: the backoff doubled on each retry, limited by max_backoff.
+func (d *Dispatcher) backoffFor(retry int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < retry && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxBackoff)
+}
+
+// sleep waits for the given duration. Returns false if the context is canceled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
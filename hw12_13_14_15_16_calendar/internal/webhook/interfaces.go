@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types" //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                //nolint:depguard,nolintlint
+)
+
+// Storage represents a storage of the webhook subscriptions and their delivery log.
+type Storage interface {
+	// GetWebhooks retrieves all webhook subscriptions.
+	// Returns a slice of subscriptions, which is empty if there are none, or an error if the operation fails.
+	GetWebhooks(ctx context.Context) ([]*types.Webhook, error)
+
+	// GetWebhook retrieves the webhook subscription by ID.
+	// Returns the subscription or an error if not found or the operation fails.
+	GetWebhook(ctx context.Context, id uuid.UUID) (*types.Webhook, error)
+
+	// UpdateWebhookState sets whether the subscription is enabled and its number of consecutive failures.
+	// Returns an error if the subscription is not found or the operation fails.
+	UpdateWebhookState(ctx context.Context, id uuid.UUID, enabled bool, failures int) error
+
+	// AddWebhookDelivery appends the delivery attempt to the delivery log.
+	// Returns an error if the operation fails.
+	AddWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error
+}
+
+// Logger represents an interface of logger visible to the dispatcher.
+type Logger interface {
+	// Info logs a message with level Info on the standard logger.
+	Info(ctx context.Context, msg string, args ...any)
+	// Debug logs a message with level Debug on the standard logger.
+	Debug(ctx context.Context, msg string, args ...any)
+	// Warn logs a message with level Warn on the standard logger.
+	Warn(ctx context.Context, msg string, args ...any)
+	// Error logs a message with level Error on the standard logger.
+	Error(ctx context.Context, msg string, args ...any)
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import "reflect"
+
+// validateFields returns missing and wrong type fields found in args.
+// requiredFields is a map of field names with their expected types.
+func validateFields(args map[string]any, requiredFields map[string]any) ([]string, []string) {
+	var missing []string
+	var wrongType []string
+
+	for field, expectedVal := range requiredFields {
+		val, exists := args[field]
+		if !exists {
+			missing = append(missing, field)
+			continue
+		}
+
+		expectedReflect := reflect.TypeOf(expectedVal)
+		valueReflect := reflect.TypeOf(val)
+
+		// Default type switch will end up with false positive results.
+		// E.g., 123.(string) -> ok.
+		if expectedReflect != valueReflect {
+			wrongType = append(wrongType, field)
+		}
+	}
+
+	return missing, wrongType
+}
+
+// validateOptionalFields returns wrong type fields found in args.
+// optionalFields is a map of field names with their expected types. Missing fields are skipped.
+func validateOptionalFields(args map[string]any, optionalFields map[string]any) []string {
+	present := make(map[string]any, len(optionalFields))
+	for field, expectedVal := range optionalFields {
+		if _, exists := args[field]; exists {
+			present[field] = expectedVal
+		}
+	}
+
+	_, wrongType := validateFields(args, present)
+	return wrongType
+}
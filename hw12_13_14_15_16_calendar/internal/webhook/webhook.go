@@ -0,0 +1,314 @@
+// Package webhook provides a dispatcher, which delivers the calendar changes to the webhook subscriptions
+// of third-party integrations.
+//
+// Changes are queued in memory and delivered by a pool of workers as signed HTTP POST requests.
+// Each worker delivers the change to all matching subscriptions concurrently.
+// Failed deliveries are retried with exponential backoff, each attempt is saved to the delivery log.
+// Subscriptions are disabled after too many consecutive failed deliveries.
+// Queued changes are lost on restart.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	pkgConfig "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config"          //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+)
+
+// Dispatcher delivers the calendar changes to the webhook subscriptions.
+type Dispatcher struct {
+	wg     sync.WaitGroup
+	l      Logger
+	s      Storage
+	client *http.Client
+	queue  chan *job
+
+	enabled     bool
+	workers     int
+	timeout     time.Duration
+	retries     int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxFailures int // 0 means subscriptions are never disabled.
+
+	stateMu sync.Mutex // Serializes the updates of the subscriptions failures.
+	now     func() time.Time
+}
+
+// job is a calendar change waiting for the delivery.
+type job struct {
+	eventType  types.WebhookEventType
+	userID     string
+	occurredAt time.Time
+	event      *Event
+}
+
+// NewDispatcher creates a new dispatcher after arguments validation. No workers are started upon the call.
+func NewDispatcher(logger Logger, storage Storage, config map[string]any) (*Dispatcher, error) {
+	// Args validation.
+	missing := make([]string, 0)
+	if logger == nil {
+		missing = append(missing, "logger")
+	}
+	if storage == nil {
+		missing = append(missing, "storage")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: some of the required parameters are missing: args=%v",
+			projectErrors.ErrAppInitFailed, missing)
+	}
+
+	st, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dispatcher{
+		l: logger,
+		s: storage,
+		client: &http.Client{
+			// Redirects are treated as failures, since POST requests are turned into GET on redirect.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		queue:       make(chan *job, st.queueSize),
+		enabled:     st.enabled,
+		workers:     st.workers,
+		timeout:     st.timeout,
+		retries:     st.retries,
+		backoff:     st.backoff,
+		maxBackoff:  st.maxBackoff,
+		maxFailures: st.maxFailures,
+		now:         time.Now,
+	}, nil
+}
+
+// ValidateConfig validates the webhooks config without creating a dispatcher.
+// Problems are returned as *config.ValidationError wrapped with ErrCorruptedConfig.
+func ValidateConfig(config map[string]any) error {
+	_, err := parseConfig(config)
+	return err
+}
+
+// settings are the dispatcher settings parsed from the config.
+type settings struct {
+	enabled     bool
+	workers     int
+	queueSize   int
+	timeout     time.Duration
+	retries     int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxFailures int
+}
+
+// parseConfig validates and extracts the dispatcher settings from the config, accumulating all problems.
+// Zero values are replaced with the defaults, except for retries and max_failures.
+func parseConfig(config map[string]any) (*settings, error) {
+	if config == nil {
+		return nil, fmt.Errorf("%w: no webhooks configuration received", projectErrors.ErrCorruptedConfig)
+	}
+
+	ve := &pkgConfig.ValidationError{}
+	// Optional fields are zero valued if missing.
+	ve.InvalidType = validateOptionalFields(config, optionalFields)
+
+	st := &settings{}
+	st.enabled, _ = config["enabled"].(bool)
+	ints := map[string]*int{
+		"workers":      &st.workers,
+		"queue_size":   &st.queueSize,
+		"retries":      &st.retries,
+		"max_failures": &st.maxFailures,
+	}
+	for key, value := range ints {
+		*value, _ = config[key].(int)
+		if *value < 0 {
+			ve.InvalidValue = append(ve.InvalidValue, key)
+		}
+	}
+	durations := map[string]*time.Duration{
+		"timeout":     &st.timeout,
+		"backoff":     &st.backoff,
+		"max_backoff": &st.maxBackoff,
+	}
+	for key, value := range durations {
+		*value, _ = config[key].(time.Duration)
+		if *value < 0 {
+			ve.InvalidValue = append(ve.InvalidValue, key)
+		}
+	}
+	if ve.HasErrors() {
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
+	}
+
+	st.workers = withDefault(st.workers, defaultWorkers)
+	st.queueSize = withDefault(st.queueSize, defaultQueueSize)
+	st.timeout = withDefault(st.timeout, defaultTimeout)
+	st.backoff = withDefault(st.backoff, defaultBackoff)
+	st.maxBackoff = withDefault(st.maxBackoff, max(defaultMaxBackoff, st.backoff))
+	if st.maxBackoff < st.backoff {
+		ve.InvalidValue = append(ve.InvalidValue, "max_backoff")
+		return nil, fmt.Errorf("%w: %w", projectErrors.ErrCorruptedConfig, ve)
+	}
+	return st, nil
+}
+
+// withDefault returns the default value if the value is zero.
+func withDefault[T int | time.Duration](value, defaultValue T) T {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// Enabled reports whether the deliveries are enabled by the config.
+// Changes are still queued by the disabled dispatcher, if it is started.
+func (d *Dispatcher) Enabled() bool {
+	return d.enabled
+}
+
+// Start starts the workers, which deliver the queued changes until the context is canceled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	for range d.workers {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-d.queue:
+					d.handle(ctx, j)
+				}
+			}
+		}()
+	}
+	d.l.Info(ctx, "webhook dispatcher started", slog.Int("workers", d.workers))
+}
+
+// Wait waits for the workers to finish after the context of Start is canceled.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// NotifyEvent queues the delivery of the event change to the matching subscriptions.
+// The method does not block: the change is dropped with a warning if the queue is full.
+func (d *Dispatcher) NotifyEvent(ctx context.Context, eventType types.WebhookEventType, event *types.Event) {
+	if event == nil {
+		return
+	}
+	d.enqueue(ctx, &job{
+		eventType:  eventType,
+		userID:     event.UserID,
+		occurredAt: d.now().UTC(),
+		event:      newEvent(event),
+	})
+}
+
+// NotifyReminder queues the delivery of the sent event reminder to the matching subscriptions.
+// The method does not block: the reminder is dropped with a warning if the queue is full.
+func (d *Dispatcher) NotifyReminder(ctx context.Context, n *types.Notification) {
+	if n == nil {
+		return
+	}
+	event, err := newReminderEvent(n)
+	if err != nil {
+		d.l.Warn(ctx, "skip reminder webhook", slog.String("id", n.ID), slog.Any("error", err))
+		return
+	}
+	d.enqueue(ctx, &job{
+		eventType:  types.WebhookEventReminder,
+		userID:     n.UserID,
+		occurredAt: d.now().UTC(),
+		event:      event,
+	})
+}
+
+// enqueue adds the job to the queue without blocking.
+func (d *Dispatcher) enqueue(ctx context.Context, j *job) {
+	select {
+	case d.queue <- j:
+	default:
+		d.l.Warn(ctx, "webhook queue is full, change dropped",
+			slog.String("type", string(j.eventType)),
+			slog.String("event_id", j.event.ID.String()),
+		)
+	}
+}
+
+// handle delivers the change to all matching subscriptions concurrently and updates their failures,
+// so the retries of the failing subscription do not delay the deliveries to the others.
+func (d *Dispatcher) handle(ctx context.Context, j *job) {
+	webhooks, err := d.s.GetWebhooks(ctx)
+	if err != nil {
+		d.l.Error(ctx, "get webhooks", slog.Any("error", err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, webhook := range webhooks {
+		if !webhook.Accepts(j.eventType, j.userID) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			success := d.deliver(ctx, webhook, j)
+			// Interrupted deliveries are not counted as failures.
+			if ctx.Err() != nil {
+				return
+			}
+			d.updateState(ctx, webhook.ID, success)
+		}()
+	}
+	wg.Wait()
+}
+
+// updateState resets the failures of the subscription on success. Otherwise, it increments the failures,
+// disabling the subscription once they reach the limit.
+func (d *Dispatcher) updateState(ctx context.Context, id uuid.UUID, success bool) {
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+
+	// The subscription is re-read, as it might be changed or deleted during the delivery.
+	webhook, err := d.s.GetWebhook(ctx, id)
+	if err != nil {
+		if !errors.Is(err, projectErrors.ErrWebhookNotFound) {
+			d.l.Error(ctx, "get webhook", slog.String("id", id.String()), slog.Any("error", err))
+		}
+		return
+	}
+
+	enabled, failures := webhook.Enabled, 0
+	if !success {
+		failures = webhook.Failures + 1
+		if d.maxFailures > 0 && failures >= d.maxFailures {
+			enabled = false
+		}
+	}
+	if enabled == webhook.Enabled && failures == webhook.Failures {
+		return
+	}
+
+	if err := d.s.UpdateWebhookState(ctx, id, enabled, failures); err != nil {
+		d.l.Error(ctx, "update webhook state", slog.String("id", id.String()), slog.Any("error", err))
+		return
+	}
+	if webhook.Enabled && !enabled {
+		d.l.Warn(ctx, "webhook disabled after repeated failures",
+			slog.String("id", id.String()),
+			slog.String("url", webhook.URL),
+			slog.Int("failures", failures),
+		)
+	}
+}
@@ -0,0 +1,357 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	projectErrors "github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/errors" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/storage/memory"       //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/internal/types"                //nolint:depguard,nolintlint
+	"github.com/google/uuid"                                                               //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/assert"                                                   //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                                                  //nolint:depguard,nolintlint
+)
+
+// discardLogger is a logger, which drops all messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+// receiver is a test webhook endpoint, which verifies the signatures and records the payloads.
+type receiver struct {
+	t        *testing.T
+	secret   string
+	statuses []int // Response statuses of the consecutive requests, the last one is repeated.
+
+	mu       sync.Mutex
+	payloads []*Payload
+	requests atomic.Int32
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Assertions do not stop the handler goroutine, so the failed requests are answered with 400.
+	n := int(rc.requests.Add(1))
+	body, err := io.ReadAll(r.Body)
+	timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	var payload Payload
+	if !assert.NoError(rc.t, err, "failed to read body") ||
+		!assert.Equal(rc.t, Sign(rc.secret, timestamp, body), r.Header.Get(HeaderSignature), "invalid signature") ||
+		!assert.NoError(rc.t, json.Unmarshal(body, &payload), "invalid payload") ||
+		!assert.Equal(rc.t, payload.Type, r.Header.Get(HeaderEvent), "event header does not match") ||
+		!assert.Equal(rc.t, payload.DeliveryID.String(), r.Header.Get(HeaderDelivery), "delivery header does not match") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rc.mu.Lock()
+	rc.payloads = append(rc.payloads, &payload)
+	rc.mu.Unlock()
+
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status = rc.statuses[min(n, len(rc.statuses))-1]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) received() []*Payload {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]*Payload(nil), rc.payloads...)
+}
+
+// newTestDispatcher creates a started dispatcher with the memory storage and fast retries.
+func newTestDispatcher(t *testing.T, config map[string]any) (*Dispatcher, *memory.Storage) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s, err := memory.NewStorage(0)
+	require.NoError(t, err, "failed to create storage")
+	require.NoError(t, s.Connect(ctx), "failed to connect storage")
+
+	cfg := map[string]any{
+		"enabled":     true,
+		"workers":     2,
+		"timeout":     time.Second,
+		"retries":     2,
+		"backoff":     time.Millisecond,
+		"max_backoff": 5 * time.Millisecond,
+	}
+	for key, value := range config {
+		cfg[key] = value
+	}
+	d, err := NewDispatcher(discardLogger{}, s, cfg)
+	require.NoError(t, err, "failed to create dispatcher")
+	d.Start(ctx)
+
+	t.Cleanup(func() {
+		cancel()
+		d.Wait()
+		s.Close(context.Background())
+	})
+	return d, s
+}
+
+// newTestWebhook registers the webhook subscription for the receiver.
+func newTestWebhook(t *testing.T, s *memory.Storage, rc *receiver, userID string, eventTypes ...string) *types.Webhook {
+	t.Helper()
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	webhook, err := types.NewWebhook(userID, server.URL, rc.secret, eventTypes)
+	require.NoError(t, err, "failed to create webhook")
+	_, err = s.CreateWebhook(context.Background(), webhook)
+	require.NoError(t, err, "failed to save webhook")
+	return webhook
+}
+
+func newTestEvent(t *testing.T, userID string) *types.Event {
+	t.Helper()
+	event, err := types.NewEvent("Event", time.Date(2030, 1, 16, 10, 0, 0, 0, time.UTC), time.Hour,
+		"Description", userID, 0)
+	require.NoError(t, err, "failed to create event")
+	return event
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"created"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`1700000000.{"type":"created"}`))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	require.Equal(t, expected, Sign("secret", 1700000000, body))
+	require.NotEqual(t, expected, Sign("other", 1700000000, body), "secret is not used")
+	require.NotEqual(t, expected, Sign("secret", 1700000001, body), "timestamp is not used")
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      map[string]any
+		expectedErr error
+	}{
+		{"empty", map[string]any{}, nil},
+		{"all options", map[string]any{
+			"enabled": true, "workers": 4, "queue_size": 1000, "timeout": 5 * time.Second, "retries": 3,
+			"backoff": time.Second, "max_backoff": time.Minute, "max_failures": 10,
+		}, nil},
+		{"nil", nil, projectErrors.ErrCorruptedConfig},
+		{"wrong type", map[string]any{"workers": "4"}, projectErrors.ErrCorruptedConfig},
+		{"negative value", map[string]any{"retries": -1}, projectErrors.ErrCorruptedConfig},
+		{"negative duration", map[string]any{"timeout": -time.Second}, projectErrors.ErrCorruptedConfig},
+		{"max backoff below backoff", map[string]any{
+			"backoff": time.Minute, "max_backoff": time.Second,
+		}, projectErrors.ErrCorruptedConfig},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			err := ValidateConfig(tC.config)
+			if tC.expectedErr != nil {
+				require.ErrorIs(t, err, tC.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := &Dispatcher{backoff: time.Second, maxBackoff: 5 * time.Second}
+	delays := make([]time.Duration, 0, 5)
+	for retry := 1; retry <= 5; retry++ {
+		delays = append(delays, d.backoffFor(retry))
+	}
+	require.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+	}, delays)
+}
+
+func TestDispatcher_Delivery(t *testing.T) {
+	ctx := context.Background()
+	d, s := newTestDispatcher(t, nil)
+
+	user := &receiver{t: t, secret: "user-secret"}
+	all := &receiver{t: t, secret: "all-secret"}
+	userWebhook := newTestWebhook(t, s, user, "user1", "created", "reminder")
+	newTestWebhook(t, s, all, "", "deleted")
+
+	event := newTestEvent(t, "user1")
+	d.NotifyEvent(ctx, types.WebhookEventCreated, event)
+	d.NotifyEvent(ctx, types.WebhookEventUpdated, event)
+	d.NotifyEvent(ctx, types.WebhookEventDeleted, newTestEvent(t, "user2"))
+	d.NotifyReminder(ctx, &types.Notification{
+		ID: event.ID.String(), Title: event.Title, UserID: "user1", Datetime: event.Datetime,
+		Duration: event.Duration, Late: true,
+	})
+
+	require.Eventually(t, func() bool {
+		return len(user.received()) == 2 && len(all.received()) == 1
+	}, 5*time.Second, 10*time.Millisecond, "changes are not delivered")
+
+	byType := make(map[string]*Payload)
+	for _, payload := range user.received() {
+		byType[payload.Type] = payload
+	}
+	require.Contains(t, byType, "created", "created event is not delivered")
+	require.Equal(t, event.ID, byType["created"].Event.ID)
+	require.Equal(t, "1h0m0s", byType["created"].Event.Duration)
+	require.Contains(t, byType, "reminder", "reminder is not delivered")
+	require.True(t, byType["reminder"].Event.Late, "late marker is not delivered")
+	require.Equal(t, "deleted", all.received()[0].Type, "unexpected change of the other user")
+
+	require.Eventually(t, func() bool {
+		deliveries, err := s.GetWebhookDeliveries(ctx, userWebhook.ID, 0)
+		return err == nil && len(deliveries) == 2
+	}, 5*time.Second, 10*time.Millisecond, "deliveries are not logged")
+	deliveries, err := s.GetWebhookDeliveries(ctx, userWebhook.ID, 0)
+	require.NoError(t, err)
+	for _, delivery := range deliveries {
+		require.True(t, delivery.Success, "delivery is not successful")
+		require.Equal(t, http.StatusOK, delivery.StatusCode)
+		require.Equal(t, 1, delivery.Attempt)
+		require.Equal(t, event.ID, delivery.EventID)
+	}
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	ctx := context.Background()
+	d, s := newTestDispatcher(t, map[string]any{"workers": 1})
+
+	rc := &receiver{t: t, secret: "secret", statuses: []int{
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent,
+	}}
+	webhook := newTestWebhook(t, s, rc, "")
+	require.NoError(t, s.UpdateWebhookState(ctx, webhook.ID, true, 2))
+
+	d.NotifyEvent(ctx, types.WebhookEventCreated, newTestEvent(t, "user1"))
+
+	var deliveries []*types.WebhookDelivery
+	require.Eventually(t, func() bool {
+		var err error
+		deliveries, err = s.GetWebhookDeliveries(ctx, webhook.ID, 0)
+		return err == nil && len(deliveries) == 3
+	}, 5*time.Second, 10*time.Millisecond, "attempts are not logged")
+
+	require.Equal(t, 3, deliveries[0].Attempt, "deliveries are not sorted newest first")
+	require.True(t, deliveries[0].Success)
+	require.Equal(t, http.StatusNoContent, deliveries[0].StatusCode)
+	require.False(t, deliveries[2].Success)
+	require.Equal(t, http.StatusInternalServerError, deliveries[2].StatusCode)
+	require.Contains(t, deliveries[2].Error, "unexpected status code")
+	require.Equal(t, deliveries[0].DeliveryID, deliveries[2].DeliveryID, "attempts do not share the delivery ID")
+
+	payloads := rc.received()
+	require.Len(t, payloads, 3)
+	require.Equal(t, payloads[0].DeliveryID, payloads[2].DeliveryID, "retries do not share the delivery ID")
+
+	require.Eventually(t, func() bool {
+		stored, err := s.GetWebhook(ctx, webhook.ID)
+		return err == nil && stored.Failures == 0
+	}, 5*time.Second, 10*time.Millisecond, "failures are not reset on success")
+}
+
+func TestDispatcher_DisableAfterFailures(t *testing.T) {
+	ctx := context.Background()
+	d, s := newTestDispatcher(t, map[string]any{"workers": 1, "retries": 1, "max_failures": 2})
+
+	rc := &receiver{t: t, secret: "secret", statuses: []int{http.StatusServiceUnavailable}}
+	webhook := newTestWebhook(t, s, rc, "")
+
+	d.NotifyEvent(ctx, types.WebhookEventCreated, newTestEvent(t, "user1"))
+	require.Eventually(t, func() bool {
+		stored, err := s.GetWebhook(ctx, webhook.ID)
+		return err == nil && stored.Failures == 1
+	}, 5*time.Second, 10*time.Millisecond, "failure is not counted")
+	stored, err := s.GetWebhook(ctx, webhook.ID)
+	require.NoError(t, err)
+	require.True(t, stored.Enabled, "webhook is disabled too early")
+
+	d.NotifyEvent(ctx, types.WebhookEventUpdated, newTestEvent(t, "user1"))
+	require.Eventually(t, func() bool {
+		stored, err := s.GetWebhook(ctx, webhook.ID)
+		return err == nil && !stored.Enabled
+	}, 5*time.Second, 10*time.Millisecond, "webhook is not disabled")
+	require.Equal(t, int32(4), rc.requests.Load(), "unexpected number of attempts")
+
+	// Disabled webhooks receive nothing.
+	d.NotifyEvent(ctx, types.WebhookEventDeleted, newTestEvent(t, "user1"))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(4), rc.requests.Load(), "disabled webhook receives changes")
+}
+
+func TestDispatcher_ConcurrentSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	d, s := newTestDispatcher(t, map[string]any{"workers": 1})
+
+	// Both subscriptions hold the requests until released, so the sequential delivery never reaches the second one.
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	var once sync.Once
+	t.Cleanup(func() { once.Do(func() { close(release) }) })
+
+	ids := make([]uuid.UUID, 0, 2)
+	for range 2 {
+		webhook, err := types.NewWebhook("", server.URL, "secret", nil)
+		require.NoError(t, err, "failed to create webhook")
+		_, err = s.CreateWebhook(ctx, webhook)
+		require.NoError(t, err, "failed to save webhook")
+		ids = append(ids, webhook.ID)
+	}
+
+	d.NotifyEvent(ctx, types.WebhookEventCreated, newTestEvent(t, "user1"))
+	require.Eventually(t, func() bool {
+		return requests.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond, "subscriptions are not delivered concurrently")
+	once.Do(func() { close(release) })
+
+	for _, id := range ids {
+		require.Eventually(t, func() bool {
+			deliveries, err := s.GetWebhookDeliveries(ctx, id, 0)
+			return err == nil && len(deliveries) == 1 && deliveries[0].Success
+		}, 5*time.Second, 10*time.Millisecond, "delivery is not logged")
+	}
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	s, err := memory.NewStorage(0)
+	require.NoError(t, err)
+	d, err := NewDispatcher(discardLogger{}, s, map[string]any{"queue_size": 1})
+	require.NoError(t, err)
+	require.False(t, d.Enabled())
+
+	// Workers are not started, so the queue is not drained.
+	ctx := context.Background()
+	d.NotifyEvent(ctx, types.WebhookEventCreated, newTestEvent(t, "user1"))
+	d.NotifyEvent(ctx, types.WebhookEventCreated, newTestEvent(t, "user1"))
+	d.NotifyReminder(ctx, &types.Notification{ID: uuid.NewString(), UserID: "user1"})
+	require.Len(t, d.queue, 1, "changes are not dropped")
+
+	// Invalid reminders are skipped.
+	d.queue = make(chan *job, 1)
+	d.NotifyReminder(ctx, &types.Notification{ID: "invalid"})
+	require.Empty(t, d.queue, "invalid reminder is queued")
+}
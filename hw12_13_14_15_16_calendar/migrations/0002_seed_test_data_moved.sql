@@ -0,0 +1,6 @@
+-- +goose Up
+-- No-op. Test data is moved to seed/0002_seed_test_data.sql and versioned separately.
+-- The version is kept, since it is applied to the existing databases.
+
+-- +goose Down
+-- No-op.
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Extend event schema with busy_status field
+ALTER TABLE events
+ADD busy_status TEXT NOT NULL DEFAULT 'busy';
+
+ALTER TABLE events
+ADD CONSTRAINT busy_status_check CHECK (busy_status IN ('busy', 'free', 'tentative', 'out-of-office'));
+
+
+-- +goose Down
+-- Remove busy_status field
+ALTER TABLE events
+DROP CONSTRAINT IF EXISTS busy_status_check;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS busy_status;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Extend event schema with all_day field
+ALTER TABLE events
+ADD all_day bool NOT NULL DEFAULT false;
+
+-- Period queries filter events by their end as well as by their start
+CREATE INDEX idx_events_datetime ON events(datetime);
+
+
+-- +goose Down
+-- Remove all_day field
+DROP INDEX IF EXISTS idx_events_datetime;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS all_day;
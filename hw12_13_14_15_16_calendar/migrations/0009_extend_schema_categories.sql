@@ -0,0 +1,23 @@
+-- +goose Up
+-- Extend event schema with categorization fields
+ALTER TABLE events
+ADD tags TEXT[] NOT NULL DEFAULT '{}',
+ADD color TEXT NOT NULL DEFAULT '',
+ADD location TEXT NOT NULL DEFAULT '',
+ADD url TEXT NOT NULL DEFAULT '';
+
+-- Indexes for tag and location filters
+CREATE INDEX idx_events_tags ON events USING GIN (tags);
+CREATE INDEX idx_events_location ON events (lower(location));
+
+
+-- +goose Down
+-- Remove categorization fields
+DROP INDEX IF EXISTS idx_events_location;
+DROP INDEX IF EXISTS idx_events_tags;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS url,
+DROP COLUMN IF EXISTS location,
+DROP COLUMN IF EXISTS color,
+DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Notify the scheduler about created or updated events waiting for notification
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_events_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('events_changed', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER events_changed
+AFTER INSERT OR UPDATE ON events
+FOR EACH ROW
+WHEN (NEW.remind_in > INTERVAL '0 microseconds' AND NOT NEW.is_notified)
+EXECUTE FUNCTION notify_events_changed();
+
+
+-- +goose Down
+-- Remove events changes notification
+DROP TRIGGER IF EXISTS events_changed ON events;
+DROP FUNCTION IF EXISTS notify_events_changed();
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Notification templates per channel and locale
+CREATE TABLE notification_templates (
+    channel TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    format TEXT NOT NULL DEFAULT 'text',
+    body TEXT NOT NULL,
+
+    PRIMARY KEY (channel, locale),
+    CONSTRAINT format_check CHECK (format IN ('text', 'html'))
+);
+
+-- User preferences for notification rendering. Empty values mean the sender defaults
+CREATE TABLE user_settings (
+    user_id TEXT PRIMARY KEY,
+    locale TEXT NOT NULL DEFAULT '',
+    time_zone TEXT NOT NULL DEFAULT ''
+);
+
+
+-- +goose Down
+-- Remove notification templates and user settings
+DROP TABLE IF EXISTS user_settings;
+DROP TABLE IF EXISTS notification_templates;
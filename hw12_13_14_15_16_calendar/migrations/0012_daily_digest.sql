@@ -0,0 +1,30 @@
+-- +goose Up
+-- Notification templates of the daily digests are distinguished by the kind
+ALTER TABLE notification_templates
+ADD kind TEXT NOT NULL DEFAULT 'reminder',
+ADD CONSTRAINT kind_check CHECK (kind IN ('reminder', 'digest'));
+
+ALTER TABLE notification_templates DROP CONSTRAINT notification_templates_pkey;
+ALTER TABLE notification_templates ADD PRIMARY KEY (kind, channel, locale);
+
+-- Daily agenda digest preferences. sent_date is the local date of the last sent digest
+CREATE TABLE digest_settings (
+    user_id TEXT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    send_time TEXT NOT NULL,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    sent_date TEXT NOT NULL DEFAULT ''
+);
+
+
+-- +goose Down
+-- Remove digest settings and digest templates
+DROP TABLE IF EXISTS digest_settings;
+
+DELETE FROM notification_templates WHERE kind <> 'reminder';
+ALTER TABLE notification_templates DROP CONSTRAINT notification_templates_pkey;
+ALTER TABLE notification_templates ADD PRIMARY KEY (channel, locale);
+
+ALTER TABLE notification_templates
+DROP CONSTRAINT IF EXISTS kind_check,
+DROP COLUMN IF EXISTS kind;
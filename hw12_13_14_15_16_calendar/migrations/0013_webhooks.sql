@@ -0,0 +1,35 @@
+-- +goose Up
+-- Webhook subscriptions of third-party integrations. Empty user_id means all users
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    failures INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- Delivery log of the webhooks. Attempts of the same delivery share the delivery_id
+CREATE TABLE webhook_deliveries (
+    delivery_id UUID NOT NULL,
+    attempt INTEGER NOT NULL,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    event_id UUID NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (delivery_id, attempt)
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+
+
+-- +goose Down
+-- Remove webhook subscriptions and their delivery log
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Bookable resources: meeting rooms and shared equipment. Attributes are normalized tags
+CREATE TABLE resources (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'room',
+    capacity INTEGER NOT NULL DEFAULT 0,
+    attributes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- Resources booked by the events. Bookings are kept after the resource is deleted
+ALTER TABLE events
+ADD resources TEXT[] NOT NULL DEFAULT '{}';
+
+-- Index for the resource overlap checks and availability queries
+CREATE INDEX idx_events_resources ON events USING GIN (resources);
+
+
+-- +goose Down
+-- Remove bookable resources
+DROP INDEX IF EXISTS idx_events_resources;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS resources;
+
+DROP TABLE IF EXISTS resources;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Change log of the events, used by the incremental synchronization of the clients.
+-- Each event keeps its latest change only, deleted events are kept as tombstones until they are pruned
+CREATE TABLE event_changes (
+    event_id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    seq BIGINT NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+-- Index for the changes of the user since the given sequence number
+CREATE INDEX idx_event_changes_user_seq ON event_changes (user_id, seq);
+
+-- The last sequence number of the change log and the last one of the pruned tombstones
+CREATE TABLE event_changes_state (
+    seq BIGINT NOT NULL,
+    pruned_seq BIGINT NOT NULL
+);
+
+INSERT INTO event_changes_state (seq, pruned_seq) VALUES (0, 0);
+
+
+-- +goose Down
+-- Remove the change log
+DROP TABLE IF EXISTS event_changes_state;
+
+DROP INDEX IF EXISTS idx_event_changes_user_seq;
+
+DROP TABLE IF EXISTS event_changes;
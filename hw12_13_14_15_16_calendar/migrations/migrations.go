@@ -0,0 +1,38 @@
+// Package migrations embeds the SQL migrations of the calendar database.
+//
+// PostgreSQL schema migrations are located in the package root and are required for the service to work.
+// Seed migrations fill the PostgreSQL database with the test data. They are located in the seed directory
+// and are versioned independently of the schema ones. Versions of the former test data migrations are kept
+// in the package root as no-op ones, since they are applied to the existing databases.
+//
+// Schema migrations of the other dialects are located in the directories named after the dialect.
+package migrations
+
+import "embed"
+
+// Schema contains the PostgreSQL schema migrations.
+//
+//go:embed *.sql
+var Schema embed.FS
+
+// Seed contains the test data migrations. Files are located in the SeedDir directory.
+//
+//go:embed seed/*.sql
+var Seed embed.FS
+
+// MySQL contains the MySQL/MariaDB schema migrations. Files are located in the MySQLDir directory.
+//
+//go:embed mysql/*.sql
+var MySQL embed.FS
+
+// SQLite contains the SQLite schema migrations. Files are located in the SQLiteDir directory.
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
+
+// Directories of the migrations within the corresponding file systems.
+const (
+	SeedDir   = "seed"
+	MySQLDir  = "mysql"
+	SQLiteDir = "sqlite"
+)
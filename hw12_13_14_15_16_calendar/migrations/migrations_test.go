@@ -0,0 +1,91 @@
+package migrations_test
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/migrations" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                               //nolint:depguard,nolintlint
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	testCases := []struct {
+		name  string
+		fsys  fs.FS
+		dir   string
+		files []string
+	}{
+		{
+			name: "schema",
+			fsys: migrations.Schema,
+			dir:  ".",
+			files: []string{
+				"0001_init_schema.sql",
+				"0002_seed_test_data_moved.sql",
+				"0003_reset_to_work_state.sql",
+				"0004_extend_schema_notify.sql",
+				"0005_seed_brocker_test_data_moved.sql",
+				"0006_reset_to_work_state.sql",
+				"0007_extend_schema_busy_status.sql",
+				"0008_extend_schema_all_day.sql",
+				"0009_extend_schema_categories.sql",
+				"0010_notify_events_changed.sql",
+				"0011_notification_templates.sql",
+				"0012_daily_digest.sql",
+				"0013_webhooks.sql",
+				"0014_resources.sql",
+				"0015_event_changes.sql",
+			},
+		},
+		{
+			name: "seed",
+			fsys: migrations.Seed,
+			dir:  migrations.SeedDir,
+			files: []string{
+				"0002_seed_test_data.sql",
+				"0005_seed_brocker_test_data.sql",
+			},
+		},
+		{
+			name: "mysql",
+			fsys: migrations.MySQL,
+			dir:  migrations.MySQLDir,
+			files: []string{
+				"0001_init_schema.sql", "0002_notification_templates.sql", "0003_daily_digest.sql", "0004_webhooks.sql",
+				"0005_resources.sql", "0006_event_changes.sql",
+			},
+		},
+		{
+			name: "sqlite",
+			fsys: migrations.SQLite,
+			dir:  migrations.SQLiteDir,
+			files: []string{
+				"0001_init_schema.sql", "0002_notification_templates.sql", "0003_daily_digest.sql", "0004_webhooks.sql",
+				"0005_resources.sql", "0006_event_changes.sql",
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			entries, err := fs.ReadDir(tC.fsys, tC.dir)
+			require.NoError(t, err, "expected no error reading migrations")
+
+			var names []string
+			for _, entry := range entries {
+				if entry.IsDir() {
+					continue
+				}
+				names = append(names, entry.Name())
+
+				data, err := fs.ReadFile(tC.fsys, path.Join(tC.dir, entry.Name()))
+				require.NoError(t, err, "expected no error reading migration")
+				require.True(t, strings.Contains(string(data), "-- +goose Up"), "expected up section in %s", entry.Name())
+				require.True(t, strings.Contains(string(data), "-- +goose Down"), "expected down section in %s", entry.Name())
+			}
+			require.Equal(t, tC.files, names, "embedded migrations do not match the expected ones")
+		})
+	}
+}
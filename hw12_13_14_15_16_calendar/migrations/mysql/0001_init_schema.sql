@@ -0,0 +1,30 @@
+-- +goose Up
+-- This migration initializes the MySQL/MariaDB database schema, if it doesn't exist.
+-- Durations are stored in seconds, tags are stored as JSON arrays.
+CREATE TABLE IF NOT EXISTS events (
+    id CHAR(36) PRIMARY KEY,
+    title TEXT NOT NULL,
+    datetime DATETIME(6) NOT NULL,
+    duration BIGINT NOT NULL,
+    description TEXT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    remind_in BIGINT NOT NULL,
+    is_notified BOOLEAN NOT NULL DEFAULT FALSE,
+    busy_status VARCHAR(16) NOT NULL DEFAULT 'busy',
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    tags JSON NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+
+    CONSTRAINT duration_check CHECK (duration > 0),
+    CONSTRAINT remind_in_check CHECK (remind_in >= 0),
+    CONSTRAINT busy_status_check CHECK (busy_status IN ('busy', 'free', 'tentative', 'out-of-office'))
+);
+
+CREATE INDEX idx_events_user_id ON events(user_id);
+CREATE INDEX idx_events_datetime ON events(datetime);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Notification templates per channel and locale.
+CREATE TABLE IF NOT EXISTS notification_templates (
+    channel VARCHAR(64) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    format VARCHAR(8) NOT NULL DEFAULT 'text',
+    body TEXT NOT NULL,
+
+    PRIMARY KEY (channel, locale),
+    CONSTRAINT format_check CHECK (format IN ('text', 'html'))
+);
+
+-- User preferences for notification rendering. Empty values mean the sender defaults.
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id VARCHAR(255) PRIMARY KEY,
+    locale VARCHAR(35) NOT NULL DEFAULT '',
+    time_zone VARCHAR(64) NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS user_settings;
+DROP TABLE IF EXISTS notification_templates;
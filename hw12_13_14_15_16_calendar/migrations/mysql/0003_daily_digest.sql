@@ -0,0 +1,27 @@
+-- +goose Up
+-- Notification templates of the daily digests are distinguished by the kind.
+ALTER TABLE notification_templates
+    ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'reminder',
+    ADD CONSTRAINT kind_check CHECK (kind IN ('reminder', 'digest')),
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (kind, channel, locale);
+
+-- Daily agenda digest preferences. sent_date is the local date of the last sent digest.
+CREATE TABLE IF NOT EXISTS digest_settings (
+    user_id VARCHAR(255) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    send_time VARCHAR(5) NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    sent_date VARCHAR(10) NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS digest_settings;
+
+DELETE FROM notification_templates WHERE kind <> 'reminder';
+ALTER TABLE notification_templates
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (channel, locale),
+    DROP CONSTRAINT kind_check,
+    DROP COLUMN kind;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Webhook subscriptions of third-party integrations. Empty user_id means all users, event types are stored as JSON arrays.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id CHAR(36) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types JSON NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    failures INT NOT NULL DEFAULT 0,
+    created_at DATETIME(6) NOT NULL
+);
+
+-- Delivery log of the webhooks. Attempts of the same delivery share the delivery_id.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id CHAR(36) NOT NULL,
+    attempt INT NOT NULL,
+    webhook_id CHAR(36) NOT NULL,
+    event_type VARCHAR(16) NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL,
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME(6) NOT NULL,
+
+    PRIMARY KEY (delivery_id, attempt),
+    CONSTRAINT fk_webhook_deliveries_webhook_id FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
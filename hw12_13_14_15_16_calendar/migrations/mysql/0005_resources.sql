@@ -0,0 +1,21 @@
+-- +goose Up
+-- Bookable resources: meeting rooms and shared equipment. Attributes are normalized tags stored as JSON arrays.
+CREATE TABLE IF NOT EXISTS resources (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL DEFAULT 'room',
+    capacity INT NOT NULL DEFAULT 0,
+    attributes JSON NOT NULL,
+    created_at DATETIME(6) NOT NULL
+);
+
+-- Resources booked by the events, stored as JSON arrays. Bookings are kept after the resource is deleted.
+-- JSON columns cannot have a literal default, so the existing events are filled explicitly.
+ALTER TABLE events ADD COLUMN resources JSON NULL;
+UPDATE events SET resources = JSON_ARRAY();
+ALTER TABLE events MODIFY resources JSON NOT NULL;
+
+-- +goose Down
+-- Migration rollback.
+ALTER TABLE events DROP COLUMN resources;
+DROP TABLE IF EXISTS resources;
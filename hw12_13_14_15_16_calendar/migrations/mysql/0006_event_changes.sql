@@ -0,0 +1,25 @@
+-- +goose Up
+-- Change log of the events, used by the incremental synchronization of the clients.
+-- Each event keeps its latest change only, deleted events are kept as tombstones until they are pruned.
+CREATE TABLE IF NOT EXISTS event_changes (
+    event_id CHAR(36) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    seq BIGINT NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    changed_at DATETIME(6) NOT NULL,
+
+    INDEX idx_event_changes_user_seq (user_id, seq)
+);
+
+-- The last sequence number of the change log and the last one of the pruned tombstones.
+CREATE TABLE IF NOT EXISTS event_changes_state (
+    seq BIGINT NOT NULL,
+    pruned_seq BIGINT NOT NULL
+);
+
+INSERT INTO event_changes_state (seq, pruned_seq) VALUES (0, 0);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS event_changes_state;
+DROP TABLE IF EXISTS event_changes;
@@ -67,4 +67,11 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 
 -- +goose Down
 -- Cleanup test data
-TRUNCATE TABLE events;
\ No newline at end of file
+DELETE FROM events WHERE id IN (
+    '11111111-1111-1111-1111-111111111111',
+    '22222222-2222-2222-2222-222222222222',
+    '33333333-3333-3333-3333-333333333333',
+    '44444444-4444-4444-4444-444444444444',
+    '55555555-5555-5555-5555-555555555555',
+    '66666666-6666-6666-6666-666666666666'
+);
@@ -3,7 +3,7 @@
 INSERT INTO events (id, title, datetime, duration, description, user_id, remind_in, is_notified) VALUES
 -- Should be notified
 (
-    '11111111-1111-1111-1111-111111111111',
+    'b1111111-1111-1111-1111-111111111111',
     'Urgent Sync',
     '2025-07-08 15:25:00',
     INTERVAL '15 minutes',
@@ -14,7 +14,7 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 ),
 -- Should not be notified
 (
-    '22222222-2222-2222-2222-222222222222',
+    'b2222222-2222-2222-2222-222222222222',
     'Standup Meeting',
     '2025-07-18 15:35:00',
     INTERVAL '30 minutes',
@@ -25,7 +25,7 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 ),
 -- Should be notified, past event
 (
-    '33333333-3333-3333-3333-333333333333',
+    'b3333333-3333-3333-3333-333333333333',
     'Client Call',
     '2025-06-08 18:00:00',
     INTERVAL '1 hour',
@@ -36,7 +36,7 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 ),
 -- Should not be notified, past event
 (
-    '44444444-4444-4444-4444-444444444444',
+    'b4444444-4444-4444-4444-444444444444',
     'Team Workshop',
     '2025-06-12 10:00:00',
     INTERVAL '2 hours',
@@ -47,7 +47,7 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 ),
 -- Should be deleted, old event. May send notification
 (
-    '55555555-5555-5555-5555-555555555555',
+    'b5555555-5555-5555-5555-555555555555',
     'Training Session',
     '2023-07-15 09:00:00',
     INTERVAL '3 days',
@@ -58,7 +58,7 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 ),
 -- Should not be notified
 (
-    '66666666-6666-6666-6666-666666666666',
+    'b6666666-6666-6666-6666-666666666666',
     'Company Event',
     '2025-07-22 18:00:00',
     INTERVAL '3 hours',
@@ -69,7 +69,7 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 ),
 -- Should not be notified - no remind_in duration set
 (
-    '77777777-7777-7777-7777-777777777777',
+    'b7777777-7777-7777-7777-777777777777',
     'Some Event',
     '2025-07-27 14:00:00',
     INTERVAL '2 hours',
@@ -81,4 +81,12 @@ INSERT INTO events (id, title, datetime, duration, description, user_id, remind_
 
 -- +goose Down
 -- Cleanup test data
-TRUNCATE TABLE events;
\ No newline at end of file
+DELETE FROM events WHERE id IN (
+    'b1111111-1111-1111-1111-111111111111',
+    'b2222222-2222-2222-2222-222222222222',
+    'b3333333-3333-3333-3333-333333333333',
+    'b4444444-4444-4444-4444-444444444444',
+    'b5555555-5555-5555-5555-555555555555',
+    'b6666666-6666-6666-6666-666666666666',
+    'b7777777-7777-7777-7777-777777777777'
+);
@@ -0,0 +1,30 @@
+-- +goose Up
+-- This migration initializes the SQLite database schema, if it doesn't exist.
+-- Durations are stored in seconds, tags are stored as JSON arrays.
+CREATE TABLE IF NOT EXISTS events (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    datetime DATETIME NOT NULL,
+    duration INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    remind_in INTEGER NOT NULL,
+    is_notified BOOLEAN NOT NULL DEFAULT FALSE,
+    busy_status TEXT NOT NULL DEFAULT 'busy',
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    tags TEXT NOT NULL DEFAULT '[]',
+    color TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+
+    CONSTRAINT duration_check CHECK (duration > 0),
+    CONSTRAINT remind_in_check CHECK (remind_in >= 0),
+    CONSTRAINT busy_status_check CHECK (busy_status IN ('busy', 'free', 'tentative', 'out-of-office'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_user_id ON events(user_id);
+CREATE INDEX IF NOT EXISTS idx_events_datetime ON events(datetime);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,45 @@
+-- +goose Up
+-- Notification templates of the daily digests are distinguished by the kind.
+-- SQLite cannot change the primary key, so the table is rebuilt.
+CREATE TABLE notification_templates_new (
+    kind TEXT NOT NULL DEFAULT 'reminder',
+    channel TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    format TEXT NOT NULL DEFAULT 'text',
+    body TEXT NOT NULL,
+
+    PRIMARY KEY (kind, channel, locale),
+    CONSTRAINT kind_check CHECK (kind IN ('reminder', 'digest')),
+    CONSTRAINT format_check CHECK (format IN ('text', 'html'))
+);
+INSERT INTO notification_templates_new (channel, locale, format, body)
+SELECT channel, locale, format, body FROM notification_templates;
+DROP TABLE notification_templates;
+ALTER TABLE notification_templates_new RENAME TO notification_templates;
+
+-- Daily agenda digest preferences. sent_date is the local date of the last sent digest.
+CREATE TABLE IF NOT EXISTS digest_settings (
+    user_id TEXT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    send_time TEXT NOT NULL,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    sent_date TEXT NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS digest_settings;
+
+CREATE TABLE notification_templates_old (
+    channel TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    format TEXT NOT NULL DEFAULT 'text',
+    body TEXT NOT NULL,
+
+    PRIMARY KEY (channel, locale),
+    CONSTRAINT format_check CHECK (format IN ('text', 'html'))
+);
+INSERT INTO notification_templates_old (channel, locale, format, body)
+SELECT channel, locale, format, body FROM notification_templates WHERE kind = 'reminder';
+DROP TABLE notification_templates;
+ALTER TABLE notification_templates_old RENAME TO notification_templates;
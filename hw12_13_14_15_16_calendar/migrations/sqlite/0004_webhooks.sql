@@ -0,0 +1,35 @@
+-- +goose Up
+-- Webhook subscriptions of third-party integrations. Empty user_id means all users, event types are stored as JSON arrays.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT NOT NULL DEFAULT '[]',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    failures INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL
+);
+
+-- Delivery log of the webhooks. Attempts of the same delivery share the delivery_id.
+-- Foreign keys are not enforced by default, so the log is deleted explicitly along with the subscription.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    webhook_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME NOT NULL,
+
+    PRIMARY KEY (delivery_id, attempt)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
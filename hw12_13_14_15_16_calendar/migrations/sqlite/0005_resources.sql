@@ -0,0 +1,18 @@
+-- +goose Up
+-- Bookable resources: meeting rooms and shared equipment. Attributes are normalized tags stored as JSON arrays.
+CREATE TABLE IF NOT EXISTS resources (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'room',
+    capacity INTEGER NOT NULL DEFAULT 0,
+    attributes TEXT NOT NULL DEFAULT '[]',
+    created_at DATETIME NOT NULL
+);
+
+-- Resources booked by the events, stored as JSON arrays. Bookings are kept after the resource is deleted.
+ALTER TABLE events ADD COLUMN resources TEXT NOT NULL DEFAULT '[]';
+
+-- +goose Down
+-- Migration rollback.
+ALTER TABLE events DROP COLUMN resources;
+DROP TABLE IF EXISTS resources;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Change log of the events, used by the incremental synchronization of the clients.
+-- Each event keeps its latest change only, deleted events are kept as tombstones until they are pruned.
+CREATE TABLE IF NOT EXISTS event_changes (
+    event_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    changed_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_changes_user_seq ON event_changes(user_id, seq);
+
+-- The last sequence number of the change log and the last one of the pruned tombstones.
+CREATE TABLE IF NOT EXISTS event_changes_state (
+    seq INTEGER NOT NULL,
+    pruned_seq INTEGER NOT NULL
+);
+
+INSERT INTO event_changes_state (seq, pruned_seq) VALUES (0, 0);
+
+-- +goose Down
+-- Migration rollback.
+DROP TABLE IF EXISTS event_changes_state;
+DROP TABLE IF EXISTS event_changes;
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2" //nolint:depguard,nolintlint
+	"github.com/spf13/viper"          //nolint:depguard,nolintlint
+)
+
+// redactedValue replaces the secret values in the printed config.
+const redactedValue = "******"
+
+// secretKeys are the substrings of the keys, which values are treated as secrets.
+var secretKeys = []string{"password", "secret", "token"}
+
+// check is a config section validation.
+type check struct {
+	section  string
+	validate func(cfg map[string]any) error
+}
+
+// AddCheck registers the validation of the config section, which is run by the --check-config flag.
+//
+// validate receives the section the same way as the component does on startup and is expected to return
+// *ValidationError for the key problems. Other errors are reported for the whole section.
+func (l *Loader) AddCheck(section string, validate func(cfg map[string]any) error) {
+	l.checks = append(l.checks, check{section: section, validate: validate})
+}
+
+// checkConfig runs all registered checks on the loaded config and prints the report and the effective config
+// with the secrets redacted. Returns an error if any problem was found.
+//
+// Values, which cannot be decoded into the config, are reported as well, the checks are run on the rest.
+func (l *Loader) checkConfig(cfg ServiceConfig, writer io.Writer) error {
+	configPath := viper.GetString("config")
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("read main config at %s: %w", configPath, err)
+	}
+
+	problems := make([]string, 0)
+	if err := viper.Unmarshal(cfg); err != nil {
+		problems = append(problems, decodeProblems(err)...)
+	}
+	for _, c := range l.checks {
+		sectionCfg, err := cfg.GetSubConfig(c.section)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", c.section, err))
+			continue
+		}
+		err = c.validate(sectionCfg)
+		if err == nil {
+			continue
+		}
+		var ve *ValidationError
+		if errors.As(err, &ve) {
+			problems = append(problems, ve.Problems(c.section)...)
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s: %v", c.section, err))
+	}
+
+	// Flags bound to viper are not the part of the config.
+	settings := viper.AllSettings()
+	delete(settings, "config")
+	delete(settings, "version")
+	effective, err := toml.Marshal(redactSecrets(settings))
+	if err != nil {
+		return fmt.Errorf("marshal effective config: %w", err)
+	}
+	fmt.Fprintf(writer, "# Effective config: %s merged with the environment, secrets redacted.\n\n%s\n",
+		viper.ConfigFileUsed(), effective)
+
+	if len(problems) > 0 {
+		fmt.Fprintf(writer, "Config is invalid:\n  %s\n", strings.Join(problems, "\n  "))
+		return fmt.Errorf("config check failed: %d problem(s) found", len(problems))
+	}
+	fmt.Fprintln(writer, "Config is valid.")
+	return nil
+}
+
+// redactSecrets returns the copy of the nested settings with the non-empty secret values replaced.
+func redactSecrets(settings map[string]any) map[string]any {
+	res := make(map[string]any, len(settings))
+	for key, value := range settings {
+		switch v := value.(type) {
+		case map[string]any:
+			res[key] = redactSecrets(v)
+		default:
+			res[key] = value
+			if isSecretKey(key) && fmt.Sprint(value) != "" {
+				res[key] = redactedValue
+			}
+		}
+	}
+	return res
+}
+
+// isSecretKey reports whether the key holds a secret value.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeProblems returns the messages of all errors joined in the decoding error.
+// Each of them refers to the key it failed on.
+func decodeProblems(err error) []string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		joined, ok := e.(interface{ Unwrap() []error })
+		if !ok {
+			continue
+		}
+		res := make([]string, 0)
+		for _, inner := range joined.Unwrap() {
+			res = append(res, decodeProblems(inner)...)
+		}
+		return res
+	}
+	return []string{err.Error()}
+}
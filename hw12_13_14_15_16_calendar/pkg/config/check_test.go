@@ -0,0 +1,214 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"              //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+// typedConfig is a config with the typed sections.
+type typedConfig struct {
+	App struct {
+		Retries int    `mapstructure:"retries"`
+		Policy  string `mapstructure:"policy"`
+	} `mapstructure:"app"`
+}
+
+func (c *typedConfig) GetSubConfig(key string) (map[string]any, error) {
+	if key != "app" {
+		return nil, errors.New("section not found")
+	}
+	return map[string]any{"retries": c.App.Retries, "policy": c.App.Policy}, nil
+}
+
+func TestDecodeProblems(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{"single error", errors.New("app.retries: invalid"), []string{"app.retries: invalid"}},
+		{
+			"joined errors",
+			errors.Join(errors.New("app.retries: invalid"), errors.New("http.port: invalid")),
+			[]string{"app.retries: invalid", "http.port: invalid"},
+		},
+		{
+			"wrapped joined errors",
+			fmt.Errorf("decoding failed: %w", errors.Join(errors.New("a: invalid"), errors.New("b: invalid"))),
+			[]string{"a: invalid", "b: invalid"},
+		},
+		{
+			"nested joined errors",
+			errors.Join(errors.New("a: invalid"), fmt.Errorf("section: %w",
+				errors.Join(errors.New("b: invalid"), errors.New("c: invalid")))),
+			[]string{"a: invalid", "b: invalid", "c: invalid"},
+		},
+		{"wrapped single error", fmt.Errorf("decode: %w", errors.New("a: invalid")), []string{"decode: a: invalid"}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, decodeProblems(tC.err))
+		})
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings map[string]any
+		expected map[string]any
+	}{
+		{
+			"plain values",
+			map[string]any{"host": "localhost", "port": 5432},
+			map[string]any{"host": "localhost", "port": 5432},
+		},
+		{
+			"secret keys",
+			map[string]any{"password": "pass", "client_secret": "s", "admin_token": "t", "user": "admin"},
+			map[string]any{
+				"password": redactedValue, "client_secret": redactedValue, "admin_token": redactedValue, "user": "admin",
+			},
+		},
+		{
+			"case insensitive keys",
+			map[string]any{"DB_Password": "pass"},
+			map[string]any{"DB_Password": redactedValue},
+		},
+		{
+			"empty secrets are kept",
+			map[string]any{"password": "", "token": ""},
+			map[string]any{"password": "", "token": ""},
+		},
+		{
+			"non-string secrets",
+			map[string]any{"tokens": []string{"a", "b"}},
+			map[string]any{"tokens": redactedValue},
+		},
+		{
+			"nested sections",
+			map[string]any{"storage": map[string]any{"sql": map[string]any{"password": "pass", "user": "admin"}}},
+			map[string]any{"storage": map[string]any{"sql": map[string]any{"password": redactedValue, "user": "admin"}}},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, redactSecrets(tC.settings))
+		})
+	}
+
+	t.Run("source is not modified", func(t *testing.T) {
+		settings := map[string]any{"sql": map[string]any{"password": "pass"}}
+		redactSecrets(settings)
+		require.Equal(t, map[string]any{"sql": map[string]any{"password": "pass"}}, settings)
+	})
+}
+
+func TestValidationErrorProblems(t *testing.T) {
+	ve := &ValidationError{
+		Missing:      []string{"port"},
+		InvalidType:  []string{"sql.timeout"},
+		InvalidValue: []string{"host"},
+	}
+	require.True(t, ve.HasErrors())
+	require.Equal(t, []string{
+		"storage.host: invalid value",
+		"storage.port: missing",
+		"storage.sql.timeout: invalid type",
+	}, ve.Problems("storage"))
+	require.Equal(t, []string{"host: invalid value", "port: missing", "sql.timeout: invalid type"}, ve.Problems(""))
+	require.False(t, (&ValidationError{}).HasErrors())
+}
+
+func TestCheckConfig(t *testing.T) {
+	validate := func(cfg map[string]any) error {
+		ve := &ValidationError{}
+		if policy, _ := cfg["policy"].(string); policy != "reject" && policy != "allow" {
+			ve.InvalidValue = append(ve.InvalidValue, "policy")
+		}
+		if ve.HasErrors() {
+			return fmt.Errorf("corrupted config: %w", ve)
+		}
+		return nil
+	}
+
+	testCases := []struct {
+		name     string
+		content  string
+		checks   []check
+		problems []string
+	}{
+		{
+			name:    "valid config",
+			content: "[app]\nretries = 1\npolicy = \"reject\"\n",
+			checks:  []check{{section: "app", validate: validate}},
+		},
+		{
+			name:     "validation problems",
+			content:  "[app]\nretries = 1\npolicy = \"never\"\n",
+			checks:   []check{{section: "app", validate: validate}},
+			problems: []string{"app.policy: invalid value"},
+		},
+		{
+			name:     "decoding problems",
+			content:  "[app]\nretries = \"many\"\npolicy = \"reject\"\n",
+			checks:   []check{{section: "app", validate: validate}},
+			problems: []string{"retries"},
+		},
+		{
+			name:     "unknown section",
+			content:  "[app]\nretries = 1\npolicy = \"reject\"\n",
+			checks:   []check{{section: "logger", validate: validate}},
+			problems: []string{"logger: section not found"},
+		},
+		{
+			name:    "other errors",
+			content: "[app]\nretries = 1\npolicy = \"reject\"\n",
+			checks: []check{{section: "app", validate: func(map[string]any) error {
+				return errors.New("storage is unavailable")
+			}}},
+			problems: []string{"app: storage is unavailable"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+			path := filepath.Join(t.TempDir(), "config.toml")
+			require.NoError(t, os.WriteFile(path, []byte(tC.content+"\n[sql]\npassword = \"pass\"\n"), 0o600))
+			viper.Set("config", path)
+
+			out := &strings.Builder{}
+			err := (&Loader{checks: tC.checks}).checkConfig(&typedConfig{}, out)
+			require.Contains(t, out.String(), "password = '"+redactedValue+"'", "secret is not redacted")
+			require.NotContains(t, out.String(), "config =", "flags are printed as the config")
+			if len(tC.problems) == 0 {
+				require.NoError(t, err, "expected nil, got error")
+				require.Contains(t, out.String(), "Config is valid.")
+				return
+			}
+			require.Error(t, err, "expected error, got nil")
+			require.Contains(t, out.String(), "Config is invalid:")
+			for _, problem := range tC.problems {
+				require.Contains(t, out.String(), problem)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+		viper.Reset()
+		viper.Set("config", filepath.Join(t.TempDir(), "missing.toml"))
+		require.Error(t, (&Loader{}).checkConfig(&typedConfig{}, &strings.Builder{}))
+	})
+}
@@ -3,13 +3,26 @@ package config
 import (
 	"fmt"
 	"io"
+
+	"github.com/spf13/cobra" //nolint:depguard,nolintlint
 )
 
+// CommandFunc is the logic of a subcommand. It receives the loaded service config.
+type CommandFunc func(cmd *cobra.Command, args []string, cfg ServiceConfig) error
+
 // Loader is a viper config loader.
 type Loader struct {
 	name, short, long string // Root command attributes.
 	configPath        string
 	envPrefix         string
+	commands          []subcommand
+	checks            []check
+}
+
+// subcommand is a subcommand of the root command with its logic.
+type subcommand struct {
+	cmd *cobra.Command
+	fn  CommandFunc
 }
 
 // NewLoader returns a new viper loader.
@@ -31,24 +44,35 @@ func NewLoader(name, short, long, configPath, envPrefix string) *Loader {
 	}
 }
 
+// AddCommand registers a subcommand of the root command.
+//
+// The config is loaded the same way as for the root command and passed to fn on the subcommand execution.
+// RunE of cmd is overridden.
+func (l *Loader) AddCommand(cmd *cobra.Command, fn CommandFunc) {
+	l.commands = append(l.commands, subcommand{cmd: cmd, fn: fn})
+}
+
 // Load loads configuration from a file and environment variables.
 // It checks the flags in the process and executes the root command, depending on the flag.
 // If no additional flags are set, it will load the configuration from the path provided.
 //
-// If -h (--help) or -v (--version) flags are set, it will return nil, ErrShouldStop
-// as a signal to stop the execution.
+// If -h (--help), -v (--version) or --check-config flags are set or a subcommand was executed,
+// it will return nil, ErrShouldStop as a signal to stop the execution.
+// A failed config check is returned as an error.
 func (l *Loader) Load(cfg ServiceConfig, printVersion func(io.Writer) error, writer io.Writer) (ServiceConfig, error) {
 	cmd, err := l.buildRootCommand(l.name, l.short, l.long, cfg, printVersion, writer)
 	if err != nil {
 		return nil, fmt.Errorf("build root command: %w", err)
 	}
 
-	if err := cmd.Execute(); err != nil {
-		return nil, fmt.Errorf("execute root command: %w", err)
+	executed, err := cmd.ExecuteC()
+	if err != nil {
+		return nil, fmt.Errorf("execute %s command: %w", executed.Name(), err)
 	}
 
-	// Check if the help or version flag is set. If so, stop execution.
-	if cmd.Flags().Changed("help") || cmd.Flags().Changed("version") {
+	// Check if a subcommand was executed or the help, version or check flag is set. If so, stop execution.
+	if executed != cmd || cmd.Flags().Changed("help") || cmd.Flags().Changed("version") ||
+		cmd.Flags().Changed("check-config") {
 		return nil, ErrShouldStop
 	}
 
@@ -31,14 +31,16 @@ func (l *Loader) buildRootCommand(
 		},
 	}
 
-	rootCmd.Flags().StringP("config", "c", "", "Path to configuration file")
+	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to configuration file")
 	rootCmd.Flags().BoolP("version", "v", false, "Show version info")
+	rootCmd.Flags().Bool("check-config", false,
+		"Validate the config, print the effective one with secrets redacted and exit")
 
 	viper.SetEnvPrefix(l.envPrefix)
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
-	if err := viper.BindPFlag("config", rootCmd.Flags().Lookup("config")); err != nil {
+	if err := viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config")); err != nil {
 		return nil, fmt.Errorf("bind config flag: %w", err)
 	}
 
@@ -46,7 +48,7 @@ func (l *Loader) buildRootCommand(
 		return nil, fmt.Errorf("bind version flag: %w", err)
 	}
 
-	rootCmd.PreRunE = func(_ *cobra.Command, _ []string) error {
+	rootCmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		// Processing -v flag preemptively.
 		if versionFlag := viper.GetBool("version"); versionFlag {
 			if err := printVersion(writer); err != nil {
@@ -55,18 +57,43 @@ func (l *Loader) buildRootCommand(
 			return nil
 		}
 
-		// Setting the config.
-		configPath := viper.GetString("config")
-		viper.SetConfigFile(configPath)
-		if err := viper.ReadInConfig(); err != nil {
-			return fmt.Errorf("read main config at %s: %w", configPath, err)
-		}
-		if err := viper.Unmarshal(cfg); err != nil {
-			return fmt.Errorf("unmarshal main config: %w", err)
+		// Processing --check-config flag instead of the regular loading to report all problems at once.
+		if checkFlag, _ := cmd.Flags().GetBool("check-config"); checkFlag {
+			// Problems are already reported by the check.
+			cmd.SilenceUsage = true
+			return l.checkConfig(cfg, writer)
 		}
 
-		return nil
+		return readConfig(cfg)
+	}
+
+	// Subcommands read the same config before running their own logic.
+	for _, sub := range l.commands {
+		fn := sub.fn
+		sub.cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := readConfig(cfg); err != nil {
+				return err
+			}
+			// Arguments are valid at this point, so further errors are not the usage ones.
+			cmd.SilenceUsage = true
+			return fn(cmd, args, cfg)
+		}
+		rootCmd.AddCommand(sub.cmd)
 	}
 
 	return rootCmd, nil
 }
+
+// readConfig reads the config file set by the config flag and unmarshals it to cfg.
+func readConfig(cfg ServiceConfig) error {
+	configPath := viper.GetString("config")
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("read main config at %s: %w", configPath, err)
+	}
+	if err := viper.Unmarshal(cfg); err != nil {
+		return fmt.Errorf("unmarshal main config: %w", err)
+	}
+
+	return nil
+}
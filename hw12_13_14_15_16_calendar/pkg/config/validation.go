@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationError lists the problematic keys of a config section. It follows error accumulation pattern,
+// so a component reports all problems of its section at once.
+//
+// Keys are relative to the section, the nested ones are dotted: e.g. "sql.host" for the storage section.
+type ValidationError struct {
+	Missing      []string
+	InvalidType  []string
+	InvalidValue []string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, 3)
+	if len(e.Missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing=%v", e.Missing))
+	}
+	if len(e.InvalidType) > 0 {
+		parts = append(parts, fmt.Sprintf("invalid_type=%v", e.InvalidType))
+	}
+	if len(e.InvalidValue) > 0 {
+		parts = append(parts, fmt.Sprintf("invalid_value=%v", e.InvalidValue))
+	}
+	return strings.Join(parts, " ")
+}
+
+// HasErrors reports whether any problem was found.
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Missing) > 0 || len(e.InvalidType) > 0 || len(e.InvalidValue) > 0
+}
+
+// Problems returns the problem descriptions with the full key paths, sorted by the key.
+func (e *ValidationError) Problems(section string) []string {
+	res := make([]string, 0, len(e.Missing)+len(e.InvalidType)+len(e.InvalidValue))
+	for _, group := range []struct {
+		keys    []string
+		problem string
+	}{
+		{e.Missing, "missing"},
+		{e.InvalidType, "invalid type"},
+		{e.InvalidValue, "invalid value"},
+	} {
+		for _, key := range group.keys {
+			res = append(res, fmt.Sprintf("%s: %s", keyPath(section, key), group.problem))
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// keyPath joins the section and the key into the dotted path.
+func keyPath(section, key string) string {
+	if section == "" {
+		return key
+	}
+	return section + "." + key
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify" //nolint:depguard,nolintlint
+	"github.com/spf13/viper"       //nolint:depguard,nolintlint
+)
+
+// Logger represents an interface of logger visible to the config watcher.
+type Logger interface {
+	// Info logs a message with level Info on the standard logger.
+	Info(ctx context.Context, msg string, args ...any)
+	// Warn logs a message with level Warn on the standard logger.
+	Warn(ctx context.Context, msg string, args ...any)
+	// Error logs a message with level Error on the standard logger.
+	Error(ctx context.Context, msg string, args ...any)
+}
+
+// Reloader applies the changed config section at runtime.
+type Reloader struct {
+	// Section is the config section passed to Apply.
+	Section string
+	// Keys are the section keys, which are safe to change at runtime. Empty slice means all keys of the section.
+	Keys []string
+	// Apply validates and applies the whole section. On error the current settings are expected to be kept.
+	Apply func(cfg map[string]any) error
+}
+
+// covers reports whether the dotted config key is handled by the reloader.
+func (r *Reloader) covers(key string) bool {
+	field, ok := strings.CutPrefix(key, r.Section+".")
+	if !ok {
+		return false
+	}
+	return len(r.Keys) == 0 || slices.Contains(r.Keys, field)
+}
+
+// watcher reloads the config on the file change or SIGHUP.
+//
+// Both triggers are handled by a single goroutine, as viper is not safe for concurrent use.
+type watcher struct {
+	ctx       context.Context
+	cfgType   reflect.Type
+	settings  map[string]any // Flattened settings the current config is built from.
+	reloaders []Reloader
+	l         Logger
+}
+
+// Watch starts watching the config file, which was read by Load, and SIGHUP signal. The method does not block.
+//
+// On each change the config is read and unmarshaled into a new instance of the cfg type.
+// For the changed keys, the reloaders covering them are applied with their config sections.
+// Changed keys not covered by any reloader are logged as the ones requiring a restart.
+//
+// Watching stops on the context cancellation.
+func (l *Loader) Watch(ctx context.Context, cfg ServiceConfig, logger Logger, reloaders ...Reloader) {
+	w := &watcher{
+		ctx:       ctx,
+		cfgType:   reflect.TypeOf(cfg),
+		settings:  flattenSettings(viper.AllSettings()),
+		reloaders: reloaders,
+		l:         logger,
+	}
+
+	configFile := filepath.Clean(viper.ConfigFileUsed())
+	// The directory is watched, so the file replaced by an editor or by a symlink swap is still tracked.
+	fw, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = fw.Add(filepath.Dir(configFile))
+		if err != nil {
+			_ = fw.Close()
+		}
+	}
+	if err != nil {
+		// SIGHUP still reloads the config.
+		logger.Error(ctx, "watch config file", slog.String("config", configFile), slog.Any("err", err))
+		fw = nil
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	go w.run(configFile, fw, sig)
+
+	logger.Info(ctx, "watching config changes", slog.String("config", configFile))
+}
+
+// run reloads the config on the file events and the signals until the context is canceled.
+// A nil file watcher means the file is not watched.
+func (w *watcher) run(configFile string, fw *fsnotify.Watcher, sig chan os.Signal) {
+	defer signal.Stop(sig)
+
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if fw != nil {
+		defer fw.Close()
+		events, errs = fw.Events, fw.Errors
+	}
+	realConfigFile, _ := filepath.EvalSymlinks(configFile)
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-sig:
+			w.reload("SIGHUP received")
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			// The file is either written or created, or the symlink to it is changed (e.g. Kubernetes ConfigMap).
+			currentConfigFile, _ := filepath.EvalSymlinks(configFile)
+			written := filepath.Clean(event.Name) == configFile && event.Has(fsnotify.Write|fsnotify.Create)
+			if written || (currentConfigFile != "" && currentConfigFile != realConfigFile) {
+				realConfigFile = currentConfigFile
+				w.reload("config file changed")
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			w.l.Error(w.ctx, "watch config file", slog.Any("err", err))
+		}
+	}
+}
+
+// reload reads the config file, builds the new config and applies the changes.
+func (w *watcher) reload(reason string) {
+	if w.ctx.Err() != nil {
+		return
+	}
+
+	w.l.Info(w.ctx, "reloading config", slog.String("reason", reason))
+
+	if err := viper.ReadInConfig(); err != nil {
+		w.l.Error(w.ctx, "read config, keeping the current one", slog.Any("err", err))
+		return
+	}
+
+	if w.cfgType.Kind() != reflect.Pointer {
+		w.l.Error(w.ctx, "unexpected config type, reload is not supported", slog.String("type", w.cfgType.String()))
+		return
+	}
+	cfg, _ := reflect.New(w.cfgType.Elem()).Interface().(ServiceConfig)
+	if err := viper.Unmarshal(cfg); err != nil {
+		w.l.Error(w.ctx, "unmarshal config, keeping the current one", slog.Any("err", err))
+		return
+	}
+
+	settings := flattenSettings(viper.AllSettings())
+	changed := changedKeys(w.settings, settings)
+	if len(changed) == 0 {
+		w.l.Info(w.ctx, "config has no changes")
+		return
+	}
+
+	restart := make([]string, 0)
+	for _, key := range changed {
+		if !slices.ContainsFunc(w.reloaders, func(r Reloader) bool { return r.covers(key) }) {
+			restart = append(restart, key)
+		}
+	}
+	if len(restart) > 0 {
+		w.l.Warn(w.ctx, "changed settings require a restart to take effect", slog.Any("keys", restart))
+	}
+
+	for _, r := range w.reloaders {
+		if !slices.ContainsFunc(changed, r.covers) {
+			continue
+		}
+		if err := w.apply(cfg, &r); err != nil {
+			w.l.Error(w.ctx, "reload config section, keeping the current settings",
+				slog.String("section", r.Section), slog.Any("err", err))
+			// The section keys stay changed, so the next reload retries them.
+			for _, key := range changed {
+				if !r.covers(key) {
+					continue
+				}
+				if prev, ok := w.settings[key]; ok {
+					settings[key] = prev
+				} else {
+					delete(settings, key)
+				}
+			}
+			continue
+		}
+		w.l.Info(w.ctx, "config section reloaded", slog.String("section", r.Section))
+	}
+
+	w.settings = settings
+}
+
+// apply passes the reloader section of the config to the reloader.
+func (w *watcher) apply(cfg ServiceConfig, r *Reloader) error {
+	section, err := cfg.GetSubConfig(r.Section)
+	if err != nil {
+		return fmt.Errorf("get %s config: %w", r.Section, err)
+	}
+	return r.Apply(section)
+}
+
+// flattenSettings converts the nested settings into the map with the dotted keys.
+func flattenSettings(settings map[string]any) map[string]any {
+	res := make(map[string]any)
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for key, value := range m {
+			if nested, ok := value.(map[string]any); ok {
+				walk(prefix+key+".", nested)
+				continue
+			}
+			res[prefix+key] = value
+		}
+	}
+	walk("", settings)
+	return res
+}
+
+// changedKeys returns the sorted keys, which were added, removed or changed.
+func changedKeys(prev, curr map[string]any) []string {
+	res := make([]string, 0)
+	for key, value := range curr {
+		if prevValue, ok := prev[key]; !ok || !reflect.DeepEqual(prevValue, value) {
+			res = append(res, key)
+		}
+	}
+	for key := range prev {
+		if _, ok := curr[key]; !ok {
+			res = append(res, key)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
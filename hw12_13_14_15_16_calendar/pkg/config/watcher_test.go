@@ -0,0 +1,281 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"        //nolint:depguard,nolintlint
+	"github.com/spf13/viper"              //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/assert"  //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require" //nolint:depguard,nolintlint
+)
+
+// testConfig is a config with the free-form sections.
+type testConfig struct {
+	App    map[string]any `mapstructure:"app"`
+	Logger map[string]any `mapstructure:"logger"`
+	HTTP   map[string]any `mapstructure:"http"`
+}
+
+func (c *testConfig) GetSubConfig(key string) (map[string]any, error) {
+	section, ok := map[string]map[string]any{"app": c.App, "logger": c.Logger, "http": c.HTTP}[key]
+	if !ok {
+		return nil, errors.New("section not found")
+	}
+	return section, nil
+}
+
+// recordLogger records the messages by their levels.
+type recordLogger struct {
+	mu       sync.Mutex
+	messages map[string][]string
+}
+
+func (l *recordLogger) record(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.messages == nil {
+		l.messages = make(map[string][]string)
+	}
+	l.messages[level] = append(l.messages[level], msg)
+}
+
+func (l *recordLogger) Info(_ context.Context, msg string, _ ...any)  { l.record("info", msg) }
+func (l *recordLogger) Warn(_ context.Context, msg string, _ ...any)  { l.record("warn", msg) }
+func (l *recordLogger) Error(_ context.Context, msg string, _ ...any) { l.record("error", msg) }
+
+// writeConfig writes the config file and makes viper read it.
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600), "expected nil, got error")
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig(), "expected nil, got error")
+}
+
+func TestChangedKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prev     map[string]any
+		curr     map[string]any
+		expected []string
+	}{
+		{"no changes", map[string]any{"app.retries": 1}, map[string]any{"app.retries": 1}, []string{}},
+		{"both empty", map[string]any{}, map[string]any{}, []string{}},
+		{"changed value", map[string]any{"app.retries": 1}, map[string]any{"app.retries": 2}, []string{"app.retries"}},
+		{"changed type", map[string]any{"app.retries": 1}, map[string]any{"app.retries": "1"}, []string{"app.retries"}},
+		{"added key", map[string]any{}, map[string]any{"logger.level": "info"}, []string{"logger.level"}},
+		{"removed key", map[string]any{"logger.level": "info"}, map[string]any{}, []string{"logger.level"}},
+		{
+			"changed slice",
+			map[string]any{"sql.replicas": []any{"host1"}},
+			map[string]any{"sql.replicas": []any{"host1", "host2"}},
+			[]string{"sql.replicas"},
+		},
+		{
+			"sorted keys",
+			map[string]any{"b": 1, "c": 1, "d": 1},
+			map[string]any{"a": 1, "b": 2, "c": 1},
+			[]string{"a", "b", "d"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, changedKeys(tC.prev, tC.curr))
+		})
+	}
+}
+
+func TestFlattenSettings(t *testing.T) {
+	settings := map[string]any{
+		"app": map[string]any{"retries": 1},
+		"storage": map[string]any{
+			"type": "sql",
+			"sql":  map[string]any{"host": "localhost"},
+		},
+		"version": false,
+	}
+	require.Equal(t, map[string]any{
+		"app.retries":      1,
+		"storage.type":     "sql",
+		"storage.sql.host": "localhost",
+		"version":          false,
+	}, flattenSettings(settings))
+}
+
+func TestReloaderCovers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		reloader Reloader
+		key      string
+		expected bool
+	}{
+		{"any key of the section", Reloader{Section: "logger"}, "logger.level", true},
+		{"listed key", Reloader{Section: "app", Keys: []string{"retries"}}, "app.retries", true},
+		{"not listed key", Reloader{Section: "app", Keys: []string{"retries"}}, "app.cache_size", false},
+		{"other section", Reloader{Section: "app"}, "logger.level", false},
+		{"section name prefix", Reloader{Section: "app"}, "application.retries", false},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, tC.reloader.covers(tC.key))
+		})
+	}
+}
+
+//nolint:funlen
+func TestWatcherReload(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeConfig(t, path, "[app]\nretries = 1\n\n[logger]\nlevel = \"info\"\n\n[http]\nport = \"8080\"\n")
+
+	var appErr error
+	applied := make(map[string][]map[string]any)
+	reloader := func(section string, keys ...string) Reloader {
+		return Reloader{Section: section, Keys: keys, Apply: func(cfg map[string]any) error {
+			if section == "app" && appErr != nil {
+				return appErr
+			}
+			applied[section] = append(applied[section], cfg)
+			return nil
+		}}
+	}
+
+	logger := &recordLogger{}
+	w := &watcher{
+		ctx:       context.Background(),
+		cfgType:   reflect.TypeOf(&testConfig{}),
+		settings:  flattenSettings(viper.AllSettings()),
+		reloaders: []Reloader{reloader("app", "retries"), reloader("logger")},
+		l:         logger,
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		w.reload("test")
+		require.Empty(t, applied)
+		require.Contains(t, logger.messages["info"], "config has no changes")
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		appErr = errors.New("invalid retries")
+		writeConfig(t, path, "[app]\nretries = 2\n\n[logger]\nlevel = \"debug\"\n\n[http]\nport = \"8081\"\n")
+
+		w.reload("test")
+		require.Len(t, applied["logger"], 1, "valid section is not applied")
+		require.Equal(t, "debug", applied["logger"][0]["level"])
+		require.Empty(t, applied["app"])
+		require.Contains(t, logger.messages["error"], "reload config section, keeping the current settings")
+		require.Contains(t, logger.messages["warn"], "changed settings require a restart to take effect")
+
+		// Failed section keeps the previous values, so it is retried on the next reload.
+		require.Equal(t, int64(1), w.settings["app.retries"])
+		require.Equal(t, "debug", w.settings["logger.level"])
+		require.Equal(t, "8081", w.settings["http.port"], "restart-only keys are not expected to be retried")
+	})
+
+	t.Run("retry of the failed section", func(t *testing.T) {
+		appErr = nil
+		w.reload("test")
+		require.Len(t, applied["app"], 1, "failed section is not retried")
+		require.Equal(t, int64(2), applied["app"][0]["retries"])
+		require.Len(t, applied["logger"], 1, "applied section is applied twice")
+		require.Equal(t, int64(2), w.settings["app.retries"])
+	})
+
+	t.Run("restart-only keys of the failed section", func(t *testing.T) {
+		appErr = errors.New("invalid retries")
+		writeConfig(t, path, "[app]\nretries = 2\ncache_size = 10\n\n[logger]\nlevel = \"debug\"\n\n"+
+			"[http]\nport = \"8081\"\n")
+		w.reload("test")
+		_, ok := w.settings["app.cache_size"]
+		require.True(t, ok, "key not covered by the failed reloader is rolled back")
+
+		writeConfig(t, path, "[app]\nretries = 3\n\n[logger]\nlevel = \"debug\"\n\n[http]\nport = \"8081\"\n")
+		w.reload("test")
+		_, ok = w.settings["app.cache_size"]
+		require.False(t, ok, "removed key is kept")
+		require.Equal(t, int64(2), w.settings["app.retries"], "failed key is not rolled back")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		canceled := &watcher{ctx: ctx, l: logger}
+		canceled.reload("test")
+		require.Empty(t, canceled.settings)
+	})
+}
+
+// TestWatcherTriggers fires the file changes and SIGHUP at the same time. Run with -race to check
+// that viper is not accessed concurrently.
+func TestWatcherTriggers(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeConfig(t, path, "[app]\nretries = 0\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	var retries int64
+	w := &watcher{
+		ctx:      ctx,
+		cfgType:  reflect.TypeOf(&testConfig{}),
+		settings: flattenSettings(viper.AllSettings()),
+		reloaders: []Reloader{{Section: "app", Apply: func(cfg map[string]any) error {
+			mu.Lock()
+			defer mu.Unlock()
+			retries, _ = cfg["retries"].(int64)
+			return nil
+		}}},
+		l: &recordLogger{},
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	require.NoError(t, err, "expected nil, got error")
+	require.NoError(t, fw.Add(filepath.Dir(path)), "expected nil, got error")
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(path, fw, sig)
+	}()
+
+	const changes = 20
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= changes; i++ {
+			content := fmt.Sprintf("[app]\nretries = %d\n", i)
+			assert.NoError(t, os.WriteFile(path, []byte(content), 0o600), "expected nil, got error")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range changes {
+			assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP), "expected nil, got error")
+		}
+	}()
+	wg.Wait()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return retries == changes
+	}, 5*time.Second, 10*time.Millisecond, "last change is not applied")
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,62 @@
+package kafka
+
+// File contains declarations for expected configuration fields based on the client type.
+
+import "time"
+
+// contentTypeHeader is the message header carrying the content type.
+const contentTypeHeader = "content-type"
+
+// expectedFields is a map of expected configuration fields and their default values.
+var expectedFieldsFull = map[string]any{
+	"host":     "",
+	"port":     "",
+	"user":     "",
+	"password": "",
+
+	"timeout":       time.Duration(0),
+	"retry_timeout": time.Duration(0),
+	"retries":       0,
+
+	"topic":              "",
+	"partitions":         0, // 0 means a single partition.
+	"replication_factor": 0, // 0 means a single replica.
+
+	"group":         "",               // Consumer group ID.
+	"resub_timeout": time.Duration(0), // Resubscription timeout for the consumer.
+	"auto_ack":      false,
+	"requeue":       false,
+}
+
+var expectedFieldsConsumer = map[string]any{
+	"host":     "",
+	"port":     "",
+	"user":     "",
+	"password": "",
+
+	"timeout":       time.Duration(0),
+	"retry_timeout": time.Duration(0),
+	"retries":       0,
+
+	"topic": "",
+
+	"group":         "",
+	"resub_timeout": time.Duration(0),
+	"auto_ack":      false,
+	"requeue":       false,
+}
+
+var expectedFieldsProducer = map[string]any{
+	"host":     "",
+	"port":     "",
+	"user":     "",
+	"password": "",
+
+	"timeout":       time.Duration(0),
+	"retry_timeout": time.Duration(0),
+	"retries":       0,
+
+	"topic":              "",
+	"partitions":         0,
+	"replication_factor": 0,
+}
@@ -0,0 +1,272 @@
+// Package kafka provides a Kafka client, which is suitable for sending and receiving messages.
+// It is expected to work with a single topic and a single consumer group.
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+	kafkago "github.com/segmentio/kafka-go"                             //nolint:depguard,nolintlint
+	"github.com/segmentio/kafka-go/sasl/plain"                          //nolint:depguard,nolintlint
+)
+
+// ClientType represents a Kafka client type.
+type ClientType int
+
+const (
+	// FullClient matches a full client configuration.
+	FullClient ClientType = iota
+	// ProducerOnly matches a client configuration with producer-only fields.
+	ProducerOnly
+	// ConsumerOnly matches a client configuration with consumer-only fields.
+	ConsumerOnly
+)
+
+// Kafka represents a Kafka client.
+type Kafka struct {
+	mu sync.RWMutex
+
+	dialer     *kafkago.Dialer
+	writer     *kafkago.Writer
+	reader     *kafkago.Reader // Reader of the current consumer subscription.
+	clientType ClientType
+
+	addr         string
+	user         string
+	password     string
+	timeout      time.Duration
+	retryTimeout time.Duration
+	retries      int
+
+	topic             string
+	partitions        int
+	replicationFactor int
+
+	group        string        // Consumer group ID.
+	resubTimeout time.Duration // Resubscription timeout for the consumer.
+	autoAck      bool
+	requeue      bool
+
+	l Logger
+}
+
+// NewKafka creates a new Kafka client.
+// Supports partial configuration for different client types: consumer, producer or full client.
+// Unknown client type defaults to FullClient.
+func NewKafka(logger Logger, cfg map[string]any, typ ClientType) (*Kafka, error) {
+	// Args validation.
+	if cfg == nil {
+		return nil, fmt.Errorf("no configuration passed to Kafka constructor")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("invalid Kafka config: missing=[logger]")
+	}
+	if typ != ProducerOnly && typ != ConsumerOnly {
+		typ = FullClient
+	}
+	if err := ValidateConfig(cfg, typ); err != nil {
+		return nil, err
+	}
+
+	// Extract from config an normalize the value.
+	full := mapToFullClient(cfg)
+
+	// Init the full version regardless of the client type.
+	return &Kafka{
+		l:                 logger,
+		clientType:        typ,
+		addr:              net.JoinHostPort(full["host"].(string), full["port"].(string)),
+		user:              full["user"].(string),
+		password:          full["password"].(string),
+		timeout:           full["timeout"].(time.Duration),
+		retryTimeout:      full["retry_timeout"].(time.Duration),
+		retries:           max(full["retries"].(int), 0),
+		topic:             full["topic"].(string),
+		partitions:        max(full["partitions"].(int), 1),
+		replicationFactor: max(full["replication_factor"].(int), 1),
+		group:             full["group"].(string),
+		autoAck:           full["auto_ack"].(bool),
+		requeue:           full["requeue"].(bool),
+		resubTimeout:      full["resub_timeout"].(time.Duration),
+	}, nil
+}
+
+// ValidateConfig validates the config of the given client type without creating a client.
+// Unknown client type is validated as FullClient. Problems are returned as *config.ValidationError.
+func ValidateConfig(cfg map[string]any, typ ClientType) error {
+	var reqFields map[string]any
+	switch typ {
+	case ProducerOnly:
+		reqFields = expectedFieldsProducer
+	case ConsumerOnly:
+		reqFields = expectedFieldsConsumer
+	default:
+		typ = FullClient
+		reqFields = expectedFieldsFull
+	}
+
+	ve := &config.ValidationError{}
+	ve.Missing, ve.InvalidType = validateFields(cfg, reqFields)
+
+	if retryTimeout, ok := cfg["retry_timeout"].(time.Duration); ok && retryTimeout <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "retry_timeout")
+	}
+	if topic, ok := cfg["topic"].(string); ok && topic == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "topic")
+	}
+	if typ != ProducerOnly {
+		if resubTimeout, ok := cfg["resub_timeout"].(time.Duration); ok && resubTimeout <= 0 {
+			ve.InvalidValue = append(ve.InvalidValue, "resub_timeout")
+		}
+		if group, ok := cfg["group"].(string); ok && group == "" {
+			ve.InvalidValue = append(ve.InvalidValue, "group")
+		}
+	}
+
+	if ve.HasErrors() {
+		return fmt.Errorf("invalid Kafka config: %w", ve)
+	}
+	return nil
+}
+
+// Reload applies the settings which might be changed at runtime: retries, retry_timeout and, for the clients
+// with consumer part, resub_timeout. Connection and topic settings require reconnection and are not reloaded.
+// Returns an error if the config is invalid, keeping the current settings.
+func (k *Kafka) Reload(cfg map[string]any) error {
+	reqFields := map[string]any{"retries": 0, "retry_timeout": time.Duration(0)}
+	if k.clientType != ProducerOnly {
+		reqFields["resub_timeout"] = time.Duration(0)
+	}
+	missing, wrongType := validateFields(cfg, reqFields)
+	if len(missing) > 0 || len(wrongType) > 0 {
+		return fmt.Errorf("invalid Kafka config: missing=%v invalid_type=%v", missing, wrongType)
+	}
+
+	retryTimeout, _ := cfg["retry_timeout"].(time.Duration)
+	if retryTimeout <= 0 {
+		return fmt.Errorf("invalid config data: retry timeout must be positive, got %v", retryTimeout)
+	}
+	resubTimeout, _ := cfg["resub_timeout"].(time.Duration)
+	if resubTimeout <= 0 && k.clientType != ProducerOnly {
+		return fmt.Errorf("invalid config data: resubscription timeout must be positive, got %v", resubTimeout)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.retries = max(cfg["retries"].(int), 0)
+	k.retryTimeout = retryTimeout
+	if k.clientType != ProducerOnly {
+		k.resubTimeout = resubTimeout
+	}
+	return nil
+}
+
+// Connect to the Kafka cluster and ensure the topic exists.
+// Producer and full clients create the missing topic, consumer-only client requires it to exist.
+func (k *Kafka) Connect(ctx context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	dialer := &kafkago.Dialer{
+		ClientID:  "calendar",
+		Timeout:   k.timeout,
+		DualStack: true,
+	}
+	if k.user != "" {
+		dialer.SASLMechanism = plain.Mechanism{Username: k.user, Password: k.password}
+	}
+
+	localCtx := ctx
+	if k.timeout > 0 {
+		var cancel context.CancelFunc
+		localCtx, cancel = context.WithTimeout(ctx, k.timeout)
+		defer cancel()
+	}
+
+	conn, err := dialer.DialContext(localCtx, "tcp", k.addr)
+	if err != nil {
+		return fmt.Errorf("message queue connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Consumer-only logic shortcut.
+	if k.clientType == ConsumerOnly {
+		ok, err := k.isTopicExists(conn)
+		if err != nil {
+			return fmt.Errorf("unexpected error on consumer message queue check: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("consumer message queue does not exist")
+		}
+		k.dialer = dialer
+		return nil
+	}
+
+	// Producer-only logic follows the full client scenario.
+	err = k.initTopic(localCtx, dialer, conn)
+	if err != nil {
+		return fmt.Errorf("producer message queue init: %w", err)
+	}
+	k.dialer = dialer
+	k.writer = &kafkago.Writer{
+		Addr:         kafkago.TCP(k.addr),
+		Topic:        k.topic,
+		BatchSize:    1,                  // Notifications are sent one by one, no need to wait for the batch.
+		MaxAttempts:  1,                  // Retries are handled by the client.
+		RequiredAcks: kafkago.RequireAll, // All in-sync replicas.
+		Transport: &kafkago.Transport{
+			ClientID:    dialer.ClientID,
+			DialTimeout: dialer.Timeout,
+			SASL:        dialer.SASLMechanism,
+		},
+	}
+
+	return nil
+}
+
+// Close the connection to the Kafka cluster.
+func (k *Kafka) Close(_ context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.dialer = nil
+	if k.writer == nil && k.reader == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if k.writer != nil {
+		err := k.writer.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		// The writer does not own the transport, so its connections are closed separately.
+		if transport, ok := k.writer.Transport.(*kafkago.Transport); ok {
+			transport.CloseIdleConnections()
+		}
+		k.writer = nil
+	}
+
+	if k.reader != nil {
+		err := k.reader.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		k.reader = nil
+	}
+
+	var err error
+	for i := range errs {
+		err = fmt.Errorf("%w: %w", errs[i], err)
+	}
+	if err != nil {
+		return fmt.Errorf("message queue close: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+	kafkago "github.com/segmentio/kafka-go"                             //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                               //nolint:depguard,nolintlint
+)
+
+// discardLogger is a logger, which drops all messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+// validConfig returns a valid full client config.
+func validConfig() map[string]any {
+	return map[string]any{
+		"host":               "localhost",
+		"port":               "9092",
+		"user":               "",
+		"password":           "",
+		"timeout":            time.Second,
+		"retry_timeout":      100 * time.Millisecond,
+		"retries":            3,
+		"topic":              "notifications",
+		"partitions":         0,
+		"replication_factor": 0,
+		"group":              "sender",
+		"resub_timeout":      time.Second,
+		"auto_ack":           false,
+		"requeue":            true,
+	}
+}
+
+// withChanges returns a copy of the config with the given values replaced. Nil values are removed.
+func withChanges(cfg, changes map[string]any) map[string]any {
+	res := make(map[string]any, len(cfg))
+	for k, v := range cfg {
+		res[k] = v
+	}
+	for k, v := range changes {
+		if v == nil {
+			delete(res, k)
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+func TestValidateConfig(t *testing.T) {
+	producerOnly := withChanges(validConfig(), map[string]any{
+		"group": nil, "resub_timeout": nil, "auto_ack": nil, "requeue": nil,
+	})
+	consumerOnly := withChanges(validConfig(), map[string]any{"partitions": nil, "replication_factor": nil})
+
+	testCases := []struct {
+		name         string
+		cfg          map[string]any
+		typ          ClientType
+		missing      []string
+		invalidType  []string
+		invalidValue []string
+	}{
+		{name: "full client", cfg: validConfig(), typ: FullClient},
+		{name: "producer only", cfg: producerOnly, typ: ProducerOnly},
+		{name: "consumer only", cfg: consumerOnly, typ: ConsumerOnly},
+		{name: "unknown type is full client", cfg: producerOnly, typ: ClientType(42),
+			missing: []string{"auto_ack", "group", "requeue", "resub_timeout"}},
+		{name: "producer part missing", cfg: consumerOnly, typ: FullClient,
+			missing: []string{"partitions", "replication_factor"}},
+		{name: "consumer part missing", cfg: producerOnly, typ: ConsumerOnly,
+			missing: []string{"auto_ack", "group", "requeue", "resub_timeout"}},
+		{
+			name:        "invalid types",
+			cfg:         withChanges(validConfig(), map[string]any{"port": 9092, "timeout": "1s", "auto_ack": "yes"}),
+			typ:         FullClient,
+			invalidType: []string{"auto_ack", "port", "timeout"},
+		},
+		{
+			name: "invalid values",
+			cfg: withChanges(validConfig(), map[string]any{
+				"retry_timeout": time.Duration(0), "topic": "", "group": "", "resub_timeout": -time.Second,
+			}),
+			typ:          FullClient,
+			invalidValue: []string{"group", "resub_timeout", "retry_timeout", "topic"},
+		},
+		{
+			name:         "consumer values of producer are not checked",
+			cfg:          withChanges(producerOnly, map[string]any{"group": "", "resub_timeout": time.Duration(0)}),
+			typ:          ProducerOnly,
+			invalidValue: nil,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			err := ValidateConfig(tC.cfg, tC.typ)
+			if tC.missing == nil && tC.invalidType == nil && tC.invalidValue == nil {
+				require.NoError(t, err, "expected nil, got error")
+				return
+			}
+			var ve *config.ValidationError
+			require.ErrorAs(t, err, &ve)
+			require.ElementsMatch(t, tC.missing, ve.Missing, "unexpected missing fields")
+			require.ElementsMatch(t, tC.invalidType, ve.InvalidType, "unexpected invalid type fields")
+			require.ElementsMatch(t, tC.invalidValue, ve.InvalidValue, "unexpected invalid value fields")
+		})
+	}
+}
+
+func TestNewKafka(t *testing.T) {
+	t.Run("normalized values", func(t *testing.T) {
+		k, err := NewKafka(discardLogger{}, withChanges(validConfig(), map[string]any{"retries": -1}), FullClient)
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, "localhost:9092", k.addr)
+		require.Zero(t, k.retries)
+		require.Equal(t, 1, k.partitions, "zero partitions are not defaulted")
+		require.Equal(t, 1, k.replicationFactor, "zero replication factor is not defaulted")
+		require.Equal(t, "sender", k.group)
+		require.True(t, k.requeue)
+	})
+
+	t.Run("producer defaults", func(t *testing.T) {
+		cfg := withChanges(validConfig(), map[string]any{
+			"group": nil, "resub_timeout": nil, "auto_ack": nil, "requeue": nil, "partitions": 3,
+		})
+		k, err := NewKafka(discardLogger{}, cfg, ProducerOnly)
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, ProducerOnly, k.clientType)
+		require.Equal(t, 3, k.partitions)
+		require.Empty(t, k.group)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		k, err := NewKafka(discardLogger{}, validConfig(), ClientType(42))
+		require.NoError(t, err, "expected nil, got error")
+		require.Equal(t, FullClient, k.clientType)
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		_, err := NewKafka(discardLogger{}, nil, FullClient)
+		require.Error(t, err)
+		_, err = NewKafka(nil, validConfig(), FullClient)
+		require.Error(t, err)
+		_, err = NewKafka(discardLogger{}, withChanges(validConfig(), map[string]any{"topic": ""}), FullClient)
+		var ve *config.ValidationError
+		require.ErrorAs(t, err, &ve)
+	})
+}
+
+func TestReload(t *testing.T) {
+	testCases := []struct {
+		name    string
+		typ     ClientType
+		cfg     map[string]any
+		wantErr bool
+	}{
+		{"full client", FullClient, map[string]any{
+			"retries": 5, "retry_timeout": time.Second, "resub_timeout": 2 * time.Second,
+		}, false},
+		{"producer without resub timeout", ProducerOnly, map[string]any{
+			"retries": 5, "retry_timeout": time.Second,
+		}, false},
+		{"consumer without resub timeout", ConsumerOnly, map[string]any{
+			"retries": 5, "retry_timeout": time.Second,
+		}, true},
+		{"invalid type", FullClient, map[string]any{
+			"retries": "5", "retry_timeout": time.Second, "resub_timeout": time.Second,
+		}, true},
+		{"non-positive retry timeout", FullClient, map[string]any{
+			"retries": 5, "retry_timeout": time.Duration(0), "resub_timeout": time.Second,
+		}, true},
+		{"non-positive resub timeout", ConsumerOnly, map[string]any{
+			"retries": 5, "retry_timeout": time.Second, "resub_timeout": -time.Second,
+		}, true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			k, err := NewKafka(discardLogger{}, validConfig(), tC.typ)
+			require.NoError(t, err, "expected nil, got error")
+
+			err = k.Reload(tC.cfg)
+			if tC.wantErr {
+				require.Error(t, err, "expected error, got nil")
+				require.Equal(t, 3, k.retries, "settings are changed on error")
+				require.Equal(t, 100*time.Millisecond, k.retryTimeout, "settings are changed on error")
+				require.Equal(t, time.Second, k.resubTimeout, "settings are changed on error")
+				return
+			}
+			require.NoError(t, err, "expected nil, got error")
+			require.Equal(t, 5, k.retries)
+			require.Equal(t, time.Second, k.retryTimeout)
+			if tC.typ != ProducerOnly {
+				require.Equal(t, 2*time.Second, k.resubTimeout)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"EOF", fmt.Errorf("read: %w", io.EOF), true},
+		{"connection reset", syscall.ECONNRESET, true},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), true},
+		{"uninitialized", ErrUninitialized, true},
+		{"timeout", fmt.Errorf("%w: %w", errTimeoutExceeded, context.DeadlineExceeded), true},
+		{"temporary kafka error", kafkago.LeaderNotAvailable, true},
+		{"permanent kafka error", kafkago.TopicAuthorizationFailed, false},
+		{"other error", errors.New("unexpected"), false},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			require.Equal(t, tC.expected, isRetryable(tC.err))
+		})
+	}
+}
+
+func TestUnreachableBroker(t *testing.T) {
+	// Port of the closed listener refuses the connections.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "expected nil, got error")
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err, "expected nil, got error")
+	require.NoError(t, lis.Close())
+
+	k, err := NewKafka(discardLogger{}, withChanges(validConfig(), map[string]any{
+		"host": "127.0.0.1", "port": port, "retries": 0,
+	}), FullClient)
+	require.NoError(t, err, "expected nil, got error")
+
+	require.NoError(t, k.Close(context.Background()), "closing the not connected client fails")
+	require.ErrorIs(t, k.Connect(context.Background()), syscall.ECONNREFUSED)
+	// Produce of the not connected client tries to reconnect.
+	require.ErrorIs(t, k.Produce(context.Background(), []byte("msg")), ErrFatal)
+}
@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// withTimeout wraps the given function in a context.WithTimeout call.
+func (k *Kafka) withTimeout(ctx context.Context, fn func(context.Context) error) error {
+	k.mu.RLock()
+	if k.dialer == nil {
+		k.mu.RUnlock()
+		return ErrUninitialized
+	}
+	timeout := k.timeout
+	k.mu.RUnlock()
+
+	localCtx := ctx
+	var cancel context.CancelFunc
+
+	if timeout > 0 {
+		localCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := fn(localCtx)
+	if err != nil {
+		if errors.Is(localCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w: %w", errTimeoutExceeded, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// withRetries is a middleware that implements retry logic for Kafka calls.
+//
+// It tries to execute the given function with configured retries and timeout.
+// Each attempt is logged with DEBUG level.
+//
+// If the connection is unavailable, it will try to reconnect. Failed reconnection is logged with ERROR
+// and returned wrapped with ErrFatal.
+//
+// Receiving any other error stops execution and returns the error.
+//
+// If retry limit is exceeded, ErrRetriesExceeded is returned, wrapped over the last error.
+func (k *Kafka) withRetries(ctx context.Context, method string, fn func() error) error {
+	k.mu.RLock()
+	attempts := k.retries + 1 // Guarantees at least one attempt.
+	timeout := k.retryTimeout
+	k.mu.RUnlock()
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+
+		if !isRetryable(lastErr) {
+			k.l.Error(
+				ctx,
+				"unexpected error occurred",
+				slog.String("method", method),
+				slog.Any("error", lastErr),
+			)
+			return lastErr
+		}
+
+		k.l.Debug(
+			ctx,
+			"operation failed, attempting to reconnect",
+			slog.String("method", method),
+			slog.Int("attempt", i+1),
+			slog.Any("error", lastErr),
+		)
+
+		// Closing and reestablishing connection.
+		_ = k.Close(ctx)
+		if err := k.Connect(ctx); err != nil {
+			k.l.Error(
+				ctx,
+				"reestablishing connection",
+				slog.String("method", method),
+				slog.Int("attempt", i+1),
+				slog.Any("error", err),
+			)
+			return fmt.Errorf("%w: %w", ErrFatal, err)
+		}
+
+		// No wait on last attempt.
+		if i < attempts-1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(timeout):
+				continue
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %w", ErrRetriesExceeded, lastErr)
+}
@@ -0,0 +1,198 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"                //nolint:depguard,nolintlint
+	kafkago "github.com/segmentio/kafka-go" //nolint:depguard,nolintlint
+)
+
+// Produce sends a message to the topic using retry logic and operation timeout.
+func (k *Kafka) Produce(ctx context.Context, payload []byte) error {
+	return k.ProduceWithContentType(ctx, payload, "")
+}
+
+// ProduceWithContentType sends a message the same way as Produce, passing the content type
+// in the content-type message header. Empty content type is not passed.
+func (k *Kafka) ProduceWithContentType(ctx context.Context, payload []byte, contentType string) error {
+	msgID := uuid.New().String()
+	msg := kafkago.Message{Key: []byte(msgID), Value: payload}
+	if contentType != "" {
+		msg.Headers = []kafkago.Header{{Key: contentTypeHeader, Value: []byte(contentType)}}
+	}
+	err := k.withRetries(ctx, "produce", func() error {
+		return k.withTimeout(ctx, func(localCtx context.Context) error {
+			k.mu.RLock()
+			writer := k.writer
+			k.mu.RUnlock()
+			if writer == nil {
+				return ErrUninitialized
+			}
+			return writer.WriteMessages(localCtx, msg)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	k.l.Debug(ctx, "message successfully sent", slog.String("message_id", msgID))
+	return nil
+}
+
+// Consume consumes messages from the topic using retry logic and operation timeout.
+// The methods abstracts the logic of consuming messages from the topic.
+func (k *Kafka) Consume(ctx context.Context) (<-chan []byte, <-chan error) {
+	resQueue := make(chan []byte)
+	errors := make(chan error)
+
+	k.mu.RLock()
+	requeue := k.requeue
+	autoAck := k.autoAck
+	k.mu.RUnlock()
+
+	// Looping until the context is cancelled.
+	// The loop updates the subscription and populates the consumer with data until the subscription ends.
+	go func() {
+		defer close(errors)
+		defer close(resQueue)
+
+		for {
+			// Updating the subscription.
+			reader, err := k.startConsumer(ctx)
+			if err != nil {
+				select {
+				case errors <- err:
+				case <-ctx.Done():
+				}
+				return
+			}
+			// Populating the consumer until the subscription ends or context cancellation.
+			k.populateConsumer(ctx, reader, resQueue, autoAck, requeue)
+			k.stopConsumer(reader)
+
+			// The timeout is read on each resubscription, as it might be reloaded.
+			k.mu.RLock()
+			resubTimeout := k.resubTimeout
+			k.mu.RUnlock()
+
+			select {
+			case <-ctx.Done():
+				k.l.Warn(ctx, "context done before during consuming process")
+				return
+			case <-time.After(resubTimeout):
+				continue
+			}
+		}
+	}()
+
+	return resQueue, errors
+}
+
+// populateConsumer reads messages from the topic and sends raw data to the result channel.
+// With auto ack the offset is committed on read. Otherwise it is committed on receipt and, if the commit fails,
+// the message is not delivered: with requeue the subscription is restarted from the last committed offset,
+// without it the message is dropped.
+func (k *Kafka) populateConsumer(
+	ctx context.Context,
+	reader *kafkago.Reader,
+	resQueue chan<- []byte,
+	autoAck,
+	requeue bool,
+) {
+	for {
+		var msg kafkago.Message
+		var err error
+		if autoAck {
+			msg, err = reader.ReadMessage(ctx)
+		} else {
+			msg, err = reader.FetchMessage(ctx)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				// Consumer needs to be resubscribed.
+				k.l.Warn(ctx, "consumer subscription interrupted", slog.Any("error", err))
+			}
+			return
+		}
+		msgID := string(msg.Key)
+
+		if !autoAck {
+			if err := reader.CommitMessages(ctx, msg); err != nil {
+				k.l.Warn(ctx, "message ack failed", slog.String("message_id", msgID), slog.Any("error", err))
+				if requeue {
+					return
+				}
+				k.l.Error(ctx, "message dropped", slog.String("message_id", msgID))
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			k.l.Warn(ctx, "context done before the message extracted")
+			return
+		case resQueue <- msg.Value:
+			k.l.Debug(ctx, "message successfully received", slog.String("message_id", msgID))
+		}
+	}
+}
+
+// startConsumer checks the topic and joins the consumer group using retry logic and operation timeout.
+func (k *Kafka) startConsumer(ctx context.Context) (*kafkago.Reader, error) {
+	err := k.withRetries(ctx, "consume", func() error {
+		return k.withTimeout(ctx, func(localCtx context.Context) error {
+			k.mu.RLock()
+			dialer := k.dialer
+			k.mu.RUnlock()
+			if dialer == nil {
+				return ErrUninitialized
+			}
+
+			conn, err := dialer.DialContext(localCtx, "tcp", k.addr)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			ok, err := k.isTopicExists(conn)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("consumer message queue does not exist")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("subscribe consumer: %w", err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.dialer == nil {
+		return nil, fmt.Errorf("subscribe consumer: %w", ErrUninitialized)
+	}
+	k.reader = kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:        []string{k.addr},
+		GroupID:        k.group,
+		Topic:          k.topic,
+		Dialer:         k.dialer,
+		StartOffset:    kafkago.FirstOffset,
+		CommitInterval: 0, // Synchronous commits.
+	})
+
+	return k.reader, nil
+}
+
+// stopConsumer closes the reader of the finished subscription.
+func (k *Kafka) stopConsumer(reader *kafkago.Reader) {
+	k.mu.Lock()
+	if k.reader == reader {
+		k.reader = nil
+	}
+	k.mu.Unlock()
+	_ = reader.Close()
+}
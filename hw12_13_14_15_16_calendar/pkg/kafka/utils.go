@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	kafkago "github.com/segmentio/kafka-go" //nolint:depguard,nolintlint
+)
+
+// mapToFullClient creates a new map with default values for FullClient configuration.
+func mapToFullClient(cfg map[string]any) map[string]any {
+	newCfg := make(map[string]any, len(expectedFieldsFull))
+	for k := range expectedFieldsFull {
+		// Use values from config if they are present.
+		if v, ok := cfg[k]; ok {
+			newCfg[k] = v
+			continue
+		}
+		// Null value otherwise.
+		newCfg[k] = expectedFieldsFull[k]
+	}
+	return newCfg
+}
+
+// isTopicExists checks if the topic exists.
+//
+// Method does not use locks.
+func (k *Kafka) isTopicExists(conn *kafkago.Conn) (bool, error) {
+	_, err := conn.ReadPartitions(k.topic)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, kafkago.UnknownTopicOrPartition) {
+		return false, nil
+	}
+	return false, fmt.Errorf("topic check: %w", err)
+}
+
+// initTopic creates the topic if it does not exist. Topics are created by the controller broker.
+//
+// Method does not use locks.
+func (k *Kafka) initTopic(ctx context.Context, dialer *kafkago.Dialer, conn *kafkago.Conn) error {
+	ok, err := k.isTopicExists(conn)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	controller, err := conn.Controller()
+	if err != nil {
+		return fmt.Errorf("controller lookup: %w", err)
+	}
+	controllerConn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		return fmt.Errorf("controller connection: %w", err)
+	}
+	defer controllerConn.Close()
+
+	err = controllerConn.CreateTopics(kafkago.TopicConfig{
+		Topic:             k.topic,
+		NumPartitions:     k.partitions,
+		ReplicationFactor: k.replicationFactor,
+	})
+	if err != nil && !errors.Is(err, kafkago.TopicAlreadyExists) {
+		return fmt.Errorf("topic declaration: %w", err)
+	}
+	return nil
+}
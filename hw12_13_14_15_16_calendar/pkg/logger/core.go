@@ -8,7 +8,8 @@ import (
 
 // Logger is a wrapper structure for an underlying logger.
 type Logger struct {
-	l *slog.Logger
+	l    *slog.Logger
+	root *handlerRoot // Shared by the derived loggers to apply Reload to all of them.
 }
 
 func (logg Logger) addRequestContext(ctx context.Context, args ...any) []any {
@@ -51,5 +52,5 @@ func (logg Logger) Fatal(ctx context.Context, msg string, args ...any) {
 
 // With returns a new Logger that adds the given key-value pairs to the logger's context.
 func (logg Logger) With(args ...any) *Logger {
-	return &Logger{logg.l.With(args...)}
+	return &Logger{logg.l.With(args...), logg.root}
 }
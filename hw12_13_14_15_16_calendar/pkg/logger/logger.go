@@ -9,6 +9,8 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
 )
 
 // Option defines a function that allows to configure underlying logger on construction.
@@ -59,8 +61,8 @@ func WithConfig(cfg map[string]any) Option {
 			"log_stream":    "",
 		}
 
-		ve := &validationError{}
-		ve.invalidTypes = validateTypes(cfg, optionalFields)
+		ve := &config.ValidationError{}
+		ve.InvalidType = validateTypes(cfg, optionalFields)
 
 		validateLogLevel(cfg, ve)
 		validateTimeFormat(cfg, ve)
@@ -68,7 +70,7 @@ func WithConfig(cfg map[string]any) Option {
 		validateLogType(cfg, ve)
 
 		if ve.HasErrors() {
-			return fmt.Errorf("config data is invalid: %s", ve.Error())
+			return fmt.Errorf("config data is invalid: %w", ve)
 		}
 
 		if level, ok := cfg["level"]; ok {
@@ -111,6 +113,12 @@ func WithConfig(cfg map[string]any) Option {
 	}
 }
 
+// ValidateConfig validates the config of the same structure as for WithConfig without creating a logger.
+// Problems are returned as *config.ValidationError.
+func ValidateConfig(cfg map[string]any) error {
+	return WithConfig(cfg)(&Config{})
+}
+
 // WithWriter allows to apply custom configuration.
 func WithWriter(w io.Writer) Option {
 	return func(c *Config) error {
@@ -160,5 +168,6 @@ func NewLogger(opts ...Option) (*Logger, error) {
 		}
 	}
 
-	return &Logger{slog.New(cfg.handler)}, nil
+	root := &handlerRoot{cfg: *cfg, handler: cfg.handler}
+	return &Logger{slog.New(&swapHandler{root: root}), root}, nil
 }
@@ -460,3 +460,34 @@ func (s *LoggerTestSuite) TestInvalidConfigTypes() {
 		})
 	}
 }
+
+func (s *LoggerTestSuite) TestReload() {
+	l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "error"}), logger.WithWriter(s.writer))
+	s.Require().NoError(err, "got error, expected nil")
+	derived := l.With("layer", "test")
+
+	derived.Info(context.Background(), "before reload")
+	s.Require().Empty(s.writer.arr, "unexpected log before reload")
+
+	err = l.Reload(map[string]any{"level": "info", "format": "json", "time_template": "", "log_stream": ""})
+	s.Require().NoError(err, "got error, expected nil")
+
+	derived.Info(context.Background(), "after reload")
+	s.Require().Len(s.writer.arr, 1, "derived logger is not reloaded")
+	entry, err := decodeJSON(s.writer.arr[0])
+	s.Require().NoError(err, "failed to unmarshal log entry")
+	s.Require().Equal("after reload", entry.Msg, "unexpected log message")
+
+	err = l.Reload(map[string]any{"level": "unknown"})
+	s.Require().Error(err, "expected error for invalid config")
+	derived.Debug(context.Background(), "debug")
+	derived.Info(context.Background(), "info")
+	s.Require().Len(s.writer.arr, 2, "invalid config is applied")
+
+	err = l.Reload(map[string]any{"format": "text"})
+	s.Require().NoError(err, "got error, expected nil")
+	l.Error(context.Background(), "text")
+	s.Require().Len(s.writer.arr, 3, "unexpected amount of logs received")
+	_, err = decodeJSON(s.writer.arr[2])
+	s.Require().Error(err, "format is not reloaded")
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// handlerRoot is the handler shared by the logger and all loggers derived from it, which might be replaced at runtime.
+type handlerRoot struct {
+	mu      sync.RWMutex
+	cfg     Config // Config the current handler is built from.
+	handler slog.Handler
+	gen     uint64 // Incremented on each handler replacement.
+}
+
+// current returns the current handler and its generation.
+func (r *handlerRoot) current() (slog.Handler, uint64) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.handler, r.gen
+}
+
+// swapHandler delegates to the current root handler with the attributes and groups of the derived logger applied.
+// Derived handlers are rebuilt lazily once the root handler is replaced.
+type swapHandler struct {
+	root  *handlerRoot
+	steps []func(slog.Handler) slog.Handler // WithAttrs and WithGroup calls in their order.
+	cache atomic.Pointer[builtHandler]
+}
+
+type builtHandler struct {
+	handler slog.Handler
+	gen     uint64
+}
+
+// handler returns the root handler with the derived logger steps applied.
+func (h *swapHandler) handler() slog.Handler {
+	root, gen := h.root.current()
+	if built := h.cache.Load(); built != nil && built.gen == gen {
+		return built.handler
+	}
+	for _, step := range h.steps {
+		root = step(root)
+	}
+	h.cache.Store(&builtHandler{handler: root, gen: gen})
+	return root
+}
+
+// Enabled implements slog.Handler.
+func (h *swapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler().Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *swapHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler().Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *swapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+// WithGroup implements slog.Handler.
+func (h *swapHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *swapHandler) with(step func(slog.Handler) slog.Handler) slog.Handler {
+	steps := make([]func(slog.Handler) slog.Handler, len(h.steps), len(h.steps)+1)
+	copy(steps, h.steps)
+	return &swapHandler{root: h.root, steps: append(steps, step)}
+}
+
+// Reload applies the config of the same structure as for WithConfig on top of the current logger settings.
+// The change affects the logger and all loggers derived from it with With.
+//
+// Values are treated the same way as on construction, except for empty time_template and log_stream:
+// they keep the current settings, so a custom writer is kept unless log_stream is set.
+// Returns an error if the config is invalid, keeping the current settings.
+func (logg Logger) Reload(cfg map[string]any) error {
+	if logg.root == nil {
+		return errors.New("logger reload failed: logger is not initialized")
+	}
+
+	logg.root.mu.Lock()
+	defer logg.root.mu.Unlock()
+
+	newCfg := logg.root.cfg
+	newCfg.setupLevel = false
+	if err := WithConfig(cfg)(&newCfg); err != nil {
+		return fmt.Errorf("logger reload failed: %w", err)
+	}
+
+	logg.root.cfg = newCfg
+	logg.root.handler = newCfg.handler
+	logg.root.gen++
+	return nil
+}
@@ -4,38 +4,16 @@ import (
 	"reflect"
 	"strings"
 	"time"
-)
-
-// validationError is an error type for validation errors which follows error accumulation pattern.
-type validationError struct {
-	invalidTypes  []string
-	invalidValues []string
-}
-
-func (e *validationError) Error() string {
-	var b strings.Builder
-	if len(e.invalidTypes) > 0 {
-		b.WriteString("invalid_type=" + strings.Join(e.invalidTypes, ","))
-	}
-	if len(e.invalidValues) > 0 {
-		if b.Len() > 0 {
-			b.WriteString(", ")
-		}
-		b.WriteString("invalid_value=" + strings.Join(e.invalidValues, ","))
-	}
-	return b.String()
-}
 
-func (e *validationError) HasErrors() bool {
-	return len(e.invalidTypes) > 0 || len(e.invalidValues) > 0
-}
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+)
 
 // validateLogLevel is a helper that checks if log level is valid.
-func validateLogLevel(cfg map[string]any, ve *validationError) {
+func validateLogLevel(cfg map[string]any, ve *config.ValidationError) {
 	if val, ok := cfg["level"]; ok {
 		levelStr, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "level")
+			ve.InvalidType = append(ve.InvalidType, "level")
 			return
 		}
 		levelStr = strings.ToLower(levelStr)
@@ -43,17 +21,17 @@ func validateLogLevel(cfg map[string]any, ve *validationError) {
 		switch levelStr {
 		case "debug", "info", "warn", "error", "":
 		default:
-			ve.invalidValues = append(ve.invalidValues, "level")
+			ve.InvalidValue = append(ve.InvalidValue, "level")
 		}
 	}
 }
 
 // validateTimeFormat is a helper that checks if time format is valid.
-func validateTimeFormat(cfg map[string]any, ve *validationError) {
+func validateTimeFormat(cfg map[string]any, ve *config.ValidationError) {
 	if val, ok := cfg["time_template"]; ok {
 		timeTmpl, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "time_template")
+			ve.InvalidType = append(ve.InvalidType, "time_template")
 			return
 		}
 
@@ -65,41 +43,41 @@ func validateTimeFormat(cfg map[string]any, ve *validationError) {
 		formatted := testTime.Format(timeTmpl)
 		parsedTime, err := time.Parse(timeTmpl, formatted)
 		if err != nil || !parsedTime.Equal(testTime) {
-			ve.invalidValues = append(ve.invalidValues, "time_template")
+			ve.InvalidValue = append(ve.InvalidValue, "time_template")
 		}
 	}
 }
 
 // validateWriter is a helper that checks if writer is valid.
-func validateWriter(cfg map[string]any, ve *validationError) {
+func validateWriter(cfg map[string]any, ve *config.ValidationError) {
 	if val, ok := cfg["log_stream"]; ok {
 		writerStr, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "log_stream")
+			ve.InvalidType = append(ve.InvalidType, "log_stream")
 			return
 		}
 
 		switch writerStr {
 		case "stdout", "stderr", "":
 		default:
-			ve.invalidValues = append(ve.invalidValues, "log_stream")
+			ve.InvalidValue = append(ve.InvalidValue, "log_stream")
 		}
 	}
 }
 
 // validateLogType is a helper that checks if log type is valid.
-func validateLogType(cfg map[string]any, ve *validationError) {
+func validateLogType(cfg map[string]any, ve *config.ValidationError) {
 	if val, ok := cfg["format"]; ok {
 		logTypeStr, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "format")
+			ve.InvalidType = append(ve.InvalidType, "format")
 			return
 		}
 
 		switch logTypeStr {
 		case "json", "text", "":
 		default:
-			ve.invalidValues = append(ve.invalidValues, "format")
+			ve.InvalidValue = append(ve.InvalidValue, "format")
 		}
 	}
 }
@@ -0,0 +1,11 @@
+package membroker
+
+import "time"
+
+// expectedFields is a map of expected configuration fields and their default values.
+var expectedFields = map[string]any{
+	"queue_size": 0,
+	"timeout":    time.Duration(0),
+	"auto_ack":   false,
+	"requeue":    false,
+}
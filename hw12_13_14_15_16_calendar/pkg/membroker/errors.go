@@ -0,0 +1,10 @@
+package membroker
+
+import "errors"
+
+var (
+	// ErrUninitialized is returned when the broker is not connected or already closed.
+	ErrUninitialized = errors.New("in-memory broker is not initialized (initialize connection first?)")
+	// ErrQueueFull is returned when the queue has no free slots within the produce timeout.
+	ErrQueueFull = errors.New("in-memory broker queue is full")
+)
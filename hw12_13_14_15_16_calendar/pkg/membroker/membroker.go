@@ -0,0 +1,115 @@
+// Package membroker provides an in-process message broker with a single bounded queue.
+// It implements the same producer and consumer interfaces as the RabbitMQ client, so the whole notification
+// pipeline might run in a single process or in Go tests without the external broker.
+//
+// Messages are delivered in FIFO order. Each message is delivered to a single consumer.
+//
+// Delivery follows the RabbitMQ client flags. With auto_ack the message is acknowledged as soon as a consumer
+// takes it from the queue, so it is lost if the consumer stops before handing it over. Otherwise the message
+// is acknowledged once handed over to the consumer channel and rejected if the consumer stops before that:
+// with requeue it returns to the head of the queue, without it the message is dropped.
+// Messages taken, but not acknowledged yet, occupy the queue slots.
+package membroker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+)
+
+// Broker is an in-process message broker.
+type Broker struct {
+	mu sync.Mutex
+
+	queue    [][]byte
+	inFlight int           // Messages taken by the consumers, but not acknowledged yet.
+	changed  chan struct{} // Closed and replaced on each queue state change.
+	closed   bool
+
+	queueSize int
+	timeout   time.Duration // Max wait for a free slot on produce. 0 means waiting until the context is done.
+	autoAck   bool
+	requeue   bool
+
+	l Logger
+}
+
+// NewBroker creates a new in-process broker after config validation.
+// The broker is closed until Connect is called.
+func NewBroker(logger Logger, cfg map[string]any) (*Broker, error) {
+	// Args validation.
+	if cfg == nil {
+		return nil, fmt.Errorf("no configuration passed to in-memory broker constructor")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("invalid in-memory broker config: missing=[logger]")
+	}
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return &Broker{
+		l:         logger,
+		changed:   make(chan struct{}),
+		closed:    true,
+		queueSize: cfg["queue_size"].(int),
+		timeout:   cfg["timeout"].(time.Duration),
+		autoAck:   cfg["auto_ack"].(bool),
+		requeue:   cfg["requeue"].(bool),
+	}, nil
+}
+
+// ValidateConfig validates the broker config without creating a broker.
+// Problems are returned as *config.ValidationError.
+func ValidateConfig(cfg map[string]any) error {
+	ve := &config.ValidationError{}
+	ve.Missing, ve.InvalidType = validateFields(cfg, expectedFields)
+
+	if queueSize, ok := cfg["queue_size"].(int); ok && queueSize <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "queue_size")
+	}
+	if timeout, ok := cfg["timeout"].(time.Duration); ok && timeout < 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "timeout")
+	}
+
+	if ve.HasErrors() {
+		return fmt.Errorf("invalid in-memory broker config: %w", ve)
+	}
+	return nil
+}
+
+// Connect opens the broker. Messages left in the queue after Close are kept.
+func (b *Broker) Connect(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = false
+	return nil
+}
+
+// Close closes the broker. Waiting producers fail with ErrUninitialized, consumers stop
+// and close their channels. Queued messages are kept until the broker is connected again.
+func (b *Broker) Close(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notify()
+	return nil
+}
+
+// Len returns the number of messages waiting in the queue.
+func (b *Broker) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.queue)
+}
+
+// notify wakes up all producers and consumers waiting for the queue state change.
+//
+// Method does not use locks.
+func (b *Broker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
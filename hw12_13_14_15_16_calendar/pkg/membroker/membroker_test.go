@@ -0,0 +1,162 @@
+package membroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"                               //nolint:depguard,nolintlint
+)
+
+// discardLogger is a logger, which drops all messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+func newTestBroker(t *testing.T, queueSize int, timeout time.Duration, autoAck, requeue bool) *Broker {
+	t.Helper()
+	b, err := NewBroker(discardLogger{}, map[string]any{
+		"queue_size": queueSize,
+		"timeout":    timeout,
+		"auto_ack":   autoAck,
+		"requeue":    requeue,
+	})
+	require.NoError(t, err)
+	require.NoError(t, b.Connect(context.Background()))
+	t.Cleanup(func() { _ = b.Close(context.Background()) })
+	return b
+}
+
+func TestValidateConfig(t *testing.T) {
+	require.NoError(t, ValidateConfig(map[string]any{
+		"queue_size": 1, "timeout": time.Second, "auto_ack": false, "requeue": true,
+	}))
+
+	err := ValidateConfig(map[string]any{"queue_size": 0, "timeout": -time.Second, "auto_ack": "yes"})
+	var ve *config.ValidationError
+	require.ErrorAs(t, err, &ve)
+	require.Equal(t, []string{"requeue"}, ve.Missing)
+	require.Equal(t, []string{"auto_ack"}, ve.InvalidType)
+	require.ElementsMatch(t, []string{"queue_size", "timeout"}, ve.InvalidValue)
+
+	_, err = NewBroker(nil, map[string]any{})
+	require.Error(t, err)
+}
+
+func TestProduceConsume(t *testing.T) {
+	b := newTestBroker(t, 3, 0, false, true)
+	ctx := context.Background()
+
+	payload := []byte("first")
+	require.NoError(t, b.Produce(ctx, payload))
+	payload[0] = 'X' // The broker keeps its own copy.
+	require.NoError(t, b.Produce(ctx, []byte("second")))
+	require.NoError(t, b.Produce(ctx, []byte("third")))
+	require.Equal(t, 3, b.Len())
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	msgs, errCh := b.Consume(cctx)
+	for _, expected := range []string{"first", "second", "third"} {
+		select {
+		case msg := <-msgs:
+			require.Equal(t, expected, string(msg))
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+		}
+	}
+	require.Zero(t, b.Len())
+}
+
+func TestProduce_Errors(t *testing.T) {
+	t.Run("not connected", func(t *testing.T) {
+		b, err := NewBroker(discardLogger{}, map[string]any{
+			"queue_size": 1, "timeout": time.Duration(0), "auto_ack": false, "requeue": false,
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, b.Produce(context.Background(), []byte("msg")), ErrUninitialized)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		b := newTestBroker(t, 1, 10*time.Millisecond, false, false)
+		require.NoError(t, b.Produce(context.Background(), []byte("msg")))
+		require.ErrorIs(t, b.Produce(context.Background(), []byte("msg")), ErrQueueFull)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		b := newTestBroker(t, 1, 0, false, false)
+		require.NoError(t, b.Produce(context.Background(), []byte("msg")))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, b.Produce(ctx, []byte("msg")), context.DeadlineExceeded)
+	})
+}
+
+func TestProduce_WaitsForFreeSlot(t *testing.T) {
+	b := newTestBroker(t, 1, time.Second, false, false)
+	ctx := context.Background()
+	require.NoError(t, b.Produce(ctx, []byte("first")))
+
+	done := make(chan error, 1)
+	go func() { done <- b.Produce(ctx, []byte("second")) }()
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	msgs, _ := b.Consume(cctx)
+	require.Equal(t, "first", string(<-msgs))
+	require.NoError(t, <-done)
+	require.Equal(t, "second", string(<-msgs))
+}
+
+func TestConsume_Reject(t *testing.T) {
+	testCases := []struct {
+		name     string
+		autoAck  bool
+		requeue  bool
+		expected int
+	}{
+		{name: "requeue", autoAck: false, requeue: true, expected: 1},
+		{name: "drop", autoAck: false, requeue: false, expected: 0},
+		{name: "auto ack", autoAck: true, requeue: true, expected: 0},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			b := newTestBroker(t, 1, 0, tC.autoAck, tC.requeue)
+			require.NoError(t, b.Produce(context.Background(), []byte("msg")))
+
+			// Nobody reads the messages channel, so the consumer gets stuck holding the message.
+			ctx, cancel := context.WithCancel(context.Background())
+			_, errCh := b.Consume(ctx)
+			require.Eventually(t, func() bool { return b.Len() == 0 }, time.Second, time.Millisecond)
+			cancel()
+			// Error channel is closed after the consumer has stopped.
+			_, ok := <-errCh
+			require.False(t, ok)
+
+			require.Equal(t, tC.expected, b.Len())
+		})
+	}
+}
+
+func TestConsume_Close(t *testing.T) {
+	b := newTestBroker(t, 1, 0, false, false)
+
+	msgs, errCh := b.Consume(context.Background())
+	require.NoError(t, b.Close(context.Background()))
+
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, ErrUninitialized)
+	case <-time.After(time.Second):
+		t.Fatal("consumer did not stop")
+	}
+	_, ok := <-msgs
+	require.False(t, ok)
+}
@@ -0,0 +1,144 @@
+package membroker
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Produce puts a copy of the payload to the queue. If the queue is full, it waits for a free slot
+// up to the configured timeout and returns ErrQueueFull after it.
+func (b *Broker) Produce(ctx context.Context, payload []byte) error {
+	msg := bytes.Clone(payload)
+
+	var deadline <-chan time.Time
+	if b.timeout > 0 {
+		timer := time.NewTimer(b.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return ErrUninitialized
+		}
+		if len(b.queue)+b.inFlight < b.queueSize {
+			b.queue = append(b.queue, msg)
+			b.notify()
+			b.mu.Unlock()
+			b.l.Debug(ctx, "message published", slog.Int("size", len(msg)))
+			return nil
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return ErrQueueFull
+		case <-changed:
+		}
+	}
+}
+
+// Consume starts consuming messages from the queue.
+// Messages channel is closed on context cancellation or broker closing.
+// ErrUninitialized is sent to the error channel if the broker is closed while consuming.
+func (b *Broker) Consume(ctx context.Context) (<-chan []byte, <-chan error) {
+	resQueue := make(chan []byte)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(resQueue)
+
+		for {
+			msg, err := b.take(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errCh <- err
+				}
+				return
+			}
+
+			select {
+			case resQueue <- msg:
+				b.ack()
+				b.l.Debug(ctx, "message delivered", slog.Int("size", len(msg)))
+			case <-ctx.Done():
+				b.reject(ctx, msg)
+				return
+			}
+		}
+	}()
+
+	return resQueue, errCh
+}
+
+// take waits for a message and removes it from the queue.
+// The message is marked as in-flight unless auto_ack is set.
+func (b *Broker) take(ctx context.Context) ([]byte, error) {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return nil, ErrUninitialized
+		}
+		if len(b.queue) > 0 {
+			msg := b.queue[0]
+			b.queue[0] = nil
+			b.queue = b.queue[1:]
+			if !b.autoAck {
+				b.inFlight++
+			}
+			b.notify()
+			b.mu.Unlock()
+			return msg, nil
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// ack acknowledges the in-flight message, freeing its slot.
+func (b *Broker) ack() {
+	if b.autoAck {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inFlight--
+	b.notify()
+}
+
+// reject handles the message the consumer failed to hand over.
+func (b *Broker) reject(ctx context.Context, msg []byte) {
+	if b.autoAck {
+		b.l.Warn(ctx, "message lost: consumer stopped before delivery", slog.Int("size", len(msg)))
+		return
+	}
+
+	b.mu.Lock()
+	b.inFlight--
+	if b.requeue {
+		b.queue = append([][]byte{msg}, b.queue...)
+	}
+	b.notify()
+	b.mu.Unlock()
+
+	if b.requeue {
+		b.l.Debug(ctx, "message requeued", slog.Int("size", len(msg)))
+		return
+	}
+	b.l.Warn(ctx, "message dropped: consumer stopped before delivery", slog.Int("size", len(msg)))
+}
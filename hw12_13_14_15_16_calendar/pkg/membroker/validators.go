@@ -0,0 +1,26 @@
+package membroker
+
+import "reflect"
+
+// validateFields returns missing and wrong type fields found in args.
+// requiredFields is a map of field names with their expected types.
+func validateFields(args map[string]any, requiredFields map[string]any) ([]string, []string) {
+	var missing []string
+	var wrongType []string
+
+	for field, expectedVal := range requiredFields {
+		val, exists := args[field]
+		if !exists {
+			missing = append(missing, field)
+			continue
+		}
+
+		// Default type switch will end up with false positive results.
+		// E.g., 123.(string) -> ok.
+		if reflect.TypeOf(expectedVal) != reflect.TypeOf(val) {
+			wrongType = append(wrongType, field)
+		}
+	}
+
+	return missing, wrongType
+}
@@ -0,0 +1,63 @@
+package natsjs
+
+// File contains declarations for expected configuration fields based on the client type.
+
+import "time"
+
+// contentTypeHeader is the message header carrying the content type.
+const contentTypeHeader = "Content-Type"
+
+// expectedFields is a map of expected configuration fields and their default values.
+var expectedFieldsFull = map[string]any{
+	"host":     "",
+	"port":     "",
+	"user":     "",
+	"password": "",
+
+	"timeout":       time.Duration(0),
+	"retry_timeout": time.Duration(0),
+	"retries":       0,
+
+	"topic":   "", // Stream name.
+	"subject": "",
+	"durable": false,
+
+	"consumer":      "",               // Durable consumer name.
+	"resub_timeout": time.Duration(0), // Resubscription timeout for the consumer.
+	"auto_ack":      false,
+	"requeue":       false,
+}
+
+var expectedFieldsConsumer = map[string]any{
+	"host":     "",
+	"port":     "",
+	"user":     "",
+	"password": "",
+
+	"timeout":       time.Duration(0),
+	"retry_timeout": time.Duration(0),
+	"retries":       0,
+
+	"topic":   "",
+	"subject": "",
+
+	"consumer":      "",
+	"resub_timeout": time.Duration(0),
+	"auto_ack":      false,
+	"requeue":       false,
+}
+
+var expectedFieldsProducer = map[string]any{
+	"host":     "",
+	"port":     "",
+	"user":     "",
+	"password": "",
+
+	"timeout":       time.Duration(0),
+	"retry_timeout": time.Duration(0),
+	"retries":       0,
+
+	"topic":   "",
+	"subject": "",
+	"durable": false,
+}
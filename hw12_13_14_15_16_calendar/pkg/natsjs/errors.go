@@ -0,0 +1,17 @@
+package natsjs
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUninitialized is returned when the message queue connection is not initialized.
+	ErrUninitialized = errors.New("nats is not initialized (initialize connection first?)")
+	// ErrFatal is returned when an unexpected internal error occurs.
+	ErrFatal = errors.New("fatal error")
+	// ErrRetriesExceeded is returned when the operation fails on every retry.
+	ErrRetriesExceeded = errors.New("retries exceeded")
+)
+
+// errTimeoutExceeded is returned when the operation execution times out. Internal package error.
+var errTimeoutExceeded = errors.New("timeout exceeded")
@@ -0,0 +1,106 @@
+package natsjs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// withTimeout wraps the given function in a context.WithTimeout call.
+func (j *JetStream) withTimeout(ctx context.Context, fn func(context.Context) error) error {
+	j.mu.RLock()
+	if j.js == nil {
+		j.mu.RUnlock()
+		return ErrUninitialized
+	}
+	timeout := j.timeout
+	j.mu.RUnlock()
+
+	localCtx := ctx
+	var cancel context.CancelFunc
+
+	if timeout > 0 {
+		localCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := fn(localCtx)
+	if err != nil {
+		if errors.Is(localCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w: %w", errTimeoutExceeded, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// withRetries is a middleware that implements retry logic for NATS calls.
+//
+// It tries to execute the given function with configured retries and timeout.
+// Each attempt is logged with DEBUG level.
+//
+// If the connection is unavailable, it will try to reconnect. Failed reconnection is logged with ERROR
+// and returned wrapped with ErrFatal.
+//
+// Receiving any other error stops execution and returns the error.
+//
+// If retry limit is exceeded, ErrRetriesExceeded is returned, wrapped over the last error.
+func (j *JetStream) withRetries(ctx context.Context, method string, fn func() error) error {
+	j.mu.RLock()
+	attempts := j.retries + 1 // Guarantees at least one attempt.
+	timeout := j.retryTimeout
+	j.mu.RUnlock()
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+
+		if !isRetryable(lastErr) {
+			j.l.Error(
+				ctx,
+				"unexpected error occurred",
+				slog.String("method", method),
+				slog.Any("error", lastErr),
+			)
+			return lastErr
+		}
+
+		j.l.Debug(
+			ctx,
+			"operation failed, attempting to reconnect",
+			slog.String("method", method),
+			slog.Int("attempt", i+1),
+			slog.Any("error", lastErr),
+		)
+
+		// Closing and reestablishing connection.
+		_ = j.Close(ctx)
+		if err := j.Connect(ctx); err != nil {
+			j.l.Error(
+				ctx,
+				"reestablishing connection",
+				slog.String("method", method),
+				slog.Int("attempt", i+1),
+				slog.Any("error", err),
+			)
+			return fmt.Errorf("%w: %w", ErrFatal, err)
+		}
+
+		// No wait on last attempt.
+		if i < attempts-1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(timeout):
+				continue
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %w", ErrRetriesExceeded, lastErr)
+}
@@ -0,0 +1,236 @@
+// Package natsjs provides a NATS JetStream client, which is suitable for sending and receiving messages.
+// It is expected to work with a single stream subject and a single durable consumer.
+package natsjs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+	"github.com/nats-io/nats.go"                                        //nolint:depguard,nolintlint
+	"github.com/nats-io/nats.go/jetstream"                              //nolint:depguard,nolintlint
+)
+
+// ClientType represents a NATS JetStream client type.
+type ClientType int
+
+const (
+	// FullClient matches a full client configuration.
+	FullClient ClientType = iota
+	// ProducerOnly matches a client configuration with producer-only fields.
+	ProducerOnly
+	// ConsumerOnly matches a client configuration with consumer-only fields.
+	ConsumerOnly
+)
+
+// JetStream represents a NATS JetStream client.
+type JetStream struct {
+	mu sync.RWMutex
+
+	conn       *nats.Conn
+	js         jetstream.JetStream
+	clientType ClientType
+
+	url          string
+	user         string
+	password     string
+	timeout      time.Duration
+	retryTimeout time.Duration
+	retries      int
+
+	topic   string // Stream name.
+	subject string
+	durable bool // File storage for the stream, memory storage otherwise.
+
+	consumer     string        // Durable consumer name.
+	resubTimeout time.Duration // Resubscription timeout for the consumer.
+	autoAck      bool
+	requeue      bool
+
+	l Logger
+}
+
+// NewJetStream creates a new NATS JetStream client.
+// Supports partial configuration for different client types: consumer, producer or full client.
+// Unknown client type defaults to FullClient.
+func NewJetStream(logger Logger, cfg map[string]any, typ ClientType) (*JetStream, error) {
+	// Args validation.
+	if cfg == nil {
+		return nil, fmt.Errorf("no configuration passed to NATS constructor")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("invalid NATS config: missing=[logger]")
+	}
+	if typ != ProducerOnly && typ != ConsumerOnly {
+		typ = FullClient
+	}
+	if err := ValidateConfig(cfg, typ); err != nil {
+		return nil, err
+	}
+
+	// Extract from config an normalize the value.
+	full := mapToFullClient(cfg)
+
+	// Init the full version regardless of the client type.
+	return &JetStream{
+		l:            logger,
+		clientType:   typ,
+		url:          fmt.Sprintf("nats://%s:%s", full["host"].(string), full["port"].(string)),
+		user:         full["user"].(string),
+		password:     full["password"].(string),
+		timeout:      full["timeout"].(time.Duration),
+		retryTimeout: full["retry_timeout"].(time.Duration),
+		retries:      max(full["retries"].(int), 0),
+		topic:        full["topic"].(string),
+		subject:      full["subject"].(string),
+		durable:      full["durable"].(bool),
+		consumer:     full["consumer"].(string),
+		autoAck:      full["auto_ack"].(bool),
+		requeue:      full["requeue"].(bool),
+		resubTimeout: full["resub_timeout"].(time.Duration),
+	}, nil
+}
+
+// ValidateConfig validates the config of the given client type without creating a client.
+// Unknown client type is validated as FullClient. Problems are returned as *config.ValidationError.
+func ValidateConfig(cfg map[string]any, typ ClientType) error {
+	var reqFields map[string]any
+	switch typ {
+	case ProducerOnly:
+		reqFields = expectedFieldsProducer
+	case ConsumerOnly:
+		reqFields = expectedFieldsConsumer
+	default:
+		typ = FullClient
+		reqFields = expectedFieldsFull
+	}
+
+	ve := &config.ValidationError{}
+	ve.Missing, ve.InvalidType = validateFields(cfg, reqFields)
+
+	if retryTimeout, ok := cfg["retry_timeout"].(time.Duration); ok && retryTimeout <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "retry_timeout")
+	}
+	if topic, ok := cfg["topic"].(string); ok && topic == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "topic")
+	}
+	if subject, ok := cfg["subject"].(string); ok && subject == "" {
+		ve.InvalidValue = append(ve.InvalidValue, "subject")
+	}
+	if typ != ProducerOnly {
+		if resubTimeout, ok := cfg["resub_timeout"].(time.Duration); ok && resubTimeout <= 0 {
+			ve.InvalidValue = append(ve.InvalidValue, "resub_timeout")
+		}
+		if consumer, ok := cfg["consumer"].(string); ok && consumer == "" {
+			ve.InvalidValue = append(ve.InvalidValue, "consumer")
+		}
+	}
+
+	if ve.HasErrors() {
+		return fmt.Errorf("invalid NATS config: %w", ve)
+	}
+	return nil
+}
+
+// Reload applies the settings which might be changed at runtime: retries, retry_timeout and, for the clients
+// with consumer part, resub_timeout. Connection and stream settings require reconnection and are not reloaded.
+// Returns an error if the config is invalid, keeping the current settings.
+func (j *JetStream) Reload(cfg map[string]any) error {
+	reqFields := map[string]any{"retries": 0, "retry_timeout": time.Duration(0)}
+	if j.clientType != ProducerOnly {
+		reqFields["resub_timeout"] = time.Duration(0)
+	}
+	missing, wrongType := validateFields(cfg, reqFields)
+	if len(missing) > 0 || len(wrongType) > 0 {
+		return fmt.Errorf("invalid NATS config: missing=%v invalid_type=%v", missing, wrongType)
+	}
+
+	retryTimeout, _ := cfg["retry_timeout"].(time.Duration)
+	if retryTimeout <= 0 {
+		return fmt.Errorf("invalid config data: retry timeout must be positive, got %v", retryTimeout)
+	}
+	resubTimeout, _ := cfg["resub_timeout"].(time.Duration)
+	if resubTimeout <= 0 && j.clientType != ProducerOnly {
+		return fmt.Errorf("invalid config data: resubscription timeout must be positive, got %v", resubTimeout)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.retries = max(cfg["retries"].(int), 0)
+	j.retryTimeout = retryTimeout
+	if j.clientType != ProducerOnly {
+		j.resubTimeout = resubTimeout
+	}
+	return nil
+}
+
+// Connect to the NATS server and ensure the stream exists.
+// Producer and full clients create or update the stream, consumer-only client requires it to exist.
+func (j *JetStream) Connect(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	opts := []nats.Option{nats.Name("calendar")}
+	if j.user != "" {
+		opts = append(opts, nats.UserInfo(j.user, j.password))
+	}
+	if j.timeout > 0 {
+		opts = append(opts, nats.Timeout(j.timeout))
+	}
+
+	conn, err := nats.Connect(j.url, opts...)
+	if err != nil {
+		return fmt.Errorf("message queue connection: %w", err)
+	}
+	j.conn = conn
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return fmt.Errorf("message queue jetstream context creation: %w", err)
+	}
+	j.js = js
+
+	localCtx := ctx
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		localCtx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+
+	// Consumer-only logic shortcut.
+	if j.clientType == ConsumerOnly {
+		ok, err := j.isStreamExists(localCtx)
+		if err != nil {
+			return fmt.Errorf("unexpected error on consumer message queue check: %w", err)
+		}
+		if ok {
+			return nil
+		}
+		return fmt.Errorf("consumer message queue does not exist")
+	}
+
+	// Producer-only logic follows the full client scenario.
+	err = j.initStream(localCtx)
+	if err != nil {
+		return fmt.Errorf("producer message queue init: %w", err)
+	}
+
+	return nil
+}
+
+// Close the connection to the NATS server.
+func (j *JetStream) Close(_ context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.js = nil
+	if j.conn == nil {
+		return nil
+	}
+	j.conn.Close()
+	j.conn = nil
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package natsjs
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"                   //nolint:depguard,nolintlint
+	"github.com/nats-io/nats-server/v2/server" //nolint:depguard,nolintlint
+	"github.com/stretchr/testify/require"      //nolint:depguard,nolintlint
+)
+
+// Tests run against a NATS server with JetStream enabled, started in-process by each test.
+
+// discardLogger is a logger, which drops all messages.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Debug(context.Context, string, ...any) {}
+func (discardLogger) Warn(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+// runServer starts the in-process NATS server with JetStream on a random port, which is shut down
+// on the test cleanup. Returns the host and the port of the server.
+func runServer(t *testing.T) (string, string) {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	srv.Start()
+	t.Cleanup(func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	})
+	require.True(t, srv.ReadyForConnections(5*time.Second), "NATS server is not ready")
+
+	addr, ok := srv.Addr().(*net.TCPAddr)
+	require.True(t, ok, "unexpected NATS server address")
+	return addr.IP.String(), strconv.Itoa(addr.Port)
+}
+
+// newTestClient starts the server, creates and connects the client with a unique stream.
+func newTestClient(t *testing.T, autoAck bool) *JetStream {
+	t.Helper()
+	host, port := runServer(t)
+	topic := "calendar_test_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	j, err := NewJetStream(discardLogger{}, map[string]any{
+		"host":          host,
+		"port":          port,
+		"user":          "",
+		"password":      "",
+		"timeout":       time.Second,
+		"retry_timeout": 100 * time.Millisecond,
+		"retries":       3,
+		"topic":         topic,
+		"subject":       topic + ".notifications",
+		"durable":       false,
+		"consumer":      "sender",
+		"resub_timeout": 100 * time.Millisecond,
+		"auto_ack":      autoAck,
+		"requeue":       true,
+	}, FullClient)
+	require.NoError(t, err)
+	require.NoError(t, j.Connect(context.Background()))
+	t.Cleanup(func() {
+		_ = j.Close(context.Background())
+	})
+	return j
+}
+
+func receive(t *testing.T, msgs <-chan []byte, errCh <-chan error) string {
+	t.Helper()
+	select {
+	case msg := <-msgs:
+		return string(msg)
+	case err := <-errCh:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+	return ""
+}
+
+func TestProduceConsume(t *testing.T) {
+	for _, autoAck := range []bool{false, true} {
+		t.Run(fmt.Sprintf("auto_ack=%t", autoAck), func(t *testing.T) {
+			j := newTestClient(t, autoAck)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			expected := []string{"first", "second", "third"}
+			for _, msg := range expected {
+				require.NoError(t, j.Produce(ctx, []byte(msg)))
+			}
+
+			msgs, errCh := j.Consume(ctx)
+			for _, msg := range expected {
+				require.Equal(t, msg, receive(t, msgs, errCh))
+			}
+		})
+	}
+}
+
+func TestConsume_Resubscribe(t *testing.T) {
+	j := newTestClient(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs, errCh := j.Consume(ctx)
+	require.NoError(t, j.Produce(ctx, []byte("before")))
+	require.Equal(t, "before", receive(t, msgs, errCh))
+
+	// Deleting the consumer ends the subscription, so the client has to resubscribe after resub_timeout.
+	j.mu.RLock()
+	js := j.js
+	j.mu.RUnlock()
+	require.NoError(t, js.DeleteConsumer(ctx, j.topic, j.consumer))
+
+	// The stream keeps the messages, so the recreated consumer starts from the first one.
+	require.NoError(t, j.Produce(ctx, []byte("after")))
+	require.Equal(t, "before", receive(t, msgs, errCh))
+	require.Equal(t, "after", receive(t, msgs, errCh))
+}
+
+func TestProduce_Reconnect(t *testing.T) {
+	j := newTestClient(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Closing the connection underneath the client, as if it was lost.
+	j.mu.RLock()
+	j.conn.Close()
+	j.mu.RUnlock()
+
+	require.NoError(t, j.Produce(ctx, []byte("msg")))
+
+	msgs, errCh := j.Consume(ctx)
+	require.Equal(t, "msg", receive(t, msgs, errCh))
+}
@@ -0,0 +1,184 @@
+package natsjs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"               //nolint:depguard,nolintlint
+	"github.com/nats-io/nats.go"           //nolint:depguard,nolintlint
+	"github.com/nats-io/nats.go/jetstream" //nolint:depguard,nolintlint
+)
+
+// Produce sends a message to the stream subject using retry logic and operation timeout.
+// Each message gets a unique ID, so the stream deduplicates the retried publications.
+func (j *JetStream) Produce(ctx context.Context, payload []byte) error {
+	return j.ProduceWithContentType(ctx, payload, "")
+}
+
+// ProduceWithContentType sends a message the same way as Produce, passing the content type
+// in the Content-Type message header. Empty content type is not passed.
+func (j *JetStream) ProduceWithContentType(ctx context.Context, payload []byte, contentType string) error {
+	msgID := uuid.New().String()
+	err := j.withRetries(ctx, "produce", func() error {
+		return j.withTimeout(ctx, func(localCtx context.Context) error {
+			j.mu.RLock()
+			js, subject := j.js, j.subject
+			j.mu.RUnlock()
+			if js == nil {
+				return ErrUninitialized
+			}
+			msg := nats.NewMsg(subject)
+			msg.Data = payload
+			if contentType != "" {
+				msg.Header.Set(contentTypeHeader, contentType)
+			}
+			_, err := js.PublishMsg(localCtx, msg, jetstream.WithMsgID(msgID))
+			return err
+		})
+	})
+	if err != nil {
+		return err
+	}
+	j.l.Debug(ctx, "message successfully sent", slog.String("message_id", msgID))
+	return nil
+}
+
+// Consume consumes messages from the stream using retry logic and operation timeout.
+// The methods abstracts the logic of consuming messages from the stream.
+func (j *JetStream) Consume(ctx context.Context) (<-chan []byte, <-chan error) {
+	resQueue := make(chan []byte)
+	errors := make(chan error)
+
+	j.mu.RLock()
+	requeue := j.requeue
+	autoAck := j.autoAck
+	j.mu.RUnlock()
+
+	// Looping until the context is cancelled.
+	// The loop updates the subscription and populates the consumer with data until the subscription ends.
+	go func() {
+		defer close(errors)
+		defer close(resQueue)
+
+		for {
+			// Updating the subscription.
+			iter, err := j.startConsumer(ctx, autoAck)
+			if err != nil {
+				select {
+				case errors <- err:
+				case <-ctx.Done():
+				}
+				return
+			}
+			// Populating the consumer until the subscription ends or context cancellation.
+			j.populateConsumer(ctx, iter, resQueue, autoAck, requeue)
+
+			// The timeout is read on each resubscription, as it might be reloaded.
+			j.mu.RLock()
+			resubTimeout := j.resubTimeout
+			j.mu.RUnlock()
+
+			select {
+			case <-ctx.Done():
+				j.l.Warn(ctx, "context done before during consuming process")
+				return
+			case <-time.After(resubTimeout):
+				continue
+			}
+		}
+	}()
+
+	return resQueue, errors
+}
+
+// populateConsumer reads messages from the iterator and sends raw data to the result channel.
+// Without auto ack the message is acknowledged on receipt. If the acknowledgement fails, the message is not
+// delivered: it is returned to the stream if requeue is set, or terminated otherwise.
+func (j *JetStream) populateConsumer(
+	ctx context.Context,
+	iter jetstream.MessagesContext,
+	resQueue chan<- []byte,
+	autoAck,
+	requeue bool,
+) {
+	// Next blocks until the message arrives, so the iterator is stopped on context cancellation.
+	stop := context.AfterFunc(ctx, iter.Stop)
+	defer stop()
+	defer iter.Stop()
+
+	for {
+		msg, err := iter.Next()
+		if err != nil {
+			if !errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				// Consumer needs to be resubscribed.
+				j.l.Warn(ctx, "consumer subscription interrupted", slog.Any("error", err))
+			}
+			return
+		}
+		msgID := msg.Headers().Get(nats.MsgIdHdr)
+
+		if !autoAck {
+			if err := msg.Ack(); err != nil {
+				j.l.Warn(ctx, "message ack failed", slog.String("message_id", msgID), slog.Any("error", err))
+				if requeue {
+					err = msg.Nak()
+				} else {
+					err = msg.Term()
+				}
+				if err != nil {
+					j.l.Error(ctx, "message reject failed", slog.String("message_id", msgID), slog.Any("error", err))
+				}
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			j.l.Warn(ctx, "context done before the message extracted")
+			return
+		case resQueue <- msg.Data():
+			j.l.Debug(ctx, "message successfully received", slog.String("message_id", msgID))
+		}
+	}
+}
+
+// startConsumer creates or updates the durable consumer and subscribes to it using retry logic
+// and operation timeout.
+func (j *JetStream) startConsumer(ctx context.Context, autoAck bool) (jetstream.MessagesContext, error) {
+	var iter jetstream.MessagesContext
+
+	ackPolicy := jetstream.AckExplicitPolicy
+	if autoAck {
+		ackPolicy = jetstream.AckNonePolicy
+	}
+
+	err := j.withRetries(ctx, "consume", func() error {
+		return j.withTimeout(ctx, func(localCtx context.Context) error {
+			j.mu.RLock()
+			js, topic, subject, consumer := j.js, j.topic, j.subject, j.consumer
+			j.mu.RUnlock()
+			if js == nil {
+				return ErrUninitialized
+			}
+
+			cons, err := js.CreateOrUpdateConsumer(localCtx, topic, jetstream.ConsumerConfig{
+				Durable:       consumer,
+				FilterSubject: subject,
+				AckPolicy:     ackPolicy,
+			})
+			if err != nil {
+				return err
+			}
+			iter, err = cons.Messages()
+			return err
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("subscribe consumer: %w", err)
+	}
+
+	return iter, nil
+}
@@ -0,0 +1,58 @@
+package natsjs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream" //nolint:depguard,nolintlint
+)
+
+// mapToFullClient creates a new map with default values for FullClient configuration.
+func mapToFullClient(cfg map[string]any) map[string]any {
+	newCfg := make(map[string]any, len(expectedFieldsFull))
+	for k := range expectedFieldsFull {
+		// Use values from config if they are present.
+		if v, ok := cfg[k]; ok {
+			newCfg[k] = v
+			continue
+		}
+		// Null value otherwise.
+		newCfg[k] = expectedFieldsFull[k]
+	}
+	return newCfg
+}
+
+// isStreamExists checks if the stream exists.
+//
+// Method does not use locks.
+func (j *JetStream) isStreamExists(ctx context.Context) (bool, error) {
+	_, err := j.js.Stream(ctx, j.topic)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		return false, nil
+	}
+	return false, fmt.Errorf("stream check: %w", err)
+}
+
+// initStream creates the stream or updates its configuration.
+//
+// Method does not use locks.
+func (j *JetStream) initStream(ctx context.Context) error {
+	storage := jetstream.MemoryStorage
+	if j.durable {
+		storage = jetstream.FileStorage
+	}
+
+	_, err := j.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     j.topic,
+		Subjects: []string{j.subject},
+		Storage:  storage,
+	})
+	if err != nil {
+		return fmt.Errorf("stream declaration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package natsjs
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/nats-io/nats.go" //nolint:depguard,nolintlint
+)
+
+// validateFields returns missing and wrong type fields found in args.
+// requiredFields is a map of field names with their expected types.
+func validateFields(args map[string]any, requiredFields map[string]any) ([]string, []string) {
+	var missing []string
+	var wrongType []string
+
+	for field, expectedVal := range requiredFields {
+		val, exists := args[field]
+		if !exists {
+			missing = append(missing, field)
+			continue
+		}
+
+		expectedReflect := reflect.TypeOf(expectedVal)
+		valueReflect := reflect.TypeOf(val)
+
+		// Default type switch will end up with false positive results.
+		// E.g., 123.(string) -> ok.
+		if expectedReflect != valueReflect {
+			wrongType = append(wrongType, field)
+		}
+	}
+
+	return missing, wrongType
+}
+
+// isRetryable returns true if the error can be retried.
+// Any connection/timout error is considered retryable.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.Is(err, nats.ErrConnectionClosed) ||
+		errors.Is(err, nats.ErrTimeout) ||
+		errors.Is(err, nats.ErrNoResponders) ||
+		errors.Is(err, nats.ErrDisconnected) ||
+		errors.Is(err, ErrUninitialized) ||
+		errors.Is(err, errTimeoutExceeded)
+}
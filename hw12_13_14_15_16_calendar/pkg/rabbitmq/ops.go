@@ -12,6 +12,12 @@ import (
 
 // Produce sends a message to the message queue using retry logic and operation timeout.
 func (r *RabbitMQ) Produce(ctx context.Context, payload []byte) error {
+	return r.ProduceWithContentType(ctx, payload, r.contentType)
+}
+
+// ProduceWithContentType sends a message to the message queue the same way as Produce,
+// overriding the configured content type of the message.
+func (r *RabbitMQ) ProduceWithContentType(ctx context.Context, payload []byte, contentType string) error {
 	err := r.withRetries(ctx, "produce", func() error {
 		return r.withTimeout(ctx, func(localCtx context.Context) error {
 			r.mu.Lock()
@@ -22,7 +28,7 @@ func (r *RabbitMQ) Produce(ctx context.Context, payload []byte) error {
 				false, // mandatory
 				false, // immediate
 				amqp.Publishing{
-					ContentType: r.contentType,
+					ContentType: contentType,
 					Body:        payload,
 					MessageId:   uuid.New().String(),
 				},
@@ -47,7 +53,6 @@ func (r *RabbitMQ) Consume(ctx context.Context) (<-chan []byte, <-chan error) {
 	r.mu.RLock()
 	requeue := r.requeue
 	autoAck := r.autoAck
-	resubTimeout := r.resubTimeout
 	r.mu.RUnlock()
 
 	// Looping until the context is cancelled.
@@ -69,6 +74,11 @@ func (r *RabbitMQ) Consume(ctx context.Context) (<-chan []byte, <-chan error) {
 			// Populating the consumer until the subscription ends or context cancellation.
 			r.populateConsumer(ctx, ch, resQueue, autoAck, requeue)
 
+			// The timeout is read on each resubscription, as it might be reloaded.
+			r.mu.RLock()
+			resubTimeout := r.resubTimeout
+			r.mu.RUnlock()
+
 			select {
 			case <-ctx.Done():
 				r.l.Warn(ctx, "context done before during consuming process")
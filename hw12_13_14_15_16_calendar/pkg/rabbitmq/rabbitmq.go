@@ -8,7 +8,8 @@ import (
 	"sync"
 	"time"
 
-	amqp "github.com/rabbitmq/amqp091-go" //nolint:depguard,nolintlint
+	"github.com/Averlex/golang-hw/hw12_13_14_15_16_calendar/pkg/config" //nolint:depguard,nolintlint
+	amqp "github.com/rabbitmq/amqp091-go"                               //nolint:depguard,nolintlint
 )
 
 // ClientType represents a RabbitMQ client type.
@@ -57,16 +58,48 @@ func NewRabbitMQ(logger Logger, cfg map[string]any, typ ClientType) (*RabbitMQ,
 	if cfg == nil {
 		return nil, fmt.Errorf("no configuration passed to RabbitMQ constructor")
 	}
-	missing, wrongType := make([]string, 0), make([]string, 0)
 	if logger == nil {
-		missing = append(missing, "logger")
+		return nil, fmt.Errorf("invalid RabbitMQ config: missing=[logger]")
 	}
+	if typ != ProducerOnly && typ != ConsumerOnly {
+		typ = FullClient
+	}
+	if err := ValidateConfig(cfg, typ); err != nil {
+		return nil, err
+	}
+
+	// Extract from config an normalize the value.
+	full := mapToFullClient(cfg)
+
+	// Init the full version regardless of the client type.
+	return &RabbitMQ{
+		l:          logger,
+		clientType: typ,
+		url: fmt.Sprintf(
+			"amqp://%s:%s@%s:%s/",
+			full["user"].(string),
+			full["password"].(string),
+			full["host"].(string),
+			full["port"].(string),
+		),
+		timeout:      full["timeout"].(time.Duration),
+		retryTimeout: full["retry_timeout"].(time.Duration),
+		retries:      max(full["retries"].(int), 0),
+		topic:        full["topic"].(string),
+		durable:      full["durable"].(bool),
+		contentType:  full["content_type"].(string),
+		routingKey:   full["routing_key"].(string),
+		autoAck:      full["auto_ack"].(bool),
+		requeue:      full["requeue"].(bool),
+		resubTimeout: full["resub_timeout"].(time.Duration),
+	}, nil
+}
 
-	// Field types validation.
+// ValidateConfig validates the config of the given client type without creating a client.
+// Unknown client type is validated as FullClient. Problems are returned as *config.ValidationError.
+func ValidateConfig(cfg map[string]any, typ ClientType) error {
 	var reqFields map[string]any
 	switch typ {
-	case FullClient:
-		reqFields = expectedFieldsFull
 	case ProducerOnly:
 		reqFields = expectedFieldsProducer
 	case ConsumerOnly:
@@ -76,47 +109,52 @@ func NewRabbitMQ(logger Logger, cfg map[string]any, typ ClientType) (*RabbitMQ,
 		reqFields = expectedFieldsFull
 	}
 
-	m, w := validateFields(cfg, reqFields)
-	missing, wrongType = append(missing, m...), append(wrongType, w...)
-	if len(missing) > 0 || len(wrongType) > 0 {
-		return nil, fmt.Errorf("invalid RabbitMQ config: missing=%v invalid_type=%v",
-			missing, wrongType)
+	ve := &config.ValidationError{}
+	ve.Missing, ve.InvalidType = validateFields(cfg, reqFields)
+
+	if retryTimeout, ok := cfg["retry_timeout"].(time.Duration); ok && retryTimeout <= 0 {
+		ve.InvalidValue = append(ve.InvalidValue, "retry_timeout")
+	}
+	if resubTimeout, ok := cfg["resub_timeout"].(time.Duration); ok && resubTimeout <= 0 && typ != ProducerOnly {
+		ve.InvalidValue = append(ve.InvalidValue, "resub_timeout")
 	}
 
-	// Extract from config an normalize the value.
-	config := mapToFullClient(cfg)
+	if ve.HasErrors() {
+		return fmt.Errorf("invalid RabbitMQ config: %w", ve)
+	}
+	return nil
+}
+
+// Reload applies the settings which might be changed at runtime: retries, retry_timeout and, for the clients
+// with consumer part, resub_timeout. Connection and queue settings require reconnection and are not reloaded.
+// Returns an error if the config is invalid, keeping the current settings.
+func (r *RabbitMQ) Reload(cfg map[string]any) error {
+	reqFields := map[string]any{"retries": 0, "retry_timeout": time.Duration(0)}
+	if r.clientType != ProducerOnly {
+		reqFields["resub_timeout"] = time.Duration(0)
+	}
+	missing, wrongType := validateFields(cfg, reqFields)
+	if len(missing) > 0 || len(wrongType) > 0 {
+		return fmt.Errorf("invalid RabbitMQ config: missing=%v invalid_type=%v", missing, wrongType)
+	}
 
-	retryTimeout, _ := config["retry_timeout"].(time.Duration)
+	retryTimeout, _ := cfg["retry_timeout"].(time.Duration)
 	if retryTimeout <= 0 {
-		return nil, fmt.Errorf("invalid config data: retry timeout must be positive, got %v", retryTimeout)
+		return fmt.Errorf("invalid config data: retry timeout must be positive, got %v", retryTimeout)
 	}
-	resubTimeout, _ := config["resub_timeout"].(time.Duration)
-	if resubTimeout <= 0 && typ != ProducerOnly {
-		return nil, fmt.Errorf("invalid config data: resubscription timeout must be positive, got %v", resubTimeout)
+	resubTimeout, _ := cfg["resub_timeout"].(time.Duration)
+	if resubTimeout <= 0 && r.clientType != ProducerOnly {
+		return fmt.Errorf("invalid config data: resubscription timeout must be positive, got %v", resubTimeout)
 	}
 
-	// Init the full version regardless of the client type.
-	return &RabbitMQ{
-		l:          logger,
-		clientType: typ,
-		url: fmt.Sprintf(
-			"amqp://%s:%s@%s:%s/",
-			config["user"].(string),
-			config["password"].(string),
-			config["host"].(string),
-			config["port"].(string),
-		),
-		timeout:      config["timeout"].(time.Duration),
-		retryTimeout: retryTimeout,
-		retries:      max(config["retries"].(int), 0),
-		topic:        config["topic"].(string),
-		durable:      config["durable"].(bool),
-		contentType:  config["content_type"].(string),
-		routingKey:   config["routing_key"].(string),
-		autoAck:      config["auto_ack"].(bool),
-		requeue:      config["requeue"].(bool),
-		resubTimeout: config["resub_timeout"].(time.Duration),
-	}, nil
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries = max(cfg["retries"].(int), 0)
+	r.retryTimeout = retryTimeout
+	if r.clientType != ProducerOnly {
+		r.resubTimeout = resubTimeout
+	}
+	return nil
 }
 
 // Connect to the message queue.